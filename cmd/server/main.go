@@ -3,6 +3,8 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log" // Standard log for critical startup/shutdown messages before/after zap is active
 	"os"
 	"os/signal"
@@ -12,12 +14,45 @@ import (
 	// Zap is not directly used here anymore, logger comes from server or cleanup
 )
 
+// Usage: server [--check-config] [serve|worker]
+//
+// "serve" (the default) runs the HTTP API. "worker" runs only the background jobs, so that API and
+// background processing can be scaled independently; set RUN_JOBS_IN_API=false on the API when a worker runs.
+// --check-config loads and validates the configuration, prints every problem found, and exits (1 if it is invalid).
 func main() {
+	checkConfig := flag.Bool("check-config", false, "validate the configuration and exit")
+	flag.Parse()
+
 	cfg, err := config.Load()
+	if *checkConfig {
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println("Configuration is valid.")
+		return
+	}
 	if err != nil {
 		log.Fatalf("FATAL: Failed to load configuration: %v", err)
 	}
 
+	command := "serve"
+	if flag.NArg() > 0 {
+		command = flag.Arg(0)
+	}
+	switch command {
+	case "serve":
+		runServer(cfg)
+	case "worker":
+		runWorker(cfg)
+	default:
+		log.Fatalf("FATAL: Unknown command %q. Usage: %s [--check-config] [serve|worker]", command, os.Args[0])
+	}
+
+	log.Println("INFO: Application exiting.")
+}
+
+func runServer(cfg *config.Config) {
 	// initializeServer is generated by Wire and is in wire_gen.go.
 	// It now sets up everything: DB, logger, services, handlers, jobs, and the server itself.
 	server, cleanup, err := initializeServer(cfg)
@@ -35,11 +70,7 @@ func main() {
 		}
 	}()
 
-	// Wait for an interrupt signal to gracefully shut down the server.
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-
-	sig := <-quit
+	sig := waitForShutdownSignal()
 	log.Printf("INFO: Received signal '%s'. Shutting down server...", sig)
 
 	// Create a context with a timeout for the shutdown.
@@ -52,6 +83,35 @@ func main() {
 	} else {
 		log.Println("INFO: Server shutdown complete.")
 	}
+}
 
-	log.Println("INFO: Application exiting.")
+func runWorker(cfg *config.Config) {
+	// initializeWorker is generated by Wire and is in wire_gen.go. It builds only what the jobs need.
+	worker, cleanup, err := initializeWorker(cfg)
+	if err != nil {
+		log.Fatalf("FATAL: Failed to initialize worker: %v", err)
+	}
+	defer cleanup()
+
+	worker.Start()
+
+	sig := waitForShutdownSignal()
+	log.Printf("INFO: Received signal '%s'. Shutting down worker...", sig)
+
+	// Running jobs get the same grace period as in-flight HTTP requests.
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.ServerTimeout)
+	defer cancelShutdown()
+
+	if err := worker.Shutdown(shutdownCtx); err != nil {
+		log.Printf("ERROR: Worker forced to shutdown due to error: %v", err)
+	} else {
+		log.Println("INFO: Worker shutdown complete.")
+	}
+}
+
+// waitForShutdownSignal blocks until SIGINT or SIGTERM is received.
+func waitForShutdownSignal() os.Signal {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	return <-quit
 }
@@ -6,19 +6,41 @@ package main
 
 import (
 	"log"
+	"seattle_info_backend/internal/abuse"
+	"seattle_info_backend/internal/anonsession"
+	"seattle_info_backend/internal/antispam"
+	"seattle_info_backend/internal/apikey"
 	"seattle_info_backend/internal/app"
+	"seattle_info_backend/internal/appconfig"
+	"seattle_info_backend/internal/audit"
 	"seattle_info_backend/internal/auth"
+	"seattle_info_backend/internal/captcha"
 	"seattle_info_backend/internal/category"
 	"seattle_info_backend/internal/config"
 	"seattle_info_backend/internal/firebase"     // Added
 	"seattle_info_backend/internal/filestorage" // Added
+	"seattle_info_backend/internal/grpcapi"
+	"seattle_info_backend/internal/jobrun"
 	"seattle_info_backend/internal/jobs"
 	"seattle_info_backend/internal/listing"
+	"seattle_info_backend/internal/listingimport"
+	"seattle_info_backend/internal/listingtemplate"
+	"seattle_info_backend/internal/messaging"
+	"seattle_info_backend/internal/moderation"
 	"seattle_info_backend/internal/notification" // Add this
+	"seattle_info_backend/internal/payments"
 	"seattle_info_backend/internal/platform/database"
+	"seattle_info_backend/internal/platform/email"
 	"seattle_info_backend/internal/platform/logger"
+	"seattle_info_backend/internal/platform/placenames"
+	"seattle_info_backend/internal/queue"
+	"seattle_info_backend/internal/savedsearch"
 	"seattle_info_backend/internal/shared"
+	"seattle_info_backend/internal/twofactor"
 	"seattle_info_backend/internal/user"
+	"seattle_info_backend/internal/verification"
+	"seattle_info_backend/internal/virusscan"
+	"seattle_info_backend/internal/webhook"
 	"time"
 
 	"github.com/google/wire"
@@ -30,6 +52,7 @@ import (
 func initializeServer(cfg *config.Config) (*app.Server, func(), error) {
 	wire.Build(
 		// Platform Layer
+		logger.NewLevel,
 		logger.New,
 		database.NewGORM,
 		// provideCleanup, // This should be fine
@@ -39,6 +62,7 @@ func initializeServer(cfg *config.Config) (*app.Server, func(), error) {
 
 		// FileStorage Service (New)
 		filestorage.NewFileStorageService,
+		filestorage.NewHandler, // Serves /static
 
 		// Core User Services
 		user.NewGORMRepository, // Returns user.Repository
@@ -72,6 +96,58 @@ func initializeServer(cfg *config.Config) (*app.Server, func(), error) {
 		// wire.Bind(new(notification.Service), new(*notification.ServiceImplementation)), // REMOVED
 		notification.NewHandler,
 
+		// Platform Configuration Module (runtime policies from app_configurations)
+		appconfig.NewGORMRepository,
+		appconfig.NewService,
+		appconfig.NewHandler,
+
+		// Partner API Key Module
+		apikey.NewGORMRepository,
+		apikey.NewService,
+		apikey.NewHandler,
+
+		// Audit Log Module (audit.Service is used by listing.NewService)
+		audit.NewGORMRepository,
+		audit.NewService,
+		audit.NewHandler,
+
+		// Abuse Tracking (abuse.Service is used by listing.NewService and the auth and IP ban middleware)
+		abuse.NewGORMRepository,
+		abuse.NewService,
+		abuse.NewHandler,
+
+		// Admin Overview (counts for the admin home page)
+		overview.NewGORMRepository,
+		overview.NewService,
+		overview.NewHandler,
+
+		// Job History (jobrun.Service records and triggers the jobs below)
+		jobrun.NewGORMRepository,
+		jobrun.NewService,
+		jobrun.NewHandler,
+
+		// CAPTCHA guard (used by the CAPTCHA middleware on listing and contact routes)
+		captcha.NewGuard,
+
+		// Task Queue (queue.Service is used by webhook.NewService and listing.NewService)
+		queue.NewGORMRepository,
+		queue.NewService,
+		queue.NewConsumer,
+		queue.NewHandler,
+
+		// Webhook Module (webhook.Service is used by listing.NewService)
+		webhook.NewGORMRepository,
+		webhook.NewService,
+		webhook.NewHandler,
+
+		// Content Moderation (used by listing.NewService)
+		moderation.NewModerator,
+		antispam.NewScorer,
+		placenames.NewSynonyms,
+
+		// Virus Scanning of uploaded images (used by listing.NewService)
+		virusscan.NewScanner,
+
 		// Listing Module (listing.NewService depends on notification.Service)
 		listing.NewGORMRepository, // Returns listing.Repository
 		// No bind needed for listing.Repository as NewGORMRepository returns the interface.
@@ -81,7 +157,63 @@ func initializeServer(cfg *config.Config) (*app.Server, func(), error) {
 		// wire.Bind(new(listing.Service), new(*listing.ServiceImplementation)), // REMOVED
 		listing.NewHandler,
 
+		// Listing Import Module (depends on listing.Service and queue.Service)
+		listingimport.NewGORMRepository,
+		listingimport.NewService,
+		listingimport.NewHandler,
+
+		// Listing Template Module (creates draft listings through listing.Service)
+		listingtemplate.NewGORMRepository,
+		listingtemplate.NewService,
+		listingtemplate.NewHandler,
+
+		// Anonymous Sessions (merge recently viewed listings through listing.Service; used by the anonymous session middleware)
+		anonsession.NewGORMRepository,
+		anonsession.NewService,
+		anonsession.NewHandler,
+
+		// Two-Factor Authentication (used by the admin auth middleware)
+		twofactor.NewGORMRepository,
+		twofactor.NewService,
+		twofactor.NewHandler,
+
+		// Payments Module (features listings through listing.Service once paid)
+		payments.NewGateway,
+		payments.NewGORMRepository,
+		payments.NewService,
+		payments.NewHandler,
+
+		// Saved Search Module (depends on listing.Service and notification.Service)
+		savedsearch.NewGORMRepository,
+		savedsearch.NewService,
+		savedsearch.NewHandler,
+
+		// Messaging Module (depends on listing.Service, notification.Service, user.Repository and email.Sender)
+		email.NewSender,
+		messaging.NewGORMRepository,
+		messaging.NewService,
+		messaging.NewHandler,
+
+		// Phone Verification Module (depends on user.Repository)
+		verification.NewSMSSender,
+		verification.NewGORMRepository,
+		verification.NewService,
+		verification.NewHandler,
+
 		jobs.NewListingExpiryJob,
+		jobs.NewSavedSearchDigestJob,
+		jobs.NewWebhookDeliveryJob,
+		jobs.NewImageConsistencyJob,
+		jobs.NewScheduledPublishJob,
+		jobs.NewFeaturedExpiryJob,
+		jobs.NewListingStatsRollupJob,
+		jobs.NewApprovalSLAJob,
+		jobs.NewRentBackfillJob,
+		jobs.NewTrendingListingsJob,
+		app.NewWorker,
+
+		// Internal gRPC API (shares listing, user and category services with HTTP)
+		grpcapi.NewServer,
 
 		// Application Layer
 		app.NewServer, // app.NewServer now needs notification.Handler
@@ -92,6 +224,59 @@ func initializeServer(cfg *config.Config) (*app.Server, func(), error) {
 	return nil, nil, nil
 }
 
+// initializeWorker builds only what the background jobs need, for the `worker` command.
+func initializeWorker(cfg *config.Config) (*app.Worker, func(), error) {
+	wire.Build(
+		logger.NewLevel,
+		logger.New,
+		database.NewGORM,
+		filestorage.NewFileStorageService,
+		user.NewGORMRepository,
+		user.NewService,
+		wire.Bind(new(shared.Service), new(*user.ServiceImplementation)),
+		category.NewGORMRepository,
+		category.NewService,
+		notification.NewGORMRepository,
+		notification.NewService,
+		email.NewSender,
+		appconfig.NewGORMRepository,
+		appconfig.NewService,
+		audit.NewGORMRepository,
+		audit.NewService,
+		jobrun.NewGORMRepository,
+		jobrun.NewService,
+		abuse.NewGORMRepository,
+		abuse.NewService,
+		queue.NewGORMRepository,
+		queue.NewService,
+		queue.NewConsumer,
+		webhook.NewGORMRepository,
+		webhook.NewService,
+		moderation.NewModerator,
+		antispam.NewScorer,
+		placenames.NewSynonyms,
+		virusscan.NewScanner,
+		listing.NewGORMRepository,
+		listing.NewService,
+		savedsearch.NewGORMRepository,
+		savedsearch.NewService,
+		listingimport.NewGORMRepository,
+		listingimport.NewService,
+		jobs.NewListingExpiryJob,
+		jobs.NewSavedSearchDigestJob,
+		jobs.NewWebhookDeliveryJob,
+		jobs.NewImageConsistencyJob,
+		jobs.NewScheduledPublishJob,
+		jobs.NewFeaturedExpiryJob,
+		jobs.NewListingStatsRollupJob,
+		jobs.NewApprovalSLAJob,
+		jobs.NewRentBackfillJob,
+		app.NewWorker,
+		provideImageStoragePath,
+	)
+	return nil, nil, nil
+}
+
 func provideImageStoragePath(cfg *config.Config) string {
 	return cfg.ImageStoragePath
 }
@@ -10,18 +10,41 @@ import (
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 	"log"
+	"seattle_info_backend/internal/abuse"
+	"seattle_info_backend/internal/anonsession"
+	"seattle_info_backend/internal/antispam"
+	"seattle_info_backend/internal/apikey"
 	"seattle_info_backend/internal/app"
+	"seattle_info_backend/internal/appconfig"
+	"seattle_info_backend/internal/audit"
 	"seattle_info_backend/internal/auth"
+	"seattle_info_backend/internal/captcha"
 	"seattle_info_backend/internal/category"
 	"seattle_info_backend/internal/config"
 	"seattle_info_backend/internal/filestorage"
 	"seattle_info_backend/internal/firebase"
+	"seattle_info_backend/internal/grpcapi"
+	"seattle_info_backend/internal/jobrun"
 	"seattle_info_backend/internal/jobs"
 	"seattle_info_backend/internal/listing"
+	"seattle_info_backend/internal/listingimport"
+	"seattle_info_backend/internal/listingtemplate"
+	"seattle_info_backend/internal/messaging"
+	"seattle_info_backend/internal/moderation"
 	"seattle_info_backend/internal/notification"
+	"seattle_info_backend/internal/overview"
+	"seattle_info_backend/internal/payments"
 	"seattle_info_backend/internal/platform/database"
+	"seattle_info_backend/internal/platform/email"
 	"seattle_info_backend/internal/platform/logger"
+	"seattle_info_backend/internal/platform/placenames"
+	"seattle_info_backend/internal/queue"
+	"seattle_info_backend/internal/savedsearch"
+	"seattle_info_backend/internal/twofactor"
 	"seattle_info_backend/internal/user"
+	"seattle_info_backend/internal/verification"
+	"seattle_info_backend/internal/virusscan"
+	"seattle_info_backend/internal/webhook"
 	"time"
 )
 
@@ -29,7 +52,8 @@ import (
 
 // initializeServer is the main Wire injector.
 func initializeServer(cfg *config.Config) (*app.Server, func(), error) {
-	zapLogger, err := logger.New(cfg)
+	atomicLevel := logger.NewLevel(cfg)
+	zapLogger, err := logger.New(cfg, atomicLevel)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -47,22 +71,97 @@ func initializeServer(cfg *config.Config) (*app.Server, func(), error) {
 	}
 	handler := user.NewHandler(serviceImplementation, zapLogger, inMemoryBlocklistService, firebaseService)
 	authHandler := auth.NewHandler(serviceImplementation, zapLogger)
+	string2 := provideImageStoragePath(cfg)
+	fileStorageService, err := filestorage.NewFileStorageService(string2, zapLogger)
+	if err != nil {
+		return nil, nil, err
+	}
 	categoryRepository := category.NewGORMRepository(db)
-	service := category.NewService(categoryRepository, zapLogger, cfg)
-	categoryHandler := category.NewHandler(service, zapLogger)
+	service := category.NewService(categoryRepository, fileStorageService, zapLogger, cfg)
+	categoryHandler := category.NewHandler(service, cfg, zapLogger)
 	listingRepository := listing.NewGORMRepository(db)
 	notificationRepository := notification.NewGORMRepository(db)
 	notificationService := notification.NewService(notificationRepository, zapLogger)
-	string2 := provideImageStoragePath(cfg)
-	fileStorageService, err := filestorage.NewFileStorageService(string2, zapLogger)
+	moderator := moderation.NewModerator(cfg, zapLogger)
+	scorer := antispam.NewScorer(db, cfg, zapLogger)
+	synonyms, err := placenames.NewSynonyms(cfg, zapLogger)
 	if err != nil {
 		return nil, nil, err
 	}
-	listingService := listing.NewService(listingRepository, repository, service, notificationService, fileStorageService, cfg, zapLogger)
+	appconfigRepository := appconfig.NewGORMRepository(db)
+	appconfigService := appconfig.NewService(appconfigRepository, cfg, zapLogger)
+	queueRepository := queue.NewGORMRepository(db)
+	queueService := queue.NewService(queueRepository, cfg, zapLogger)
+	webhookRepository := webhook.NewGORMRepository(db)
+	webhookService := webhook.NewService(webhookRepository, queueService, cfg, zapLogger)
+	auditRepository := audit.NewGORMRepository(db)
+	auditService := audit.NewService(auditRepository, zapLogger)
+	jobrunRepository := jobrun.NewGORMRepository(db)
+	jobrunService := jobrun.NewService(jobrunRepository, auditService, cfg, zapLogger)
+	abuseRepository := abuse.NewGORMRepository(db)
+	abuseService := abuse.NewService(abuseRepository, zapLogger)
+	scanner := virusscan.NewScanner(cfg, zapLogger)
+	listingService := listing.NewService(listingRepository, repository, service, notificationService, fileStorageService, moderator, scorer, synonyms, appconfigService, webhookService, auditService, abuseService, queueService, scanner, cfg, zapLogger)
 	listingHandler := listing.NewHandler(listingService, zapLogger, cfg)
 	notificationHandler := notification.NewHandler(notificationService, zapLogger)
-	listingExpiryJob := jobs.NewListingExpiryJob(listingService, zapLogger, cfg)
-	server, err := app.NewServer(cfg, zapLogger, handler, authHandler, categoryHandler, listingHandler, notificationHandler, listingExpiryJob, db, firebaseService, serviceImplementation, inMemoryBlocklistService)
+	savedsearchRepository := savedsearch.NewGORMRepository(db)
+	savedsearchService := savedsearch.NewService(savedsearchRepository, listingService, notificationService, zapLogger)
+	savedsearchHandler := savedsearch.NewHandler(savedsearchService, zapLogger, cfg)
+	listingExpiryJob := jobs.NewListingExpiryJob(listingService, jobrunService, zapLogger, cfg)
+	savedSearchDigestJob := jobs.NewSavedSearchDigestJob(savedsearchService, jobrunService, zapLogger, cfg)
+	appconfigHandler := appconfig.NewHandler(appconfigService, zapLogger)
+	apikeyRepository := apikey.NewGORMRepository(db)
+	apikeyService := apikey.NewService(apikeyRepository, cfg, zapLogger)
+	apikeyHandler := apikey.NewHandler(apikeyService, zapLogger)
+	webhookHandler := webhook.NewHandler(webhookService, zapLogger)
+	messagingRepository := messaging.NewGORMRepository(db)
+	sender := email.NewSender(cfg, zapLogger)
+	messagingService := messaging.NewService(messagingRepository, listingService, notificationService, repository, sender, cfg, zapLogger)
+	messagingHandler := messaging.NewHandler(messagingService, zapLogger)
+	auditHandler := audit.NewHandler(auditService, zapLogger)
+	abuseHandler := abuse.NewHandler(abuseService, zapLogger)
+	guard := captcha.NewGuard(db, cfg, zapLogger)
+	verificationRepository := verification.NewGORMRepository(db)
+	smsSender := verification.NewSMSSender(cfg, zapLogger)
+	verificationService := verification.NewService(verificationRepository, repository, smsSender, cfg, zapLogger)
+	verificationHandler := verification.NewHandler(verificationService, zapLogger)
+	queueHandler := queue.NewHandler(queueService, zapLogger)
+	filestorageHandler := filestorage.NewHandler(fileStorageService, cfg, zapLogger)
+	overviewRepository := overview.NewGORMRepository(db)
+	overviewService := overview.NewService(overviewRepository, cfg, zapLogger)
+	overviewHandler := overview.NewHandler(overviewService, zapLogger)
+	jobrunHandler := jobrun.NewHandler(jobrunService, zapLogger)
+	webhookDeliveryJob := jobs.NewWebhookDeliveryJob(webhookService, jobrunService, zapLogger, cfg)
+	consumer := queue.NewConsumer(queueRepository, cfg, zapLogger)
+	imageConsistencyJob := jobs.NewImageConsistencyJob(listingService, jobrunService, zapLogger, cfg)
+	listingimportRepository := listingimport.NewGORMRepository(db)
+	listingimportService := listingimport.NewService(listingimportRepository, listingService, service, repository, queueService, cfg, zapLogger)
+	listingimportHandler := listingimport.NewHandler(listingimportService, cfg, zapLogger)
+	listingtemplateRepository := listingtemplate.NewGORMRepository(db)
+	listingtemplateService := listingtemplate.NewService(listingtemplateRepository, listingService, zapLogger)
+	listingtemplateHandler := listingtemplate.NewHandler(listingtemplateService, zapLogger, cfg)
+	anonsessionRepository := anonsession.NewGORMRepository(db)
+	anonsessionService := anonsession.NewService(anonsessionRepository, listingService, cfg, zapLogger)
+	anonsessionHandler := anonsession.NewHandler(anonsessionService, zapLogger)
+	twofactorRepository := twofactor.NewGORMRepository(db)
+	twofactorService := twofactor.NewService(twofactorRepository, auditService, cfg, zapLogger)
+	twofactorHandler := twofactor.NewHandler(twofactorService, zapLogger)
+	scheduledPublishJob := jobs.NewScheduledPublishJob(listingService, jobrunService, zapLogger, cfg)
+	featuredExpiryJob := jobs.NewFeaturedExpiryJob(listingService, jobrunService, zapLogger, cfg)
+	listingStatsRollupJob := jobs.NewListingStatsRollupJob(listingService, jobrunService, zapLogger, cfg)
+	approvalSLAJob := jobs.NewApprovalSLAJob(listingService, serviceImplementation, notificationService, sender, jobrunService, zapLogger, cfg)
+	rentBackfillJob := jobs.NewRentBackfillJob(listingService, jobrunService, zapLogger, cfg)
+	worker := app.NewWorker(cfg, zapLogger, consumer, webhookService, listingService, listingimportService, listingExpiryJob, savedSearchDigestJob, webhookDeliveryJob, imageConsistencyJob, scheduledPublishJob, featuredExpiryJob, listingStatsRollupJob, approvalSLAJob, rentBackfillJob)
+	gateway := payments.NewGateway(cfg, zapLogger)
+	paymentsRepository := payments.NewGORMRepository(db)
+	paymentsService := payments.NewService(paymentsRepository, gateway, listingService, cfg, zapLogger)
+	paymentsHandler := payments.NewHandler(paymentsService, zapLogger)
+	trendingListingsJob := jobs.NewTrendingListingsJob(listingService, jobrunService, zapLogger, cfg)
+	grpcapiServer, err := grpcapi.NewServer(cfg, zapLogger, listingService, serviceImplementation, service)
+	if err != nil {
+		return nil, nil, err
+	}
+	server, err := app.NewServer(cfg, zapLogger, handler, authHandler, categoryHandler, listingHandler, notificationHandler, savedsearchHandler, appconfigHandler, apikeyHandler, webhookHandler, messagingHandler, auditHandler, verificationHandler, queueHandler, listingimportHandler, listingtemplateHandler, anonsessionHandler, twofactorHandler, paymentsHandler, abuseHandler, filestorageHandler, overviewHandler, jobrunHandler, listingService, worker, trendingListingsJob, grpcapiServer, db, firebaseService, serviceImplementation, inMemoryBlocklistService, apikeyService, abuseService, anonsessionService, twofactorService, guard, appconfigService, atomicLevel)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -70,6 +169,69 @@ func initializeServer(cfg *config.Config) (*app.Server, func(), error) {
 	}, nil
 }
 
+// initializeWorker builds only what the background jobs need, for the `worker` command.
+func initializeWorker(cfg *config.Config) (*app.Worker, func(), error) {
+	atomicLevel := logger.NewLevel(cfg)
+	zapLogger, err := logger.New(cfg, atomicLevel)
+	if err != nil {
+		return nil, nil, err
+	}
+	db, err := database.NewGORM(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	listingRepository := listing.NewGORMRepository(db)
+	repository := user.NewGORMRepository(db)
+	serviceImplementation := user.NewService(repository, cfg, zapLogger)
+	string2 := provideImageStoragePath(cfg)
+	fileStorageService, err := filestorage.NewFileStorageService(string2, zapLogger)
+	if err != nil {
+		return nil, nil, err
+	}
+	categoryRepository := category.NewGORMRepository(db)
+	service := category.NewService(categoryRepository, fileStorageService, zapLogger, cfg)
+	notificationRepository := notification.NewGORMRepository(db)
+	notificationService := notification.NewService(notificationRepository, zapLogger)
+	moderator := moderation.NewModerator(cfg, zapLogger)
+	scorer := antispam.NewScorer(db, cfg, zapLogger)
+	synonyms, err := placenames.NewSynonyms(cfg, zapLogger)
+	if err != nil {
+		return nil, nil, err
+	}
+	appconfigRepository := appconfig.NewGORMRepository(db)
+	appconfigService := appconfig.NewService(appconfigRepository, cfg, zapLogger)
+	queueRepository := queue.NewGORMRepository(db)
+	queueService := queue.NewService(queueRepository, cfg, zapLogger)
+	webhookRepository := webhook.NewGORMRepository(db)
+	webhookService := webhook.NewService(webhookRepository, queueService, cfg, zapLogger)
+	auditRepository := audit.NewGORMRepository(db)
+	auditService := audit.NewService(auditRepository, zapLogger)
+	jobrunRepository := jobrun.NewGORMRepository(db)
+	jobrunService := jobrun.NewService(jobrunRepository, auditService, cfg, zapLogger)
+	abuseRepository := abuse.NewGORMRepository(db)
+	abuseService := abuse.NewService(abuseRepository, zapLogger)
+	scanner := virusscan.NewScanner(cfg, zapLogger)
+	listingService := listing.NewService(listingRepository, repository, service, notificationService, fileStorageService, moderator, scorer, synonyms, appconfigService, webhookService, auditService, abuseService, queueService, scanner, cfg, zapLogger)
+	listingExpiryJob := jobs.NewListingExpiryJob(listingService, jobrunService, zapLogger, cfg)
+	savedsearchRepository := savedsearch.NewGORMRepository(db)
+	savedsearchService := savedsearch.NewService(savedsearchRepository, listingService, notificationService, zapLogger)
+	savedSearchDigestJob := jobs.NewSavedSearchDigestJob(savedsearchService, jobrunService, zapLogger, cfg)
+	webhookDeliveryJob := jobs.NewWebhookDeliveryJob(webhookService, jobrunService, zapLogger, cfg)
+	consumer := queue.NewConsumer(queueRepository, cfg, zapLogger)
+	imageConsistencyJob := jobs.NewImageConsistencyJob(listingService, jobrunService, zapLogger, cfg)
+	listingimportRepository := listingimport.NewGORMRepository(db)
+	listingimportService := listingimport.NewService(listingimportRepository, listingService, service, repository, queueService, cfg, zapLogger)
+	scheduledPublishJob := jobs.NewScheduledPublishJob(listingService, jobrunService, zapLogger, cfg)
+	featuredExpiryJob := jobs.NewFeaturedExpiryJob(listingService, jobrunService, zapLogger, cfg)
+	listingStatsRollupJob := jobs.NewListingStatsRollupJob(listingService, jobrunService, zapLogger, cfg)
+	sender := email.NewSender(cfg, zapLogger)
+	approvalSLAJob := jobs.NewApprovalSLAJob(listingService, serviceImplementation, notificationService, sender, jobrunService, zapLogger, cfg)
+	rentBackfillJob := jobs.NewRentBackfillJob(listingService, jobrunService, zapLogger, cfg)
+	worker := app.NewWorker(cfg, zapLogger, consumer, webhookService, listingService, listingimportService, listingExpiryJob, savedSearchDigestJob, webhookDeliveryJob, imageConsistencyJob, scheduledPublishJob, featuredExpiryJob, listingStatsRollupJob, approvalSLAJob, rentBackfillJob)
+	return worker, func() {
+	}, nil
+}
+
 // wire.go:
 
 func provideImageStoragePath(cfg *config.Config) string {
@@ -0,0 +1,114 @@
+// File: internal/abuse/handler.go
+package abuse
+
+import (
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Handler struct holds dependencies for abuse tracking handlers.
+type Handler struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewHandler creates a new abuse handler.
+func NewHandler(service Service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterAdminRoutes adds security events, account clusters and IP bans to the admin API group,
+// which already requires the admin role.
+func (h *Handler) RegisterAdminRoutes(router *gin.RouterGroup) {
+	router.GET("/security/events", h.adminListEvents)
+	router.GET("/security/clusters", h.adminListClusters)
+	router.GET("/ip-bans", h.adminListBans)
+	router.POST("/ip-bans", h.adminCreateBan)
+	router.DELETE("/ip-bans/:id", h.adminDeleteBan)
+}
+
+func (h *Handler) adminListEvents(c *gin.Context) {
+	var query EventQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	page, pageSize := common.GetPaginationParams(c)
+	events, pagination, err := h.service.ListEvents(c.Request.Context(), query, page, pageSize)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]SecurityEventResponse, len(events))
+	for i := range events {
+		responses[i] = ToSecurityEventResponse(&events[i])
+	}
+	common.RespondPaginated(c, "Security events retrieved successfully.", responses, pagination)
+}
+
+func (h *Handler) adminListClusters(c *gin.Context) {
+	var query ClusterQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	page, pageSize := common.GetPaginationParams(c)
+	clusters, pagination, err := h.service.FindClusters(c.Request.Context(), query, page, pageSize)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondPaginated(c, "Account clusters retrieved successfully.", clusters, pagination)
+}
+
+func (h *Handler) adminListBans(c *gin.Context) {
+	bans, err := h.service.ListBans(c.Request.Context())
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]IPBanResponse, len(bans))
+	for i := range bans {
+		responses[i] = ToIPBanResponse(&bans[i])
+	}
+	common.RespondOK(c, "IP bans retrieved successfully.", responses)
+}
+
+func (h *Handler) adminCreateBan(c *gin.Context) {
+	adminID := common.GetUserIDFromContext(c)
+	if adminID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	var req CreateIPBanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	ban, err := h.service.CreateBan(c.Request.Context(), adminID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondCreated(c, "IP ban created successfully.", ToIPBanResponse(ban))
+}
+
+func (h *Handler) adminDeleteBan(c *gin.Context) {
+	banID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid IP ban ID format."))
+		return
+	}
+	if err := h.service.DeleteBan(c.Request.Context(), banID); err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "IP ban deleted successfully.", nil)
+}
@@ -0,0 +1,130 @@
+// File: internal/abuse/model.go
+package abuse
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Security event types.
+const (
+	EventSignUp         = "sign_up"         // First authenticated request of a new account
+	EventSignIn         = "sign_in"         // Authenticated request, at most one per account, IP and device per sign-in window
+	EventListingCreated = "listing_created" // Listing created, including drafts
+)
+
+// Cluster dimensions.
+const (
+	ClusterByIP     = "ip"
+	ClusterByDevice = "device"
+)
+
+// SecurityEvent records where a security-relevant action came from. Events are never updated.
+type SecurityEvent struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID    *uuid.UUID `gorm:"type:uuid"`
+	EventType string     `gorm:"type:varchar(50);not null"`
+	IP        string     `gorm:"type:varchar(45);not null"`
+	DeviceID  *string    `gorm:"type:varchar(128)"` // From the device fingerprint header, when the client sends one
+	CreatedAt time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName specifies the table name for GORM.
+func (SecurityEvent) TableName() string {
+	return "security_events"
+}
+
+// IPBan blocks every request from an address range.
+type IPBan struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	CIDR      string     `gorm:"column:cidr;type:cidr;not null"`
+	Reason    *string    `gorm:"type:text"`
+	CreatedBy uuid.UUID  `gorm:"type:uuid;not null"`
+	ExpiresAt *time.Time // Nil bans permanently
+	CreatedAt time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName specifies the table name for GORM.
+func (IPBan) TableName() string {
+	return "ip_bans"
+}
+
+// Cluster is a shared IP address or device used by several accounts.
+type Cluster struct {
+	Value        string      `json:"value"` // The IP address or device ID
+	AccountCount int         `json:"account_count"`
+	UserIDs      []uuid.UUID `json:"user_ids"`
+	EventCount   int         `json:"event_count"`
+	LastSeenAt   time.Time   `json:"last_seen_at"`
+}
+
+// --- Request DTOs ---
+
+// ClusterQuery selects the clusters to report.
+type ClusterQuery struct {
+	By          string `form:"by" binding:"omitempty,oneof=ip device"`          // Default ip
+	MinAccounts int    `form:"min_accounts" binding:"omitempty,min=2,max=1000"` // Default 2
+	Days        int    `form:"days" binding:"omitempty,min=1,max=365"`          // Look-back period, default 30
+}
+
+// EventQuery filters security events. Empty fields match every event.
+type EventQuery struct {
+	UserID    string `form:"user_id" binding:"omitempty,uuid"`
+	IP        string `form:"ip" binding:"omitempty,ip"`
+	DeviceID  string `form:"device_id" binding:"omitempty,max=128"`
+	EventType string `form:"event_type" binding:"omitempty,oneof=sign_up sign_in listing_created"`
+}
+
+// CreateIPBanRequest bans an IP address or CIDR range.
+type CreateIPBanRequest struct {
+	CIDR      string     `json:"cidr" binding:"required,max=50"` // A single address bans just that address
+	Reason    *string    `json:"reason,omitempty" binding:"omitempty,max=500"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// --- Response DTOs ---
+
+// SecurityEventResponse is the API representation of a security event.
+type SecurityEventResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    *uuid.UUID `json:"user_id,omitempty"`
+	EventType string     `json:"event_type"`
+	IP        string     `json:"ip"`
+	DeviceID  *string    `json:"device_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ToSecurityEventResponse converts a SecurityEvent model to a SecurityEventResponse DTO.
+func ToSecurityEventResponse(e *SecurityEvent) SecurityEventResponse {
+	return SecurityEventResponse{
+		ID:        e.ID,
+		UserID:    e.UserID,
+		EventType: e.EventType,
+		IP:        e.IP,
+		DeviceID:  e.DeviceID,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+// IPBanResponse is the API representation of an IP ban.
+type IPBanResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	CIDR      string     `json:"cidr"`
+	Reason    *string    `json:"reason,omitempty"`
+	CreatedBy uuid.UUID  `json:"created_by"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ToIPBanResponse converts an IPBan model to an IPBanResponse DTO.
+func ToIPBanResponse(b *IPBan) IPBanResponse {
+	return IPBanResponse{
+		ID:        b.ID,
+		CIDR:      b.CIDR,
+		Reason:    b.Reason,
+		CreatedBy: b.CreatedBy,
+		ExpiresAt: b.ExpiresAt,
+		CreatedAt: b.CreatedAt,
+	}
+}
@@ -0,0 +1,166 @@
+// File: internal/abuse/repository.go
+package abuse
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for security event and IP ban data operations.
+type Repository interface {
+	CreateEvent(ctx context.Context, event *SecurityEvent) error
+	FindEvents(ctx context.Context, query EventQuery, page, pageSize int) ([]SecurityEvent, *common.Pagination, error)
+	// Clusters groups the events since the given time by IP or device ID and returns the groups
+	// used by at least minAccounts accounts, largest first.
+	Clusters(ctx context.Context, by string, since time.Time, minAccounts, page, pageSize int) ([]Cluster, *common.Pagination, error)
+
+	CreateBan(ctx context.Context, ban *IPBan) error
+	ListBans(ctx context.Context) ([]IPBan, error)
+	ActiveBans(ctx context.Context, now time.Time) ([]IPBan, error)
+	DeleteBan(ctx context.Context, id uuid.UUID) error
+}
+
+// GORMRepository implements the abuse Repository interface using GORM.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+// NewGORMRepository creates a new GORM abuse repository.
+func NewGORMRepository(db *gorm.DB) Repository {
+	return &GORMRepository{db: db}
+}
+
+// CreateEvent inserts a new security event.
+func (r *GORMRepository) CreateEvent(ctx context.Context, event *SecurityEvent) error {
+	if err := r.db.WithContext(ctx).Create(event).Error; err != nil {
+		return fmt.Errorf("failed to create security event: %w", err)
+	}
+	return nil
+}
+
+// FindEvents retrieves security events matching query, newest first.
+func (r *GORMRepository) FindEvents(ctx context.Context, query EventQuery, page, pageSize int) ([]SecurityEvent, *common.Pagination, error) {
+	var events []SecurityEvent
+	var totalItems int64
+
+	dbQuery := r.db.WithContext(ctx).Model(&SecurityEvent{})
+	if query.UserID != "" {
+		dbQuery = dbQuery.Where("user_id = ?", query.UserID)
+	}
+	if query.IP != "" {
+		dbQuery = dbQuery.Where("ip = ?", query.IP)
+	}
+	if query.DeviceID != "" {
+		dbQuery = dbQuery.Where("device_id = ?", query.DeviceID)
+	}
+	if query.EventType != "" {
+		dbQuery = dbQuery.Where("event_type = ?", query.EventType)
+	}
+	if err := dbQuery.Count(&totalItems).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to count security events: %w", err)
+	}
+
+	offset := (page - 1) * pageSize
+	if err := dbQuery.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&events).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to list security events: %w", err)
+	}
+	return events, common.NewPagination(totalItems, page, pageSize), nil
+}
+
+// clusterRow is a Cluster as scanned from the database.
+type clusterRow struct {
+	Value        string
+	AccountCount int
+	UserIDs      pq.StringArray `gorm:"type:text[]"`
+	EventCount   int
+	LastSeenAt   time.Time
+}
+
+// Clusters implements Repository.
+func (r *GORMRepository) Clusters(ctx context.Context, by string, since time.Time, minAccounts, page, pageSize int) ([]Cluster, *common.Pagination, error) {
+	column := "ip"
+	if by == ClusterByDevice {
+		column = "device_id"
+	}
+	grouped := r.db.WithContext(ctx).Model(&SecurityEvent{}).
+		Select(column+" AS value, COUNT(DISTINCT user_id) AS account_count, "+
+			"array_agg(DISTINCT user_id::text) AS user_ids, COUNT(*) AS event_count, MAX(created_at) AS last_seen_at").
+		Where("created_at >= ? AND user_id IS NOT NULL AND "+column+" IS NOT NULL AND "+column+" <> ''", since).
+		Group(column).
+		Having("COUNT(DISTINCT user_id) >= ?", minAccounts).
+		Session(&gorm.Session{}) // Reused for the count and the page
+
+	var totalItems int64
+	if err := r.db.WithContext(ctx).Table("(?) AS clusters", grouped).Count(&totalItems).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to count security event clusters: %w", err)
+	}
+
+	var rows []clusterRow
+	offset := (page - 1) * pageSize
+	if err := grouped.Order("account_count DESC, last_seen_at DESC").Offset(offset).Limit(pageSize).Scan(&rows).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to list security event clusters: %w", err)
+	}
+
+	clusters := make([]Cluster, len(rows))
+	for i, row := range rows {
+		userIDs := make([]uuid.UUID, 0, len(row.UserIDs))
+		for _, raw := range row.UserIDs {
+			if id, err := uuid.Parse(raw); err == nil {
+				userIDs = append(userIDs, id)
+			}
+		}
+		clusters[i] = Cluster{
+			Value:        row.Value,
+			AccountCount: row.AccountCount,
+			UserIDs:      userIDs,
+			EventCount:   row.EventCount,
+			LastSeenAt:   row.LastSeenAt,
+		}
+	}
+	return clusters, common.NewPagination(totalItems, page, pageSize), nil
+}
+
+// CreateBan inserts a new IP ban.
+func (r *GORMRepository) CreateBan(ctx context.Context, ban *IPBan) error {
+	if err := r.db.WithContext(ctx).Create(ban).Error; err != nil {
+		return fmt.Errorf("failed to create IP ban: %w", err)
+	}
+	return nil
+}
+
+// ListBans retrieves every IP ban, newest first.
+func (r *GORMRepository) ListBans(ctx context.Context) ([]IPBan, error) {
+	var bans []IPBan
+	if err := r.db.WithContext(ctx).Order("created_at DESC").Find(&bans).Error; err != nil {
+		return nil, fmt.Errorf("failed to list IP bans: %w", err)
+	}
+	return bans, nil
+}
+
+// ActiveBans retrieves the IP bans that have not expired at now.
+func (r *GORMRepository) ActiveBans(ctx context.Context, now time.Time) ([]IPBan, error) {
+	var bans []IPBan
+	if err := r.db.WithContext(ctx).Where("expires_at IS NULL OR expires_at > ?", now).Find(&bans).Error; err != nil {
+		return nil, fmt.Errorf("failed to list active IP bans: %w", err)
+	}
+	return bans, nil
+}
+
+// DeleteBan removes an IP ban.
+func (r *GORMRepository) DeleteBan(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&IPBan{}, "id = ?", id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete IP ban: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound.WithDetails("IP ban not found.")
+	}
+	return nil
+}
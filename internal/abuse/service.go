@@ -0,0 +1,253 @@
+// File: internal/abuse/service.go
+package abuse
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/platform/clientinfo"
+
+	"github.com/google/uuid"
+	"github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+)
+
+const (
+	// signInWindow is how long a sign-in from the same account, IP and device is recorded only once.
+	// Every authenticated request counts as a sign-in, so this keeps the table from growing per request.
+	signInWindow = time.Hour
+	// banCacheTTL is how long the active bans are served from memory before they are reloaded.
+	banCacheTTL = time.Minute
+	// defaultClusterDays is the look-back period of cluster queries that do not set one.
+	defaultClusterDays = 30
+)
+
+// Service defines the interface for abuse tracking: security events, account clusters and IP bans.
+type Service interface {
+	// RecordAuthentication records a sign-up for a new account, or a sign-in otherwise.
+	RecordAuthentication(ctx context.Context, userID uuid.UUID, newAccount bool)
+	// RecordEvent records an event from the client in ctx. Failures are logged, never returned,
+	// so tracking never blocks the request it observes.
+	RecordEvent(ctx context.Context, userID uuid.UUID, eventType string)
+	ListEvents(ctx context.Context, query EventQuery, page, pageSize int) ([]SecurityEvent, *common.Pagination, error)
+	FindClusters(ctx context.Context, query ClusterQuery, page, pageSize int) ([]Cluster, *common.Pagination, error)
+
+	CreateBan(ctx context.Context, adminID uuid.UUID, req CreateIPBanRequest) (*IPBan, error)
+	ListBans(ctx context.Context) ([]IPBan, error)
+	DeleteBan(ctx context.Context, id uuid.UUID) error
+	// IsBanned reports whether ip falls in an active ban. It fails open when the bans cannot be loaded.
+	IsBanned(ctx context.Context, ip string) bool
+}
+
+// activeBan is a loaded ban ready for matching.
+type activeBan struct {
+	network   *net.IPNet
+	expiresAt *time.Time
+}
+
+// ServiceImplementation implements the abuse Service interface.
+type ServiceImplementation struct {
+	repo     Repository
+	logger   *zap.Logger
+	now      func() time.Time
+	signIns  *cache.Cache
+	bansMu   sync.RWMutex
+	bans     []activeBan
+	bansAt   time.Time
+	bansOnce bool
+}
+
+// NewService creates a new abuse service.
+func NewService(repo Repository, logger *zap.Logger) Service {
+	return &ServiceImplementation{
+		repo:    repo,
+		logger:  logger,
+		now:     time.Now,
+		signIns: cache.New(signInWindow, 10*time.Minute),
+	}
+}
+
+// RecordAuthentication implements Service.
+func (s *ServiceImplementation) RecordAuthentication(ctx context.Context, userID uuid.UUID, newAccount bool) {
+	if newAccount {
+		s.RecordEvent(ctx, userID, EventSignUp)
+		return
+	}
+	info := clientinfo.FromContext(ctx)
+	key := userID.String() + "|" + info.IP + "|" + info.DeviceID
+	if s.signIns.Add(key, true, signInWindow) != nil {
+		return // Already recorded in this window
+	}
+	s.RecordEvent(ctx, userID, EventSignIn)
+}
+
+// RecordEvent implements Service.
+func (s *ServiceImplementation) RecordEvent(ctx context.Context, userID uuid.UUID, eventType string) {
+	info := clientinfo.FromContext(ctx)
+	if info.IP == "" {
+		return // Not an HTTP request, e.g. a background job
+	}
+	event := &SecurityEvent{UserID: &userID, EventType: eventType, IP: info.IP}
+	if info.DeviceID != "" {
+		event.DeviceID = &info.DeviceID
+	}
+	if err := s.repo.CreateEvent(ctx, event); err != nil {
+		s.logger.Error("Failed to record security event",
+			zap.String("eventType", eventType),
+			zap.String("userID", userID.String()),
+			zap.Error(err))
+	}
+}
+
+// ListEvents implements Service.
+func (s *ServiceImplementation) ListEvents(ctx context.Context, query EventQuery, page, pageSize int) ([]SecurityEvent, *common.Pagination, error) {
+	events, pagination, err := s.repo.FindEvents(ctx, query, page, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to list security events", zap.Error(err))
+		return nil, nil, common.ErrInternalServer
+	}
+	return events, pagination, nil
+}
+
+// FindClusters implements Service.
+func (s *ServiceImplementation) FindClusters(ctx context.Context, query ClusterQuery, page, pageSize int) ([]Cluster, *common.Pagination, error) {
+	if query.By == "" {
+		query.By = ClusterByIP
+	}
+	if query.MinAccounts == 0 {
+		query.MinAccounts = 2
+	}
+	if query.Days == 0 {
+		query.Days = defaultClusterDays
+	}
+	since := s.now().AddDate(0, 0, -query.Days)
+	clusters, pagination, err := s.repo.Clusters(ctx, query.By, since, query.MinAccounts, page, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to find account clusters", zap.String("by", query.By), zap.Error(err))
+		return nil, nil, common.ErrInternalServer
+	}
+	return clusters, pagination, nil
+}
+
+// CreateBan implements Service. The admin cannot ban a range containing their own address.
+func (s *ServiceImplementation) CreateBan(ctx context.Context, adminID uuid.UUID, req CreateIPBanRequest) (*IPBan, error) {
+	network, err := parseNetwork(req.CIDR)
+	if err != nil {
+		return nil, common.ErrBadRequest.WithDetails("cidr must be an IP address or CIDR range, e.g. 203.0.113.0/24.")
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(s.now()) {
+		return nil, common.ErrBadRequest.WithDetails("expires_at must be in the future.")
+	}
+	if ip := net.ParseIP(clientinfo.FromContext(ctx).IP); ip != nil && network.Contains(ip) {
+		return nil, common.ErrBadRequest.WithDetails("The range includes your own address.")
+	}
+
+	ban := &IPBan{CIDR: network.String(), Reason: req.Reason, CreatedBy: adminID, ExpiresAt: req.ExpiresAt}
+	if err := s.repo.CreateBan(ctx, ban); err != nil {
+		s.logger.Error("Failed to create IP ban", zap.String("cidr", ban.CIDR), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not create IP ban.")
+	}
+	s.logger.Info("IP range banned", zap.String("cidr", ban.CIDR), zap.String("adminID", adminID.String()))
+	s.invalidateBans()
+	return ban, nil
+}
+
+// ListBans implements Service.
+func (s *ServiceImplementation) ListBans(ctx context.Context) ([]IPBan, error) {
+	bans, err := s.repo.ListBans(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list IP bans", zap.Error(err))
+		return nil, common.ErrInternalServer
+	}
+	return bans, nil
+}
+
+// DeleteBan implements Service.
+func (s *ServiceImplementation) DeleteBan(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.DeleteBan(ctx, id); err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return err
+		}
+		s.logger.Error("Failed to delete IP ban", zap.String("banID", id.String()), zap.Error(err))
+		return common.ErrInternalServer.WithDetails("Could not delete IP ban.")
+	}
+	s.invalidateBans()
+	return nil
+}
+
+// IsBanned implements Service.
+func (s *ServiceImplementation) IsBanned(ctx context.Context, ip string) bool {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	now := s.now()
+	for _, ban := range s.activeBans(ctx, now) {
+		if ban.network.Contains(addr) && (ban.expiresAt == nil || ban.expiresAt.After(now)) {
+			return true
+		}
+	}
+	return false
+}
+
+// activeBans returns the cached bans, reloading them once banCacheTTL has passed.
+// When a reload fails the previous bans stay in use.
+func (s *ServiceImplementation) activeBans(ctx context.Context, now time.Time) []activeBan {
+	s.bansMu.RLock()
+	bans, fresh := s.bans, s.bansOnce && now.Sub(s.bansAt) < banCacheTTL
+	s.bansMu.RUnlock()
+	if fresh {
+		return bans
+	}
+
+	s.bansMu.Lock()
+	defer s.bansMu.Unlock()
+	if s.bansOnce && now.Sub(s.bansAt) < banCacheTTL {
+		return s.bans
+	}
+	stored, err := s.repo.ActiveBans(ctx, now)
+	if err != nil {
+		s.logger.Error("Failed to load IP bans, keeping the previous list", zap.Error(err))
+		s.bansAt, s.bansOnce = now, true // Retry after banCacheTTL rather than on every request
+		return s.bans
+	}
+	loaded := make([]activeBan, 0, len(stored))
+	for i := range stored {
+		network, err := parseNetwork(stored[i].CIDR)
+		if err != nil {
+			s.logger.Warn("Skipping malformed IP ban", zap.String("cidr", stored[i].CIDR))
+			continue
+		}
+		loaded = append(loaded, activeBan{network: network, expiresAt: stored[i].ExpiresAt})
+	}
+	s.bans, s.bansAt, s.bansOnce = loaded, now, true
+	return loaded
+}
+
+// invalidateBans makes the next IsBanned call reload the bans.
+func (s *ServiceImplementation) invalidateBans() {
+	s.bansMu.Lock()
+	s.bansOnce = false
+	s.bansMu.Unlock()
+}
+
+// parseNetwork parses a CIDR range, or a single address as a range of one.
+func parseNetwork(raw string) (*net.IPNet, error) {
+	raw = strings.TrimSpace(raw)
+	if strings.Contains(raw, "/") {
+		_, network, err := net.ParseCIDR(raw)
+		return network, err
+	}
+	ip := net.ParseIP(raw)
+	if ip == nil {
+		return nil, &net.ParseError{Type: "IP address", Text: raw}
+	}
+	if v4 := ip.To4(); v4 != nil {
+		return &net.IPNet{IP: v4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
@@ -0,0 +1,109 @@
+package abuse
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/platform/clientinfo"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryRepository keeps events and bans in memory.
+type memoryRepository struct {
+	Repository
+	events     []*SecurityEvent
+	bans       []IPBan
+	bansLoaded int
+}
+
+func (r *memoryRepository) CreateEvent(_ context.Context, event *SecurityEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *memoryRepository) CreateBan(_ context.Context, ban *IPBan) error {
+	r.bans = append(r.bans, *ban)
+	return nil
+}
+
+func (r *memoryRepository) ActiveBans(context.Context, time.Time) ([]IPBan, error) {
+	r.bansLoaded++
+	return r.bans, nil
+}
+
+func newTestService(repo Repository, now time.Time) *ServiceImplementation {
+	svc := NewService(repo, zap.NewNop()).(*ServiceImplementation)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestRecordAuthenticationDeduplicatesSignIns(t *testing.T) {
+	repo := &memoryRepository{}
+	svc := newTestService(repo, time.Now())
+	userID := uuid.New()
+	phone := clientinfo.WithInfo(context.Background(), clientinfo.Info{IP: "203.0.113.7", DeviceID: "device-1"})
+	laptop := clientinfo.WithInfo(context.Background(), clientinfo.Info{IP: "203.0.113.7", DeviceID: "device-2"})
+
+	svc.RecordAuthentication(phone, userID, true)
+	svc.RecordAuthentication(phone, userID, false)
+	svc.RecordAuthentication(phone, userID, false)
+	svc.RecordAuthentication(laptop, userID, false)
+	svc.RecordAuthentication(context.Background(), userID, false) // No client, e.g. a background job
+
+	require.Len(t, repo.events, 3)
+	assert.Equal(t, EventSignUp, repo.events[0].EventType)
+	assert.Equal(t, EventSignIn, repo.events[1].EventType)
+	assert.Equal(t, "device-1", *repo.events[1].DeviceID)
+	assert.Equal(t, "device-2", *repo.events[2].DeviceID)
+	assert.Equal(t, "203.0.113.7", repo.events[2].IP)
+}
+
+func TestIsBannedMatchesRanges(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+	repo := &memoryRepository{bans: []IPBan{
+		{CIDR: "203.0.113.0/24"},
+		{CIDR: "2001:db8::/32"},
+		{CIDR: "198.51.100.9/32", ExpiresAt: &past},
+	}}
+	svc := newTestService(repo, now)
+	ctx := context.Background()
+
+	assert.True(t, svc.IsBanned(ctx, "203.0.113.200"))
+	assert.True(t, svc.IsBanned(ctx, "2001:db8::1"))
+	assert.False(t, svc.IsBanned(ctx, "203.0.114.1"))
+	assert.False(t, svc.IsBanned(ctx, "198.51.100.9"), "expired bans no longer apply")
+	assert.False(t, svc.IsBanned(ctx, "not-an-ip"))
+	assert.Equal(t, 1, repo.bansLoaded, "bans are served from memory between reloads")
+}
+
+func TestCreateBan(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo := &memoryRepository{}
+	svc := newTestService(repo, now)
+	adminCtx := clientinfo.WithInfo(context.Background(), clientinfo.Info{IP: "192.0.2.10"})
+
+	assert.False(t, svc.IsBanned(adminCtx, "203.0.113.7"))
+
+	ban, err := svc.CreateBan(adminCtx, uuid.New(), CreateIPBanRequest{CIDR: " 203.0.113.7 "})
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.7/32", ban.CIDR)
+	assert.True(t, svc.IsBanned(adminCtx, "203.0.113.7"), "a new ban applies immediately")
+
+	ban, err = svc.CreateBan(adminCtx, uuid.New(), CreateIPBanRequest{CIDR: "198.51.100.77/24"})
+	require.NoError(t, err)
+	assert.Equal(t, "198.51.100.0/24", ban.CIDR, "ranges are stored in canonical form")
+
+	past := now.Add(-time.Hour)
+	_, err = svc.CreateBan(adminCtx, uuid.New(), CreateIPBanRequest{CIDR: "198.51.100.0/24", ExpiresAt: &past})
+	assert.Error(t, err)
+	_, err = svc.CreateBan(adminCtx, uuid.New(), CreateIPBanRequest{CIDR: "192.0.2.0/24"})
+	assert.Error(t, err, "admins cannot ban their own address")
+	_, err = svc.CreateBan(adminCtx, uuid.New(), CreateIPBanRequest{CIDR: "example.com"})
+	assert.Error(t, err)
+}
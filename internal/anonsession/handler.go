@@ -0,0 +1,65 @@
+// File: internal/anonsession/handler.go
+package anonsession
+
+import (
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Handler struct holds dependencies for anonymous session handlers.
+type Handler struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewHandler creates a new anonymous session handler.
+func NewHandler(service Service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes sets up the routes for anonymous sessions: creating one is public,
+// merging one into an account requires authentication.
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMW gin.HandlerFunc) {
+	router.POST("/anonymous-sessions", h.createSession)
+	router.POST("/users/me/merge-anonymous", authMW, h.mergeAnonymous)
+}
+
+func (h *Handler) createSession(c *gin.Context) {
+	session, token, err := h.service.CreateSession(c.Request.Context())
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondCreated(c, "Anonymous session created successfully. Store the token; it cannot be retrieved again.", SessionResponse{
+		Token:     token,
+		ExpiresAt: session.ExpiresAt,
+	})
+}
+
+func (h *Handler) mergeAnonymous(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	var req MergeAnonymousRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Merge anonymous session: Invalid request body", zap.Error(err))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	result, err := h.service.Merge(c.Request.Context(), userID, req.Token)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Anonymous session merged successfully.", result)
+}
@@ -0,0 +1,42 @@
+// File: internal/anonsession/model.go
+package anonsession
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is a server-issued session of a visitor who is not signed in, so that their recently viewed listings
+// can be kept and merged into their account when they sign in. Only the SHA-256 hash of the token is stored.
+type Session struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	TokenHash string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName specifies the table name for GORM.
+func (Session) TableName() string {
+	return "anonymous_sessions"
+}
+
+// --- Request DTOs ---
+
+// MergeAnonymousRequest names the anonymous session to merge into the signed-in user's account.
+type MergeAnonymousRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// --- Response DTOs ---
+
+// SessionResponse is returned once when a session is created; the token cannot be retrieved later.
+type SessionResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// MergeResult reports what was moved from an anonymous session into the user's account.
+type MergeResult struct {
+	RecentlyViewed int `json:"recently_viewed"` // Listings added to or refreshed in the user's recently viewed listings
+}
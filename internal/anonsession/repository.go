@@ -0,0 +1,79 @@
+// File: internal/anonsession/repository.go
+package anonsession
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for anonymous session data operations.
+type Repository interface {
+	Create(ctx context.Context, session *Session) error
+	FindByTokenHash(ctx context.Context, tokenHash string) (*Session, error)
+	Extend(ctx context.Context, id uuid.UUID, expiresAt time.Time) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// GORMRepository implements the anonymous session Repository interface using GORM.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+// NewGORMRepository creates a new GORM anonymous session repository.
+func NewGORMRepository(db *gorm.DB) Repository {
+	return &GORMRepository{db: db}
+}
+
+// Create inserts a new session.
+func (r *GORMRepository) Create(ctx context.Context, session *Session) error {
+	if err := r.db.WithContext(ctx).Create(session).Error; err != nil {
+		return fmt.Errorf("failed to create anonymous session: %w", err)
+	}
+	return nil
+}
+
+// FindByTokenHash retrieves a session by the hash of its token, expired or not.
+func (r *GORMRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*Session, error) {
+	var session Session
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Anonymous session not found.")
+		}
+		return nil, fmt.Errorf("failed to find anonymous session: %w", err)
+	}
+	return &session, nil
+}
+
+// Extend moves the expiry of a session.
+func (r *GORMRepository) Extend(ctx context.Context, id uuid.UUID, expiresAt time.Time) error {
+	err := r.db.WithContext(ctx).Model(&Session{}).Where("id = ?", id).Update("expires_at", expiresAt).Error
+	if err != nil {
+		return fmt.Errorf("failed to extend anonymous session: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a session together with the data kept for it.
+func (r *GORMRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&Session{}).Error; err != nil {
+		return fmt.Errorf("failed to delete anonymous session: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired removes the sessions that expired before now, together with their data, and returns how many.
+func (r *GORMRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&Session{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired anonymous sessions: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,156 @@
+// File: internal/anonsession/service.go
+package anonsession
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/listing"
+	"seattle_info_backend/internal/platform/crypto"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// tokenPrefix marks anonymous session tokens, so they are not mistaken for other credentials.
+	tokenPrefix = "anon_"
+	// defaultSessionTTL is used when ANONYMOUS_SESSION_TTL_DAYS is not positive.
+	defaultSessionTTL = 30 * 24 * time.Hour
+	// extendInterval is how far the expiry of a session in use must have fallen behind before it is moved
+	// forward again, so that browsing does not write the session on every request.
+	extendInterval = 24 * time.Hour
+)
+
+// Service defines the interface for anonymous sessions.
+type Service interface {
+	CreateSession(ctx context.Context) (*Session, string, error)
+	Resolve(ctx context.Context, token string) (*Session, error)
+	Merge(ctx context.Context, userID uuid.UUID, token string) (*MergeResult, error)
+}
+
+// ServiceImplementation implements the anonymous session Service interface.
+type ServiceImplementation struct {
+	repo           Repository
+	listingService listing.Service
+	cfg            *config.Config
+	logger         *zap.Logger
+	now            func() time.Time
+}
+
+// NewService creates a new anonymous session service.
+func NewService(repo Repository, listingService listing.Service, cfg *config.Config, logger *zap.Logger) Service {
+	return &ServiceImplementation{
+		repo:           repo,
+		listingService: listingService,
+		cfg:            cfg,
+		logger:         logger,
+		now:            time.Now,
+	}
+}
+
+// CreateSession issues a new session and returns it together with its token, which is not retrievable later.
+// Sessions that have expired are deleted on the way.
+func (s *ServiceImplementation) CreateSession(ctx context.Context) (*Session, string, error) {
+	now := s.now()
+	if deleted, err := s.repo.DeleteExpired(ctx, now); err != nil {
+		s.logger.Warn("Failed to delete expired anonymous sessions", zap.Error(err))
+	} else if deleted > 0 {
+		s.logger.Debug("Deleted expired anonymous sessions", zap.Int64("count", deleted))
+	}
+
+	random, err := crypto.GenerateSecureRandomString(32)
+	if err != nil {
+		s.logger.Error("Failed to generate anonymous session token", zap.Error(err))
+		return nil, "", common.ErrInternalServer.WithDetails("Could not create anonymous session.")
+	}
+	token := tokenPrefix + strings.TrimRight(random, "=")
+
+	session := &Session{
+		ID:        uuid.New(),
+		TokenHash: hashToken(token),
+		ExpiresAt: now.Add(s.ttl()),
+		CreatedAt: now,
+	}
+	if err := s.repo.Create(ctx, session); err != nil {
+		s.logger.Error("Failed to create anonymous session", zap.Error(err))
+		return nil, "", common.ErrInternalServer.WithDetails("Could not create anonymous session.")
+	}
+	return session, token, nil
+}
+
+// Resolve returns the live session of token. Using a session keeps it alive: its expiry is moved forward to
+// ANONYMOUS_SESSION_TTL_DAYS from now, at most once per extendInterval.
+func (s *ServiceImplementation) Resolve(ctx context.Context, token string) (*Session, error) {
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return nil, common.ErrUnauthorized.WithDetails("Invalid anonymous session.")
+	}
+	session, err := s.repo.FindByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return nil, common.ErrUnauthorized.WithDetails("Invalid anonymous session.")
+		}
+		s.logger.Error("Failed to look up anonymous session", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not verify anonymous session.")
+	}
+
+	now := s.now()
+	if !session.ExpiresAt.After(now) {
+		return nil, common.ErrUnauthorized.WithDetails("Anonymous session has expired.")
+	}
+	if expiresAt := now.Add(s.ttl()); expiresAt.Sub(session.ExpiresAt) >= extendInterval {
+		if err := s.repo.Extend(ctx, session.ID, expiresAt); err != nil {
+			s.logger.Warn("Failed to extend anonymous session", zap.String("sessionID", session.ID.String()), zap.Error(err))
+		} else {
+			session.ExpiresAt = expiresAt
+		}
+	}
+	return session, nil
+}
+
+// Merge moves the data of the anonymous session of token into the user's account and ends the session.
+func (s *ServiceImplementation) Merge(ctx context.Context, userID uuid.UUID, token string) (*MergeResult, error) {
+	session, err := s.Resolve(ctx, token)
+	if err != nil {
+		if errors.Is(err, common.ErrUnauthorized) {
+			// The caller is signed in; a 401 would read as a problem with their account token.
+			return nil, common.ErrBadRequest.WithDetails("The anonymous session is invalid or has expired.")
+		}
+		return nil, err
+	}
+
+	recentlyViewed, err := s.listingService.MergeAnonymousRecentlyViewed(ctx, session.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The session's data has moved; a session left behind is empty and expires on its own.
+	if err := s.repo.Delete(ctx, session.ID); err != nil {
+		s.logger.Warn("Failed to delete merged anonymous session", zap.String("sessionID", session.ID.String()), zap.Error(err))
+	}
+
+	s.logger.Info("Anonymous session merged into account",
+		zap.String("sessionID", session.ID.String()),
+		zap.String("userID", userID.String()),
+		zap.Int("recentlyViewed", recentlyViewed))
+	return &MergeResult{RecentlyViewed: recentlyViewed}, nil
+}
+
+func (s *ServiceImplementation) ttl() time.Duration {
+	if s.cfg.AnonymousSessionTTL <= 0 {
+		return defaultSessionTTL
+	}
+	return s.cfg.AnonymousSessionTTL
+}
+
+// hashToken returns the hex SHA-256 digest stored in place of the plaintext token.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
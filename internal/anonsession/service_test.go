@@ -0,0 +1,123 @@
+package anonsession
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryRepository keeps sessions in memory, keyed by token hash.
+type memoryRepository struct {
+	Repository
+	sessions map[string]*Session
+	deleted  []uuid.UUID
+}
+
+func (r *memoryRepository) Create(_ context.Context, session *Session) error {
+	r.sessions[session.TokenHash] = session
+	return nil
+}
+
+func (r *memoryRepository) FindByTokenHash(_ context.Context, tokenHash string) (*Session, error) {
+	session, ok := r.sessions[tokenHash]
+	if !ok {
+		return nil, common.ErrNotFound.WithDetails("Anonymous session not found.")
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (r *memoryRepository) Extend(_ context.Context, id uuid.UUID, expiresAt time.Time) error {
+	for _, session := range r.sessions {
+		if session.ID == id {
+			session.ExpiresAt = expiresAt
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepository) Delete(_ context.Context, id uuid.UUID) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+func (r *memoryRepository) DeleteExpired(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+// fakeListings records merges of recently viewed listings.
+type fakeListings struct {
+	listing.Service
+	sessionID, userID uuid.UUID
+}
+
+func (f *fakeListings) MergeAnonymousRecentlyViewed(_ context.Context, sessionID, userID uuid.UUID) (int, error) {
+	f.sessionID, f.userID = sessionID, userID
+	return 3, nil
+}
+
+func newTestService(now time.Time) (*ServiceImplementation, *memoryRepository, *fakeListings) {
+	repo := &memoryRepository{sessions: map[string]*Session{}}
+	listings := &fakeListings{}
+	svc := &ServiceImplementation{
+		repo:           repo,
+		listingService: listings,
+		cfg:            &config.Config{AnonymousSessionTTL: 30 * 24 * time.Hour},
+		logger:         zap.NewNop(),
+		now:            func() time.Time { return now },
+	}
+	return svc, repo, listings
+}
+
+func TestResolveExtendsAndExpiresSessions(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc, repo, _ := newTestService(start)
+
+	session, token, err := svc.CreateSession(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, start.Add(30*24*time.Hour), session.ExpiresAt)
+	assert.NotContains(t, repo.sessions, token, "only the hash of the token is stored")
+
+	_, err = svc.Resolve(context.Background(), "not-a-token")
+	assert.ErrorIs(t, err, common.ErrUnauthorized)
+
+	svc.now = func() time.Time { return start.Add(time.Hour) }
+	resolved, err := svc.Resolve(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, session.ExpiresAt, resolved.ExpiresAt, "expiry is not moved on every request")
+
+	svc.now = func() time.Time { return start.Add(10 * 24 * time.Hour) }
+	resolved, err = svc.Resolve(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, start.Add(40*24*time.Hour), resolved.ExpiresAt, "a session in use is kept alive")
+
+	svc.now = func() time.Time { return start.Add(100 * 24 * time.Hour) }
+	_, err = svc.Resolve(context.Background(), token)
+	assert.ErrorIs(t, err, common.ErrUnauthorized)
+}
+
+func TestMerge(t *testing.T) {
+	svc, repo, listings := newTestService(time.Now())
+	session, token, err := svc.CreateSession(context.Background())
+	require.NoError(t, err)
+	userID := uuid.New()
+
+	result, err := svc.Merge(context.Background(), userID, token)
+	require.NoError(t, err)
+	assert.Equal(t, &MergeResult{RecentlyViewed: 3}, result)
+	assert.Equal(t, session.ID, listings.sessionID)
+	assert.Equal(t, userID, listings.userID)
+	assert.Equal(t, []uuid.UUID{session.ID}, repo.deleted, "the merged session ends")
+
+	_, err = svc.Merge(context.Background(), userID, tokenPrefix+"unknown")
+	assert.ErrorIs(t, err, common.ErrBadRequest, "a bad anonymous token is not an authentication failure")
+}
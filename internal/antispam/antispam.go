@@ -0,0 +1,215 @@
+// File: internal/antispam/antispam.go
+package antispam
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/config"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Rule names, reported to admins as "spam:<rule>" moderation flags.
+const (
+	RuleUserVelocity    = "velocity_user"
+	RuleIPVelocity      = "velocity_ip"
+	RuleLinks           = "links"
+	RuleSharedContact   = "shared_contact"
+	RuleDisposableEmail = "disposable_email"
+)
+
+// Rule weights. A listing is held for review once the sum of the rules it trips reaches the threshold.
+const (
+	userVelocityScore    = 50
+	ipVelocityScore      = 40
+	linksScore           = 30
+	sharedContactScore   = 40
+	disposableEmailScore = 30
+)
+
+// velocityWindow is the period the posting velocity rules look back over.
+const velocityWindow = time.Hour
+
+var linkPattern = regexp.MustCompile(`(?i)\bhttps?://|\bwww\.`)
+
+// Submission is a listing being published, with what is known about who posted it.
+type Submission struct {
+	ListingID    uuid.UUID // Excluded from the velocity counts
+	UserID       uuid.UUID
+	UserEmail    string
+	IP           string
+	Text         string
+	ContactEmail string
+	ContactPhone string
+}
+
+// Hit is a rule that fired for a submission.
+type Hit struct {
+	Rule   string
+	Score  int
+	Detail string
+}
+
+// Result is the outcome of scoring a submission.
+type Result struct {
+	Score   int
+	Hits    []Hit
+	Flagged bool // Score reached the configured threshold
+}
+
+// Reasons returns the fired rules as moderation flags.
+func (r *Result) Reasons() []string {
+	reasons := make([]string, 0, len(r.Hits))
+	for _, h := range r.Hits {
+		reasons = append(reasons, "spam:"+h.Rule)
+	}
+	return reasons
+}
+
+// Scorer rates how likely a listing submission is to be spam.
+type Scorer interface {
+	Score(ctx context.Context, sub Submission) (*Result, error)
+}
+
+// Store provides the history the velocity and shared contact rules are evaluated against.
+type Store interface {
+	CountListingsByUserSince(ctx context.Context, userID uuid.UUID, since time.Time, excludeID uuid.UUID) (int64, error)
+	CountListingsByIPSince(ctx context.Context, ip string, since time.Time, excludeID uuid.UUID) (int64, error)
+	// CountOtherUsersWithContact counts the accounts other than userID with listings using the contact
+	// email (case-insensitive) or phone (digits only). Empty values are not matched.
+	CountOtherUsersWithContact(ctx context.Context, userID uuid.UUID, email, phoneDigits string) (int64, error)
+}
+
+// Limits configures the rules.
+type Limits struct {
+	Threshold           int // 0 never flags
+	UserListingsPerHour int // 0 disables the rule
+	IPListingsPerHour   int // 0 disables the rule
+	MaxLinks            int // 0 disables the rule
+	DisposableDomains   []string
+}
+
+// RuleScorer scores submissions with fixed heuristic and velocity rules.
+// A failing store lookup is logged and its rule skipped, so scoring never blocks posting.
+type RuleScorer struct {
+	store      Store
+	limits     Limits
+	disposable map[string]bool
+	now        func() time.Time
+	logger     *zap.Logger
+}
+
+// NewRuleScorer creates a RuleScorer. The disposable domains in limits extend the built-in list.
+func NewRuleScorer(store Store, limits Limits, logger *zap.Logger) *RuleScorer {
+	disposable := make(map[string]bool, len(builtinDisposableDomains)+len(limits.DisposableDomains))
+	for _, d := range builtinDisposableDomains {
+		disposable[d] = true
+	}
+	for _, d := range limits.DisposableDomains {
+		disposable[strings.ToLower(d)] = true
+	}
+	return &RuleScorer{store: store, limits: limits, disposable: disposable, now: time.Now, logger: logger}
+}
+
+// NewScorer builds the listing spam scorer from configuration.
+func NewScorer(db *gorm.DB, cfg *config.Config, logger *zap.Logger) Scorer {
+	limits := Limits{
+		Threshold:           cfg.AntispamThreshold,
+		UserListingsPerHour: cfg.AntispamUserListingsPerHour,
+		IPListingsPerHour:   cfg.AntispamIPListingsPerHour,
+		MaxLinks:            cfg.AntispamMaxLinks,
+		DisposableDomains:   splitList(cfg.AntispamDisposableDomains),
+	}
+	return NewRuleScorer(NewGORMStore(db), limits, logger.Named("Antispam"))
+}
+
+// Score implements Scorer.
+func (s *RuleScorer) Score(ctx context.Context, sub Submission) (*Result, error) {
+	result := &Result{}
+	hit := func(rule string, score int, detail string) {
+		result.Hits = append(result.Hits, Hit{Rule: rule, Score: score, Detail: detail})
+		result.Score += score
+	}
+	since := s.now().Add(-velocityWindow)
+
+	if s.limits.UserListingsPerHour > 0 {
+		count, err := s.store.CountListingsByUserSince(ctx, sub.UserID, since, sub.ListingID)
+		if err != nil {
+			s.logger.Warn("User velocity lookup failed, skipping rule", zap.Error(err))
+		} else if count >= int64(s.limits.UserListingsPerHour) {
+			hit(RuleUserVelocity, userVelocityScore, fmt.Sprintf("%d listings by this account in the last hour", count))
+		}
+	}
+
+	if s.limits.IPListingsPerHour > 0 && sub.IP != "" {
+		count, err := s.store.CountListingsByIPSince(ctx, sub.IP, since, sub.ListingID)
+		if err != nil {
+			s.logger.Warn("IP velocity lookup failed, skipping rule", zap.Error(err))
+		} else if count >= int64(s.limits.IPListingsPerHour) {
+			hit(RuleIPVelocity, ipVelocityScore, fmt.Sprintf("%d listings from %s in the last hour", count, sub.IP))
+		}
+	}
+
+	if s.limits.MaxLinks > 0 {
+		if links := len(linkPattern.FindAllStringIndex(sub.Text, -1)); links > s.limits.MaxLinks {
+			hit(RuleLinks, linksScore, fmt.Sprintf("%d links", links))
+		}
+	}
+
+	email, phone := strings.ToLower(strings.TrimSpace(sub.ContactEmail)), digits(sub.ContactPhone)
+	if email != "" || phone != "" {
+		count, err := s.store.CountOtherUsersWithContact(ctx, sub.UserID, email, phone)
+		if err != nil {
+			s.logger.Warn("Shared contact lookup failed, skipping rule", zap.Error(err))
+		} else if count > 0 {
+			hit(RuleSharedContact, sharedContactScore, fmt.Sprintf("contact details used by %d other accounts", count))
+		}
+	}
+
+	for _, addr := range []string{sub.UserEmail, sub.ContactEmail} {
+		if domain := emailDomain(addr); domain != "" && s.disposable[domain] {
+			hit(RuleDisposableEmail, disposableEmailScore, "disposable email domain "+domain)
+			break
+		}
+	}
+
+	result.Flagged = s.limits.Threshold > 0 && result.Score >= s.limits.Threshold
+	return result, nil
+}
+
+// emailDomain returns the lower-cased domain of an email address, or "".
+func emailDomain(addr string) string {
+	at := strings.LastIndex(addr, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(addr[at+1:]))
+}
+
+// digits strips everything but digits from a phone number.
+func digits(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// splitList parses a comma-separated list, ignoring blanks.
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
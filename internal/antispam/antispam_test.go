@@ -0,0 +1,72 @@
+package antispam
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type stubStore struct {
+	byUser, byIP, sharedContact int64
+	err                         error
+	contactEmail, contactPhone  string
+}
+
+func (s *stubStore) CountListingsByUserSince(context.Context, uuid.UUID, time.Time, uuid.UUID) (int64, error) {
+	return s.byUser, s.err
+}
+
+func (s *stubStore) CountListingsByIPSince(context.Context, string, time.Time, uuid.UUID) (int64, error) {
+	return s.byIP, s.err
+}
+
+func (s *stubStore) CountOtherUsersWithContact(_ context.Context, _ uuid.UUID, email, phone string) (int64, error) {
+	s.contactEmail, s.contactPhone = email, phone
+	return s.sharedContact, s.err
+}
+
+var testLimits = Limits{Threshold: 50, UserListingsPerHour: 5, IPListingsPerHour: 10, MaxLinks: 2, DisposableDomains: []string{"Burner.example"}}
+
+func TestRuleScorerCleanSubmission(t *testing.T) {
+	scorer := NewRuleScorer(&stubStore{byUser: 4, byIP: 9}, testLimits, zap.NewNop())
+
+	res, err := scorer.Score(context.Background(), Submission{UserEmail: "jo@example.com", IP: "203.0.113.7", Text: "Bike, see https://example.com"})
+	require.NoError(t, err)
+	assert.Zero(t, res.Score)
+	assert.False(t, res.Flagged)
+	assert.Empty(t, res.Reasons())
+}
+
+func TestRuleScorerSumsFiredRules(t *testing.T) {
+	store := &stubStore{byUser: 5, byIP: 10, sharedContact: 2}
+	scorer := NewRuleScorer(store, testLimits, zap.NewNop())
+
+	res, err := scorer.Score(context.Background(), Submission{
+		UserEmail:    "jo@burner.example",
+		IP:           "203.0.113.7",
+		Text:         "http://a.example https://b.example www.c.example",
+		ContactEmail: " Jo@Example.com ",
+		ContactPhone: "+1 (206) 555-0100",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"spam:velocity_user", "spam:velocity_ip", "spam:links", "spam:shared_contact", "spam:disposable_email"}, res.Reasons())
+	assert.Equal(t, 190, res.Score)
+	assert.True(t, res.Flagged)
+	assert.Equal(t, "jo@example.com", store.contactEmail)
+	assert.Equal(t, "12065550100", store.contactPhone)
+}
+
+func TestRuleScorerSkipsFailingLookups(t *testing.T) {
+	scorer := NewRuleScorer(&stubStore{byUser: 100, err: errors.New("db down")}, testLimits, zap.NewNop())
+
+	res, err := scorer.Score(context.Background(), Submission{UserEmail: "jo@mailinator.com", IP: "203.0.113.7", ContactPhone: "555"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"spam:disposable_email"}, res.Reasons())
+	assert.False(t, res.Flagged, "a single low-weight rule stays under the threshold")
+}
@@ -0,0 +1,29 @@
+// File: internal/antispam/domains.go
+package antispam
+
+// builtinDisposableDomains are well-known throwaway email providers.
+// ANTISPAM_DISPOSABLE_DOMAINS adds to this list.
+var builtinDisposableDomains = []string{
+	"10minutemail.com",
+	"discard.email",
+	"dispostable.com",
+	"emailondeck.com",
+	"fakeinbox.com",
+	"getairmail.com",
+	"getnada.com",
+	"guerrillamail.com",
+	"guerrillamail.net",
+	"maildrop.cc",
+	"mailinator.com",
+	"mailnesia.com",
+	"mintemail.com",
+	"mohmal.com",
+	"sharklasers.com",
+	"spamgourmet.com",
+	"temp-mail.org",
+	"tempmail.com",
+	"tempmailo.com",
+	"throwawaymail.com",
+	"trashmail.com",
+	"yopmail.com",
+}
@@ -0,0 +1,61 @@
+// File: internal/antispam/store.go
+package antispam
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GORMStore implements Store over the listings table.
+type GORMStore struct {
+	db *gorm.DB
+}
+
+// NewGORMStore creates a new GORM antispam store.
+func NewGORMStore(db *gorm.DB) *GORMStore {
+	return &GORMStore{db: db}
+}
+
+// CountListingsByUserSince counts the listings a user created since the given time.
+func (s *GORMStore) CountListingsByUserSince(ctx context.Context, userID uuid.UUID, since time.Time, excludeID uuid.UUID) (int64, error) {
+	var count int64
+	err := s.db.WithContext(ctx).Table("listings").
+		Where("user_id = ? AND created_at >= ? AND id <> ?", userID, since, excludeID).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recent listings by user: %w", err)
+	}
+	return count, nil
+}
+
+// CountListingsByIPSince counts the listings created from an IP address since the given time.
+func (s *GORMStore) CountListingsByIPSince(ctx context.Context, ip string, since time.Time, excludeID uuid.UUID) (int64, error) {
+	var count int64
+	err := s.db.WithContext(ctx).Table("listings").
+		Where("created_ip = ? AND created_at >= ? AND id <> ?", ip, since, excludeID).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recent listings by IP: %w", err)
+	}
+	return count, nil
+}
+
+// CountOtherUsersWithContact counts the other accounts whose listings share the contact email or phone.
+func (s *GORMStore) CountOtherUsersWithContact(ctx context.Context, userID uuid.UUID, email, phoneDigits string) (int64, error) {
+	var count int64
+	err := s.db.WithContext(ctx).Table("listings").
+		Where("user_id <> ?", userID).
+		Where(s.db.
+			Where("? <> '' AND LOWER(contact_email) = ?", email, email).
+			Or("? <> '' AND regexp_replace(contact_phone, '\\D', '', 'g') = ?", phoneDigits, phoneDigits)).
+		Distinct("user_id").
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count accounts sharing contact details: %w", err)
+	}
+	return count, nil
+}
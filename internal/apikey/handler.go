@@ -0,0 +1,136 @@
+// File: internal/apikey/handler.go
+package apikey
+
+import (
+	"strconv"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// defaultUsageDays is how many days of usage are returned when ?days is not given.
+const defaultUsageDays = 30
+
+// Handler struct holds dependencies for API key admin handlers.
+type Handler struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewHandler creates a new API key handler.
+func NewHandler(service Service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes sets up the admin-only routes for managing partner API keys.
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMW gin.HandlerFunc, adminRoleMW gin.HandlerFunc) {
+	adminKeyGroup := router.Group("/api-keys/admin")
+	adminKeyGroup.Use(authMW)
+	adminKeyGroup.Use(adminRoleMW)
+	{
+		adminKeyGroup.POST("", h.adminIssueKey)
+		adminKeyGroup.GET("", h.adminListKeys)
+		adminKeyGroup.GET("/:id", h.adminGetKey)
+		adminKeyGroup.DELETE("/:id", h.adminRevokeKey)
+		adminKeyGroup.GET("/:id/usage", h.adminGetUsage)
+	}
+}
+
+func (h *Handler) adminIssueKey(c *gin.Context) {
+	adminID := common.GetUserIDFromContext(c)
+	if adminID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	var req AdminCreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Admin issue API key: Invalid request body", zap.Error(err))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	key, plaintext, err := h.service.IssueKey(c.Request.Context(), adminID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondCreated(c, "API key issued successfully. Store the key now; it cannot be shown again.", CreatedAPIKeyResponse{
+		APIKeyResponse: ToAPIKeyResponse(key),
+		Key:            plaintext,
+	})
+}
+
+func (h *Handler) adminListKeys(c *gin.Context) {
+	page, pageSize := common.GetPaginationParams(c)
+	keys, pagination, err := h.service.ListKeys(c.Request.Context(), page, pageSize)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]APIKeyResponse, len(keys))
+	for i := range keys {
+		responses[i] = ToAPIKeyResponse(&keys[i])
+	}
+	common.RespondPaginated(c, "API keys retrieved successfully.", responses, pagination)
+}
+
+func (h *Handler) adminGetKey(c *gin.Context) {
+	keyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid API key ID format."))
+		return
+	}
+	key, err := h.service.GetKey(c.Request.Context(), keyID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "API key retrieved successfully.", ToAPIKeyResponse(key))
+}
+
+func (h *Handler) adminRevokeKey(c *gin.Context) {
+	keyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid API key ID format."))
+		return
+	}
+	if err := h.service.RevokeKey(c.Request.Context(), keyID); err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondNoContent(c)
+}
+
+func (h *Handler) adminGetUsage(c *gin.Context) {
+	keyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid API key ID format."))
+		return
+	}
+	days := defaultUsageDays
+	if raw := c.Query("days"); raw != "" {
+		days, err = strconv.Atoi(raw)
+		if err != nil || days < 1 || days > 365 {
+			common.RespondWithError(c, common.ErrBadRequest.WithDetails("days must be an integer between 1 and 365."))
+			return
+		}
+	}
+
+	usage, err := h.service.GetUsage(c.Request.Context(), keyID, days)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]UsageResponse, len(usage))
+	for i := range usage {
+		responses[i] = ToUsageResponse(&usage[i])
+	}
+	common.RespondOK(c, "API key usage retrieved successfully.", responses)
+}
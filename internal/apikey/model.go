@@ -0,0 +1,127 @@
+// File: internal/apikey/model.go
+package apikey
+
+import (
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Scopes an API key can be granted.
+const (
+	ScopeListingsRead    = "listings:read"    // Read public listings
+	ScopeListingsFeature = "listings:feature" // Feature listings, e.g. after a customer pays for it
+)
+
+// validScopes lists every scope an admin may grant.
+var validScopes = map[string]bool{
+	ScopeListingsRead:    true,
+	ScopeListingsFeature: true,
+}
+
+// APIKey is a credential issued to an external partner. Only the SHA-256 hash of the key is stored.
+type APIKey struct {
+	common.BaseModel
+	Name               string         `gorm:"type:varchar(150);not null"`
+	KeyPrefix          string         `gorm:"type:varchar(16);not null"`
+	KeyHash            string         `gorm:"type:varchar(64);not null;uniqueIndex"`
+	Scopes             pq.StringArray `gorm:"type:text[];not null"`
+	RateLimitPerMinute int            `gorm:"not null;default:60"`
+	CreatedBy          *uuid.UUID     `gorm:"type:uuid"`
+	LastUsedAt         *time.Time
+	RevokedAt          *time.Time
+}
+
+// TableName specifies the table name for GORM.
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// HasScope reports whether the key was granted scope.
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRevoked reports whether the key has been revoked.
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// Usage is the number of requests made with a key on a given day.
+type Usage struct {
+	APIKeyID     uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UsageDate    time.Time `gorm:"type:date;primaryKey"`
+	RequestCount int64     `gorm:"not null;default:0"`
+}
+
+// TableName specifies the table name for GORM.
+func (Usage) TableName() string {
+	return "api_key_usage"
+}
+
+// --- Request DTOs ---
+
+// AdminCreateAPIKeyRequest is the payload for issuing a new key.
+type AdminCreateAPIKeyRequest struct {
+	Name               string   `json:"name" binding:"required,max=150"`
+	Scopes             []string `json:"scopes" binding:"required,min=1,dive,required"`
+	RateLimitPerMinute *int     `json:"rate_limit_per_minute,omitempty" binding:"omitempty,min=1,max=10000"`
+}
+
+// --- Response DTOs ---
+
+// APIKeyResponse is the API representation of a key. The plaintext key is never included.
+type APIKeyResponse struct {
+	ID                 uuid.UUID  `json:"id"`
+	Name               string     `json:"name"`
+	KeyPrefix          string     `json:"key_prefix"`
+	Scopes             []string   `json:"scopes"`
+	RateLimitPerMinute int        `json:"rate_limit_per_minute"`
+	CreatedBy          *uuid.UUID `json:"created_by,omitempty"`
+	LastUsedAt         *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt          *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+// CreatedAPIKeyResponse is returned once, when a key is issued, and carries the plaintext key.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// UsageResponse is the API representation of a day's usage for a key.
+type UsageResponse struct {
+	Date         string `json:"date"`
+	RequestCount int64  `json:"request_count"`
+}
+
+// ToAPIKeyResponse converts an APIKey model to an APIKeyResponse DTO.
+func ToAPIKeyResponse(k *APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:                 k.ID,
+		Name:               k.Name,
+		KeyPrefix:          k.KeyPrefix,
+		Scopes:             k.Scopes,
+		RateLimitPerMinute: k.RateLimitPerMinute,
+		CreatedBy:          k.CreatedBy,
+		LastUsedAt:         k.LastUsedAt,
+		RevokedAt:          k.RevokedAt,
+		CreatedAt:          k.CreatedAt,
+	}
+}
+
+// ToUsageResponse converts a Usage model to a UsageResponse DTO.
+func ToUsageResponse(u *Usage) UsageResponse {
+	return UsageResponse{
+		Date:         u.UsageDate.Format("2006-01-02"),
+		RequestCount: u.RequestCount,
+	}
+}
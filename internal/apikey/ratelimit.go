@@ -0,0 +1,49 @@
+// File: internal/apikey/ratelimit.go
+package apikey
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// rateLimiter is an in-memory fixed-window limiter keyed by API key ID.
+// Limits are per process; a multi-instance deployment gets limit*instances in total.
+type rateLimiter struct {
+	mu      sync.Mutex
+	window  time.Duration
+	windows map[uuid.UUID]*rateWindow
+	now     func() time.Time
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+func newRateLimiter(window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		window:  window,
+		windows: make(map[uuid.UUID]*rateWindow),
+		now:     time.Now,
+	}
+}
+
+// Allow records a request for id and reports whether it is within limit for the current window.
+func (l *rateLimiter) Allow(id uuid.UUID, limit int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	w, ok := l.windows[id]
+	if !ok || now.Sub(w.start) >= l.window {
+		l.windows[id] = &rateWindow{start: now, count: 1}
+		return true
+	}
+	if w.count >= limit {
+		return false
+	}
+	w.count++
+	return true
+}
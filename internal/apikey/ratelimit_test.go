@@ -0,0 +1,31 @@
+package apikey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterFixedWindow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := newRateLimiter(time.Minute)
+	l.now = func() time.Time { return now }
+
+	keyA, keyB := uuid.New(), uuid.New()
+	assert.True(t, l.Allow(keyA, 2))
+	assert.True(t, l.Allow(keyA, 2))
+	assert.False(t, l.Allow(keyA, 2), "third request in the window exceeds the limit")
+	assert.True(t, l.Allow(keyB, 2), "limits are tracked per key")
+
+	now = now.Add(time.Minute)
+	assert.True(t, l.Allow(keyA, 2), "a new window resets the count")
+}
+
+func TestHashKeyIsStableAndHidesPlaintext(t *testing.T) {
+	h := hashKey("sik_example")
+	assert.Len(t, h, 64)
+	assert.Equal(t, h, hashKey("sik_example"))
+	assert.NotContains(t, h, "example")
+}
@@ -0,0 +1,125 @@
+// File: internal/apikey/repository.go
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository defines the interface for API key data operations.
+type Repository interface {
+	Create(ctx context.Context, key *APIKey) error
+	FindByID(ctx context.Context, id uuid.UUID) (*APIKey, error)
+	FindByHash(ctx context.Context, hash string) (*APIKey, error)
+	FindAll(ctx context.Context, page, pageSize int) ([]APIKey, *common.Pagination, error)
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+	RecordUsage(ctx context.Context, id uuid.UUID, at time.Time) error
+	FindUsage(ctx context.Context, id uuid.UUID, since time.Time) ([]Usage, error)
+}
+
+// GORMRepository implements the API key Repository interface using GORM.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+// NewGORMRepository creates a new GORM API key repository.
+func NewGORMRepository(db *gorm.DB) Repository {
+	return &GORMRepository{db: db}
+}
+
+// Create inserts a new API key.
+func (r *GORMRepository) Create(ctx context.Context, key *APIKey) error {
+	if err := r.db.WithContext(ctx).Create(key).Error; err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+	return nil
+}
+
+// FindByID retrieves an API key by its ID.
+func (r *GORMRepository) FindByID(ctx context.Context, id uuid.UUID) (*APIKey, error) {
+	var key APIKey
+	if err := r.db.WithContext(ctx).First(&key, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("API key not found.")
+		}
+		return nil, fmt.Errorf("failed to find api key: %w", err)
+	}
+	return &key, nil
+}
+
+// FindByHash retrieves an API key by the hash of its plaintext value.
+func (r *GORMRepository) FindByHash(ctx context.Context, hash string) (*APIKey, error) {
+	var key APIKey
+	if err := r.db.WithContext(ctx).First(&key, "key_hash = ?", hash).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("API key not found.")
+		}
+		return nil, fmt.Errorf("failed to find api key: %w", err)
+	}
+	return &key, nil
+}
+
+// FindAll retrieves all API keys, newest first.
+func (r *GORMRepository) FindAll(ctx context.Context, page, pageSize int) ([]APIKey, *common.Pagination, error) {
+	var keys []APIKey
+	var totalItems int64
+
+	dbQuery := r.db.WithContext(ctx).Model(&APIKey{})
+	if err := dbQuery.Count(&totalItems).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to count api keys: %w", err)
+	}
+
+	offset := (page - 1) * pageSize
+	if err := dbQuery.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&keys).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return keys, common.NewPagination(totalItems, page, pageSize), nil
+}
+
+// Revoke marks an API key as revoked. Revoking an already revoked key is a no-op.
+func (r *GORMRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	result := r.db.WithContext(ctx).Model(&APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke api key: %w", result.Error)
+	}
+	return nil
+}
+
+// RecordUsage bumps the key's request counter for the day and its last-used time.
+func (r *GORMRepository) RecordUsage(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		usage := Usage{APIKeyID: id, UsageDate: at.UTC().Truncate(24 * time.Hour), RequestCount: 1}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "api_key_id"}, {Name: "usage_date"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"request_count": gorm.Expr("api_key_usage.request_count + 1")}),
+		}).Create(&usage).Error; err != nil {
+			return fmt.Errorf("failed to record api key usage: %w", err)
+		}
+		if err := tx.Model(&APIKey{}).Where("id = ?", id).UpdateColumn("last_used_at", at).Error; err != nil {
+			return fmt.Errorf("failed to update api key last used time: %w", err)
+		}
+		return nil
+	})
+}
+
+// FindUsage retrieves daily usage for a key since the given day, newest first.
+func (r *GORMRepository) FindUsage(ctx context.Context, id uuid.UUID, since time.Time) ([]Usage, error) {
+	var usage []Usage
+	if err := r.db.WithContext(ctx).
+		Where("api_key_id = ? AND usage_date >= ?", id, since).
+		Order("usage_date DESC").
+		Find(&usage).Error; err != nil {
+		return nil, fmt.Errorf("failed to find api key usage: %w", err)
+	}
+	return usage, nil
+}
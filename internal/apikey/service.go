@@ -0,0 +1,186 @@
+// File: internal/apikey/service.go
+package apikey
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/platform/crypto"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// keyPrefix marks plaintext keys so they are recognisable in logs and secret scanners.
+	keyPrefix = "sik_"
+	// displayPrefixLen is how much of the plaintext key is kept for identification.
+	displayPrefixLen = 12
+)
+
+// Service defines the interface for API key management and authentication.
+type Service interface {
+	// Admin methods
+	IssueKey(ctx context.Context, adminID uuid.UUID, req AdminCreateAPIKeyRequest) (*APIKey, string, error)
+	ListKeys(ctx context.Context, page, pageSize int) ([]APIKey, *common.Pagination, error)
+	GetKey(ctx context.Context, id uuid.UUID) (*APIKey, error)
+	RevokeKey(ctx context.Context, id uuid.UUID) error
+	GetUsage(ctx context.Context, id uuid.UUID, days int) ([]Usage, error)
+
+	// Authentication (used by middleware)
+	Authenticate(ctx context.Context, plaintext string) (*APIKey, error)
+	AllowRequest(key *APIKey) bool
+	RecordUsage(ctx context.Context, key *APIKey)
+}
+
+// ServiceImplementation implements the API key Service interface.
+type ServiceImplementation struct {
+	repo    Repository
+	cfg     *config.Config
+	logger  *zap.Logger
+	limiter *rateLimiter
+}
+
+// NewService creates a new API key service.
+func NewService(repo Repository, cfg *config.Config, logger *zap.Logger) Service {
+	return &ServiceImplementation{
+		repo:    repo,
+		cfg:     cfg,
+		logger:  logger,
+		limiter: newRateLimiter(time.Minute),
+	}
+}
+
+// IssueKey creates a new key and returns it together with its plaintext value, which is not retrievable later.
+func (s *ServiceImplementation) IssueKey(ctx context.Context, adminID uuid.UUID, req AdminCreateAPIKeyRequest) (*APIKey, string, error) {
+	for _, scope := range req.Scopes {
+		if !validScopes[scope] {
+			return nil, "", common.ErrBadRequest.WithDetails(fmt.Sprintf("Unknown scope '%s'.", scope))
+		}
+	}
+
+	random, err := crypto.GenerateSecureRandomString(32)
+	if err != nil {
+		s.logger.Error("Failed to generate API key", zap.Error(err))
+		return nil, "", common.ErrInternalServer.WithDetails("Could not generate API key.")
+	}
+	plaintext := keyPrefix + strings.TrimRight(random, "=")
+
+	rateLimit := s.cfg.APIKeyDefaultRateLimitPerMinute
+	if req.RateLimitPerMinute != nil {
+		rateLimit = *req.RateLimitPerMinute
+	}
+
+	key := &APIKey{
+		Name:               strings.TrimSpace(req.Name),
+		KeyPrefix:          plaintext[:displayPrefixLen],
+		KeyHash:            hashKey(plaintext),
+		Scopes:             req.Scopes,
+		RateLimitPerMinute: rateLimit,
+		CreatedBy:          &adminID,
+	}
+	if err := s.repo.Create(ctx, key); err != nil {
+		s.logger.Error("Failed to create API key", zap.Error(err))
+		return nil, "", common.ErrInternalServer.WithDetails("Could not create API key.")
+	}
+
+	s.logger.Info("API key issued",
+		zap.String("apiKeyID", key.ID.String()),
+		zap.String("name", key.Name),
+		zap.Strings("scopes", key.Scopes),
+		zap.String("adminID", adminID.String()))
+	return key, plaintext, nil
+}
+
+// ListKeys returns all issued keys.
+func (s *ServiceImplementation) ListKeys(ctx context.Context, page, pageSize int) ([]APIKey, *common.Pagination, error) {
+	keys, pagination, err := s.repo.FindAll(ctx, page, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to list API keys", zap.Error(err))
+		return nil, nil, common.ErrInternalServer.WithDetails("Could not retrieve API keys.")
+	}
+	return keys, pagination, nil
+}
+
+// GetKey returns a single key.
+func (s *ServiceImplementation) GetKey(ctx context.Context, id uuid.UUID) (*APIKey, error) {
+	key, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to get API key", zap.Error(err), zap.String("apiKeyID", id.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve API key.")
+	}
+	return key, nil
+}
+
+// RevokeKey permanently disables a key.
+func (s *ServiceImplementation) RevokeKey(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetKey(ctx, id); err != nil {
+		return err
+	}
+	if err := s.repo.Revoke(ctx, id, time.Now()); err != nil {
+		s.logger.Error("Failed to revoke API key", zap.Error(err), zap.String("apiKeyID", id.String()))
+		return common.ErrInternalServer.WithDetails("Could not revoke API key.")
+	}
+	s.logger.Info("API key revoked", zap.String("apiKeyID", id.String()))
+	return nil
+}
+
+// GetUsage returns daily request counts for a key over the last days days.
+func (s *ServiceImplementation) GetUsage(ctx context.Context, id uuid.UUID, days int) ([]Usage, error) {
+	if _, err := s.GetKey(ctx, id); err != nil {
+		return nil, err
+	}
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -(days - 1))
+	usage, err := s.repo.FindUsage(ctx, id, since)
+	if err != nil {
+		s.logger.Error("Failed to get API key usage", zap.Error(err), zap.String("apiKeyID", id.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve API key usage.")
+	}
+	return usage, nil
+}
+
+// Authenticate resolves a plaintext key to an active APIKey.
+func (s *ServiceImplementation) Authenticate(ctx context.Context, plaintext string) (*APIKey, error) {
+	if !strings.HasPrefix(plaintext, keyPrefix) {
+		return nil, common.ErrUnauthorized.WithDetails("Invalid API key.")
+	}
+	key, err := s.repo.FindByHash(ctx, hashKey(plaintext))
+	if err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return nil, common.ErrUnauthorized.WithDetails("Invalid API key.")
+		}
+		s.logger.Error("Failed to look up API key", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not verify API key.")
+	}
+	if key.IsRevoked() {
+		return nil, common.ErrUnauthorized.WithDetails("API key has been revoked.")
+	}
+	return key, nil
+}
+
+// AllowRequest applies the key's per-minute rate limit.
+func (s *ServiceImplementation) AllowRequest(key *APIKey) bool {
+	return s.limiter.Allow(key.ID, key.RateLimitPerMinute)
+}
+
+// RecordUsage counts a request against the key. Failures are logged, not surfaced to the caller.
+func (s *ServiceImplementation) RecordUsage(ctx context.Context, key *APIKey) {
+	if err := s.repo.RecordUsage(ctx, key.ID, time.Now()); err != nil {
+		s.logger.Error("Failed to record API key usage", zap.Error(err), zap.String("apiKeyID", key.ID.String()))
+	}
+}
+
+// hashKey returns the hex SHA-256 digest stored in place of the plaintext key.
+func hashKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,50 @@
+// File: internal/app/log_level.go
+package app
+
+import (
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/platform/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// LogLevelRequest is the body of PUT /admin/log-level.
+type LogLevelRequest struct {
+	Level string `json:"level" binding:"required,oneof=debug info warn warning error dpanic panic fatal"`
+}
+
+// LogLevelResponse describes the current level of the application logger.
+type LogLevelResponse struct {
+	Level string `json:"level"`
+}
+
+// registerLogLevelRoutes adds GET and PUT /log-level to the admin group. The level is changed for this process
+// only and lasts until it restarts, when LOG_LEVEL applies again.
+func registerLogLevelRoutes(adminAPIs *gin.RouterGroup, level zap.AtomicLevel, log *zap.Logger) {
+	adminAPIs.GET("/log-level", func(c *gin.Context) {
+		common.RespondOK(c, "Log level retrieved successfully.", LogLevelResponse{Level: level.Level().String()})
+	})
+	adminAPIs.PUT("/log-level", func(c *gin.Context) {
+		var req LogLevelRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			common.RespondWithError(c, common.BindingError(err))
+			return
+		}
+		newLevel, err := logger.ParseLevel(req.Level)
+		if err != nil {
+			common.RespondWithError(c, common.ErrBadRequest.WithDetails(err.Error()))
+			return
+		}
+
+		previous := level.Level()
+		level.SetLevel(newLevel)
+		// Logged at warn so the change is recorded under any level but error and above.
+		log.Warn("Log level changed",
+			zap.String("from", previous.String()),
+			zap.String("to", newLevel.String()),
+			zap.String("adminID", common.GetUserIDFromContext(c).String()),
+		)
+		common.RespondOK(c, "Log level updated successfully.", LogLevelResponse{Level: newLevel.String()})
+	})
+}
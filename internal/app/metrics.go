@@ -0,0 +1,52 @@
+// File: internal/app/metrics.go
+package app
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// metricsContentType is the Prometheus text exposition format.
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// registerMetricsRoute adds GET /metrics for Prometheus. The gauges are read from the database on each scrape, so
+// they are current whichever process runs the jobs. With METRICS_TOKEN set, scrapers must send it as a bearer token.
+func registerMetricsRoute(router *gin.Engine, listingService listing.Service, cfg *config.Config, log *zap.Logger) {
+	router.GET("/metrics", func(c *gin.Context) {
+		if cfg.MetricsToken != "" {
+			token := strings.TrimPrefix(c.GetHeader(common.AuthorizationHeader), common.AuthorizationTypeBearer+" ")
+			if subtle.ConstantTimeCompare([]byte(token), []byte(cfg.MetricsToken)) != 1 {
+				common.RespondWithError(c, common.ErrUnauthorized.WithDetails("A valid metrics token is required."))
+				return
+			}
+		}
+
+		backlog, err := listingService.GetApprovalBacklog(c.Request.Context())
+		if err != nil {
+			log.Error("Failed to collect metrics", zap.Error(err))
+			common.RespondWithError(c, err)
+			return
+		}
+
+		var b strings.Builder
+		writeGauge(&b, "seattle_info_approval_backlog_listings", "Listings waiting in pending_approval.", float64(backlog.Pending))
+		writeGauge(&b, "seattle_info_approval_backlog_oldest_age_seconds", "How long the longest-waiting listing has been in pending_approval; 0 when none are.", backlog.OldestAge(time.Now()).Seconds())
+		writeGauge(&b, "seattle_info_approval_sla_seconds", "APPROVAL_SLA_HOURS in seconds; 0 when alerts are disabled.", cfg.ApprovalSLA.Seconds())
+		c.Data(http.StatusOK, metricsContentType, []byte(b.String()))
+	})
+}
+
+// writeGauge writes one gauge sample with its HELP and TYPE lines.
+func writeGauge(b *strings.Builder, name, help string, value float64) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s gauge\n%s %g\n", name, help, name, name, value)
+}
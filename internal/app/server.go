@@ -7,21 +7,43 @@ import (
 	"net/http"
 	"time"
 
+	"seattle_info_backend/internal/abuse"
+	"seattle_info_backend/internal/anonsession"
+	"seattle_info_backend/internal/apikey"
+	"seattle_info_backend/internal/appconfig"
+	"seattle_info_backend/internal/audit"
 	"seattle_info_backend/internal/auth"
+	"seattle_info_backend/internal/captcha"
 	// "seattle_info_backend/internal/auth" // Duplicate import removed
 	"seattle_info_backend/internal/category"
 	"seattle_info_backend/internal/common" // Added for common.RoleAdmin
 	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/filestorage"
 	"seattle_info_backend/internal/firebase"
+	"seattle_info_backend/internal/grpcapi"
+	"seattle_info_backend/internal/jobrun"
 	"seattle_info_backend/internal/jobs"
 	"seattle_info_backend/internal/listing"
+	"seattle_info_backend/internal/listingimport"
+	"seattle_info_backend/internal/listingtemplate"
+	"seattle_info_backend/internal/messaging"
 	"seattle_info_backend/internal/middleware"
 	"seattle_info_backend/internal/notification" // Add this
+	"seattle_info_backend/internal/overview"
+	"seattle_info_backend/internal/payments"
+	"seattle_info_backend/internal/platform/database"
+	"seattle_info_backend/internal/queue"
+	"seattle_info_backend/internal/savedsearch"
 	"seattle_info_backend/internal/shared"
+	"seattle_info_backend/internal/twofactor"
 	"seattle_info_backend/internal/user"
+	"seattle_info_backend/internal/verification"
+	"seattle_info_backend/internal/webhook"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"go.uber.org/zap"
 	"gorm.io/gorm" // Added for db *gorm.DB parameter
 )
@@ -41,9 +63,27 @@ type Server struct {
 	categoryHandler     *category.Handler
 	listingHandler      *listing.Handler
 	notificationHandler *notification.Handler // Add this
+	savedSearchHandler  *savedsearch.Handler
+	appConfigHandler    *appconfig.Handler
+	apiKeyHandler       *apikey.Handler
+	webhookHandler      *webhook.Handler
+	messagingHandler    *messaging.Handler
+	auditHandler        *audit.Handler
+	verificationHandler *verification.Handler
+	queueHandler        *queue.Handler
+	importHandler       *listingimport.Handler
+	templateHandler     *listingtemplate.Handler
+	anonSessionHandler  *anonsession.Handler
+	twoFactorHandler    *twofactor.Handler
+	paymentsHandler     *payments.Handler
+	abuseHandler        *abuse.Handler
 
 	// Jobs
-	listingExpiryJob *jobs.ListingExpiryJob
+	worker              *Worker // Runs only when RUN_JOBS_IN_API is true
+	trendingListingsJob *jobs.TrendingListingsJob
+
+	// Internal gRPC API; nil when GRPC_ENABLED is false.
+	grpcServer *grpcapi.Server
 
 	// Middleware instances
 	authMW      gin.HandlerFunc
@@ -59,17 +99,52 @@ func NewServer(
 	categoryHandler *category.Handler,
 	listingHandler *listing.Handler,
 	notificationHandler *notification.Handler, // Add this
-	listingExpiryJob *jobs.ListingExpiryJob,
+	savedSearchHandler *savedsearch.Handler,
+	appConfigHandler *appconfig.Handler,
+	apiKeyHandler *apikey.Handler,
+	webhookHandler *webhook.Handler,
+	messagingHandler *messaging.Handler,
+	auditHandler *audit.Handler,
+	verificationHandler *verification.Handler,
+	queueHandler *queue.Handler,
+	importHandler *listingimport.Handler,
+	templateHandler *listingtemplate.Handler,
+	anonSessionHandler *anonsession.Handler,
+	twoFactorHandler *twofactor.Handler,
+	paymentsHandler *payments.Handler,
+	abuseHandler *abuse.Handler,
+	imageHandler *filestorage.Handler,
+	overviewHandler *overview.Handler,
+	jobRunHandler *jobrun.Handler,
+	listingService listing.Service,
+	worker *Worker,
+	trendingListingsJob *jobs.TrendingListingsJob,
+	grpcServer *grpcapi.Server,
 	db *gorm.DB, // Added db *gorm.DB
 	firebaseService *firebase.FirebaseService,
 	userService shared.Service,
 	blocklistService auth.TokenBlocklistService, // Add blocklist service
+	apiKeyService apikey.Service,
+	abuseService abuse.Service,
+	anonSessionService anonsession.Service,
+	twoFactorService twofactor.Service,
+	captchaGuard *captcha.Guard,
+	appConfigService appconfig.Service,
+	logLevel zap.AtomicLevel,
 ) (*Server, error) {
 	gin.SetMode(cfg.GinMode)
+	// Validation errors name fields as clients send them
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		common.RegisterJSONFieldNames(v)
+	}
 	router := gin.New()
+	router.MaxMultipartMemory = cfg.MultipartMemoryBytes // Larger files are streamed to temporary files
 
 	// --- Global Middleware ---
 	router.Use(middleware.ZapLogger(logger, cfg))
+	router.Use(middleware.LanguageMiddleware())
+	router.Use(middleware.ClientInfoMiddleware())
+	router.Use(middleware.IPBanMiddleware(abuseService, logger.Named("IPBanMiddleware")))
 	router.Use(middleware.ErrorHandler(logger))
 	router.Use(gin.Recovery())
 
@@ -77,32 +152,38 @@ func NewServer(
 	corsConfig := cors.DefaultConfig()
 	corsConfig.AllowOrigins = []string{"*"}
 	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
-	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", middleware.RequestIDHeader}
+	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", middleware.RequestIDHeader, middleware.APIKeyHeader, middleware.AcceptLanguageHeader, middleware.AnonymousSessionHeader, middleware.TwoFactorTokenHeader}
 	corsConfig.AllowCredentials = true
-	corsConfig.ExposeHeaders = []string{"Content-Length", middleware.RequestIDHeader}
+	corsConfig.ExposeHeaders = []string{"Content-Length", middleware.RequestIDHeader, middleware.ContentLanguageHeader}
 	router.Use(cors.New(corsConfig))
+	// Bodies over MAX_REQUEST_BODY_BYTES are refused; upload routes raise the limit for themselves
+	router.Use(middleware.BodyLimitMiddleware(cfg.MaxRequestBodyBytes))
 
 	// Serve static files (e.g., uploaded images)
-	// The path cfg.ImageStoragePath (e.g. "./images") will be the root for "/static"
-	// So, a request to "/static/listings/foo.jpg" would serve "./images/listings/foo.jpg"
-	// If ImageStoragePath is "./uploads", then "/static/images/listings/foo.jpg" serves "./uploads/images/listings/foo.jpg"
-	// Based on user's desire for URL /static/images/listings/:filename and storage path being the root of "images/listings"
-	// We serve cfg.ImageStoragePath as the root of /static.
-	// If cfg.ImageStoragePath = "./images", then GET /static/listings/file.jpg serves ./images/listings/file.jpg
-	// To achieve /static/images/listings/filename, and assuming ImageStoragePath is something like "./data_storage",
-	// and files are in "./data_storage/images/listings/", then router.StaticFS("/static", http.Dir(cfg.ImageStoragePath)) is correct.
-	// A request to /static/images/listings/foo.jpg will look for cfg.ImageStoragePath + "/images/listings/foo.jpg".
-	router.StaticFS("/static", http.Dir(cfg.ImageStoragePath))
+	// cfg.ImageStoragePath is the root for "/static": with "./images", GET /static/listings/foo.jpg serves ./images/listings/foo.jpg.
+	// imageHandler confines requests to that root, checks signed URLs and sets CDN-friendly cache headers.
+	imageHandler.RegisterRoutes(router.Group("/static"))
 	logger.Info("Serving static files", zap.String("url_prefix", "/static"), zap.String("filesystem_root", cfg.ImageStoragePath))
 
 	// Create middleware instances
-	authMW := middleware.AuthMiddleware(firebaseService, userService, blocklistService, logger.Named("AuthMiddleware"))
-	adminRoleMW := middleware.RoleAuthMiddleware(common.RoleAdmin) // Use common.RoleAdmin
+	authMW := middleware.AuthMiddleware(firebaseService, userService, blocklistService, abuseService, logger.Named("AuthMiddleware"))
+	// Maintenance mode applies to every write under the API; routes below see the user it authenticated.
+	authenticate := middleware.NewAuthenticator(firebaseService, userService, blocklistService, abuseService, logger.Named("AuthMiddleware"))
+	router.Use(middleware.MaintenanceMiddleware(appConfigService, authenticate, logger.Named("MaintenanceMiddleware")))
+	// Public browse routes: signed-in callers are authenticated, anonymous ones get trimmed responses and a per-IP quota
+	browseMW := middleware.AnonymousBrowseMiddleware(authMW, cfg.AnonymousRequestsPerMinute, logger.Named("AnonymousBrowseMiddleware"))
+	anonSessionMW := middleware.AnonymousSessionMiddleware(anonSessionService, logger.Named("AnonymousSessionMiddleware"))
+	requireAnonSessionMW := middleware.RequireAnonymousSessionMiddleware(anonSessionService, logger.Named("AnonymousSessionMiddleware"))
+	// Admin routes also require the admin's second factor, once they have enabled 2FA (or always with ADMIN_2FA_REQUIRED)
+	adminRoleMW := middleware.AdminAuthMiddleware(twoFactorService, logger.Named("AdminAuthMiddleware"))
+	listingCaptchaMW := middleware.CaptchaMiddleware(captchaGuard, captcha.ActionPublishListing, logger.Named("CaptchaMiddleware"))
+	contactCaptchaMW := middleware.CaptchaMiddleware(captchaGuard, captcha.ActionContact, logger.Named("CaptchaMiddleware"))
 
 	// --- Setup Routes ---
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "UP", "message": "Seattle Info API is healthy!"})
 	})
+	registerMetricsRoute(router, listingService, cfg, logger.Named("Metrics"))
 
 	v1 := router.Group("/api/v1")
 
@@ -110,18 +191,68 @@ func NewServer(
 	// These routes will be under /api/v1/auth and will use the authMW
 	authRouterGroup := v1.Group("/auth", authMW) // Auth routes are simple, keep specific group
 	authHandler.RegisterRoutes(authRouterGroup)
+	// Second factor management is for admins, before their second factor is verified: no AdminAuthMiddleware here
+	twoFactorHandler.RegisterRoutes(v1.Group("/auth/2fa", authMW, middleware.RoleAuthMiddleware(common.RoleAdmin)))
 
 	// Register routes for other modules by passing the base v1 group and middlewares
 	userHandler.RegisterRoutes(v1, authMW, adminRoleMW) // Pass adminRoleMW here
 	categoryHandler.RegisterRoutes(v1, authMW, adminRoleMW)
-	listingHandler.RegisterRoutes(v1, authMW, adminRoleMW)
+	listingHandler.RegisterRoutes(v1, authMW, browseMW, anonSessionMW, adminRoleMW, listingCaptchaMW)
+	listingHandler.RegisterUserRoutes(v1.Group("/users/me", authMW))
+	listingHandler.RegisterAnonymousSessionRoutes(v1.Group("/anonymous-sessions/current", requireAnonSessionMW))
+	savedSearchHandler.RegisterRoutes(v1, authMW)
+	templateHandler.RegisterRoutes(v1, authMW)
+	anonSessionHandler.RegisterRoutes(v1, authMW)
+	appConfigHandler.RegisterRoutes(v1, authMW, adminRoleMW)
+	apiKeyHandler.RegisterRoutes(v1, authMW, adminRoleMW)
+	webhookHandler.RegisterRoutes(v1, authMW, adminRoleMW)
+	messagingHandler.RegisterRoutes(v1, authMW, contactCaptchaMW)
+	verificationHandler.RegisterRoutes(v1, authMW)
+	paymentsHandler.RegisterRoutes(v1, authMW)
+	imageHandler.RegisterUploadRoutes(v1.Group("/uploads", authMW))
+
+	// Partner API: read-only access for external integrations, authenticated by X-API-Key
+	partnerAPIs := v1.Group("/partner", middleware.APIKeyMiddleware(apiKeyService, apikey.ScopeListingsRead, logger.Named("APIKeyMiddleware")))
+	listingHandler.RegisterPartnerRoutes(partnerAPIs)
+	partnerFeatureAPIs := v1.Group("/partner", middleware.APIKeyMiddleware(apiKeyService, apikey.ScopeListingsFeature, logger.Named("APIKeyMiddleware")))
+	listingHandler.RegisterPartnerFeatureRoutes(partnerFeatureAPIs)
+
+	// Admin API: /api/v1/admin/..., every route requires an authenticated admin
+	adminAPIs := v1.Group("/admin", authMW, adminRoleMW)
+	overviewHandler.RegisterAdminRoutes(adminAPIs)
+	userHandler.RegisterAdminRoutes(adminAPIs)
+	listingHandler.RegisterAdminRoutes(adminAPIs)
+	auditHandler.RegisterAdminRoutes(adminAPIs)
+	queueHandler.RegisterAdminRoutes(adminAPIs)
+	importHandler.RegisterAdminRoutes(adminAPIs)
+	paymentsHandler.RegisterAdminRoutes(adminAPIs)
+	abuseHandler.RegisterAdminRoutes(adminAPIs)
+	jobRunHandler.RegisterAdminRoutes(adminAPIs)
+	adminAPIs.GET("/database/pool", func(c *gin.Context) {
+		stats, err := database.PoolStatistics(db)
+		if err != nil {
+			logger.Error("Failed to read database pool statistics", zap.Error(err))
+			common.RespondWithError(c, common.ErrInternalServer.WithDetails("Could not read database pool statistics."))
+			return
+		}
+		common.RespondOK(c, "Database pool statistics retrieved successfully.", stats)
+	})
+	appConfigHandler.RegisterAdminRoutes(adminAPIs)
+	registerLogLevelRoutes(adminAPIs, logLevel, logger.Named("LogLevel"))
 
 	// New route group for events:
 	// This defines /api/v1/events
 	// The listingHandler.RegisterEventRoutes will then add /upcoming to this, making it /api/v1/events/upcoming
-	eventAPIs := v1.Group("/events")
+	eventAPIs := v1.Group("/events", browseMW)
 	listingHandler.RegisterEventRoutes(eventAPIs) // This uses the new method in listing.Handler
 
+	// Public neighborhood list: /api/v1/neighborhoods
+	listingHandler.RegisterNeighborhoodRoutes(v1.Group("/neighborhoods", browseMW))
+
+	// Public RSS feeds: /api/v1/feeds/recent.xml
+	feedAPIs := v1.Group("/feeds")
+	listingHandler.RegisterFeedRoutes(feedAPIs)
+
 	// Register notification routes (these require authentication)
 	// The local variable 'authMW' is in scope here and can be used directly.
 	// 's.authMW' would be used if we were in a method of Server after NewServer has completed.
@@ -152,7 +283,23 @@ func NewServer(
 		categoryHandler:     categoryHandler,
 		listingHandler:      listingHandler,
 		notificationHandler: notificationHandler, // Add this
-		listingExpiryJob:    listingExpiryJob,
+		savedSearchHandler:  savedSearchHandler,
+		appConfigHandler:    appConfigHandler,
+		apiKeyHandler:       apiKeyHandler,
+		webhookHandler:      webhookHandler,
+		messagingHandler:    messagingHandler,
+		auditHandler:        auditHandler,
+		verificationHandler: verificationHandler,
+		queueHandler:        queueHandler,
+		importHandler:       importHandler,
+		templateHandler:     templateHandler,
+		anonSessionHandler:  anonSessionHandler,
+		twoFactorHandler:    twoFactorHandler,
+		paymentsHandler:     paymentsHandler,
+		abuseHandler:        abuseHandler,
+		worker:              worker,
+		trendingListingsJob: trendingListingsJob,
+		grpcServer:          grpcServer,
 		authMW:              authMW,
 		adminRoleMW:         adminRoleMW,
 		// firebaseService: firebaseService, // Store if needed elsewhere
@@ -161,13 +308,23 @@ func NewServer(
 }
 
 func (s *Server) Start() error {
-	if s.listingExpiryJob != nil {
-		err := s.listingExpiryJob.SetupAndStart()
-		if err != nil {
-			s.logger.Error("Failed to setup and start listing expiry job", zap.Error(err))
-		}
+	if s.cfg.RunJobsInAPI {
+		s.worker.Start()
 	} else {
-		s.logger.Info("Listing expiry job is not configured, skipping start.")
+		s.logger.Info("RUN_JOBS_IN_API is false; background jobs are left to the worker process.")
+	}
+	if s.trendingListingsJob != nil {
+		if err := s.trendingListingsJob.SetupAndStart(); err != nil {
+			s.logger.Error("Failed to setup and start trending listings job", zap.Error(err))
+		}
+	}
+
+	if s.grpcServer != nil {
+		go func() {
+			if err := s.grpcServer.Start(); err != nil {
+				s.logger.Error("Failed to start gRPC server", zap.Error(err))
+			}
+		}()
 	}
 
 	s.logger.Info("HTTP Server starting",
@@ -184,8 +341,18 @@ func (s *Server) Start() error {
 
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.Info("Attempting graceful server shutdown...")
-	if s.listingExpiryJob != nil {
-		s.listingExpiryJob.Stop()
+	if s.cfg.RunJobsInAPI {
+		if err := s.worker.Shutdown(ctx); err != nil {
+			s.logger.Warn("Background jobs did not shut down gracefully", zap.Error(err))
+		}
+	}
+	if s.trendingListingsJob != nil {
+		s.trendingListingsJob.Stop()
+	}
+	if s.grpcServer != nil {
+		if err := s.grpcServer.Shutdown(ctx); err != nil {
+			s.logger.Warn("gRPC server did not shut down gracefully", zap.Error(err))
+		}
 	}
 	return s.httpServer.Shutdown(ctx)
 }
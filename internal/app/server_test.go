@@ -0,0 +1,79 @@
+package app
+
+import (
+	"testing"
+
+	"seattle_info_backend/internal/abuse"
+	"seattle_info_backend/internal/anonsession"
+	"seattle_info_backend/internal/apikey"
+	"seattle_info_backend/internal/appconfig"
+	"seattle_info_backend/internal/audit"
+	"seattle_info_backend/internal/auth"
+	"seattle_info_backend/internal/category"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/filestorage"
+	"seattle_info_backend/internal/jobrun"
+	"seattle_info_backend/internal/listing"
+	"seattle_info_backend/internal/listingimport"
+	"seattle_info_backend/internal/listingtemplate"
+	"seattle_info_backend/internal/messaging"
+	"seattle_info_backend/internal/notification"
+	"seattle_info_backend/internal/overview"
+	"seattle_info_backend/internal/payments"
+	"seattle_info_backend/internal/queue"
+	"seattle_info_backend/internal/savedsearch"
+	"seattle_info_backend/internal/twofactor"
+	"seattle_info_backend/internal/user"
+	"seattle_info_backend/internal/verification"
+	"seattle_info_backend/internal/webhook"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestNewServerRegistersRoutes builds the full router. Gin panics on conflicting routes, such as two wildcard
+// names at the same position of a path, so a conflict fails here rather than at startup.
+func TestNewServerRegistersRoutes(t *testing.T) {
+	var server *Server
+	require.NotPanics(t, func() {
+		var err error
+		server, err = NewServer(
+			&config.Config{GinMode: gin.TestMode},
+			zap.NewNop(),
+			&user.Handler{},
+			&auth.Handler{},
+			&category.Handler{},
+			&listing.Handler{},
+			&notification.Handler{},
+			&savedsearch.Handler{},
+			&appconfig.Handler{},
+			&apikey.Handler{},
+			&webhook.Handler{},
+			&messaging.Handler{},
+			&audit.Handler{},
+			&verification.Handler{},
+			&queue.Handler{},
+			&listingimport.Handler{},
+			&listingtemplate.Handler{},
+			&anonsession.Handler{},
+			&twofactor.Handler{},
+			&payments.Handler{},
+			&abuse.Handler{},
+			&filestorage.Handler{},
+			&overview.Handler{},
+			&jobrun.Handler{},
+			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+			zap.NewAtomicLevel(),
+		)
+		require.NoError(t, err)
+	})
+
+	routes := make(map[string]bool)
+	for _, route := range server.router.Routes() {
+		routes[route.Method+" "+route.Path] = true
+	}
+	assert.True(t, routes["POST /api/v1/categories/admin/:id/subcategories"])
+	assert.True(t, routes["POST /api/v1/categories/admin/:id/attributes"])
+}
@@ -0,0 +1,184 @@
+// File: internal/app/worker.go
+package app
+
+import (
+	"context"
+	"sync"
+
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/jobs"
+	"seattle_info_backend/internal/listing"
+	"seattle_info_backend/internal/listingimport"
+	"seattle_info_backend/internal/queue"
+	"seattle_info_backend/internal/webhook"
+
+	"go.uber.org/zap"
+)
+
+// Worker runs the scheduled background jobs and consumes the task queue. It is started by the API server when RUN_JOBS_IN_API is true,
+// or on its own by the `worker` command so that API and background processing can be scaled independently.
+//
+// The trending listings job is not part of it: it only refreshes a cache held in the API process.
+type Worker struct {
+	cfg    *config.Config
+	logger *zap.Logger
+
+	consumer *queue.Consumer
+
+	listingExpiryJob     *jobs.ListingExpiryJob
+	savedSearchDigestJob *jobs.SavedSearchDigestJob
+	webhookDeliveryJob   *jobs.WebhookDeliveryJob
+	imageConsistencyJob  *jobs.ImageConsistencyJob
+	scheduledPublishJob  *jobs.ScheduledPublishJob
+	featuredExpiryJob    *jobs.FeaturedExpiryJob
+	listingStatsJob      *jobs.ListingStatsRollupJob
+	approvalSLAJob       *jobs.ApprovalSLAJob
+	rentBackfillJob      *jobs.RentBackfillJob
+}
+
+// NewWorker creates a Worker for the given jobs and registers the queue task handlers. Nil jobs are skipped.
+func NewWorker(
+	cfg *config.Config,
+	logger *zap.Logger,
+	consumer *queue.Consumer,
+	webhookService webhook.Service,
+	listingService listing.Service,
+	listingImportService listingimport.Service,
+	listingExpiryJob *jobs.ListingExpiryJob,
+	savedSearchDigestJob *jobs.SavedSearchDigestJob,
+	webhookDeliveryJob *jobs.WebhookDeliveryJob,
+	imageConsistencyJob *jobs.ImageConsistencyJob,
+	scheduledPublishJob *jobs.ScheduledPublishJob,
+	featuredExpiryJob *jobs.FeaturedExpiryJob,
+	listingStatsJob *jobs.ListingStatsRollupJob,
+	approvalSLAJob *jobs.ApprovalSLAJob,
+	rentBackfillJob *jobs.RentBackfillJob,
+) *Worker {
+	consumer.Handle(webhook.TaskDeliver, webhookService.HandleDeliverTask)
+	consumer.Handle(listingimport.TaskImport, listingImportService.HandleImportTask)
+	consumer.Handle(listing.TaskScanImage, listingService.HandleScanImageTask)
+
+	return &Worker{
+		cfg:                  cfg,
+		logger:               logger.Named("Worker"),
+		consumer:             consumer,
+		listingExpiryJob:     listingExpiryJob,
+		savedSearchDigestJob: savedSearchDigestJob,
+		webhookDeliveryJob:   webhookDeliveryJob,
+		imageConsistencyJob:  imageConsistencyJob,
+		scheduledPublishJob:  scheduledPublishJob,
+		featuredExpiryJob:    featuredExpiryJob,
+		listingStatsJob:      listingStatsJob,
+		approvalSLAJob:       approvalSLAJob,
+		rentBackfillJob:      rentBackfillJob,
+	}
+}
+
+// Start schedules every job. A job that fails to schedule is logged and the others still start.
+func (w *Worker) Start() {
+	if w.listingExpiryJob != nil {
+		if err := w.listingExpiryJob.SetupAndStart(); err != nil {
+			w.logger.Error("Failed to setup and start listing expiry job", zap.Error(err))
+		}
+	} else {
+		w.logger.Info("Listing expiry job is not configured, skipping start.")
+	}
+	if w.savedSearchDigestJob != nil {
+		if err := w.savedSearchDigestJob.SetupAndStart(); err != nil {
+			w.logger.Error("Failed to setup and start saved search digest job", zap.Error(err))
+		}
+	}
+	if w.webhookDeliveryJob != nil {
+		if err := w.webhookDeliveryJob.SetupAndStart(); err != nil {
+			w.logger.Error("Failed to setup and start webhook delivery job", zap.Error(err))
+		}
+	}
+	if w.imageConsistencyJob != nil {
+		if err := w.imageConsistencyJob.SetupAndStart(); err != nil {
+			w.logger.Error("Failed to setup and start image consistency job", zap.Error(err))
+		}
+	}
+	if w.scheduledPublishJob != nil {
+		if err := w.scheduledPublishJob.SetupAndStart(); err != nil {
+			w.logger.Error("Failed to setup and start scheduled publish job", zap.Error(err))
+		}
+	}
+	if w.featuredExpiryJob != nil {
+		if err := w.featuredExpiryJob.SetupAndStart(); err != nil {
+			w.logger.Error("Failed to setup and start featured expiry job", zap.Error(err))
+		}
+	}
+	if w.listingStatsJob != nil {
+		if err := w.listingStatsJob.SetupAndStart(); err != nil {
+			w.logger.Error("Failed to setup and start listing stats rollup job", zap.Error(err))
+		}
+	}
+	if w.approvalSLAJob != nil {
+		if err := w.approvalSLAJob.SetupAndStart(); err != nil {
+			w.logger.Error("Failed to setup and start approval SLA job", zap.Error(err))
+		}
+	}
+	if w.rentBackfillJob != nil {
+		if err := w.rentBackfillJob.SetupAndStart(); err != nil {
+			w.logger.Error("Failed to setup and start rent backfill job", zap.Error(err))
+		}
+	}
+	w.consumer.Start()
+	w.logger.Info("Background jobs started")
+}
+
+// Shutdown stops the schedulers and waits for running jobs to finish, or until ctx is done.
+func (w *Worker) Shutdown(ctx context.Context) error {
+	w.logger.Info("Stopping background jobs...")
+	var wg sync.WaitGroup
+	stop := func(fn func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn()
+		}()
+	}
+	var consumerErr error
+	stop(func() { consumerErr = w.consumer.Shutdown(ctx) })
+	if w.listingExpiryJob != nil {
+		stop(w.listingExpiryJob.Stop)
+	}
+	if w.savedSearchDigestJob != nil {
+		stop(w.savedSearchDigestJob.Stop)
+	}
+	if w.webhookDeliveryJob != nil {
+		stop(w.webhookDeliveryJob.Stop)
+	}
+	if w.imageConsistencyJob != nil {
+		stop(w.imageConsistencyJob.Stop)
+	}
+	if w.scheduledPublishJob != nil {
+		stop(w.scheduledPublishJob.Stop)
+	}
+	if w.featuredExpiryJob != nil {
+		stop(w.featuredExpiryJob.Stop)
+	}
+	if w.listingStatsJob != nil {
+		stop(w.listingStatsJob.Stop)
+	}
+	if w.approvalSLAJob != nil {
+		stop(w.approvalSLAJob.Stop)
+	}
+	if w.rentBackfillJob != nil {
+		stop(w.rentBackfillJob.Stop)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		w.logger.Info("Background jobs stopped")
+		return consumerErr
+	case <-ctx.Done():
+		w.logger.Warn("Background jobs did not stop before the shutdown deadline")
+		return ctx.Err()
+	}
+}
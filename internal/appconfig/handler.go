@@ -0,0 +1,122 @@
+// File: internal/appconfig/handler.go
+package appconfig
+
+import (
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Handler struct holds dependencies for app configuration handlers.
+type Handler struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewHandler creates a new app configuration handler.
+func NewHandler(service Service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes sets up the admin-only routes for platform configuration.
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMW gin.HandlerFunc, adminRoleMW gin.HandlerFunc) {
+	adminConfigGroup := router.Group("/configurations/admin")
+	adminConfigGroup.Use(authMW)
+	adminConfigGroup.Use(adminRoleMW)
+	{
+		adminConfigGroup.GET("", h.adminListConfigurations)
+		adminConfigGroup.GET("/:key", h.adminGetConfiguration)
+		adminConfigGroup.POST("", h.adminCreateConfiguration)
+		adminConfigGroup.PUT("/:key", h.adminUpdateConfiguration)
+		adminConfigGroup.DELETE("/:key", h.adminDeleteConfiguration)
+	}
+}
+
+// RegisterAdminRoutes sets up the maintenance mode switch under the admin group (/api/v1/admin).
+func (h *Handler) RegisterAdminRoutes(adminGroup *gin.RouterGroup) {
+	adminGroup.GET("/maintenance", h.adminGetMaintenance)
+	adminGroup.PUT("/maintenance", h.adminSetMaintenance)
+}
+
+func (h *Handler) adminListConfigurations(c *gin.Context) {
+	configs, err := h.service.ListConfigurations(c.Request.Context())
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]ConfigurationResponse, len(configs))
+	for i := range configs {
+		responses[i] = ToConfigurationResponse(&configs[i])
+	}
+	common.RespondOK(c, "Configurations retrieved successfully.", responses)
+}
+
+func (h *Handler) adminGetConfiguration(c *gin.Context) {
+	cfg, err := h.service.GetConfiguration(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Configuration retrieved successfully.", ToConfigurationResponse(cfg))
+}
+
+func (h *Handler) adminCreateConfiguration(c *gin.Context) {
+	var req AdminCreateConfigurationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Admin create configuration: Invalid request body", zap.Error(err))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	cfg, err := h.service.CreateConfiguration(c.Request.Context(), req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondCreated(c, "Configuration created successfully.", ToConfigurationResponse(cfg))
+}
+
+func (h *Handler) adminUpdateConfiguration(c *gin.Context) {
+	key := c.Param("key")
+	var req AdminUpdateConfigurationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Admin update configuration: Invalid request body", zap.Error(err), zap.String("key", key))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	cfg, err := h.service.UpdateConfiguration(c.Request.Context(), key, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Configuration updated successfully.", ToConfigurationResponse(cfg))
+}
+
+func (h *Handler) adminDeleteConfiguration(c *gin.Context) {
+	if err := h.service.DeleteConfiguration(c.Request.Context(), c.Param("key")); err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondNoContent(c)
+}
+
+func (h *Handler) adminGetMaintenance(c *gin.Context) {
+	common.RespondOK(c, "Maintenance status retrieved successfully.", h.service.Maintenance(c.Request.Context()))
+}
+
+func (h *Handler) adminSetMaintenance(c *gin.Context) {
+	var req MaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	status, err := h.service.SetMaintenance(c.Request.Context(), req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Maintenance mode updated successfully.", status)
+}
@@ -0,0 +1,93 @@
+// File: internal/appconfig/model.go
+package appconfig
+
+import (
+	"time"
+)
+
+// DataType describes how the value of a configuration entry is interpreted.
+type DataType string
+
+const (
+	DataTypeString  DataType = "string"
+	DataTypeInteger DataType = "integer"
+	DataTypeBoolean DataType = "boolean"
+	DataTypeDate    DataType = "date"
+)
+
+// Well-known platform policy keys.
+const (
+	KeyDefaultListingLifespanDays        = "DEFAULT_LISTING_LIFESPAN_DAYS"
+	KeyMaxListingDistanceKM              = "MAX_LISTING_DISTANCE_KM"
+	KeyFirstPostApprovalModelActiveUntil = "FIRST_POST_APPROVAL_MODEL_ACTIVE_UNTIL"
+	KeyMaintenanceMode                   = "MAINTENANCE_MODE"
+	KeyMaintenanceMessage                = "MAINTENANCE_MESSAGE"
+)
+
+// AppConfiguration is a runtime-editable platform setting stored in app_configurations.
+type AppConfiguration struct {
+	Key         string    `gorm:"type:varchar(100);primaryKey"`
+	Value       string    `gorm:"type:text;not null"`
+	Description *string   `gorm:"type:text"`
+	DataType    DataType  `gorm:"type:varchar(50);default:'string'"`
+	CreatedAt   time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	UpdatedAt   time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName specifies the table name for GORM.
+func (AppConfiguration) TableName() string {
+	return "app_configurations"
+}
+
+// --- Request DTOs ---
+
+// AdminCreateConfigurationRequest is the payload for adding a configuration entry.
+type AdminCreateConfigurationRequest struct {
+	Key         string   `json:"key" binding:"required,max=100"`
+	Value       string   `json:"value" binding:"required"`
+	Description *string  `json:"description,omitempty"`
+	DataType    DataType `json:"data_type" binding:"required,oneof=string integer boolean date"`
+}
+
+// AdminUpdateConfigurationRequest is the payload for changing a configuration entry.
+type AdminUpdateConfigurationRequest struct {
+	Value       string  `json:"value" binding:"required"`
+	Description *string `json:"description,omitempty"`
+}
+
+// MaintenanceRequest is the payload for switching maintenance mode. A nil Message keeps the current one, and an
+// empty one restores the default.
+type MaintenanceRequest struct {
+	Enabled *bool   `json:"enabled" binding:"required"`
+	Message *string `json:"message,omitempty" binding:"omitempty,max=500"`
+}
+
+// --- Response DTOs ---
+
+// ConfigurationResponse is the API representation of a configuration entry.
+type ConfigurationResponse struct {
+	Key         string    `json:"key"`
+	Value       string    `json:"value"`
+	Description *string   `json:"description,omitempty"`
+	DataType    DataType  `json:"data_type"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ToConfigurationResponse converts an AppConfiguration model to a ConfigurationResponse DTO.
+func ToConfigurationResponse(c *AppConfiguration) ConfigurationResponse {
+	return ConfigurationResponse{
+		Key:         c.Key,
+		Value:       c.Value,
+		Description: c.Description,
+		DataType:    c.DataType,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+// MaintenanceStatus describes maintenance mode, during which writes by non-admins are refused.
+type MaintenanceStatus struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,90 @@
+// File: internal/appconfig/repository.go
+package appconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"seattle_info_backend/internal/common"
+
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for app configuration data operations.
+type Repository interface {
+	Create(ctx context.Context, cfg *AppConfiguration) error
+	FindByKey(ctx context.Context, key string) (*AppConfiguration, error)
+	FindAll(ctx context.Context) ([]AppConfiguration, error)
+	Update(ctx context.Context, cfg *AppConfiguration) error
+	Delete(ctx context.Context, key string) error
+}
+
+// GORMRepository implements the app configuration Repository interface using GORM.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+// NewGORMRepository creates a new GORM app configuration repository.
+func NewGORMRepository(db *gorm.DB) Repository {
+	return &GORMRepository{db: db}
+}
+
+// Create inserts a new configuration entry.
+func (r *GORMRepository) Create(ctx context.Context, cfg *AppConfiguration) error {
+	if err := r.db.WithContext(ctx).Create(cfg).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) || strings.Contains(err.Error(), "unique constraint") || strings.Contains(err.Error(), "duplicate key") {
+			return common.ErrConflict.WithDetails("Configuration with this key already exists.")
+		}
+		return fmt.Errorf("failed to create app configuration: %w", err)
+	}
+	return nil
+}
+
+// FindByKey retrieves a configuration entry by its key.
+func (r *GORMRepository) FindByKey(ctx context.Context, key string) (*AppConfiguration, error) {
+	var cfg AppConfiguration
+	if err := r.db.WithContext(ctx).First(&cfg, "key = ?", key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Configuration not found.")
+		}
+		return nil, fmt.Errorf("failed to find app configuration: %w", err)
+	}
+	return &cfg, nil
+}
+
+// FindAll retrieves every configuration entry ordered by key.
+func (r *GORMRepository) FindAll(ctx context.Context) ([]AppConfiguration, error) {
+	var configs []AppConfiguration
+	if err := r.db.WithContext(ctx).Order("key ASC").Find(&configs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list app configurations: %w", err)
+	}
+	return configs, nil
+}
+
+// Update saves the value and description of an existing configuration entry.
+func (r *GORMRepository) Update(ctx context.Context, cfg *AppConfiguration) error {
+	result := r.db.WithContext(ctx).Model(&AppConfiguration{}).
+		Where("key = ?", cfg.Key).
+		Updates(map[string]interface{}{"value": cfg.Value, "description": cfg.Description})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update app configuration: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound.WithDetails("Configuration not found.")
+	}
+	return nil
+}
+
+// Delete removes a configuration entry.
+func (r *GORMRepository) Delete(ctx context.Context, key string) error {
+	result := r.db.WithContext(ctx).Delete(&AppConfiguration{}, "key = ?", key)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete app configuration: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound.WithDetails("Configuration not found.")
+	}
+	return nil
+}
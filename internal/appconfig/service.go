@@ -0,0 +1,341 @@
+// File: internal/appconfig/service.go
+package appconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+
+	"go.uber.org/zap"
+)
+
+// dateLayouts are the accepted formats for 'date' values. The seeded
+// FIRST_POST_APPROVAL_MODEL_ACTIVE_UNTIL row is a Postgres timestamp cast to text.
+var dateLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+// defaultMaintenanceMessage is shown during maintenance when no message is configured.
+const defaultMaintenanceMessage = "We are performing maintenance. You can keep browsing, but posting and other changes are paused. Please try again later."
+
+// Service defines the interface for platform configuration management and typed lookups.
+type Service interface {
+	// Admin methods
+	ListConfigurations(ctx context.Context) ([]AppConfiguration, error)
+	GetConfiguration(ctx context.Context, key string) (*AppConfiguration, error)
+	CreateConfiguration(ctx context.Context, req AdminCreateConfigurationRequest) (*AppConfiguration, error)
+	UpdateConfiguration(ctx context.Context, key string, req AdminUpdateConfigurationRequest) (*AppConfiguration, error)
+	DeleteConfiguration(ctx context.Context, key string) error
+
+	// Typed accessors (served from cache)
+	GetString(ctx context.Context, key string) (string, error)
+	GetInt(ctx context.Context, key string) (int, error)
+	GetBool(ctx context.Context, key string) (bool, error)
+	GetDate(ctx context.Context, key string) (time.Time, error)
+
+	// Maintenance mode
+	Maintenance(ctx context.Context) MaintenanceStatus
+	SetMaintenance(ctx context.Context, req MaintenanceRequest) (*MaintenanceStatus, error)
+}
+
+// ServiceImplementation implements the app configuration Service interface.
+// Values are cached in memory and refreshed after cfg.AppConfigCacheTTL or any admin write.
+type ServiceImplementation struct {
+	repo   Repository
+	logger *zap.Logger
+	ttl    time.Duration
+
+	// MAINTENANCE_MODE and MAINTENANCE_MESSAGE, used while app_configurations has no entry for them
+	maintenanceMode    bool
+	maintenanceMessage string
+
+	mu       sync.RWMutex
+	cache    map[string]AppConfiguration
+	loadedAt time.Time
+}
+
+// NewService creates a new app configuration service.
+func NewService(repo Repository, cfg *config.Config, logger *zap.Logger) Service {
+	return &ServiceImplementation{
+		repo:               repo,
+		logger:             logger,
+		ttl:                cfg.AppConfigCacheTTL,
+		maintenanceMode:    cfg.MaintenanceMode,
+		maintenanceMessage: cfg.MaintenanceMessage,
+	}
+}
+
+// --- Admin Methods ---
+
+// ListConfigurations returns every configuration entry.
+func (s *ServiceImplementation) ListConfigurations(ctx context.Context) ([]AppConfiguration, error) {
+	configs, err := s.repo.FindAll(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list app configurations", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve configurations.")
+	}
+	return configs, nil
+}
+
+// GetConfiguration returns a single configuration entry.
+func (s *ServiceImplementation) GetConfiguration(ctx context.Context, key string) (*AppConfiguration, error) {
+	cfg, err := s.repo.FindByKey(ctx, key)
+	if err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to get app configuration", zap.Error(err), zap.String("key", key))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve configuration.")
+	}
+	return cfg, nil
+}
+
+// CreateConfiguration adds a new configuration entry after checking its value matches the data type.
+func (s *ServiceImplementation) CreateConfiguration(ctx context.Context, req AdminCreateConfigurationRequest) (*AppConfiguration, error) {
+	key := strings.ToUpper(strings.TrimSpace(req.Key))
+	value := strings.TrimSpace(req.Value)
+	if err := validateValue(req.DataType, value); err != nil {
+		return nil, common.ErrBadRequest.WithDetails(err.Error())
+	}
+
+	cfg := &AppConfiguration{
+		Key:         key,
+		Value:       value,
+		Description: req.Description,
+		DataType:    req.DataType,
+	}
+	if err := s.repo.Create(ctx, cfg); err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to create app configuration", zap.Error(err), zap.String("key", key))
+		return nil, common.ErrInternalServer.WithDetails("Could not create configuration.")
+	}
+	s.invalidate()
+	s.logger.Info("App configuration created", zap.String("key", key), zap.String("value", value))
+	return cfg, nil
+}
+
+// UpdateConfiguration changes the value (and optionally description) of an existing entry.
+func (s *ServiceImplementation) UpdateConfiguration(ctx context.Context, key string, req AdminUpdateConfigurationRequest) (*AppConfiguration, error) {
+	cfg, err := s.GetConfiguration(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	value := strings.TrimSpace(req.Value)
+	if err := validateValue(cfg.DataType, value); err != nil {
+		return nil, common.ErrBadRequest.WithDetails(err.Error())
+	}
+	cfg.Value = value
+	if req.Description != nil {
+		cfg.Description = req.Description
+	}
+
+	if err := s.repo.Update(ctx, cfg); err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to update app configuration", zap.Error(err), zap.String("key", key))
+		return nil, common.ErrInternalServer.WithDetails("Could not update configuration.")
+	}
+	s.invalidate()
+	s.logger.Info("App configuration updated", zap.String("key", key), zap.String("value", value))
+	return s.GetConfiguration(ctx, key)
+}
+
+// DeleteConfiguration removes a configuration entry; lookups then fall back to environment defaults.
+func (s *ServiceImplementation) DeleteConfiguration(ctx context.Context, key string) error {
+	if err := s.repo.Delete(ctx, key); err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return err
+		}
+		s.logger.Error("Failed to delete app configuration", zap.Error(err), zap.String("key", key))
+		return common.ErrInternalServer.WithDetails("Could not delete configuration.")
+	}
+	s.invalidate()
+	s.logger.Info("App configuration deleted", zap.String("key", key))
+	return nil
+}
+
+// --- Typed Accessors ---
+
+// GetString returns the raw value for key.
+func (s *ServiceImplementation) GetString(ctx context.Context, key string) (string, error) {
+	cfg, err := s.lookup(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	return cfg.Value, nil
+}
+
+// GetInt returns the value for key parsed as an integer.
+func (s *ServiceImplementation) GetInt(ctx context.Context, key string) (int, error) {
+	raw, err := s.GetString(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("config %s is not an integer: %w", key, err)
+	}
+	return v, nil
+}
+
+// GetBool returns the value for key parsed as a boolean.
+func (s *ServiceImplementation) GetBool(ctx context.Context, key string) (bool, error) {
+	raw, err := s.GetString(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("config %s is not a boolean: %w", key, err)
+	}
+	return v, nil
+}
+
+// GetDate returns the value for key parsed as a date.
+func (s *ServiceImplementation) GetDate(ctx context.Context, key string) (time.Time, error) {
+	raw, err := s.GetString(ctx, key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	v, err := parseDate(raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("config %s: %w", key, err)
+	}
+	return v, nil
+}
+
+// --- Maintenance Mode ---
+
+// Maintenance returns whether maintenance mode is on and the message for clients. It is served from the cache, as
+// it is checked on every write request. The entries set through SetMaintenance take precedence over
+// MAINTENANCE_MODE and MAINTENANCE_MESSAGE.
+func (s *ServiceImplementation) Maintenance(ctx context.Context) MaintenanceStatus {
+	status := MaintenanceStatus{Enabled: s.maintenanceMode, Message: s.maintenanceMessage}
+	if enabled, err := s.GetBool(ctx, KeyMaintenanceMode); err == nil {
+		status.Enabled = enabled
+	}
+	if message, err := s.GetString(ctx, KeyMaintenanceMessage); err == nil && message != "" {
+		status.Message = message
+	}
+	if status.Message == "" {
+		status.Message = defaultMaintenanceMessage
+	}
+	return status
+}
+
+// SetMaintenance switches maintenance mode on or off for every API instance. Instances other than this one pick the
+// change up within APP_CONFIG_CACHE_TTL_SECONDS.
+func (s *ServiceImplementation) SetMaintenance(ctx context.Context, req MaintenanceRequest) (*MaintenanceStatus, error) {
+	if req.Message != nil {
+		description := "Message shown to clients while maintenance mode is on; empty uses the default"
+		if err := s.setValue(ctx, KeyMaintenanceMessage, DataTypeString, strings.TrimSpace(*req.Message), description); err != nil {
+			return nil, err
+		}
+	}
+	description := "Maintenance mode: writes by non-admins are refused with 503"
+	if err := s.setValue(ctx, KeyMaintenanceMode, DataTypeBoolean, strconv.FormatBool(*req.Enabled), description); err != nil {
+		return nil, err
+	}
+
+	status := s.Maintenance(ctx)
+	s.logger.Warn("Maintenance mode changed", zap.Bool("enabled", status.Enabled), zap.String("message", status.Message))
+	return &status, nil
+}
+
+// setValue updates the entry for key, creating it if it does not exist yet.
+func (s *ServiceImplementation) setValue(ctx context.Context, key string, dataType DataType, value, description string) error {
+	_, err := s.GetConfiguration(ctx, key)
+	if errors.Is(err, common.ErrNotFound) {
+		_, err = s.CreateConfiguration(ctx, AdminCreateConfigurationRequest{Key: key, Value: value, Description: &description, DataType: dataType})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	_, err = s.UpdateConfiguration(ctx, key, AdminUpdateConfigurationRequest{Value: value})
+	return err
+}
+
+// lookup serves key from the cache, reloading the whole table when the cache is stale.
+func (s *ServiceImplementation) lookup(ctx context.Context, key string) (*AppConfiguration, error) {
+	s.mu.RLock()
+	fresh := s.cache != nil && time.Since(s.loadedAt) < s.ttl
+	cfg, ok := s.cache[key]
+	s.mu.RUnlock()
+
+	if !fresh {
+		if err := s.reload(ctx); err != nil {
+			return nil, err
+		}
+		s.mu.RLock()
+		cfg, ok = s.cache[key]
+		s.mu.RUnlock()
+	}
+	if !ok {
+		return nil, fmt.Errorf("config key not found: %s", key)
+	}
+	return &cfg, nil
+}
+
+func (s *ServiceImplementation) reload(ctx context.Context) error {
+	configs, err := s.repo.FindAll(ctx)
+	if err != nil {
+		s.logger.Error("Failed to load app configurations into cache", zap.Error(err))
+		return err
+	}
+
+	cache := make(map[string]AppConfiguration, len(configs))
+	for _, c := range configs {
+		cache[c.Key] = c
+	}
+
+	s.mu.Lock()
+	s.cache = cache
+	s.loadedAt = time.Now()
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *ServiceImplementation) invalidate() {
+	s.mu.Lock()
+	s.cache = nil
+	s.mu.Unlock()
+}
+
+// validateValue checks that value can be read back as the given data type.
+func validateValue(dataType DataType, value string) error {
+	var err error
+	switch dataType {
+	case DataTypeInteger:
+		_, err = strconv.Atoi(value)
+	case DataTypeBoolean:
+		_, err = strconv.ParseBool(value)
+	case DataTypeDate:
+		_, err = parseDate(value)
+	case DataTypeString, "":
+	default:
+		return fmt.Errorf("unsupported data type %q", dataType)
+	}
+	if err != nil {
+		return fmt.Errorf("value %q is not a valid %s", value, dataType)
+	}
+	return nil
+}
+
+func parseDate(raw string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a date (expected YYYY-MM-DD or RFC3339)", raw)
+}
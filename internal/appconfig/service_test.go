@@ -0,0 +1,108 @@
+package appconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeRepo struct {
+	configs  map[string]AppConfiguration
+	findAlls int
+}
+
+func (f *fakeRepo) Create(_ context.Context, c *AppConfiguration) error {
+	f.configs[c.Key] = *c
+	return nil
+}
+func (f *fakeRepo) FindByKey(_ context.Context, key string) (*AppConfiguration, error) {
+	c, ok := f.configs[key]
+	if !ok {
+		return nil, common.ErrNotFound.WithDetails("Configuration not found.")
+	}
+	return &c, nil
+}
+func (f *fakeRepo) FindAll(context.Context) ([]AppConfiguration, error) {
+	f.findAlls++
+	out := make([]AppConfiguration, 0, len(f.configs))
+	for _, c := range f.configs {
+		out = append(out, c)
+	}
+	return out, nil
+}
+func (f *fakeRepo) Update(_ context.Context, c *AppConfiguration) error {
+	f.configs[c.Key] = *c
+	return nil
+}
+func (f *fakeRepo) Delete(_ context.Context, key string) error {
+	delete(f.configs, key)
+	return nil
+}
+
+func TestTypedAccessorsUseCacheAndInvalidateOnWrite(t *testing.T) {
+	repo := &fakeRepo{configs: map[string]AppConfiguration{
+		KeyDefaultListingLifespanDays:        {Key: KeyDefaultListingLifespanDays, Value: "10", DataType: DataTypeInteger},
+		KeyFirstPostApprovalModelActiveUntil: {Key: KeyFirstPostApprovalModelActiveUntil, Value: "2030-01-31 00:00:00", DataType: DataTypeDate},
+	}}
+	svc := NewService(repo, &config.Config{AppConfigCacheTTL: time.Hour}, zap.NewNop())
+	ctx := context.Background()
+
+	days, err := svc.GetInt(ctx, KeyDefaultListingLifespanDays)
+	require.NoError(t, err)
+	assert.Equal(t, 10, days)
+
+	until, err := svc.GetDate(ctx, KeyFirstPostApprovalModelActiveUntil)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2030, 1, 31, 0, 0, 0, 0, time.UTC), until)
+	assert.Equal(t, 1, repo.findAlls, "second lookup should be served from cache")
+
+	_, err = svc.UpdateConfiguration(ctx, KeyDefaultListingLifespanDays, AdminUpdateConfigurationRequest{Value: "30"})
+	require.NoError(t, err)
+	days, err = svc.GetInt(ctx, KeyDefaultListingLifespanDays)
+	require.NoError(t, err)
+	assert.Equal(t, 30, days)
+
+	_, err = svc.GetInt(ctx, "MISSING_KEY")
+	assert.Error(t, err)
+}
+
+func TestUpdateConfigurationRejectsValueOfWrongType(t *testing.T) {
+	repo := &fakeRepo{configs: map[string]AppConfiguration{
+		KeyMaxListingDistanceKM: {Key: KeyMaxListingDistanceKM, Value: "50", DataType: DataTypeInteger},
+	}}
+	svc := NewService(repo, &config.Config{AppConfigCacheTTL: time.Hour}, zap.NewNop())
+
+	_, err := svc.UpdateConfiguration(context.Background(), KeyMaxListingDistanceKM, AdminUpdateConfigurationRequest{Value: "fifty"})
+	assert.Error(t, err)
+	assert.Equal(t, "50", repo.configs[KeyMaxListingDistanceKM].Value)
+}
+
+func TestMaintenanceFallsBackToConfigUntilSet(t *testing.T) {
+	repo := &fakeRepo{configs: map[string]AppConfiguration{}}
+	svc := NewService(repo, &config.Config{AppConfigCacheTTL: time.Hour, MaintenanceMode: true}, zap.NewNop())
+	ctx := context.Background()
+
+	status := svc.Maintenance(ctx)
+	assert.True(t, status.Enabled, "MAINTENANCE_MODE applies while there is no entry")
+	assert.Equal(t, defaultMaintenanceMessage, status.Message)
+
+	enabled, message := false, "  Back at 10 PM.  "
+	updated, err := svc.SetMaintenance(ctx, MaintenanceRequest{Enabled: &enabled, Message: &message})
+	require.NoError(t, err)
+	assert.False(t, updated.Enabled)
+	assert.Equal(t, "Back at 10 PM.", updated.Message)
+	assert.Equal(t, DataTypeBoolean, repo.configs[KeyMaintenanceMode].DataType)
+
+	enabled = true
+	updated, err = svc.SetMaintenance(ctx, MaintenanceRequest{Enabled: &enabled})
+	require.NoError(t, err)
+	assert.True(t, updated.Enabled)
+	assert.Equal(t, "Back at 10 PM.", updated.Message, "a nil message keeps the current one")
+}
@@ -0,0 +1,47 @@
+// File: internal/audit/handler.go
+package audit
+
+import (
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Handler struct holds dependencies for audit log handlers.
+type Handler struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewHandler creates a new audit handler.
+func NewHandler(service Service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterAdminRoutes adds the audit log to the admin API group, which already requires the admin role.
+func (h *Handler) RegisterAdminRoutes(router *gin.RouterGroup) {
+	router.GET("/audit-logs", h.adminListEntries)
+}
+
+func (h *Handler) adminListEntries(c *gin.Context) {
+	var query ListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	page, pageSize := common.GetPaginationParams(c)
+	entries, pagination, err := h.service.ListEntries(c.Request.Context(), query, page, pageSize)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]EntryResponse, len(entries))
+	for i := range entries {
+		responses[i] = ToEntryResponse(&entries[i])
+	}
+	common.RespondPaginated(c, "Audit log retrieved successfully.", responses, pagination)
+}
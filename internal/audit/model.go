@@ -0,0 +1,101 @@
+// File: internal/audit/model.go
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Actions recorded in the audit log.
+const (
+	ActionListingAdminEdit         = "listing.admin_edit"
+	ActionListingFeature           = "listing.feature"
+	ActionListingUnfeature         = "listing.unfeature"
+	ActionListingTakedown          = "listing.takedown"
+	ActionListingAppeal            = "listing.appeal"
+	ActionListingAppealResolve     = "listing.appeal_resolve"
+	ActionListingBackgroundCheck   = "listing.background_check"
+	ActionUserMerge                = "user.merge"
+	ActionUserTwoFactorEnable      = "user.2fa_enable"
+	ActionUserTwoFactorDisable     = "user.2fa_disable"
+	ActionUserTwoFactorBackupCodes = "user.2fa_backup_codes"
+	ActionJobRun                   = "job.run"
+)
+
+// Entity types that audit entries refer to.
+const (
+	EntityListing = "listing"
+	EntityUser    = "user"
+	EntityJob     = "job" // EntityID is the job name
+)
+
+// FieldChange is the value of one field before and after a change.
+type FieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// Entry is one recorded administrative change. Entries are never updated or deleted.
+type Entry struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	ActorID    *uuid.UUID `gorm:"type:uuid"` // Nil for changes made by the system
+	Action     string     `gorm:"type:varchar(100);not null"`
+	EntityType string     `gorm:"type:varchar(50);not null"`
+	EntityID   string     `gorm:"type:varchar(100);not null"`
+	Changes    string     `gorm:"type:jsonb;not null"` // Encoded map of field name to FieldChange
+	Note       *string    `gorm:"type:text"`
+	CreatedAt  time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName specifies the table name for GORM.
+func (Entry) TableName() string {
+	return "audit_logs"
+}
+
+// Event describes a change to record with Service.Record.
+type Event struct {
+	ActorID    *uuid.UUID
+	Action     string
+	EntityType string
+	EntityID   string
+	Changes    map[string]FieldChange
+	Note       *string
+}
+
+// ListQuery filters the audit log. Empty fields match every entry.
+type ListQuery struct {
+	EntityType string `form:"entity_type"`
+	EntityID   string `form:"entity_id"`
+	Action     string `form:"action"`
+	ActorID    string `form:"actor_id" binding:"omitempty,uuid"`
+}
+
+// --- Response DTOs ---
+
+// EntryResponse is the API representation of an audit entry.
+type EntryResponse struct {
+	ID         uuid.UUID       `json:"id"`
+	ActorID    *uuid.UUID      `json:"actor_id,omitempty"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Changes    json.RawMessage `json:"changes"`
+	Note       *string         `json:"note,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// ToEntryResponse converts an Entry model to an EntryResponse DTO.
+func ToEntryResponse(e *Entry) EntryResponse {
+	return EntryResponse{
+		ID:         e.ID,
+		ActorID:    e.ActorID,
+		Action:     e.Action,
+		EntityType: e.EntityType,
+		EntityID:   e.EntityID,
+		Changes:    json.RawMessage(e.Changes),
+		Note:       e.Note,
+		CreatedAt:  e.CreatedAt,
+	}
+}
@@ -0,0 +1,64 @@
+// File: internal/audit/repository.go
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"seattle_info_backend/internal/common"
+
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for audit log data operations.
+type Repository interface {
+	Create(ctx context.Context, entry *Entry) error
+	Find(ctx context.Context, query ListQuery, page, pageSize int) ([]Entry, *common.Pagination, error)
+}
+
+// GORMRepository implements the audit Repository interface using GORM.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+// NewGORMRepository creates a new GORM audit repository.
+func NewGORMRepository(db *gorm.DB) Repository {
+	return &GORMRepository{db: db}
+}
+
+// Create inserts a new audit entry.
+func (r *GORMRepository) Create(ctx context.Context, entry *Entry) error {
+	if err := r.db.WithContext(ctx).Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+	return nil
+}
+
+// Find retrieves audit entries matching query, newest first.
+func (r *GORMRepository) Find(ctx context.Context, query ListQuery, page, pageSize int) ([]Entry, *common.Pagination, error) {
+	var entries []Entry
+	var totalItems int64
+
+	dbQuery := r.db.WithContext(ctx).Model(&Entry{})
+	if query.EntityType != "" {
+		dbQuery = dbQuery.Where("entity_type = ?", query.EntityType)
+	}
+	if query.EntityID != "" {
+		dbQuery = dbQuery.Where("entity_id = ?", query.EntityID)
+	}
+	if query.Action != "" {
+		dbQuery = dbQuery.Where("action = ?", query.Action)
+	}
+	if query.ActorID != "" {
+		dbQuery = dbQuery.Where("actor_id = ?", query.ActorID)
+	}
+	if err := dbQuery.Count(&totalItems).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	offset := (page - 1) * pageSize
+	if err := dbQuery.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&entries).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	return entries, common.NewPagination(totalItems, page, pageSize), nil
+}
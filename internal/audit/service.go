@@ -0,0 +1,76 @@
+// File: internal/audit/service.go
+package audit
+
+import (
+	"context"
+	"encoding/json"
+
+	"seattle_info_backend/internal/common"
+
+	"go.uber.org/zap"
+)
+
+// Service defines the interface for recording and reading the audit log.
+type Service interface {
+	Record(ctx context.Context, event Event) error
+	ListEntries(ctx context.Context, query ListQuery, page, pageSize int) ([]Entry, *common.Pagination, error)
+}
+
+// ServiceImplementation implements the audit Service interface.
+type ServiceImplementation struct {
+	repo   Repository
+	logger *zap.Logger
+}
+
+// NewService creates a new audit service.
+func NewService(repo Repository, logger *zap.Logger) Service {
+	return &ServiceImplementation{repo: repo, logger: logger}
+}
+
+// Record stores event in the audit log.
+func (s *ServiceImplementation) Record(ctx context.Context, event Event) error {
+	entry, err := NewEntry(event)
+	if err != nil {
+		s.logger.Error("Failed to encode audit changes", zap.String("action", event.Action), zap.Error(err))
+		return common.ErrInternalServer
+	}
+	if err := s.repo.Create(ctx, entry); err != nil {
+		s.logger.Error("Failed to record audit entry",
+			zap.String("action", event.Action),
+			zap.String("entityType", event.EntityType),
+			zap.String("entityID", event.EntityID),
+			zap.Error(err))
+		return common.ErrInternalServer
+	}
+	return nil
+}
+
+// ListEntries returns a page of audit entries matching query, newest first.
+func (s *ServiceImplementation) ListEntries(ctx context.Context, query ListQuery, page, pageSize int) ([]Entry, *common.Pagination, error) {
+	entries, pagination, err := s.repo.Find(ctx, query, page, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to list audit entries", zap.Error(err))
+		return nil, nil, common.ErrInternalServer
+	}
+	return entries, pagination, nil
+}
+
+// NewEntry builds the stored form of event, for callers that write it in their own transaction.
+func NewEntry(event Event) (*Entry, error) {
+	changes := event.Changes
+	if changes == nil {
+		changes = map[string]FieldChange{}
+	}
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+	return &Entry{
+		ActorID:    event.ActorID,
+		Action:     event.Action,
+		EntityType: event.EntityType,
+		EntityID:   event.EntityID,
+		Changes:    string(encoded),
+		Note:       event.Note,
+	}, nil
+}
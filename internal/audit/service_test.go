@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubRepository keeps created entries in memory.
+type stubRepository struct {
+	created []*Entry
+	err     error
+}
+
+func (r *stubRepository) Create(_ context.Context, entry *Entry) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.created = append(r.created, entry)
+	return nil
+}
+
+func (r *stubRepository) Find(_ context.Context, _ ListQuery, page, pageSize int) ([]Entry, *common.Pagination, error) {
+	return nil, common.NewPagination(0, page, pageSize), r.err
+}
+
+func TestRecordEncodesChanges(t *testing.T) {
+	repo := &stubRepository{}
+	svc := NewService(repo, zap.NewNop())
+	actorID := uuid.New()
+	note := "Fixed a typo"
+
+	err := svc.Record(context.Background(), Event{
+		ActorID:    &actorID,
+		Action:     ActionListingAdminEdit,
+		EntityType: EntityListing,
+		EntityID:   "listing-1",
+		Changes:    map[string]FieldChange{"title": {From: "Potluk", To: "Potluck"}},
+		Note:       &note,
+	})
+	require.NoError(t, err)
+	require.Len(t, repo.created, 1)
+
+	entry := repo.created[0]
+	assert.Equal(t, &actorID, entry.ActorID)
+	assert.Equal(t, ActionListingAdminEdit, entry.Action)
+	assert.Equal(t, EntityListing, entry.EntityType)
+	assert.Equal(t, "listing-1", entry.EntityID)
+	assert.JSONEq(t, `{"title":{"from":"Potluk","to":"Potluck"}}`, entry.Changes)
+	assert.Equal(t, &note, entry.Note)
+}
+
+func TestRecordStoresEmptyChangesAsObject(t *testing.T) {
+	repo := &stubRepository{}
+	require.NoError(t, NewService(repo, zap.NewNop()).Record(context.Background(), Event{Action: "system.test", EntityType: "system", EntityID: "-"}))
+	require.Len(t, repo.created, 1)
+	assert.Equal(t, "{}", repo.created[0].Changes)
+	assert.Nil(t, repo.created[0].ActorID)
+}
+
+func TestRecordHidesRepositoryErrors(t *testing.T) {
+	svc := NewService(&stubRepository{err: errors.New("connection refused")}, zap.NewNop())
+	err := svc.Record(context.Background(), Event{Action: ActionListingAdminEdit, EntityType: EntityListing, EntityID: "listing-1"})
+	assert.Equal(t, common.ErrInternalServer, err)
+}
@@ -59,5 +59,5 @@ func (h *Handler) me(c *gin.Context) {
 	}
 
 	userResponse := shared.ToUserResponse(sharedUser)
-	common.RespondOK(c, "User profile retrieved successfully.", userResponse)
+	common.RespondOK(c, "User profile retrieved successfully.", common.SparseFields(c, userResponse))
 }
@@ -0,0 +1,103 @@
+package captcha
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSiteVerifyVerifier(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "secret", r.PostForm.Get("secret"))
+		assert.Equal(t, "203.0.113.7", r.PostForm.Get("remoteip"))
+		switch r.PostForm.Get("response") {
+		case "good":
+			fmt.Fprint(w, `{"success": true}`)
+		case "good-score":
+			fmt.Fprint(w, `{"success": true, "score": 0.9}`)
+		case "low-score":
+			fmt.Fprint(w, `{"success": true, "score": 0.1}`)
+		case "outage":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			fmt.Fprint(w, `{"success": false, "error-codes": ["invalid-input-response"]}`)
+		}
+	}))
+	defer server.Close()
+
+	v := NewSiteVerifyVerifier(server.URL, "secret", 0.5, 0)
+	ctx := context.Background()
+	assert.NoError(t, v.Verify(ctx, "good", "203.0.113.7"))
+	assert.NoError(t, v.Verify(ctx, "good-score", "203.0.113.7"))
+	assert.ErrorIs(t, v.Verify(ctx, "low-score", "203.0.113.7"), ErrRejected)
+	assert.ErrorIs(t, v.Verify(ctx, "forged", "203.0.113.7"), ErrRejected)
+	assert.ErrorIs(t, v.Verify(ctx, "", "203.0.113.7"), ErrRejected)
+
+	err := v.Verify(ctx, "outage", "203.0.113.7")
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrRejected), "provider failures are not rejections")
+}
+
+type stubStore struct {
+	createdAt time.Time
+	listings  int64
+}
+
+func (s stubStore) UserCreatedAt(context.Context, uuid.UUID) (time.Time, error) {
+	return s.createdAt, nil
+}
+
+func (s stubStore) CountPublishedListings(context.Context, uuid.UUID) (int64, error) {
+	return s.listings, nil
+}
+
+type acceptAll struct{}
+
+func (acceptAll) Verify(context.Context, string, string) error { return nil }
+
+func TestGuardRequired(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+	userID := uuid.New()
+	newGuard := func(store Store) *Guard {
+		g := NewGuardWith(acceptAll{}, store, 24*time.Hour, 1, zap.NewNop())
+		g.now = func() time.Time { return now }
+		return g
+	}
+
+	established := newGuard(stubStore{createdAt: now.AddDate(0, -1, 0), listings: 3})
+	required, err := established.Required(ctx, uuid.Nil, ActionContact)
+	require.NoError(t, err)
+	assert.True(t, required, "anonymous requests always need a CAPTCHA")
+	required, err = established.Required(ctx, userID, ActionPublishListing)
+	require.NoError(t, err)
+	assert.False(t, required)
+
+	fresh := newGuard(stubStore{createdAt: now.Add(-time.Hour), listings: 3})
+	required, err = fresh.Required(ctx, userID, ActionContact)
+	require.NoError(t, err)
+	assert.True(t, required, "new accounts need a CAPTCHA")
+
+	firstListing := newGuard(stubStore{createdAt: now.AddDate(0, -1, 0)})
+	required, err = firstListing.Required(ctx, userID, ActionPublishListing)
+	require.NoError(t, err)
+	assert.True(t, required, "a first listing needs a CAPTCHA")
+	required, err = firstListing.Required(ctx, userID, ActionContact)
+	require.NoError(t, err)
+	assert.False(t, required, "the listing threshold only applies to posting")
+
+	disabled := NewGuardWith(nil, stubStore{}, 24*time.Hour, 1, zap.NewNop())
+	required, err = disabled.Required(ctx, uuid.Nil, ActionPublishListing)
+	require.NoError(t, err)
+	assert.False(t, required, "nothing is required without a provider")
+}
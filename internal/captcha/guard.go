@@ -0,0 +1,145 @@
+// File: internal/captcha/guard.go
+package captcha
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"seattle_info_backend/internal/config"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Action is a write that may require a CAPTCHA.
+type Action string
+
+const (
+	// ActionPublishListing covers creating and publishing listings. New accounts and accounts with
+	// fewer than CAPTCHA_LISTINGS_THRESHOLD published listings must solve a CAPTCHA.
+	ActionPublishListing Action = "publish_listing"
+	// ActionContact covers starting conversations. Only new accounts must solve a CAPTCHA.
+	ActionContact Action = "contact"
+)
+
+// Store provides the account history the guard decides on.
+type Store interface {
+	UserCreatedAt(ctx context.Context, userID uuid.UUID) (time.Time, error)
+	// CountPublishedListings counts the user's listings that are not drafts.
+	CountPublishedListings(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+// Guard decides which requests must carry a CAPTCHA and verifies it.
+// A Guard without a verifier is disabled and never requires one.
+type Guard struct {
+	verifier          Verifier
+	store             Store
+	newAccountAge     time.Duration
+	listingsThreshold int
+	now               func() time.Time
+	logger            *zap.Logger
+}
+
+// NewGuardWith creates a Guard from its parts. A nil verifier disables it.
+func NewGuardWith(verifier Verifier, store Store, newAccountAge time.Duration, listingsThreshold int, logger *zap.Logger) *Guard {
+	return &Guard{
+		verifier:          verifier,
+		store:             store,
+		newAccountAge:     newAccountAge,
+		listingsThreshold: listingsThreshold,
+		now:               time.Now,
+		logger:            logger,
+	}
+}
+
+// NewGuard builds the CAPTCHA guard from configuration. It is disabled unless CAPTCHA_PROVIDER is set.
+func NewGuard(db *gorm.DB, cfg *config.Config, logger *zap.Logger) *Guard {
+	log := logger.Named("Captcha")
+
+	var verifier Verifier
+	verifyURL := cfg.CaptchaVerifyURL
+	switch cfg.CaptchaProvider {
+	case "":
+	case ProviderRecaptcha, ProviderHCaptcha:
+		if verifyURL == "" {
+			verifyURL = recaptchaVerifyURL
+			if cfg.CaptchaProvider == ProviderHCaptcha {
+				verifyURL = hcaptchaVerifyURL
+			}
+		}
+		timeout := time.Duration(cfg.CaptchaTimeoutSeconds) * time.Second
+		verifier = NewSiteVerifyVerifier(verifyURL, cfg.CaptchaSecretKey, cfg.CaptchaMinScore, timeout)
+		log.Info("CAPTCHA verification enabled", zap.String("provider", cfg.CaptchaProvider))
+	default:
+		log.Warn("Unknown CAPTCHA_PROVIDER, CAPTCHA verification disabled", zap.String("provider", cfg.CaptchaProvider))
+	}
+
+	newAccountAge := time.Duration(cfg.CaptchaNewAccountHours) * time.Hour
+	return NewGuardWith(verifier, &GORMStore{db: db}, newAccountAge, cfg.CaptchaListingsThreshold, log)
+}
+
+// Enabled reports whether a CAPTCHA provider is configured.
+func (g *Guard) Enabled() bool {
+	return g.verifier != nil
+}
+
+// Required reports whether the user must solve a CAPTCHA for action. Anonymous requests always must.
+func (g *Guard) Required(ctx context.Context, userID uuid.UUID, action Action) (bool, error) {
+	if !g.Enabled() {
+		return false, nil
+	}
+	if userID == uuid.Nil {
+		return true, nil
+	}
+
+	if g.newAccountAge > 0 {
+		createdAt, err := g.store.UserCreatedAt(ctx, userID)
+		if err != nil {
+			return false, err
+		}
+		if g.now().Sub(createdAt) < g.newAccountAge {
+			return true, nil
+		}
+	}
+
+	if action == ActionPublishListing && g.listingsThreshold > 0 {
+		count, err := g.store.CountPublishedListings(ctx, userID)
+		if err != nil {
+			return false, err
+		}
+		if count < int64(g.listingsThreshold) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Verify checks a CAPTCHA token with the configured provider.
+func (g *Guard) Verify(ctx context.Context, token, remoteIP string) error {
+	return g.verifier.Verify(ctx, token, remoteIP)
+}
+
+// GORMStore implements Store over the users and listings tables.
+type GORMStore struct {
+	db *gorm.DB
+}
+
+// UserCreatedAt returns when the user's account was created.
+func (s *GORMStore) UserCreatedAt(ctx context.Context, userID uuid.UUID) (time.Time, error) {
+	var createdAt time.Time
+	if err := s.db.WithContext(ctx).Table("users").Select("created_at").Where("id = ?", userID).Scan(&createdAt).Error; err != nil {
+		return time.Time{}, fmt.Errorf("failed to load account creation time: %w", err)
+	}
+	return createdAt, nil
+}
+
+// CountPublishedListings implements Store.
+func (s *GORMStore) CountPublishedListings(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	if err := s.db.WithContext(ctx).Table("listings").Where("user_id = ? AND status <> 'draft'", userID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count published listings: %w", err)
+	}
+	return count, nil
+}
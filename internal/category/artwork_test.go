@@ -0,0 +1,84 @@
+package category
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"testing"
+
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/filestorage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// artworkRepository keeps a single category in memory.
+type artworkRepository struct {
+	Repository
+	category Category
+}
+
+func (r *artworkRepository) FindCategoryByID(_ context.Context, _ uuid.UUID, _ bool) (*Category, error) {
+	c := r.category
+	return &c, nil
+}
+
+func (r *artworkRepository) UpdateCategory(_ context.Context, category *Category) error {
+	r.category = *category
+	return nil
+}
+
+func artworkFile(t *testing.T, name string) *multipart.FileHeader {
+	t.Helper()
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", name)
+	require.NoError(t, err)
+	_, err = part.Write([]byte("image data"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	form, err := multipart.NewReader(body, writer.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err)
+	return form.File["file"][0]
+}
+
+func TestCategoryArtworkReplacesAndRemovesFiles(t *testing.T) {
+	storage, err := filestorage.NewFileStorageService(t.TempDir(), zap.NewNop())
+	require.NoError(t, err)
+	repo := &artworkRepository{}
+	svc := NewService(repo, storage, zap.NewNop(), &config.Config{}).(*ServiceImplementation)
+	ctx := context.Background()
+
+	first, err := svc.AdminSetCategoryArtwork(ctx, uuid.New(), ArtworkIcon, artworkFile(t, "icon.png"))
+	require.NoError(t, err)
+	require.NotNil(t, first.IconPath)
+	assert.Nil(t, first.ImagePath)
+
+	second, err := svc.AdminSetCategoryArtwork(ctx, uuid.New(), ArtworkIcon, artworkFile(t, "icon.webp"))
+	require.NoError(t, err)
+	files, err := storage.ListFiles(categoryArtworkDir)
+	require.NoError(t, err)
+	require.Len(t, files, 1, "the replaced icon is deleted")
+	assert.Equal(t, *second.IconPath, files[0].Path)
+
+	_, err = svc.AdminSetCategoryArtwork(ctx, uuid.New(), ArtworkImage, artworkFile(t, "banner.exe"))
+	assert.Error(t, err, "only images are accepted")
+
+	removed, err := svc.AdminDeleteCategoryArtwork(ctx, uuid.New(), ArtworkIcon)
+	require.NoError(t, err)
+	assert.Nil(t, removed.IconPath)
+	files, err = storage.ListFiles(categoryArtworkDir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestToCategoryResponseResolvesArtworkURLs(t *testing.T) {
+	icon := "categories/icon.png"
+	resp := ToCategoryResponse(&Category{IconPath: &icon}, filestorage.NewImageURLBuilder(&config.Config{ImagePublicBaseURL: "https://cdn.example.com/static/"}))
+	require.NotNil(t, resp.IconURL)
+	assert.Equal(t, "https://cdn.example.com/static/categories/icon.png", *resp.IconURL)
+	assert.Nil(t, resp.ImageURL)
+}
@@ -0,0 +1,234 @@
+// File: internal/category/attributes.go
+package category
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/common"
+)
+
+const (
+	attributeDateLayout     = "2006-01-02"
+	maxAttributeStringValue = 500
+)
+
+var attributeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// AttributeFilterOp is a comparison applied by an AttributeFilter.
+type AttributeFilterOp string
+
+const (
+	AttributeFilterEq  AttributeFilterOp = "="
+	AttributeFilterGte AttributeFilterOp = ">="
+	AttributeFilterLte AttributeFilterOp = "<="
+)
+
+// AttributeFilter is a typed listing search condition on one attribute.
+type AttributeFilter struct {
+	Key   string
+	Type  AttributeType
+	Op    AttributeFilterOp
+	Value interface{} // float64 for number attributes, string otherwise
+}
+
+// ValidateAttributeValues checks listing attribute values against a category's definitions and
+// returns them normalized (strings trimmed, numbers as float64, dates as YYYY-MM-DD).
+// Unknown keys and mistyped values are rejected. Missing required attributes are only
+// rejected when requireAll is set, so drafts can be saved incomplete.
+func ValidateAttributeValues(defs []AttributeDefinition, values map[string]interface{}, requireAll bool) (map[string]interface{}, error) {
+	byKey := make(map[string]*AttributeDefinition, len(defs))
+	for i := range defs {
+		byKey[defs[i].Key] = &defs[i]
+	}
+
+	normalized := make(map[string]interface{}, len(values))
+	problems := make(map[string]common.ValidationMessage)
+	for key, raw := range values {
+		def, ok := byKey[key]
+		if !ok {
+			problems[key] = common.NewValidationMessage(attributeField(key), "unknown", fmt.Sprintf("Unknown attribute '%s' for this category.", key))
+			continue
+		}
+		if raw == nil {
+			continue
+		}
+		value, err := normalizeAttributeValue(def, raw)
+		if err != nil {
+			problems[key] = common.NewValidationMessage(attributeField(key), "type", err.Error())
+			continue
+		}
+		if value != nil {
+			normalized[key] = value
+		}
+	}
+	if requireAll {
+		for _, def := range defs {
+			if _, ok := normalized[def.Key]; def.Required && !ok {
+				if _, reported := problems[def.Key]; !reported {
+					problems[def.Key] = common.NewValidationMessage(attributeField(def.Key), "required", fmt.Sprintf("%s is required.", def.Label))
+				}
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		keys := make([]string, 0, len(problems))
+		for key := range problems {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		messages := make(common.ValidationMessages, 0, len(keys))
+		for _, key := range keys {
+			messages = append(messages, problems[key])
+		}
+		return nil, common.NewValidationAPIError(messages)
+	}
+	return normalized, nil
+}
+
+// attributeField names an attribute in validation errors, as it is sent in a listing request.
+func attributeField(key string) string {
+	return "attributes." + key
+}
+
+func normalizeAttributeValue(def *AttributeDefinition, raw interface{}) (interface{}, error) {
+	switch def.Type {
+	case AttributeTypeNumber:
+		switch v := raw.(type) {
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		default:
+			return nil, fmt.Errorf("%s must be a number.", def.Label)
+		}
+	case AttributeTypeDate:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a date in the format YYYY-MM-DD.", def.Label)
+		}
+		return parseAttributeDate(def, s)
+	case AttributeTypeEnum:
+		s, ok := raw.(string)
+		if !ok || !containsOption(def.Options, s) {
+			return nil, fmt.Errorf("%s must be one of: %s.", def.Label, strings.Join(def.Options, ", "))
+		}
+		return s, nil
+	default: // AttributeTypeString
+		s, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be text.", def.Label)
+		}
+		s = strings.TrimSpace(s)
+		if len(s) > maxAttributeStringValue {
+			return nil, fmt.Errorf("%s may not be longer than %d characters.", def.Label, maxAttributeStringValue)
+		}
+		if s == "" {
+			return nil, nil
+		}
+		return s, nil
+	}
+}
+
+// BuildAttributeFilters turns attr[key]=value, attr_min[key]=value and attr_max[key]=value query
+// parameters into typed filters for a category. Range filters apply to number and date attributes only.
+func BuildAttributeFilters(defs []AttributeDefinition, equals, minimums, maximums map[string]string) ([]AttributeFilter, error) {
+	byKey := make(map[string]*AttributeDefinition, len(defs))
+	for i := range defs {
+		byKey[defs[i].Key] = &defs[i]
+	}
+
+	var filters []AttributeFilter
+	add := func(params map[string]string, op AttributeFilterOp) error {
+		for key, raw := range params {
+			def, ok := byKey[key]
+			if !ok {
+				return common.ErrBadRequest.WithDetails(fmt.Sprintf("Unknown attribute '%s' for this category.", key))
+			}
+			if op != AttributeFilterEq && def.Type != AttributeTypeNumber && def.Type != AttributeTypeDate {
+				return common.ErrBadRequest.WithDetails(fmt.Sprintf("Range filters are only supported on number and date attributes; '%s' is %s.", key, def.Type))
+			}
+			value, err := parseAttributeFilterValue(def, raw)
+			if err != nil {
+				return common.ErrBadRequest.WithDetails("Invalid attribute filter: " + err.Error())
+			}
+			filters = append(filters, AttributeFilter{Key: key, Type: def.Type, Op: op, Value: value})
+		}
+		return nil
+	}
+	if err := add(equals, AttributeFilterEq); err != nil {
+		return nil, err
+	}
+	if err := add(minimums, AttributeFilterGte); err != nil {
+		return nil, err
+	}
+	if err := add(maximums, AttributeFilterLte); err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
+
+func parseAttributeFilterValue(def *AttributeDefinition, raw string) (interface{}, error) {
+	raw = strings.TrimSpace(raw)
+	switch def.Type {
+	case AttributeTypeNumber:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number.", def.Label)
+		}
+		return f, nil
+	case AttributeTypeDate:
+		return parseAttributeDate(def, raw)
+	case AttributeTypeEnum:
+		if !containsOption(def.Options, raw) {
+			return nil, fmt.Errorf("%s must be one of: %s.", def.Label, strings.Join(def.Options, ", "))
+		}
+		return raw, nil
+	default:
+		return raw, nil
+	}
+}
+
+func parseAttributeDate(def *AttributeDefinition, s string) (interface{}, error) {
+	d, err := time.Parse(attributeDateLayout, strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a date in the format YYYY-MM-DD.", def.Label)
+	}
+	return d.Format(attributeDateLayout), nil
+}
+
+// validateAttributeDefinition checks the parts of a definition that binding tags cannot express.
+func validateAttributeDefinition(def *AttributeDefinition) error {
+	if !attributeKeyPattern.MatchString(def.Key) {
+		return common.ErrBadRequest.WithDetails("Attribute key must start with a lowercase letter and contain only lowercase letters, digits and underscores (max 50).")
+	}
+	if def.Type == AttributeTypeEnum {
+		if len(def.Options) == 0 {
+			return common.ErrBadRequest.WithDetails("Enum attributes need at least one option.")
+		}
+		seen := make(map[string]bool, len(def.Options))
+		for _, o := range def.Options {
+			if strings.TrimSpace(o) == "" || seen[o] {
+				return common.ErrBadRequest.WithDetails("Enum options must be non-empty and unique.")
+			}
+			seen[o] = true
+		}
+	} else if len(def.Options) > 0 {
+		return common.ErrBadRequest.WithDetails("Options are only allowed on enum attributes.")
+	}
+	return nil
+}
+
+func containsOption(options []string, value string) bool {
+	for _, o := range options {
+		if o == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package category
+
+import (
+	"testing"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAttributeDefinitions() []AttributeDefinition {
+	return []AttributeDefinition{
+		{Key: "bedrooms", Label: "Bedrooms", Type: AttributeTypeNumber, Required: true},
+		{Key: "available_from", Label: "Available from", Type: AttributeTypeDate},
+		{Key: "furnished", Label: "Furnished", Type: AttributeTypeEnum, Options: []string{"yes", "no", "partial"}},
+		{Key: "parking", Label: "Parking", Type: AttributeTypeString},
+	}
+}
+
+func TestValidateAttributeValuesNormalizes(t *testing.T) {
+	values, err := ValidateAttributeValues(testAttributeDefinitions(), map[string]interface{}{
+		"bedrooms":       float64(2),
+		"available_from": "2024-07-01",
+		"furnished":      "partial",
+		"parking":        "  street  ",
+	}, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"bedrooms":       float64(2),
+		"available_from": "2024-07-01",
+		"furnished":      "partial",
+		"parking":        "street",
+	}, values)
+}
+
+func TestValidateAttributeValuesRejectsBadInput(t *testing.T) {
+	_, err := ValidateAttributeValues(testAttributeDefinitions(), map[string]interface{}{
+		"bedrooms":       "two",
+		"available_from": "07/01/2024",
+		"furnished":      "maybe",
+		"pets":           "yes",
+	}, true)
+	require.Error(t, err)
+	apiErr, ok := common.IsAPIError(err)
+	require.True(t, ok)
+	details, ok := apiErr.Details.(common.ValidationMessages)
+	require.True(t, ok)
+	require.Len(t, details, 4)
+	assert.Equal(t, "attributes.pets", details[3].Field, "problems are sorted by attribute")
+	assert.Equal(t, "unknown", details[3].Rule)
+}
+
+func TestValidateAttributeValuesRequiredOnlyWhenPublishing(t *testing.T) {
+	_, err := ValidateAttributeValues(testAttributeDefinitions(), map[string]interface{}{}, false)
+	assert.NoError(t, err, "drafts may omit required attributes")
+
+	_, err = ValidateAttributeValues(testAttributeDefinitions(), map[string]interface{}{}, true)
+	assert.Error(t, err)
+}
+
+func TestBuildAttributeFilters(t *testing.T) {
+	filters, err := BuildAttributeFilters(testAttributeDefinitions(),
+		map[string]string{"furnished": "yes"},
+		map[string]string{"bedrooms": "2"},
+		map[string]string{"available_from": "2024-08-01"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []AttributeFilter{
+		{Key: "furnished", Type: AttributeTypeEnum, Op: AttributeFilterEq, Value: "yes"},
+		{Key: "bedrooms", Type: AttributeTypeNumber, Op: AttributeFilterGte, Value: float64(2)},
+		{Key: "available_from", Type: AttributeTypeDate, Op: AttributeFilterLte, Value: "2024-08-01"},
+	}, filters)
+
+	_, err = BuildAttributeFilters(testAttributeDefinitions(), nil, map[string]string{"parking": "a"}, nil)
+	assert.Error(t, err, "range filters need a number or date attribute")
+
+	_, err = BuildAttributeFilters(testAttributeDefinitions(), map[string]string{"unknown": "x"}, nil, nil)
+	assert.Error(t, err)
+}
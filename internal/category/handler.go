@@ -2,27 +2,46 @@
 package category
 
 import (
-	"errors"
+	"fmt"
 	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/filestorage"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// categoryMaxAge is how long clients and proxies may reuse the public category responses.
+const categoryMaxAge = 5 * time.Minute
+
 // Handler struct holds dependencies for category handlers.
 type Handler struct {
-	service Service // Depends on category.Service
-	logger  *zap.Logger
+	service   Service // Depends on category.Service
+	logger    *zap.Logger
+	imageURLs *filestorage.ImageURLBuilder
+	maxAge    time.Duration // Cache-Control max-age of public responses
+
+	uploadLimits common.MultipartLimits // Of artwork uploads
 }
 
 // NewHandler creates a new category handler.
 // It does NOT take auth.TokenService.
-func NewHandler(service Service, logger *zap.Logger) *Handler {
+func NewHandler(service Service, cfg *config.Config, logger *zap.Logger) *Handler {
+	imageURLs := filestorage.NewImageURLBuilder(cfg)
+	maxAge := categoryMaxAge
+	if imageURLs.SigningEnabled() && cfg.ImageURLTTL > 0 && cfg.ImageURLTTL < maxAge {
+		maxAge = cfg.ImageURLTTL // Cached responses must not outlive their signed artwork URLs
+	}
 	return &Handler{
-		service: service,
-		logger:  logger,
+		service:   service,
+		logger:    logger,
+		imageURLs: imageURLs,
+		maxAge:    maxAge,
+
+		uploadLimits: common.MultipartLimits{MaxBytes: cfg.MaxUploadBodyBytes, MaxParts: cfg.MaxMultipartParts, MaxMemory: cfg.MultipartMemoryBytes},
 	}
 }
 
@@ -33,15 +52,30 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMW gin.HandlerFunc
 	{
 		categoryGroup.GET("", h.getAllCategories)
 		categoryGroup.GET("/:idOrSlug", h.getCategory)
+		categoryGroup.GET("/:idOrSlug/attributes", h.getCategoryAttributes)
 
 		adminCategoryGroup := categoryGroup.Group("/admin")
 		adminCategoryGroup.Use(authMW)
 		adminCategoryGroup.Use(adminRoleMW)
 		{
+			adminCategoryGroup.GET("", h.adminGetAllCategories)
 			adminCategoryGroup.POST("", h.adminCreateCategory)
+			adminCategoryGroup.PUT("/order", h.adminReorderCategories)
 			adminCategoryGroup.PUT("/:id", h.adminUpdateCategory)
+			adminCategoryGroup.PUT("/:id/visibility", h.adminSetCategoryVisibility)
+			adminCategoryGroup.PUT("/:id/icon", h.adminUploadArtwork(ArtworkIcon))
+			adminCategoryGroup.DELETE("/:id/icon", h.adminDeleteArtwork(ArtworkIcon))
+			adminCategoryGroup.PUT("/:id/image", h.adminUploadArtwork(ArtworkImage))
+			adminCategoryGroup.DELETE("/:id/image", h.adminDeleteArtwork(ArtworkImage))
+			adminCategoryGroup.PUT("/:id/subcategories/order", h.adminReorderSubCategories)
 			adminCategoryGroup.DELETE("/:id", h.adminDeleteCategory)
-			adminCategoryGroup.POST("/:categoryId/subcategories", h.adminCreateSubCategory)
+			adminCategoryGroup.POST("/:id/subcategories", h.adminCreateSubCategory)
+			adminCategoryGroup.GET("/:id/translations", h.adminListCategoryTranslations)
+			adminCategoryGroup.PUT("/:id/translations/:lang", h.adminUpsertCategoryTranslation)
+			adminCategoryGroup.DELETE("/:id/translations/:lang", h.adminDeleteCategoryTranslation)
+			adminCategoryGroup.POST("/:id/attributes", h.adminCreateAttribute)
+			adminCategoryGroup.PUT("/:id/attributes/:attributeId", h.adminUpdateAttribute)
+			adminCategoryGroup.DELETE("/:id/attributes/:attributeId", h.adminDeleteAttribute)
 		}
 	}
 	subCategoryAdminGroup := router.Group("/subcategories/admin")
@@ -49,6 +83,7 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMW gin.HandlerFunc
 	subCategoryAdminGroup.Use(adminRoleMW)
 	{
 		subCategoryAdminGroup.PUT("/:id", h.adminUpdateSubCategory)
+		subCategoryAdminGroup.PUT("/:id/visibility", h.adminSetSubCategoryVisibility)
 		subCategoryAdminGroup.DELETE("/:id", h.adminDeleteSubCategory)
 	}
 }
@@ -60,10 +95,12 @@ func (h *Handler) getAllCategories(c *gin.Context) {
 		common.RespondWithError(c, err)
 		return
 	}
+	categories = h.service.LocalizeCategories(c.Request.Context(), common.GetLanguageFromContext(c), categories)
 	categoryResponses := make([]CategoryResponse, len(categories))
 	for i, cat := range categories {
-		categoryResponses[i] = ToCategoryResponse(&cat)
+		categoryResponses[i] = ToCategoryResponse(&cat, h.imageURLs)
 	}
+	h.setCacheHeaders(c)
 	common.RespondOK(c, "Categories retrieved successfully.", categoryResponses)
 }
 
@@ -82,19 +119,158 @@ func (h *Handler) getCategory(c *gin.Context) {
 		common.RespondWithError(c, err)
 		return
 	}
-	common.RespondOK(c, "Category retrieved successfully.", ToCategoryResponse(catModel))
+	if !catModel.IsActive {
+		common.RespondWithError(c, common.ErrNotFound.WithDetails("Category not found."))
+		return
+	}
+	catModel.SubCategories = visibleSubCategories(catModel.SubCategories)
+	localized := h.service.LocalizeCategories(c.Request.Context(), common.GetLanguageFromContext(c), []Category{*catModel})
+	h.setCacheHeaders(c)
+	common.RespondOK(c, "Category retrieved successfully.", ToCategoryResponse(&localized[0], h.imageURLs))
+}
+
+// setCacheHeaders lets clients and CDNs cache a public category response. Responses differ by
+// language, which the language middleware already adds to Vary.
+func (h *Handler) setCacheHeaders(c *gin.Context) {
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.maxAge.Seconds())))
+}
+
+// visibleSubCategories leaves out hidden sub-categories.
+func visibleSubCategories(subCategories []SubCategory) []SubCategory {
+	visible := make([]SubCategory, 0, len(subCategories))
+	for _, sc := range subCategories {
+		if sc.IsActive {
+			visible = append(visible, sc)
+		}
+	}
+	return visible
+}
+
+func (h *Handler) adminGetAllCategories(c *gin.Context) {
+	preloadSubcategories := c.Query("include_subcategories") == "true"
+	categories, err := h.service.AdminGetAllCategories(c.Request.Context(), preloadSubcategories)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	categoryResponses := make([]CategoryResponse, len(categories))
+	for i, cat := range categories {
+		categoryResponses[i] = ToCategoryResponse(&cat, h.imageURLs)
+	}
+	common.RespondOK(c, "Categories retrieved successfully.", categoryResponses)
+}
+
+func (h *Handler) adminReorderCategories(c *gin.Context) {
+	var req AdminReorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Admin reorder categories: Invalid request body", zap.Error(err))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	categories, err := h.service.AdminReorderCategories(c.Request.Context(), req.IDs)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	categoryResponses := make([]CategoryResponse, len(categories))
+	for i, cat := range categories {
+		categoryResponses[i] = ToCategoryResponse(&cat, h.imageURLs)
+	}
+	common.RespondOK(c, "Categories reordered successfully.", categoryResponses)
+}
+
+func (h *Handler) adminSetCategoryVisibility(c *gin.Context) {
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid category ID format."))
+		return
+	}
+	var req AdminSetVisibilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Admin set category visibility: Invalid request body", zap.Error(err), zap.String("categoryID", categoryID.String()))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	catModel, err := h.service.AdminSetCategoryVisibility(c.Request.Context(), categoryID, *req.IsActive)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Category visibility updated successfully.", ToCategoryResponse(catModel, h.imageURLs))
+}
+
+func (h *Handler) adminReorderSubCategories(c *gin.Context) {
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid category ID format."))
+		return
+	}
+	var req AdminReorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Admin reorder subcategories: Invalid request body", zap.Error(err), zap.String("categoryID", categoryID.String()))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	subCategories, err := h.service.AdminReorderSubCategories(c.Request.Context(), categoryID, req.IDs)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]SubCategoryResponse, len(subCategories))
+	for i := range subCategories {
+		responses[i] = ToSubCategoryResponse(&subCategories[i])
+	}
+	common.RespondOK(c, "SubCategories reordered successfully.", responses)
+}
+
+// adminUploadArtwork stores the category's icon or image from the multipart field "file".
+func (h *Handler) adminUploadArtwork(artwork Artwork) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		categoryID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid category ID format."))
+			return
+		}
+		if apiErr := common.ParseMultipartForm(c, h.uploadLimits); apiErr != nil {
+			common.RespondWithError(c, apiErr)
+			return
+		}
+		file, err := c.FormFile("file")
+		if err != nil {
+			common.RespondWithError(c, common.ErrBadRequest.WithDetails("Missing required 'file' field in multipart form."))
+			return
+		}
+		catModel, err := h.service.AdminSetCategoryArtwork(c.Request.Context(), categoryID, artwork, file)
+		if err != nil {
+			common.RespondWithError(c, err)
+			return
+		}
+		common.RespondOK(c, "Category "+string(artwork)+" updated successfully.", ToCategoryResponse(catModel, h.imageURLs))
+	}
+}
+
+// adminDeleteArtwork removes the category's icon or image.
+func (h *Handler) adminDeleteArtwork(artwork Artwork) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		categoryID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid category ID format."))
+			return
+		}
+		catModel, err := h.service.AdminDeleteCategoryArtwork(c.Request.Context(), categoryID, artwork)
+		if err != nil {
+			common.RespondWithError(c, err)
+			return
+		}
+		common.RespondOK(c, "Category "+string(artwork)+" removed successfully.", ToCategoryResponse(catModel, h.imageURLs))
+	}
 }
 
 func (h *Handler) adminCreateCategory(c *gin.Context) {
 	var req AdminCreateCategoryRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Warn("Admin create category: Invalid request body", zap.Error(err))
-		var ve validator.ValidationErrors
-		if errors.As(err, &ve) {
-			common.RespondWithError(c, common.NewValidationAPIError(common.FormatValidationErrors(ve)))
-			return
-		}
-		common.RespondWithError(c, common.ErrBadRequest.WithDetails(err.Error()))
+		common.RespondWithError(c, common.BindingError(err))
 		return
 	}
 	catModel, err := h.service.AdminCreateCategory(c.Request.Context(), req)
@@ -102,7 +278,7 @@ func (h *Handler) adminCreateCategory(c *gin.Context) {
 		common.RespondWithError(c, err)
 		return
 	}
-	common.RespondCreated(c, "Category created successfully.", ToCategoryResponse(catModel))
+	common.RespondCreated(c, "Category created successfully.", ToCategoryResponse(catModel, h.imageURLs))
 }
 
 func (h *Handler) adminUpdateCategory(c *gin.Context) {
@@ -114,12 +290,7 @@ func (h *Handler) adminUpdateCategory(c *gin.Context) {
 	var req AdminCreateCategoryRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Warn("Admin update category: Invalid request body", zap.Error(err), zap.String("categoryID", categoryID.String()))
-		var ve validator.ValidationErrors
-		if errors.As(err, &ve) {
-			common.RespondWithError(c, common.NewValidationAPIError(common.FormatValidationErrors(ve)))
-			return
-		}
-		common.RespondWithError(c, common.ErrBadRequest.WithDetails(err.Error()))
+		common.RespondWithError(c, common.BindingError(err))
 		return
 	}
 	catModel, err := h.service.AdminUpdateCategory(c.Request.Context(), categoryID, req)
@@ -127,7 +298,7 @@ func (h *Handler) adminUpdateCategory(c *gin.Context) {
 		common.RespondWithError(c, err)
 		return
 	}
-	common.RespondOK(c, "Category updated successfully.", ToCategoryResponse(catModel))
+	common.RespondOK(c, "Category updated successfully.", ToCategoryResponse(catModel, h.imageURLs))
 }
 
 func (h *Handler) adminDeleteCategory(c *gin.Context) {
@@ -144,7 +315,7 @@ func (h *Handler) adminDeleteCategory(c *gin.Context) {
 }
 
 func (h *Handler) adminCreateSubCategory(c *gin.Context) {
-	categoryID, err := uuid.Parse(c.Param("categoryId"))
+	categoryID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid parent category ID format."))
 		return
@@ -152,12 +323,7 @@ func (h *Handler) adminCreateSubCategory(c *gin.Context) {
 	var req AdminCreateSubCategoryRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Warn("Admin create subcategory: Invalid request body", zap.Error(err), zap.String("categoryID", categoryID.String()))
-		var ve validator.ValidationErrors
-		if errors.As(err, &ve) {
-			common.RespondWithError(c, common.NewValidationAPIError(common.FormatValidationErrors(ve)))
-			return
-		}
-		common.RespondWithError(c, common.ErrBadRequest.WithDetails(err.Error()))
+		common.RespondWithError(c, common.BindingError(err))
 		return
 	}
 	subCatModel, err := h.service.AdminCreateSubCategory(c.Request.Context(), categoryID, req)
@@ -177,12 +343,7 @@ func (h *Handler) adminUpdateSubCategory(c *gin.Context) {
 	var req AdminCreateSubCategoryRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Warn("Admin update subcategory: Invalid request body", zap.Error(err), zap.String("subCategoryID", subCategoryID.String()))
-		var ve validator.ValidationErrors
-		if errors.As(err, &ve) {
-			common.RespondWithError(c, common.NewValidationAPIError(common.FormatValidationErrors(ve)))
-			return
-		}
-		common.RespondWithError(c, common.ErrBadRequest.WithDetails(err.Error()))
+		common.RespondWithError(c, common.BindingError(err))
 		return
 	}
 	subCatModel, err := h.service.AdminUpdateSubCategory(c.Request.Context(), subCategoryID, req)
@@ -205,3 +366,163 @@ func (h *Handler) adminDeleteSubCategory(c *gin.Context) {
 	}
 	common.RespondNoContent(c)
 }
+
+func (h *Handler) adminSetSubCategoryVisibility(c *gin.Context) {
+	subCategoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid subcategory ID format."))
+		return
+	}
+	var req AdminSetVisibilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Admin set subcategory visibility: Invalid request body", zap.Error(err), zap.String("subCategoryID", subCategoryID.String()))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	subCatModel, err := h.service.AdminSetSubCategoryVisibility(c.Request.Context(), subCategoryID, *req.IsActive)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "SubCategory visibility updated successfully.", ToSubCategoryResponse(subCatModel))
+}
+
+func (h *Handler) adminListCategoryTranslations(c *gin.Context) {
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid category ID format."))
+		return
+	}
+	translations, err := h.service.AdminListCategoryTranslations(c.Request.Context(), categoryID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]CategoryTranslationResponse, len(translations))
+	for i := range translations {
+		responses[i] = ToCategoryTranslationResponse(&translations[i])
+	}
+	common.RespondOK(c, "Category translations retrieved successfully.", responses)
+}
+
+func (h *Handler) adminUpsertCategoryTranslation(c *gin.Context) {
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid category ID format."))
+		return
+	}
+	language := strings.ToLower(c.Param("lang"))
+	var req AdminUpsertCategoryTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Admin upsert category translation: Invalid request body", zap.Error(err), zap.String("categoryID", categoryID.String()), zap.String("language", language))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	translation, err := h.service.AdminUpsertCategoryTranslation(c.Request.Context(), categoryID, language, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Category translation saved successfully.", ToCategoryTranslationResponse(translation))
+}
+
+func (h *Handler) adminDeleteCategoryTranslation(c *gin.Context) {
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid category ID format."))
+		return
+	}
+	if err := h.service.AdminDeleteCategoryTranslation(c.Request.Context(), categoryID, strings.ToLower(c.Param("lang"))); err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondNoContent(c)
+}
+
+func (h *Handler) getCategoryAttributes(c *gin.Context) {
+	idOrSlug := c.Param("idOrSlug")
+	var catModel *Category
+	var err error
+	if catID, parseErr := uuid.Parse(idOrSlug); parseErr == nil {
+		catModel, err = h.service.GetCategoryByID(c.Request.Context(), catID, false)
+	} else {
+		catModel, err = h.service.GetCategoryBySlug(c.Request.Context(), idOrSlug, false)
+	}
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	attributes, err := h.service.GetAttributeDefinitions(c.Request.Context(), catModel.ID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]AttributeDefinitionResponse, len(attributes))
+	for i := range attributes {
+		responses[i] = ToAttributeDefinitionResponse(&attributes[i])
+	}
+	common.RespondOK(c, "Category attributes retrieved successfully.", responses)
+}
+
+func (h *Handler) adminCreateAttribute(c *gin.Context) {
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid category ID format."))
+		return
+	}
+	var req AdminCreateAttributeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Admin create category attribute: Invalid request body", zap.Error(err), zap.String("categoryID", categoryID.String()))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	attribute, err := h.service.AdminCreateAttribute(c.Request.Context(), categoryID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondCreated(c, "Category attribute created successfully.", ToAttributeDefinitionResponse(attribute))
+}
+
+func (h *Handler) adminUpdateAttribute(c *gin.Context) {
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid category ID format."))
+		return
+	}
+	attributeID, err := uuid.Parse(c.Param("attributeId"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid attribute ID format."))
+		return
+	}
+	var req AdminUpdateAttributeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Admin update category attribute: Invalid request body", zap.Error(err), zap.String("attributeID", attributeID.String()))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	attribute, err := h.service.AdminUpdateAttribute(c.Request.Context(), categoryID, attributeID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Category attribute updated successfully.", ToAttributeDefinitionResponse(attribute))
+}
+
+func (h *Handler) adminDeleteAttribute(c *gin.Context) {
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid category ID format."))
+		return
+	}
+	attributeID, err := uuid.Parse(c.Param("attributeId"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid attribute ID format."))
+		return
+	}
+	if err := h.service.AdminDeleteAttribute(c.Request.Context(), categoryID, attributeID); err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondNoContent(c)
+}
@@ -3,9 +3,11 @@ package category
 
 import (
 	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/filestorage"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq" // For pq.StringArray
 )
 
 // Category represents the category model in the database.
@@ -14,6 +16,11 @@ type Category struct {
 	Name             string        `gorm:"type:varchar(100);not null;uniqueIndex:idx_categories_name,unique"`
 	Slug             string        `gorm:"type:varchar(100);not null;uniqueIndex:idx_categories_slug,unique"`
 	Description      *string       `gorm:"type:text"`
+	DisplayOrder     int           `gorm:"not null;default:0"`    // Position in category lists, ascending
+	IsActive         bool          `gorm:"not null;default:true"` // Hidden categories are left out of public lists and listing browsing
+	IconPath         *string       `gorm:"type:varchar(255)"`     // Stored file path, relative to the image storage root
+	ImagePath        *string       `gorm:"type:varchar(255)"`
+	LifespanDays     *int          // Days listings stay live before they expire; nil uses the default lifespan
 	SubCategories    []SubCategory `gorm:"foreignKey:CategoryID;constraint:OnDelete:CASCADE;"`
 	SubCategoryCount int           `gorm:"column:sub_category_count;->"` // read-only, no writes
 }
@@ -26,11 +33,13 @@ func (Category) TableName() string {
 // SubCategory represents the sub_category model in the database.
 type SubCategory struct {
 	common.BaseModel
-	CategoryID  uuid.UUID `gorm:"type:uuid;not null"`
-	Category    Category  `gorm:"foreignKey:CategoryID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	Name        string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_sub_categories_category_id_name,unique,composite:unique_name_in_category"`
-	Slug        string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_sub_categories_category_id_slug,unique,composite:unique_slug_in_category"`
-	Description *string   `gorm:"type:text"`
+	CategoryID   uuid.UUID `gorm:"type:uuid;not null"`
+	Category     Category  `gorm:"foreignKey:CategoryID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Name         string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_sub_categories_category_id_name,unique,composite:unique_name_in_category"`
+	Slug         string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_sub_categories_category_id_slug,unique,composite:unique_slug_in_category"`
+	Description  *string   `gorm:"type:text"`
+	DisplayOrder int       `gorm:"not null;default:0"`    // Position within the category, ascending
+	IsActive     bool      `gorm:"not null;default:true"` // Hidden sub-categories are left out of public lists and listing browsing
 }
 
 // TableName specifies the table name for the SubCategory model.
@@ -38,6 +47,49 @@ func (SubCategory) TableName() string {
 	return "sub_categories"
 }
 
+// CategoryTranslation holds a category's name and description in a language other than the default.
+type CategoryTranslation struct {
+	CategoryID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Language    string    `gorm:"type:varchar(10);primaryKey"`
+	Name        string    `gorm:"type:varchar(100);not null"`
+	Description *string   `gorm:"type:text"`
+	CreatedAt   time.Time `gorm:"not null;default:current_timestamp"`
+	UpdatedAt   time.Time `gorm:"not null;default:current_timestamp"`
+}
+
+// TableName specifies the table name for the CategoryTranslation model.
+func (CategoryTranslation) TableName() string {
+	return "category_translations"
+}
+
+// AttributeType is the type of value a category attribute holds.
+type AttributeType string
+
+const (
+	AttributeTypeString AttributeType = "string"
+	AttributeTypeNumber AttributeType = "number"
+	AttributeTypeDate   AttributeType = "date" // YYYY-MM-DD
+	AttributeTypeEnum   AttributeType = "enum" // One of AttributeDefinition.Options
+)
+
+// AttributeDefinition is an admin-defined, typed field that listings in a category can carry.
+// Listing values are stored in listings.attributes keyed by Key.
+type AttributeDefinition struct {
+	common.BaseModel
+	CategoryID uuid.UUID      `gorm:"type:uuid;not null"`
+	Key        string         `gorm:"type:varchar(50);not null"`
+	Label      string         `gorm:"type:varchar(100);not null"`
+	Type       AttributeType  `gorm:"type:varchar(20);not null"`
+	Required   bool           `gorm:"not null;default:false"` // Enforced when a listing goes public
+	Options    pq.StringArray `gorm:"type:text[]"`            // Allowed values for enum attributes
+	SortOrder  int            `gorm:"not null;default:0"`
+}
+
+// TableName specifies the table name for the AttributeDefinition model.
+func (AttributeDefinition) TableName() string {
+	return "category_attributes"
+}
+
 // --- DTOs ---
 
 // CategoryResponse defines the structure for category data sent in API responses.
@@ -46,6 +98,11 @@ type CategoryResponse struct {
 	Name             string                `json:"name"`
 	Slug             string                `json:"slug"`
 	Description      *string               `json:"description,omitempty"`
+	IconURL          *string               `json:"icon_url,omitempty"`
+	ImageURL         *string               `json:"image_url,omitempty"`
+	DisplayOrder     int                   `json:"display_order"`
+	IsActive         bool                  `json:"is_active"`
+	LifespanDays     *int                  `json:"lifespan_days,omitempty"`
 	SubCategoryCount int                   `json:"sub_category_count"`
 	SubCategories    []SubCategoryResponse `json:"sub_categories,omitempty"`
 	CreatedAt        time.Time             `json:"created_at"`
@@ -54,17 +111,19 @@ type CategoryResponse struct {
 
 // SubCategoryResponse defines the structure for sub_category data.
 type SubCategoryResponse struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Slug        string    `json:"slug"`
-	Description *string   `json:"description,omitempty"`
-	CategoryID  uuid.UUID `json:"category_id"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	Slug         string    `json:"slug"`
+	Description  *string   `json:"description,omitempty"`
+	CategoryID   uuid.UUID `json:"category_id"`
+	DisplayOrder int       `json:"display_order"`
+	IsActive     bool      `json:"is_active"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
 }
 
-// ToCategoryResponse converts a Category model to a CategoryResponse DTO.
-func ToCategoryResponse(category *Category) CategoryResponse {
+// ToCategoryResponse converts a Category model to a CategoryResponse DTO, resolving artwork paths with imageURLs.
+func ToCategoryResponse(category *Category, imageURLs *filestorage.ImageURLBuilder) CategoryResponse {
 	subCategoryDTOs := make([]SubCategoryResponse, len(category.SubCategories))
 	for i, sc := range category.SubCategories {
 		subCategoryDTOs[i] = ToSubCategoryResponse(&sc)
@@ -74,6 +133,11 @@ func ToCategoryResponse(category *Category) CategoryResponse {
 		Name:             category.Name,
 		Slug:             category.Slug,
 		Description:      category.Description,
+		IconURL:          artworkURL(category.IconPath, imageURLs),
+		ImageURL:         artworkURL(category.ImagePath, imageURLs),
+		DisplayOrder:     category.DisplayOrder,
+		IsActive:         category.IsActive,
+		LifespanDays:     category.LifespanDays,
 		SubCategoryCount: category.SubCategoryCount,
 		SubCategories:    subCategoryDTOs,
 		CreatedAt:        category.CreatedAt,
@@ -81,24 +145,36 @@ func ToCategoryResponse(category *Category) CategoryResponse {
 	}
 }
 
+func artworkURL(storedPath *string, imageURLs *filestorage.ImageURLBuilder) *string {
+	if storedPath == nil || *storedPath == "" {
+		return nil
+	}
+	u := imageURLs.URL(*storedPath)
+	return &u
+}
+
 // ToSubCategoryResponse converts a SubCategory model to a SubCategoryResponse DTO.
 func ToSubCategoryResponse(subCategory *SubCategory) SubCategoryResponse {
 	return SubCategoryResponse{
-		ID:          subCategory.ID,
-		Name:        subCategory.Name,
-		Slug:        subCategory.Slug,
-		Description: subCategory.Description,
-		CategoryID:  subCategory.CategoryID,
-		CreatedAt:   subCategory.CreatedAt,
-		UpdatedAt:   subCategory.UpdatedAt,
+		ID:           subCategory.ID,
+		Name:         subCategory.Name,
+		Slug:         subCategory.Slug,
+		Description:  subCategory.Description,
+		CategoryID:   subCategory.CategoryID,
+		DisplayOrder: subCategory.DisplayOrder,
+		IsActive:     subCategory.IsActive,
+		CreatedAt:    subCategory.CreatedAt,
+		UpdatedAt:    subCategory.UpdatedAt,
 	}
 }
 
-// AdminCreateCategoryRequest for admin creating categories
+// AdminCreateCategoryRequest for admin creating categories.
+// LifespanDays overrides the default listing lifespan for the category; leaving it out uses the default.
 type AdminCreateCategoryRequest struct {
-	Name        string  `json:"name" binding:"required,max=100"`
-	Slug        string  `json:"slug" binding:"required,max=100,alphanumdash"`
-	Description *string `json:"description,omitempty"`
+	Name         string  `json:"name" binding:"required,max=100"`
+	Slug         string  `json:"slug" binding:"required,max=100,alphanumdash"`
+	Description  *string `json:"description,omitempty"`
+	LifespanDays *int    `json:"lifespan_days,omitempty" binding:"omitempty,min=1,max=365"`
 }
 
 // AdminCreateSubCategoryRequest for admin creating subcategories
@@ -107,3 +183,85 @@ type AdminCreateSubCategoryRequest struct {
 	Slug        string  `json:"slug" binding:"required,max=100,alphanumdash"`
 	Description *string `json:"description,omitempty"`
 }
+
+// AdminReorderRequest for admin setting the display order of categories or of a category's sub-categories.
+// IDs lists every one of them, first to last.
+type AdminReorderRequest struct {
+	IDs []uuid.UUID `json:"ids" binding:"required,min=1"`
+}
+
+// AdminSetVisibilityRequest for admin showing or hiding a category or sub-category.
+type AdminSetVisibilityRequest struct {
+	IsActive *bool `json:"is_active" binding:"required"`
+}
+
+// AdminUpsertCategoryTranslationRequest for admin creating or replacing a category translation
+type AdminUpsertCategoryTranslationRequest struct {
+	Name        string  `json:"name" binding:"required,max=100"`
+	Description *string `json:"description,omitempty"`
+}
+
+// CategoryTranslationResponse defines the structure for category translation data.
+type CategoryTranslationResponse struct {
+	CategoryID  uuid.UUID `json:"category_id"`
+	Language    string    `json:"language"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ToCategoryTranslationResponse converts a CategoryTranslation model to a CategoryTranslationResponse DTO.
+func ToCategoryTranslationResponse(t *CategoryTranslation) CategoryTranslationResponse {
+	return CategoryTranslationResponse{
+		CategoryID:  t.CategoryID,
+		Language:    t.Language,
+		Name:        t.Name,
+		Description: t.Description,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+// AdminCreateAttributeRequest for admin defining a category attribute.
+// Key and Type cannot be changed afterwards, since listings already store values under them.
+type AdminCreateAttributeRequest struct {
+	Key       string        `json:"key" binding:"required,max=50"`
+	Label     string        `json:"label" binding:"required,max=100"`
+	Type      AttributeType `json:"type" binding:"required,oneof=string number date enum"`
+	Required  bool          `json:"required"`
+	Options   []string      `json:"options,omitempty"`
+	SortOrder int           `json:"sort_order"`
+}
+
+// AdminUpdateAttributeRequest for admin updating a category attribute.
+type AdminUpdateAttributeRequest struct {
+	Label     *string  `json:"label,omitempty" binding:"omitempty,max=100"`
+	Required  *bool    `json:"required,omitempty"`
+	Options   []string `json:"options,omitempty"`
+	SortOrder *int     `json:"sort_order,omitempty"`
+}
+
+// AttributeDefinitionResponse defines the structure for category attribute data.
+type AttributeDefinitionResponse struct {
+	ID         uuid.UUID     `json:"id"`
+	CategoryID uuid.UUID     `json:"category_id"`
+	Key        string        `json:"key"`
+	Label      string        `json:"label"`
+	Type       AttributeType `json:"type"`
+	Required   bool          `json:"required"`
+	Options    []string      `json:"options,omitempty"`
+	SortOrder  int           `json:"sort_order"`
+}
+
+// ToAttributeDefinitionResponse converts an AttributeDefinition model to an AttributeDefinitionResponse DTO.
+func ToAttributeDefinitionResponse(a *AttributeDefinition) AttributeDefinitionResponse {
+	return AttributeDefinitionResponse{
+		ID:         a.ID,
+		CategoryID: a.CategoryID,
+		Key:        a.Key,
+		Label:      a.Label,
+		Type:       a.Type,
+		Required:   a.Required,
+		Options:    a.Options,
+		SortOrder:  a.SortOrder,
+	}
+}
@@ -20,8 +20,9 @@ type Repository interface {
 	CreateCategory(ctx context.Context, category *Category) error
 	FindCategoryByID(ctx context.Context, id uuid.UUID, preloadSubcategories bool) (*Category, error)
 	FindCategoryBySlug(ctx context.Context, slug string, preloadSubcategories bool) (*Category, error)
-	FindAllCategories(ctx context.Context, preloadSubcategories bool) ([]Category, error)
+	FindAllCategories(ctx context.Context, preloadSubcategories, includeHidden bool) ([]Category, error)
 	UpdateCategory(ctx context.Context, category *Category) error
+	ReorderCategories(ctx context.Context, ids []uuid.UUID) error
 	DeleteCategory(ctx context.Context, id uuid.UUID) error // Deletion might cascade to subcategories
 
 	// SubCategory methods
@@ -29,7 +30,21 @@ type Repository interface {
 	FindSubCategoryByID(ctx context.Context, id uuid.UUID) (*SubCategory, error)
 	FindSubCategoriesByCategoryID(ctx context.Context, categoryID uuid.UUID) ([]SubCategory, error)
 	UpdateSubCategory(ctx context.Context, subCategory *SubCategory) error
+	ReorderSubCategories(ctx context.Context, categoryID uuid.UUID, ids []uuid.UUID) error
 	DeleteSubCategory(ctx context.Context, id uuid.UUID) error
+
+	// Translation methods
+	FindTranslationsByCategoryID(ctx context.Context, categoryID uuid.UUID) ([]CategoryTranslation, error)
+	FindTranslationsForCategories(ctx context.Context, categoryIDs []uuid.UUID, language string) ([]CategoryTranslation, error)
+	UpsertTranslation(ctx context.Context, translation *CategoryTranslation) error
+	DeleteTranslation(ctx context.Context, categoryID uuid.UUID, language string) error
+
+	// Attribute definition methods
+	CreateAttribute(ctx context.Context, attribute *AttributeDefinition) error
+	FindAttributeByID(ctx context.Context, id uuid.UUID) (*AttributeDefinition, error)
+	FindAttributesByCategoryID(ctx context.Context, categoryID uuid.UUID) ([]AttributeDefinition, error)
+	UpdateAttribute(ctx context.Context, attribute *AttributeDefinition) error
+	DeleteAttribute(ctx context.Context, id uuid.UUID) error
 }
 
 // GORMRepository implements the Repository interface using GORM.
@@ -47,6 +62,10 @@ func NewGORMRepository(db *gorm.DB) Repository {
 // CreateCategory creates a new category.
 func (r *GORMRepository) CreateCategory(ctx context.Context, category *Category) error {
 	category.Slug = strings.ToLower(strings.TrimSpace(category.Slug)) // Normalize slug
+	// New categories are listed last
+	if err := r.db.WithContext(ctx).Model(&Category{}).Select("COALESCE(MAX(display_order) + 1, 0)").Scan(&category.DisplayOrder).Error; err != nil {
+		return fmt.Errorf("failed to find next category display order: %w", err)
+	}
 	err := r.db.WithContext(ctx).Create(category).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrDuplicatedKey) || strings.Contains(err.Error(), "unique constraint") {
@@ -62,7 +81,7 @@ func (r *GORMRepository) FindCategoryByID(ctx context.Context, id uuid.UUID, pre
 	var category Category
 	query := r.db.WithContext(ctx)
 	if preloadSubcategories {
-		query = query.Preload("SubCategories")
+		query = query.Preload("SubCategories", orderSubCategories)
 	}
 	err := query.First(&category, "id = ?", id).Error
 	if err != nil {
@@ -80,7 +99,7 @@ func (r *GORMRepository) FindCategoryBySlug(ctx context.Context, slug string, pr
 	normalizedSlug := strings.ToLower(strings.TrimSpace(slug))
 	query := r.db.WithContext(ctx)
 	if preloadSubcategories {
-		query = query.Preload("SubCategories")
+		query = query.Preload("SubCategories", orderSubCategories)
 	}
 	err := query.First(&category, "slug = ?", normalizedSlug).Error
 	if err != nil {
@@ -92,24 +111,32 @@ func (r *GORMRepository) FindCategoryBySlug(ctx context.Context, slug string, pr
 	return &category, nil
 }
 
-// FindAllCategories retrieves all categories, optionally preloading their subcategories.
-func (r *GORMRepository) FindAllCategories(ctx context.Context, preloadSubcategories bool) ([]Category, error) {
+// FindAllCategories retrieves all categories in display order, optionally preloading their subcategories.
+// Hidden categories and sub-categories are left out unless includeHidden is set.
+func (r *GORMRepository) FindAllCategories(ctx context.Context, preloadSubcategories, includeHidden bool) ([]Category, error) {
 	var categories []Category
 	query := r.db.WithContext(ctx).Model(&Category{})
 
 	subQuery := r.db.Model(&SubCategory{}).
 		Select("count(*)").
 		Where("sub_categories.category_id = categories.id")
+	if !includeHidden {
+		subQuery = subQuery.Where("sub_categories.is_active")
+		query = query.Where("categories.is_active")
+	}
 
 	query = query.Select("categories.*, (?) as sub_category_count", subQuery)
 
 	if preloadSubcategories {
 		query = query.Preload("SubCategories", func(db *gorm.DB) *gorm.DB {
-			return db.Order("sub_categories.name ASC")
+			if !includeHidden {
+				db = db.Where("sub_categories.is_active")
+			}
+			return orderSubCategories(db)
 		})
 	}
 
-	err := query.Order("categories.name ASC").Find(&categories).Error
+	err := query.Order("categories.display_order ASC, categories.name ASC").Find(&categories).Error
 	if err != nil {
 		return nil, err
 	}
@@ -117,6 +144,11 @@ func (r *GORMRepository) FindAllCategories(ctx context.Context, preloadSubcatego
 
 }
 
+// orderSubCategories sorts sub-categories in display order.
+func orderSubCategories(db *gorm.DB) *gorm.DB {
+	return db.Order("sub_categories.display_order ASC, sub_categories.name ASC")
+}
+
 // UpdateCategory updates an existing category.
 func (r *GORMRepository) UpdateCategory(ctx context.Context, category *Category) error {
 	if category.Slug != "" {
@@ -132,6 +164,22 @@ func (r *GORMRepository) UpdateCategory(ctx context.Context, category *Category)
 	return nil
 }
 
+// ReorderCategories sets the display order of the categories to the order of ids.
+func (r *GORMRepository) ReorderCategories(ctx context.Context, ids []uuid.UUID) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			if err := tx.Model(&Category{}).Where("id = ?", id).Update("display_order", i).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reorder categories: %w", err)
+	}
+	return nil
+}
+
 // DeleteCategory deletes a category by ID, ensuring no listings are associated.
 func (r *GORMRepository) DeleteCategory(ctx context.Context, id uuid.UUID) error {
 	// The migration `000001_create_initial_tables.up.sql` has `ON DELETE CASCADE` for sub_categories.
@@ -165,7 +213,15 @@ func (r *GORMRepository) DeleteCategory(ctx context.Context, id uuid.UUID) error
 // CreateSubCategory creates a new subcategory.
 func (r *GORMRepository) CreateSubCategory(ctx context.Context, subCategory *SubCategory) error {
 	subCategory.Slug = strings.ToLower(strings.TrimSpace(subCategory.Slug)) // Normalize slug
-	err := r.db.WithContext(ctx).Create(subCategory).Error
+	// New sub-categories are listed last within their category
+	err := r.db.WithContext(ctx).Model(&SubCategory{}).
+		Where("category_id = ?", subCategory.CategoryID).
+		Select("COALESCE(MAX(display_order) + 1, 0)").
+		Scan(&subCategory.DisplayOrder).Error
+	if err != nil {
+		return fmt.Errorf("failed to find next sub-category display order: %w", err)
+	}
+	err = r.db.WithContext(ctx).Create(subCategory).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrDuplicatedKey) || strings.Contains(err.Error(), "unique constraint") {
 			return common.ErrConflict.WithDetails("SubCategory with this name or slug already exists within the parent category.")
@@ -191,7 +247,7 @@ func (r *GORMRepository) FindSubCategoryByID(ctx context.Context, id uuid.UUID)
 // FindSubCategoriesByCategoryID finds all subcategories for a given category ID.
 func (r *GORMRepository) FindSubCategoriesByCategoryID(ctx context.Context, categoryID uuid.UUID) ([]SubCategory, error) {
 	var subCategories []SubCategory
-	err := r.db.WithContext(ctx).Where("category_id = ?", categoryID).Order("name ASC").Find(&subCategories).Error
+	err := orderSubCategories(r.db.WithContext(ctx).Where("category_id = ?", categoryID)).Find(&subCategories).Error
 	return subCategories, err
 }
 
@@ -210,6 +266,25 @@ func (r *GORMRepository) UpdateSubCategory(ctx context.Context, subCategory *Sub
 	return nil
 }
 
+// ReorderSubCategories sets the display order of a category's sub-categories to the order of ids.
+func (r *GORMRepository) ReorderSubCategories(ctx context.Context, categoryID uuid.UUID, ids []uuid.UUID) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			err := tx.Model(&SubCategory{}).
+				Where("id = ? AND category_id = ?", id, categoryID).
+				Update("display_order", i).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reorder sub-categories: %w", err)
+	}
+	return nil
+}
+
 // DeleteSubCategory deletes a subcategory by ID.
 func (r *GORMRepository) DeleteSubCategory(ctx context.Context, id uuid.UUID) error {
 	// Check for associated listings with this subcategory
@@ -237,3 +312,110 @@ func (r *GORMRepository) DeleteSubCategory(ctx context.Context, id uuid.UUID) er
 	}
 	return nil
 }
+
+// --- Translation Methods ---
+
+// FindTranslationsByCategoryID lists all translations of a category.
+func (r *GORMRepository) FindTranslationsByCategoryID(ctx context.Context, categoryID uuid.UUID) ([]CategoryTranslation, error) {
+	var translations []CategoryTranslation
+	err := r.db.WithContext(ctx).Where("category_id = ?", categoryID).Order("language ASC").Find(&translations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list category translations: %w", err)
+	}
+	return translations, nil
+}
+
+// FindTranslationsForCategories retrieves the translations of the given categories into one language.
+func (r *GORMRepository) FindTranslationsForCategories(ctx context.Context, categoryIDs []uuid.UUID, language string) ([]CategoryTranslation, error) {
+	var translations []CategoryTranslation
+	if len(categoryIDs) == 0 {
+		return translations, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("category_id IN ? AND language = ?", categoryIDs, language).
+		Find(&translations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find category translations: %w", err)
+	}
+	return translations, nil
+}
+
+// UpsertTranslation creates a translation or replaces the existing one for the same category and language.
+func (r *GORMRepository) UpsertTranslation(ctx context.Context, translation *CategoryTranslation) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "category_id"}, {Name: "language"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "description", "updated_at"}),
+	}).Create(translation).Error
+	if err != nil {
+		return fmt.Errorf("failed to upsert category translation: %w", err)
+	}
+	return nil
+}
+
+// DeleteTranslation removes a category translation.
+func (r *GORMRepository) DeleteTranslation(ctx context.Context, categoryID uuid.UUID, language string) error {
+	result := r.db.WithContext(ctx).Delete(&CategoryTranslation{}, "category_id = ? AND language = ?", categoryID, language)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete category translation: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound.WithDetails("Category translation not found.")
+	}
+	return nil
+}
+
+// --- Attribute Definition Methods ---
+
+// CreateAttribute creates a new attribute definition.
+func (r *GORMRepository) CreateAttribute(ctx context.Context, attribute *AttributeDefinition) error {
+	err := r.db.WithContext(ctx).Create(attribute).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) || strings.Contains(err.Error(), "unique constraint") {
+			return common.ErrConflict.WithDetails("An attribute with this key already exists in the category.")
+		}
+		return fmt.Errorf("failed to create category attribute: %w", err)
+	}
+	return nil
+}
+
+// FindAttributeByID finds an attribute definition by its ID.
+func (r *GORMRepository) FindAttributeByID(ctx context.Context, id uuid.UUID) (*AttributeDefinition, error) {
+	var attribute AttributeDefinition
+	if err := r.db.WithContext(ctx).First(&attribute, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Category attribute not found.")
+		}
+		return nil, fmt.Errorf("failed to find category attribute: %w", err)
+	}
+	return &attribute, nil
+}
+
+// FindAttributesByCategoryID lists a category's attribute definitions in display order.
+func (r *GORMRepository) FindAttributesByCategoryID(ctx context.Context, categoryID uuid.UUID) ([]AttributeDefinition, error) {
+	var attributes []AttributeDefinition
+	err := r.db.WithContext(ctx).Where("category_id = ?", categoryID).Order("sort_order ASC, key ASC").Find(&attributes).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list category attributes: %w", err)
+	}
+	return attributes, nil
+}
+
+// UpdateAttribute saves an attribute definition.
+func (r *GORMRepository) UpdateAttribute(ctx context.Context, attribute *AttributeDefinition) error {
+	if err := r.db.WithContext(ctx).Save(attribute).Error; err != nil {
+		return fmt.Errorf("failed to update category attribute: %w", err)
+	}
+	return nil
+}
+
+// DeleteAttribute removes an attribute definition. Values already stored on listings are left in place.
+func (r *GORMRepository) DeleteAttribute(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&AttributeDefinition{}, "id = ?", id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete category attribute: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound.WithDetails("Category attribute not found.")
+	}
+	return nil
+}
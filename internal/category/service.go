@@ -4,10 +4,14 @@ package category
 import (
 	"context"
 	"fmt"
+	"mime/multipart"
 	"strings"
+	"time"
 
 	"seattle_info_backend/internal/common"
 	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/filestorage"
+	"seattle_info_backend/internal/i18n"
 
 	"github.com/google/uuid"
 	"github.com/gosimple/slug" // For robust slug generation
@@ -23,27 +27,46 @@ type Service interface {
 	AdminUpdateSubCategory(ctx context.Context, id uuid.UUID, req AdminCreateSubCategoryRequest) (*SubCategory, error)
 	AdminDeleteCategory(ctx context.Context, id uuid.UUID) error
 	AdminDeleteSubCategory(ctx context.Context, id uuid.UUID) error
+	AdminGetAllCategories(ctx context.Context, preloadSubcategories bool) ([]Category, error)
+	AdminReorderCategories(ctx context.Context, ids []uuid.UUID) ([]Category, error)
+	AdminReorderSubCategories(ctx context.Context, categoryID uuid.UUID, ids []uuid.UUID) ([]SubCategory, error)
+	AdminSetCategoryVisibility(ctx context.Context, id uuid.UUID, active bool) (*Category, error)
+	AdminSetSubCategoryVisibility(ctx context.Context, id uuid.UUID, active bool) (*SubCategory, error)
+	AdminSetCategoryArtwork(ctx context.Context, id uuid.UUID, artwork Artwork, file *multipart.FileHeader) (*Category, error)
+	AdminDeleteCategoryArtwork(ctx context.Context, id uuid.UUID, artwork Artwork) (*Category, error)
+	AdminListCategoryTranslations(ctx context.Context, categoryID uuid.UUID) ([]CategoryTranslation, error)
+	AdminUpsertCategoryTranslation(ctx context.Context, categoryID uuid.UUID, language string, req AdminUpsertCategoryTranslationRequest) (*CategoryTranslation, error)
+	AdminDeleteCategoryTranslation(ctx context.Context, categoryID uuid.UUID, language string) error
+	AdminCreateAttribute(ctx context.Context, categoryID uuid.UUID, req AdminCreateAttributeRequest) (*AttributeDefinition, error)
+	AdminUpdateAttribute(ctx context.Context, categoryID, attributeID uuid.UUID, req AdminUpdateAttributeRequest) (*AttributeDefinition, error)
+	AdminDeleteAttribute(ctx context.Context, categoryID, attributeID uuid.UUID) error
 
 	// Public methods
 	GetCategoryByID(ctx context.Context, id uuid.UUID, preloadSubcategories bool) (*Category, error)
 	GetCategoryBySlug(ctx context.Context, slug string, preloadSubcategories bool) (*Category, error)
 	GetAllCategories(ctx context.Context, preloadSubcategories bool) ([]Category, error)
 	GetSubCategoryByID(ctx context.Context, id uuid.UUID) (*SubCategory, error)
+	LocalizeCategories(ctx context.Context, language string, categories []Category) []Category
+	GetAttributeDefinitions(ctx context.Context, categoryID uuid.UUID) ([]AttributeDefinition, error)
+	ValidateListingAttributes(ctx context.Context, categoryID uuid.UUID, values map[string]interface{}, requireAll bool) (map[string]interface{}, error)
+	BuildAttributeFilters(ctx context.Context, categoryID uuid.UUID, equals, minimums, maximums map[string]string) ([]AttributeFilter, error)
 }
 
 // ServiceImplementation implements the category Service interface.
 type ServiceImplementation struct {
-	repo   Repository
-	logger *zap.Logger
-	config *config.Config // If needed for category-specific configs
+	repo        Repository
+	fileStorage *filestorage.FileStorageService // Category artwork
+	logger      *zap.Logger
+	config      *config.Config // If needed for category-specific configs
 }
 
 // NewService creates a new category service.
-func NewService(repo Repository, logger *zap.Logger, cfg *config.Config) Service {
+func NewService(repo Repository, fileStorage *filestorage.FileStorageService, logger *zap.Logger, cfg *config.Config) Service {
 	return &ServiceImplementation{
-		repo:   repo,
-		logger: logger,
-		config: cfg,
+		repo:        repo,
+		fileStorage: fileStorage,
+		logger:      logger,
+		config:      cfg,
 	}
 }
 
@@ -59,9 +82,11 @@ func (s *ServiceImplementation) AdminCreateCategory(ctx context.Context, req Adm
 	}
 
 	category := &Category{
-		Name:        strings.TrimSpace(req.Name),
-		Slug:        finalSlug,
-		Description: req.Description,
+		Name:         strings.TrimSpace(req.Name),
+		Slug:         finalSlug,
+		Description:  req.Description,
+		LifespanDays: req.LifespanDays,
+		IsActive:     true,
 	}
 
 	if err := s.repo.CreateCategory(ctx, category); err != nil {
@@ -93,6 +118,7 @@ func (s *ServiceImplementation) AdminCreateSubCategory(ctx context.Context, cate
 		Name:        strings.TrimSpace(req.Name),
 		Slug:        finalSlug,
 		Description: req.Description,
+		IsActive:    true,
 	}
 
 	if err := s.repo.CreateSubCategory(ctx, subCategory); err != nil {
@@ -118,6 +144,7 @@ func (s *ServiceImplementation) AdminUpdateCategory(ctx context.Context, id uuid
 		category.Slug = slug.Make(req.Name) // Regenerate slug if slug field is empty, based on new name
 	}
 	category.Description = req.Description
+	category.LifespanDays = req.LifespanDays
 
 	if err := s.repo.UpdateCategory(ctx, category); err != nil {
 		s.logger.Error("Failed to update category", zap.Error(err), zap.String("id", id.String()))
@@ -150,12 +177,18 @@ func (s *ServiceImplementation) AdminUpdateSubCategory(ctx context.Context, id u
 	return subCategory, nil
 }
 
-// AdminDeleteCategory deletes a category by its ID.
+// AdminDeleteCategory deletes a category by its ID, along with its artwork files.
 func (s *ServiceImplementation) AdminDeleteCategory(ctx context.Context, id uuid.UUID) error {
+	category, err := s.repo.FindCategoryByID(ctx, id, false)
+	if err != nil {
+		return err
+	}
 	if err := s.repo.DeleteCategory(ctx, id); err != nil {
 		s.logger.Error("Failed to delete category", zap.Error(err), zap.String("id", id.String()))
 		return err
 	}
+	s.deleteArtworkFile(category.IconPath)
+	s.deleteArtworkFile(category.ImagePath)
 	s.logger.Info("Category deleted successfully", zap.String("id", id.String()))
 	return nil
 }
@@ -170,6 +203,275 @@ func (s *ServiceImplementation) AdminDeleteSubCategory(ctx context.Context, id u
 	return nil
 }
 
+// AdminGetAllCategories retrieves all categories in display order, hidden ones included.
+func (s *ServiceImplementation) AdminGetAllCategories(ctx context.Context, preloadSubcategories bool) ([]Category, error) {
+	categories, err := s.repo.FindAllCategories(ctx, preloadSubcategories, true)
+	if err != nil {
+		s.logger.Error("Failed to get all categories for admin", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve categories.")
+	}
+	return categories, nil
+}
+
+// AdminReorderCategories sets the display order of all categories. ids must list every category exactly once.
+func (s *ServiceImplementation) AdminReorderCategories(ctx context.Context, ids []uuid.UUID) ([]Category, error) {
+	categories, err := s.AdminGetAllCategories(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	existing := make([]uuid.UUID, len(categories))
+	for i := range categories {
+		existing[i] = categories[i].ID
+	}
+	if err := validateOrdering(ids, existing, "category"); err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReorderCategories(ctx, ids); err != nil {
+		s.logger.Error("Failed to reorder categories", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not reorder categories.")
+	}
+	s.logger.Info("Categories reordered", zap.Int("count", len(ids)))
+	return s.AdminGetAllCategories(ctx, false)
+}
+
+// AdminReorderSubCategories sets the display order of a category's sub-categories.
+// ids must list every sub-category of the category exactly once.
+func (s *ServiceImplementation) AdminReorderSubCategories(ctx context.Context, categoryID uuid.UUID, ids []uuid.UUID) ([]SubCategory, error) {
+	if _, err := s.repo.FindCategoryByID(ctx, categoryID, false); err != nil {
+		return nil, err
+	}
+	subCategories, err := s.repo.FindSubCategoriesByCategoryID(ctx, categoryID)
+	if err != nil {
+		s.logger.Error("Failed to list sub-categories for reordering", zap.Error(err), zap.String("categoryID", categoryID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not reorder sub-categories.")
+	}
+	existing := make([]uuid.UUID, len(subCategories))
+	for i := range subCategories {
+		existing[i] = subCategories[i].ID
+	}
+	if err := validateOrdering(ids, existing, "sub-category"); err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReorderSubCategories(ctx, categoryID, ids); err != nil {
+		s.logger.Error("Failed to reorder sub-categories", zap.Error(err), zap.String("categoryID", categoryID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not reorder sub-categories.")
+	}
+	s.logger.Info("Sub-categories reordered", zap.String("categoryID", categoryID.String()), zap.Int("count", len(ids)))
+	reordered, err := s.repo.FindSubCategoriesByCategoryID(ctx, categoryID)
+	if err != nil {
+		s.logger.Error("Failed to list reordered sub-categories", zap.Error(err), zap.String("categoryID", categoryID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve sub-categories.")
+	}
+	return reordered, nil
+}
+
+// AdminSetCategoryVisibility shows or hides a category. A hidden category keeps its listings, but neither
+// it nor its listings appear in public lists, and it accepts no new listings.
+func (s *ServiceImplementation) AdminSetCategoryVisibility(ctx context.Context, id uuid.UUID, active bool) (*Category, error) {
+	category, err := s.repo.FindCategoryByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	category.IsActive = active
+	if err := s.repo.UpdateCategory(ctx, category); err != nil {
+		s.logger.Error("Failed to change category visibility", zap.Error(err), zap.String("id", id.String()))
+		return nil, err
+	}
+	s.logger.Info("Category visibility changed", zap.String("id", id.String()), zap.Bool("isActive", active))
+	return category, nil
+}
+
+// AdminSetSubCategoryVisibility shows or hides a sub-category, like AdminSetCategoryVisibility.
+func (s *ServiceImplementation) AdminSetSubCategoryVisibility(ctx context.Context, id uuid.UUID, active bool) (*SubCategory, error) {
+	subCategory, err := s.repo.FindSubCategoryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	subCategory.IsActive = active
+	if err := s.repo.UpdateSubCategory(ctx, subCategory); err != nil {
+		s.logger.Error("Failed to change sub-category visibility", zap.Error(err), zap.String("id", id.String()))
+		return nil, err
+	}
+	s.logger.Info("SubCategory visibility changed", zap.String("id", id.String()), zap.Bool("isActive", active))
+	return subCategory, nil
+}
+
+// validateOrdering checks that ids is a permutation of existing.
+func validateOrdering(ids, existing []uuid.UUID, kind string) error {
+	known := make(map[uuid.UUID]bool, len(existing))
+	for _, id := range existing {
+		known[id] = true
+	}
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if !known[id] {
+			return common.ErrBadRequest.WithDetails(fmt.Sprintf("Unknown %s ID %s.", kind, id))
+		}
+		if seen[id] {
+			return common.ErrBadRequest.WithDetails(fmt.Sprintf("Duplicate %s ID %s.", kind, id))
+		}
+		seen[id] = true
+	}
+	if len(ids) != len(existing) {
+		return common.ErrBadRequest.WithDetails(fmt.Sprintf("Every %s must be listed: expected %d IDs, got %d.", kind, len(existing), len(ids)))
+	}
+	return nil
+}
+
+// AdminListCategoryTranslations lists the translations of a category.
+func (s *ServiceImplementation) AdminListCategoryTranslations(ctx context.Context, categoryID uuid.UUID) ([]CategoryTranslation, error) {
+	if _, err := s.repo.FindCategoryByID(ctx, categoryID, false); err != nil {
+		return nil, err
+	}
+	translations, err := s.repo.FindTranslationsByCategoryID(ctx, categoryID)
+	if err != nil {
+		s.logger.Error("Failed to list category translations", zap.Error(err), zap.String("categoryID", categoryID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve category translations.")
+	}
+	return translations, nil
+}
+
+// AdminUpsertCategoryTranslation creates or replaces a category's translation into language.
+// The default language is stored on the category itself and cannot be translated.
+func (s *ServiceImplementation) AdminUpsertCategoryTranslation(ctx context.Context, categoryID uuid.UUID, language string, req AdminUpsertCategoryTranslationRequest) (*CategoryTranslation, error) {
+	if err := validateTranslationLanguage(language); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.FindCategoryByID(ctx, categoryID, false); err != nil {
+		return nil, err
+	}
+
+	translation := &CategoryTranslation{
+		CategoryID:  categoryID,
+		Language:    language,
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		UpdatedAt:   time.Now(),
+	}
+	if err := s.repo.UpsertTranslation(ctx, translation); err != nil {
+		s.logger.Error("Failed to save category translation", zap.Error(err), zap.String("categoryID", categoryID.String()), zap.String("language", language))
+		return nil, common.ErrInternalServer.WithDetails("Could not save category translation.")
+	}
+	s.logger.Info("Category translation saved", zap.String("categoryID", categoryID.String()), zap.String("language", language))
+	return translation, nil
+}
+
+// AdminDeleteCategoryTranslation removes a category's translation into language.
+func (s *ServiceImplementation) AdminDeleteCategoryTranslation(ctx context.Context, categoryID uuid.UUID, language string) error {
+	if err := validateTranslationLanguage(language); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteTranslation(ctx, categoryID, language); err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return err
+		}
+		s.logger.Error("Failed to delete category translation", zap.Error(err), zap.String("categoryID", categoryID.String()), zap.String("language", language))
+		return common.ErrInternalServer.WithDetails("Could not delete category translation.")
+	}
+	s.logger.Info("Category translation deleted", zap.String("categoryID", categoryID.String()), zap.String("language", language))
+	return nil
+}
+
+// AdminCreateAttribute defines a new typed attribute for listings in a category.
+func (s *ServiceImplementation) AdminCreateAttribute(ctx context.Context, categoryID uuid.UUID, req AdminCreateAttributeRequest) (*AttributeDefinition, error) {
+	if _, err := s.repo.FindCategoryByID(ctx, categoryID, false); err != nil {
+		return nil, err
+	}
+	attribute := &AttributeDefinition{
+		CategoryID: categoryID,
+		Key:        strings.TrimSpace(req.Key),
+		Label:      strings.TrimSpace(req.Label),
+		Type:       req.Type,
+		Required:   req.Required,
+		Options:    req.Options,
+		SortOrder:  req.SortOrder,
+	}
+	if err := validateAttributeDefinition(attribute); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateAttribute(ctx, attribute); err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to create category attribute", zap.Error(err), zap.String("categoryID", categoryID.String()), zap.String("key", attribute.Key))
+		return nil, common.ErrInternalServer.WithDetails("Could not create category attribute.")
+	}
+	s.logger.Info("Category attribute created", zap.String("categoryID", categoryID.String()), zap.String("key", attribute.Key))
+	return attribute, nil
+}
+
+// AdminUpdateAttribute updates an attribute's label, requirement, enum options or display order.
+// Changing enum options does not rewrite values already stored on listings.
+func (s *ServiceImplementation) AdminUpdateAttribute(ctx context.Context, categoryID, attributeID uuid.UUID, req AdminUpdateAttributeRequest) (*AttributeDefinition, error) {
+	attribute, err := s.findCategoryAttribute(ctx, categoryID, attributeID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Label != nil {
+		attribute.Label = strings.TrimSpace(*req.Label)
+	}
+	if req.Required != nil {
+		attribute.Required = *req.Required
+	}
+	if req.Options != nil {
+		attribute.Options = req.Options
+	}
+	if req.SortOrder != nil {
+		attribute.SortOrder = *req.SortOrder
+	}
+	if err := validateAttributeDefinition(attribute); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateAttribute(ctx, attribute); err != nil {
+		s.logger.Error("Failed to update category attribute", zap.Error(err), zap.String("attributeID", attributeID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not update category attribute.")
+	}
+	s.logger.Info("Category attribute updated", zap.String("attributeID", attributeID.String()))
+	return attribute, nil
+}
+
+// AdminDeleteAttribute removes an attribute definition from a category.
+func (s *ServiceImplementation) AdminDeleteAttribute(ctx context.Context, categoryID, attributeID uuid.UUID) error {
+	if _, err := s.findCategoryAttribute(ctx, categoryID, attributeID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteAttribute(ctx, attributeID); err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return err
+		}
+		s.logger.Error("Failed to delete category attribute", zap.Error(err), zap.String("attributeID", attributeID.String()))
+		return common.ErrInternalServer.WithDetails("Could not delete category attribute.")
+	}
+	s.logger.Info("Category attribute deleted", zap.String("attributeID", attributeID.String()))
+	return nil
+}
+
+// findCategoryAttribute loads an attribute and checks it belongs to categoryID.
+func (s *ServiceImplementation) findCategoryAttribute(ctx context.Context, categoryID, attributeID uuid.UUID) (*AttributeDefinition, error) {
+	attribute, err := s.repo.FindAttributeByID(ctx, attributeID)
+	if err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to find category attribute", zap.Error(err), zap.String("attributeID", attributeID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve category attribute.")
+	}
+	if attribute.CategoryID != categoryID {
+		return nil, common.ErrNotFound.WithDetails("Category attribute not found.")
+	}
+	return attribute, nil
+}
+
+func validateTranslationLanguage(language string) error {
+	if language == i18n.DefaultLanguage {
+		return common.ErrBadRequest.WithDetails(fmt.Sprintf("'%s' is the default language; update the category itself instead.", language))
+	}
+	if !i18n.IsSupported(language) {
+		return common.ErrBadRequest.WithDetails(fmt.Sprintf("Unsupported language '%s'. Supported languages: %s.", language, strings.Join(i18n.Languages(), ", ")))
+	}
+	return nil
+}
+
 // --- Public Methods ---
 
 // GetCategoryByID retrieves a category by its ID.
@@ -190,9 +492,9 @@ func (s *ServiceImplementation) GetCategoryBySlug(ctx context.Context, slugToFin
 	return category, nil
 }
 
-// GetAllCategories retrieves all categories, optionally preloading subcategories.
+// GetAllCategories retrieves the visible categories in display order, optionally preloading their visible subcategories.
 func (s *ServiceImplementation) GetAllCategories(ctx context.Context, preloadSubcategories bool) ([]Category, error) {
-	categories, err := s.repo.FindAllCategories(ctx, preloadSubcategories)
+	categories, err := s.repo.FindAllCategories(ctx, preloadSubcategories, false)
 	if err != nil {
 		s.logger.Error("Failed to get all categories", zap.Error(err))
 		return nil, common.ErrInternalServer.WithDetails("Could not retrieve categories.")
@@ -208,3 +510,61 @@ func (s *ServiceImplementation) GetSubCategoryByID(ctx context.Context, id uuid.
 	}
 	return subCategory, nil
 }
+
+// LocalizeCategories replaces category names and descriptions with their translations into language
+// where one exists. Categories without a translation, or any lookup failure, keep the default text.
+func (s *ServiceImplementation) LocalizeCategories(ctx context.Context, language string, categories []Category) []Category {
+	if language == i18n.DefaultLanguage || len(categories) == 0 {
+		return categories
+	}
+	ids := make([]uuid.UUID, len(categories))
+	for i := range categories {
+		ids[i] = categories[i].ID
+	}
+	translations, err := s.repo.FindTranslationsForCategories(ctx, ids, language)
+	if err != nil {
+		s.logger.Warn("Failed to load category translations; serving default language", zap.Error(err), zap.String("language", language))
+		return categories
+	}
+	byCategory := make(map[uuid.UUID]CategoryTranslation, len(translations))
+	for _, t := range translations {
+		byCategory[t.CategoryID] = t
+	}
+	for i := range categories {
+		if t, ok := byCategory[categories[i].ID]; ok {
+			categories[i].Name = t.Name
+			if t.Description != nil {
+				categories[i].Description = t.Description
+			}
+		}
+	}
+	return categories
+}
+
+// GetAttributeDefinitions lists the attributes defined for a category.
+func (s *ServiceImplementation) GetAttributeDefinitions(ctx context.Context, categoryID uuid.UUID) ([]AttributeDefinition, error) {
+	attributes, err := s.repo.FindAttributesByCategoryID(ctx, categoryID)
+	if err != nil {
+		s.logger.Error("Failed to get category attributes", zap.Error(err), zap.String("categoryID", categoryID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve category attributes.")
+	}
+	return attributes, nil
+}
+
+// ValidateListingAttributes validates and normalizes listing attribute values against the category's schema.
+func (s *ServiceImplementation) ValidateListingAttributes(ctx context.Context, categoryID uuid.UUID, values map[string]interface{}, requireAll bool) (map[string]interface{}, error) {
+	defs, err := s.GetAttributeDefinitions(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	return ValidateAttributeValues(defs, values, requireAll)
+}
+
+// BuildAttributeFilters resolves attribute search parameters against the category's schema.
+func (s *ServiceImplementation) BuildAttributeFilters(ctx context.Context, categoryID uuid.UUID, equals, minimums, maximums map[string]string) ([]AttributeFilter, error) {
+	defs, err := s.GetAttributeDefinitions(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	return BuildAttributeFilters(defs, equals, minimums, maximums)
+}
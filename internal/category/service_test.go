@@ -0,0 +1,35 @@
+package category
+
+import (
+	"testing"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateOrdering(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	existing := []uuid.UUID{a, b, c}
+
+	assert.NoError(t, validateOrdering([]uuid.UUID{c, a, b}, existing, "category"))
+
+	for name, ids := range map[string][]uuid.UUID{
+		"missing":   {c, a},
+		"duplicate": {c, a, a},
+		"unknown":   {c, a, b, uuid.New()},
+	} {
+		err := validateOrdering(ids, existing, "category")
+		apiErr, ok := common.IsAPIError(err)
+		if assert.True(t, ok, name) {
+			assert.Equal(t, "BAD_REQUEST", apiErr.Code, name)
+		}
+	}
+}
+
+func TestVisibleSubCategories(t *testing.T) {
+	shown := SubCategory{Name: "Rooms", IsActive: true}
+	hidden := SubCategory{Name: "Summer sublets"}
+	assert.Equal(t, []SubCategory{shown}, visibleSubCategories([]SubCategory{hidden, shown}))
+}
@@ -0,0 +1,74 @@
+// File: internal/common/anonymous.go
+package common
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+const (
+	// anonymousCoordinateStep is the grid, in degrees, that coordinates are snapped to for anonymous browsers:
+	// about 300 m of latitude, and less of longitude at Seattle's latitude.
+	anonymousCoordinateStep = 0.0027
+	// anonymousDistanceStepKM is the step that distances are rounded to, so they do not give away the exact point.
+	anonymousDistanceStepKM = 0.3
+)
+
+// anonymousHiddenFields are removed, at any depth, from responses to anonymous browsers: contact details,
+// street addresses and the email of embedded users.
+var anonymousHiddenFields = []string{"contact_name", "contact_email", "contact_phone", "address_line1", "address_line2", "email"}
+
+// anonymousCoordinateFields are numeric fields snapped to anonymousCoordinateStep.
+var anonymousCoordinateFields = map[string]bool{"latitude": true, "longitude": true, "lat": true, "lon": true}
+
+// TrimForAnonymous removes contact details and exact addresses from response data and coarsens coordinates and
+// distances, for callers browsing without signing in (see IsAnonymousBrowse). RespondSuccess and RespondPaginated
+// apply it, so handlers do not need to. Data that cannot be trimmed is dropped rather than sent as is.
+func TrimForAnonymous(data interface{}) interface{} {
+	if data == nil {
+		return nil
+	}
+	generic, err := toGenericJSON(data)
+	if err != nil {
+		return nil
+	}
+	return trimForAnonymous(generic)
+}
+
+func trimForAnonymous(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range anonymousHiddenFields {
+			delete(v, name)
+		}
+		for key, field := range v {
+			number, ok := field.(json.Number)
+			switch {
+			case ok && anonymousCoordinateFields[key]:
+				v[key] = snapNumber(number, anonymousCoordinateStep, 4)
+			case ok && key == "distance_km":
+				v[key] = snapNumber(number, anonymousDistanceStepKM, 1)
+			default:
+				v[key] = trimForAnonymous(field)
+			}
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = trimForAnonymous(v[i])
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// snapNumber rounds n to the nearest multiple of step, written with the given number of decimals.
+func snapNumber(n json.Number, step float64, decimals int) json.Number {
+	f, err := n.Float64()
+	if err != nil {
+		return n
+	}
+	return json.Number(strconv.FormatFloat(math.Round(f/step)*step, 'f', decimals, 64))
+}
@@ -0,0 +1,70 @@
+package common
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type anonymousTestListing struct {
+	ID           string            `json:"id"`
+	ContactName  string            `json:"contact_name"`
+	AddressLine1 string            `json:"address_line1"`
+	City         string            `json:"city"`
+	Latitude     float64           `json:"latitude"`
+	Longitude    float64           `json:"longitude"`
+	Location     map[string]any    `json:"location"`
+	Distance     float64           `json:"distance_km"`
+	User         map[string]string `json:"user"`
+}
+
+func TestTrimForAnonymous(t *testing.T) {
+	listing := anonymousTestListing{
+		ID:           "l1",
+		ContactName:  "Jane",
+		AddressLine1: "123 Pike St",
+		City:         "Seattle",
+		Latitude:     47.60912,
+		Longitude:    -122.34075,
+		Location:     map[string]any{"lat": 47.60912, "lon": -122.34075},
+		Distance:     1.234,
+		User:         map[string]string{"id": "u1", "email": "jane@example.com", "first_name": "Jane"},
+	}
+
+	trimmed := TrimForAnonymous([]anonymousTestListing{listing}).([]interface{})[0].(map[string]interface{})
+	assert.NotContains(t, trimmed, "contact_name")
+	assert.NotContains(t, trimmed, "address_line1")
+	assert.Equal(t, "Seattle", trimmed["city"])
+	assert.Equal(t, json.Number("47.6091"), trimmed["latitude"])
+	assert.Equal(t, json.Number("-122.3397"), trimmed["longitude"])
+	assert.Equal(t, map[string]interface{}{"lat": json.Number("47.6091"), "lon": json.Number("-122.3397")}, trimmed["location"])
+	assert.Equal(t, json.Number("1.2"), trimmed["distance_km"])
+	assert.Equal(t, map[string]interface{}{"id": "u1", "first_name": "Jane"}, trimmed["user"])
+
+	again := TrimForAnonymous(trimmed).(map[string]interface{})
+	assert.Equal(t, trimmed["latitude"], again["latitude"], "trimming twice changes nothing")
+	assert.Nil(t, TrimForAnonymous(nil))
+}
+
+func TestRespondSuccessTrimsForAnonymousBrowsers(t *testing.T) {
+	for _, anonymous := range []bool{false, true} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/listings/l1", nil)
+		if anonymous {
+			c.Set(AnonymousBrowseKey, true)
+		}
+		RespondOK(c, "ok", map[string]string{"id": "l1", "contact_name": "Jane"})
+
+		var body struct {
+			Data map[string]string `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		_, hasContact := body.Data["contact_name"]
+		assert.Equal(t, !anonymous, hasContact)
+	}
+}
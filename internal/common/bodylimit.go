@@ -0,0 +1,117 @@
+// File: internal/common/bodylimit.go
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// originalBodyKey is the context key for the request body before LimitRequestBody wrapped it.
+const originalBodyKey = "originalRequestBody"
+
+// MultipartLimits bound a multipart request: its size, its number of parts and how much of it is kept in memory.
+// File parts beyond MaxMemory are written to temporary files, which net/http removes once the request is handled.
+type MultipartLimits struct {
+	MaxBytes  int64
+	MaxParts  int
+	MaxMemory int64
+}
+
+// LimitRequestBody caps the request body at maxBytes; reading past it fails with *http.MaxBytesError. It replaces a
+// cap set earlier, so routes that take uploads can raise the global MAX_REQUEST_BODY_BYTES. A body whose declared
+// Content-Length is already over the cap is rejected without reading it.
+func LimitRequestBody(c *gin.Context, maxBytes int64) *APIError {
+	if c.Request.ContentLength > maxBytes {
+		return ErrPayloadTooLarge.WithDetails(fmt.Sprintf("Request bodies may be at most %d bytes.", maxBytes))
+	}
+	CapRequestBody(c, maxBytes)
+	return nil
+}
+
+// CapRequestBody caps the request body at maxBytes like LimitRequestBody, but does not check its declared
+// Content-Length: the body is only refused once it is read past the cap, so a route can still raise the cap first.
+func CapRequestBody(c *gin.Context, maxBytes int64) {
+	body, ok := c.Get(originalBodyKey)
+	if !ok {
+		body = c.Request.Body
+		c.Set(originalBodyKey, body)
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, body.(io.ReadCloser), maxBytes)
+}
+
+// ParseMultipartForm parses the multipart/form-data body of c within limits, before handlers read
+// c.Request.MultipartForm, c.FormFile or bind the form. Parts are counted as the body streams in, so a request with
+// too many of them is refused before they are parsed.
+func ParseMultipartForm(c *gin.Context, limits MultipartLimits) *APIError {
+	if apiErr := LimitRequestBody(c, limits.MaxBytes); apiErr != nil {
+		return apiErr
+	}
+	var counter *partCounter
+	if _, params, err := mime.ParseMediaType(c.GetHeader("Content-Type")); err == nil && params["boundary"] != "" {
+		counter = newPartCounter(c.Request.Body, params["boundary"], limits.MaxParts)
+		c.Request.Body = counter
+	}
+
+	err := c.Request.ParseMultipartForm(limits.MaxMemory)
+	if err == nil {
+		return nil
+	}
+	var tooLarge *http.MaxBytesError
+	switch {
+	case counter != nil && counter.exceeded:
+		return ErrPayloadTooLarge.WithDetails(fmt.Sprintf("Multipart requests may have at most %d parts.", limits.MaxParts))
+	case errors.As(err, &tooLarge):
+		return ErrPayloadTooLarge.WithDetails(fmt.Sprintf("Request bodies may be at most %d bytes.", limits.MaxBytes))
+	case errors.Is(err, multipart.ErrMessageTooLarge):
+		return ErrPayloadTooLarge.WithDetails("The form fields of the request are too large.")
+	case errors.Is(err, http.ErrNotMultipart):
+		return ErrBadRequest.WithDetails("The request must be multipart/form-data.")
+	default:
+		return ErrBadRequest.WithDetails("Invalid multipart form: " + err.Error())
+	}
+}
+
+// errTooManyParts fails the read of a multipart body with more parts than allowed.
+var errTooManyParts = errors.New("too many multipart parts")
+
+// partCounter counts the boundary delimiters of a multipart body as it is read, one before each part and one
+// closing the body, and fails once there are more than maxParts parts.
+type partCounter struct {
+	io.ReadCloser
+	delimiter []byte
+	tail      []byte // End of the previous read, to find delimiters split between reads
+	buf       []byte
+	seen      int
+	max       int
+	exceeded  bool
+}
+
+func newPartCounter(body io.ReadCloser, boundary string, maxParts int) *partCounter {
+	return &partCounter{ReadCloser: body, delimiter: []byte("--" + boundary), max: maxParts + 1}
+}
+
+func (p *partCounter) Read(b []byte) (int, error) {
+	if p.exceeded {
+		return 0, errTooManyParts
+	}
+	n, err := p.ReadCloser.Read(b)
+	if n > 0 {
+		p.buf = append(append(p.buf[:0], p.tail...), b[:n]...)
+		p.seen += bytes.Count(p.buf, p.delimiter)
+		keep := min(len(p.delimiter)-1, len(p.buf))
+		p.tail = append(p.tail[:0], p.buf[len(p.buf)-keep:]...)
+		if p.seen > p.max {
+			// Withhold the bytes read, so the parser stops at the first part over the limit
+			p.exceeded = true
+			return 0, errTooManyParts
+		}
+	}
+	return n, err
+}
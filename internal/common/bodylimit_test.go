@@ -0,0 +1,115 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// multipartRequest builds a multipart request with fields form fields and one file of fileSize bytes.
+func multipartRequest(t *testing.T, fields int, fileSize int) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for i := 0; i < fields; i++ {
+		require.NoError(t, w.WriteField(fmt.Sprintf("field%d", i), "value"))
+	}
+	part, err := w.CreateFormFile("file", "photo.jpg")
+	require.NoError(t, err)
+	_, err = part.Write(bytes.Repeat([]byte("x"), fileSize))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func testContext(req *http.Request) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = req
+	return c
+}
+
+func TestParseMultipartFormStreamsFilesToDisk(t *testing.T) {
+	c := testContext(multipartRequest(t, 3, 64<<10))
+	require.Nil(t, ParseMultipartForm(c, MultipartLimits{MaxBytes: 1 << 20, MaxParts: 4, MaxMemory: 1 << 10}))
+	defer c.Request.MultipartForm.RemoveAll()
+
+	assert.Equal(t, "value", c.Request.FormValue("field2"))
+	file, err := c.FormFile("file")
+	require.NoError(t, err)
+	assert.EqualValues(t, 64<<10, file.Size)
+	f, err := file.Open()
+	require.NoError(t, err)
+	defer f.Close()
+	_, onDisk := f.(interface{ Name() string })
+	assert.True(t, onDisk, "a file larger than MaxMemory is kept in a temporary file")
+}
+
+func TestParseMultipartFormLimits(t *testing.T) {
+	apiErr := ParseMultipartForm(testContext(multipartRequest(t, 10, 10)), MultipartLimits{MaxBytes: 1 << 20, MaxParts: 4, MaxMemory: 1 << 10})
+	require.NotNil(t, apiErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, apiErr.StatusCode)
+	assert.Contains(t, apiErr.Details, "at most 4 parts")
+
+	apiErr = ParseMultipartForm(testContext(multipartRequest(t, 0, 2<<10)), MultipartLimits{MaxBytes: 1 << 10, MaxParts: 4, MaxMemory: 1 << 10})
+	require.NotNil(t, apiErr)
+	assert.Equal(t, "PAYLOAD_TOO_LARGE", apiErr.Code)
+
+	req := multipartRequest(t, 0, 2<<10)
+	req.ContentLength = -1 // Chunked: the limit applies while reading
+	apiErr = ParseMultipartForm(testContext(req), MultipartLimits{MaxBytes: 1 << 10, MaxParts: 4, MaxMemory: 1 << 10})
+	require.NotNil(t, apiErr)
+	assert.Equal(t, "PAYLOAD_TOO_LARGE", apiErr.Code)
+
+	apiErr = ParseMultipartForm(testContext(httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("{}"))), MultipartLimits{MaxBytes: 1 << 10, MaxParts: 4, MaxMemory: 1 << 10})
+	require.NotNil(t, apiErr)
+	assert.Equal(t, "BAD_REQUEST", apiErr.Code)
+}
+
+func TestLimitRequestBodyReplacesEarlierLimit(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(strings.Repeat("x", 100)))
+	req.ContentLength = -1
+	c := testContext(req)
+	require.Nil(t, LimitRequestBody(c, 10))
+	require.Nil(t, LimitRequestBody(c, 1000))
+	data, err := io.ReadAll(c.Request.Body)
+	require.NoError(t, err)
+	assert.Len(t, data, 100)
+
+	c = testContext(httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(strings.Repeat("x", 100))))
+	apiErr := LimitRequestBody(c, 10)
+	require.NotNil(t, apiErr, "a declared length over the limit is refused up front")
+	assert.Equal(t, http.StatusRequestEntityTooLarge, apiErr.StatusCode)
+
+	var tooLarge *http.MaxBytesError
+	c = testContext(req)
+	req.Body = io.NopCloser(strings.NewReader(strings.Repeat("x", 100)))
+	require.Nil(t, LimitRequestBody(c, 10))
+	_, err = io.ReadAll(c.Request.Body)
+	require.ErrorAs(t, err, &tooLarge)
+	assert.Equal(t, "PAYLOAD_TOO_LARGE", BindingError(err).Code)
+}
+
+func TestPartCounterFindsDelimitersAcrossReads(t *testing.T) {
+	body := "--b\r\nx\r\n--b\r\ny\r\n--b--"
+	counter := newPartCounter(io.NopCloser(iotest.OneByteReader(strings.NewReader(body))), "b", 2)
+	_, err := io.ReadAll(counter)
+	require.NoError(t, err)
+	assert.Equal(t, 3, counter.seen)
+
+	counter = newPartCounter(io.NopCloser(iotest.OneByteReader(strings.NewReader(body))), "b", 1)
+	_, err = io.ReadAll(counter)
+	assert.ErrorIs(t, err, errTooManyParts)
+}
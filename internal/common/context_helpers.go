@@ -3,6 +3,9 @@ package common
 
 import (
 	"strings"
+	"time"
+
+	"seattle_info_backend/internal/i18n"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -36,6 +39,39 @@ func GetUserIDFromContext(c *gin.Context) uuid.UUID {
 	return userID
 }
 
+// GetAnonymousSessionIDFromContext retrieves the ID of the caller's anonymous session from the Gin context.
+// Returns uuid.Nil if the request carries no valid anonymous session.
+func GetAnonymousSessionIDFromContext(c *gin.Context) uuid.UUID {
+	val, exists := c.Get(AnonymousSessionIDKey)
+	if !exists {
+		return uuid.Nil
+	}
+	sessionID, ok := val.(uuid.UUID)
+	if !ok {
+		return uuid.Nil
+	}
+	return sessionID
+}
+
+// IsAnonymousBrowse reports whether the request is browsing a public route without signing in.
+func IsAnonymousBrowse(c *gin.Context) bool {
+	return c.GetBool(AnonymousBrowseKey)
+}
+
+// GetAuthTimeFromContext retrieves when the authenticated user signed in from the Gin context.
+// Returns the zero time if not found.
+func GetAuthTimeFromContext(c *gin.Context) time.Time {
+	val, exists := c.Get(AuthTimeKey)
+	if !exists {
+		return time.Time{}
+	}
+	authTime, ok := val.(time.Time)
+	if !ok {
+		return time.Time{}
+	}
+	return authTime
+}
+
 // GetUserRoleFromContext retrieves the user role from the Gin context.
 func GetUserRoleFromContext(c *gin.Context) string {
 	val, exists := c.Get(UserRoleKey)
@@ -61,3 +97,17 @@ func GetFirebaseUIDFromContext(c *gin.Context) string {
 	}
 	return uid
 }
+
+// GetLanguageFromContext retrieves the negotiated response language from the Gin context.
+// Returns the default language if the language middleware did not run.
+func GetLanguageFromContext(c *gin.Context) string {
+	val, exists := c.Get(LanguageKey)
+	if !exists {
+		return i18n.DefaultLanguage
+	}
+	lang, ok := val.(string)
+	if !ok || lang == "" {
+		return i18n.DefaultLanguage
+	}
+	return lang
+}
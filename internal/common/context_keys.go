@@ -14,4 +14,14 @@ const (
 	UserRoleKey = "userRole"
 	// FirebaseUIDKey is the context key for storing the Firebase UID
 	FirebaseUIDKey = "firebaseUID"
+	// AuthTimeKey is the context key for storing when the user signed in, from the ID token's auth_time claim
+	AuthTimeKey = "authTime"
+	// APIKeyIDKey is the context key for storing the ID of the authenticated partner API key
+	APIKeyIDKey = "apiKeyID"
+	// AnonymousSessionIDKey is the context key for storing the ID of the caller's anonymous session
+	AnonymousSessionIDKey = "anonymousSessionID"
+	// AnonymousBrowseKey is the context key set on public routes called without a token; see TrimForAnonymous
+	AnonymousBrowseKey = "anonymousBrowse"
+	// LanguageKey is the context key for storing the negotiated response language
+	LanguageKey = "language"
 )
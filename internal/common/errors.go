@@ -2,11 +2,16 @@
 package common
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"reflect"
 	"strings"
 
+	"seattle_info_backend/internal/i18n"
+
 	// Ensure this is the correct import used by Gin for binding
 	"github.com/go-playground/validator/v10"
 )
@@ -38,9 +43,19 @@ var (
 	ErrForbidden           = NewAPIError(http.StatusForbidden, "FORBIDDEN", "You do not have permission to access this resource.")
 	ErrNotFound            = NewAPIError(http.StatusNotFound, "NOT_FOUND", "The requested resource could not be found.")
 	ErrConflict            = NewAPIError(http.StatusConflict, "CONFLICT", "A conflict occurred with the current state of the resource.")
+	ErrPreconditionFailed  = NewAPIError(http.StatusPreconditionFailed, "PRECONDITION_FAILED", "The resource has changed since it was last retrieved.")
 	ErrUnprocessableEntity = NewAPIError(http.StatusUnprocessableEntity, "UNPROCESSABLE_ENTITY", "The request was well-formed but was unable to be followed due to semantic errors.")
 	ErrInternalServer      = NewAPIError(http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "An unexpected error occurred on the server.")
 	ErrServiceUnavailable  = NewAPIError(http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "The server is currently unable to handle the request.")
+	ErrTooManyRequests     = NewAPIError(http.StatusTooManyRequests, "TOO_MANY_REQUESTS", "Too many requests. Please slow down.")
+	ErrCaptchaRequired     = NewAPIError(http.StatusForbidden, "CAPTCHA_REQUIRED", "A CAPTCHA challenge must be completed for this request.")
+	ErrQueryTooExpensive   = NewAPIError(http.StatusBadRequest, "QUERY_TOO_EXPENSIVE", "The search is too expensive to run. Narrow it or page with a cursor.")
+	ErrPayloadTooLarge     = NewAPIError(http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", "The request body is too large.")
+	// Maintenance mode: writes by non-admins are refused; the details carry the message set by the admins.
+	ErrMaintenance = NewAPIError(http.StatusServiceUnavailable, "MAINTENANCE_MODE", "The service is undergoing maintenance. Browsing still works, but changes are disabled for now.")
+	// Admin routes: the admin has 2FA enabled and must verify it, or ADMIN_2FA_REQUIRED is set and they must enable it.
+	ErrTwoFactorRequired      = NewAPIError(http.StatusForbidden, "TWO_FACTOR_REQUIRED", "Two-factor verification is required for this request.")
+	ErrTwoFactorSetupRequired = NewAPIError(http.StatusForbidden, "TWO_FACTOR_SETUP_REQUIRED", "Two-factor authentication must be set up for this request.")
 )
 
 func IsAPIError(err error) (*APIError, bool) {
@@ -60,36 +75,163 @@ func NewValidationAPIError(details interface{}) *APIError {
 	}
 }
 
-// FormatValidationErrors converts validator.ValidationErrors into a map.
+// ValidationMessage is a single field validation failure: the field's JSON name, the rule it
+// broke and a message. Messages rendered from a validation rule keep the rule's parameter, so
+// that they can be rendered again in another language.
+type ValidationMessage struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"-"`
+	Message string `json:"message"`
+	literal bool   // Message is returned as it is in every language
+}
+
+// NewValidationMessage returns a validation failure with a fixed message, for checks that
+// have no translation of their own.
+func NewValidationMessage(field, rule, message string) ValidationMessage {
+	return ValidationMessage{Field: field, Rule: rule, Message: message, literal: true}
+}
+
+// ValidationMessages lists the validation failures of a request in the order they were found.
+type ValidationMessages []ValidationMessage
+
+// Localize returns a copy of the messages rendered in lang.
+func (v ValidationMessages) Localize(lang string) ValidationMessages {
+	localized := make(ValidationMessages, len(v))
+	for i, m := range v {
+		if !m.literal {
+			m.Message = validationMessage(lang, m.Field, m.Rule, m.Param)
+		}
+		localized[i] = m
+	}
+	return localized
+}
+
+// FormatValidationErrors converts validator.ValidationErrors into validation messages.
+// Messages are rendered in the default language; RespondWithError translates them for the request.
 // Make sure the import for validator.ValidationErrors is "github.com/go-playground/validator/v10"
-func FormatValidationErrors(errs validator.ValidationErrors) map[string]string {
-	errorMap := make(map[string]string)
+func FormatValidationErrors(errs validator.ValidationErrors) ValidationMessages {
+	messages := make(ValidationMessages, 0, len(errs))
 	for _, e := range errs {
-		field := e.Field()
-		var message string
-		switch e.Tag() {
-		case "required":
-			message = fmt.Sprintf("The %s field is required.", strings.ToLower(field))
-		case "email":
-			message = fmt.Sprintf("The %s field must be a valid email address.", strings.ToLower(field))
-		case "min":
-			message = fmt.Sprintf("The %s field must be at least %s characters long.", strings.ToLower(field), e.Param())
-		case "max":
-			message = fmt.Sprintf("The %s field may not be greater than %s characters.", strings.ToLower(field), e.Param())
-		case "alphanumdash":
-			message = fmt.Sprintf("The %s field may only contain alphanumeric characters and dashes.", strings.ToLower(field))
-		case "oneof":
-			message = fmt.Sprintf("The %s field must be one of the following values: %s.", strings.ToLower(field), e.Param())
-		case "latitude":
-			message = fmt.Sprintf("The %s field must be a valid latitude.", strings.ToLower(field))
-		case "longitude":
-			message = fmt.Sprintf("The %s field must be a valid longitude.", strings.ToLower(field))
-		case "datetime":
-			message = fmt.Sprintf("The %s field must be a valid datetime in the format %s.", strings.ToLower(field), e.Param())
-		default:
-			message = fmt.Sprintf("Field validation for '%s' failed on the '%s' tag.", field, e.Tag())
+		field := fieldPath(e)
+		messages = append(messages, ValidationMessage{
+			Field:   field,
+			Rule:    e.Tag(),
+			Param:   e.Param(),
+			Message: validationMessage(i18n.DefaultLanguage, field, e.Tag(), e.Param()),
+		})
+	}
+	return messages
+}
+
+// fieldPath names a failed field by its path below the validated struct, e.g. "event_details.start_date".
+func fieldPath(e validator.FieldError) string {
+	namespace := e.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return e.Field()
+}
+
+// BindingError translates an error from binding or validating a request into an API error.
+// Failed validation rules and JSON values of the wrong type become a VALIDATION_ERROR listing
+// each field; a body that is not JSON at all is a BAD_REQUEST, and one over the size limit PAYLOAD_TOO_LARGE.
+func BindingError(err error) *APIError {
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		return ErrPayloadTooLarge.WithDetails(fmt.Sprintf("Request bodies may be at most %d bytes.", tooLarge.Limit))
+	case errors.As(err, &validationErrs):
+		return NewValidationAPIError(FormatValidationErrors(validationErrs))
+	case errors.As(err, &typeErr):
+		field, kind := typeErr.Field, jsonKind(typeErr.Type)
+		return NewValidationAPIError(ValidationMessages{{
+			Field:   field,
+			Rule:    "type",
+			Param:   kind,
+			Message: validationMessage(i18n.DefaultLanguage, field, "type", kind),
+		}})
+	case errors.As(err, &syntaxErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrBadRequest.WithDetails("The request body is not valid JSON.")
+	default:
+		return ErrBadRequest.WithDetails(err.Error())
+	}
+}
+
+// jsonKind names the JSON type a Go type is decoded from.
+func jsonKind(t reflect.Type) string {
+	if t == nil {
+		return "value"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	case reflect.Ptr:
+		return jsonKind(t.Elem())
+	default:
+		return "value"
+	}
+}
+
+// NewValidator returns a validator that reports failed fields by their JSON name.
+func NewValidator() *validator.Validate {
+	v := validator.New()
+	RegisterJSONFieldNames(v)
+	return v
+}
+
+// RegisterJSONFieldNames makes v report failed fields by their JSON name rather than the Go field name.
+func RegisterJSONFieldNames(v *validator.Validate) {
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
 		}
-		errorMap[field] = message
+		return field.Name
+	})
+}
+
+// validationMessage renders the translation for a validator tag, falling back to the generic message.
+func validationMessage(lang, field, tag, param string) string {
+	key := "validation." + tag
+	if _, ok := i18n.Lookup(i18n.DefaultLanguage, key); !ok {
+		key = "validation.default"
+	}
+	return i18n.T(lang, key, i18n.Vars{
+		"field": strings.ToLower(field),
+		"name":  field,
+		"tag":   tag,
+		"param": param,
+	})
+}
+
+// Localize returns a copy of e with its message, and any validation details, translated into lang.
+// Free-form details are left as they are. The shared error values are never modified.
+func (e *APIError) Localize(lang string) *APIError {
+	localized := *e
+	if msg, ok := i18n.Lookup(lang, "error."+e.Code); ok {
+		localized.Message = msg
+	}
+	if details, ok := e.Details.(ValidationMessages); ok {
+		localized.Details = details.Localize(lang)
 	}
-	return errorMap
+	return &localized
 }
@@ -0,0 +1,70 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bindingTestDetails struct {
+	StartDate string `json:"start_date" validate:"required"`
+}
+
+type bindingTestRequest struct {
+	Title   string              `json:"title" validate:"required,min=5"`
+	Price   float64             `json:"price"`
+	Details *bindingTestDetails `json:"details" validate:"required"`
+}
+
+func TestBindingErrorListsFailedRules(t *testing.T) {
+	err := NewValidator().Struct(bindingTestRequest{Title: "abc", Details: &bindingTestDetails{}})
+	apiErr := BindingError(err)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+	assert.Equal(t, "VALIDATION_ERROR", apiErr.Code)
+	details, ok := apiErr.Details.(ValidationMessages)
+	require.True(t, ok)
+	require.Len(t, details, 2)
+	assert.Equal(t, "title", details[0].Field)
+	assert.Equal(t, "min", details[0].Rule)
+	assert.Equal(t, "The title field must be at least 5 characters long.", details[0].Message)
+	assert.Equal(t, "details.start_date", details[1].Field)
+	assert.Equal(t, "required", details[1].Rule)
+
+	encoded, err := json.Marshal(details[1])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"field":"details.start_date","rule":"required","message":"The details.start_date field is required."}`, string(encoded))
+}
+
+func TestBindingErrorTranslatesDecodeErrors(t *testing.T) {
+	var req bindingTestRequest
+	apiErr := BindingError(json.Unmarshal([]byte(`{"price":"cheap"}`), &req))
+	assert.Equal(t, "VALIDATION_ERROR", apiErr.Code)
+	assert.Equal(t, ValidationMessages{{
+		Field:   "price",
+		Rule:    "type",
+		Param:   "number",
+		Message: "The price field must be of type number.",
+	}}, apiErr.Details)
+
+	apiErr = BindingError(json.Unmarshal([]byte(`{"title":`), &req))
+	assert.Equal(t, "BAD_REQUEST", apiErr.Code)
+
+	apiErr = BindingError(errors.New("strconv.ParseInt: parsing \"x\": invalid syntax"))
+	assert.Equal(t, "BAD_REQUEST", apiErr.Code)
+}
+
+func TestValidationMessagesLocalize(t *testing.T) {
+	messages := ValidationMessages{
+		{Field: "title", Rule: "required", Message: "The title field is required."},
+		NewValidationMessage("attributes.pets", "unknown", "Unknown attribute 'pets' for this category."),
+	}
+	localized := messages.Localize("es")
+	assert.Equal(t, "El campo title es obligatorio.", localized[0].Message)
+	assert.Equal(t, "Unknown attribute 'pets' for this category.", localized[1].Message, "fixed messages are not translated")
+	assert.Equal(t, "The title field is required.", messages[0].Message, "the original is not modified")
+}
@@ -0,0 +1,122 @@
+// File: internal/common/fields.go
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FieldsQueryParam is the query parameter that selects a sparse fieldset, e.g. ?fields=id,title,user.first_name.
+const FieldsQueryParam = "fields"
+
+// FieldSet is a parsed sparse fieldset. Each key is a JSON field to keep; a nil value keeps the whole
+// field, a non-nil one keeps only the listed fields of the nested object.
+type FieldSet map[string]FieldSet
+
+// ParseFieldSet parses a comma-separated list of JSON field names. Nested fields are named with dots.
+// It returns nil, meaning no trimming, when no field is named.
+func ParseFieldSet(raw string) FieldSet {
+	var fs FieldSet
+	for _, path := range strings.Split(raw, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		if fs == nil {
+			fs = FieldSet{}
+		}
+		fs.add(strings.Split(path, "."))
+	}
+	return fs
+}
+
+func (fs FieldSet) add(path []string) {
+	name := path[0]
+	child, seen := fs[name]
+	if seen && child == nil {
+		return // The whole field is already kept
+	}
+	if len(path) == 1 {
+		fs[name] = nil
+		return
+	}
+	if child == nil {
+		child = FieldSet{}
+		fs[name] = child
+	}
+	child.add(path[1:])
+}
+
+// ShapeFields trims data, which must encode to a JSON object or an array of objects, to the fields in fs.
+// An object's "id" is always kept, as in JSON:API sparse fieldsets. Unknown field names are ignored.
+func ShapeFields(data interface{}, fs FieldSet) (interface{}, error) {
+	if fs == nil {
+		return data, nil
+	}
+	generic, err := toGenericJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return fs.apply(generic), nil
+}
+
+// toGenericJSON round-trips data through JSON into maps, slices and json.Number values.
+func toGenericJSON(data interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber() // Keep numbers exactly as encoded
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+func (fs FieldSet) apply(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		trimmed := make(map[string]interface{}, len(fs)+1)
+		if id, ok := v["id"]; ok {
+			trimmed["id"] = id
+		}
+		for name, child := range fs {
+			field, ok := v[name]
+			if !ok {
+				continue
+			}
+			if child == nil {
+				trimmed[name] = field
+			} else {
+				trimmed[name] = child.apply(field)
+			}
+		}
+		return trimmed
+	case []interface{}:
+		for i := range v {
+			v[i] = fs.apply(v[i])
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// SparseFields applies the ?fields= query parameter of the request to response data.
+// Without the parameter, or if data cannot be reshaped, data is returned unchanged.
+func SparseFields(c *gin.Context, data interface{}) interface{} {
+	fs := ParseFieldSet(c.Query(FieldsQueryParam))
+	if fs == nil {
+		return data
+	}
+	shaped, err := ShapeFields(data, fs)
+	if err != nil {
+		return data
+	}
+	return shaped
+}
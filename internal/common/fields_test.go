@@ -0,0 +1,71 @@
+package common
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fieldsOwner struct {
+	ID        string `json:"id"`
+	FirstName string `json:"first_name"`
+	Email     string `json:"email"`
+}
+
+type fieldsItem struct {
+	ID    string       `json:"id"`
+	Title string       `json:"title"`
+	Price float64      `json:"price"`
+	Tags  []string     `json:"tags"`
+	Owner *fieldsOwner `json:"owner,omitempty"`
+}
+
+func TestParseFieldSet(t *testing.T) {
+	assert.Nil(t, ParseFieldSet(""))
+	assert.Nil(t, ParseFieldSet(" , "))
+	assert.Equal(t, FieldSet{"title": nil, "owner": FieldSet{"first_name": nil}}, ParseFieldSet("title, owner.first_name"))
+	assert.Equal(t, FieldSet{"owner": nil}, ParseFieldSet("owner.email,owner"), "the whole object wins over its fields")
+	assert.Equal(t, FieldSet{"owner": nil}, ParseFieldSet("owner,owner.email"))
+}
+
+func TestShapeFields(t *testing.T) {
+	items := []fieldsItem{
+		{ID: "a", Title: "Desk", Price: 12345678.91, Tags: []string{"wood"}, Owner: &fieldsOwner{ID: "u1", FirstName: "Sam", Email: "sam@example.com"}},
+		{ID: "b", Title: "Lamp"},
+	}
+	shaped, err := ShapeFields(items, ParseFieldSet("title,price,owner.first_name,unknown"))
+	require.NoError(t, err)
+	encoded, err := json.Marshal(shaped)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"id":"a","title":"Desk","price":12345678.91,"owner":{"id":"u1","first_name":"Sam"}},
+		{"id":"b","title":"Lamp","price":0}
+	]`, string(encoded))
+
+	single, err := ShapeFields(items[0], ParseFieldSet("tags"))
+	require.NoError(t, err)
+	encoded, err = json.Marshal(single)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"a","tags":["wood"]}`, string(encoded))
+
+	same, err := ShapeFields(items, nil)
+	require.NoError(t, err)
+	assert.Equal(t, items, same)
+}
+
+func TestSparseFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	item := fieldsItem{ID: "a", Title: "Desk"}
+
+	request := func(target string) *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", target, nil)
+		return c
+	}
+	assert.Equal(t, item, SparseFields(request("/items/a"), item))
+	assert.Equal(t, map[string]interface{}{"id": "a", "title": "Desk"}, SparseFields(request("/items/a?fields=title"), item))
+}
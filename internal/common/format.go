@@ -0,0 +1,256 @@
+// File: internal/common/format.go
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// RegionQueryParam selects the region that drives units and date order, e.g. ?region=GB.
+	RegionQueryParam = "region"
+	// RegionHeader is read when the region query parameter is absent.
+	RegionHeader = "X-Region"
+	// DefaultRegion is used when neither the request nor its language tags name a region.
+	DefaultRegion = "US"
+	// FormattedField is the key of the object that FormatHints adds next to formattable fields.
+	FormattedField = "formatted"
+)
+
+// mileRegions are the regions that measure road distances in miles.
+var mileRegions = map[string]bool{"US": true, "GB": true, "LR": true, "MM": true}
+
+// yearFirstRegions write dates as year/month/day.
+var yearFirstRegions = map[string]bool{"CN": true, "TW": true, "HK": true, "JP": true, "KR": true}
+
+// currencySymbols maps ISO 4217 codes to display symbols. Other currencies are shown by code.
+var currencySymbols = map[string]string{
+	"USD": "$", "CAD": "CA$", "MXN": "MX$", "EUR": "€", "GBP": "£", "JPY": "¥", "CNY": "¥", "VND": "₫",
+}
+
+// zeroDecimalCurrencies have no minor unit in everyday use.
+var zeroDecimalCurrencies = map[string]bool{"JPY": true, "VND": true}
+
+// numberStyle is how a language writes numbers and places the currency symbol.
+type numberStyle struct {
+	group, decimal string
+	symbolAfter    bool // "1.234,50 €" rather than "€1,234.50"
+}
+
+var numberStyles = map[string]numberStyle{
+	"en": {group: ",", decimal: "."},
+	"zh": {group: ",", decimal: "."},
+	"es": {group: ".", decimal: ",", symbolAfter: true},
+	"vi": {group: ".", decimal: ",", symbolAfter: true},
+}
+
+// Formatter renders prices, distances and dates for display. The language decides number separators and
+// where the currency symbol goes; the region decides distance units and the order of day, month and year.
+type Formatter struct {
+	Language string
+	Region   string
+}
+
+// FormatterFromContext builds the Formatter for a request from the negotiated language and the region given by
+// the region query parameter, the X-Region header, or the region subtag of ?lang= or Accept-Language, in that order.
+func FormatterFromContext(c *gin.Context) Formatter {
+	region := ""
+	for _, candidate := range []string{c.Query(RegionQueryParam), c.GetHeader(RegionHeader)} {
+		if region = normalizeRegion(candidate); region != "" {
+			break
+		}
+	}
+	if region == "" {
+		region = regionFromLanguageTags(c.Query("lang"))
+	}
+	if region == "" {
+		region = regionFromLanguageTags(c.GetHeader("Accept-Language"))
+	}
+	if region == "" {
+		region = DefaultRegion
+	}
+	return Formatter{Language: GetLanguageFromContext(c), Region: region}
+}
+
+// normalizeRegion returns raw as an upper-case two-letter region code, or "" if it is not one.
+func normalizeRegion(raw string) string {
+	raw = strings.ToUpper(strings.TrimSpace(raw))
+	if len(raw) != 2 || raw[0] < 'A' || raw[0] > 'Z' || raw[1] < 'A' || raw[1] > 'Z' {
+		return ""
+	}
+	return raw
+}
+
+// regionFromLanguageTags returns the region subtag of the first tag in a language list that has one,
+// e.g. "MX" for "es-MX,es;q=0.9".
+func regionFromLanguageTags(tags string) string {
+	for _, tag := range strings.Split(tags, ",") {
+		tag = strings.TrimSpace(strings.SplitN(tag, ";", 2)[0])
+		for _, subtag := range strings.Split(tag, "-")[1:] {
+			if region := normalizeRegion(subtag); region != "" {
+				return region
+			}
+		}
+	}
+	return ""
+}
+
+func (f Formatter) numberStyle() numberStyle {
+	if style, ok := numberStyles[f.Language]; ok {
+		return style
+	}
+	return numberStyles["en"]
+}
+
+// Number writes value with the given number of decimals and the language's separators.
+func (f Formatter) Number(value float64, decimals int) string {
+	style := f.numberStyle()
+	text := fmt.Sprintf("%.*f", decimals, math.Abs(value))
+	whole, fraction := text, ""
+	if i := strings.IndexByte(text, '.'); i >= 0 {
+		whole, fraction = text[:i], text[i+1:]
+	}
+	var b strings.Builder
+	if value < 0 && strings.Trim(text, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(style.group)
+		}
+		b.WriteRune(digit)
+	}
+	if fraction != "" {
+		b.WriteString(style.decimal)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// Price writes an amount in an ISO 4217 currency, e.g. "$1,234.50" or "1.234,50 €".
+func (f Formatter) Price(amount float64, currency string) string {
+	currency = strings.ToUpper(currency)
+	decimals := 2
+	if zeroDecimalCurrencies[currency] {
+		decimals = 0
+	}
+	number := f.Number(math.Abs(amount), decimals)
+	sign := ""
+	if amount < 0 && f.Number(amount, decimals) != number {
+		sign = "-" // Keep the sign ahead of a leading symbol, e.g. "-$5.00"
+	}
+	symbol, ok := currencySymbols[currency]
+	switch {
+	case !ok:
+		return sign + currency + " " + number
+	case f.numberStyle().symbolAfter:
+		return sign + number + " " + symbol
+	default:
+		return sign + symbol + number
+	}
+}
+
+// Distance writes a distance given in kilometres in the region's unit, e.g. "1.2 mi" or "2.0 km".
+func (f Formatter) Distance(km float64) string {
+	if mileRegions[f.Region] {
+		return f.Number(km/1.609344, 1) + " mi"
+	}
+	return f.Number(km, 1) + " km"
+}
+
+// DateLayout returns the time.Format layout for dates in the region.
+func (f Formatter) DateLayout() string {
+	switch {
+	case f.Region == "US":
+		return "01/02/2006"
+	case yearFirstRegions[f.Region]:
+		return "2006/01/02"
+	default:
+		return "02/01/2006"
+	}
+}
+
+// Date writes the calendar date of t in the offset it carries.
+func (f Formatter) Date(t time.Time) string {
+	return t.Format(f.DateLayout())
+}
+
+// Decorate adds a "formatted" object to every object in data, which must be the generic JSON produced by
+// decoding with json.Number, that has a price ({"amount", "currency"}), a distance_km or RFC 3339 timestamps.
+// The formatted object holds display strings under the same keys, with distance_km under "distance".
+func (f Formatter) Decorate(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		formatted := map[string]string{}
+		for key, field := range v {
+			switch field := field.(type) {
+			case map[string]interface{}:
+				if key == "price" {
+					if text, ok := f.formatPrice(field); ok {
+						formatted[key] = text
+					}
+				}
+				f.Decorate(field)
+			case []interface{}:
+				f.Decorate(field)
+			case json.Number:
+				if key == "distance_km" {
+					if km, err := field.Float64(); err == nil {
+						formatted["distance"] = f.Distance(km)
+					}
+				}
+			case string:
+				if t, err := time.Parse(time.RFC3339Nano, field); err == nil {
+					formatted[key] = f.Date(t)
+				}
+			}
+		}
+		if len(formatted) > 0 {
+			v[FormattedField] = formatted
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = f.Decorate(v[i])
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func (f Formatter) formatPrice(price map[string]interface{}) (string, bool) {
+	amount, ok := price["amount"].(json.Number)
+	if !ok {
+		return "", false
+	}
+	currency, ok := price["currency"].(string)
+	if !ok || currency == "" {
+		return "", false
+	}
+	value, err := amount.Float64()
+	if err != nil {
+		return "", false
+	}
+	return f.Price(value, currency), true
+}
+
+// FormatHints adds display strings for prices, distances and dates to response data, formatted for the
+// request's language and region (see FormatterFromContext). If data cannot be decorated it is returned unchanged.
+// For anonymous browsers the data is trimmed first, so the display strings show the coarsened values.
+func FormatHints(c *gin.Context, data interface{}) interface{} {
+	c.Writer.Header().Add("Vary", RegionHeader)
+	if IsAnonymousBrowse(c) {
+		data = TrimForAnonymous(data)
+	}
+	generic, err := toGenericJSON(data)
+	if err != nil {
+		return data
+	}
+	return FormatterFromContext(c).Decorate(generic)
+}
@@ -0,0 +1,98 @@
+package common
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatterPrice(t *testing.T) {
+	en := Formatter{Language: "en", Region: "US"}
+	es := Formatter{Language: "es", Region: "ES"}
+	assert.Equal(t, "$1,234.50", en.Price(1234.5, "usd"))
+	assert.Equal(t, "1.234,50 $", es.Price(1234.5, "USD"))
+	assert.Equal(t, "€0.99", en.Price(0.99, "EUR"))
+	assert.Equal(t, "1.500.000 ₫", Formatter{Language: "vi", Region: "VN"}.Price(1500000, "VND"))
+	assert.Equal(t, "CHF 12.00", en.Price(12, "CHF"))
+	assert.Equal(t, "-$5.00", en.Price(-5, "USD"))
+}
+
+func TestFormatterDistanceAndDate(t *testing.T) {
+	day := time.Date(2024, time.March, 7, 18, 0, 0, 0, time.UTC)
+
+	us := Formatter{Language: "en", Region: "US"}
+	assert.Equal(t, "1.0 mi", us.Distance(1.609344))
+	assert.Equal(t, "03/07/2024", us.Date(day))
+
+	mx := Formatter{Language: "es", Region: "MX"}
+	assert.Equal(t, "2,5 km", mx.Distance(2.5))
+	assert.Equal(t, "07/03/2024", mx.Date(day))
+
+	assert.Equal(t, "2024/03/07", Formatter{Language: "zh", Region: "CN"}.Date(day))
+}
+
+func TestFormatterFromContext(t *testing.T) {
+	tests := []struct {
+		name, url, acceptLanguage, regionHeader string
+		want                                    string
+	}{
+		{name: "default", url: "/", want: "US"},
+		{name: "query", url: "/?region=gb", regionHeader: "MX", want: "GB"},
+		{name: "header", url: "/", regionHeader: "mx", want: "MX"},
+		{name: "invalid query falls through", url: "/?region=mexico", regionHeader: "MX", want: "MX"},
+		{name: "lang subtag", url: "/?lang=es-MX", acceptLanguage: "en-GB", want: "MX"},
+		{name: "accept-language subtag", url: "/", acceptLanguage: "zh-Hans-TW,zh;q=0.9", want: "TW"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", tt.url, nil)
+			c.Request.Header.Set("Accept-Language", tt.acceptLanguage)
+			c.Request.Header.Set(RegionHeader, tt.regionHeader)
+			c.Set(LanguageKey, "es")
+
+			f := FormatterFromContext(c)
+			assert.Equal(t, tt.want, f.Region)
+			assert.Equal(t, "es", f.Language)
+		})
+	}
+}
+
+func TestFormatHints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/?region=DE", nil)
+	c.Set(LanguageKey, "es")
+
+	type price struct {
+		Amount   float64 `json:"amount"`
+		Currency string  `json:"currency"`
+	}
+	type item struct {
+		ID        string    `json:"id"`
+		Price     *price    `json:"price,omitempty"`
+		Distance  *float64  `json:"distance_km,omitempty"`
+		CreatedAt time.Time `json:"created_at"`
+		Owner     struct {
+			Name string `json:"name"`
+		} `json:"owner"`
+	}
+	km := 3.26
+	items := []item{
+		{ID: "a", Price: &price{Amount: 1200, Currency: "EUR"}, Distance: &km, CreatedAt: time.Date(2024, time.March, 7, 9, 0, 0, 0, time.UTC)},
+		{ID: "b", CreatedAt: time.Date(2024, time.December, 31, 9, 0, 0, 0, time.UTC)},
+	}
+
+	encoded, err := json.Marshal(FormatHints(c, items))
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"id":"a","price":{"amount":1200,"currency":"EUR"},"distance_km":3.26,"created_at":"2024-03-07T09:00:00Z","owner":{"name":""},
+		 "formatted":{"price":"1.200,00 €","distance":"3,3 km","created_at":"07/03/2024"}},
+		{"id":"b","created_at":"2024-12-31T09:00:00Z","owner":{"name":""},"formatted":{"created_at":"31/12/2024"}}
+	]`, string(encoded))
+}
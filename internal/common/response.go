@@ -29,11 +29,16 @@ func RespondWithError(c *gin.Context, err error) {
 		apiErr = ErrInternalServer.WithDetails(err.Error()) // ErrInternalServer must be defined in common/errors.go
 	}
 
+	apiErr = apiErr.Localize(GetLanguageFromContext(c))
 	c.AbortWithStatusJSON(apiErr.StatusCode, apiErr)
 }
 
 // RespondSuccess sends a JSON success response.
+// Anonymous browsers get the data trimmed by TrimForAnonymous.
 func RespondSuccess(c *gin.Context, statusCode int, message string, data interface{}) {
+	if IsAnonymousBrowse(c) {
+		data = TrimForAnonymous(data)
+	}
 	response := SuccessResponse{
 		Status:  "success",
 		Message: message,
@@ -65,8 +70,11 @@ type PaginatedResponse struct {
 	Pagination *Pagination `json:"pagination"` // Pagination must be defined in common/model.go or common/pagination.go
 }
 
-// RespondPaginated sends a JSON response for paginated data.
+// RespondPaginated sends a JSON response for paginated data, trimmed like RespondSuccess for anonymous browsers.
 func RespondPaginated(c *gin.Context, message string, data interface{}, pagination *Pagination) {
+	if IsAnonymousBrowse(c) {
+		data = TrimForAnonymous(data)
+	}
 	response := PaginatedResponse{
 		Status:     "success",
 		Message:    message,
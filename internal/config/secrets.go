@@ -0,0 +1,124 @@
+// File: internal/config/secrets.go
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// SecretsProvider fetches secret settings from a secret manager, keyed by setting name (e.g. DB_PASSWORD).
+type SecretsProvider interface {
+	Secrets(ctx context.Context) (map[string]string, error)
+}
+
+// secretsProviders builds the provider named in SECRETS_PROVIDER. Add an entry here to support another secret manager.
+var secretsProviders = map[string]func(cfg *Config) (SecretsProvider, error){
+	"vault": newVaultProvider,
+	"aws":   newAWSSecretsManagerProvider,
+}
+
+// secretsFetchTimeout bounds the call to the secret manager at startup.
+const secretsFetchTimeout = 15 * time.Second
+
+// secretFields returns the settings that hold credentials. Each can be read from the file named by <KEY>_FILE, and,
+// when SECRETS_PROVIDER is set, from the secret manager.
+func (c *Config) secretFields() map[string]*string {
+	return map[string]*string{
+		"DB_PASSWORD":               &c.DBPassword,
+		"DB_REPLICA_DSNS":           &c.DBReplicaDSNs,
+		"MODERATION_API_KEY":        &c.ModerationAPIKey,
+		"CAPTCHA_SECRET_KEY":        &c.CaptchaSecretKey,
+		"SMS_AUTH_TOKEN":            &c.SMSAuthToken,
+		"SMTP_PASSWORD":             &c.SMTPPassword,
+		"TWO_FACTOR_ENCRYPTION_KEY": &c.TwoFactorEncryptionKey,
+		"STRIPE_SECRET_KEY":         &c.StripeSecretKey,
+		"STRIPE_WEBHOOK_SECRET":     &c.StripeWebhookSecret,
+		"IMAGE_URL_SIGNING_SECRET":  &c.ImageURLSigningSecret,
+		"METRICS_TOKEN":             &c.MetricsToken,
+		// Credentials of the secret managers themselves; only files apply to these.
+		"VAULT_TOKEN":           &c.VaultToken,
+		"AWS_SECRET_ACCESS_KEY": &c.AWSSecretAccessKey,
+		"AWS_SESSION_TOKEN":     &c.AWSSessionToken,
+	}
+}
+
+// loadSecrets fills in the secret settings. A value given in the environment or .env wins; otherwise <KEY>_FILE is
+// read; otherwise the secret manager's value, if it has one, replaces the default.
+func loadSecrets(v *viper.Viper, cfg *Config) error {
+	fields := cfg.secretFields()
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	resolved := map[string]bool{}
+	for _, key := range keys {
+		if explicitlySet(v, key) {
+			resolved[key] = true
+		}
+		path := v.GetString(key + "_FILE")
+		if path == "" {
+			continue
+		}
+		if resolved[key] {
+			return fmt.Errorf("both %s and %s_FILE are set; use one of them", key, key)
+		}
+		value, err := readSecretFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading %s_FILE: %w", key, err)
+		}
+		*fields[key] = value
+		resolved[key] = true
+	}
+
+	name := strings.ToLower(strings.TrimSpace(cfg.SecretsProvider))
+	if name == "" {
+		return nil
+	}
+	newProvider, ok := secretsProviders[name]
+	if !ok {
+		return fmt.Errorf("unknown SECRETS_PROVIDER %q (expected vault or aws)", cfg.SecretsProvider)
+	}
+	provider, err := newProvider(cfg)
+	if err != nil {
+		return fmt.Errorf("error configuring the %s secrets provider: %w", name, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), secretsFetchTimeout)
+	defer cancel()
+	secrets, err := provider.Secrets(ctx)
+	if err != nil {
+		return fmt.Errorf("error fetching secrets from %s: %w", name, err)
+	}
+	for _, key := range keys {
+		if value, ok := secrets[key]; ok && !resolved[key] {
+			*fields[key] = value
+		}
+	}
+	return nil
+}
+
+// explicitlySet reports whether key has a non-empty value in the environment or the .env file, as opposed to a
+// default or an empty placeholder copied from .env.example.
+func explicitlySet(v *viper.Viper, key string) bool {
+	if v.GetString(key) == "" {
+		return false
+	}
+	_, inEnv := os.LookupEnv(key)
+	return inEnv || v.InConfig(key)
+}
+
+// readSecretFile reads a secret mounted as a file (Docker and Kubernetes secrets), without the trailing newline.
+func readSecretFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
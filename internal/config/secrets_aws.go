@@ -0,0 +1,150 @@
+// File: internal/config/secrets_aws.go
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// awsSecretsManagerProvider reads secrets from one AWS Secrets Manager secret holding a JSON object whose keys are
+// setting names. Requests are signed with the static credentials in AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.
+type awsSecretsManagerProvider struct {
+	endpoint     string
+	region       string
+	secretID     string
+	accessKeyID  string
+	secretKey    string
+	sessionToken string
+	client       *http.Client
+	now          func() time.Time
+}
+
+func newAWSSecretsManagerProvider(cfg *Config) (SecretsProvider, error) {
+	var missing []string
+	for _, setting := range []struct{ name, value string }{
+		{"AWS_REGION", cfg.AWSRegion},
+		{"AWS_SECRETS_MANAGER_SECRET_ID", cfg.AWSSecretsManagerSecretID},
+		{"AWS_ACCESS_KEY_ID", cfg.AWSAccessKeyID},
+		{"AWS_SECRET_ACCESS_KEY", cfg.AWSSecretAccessKey},
+	} {
+		if strings.TrimSpace(setting.value) == "" {
+			missing = append(missing, setting.name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%s must be set", strings.Join(missing, ", "))
+	}
+	endpoint := cfg.AWSSecretsManagerEndpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://secretsmanager.%s.amazonaws.com", cfg.AWSRegion)
+	}
+	return &awsSecretsManagerProvider{
+		endpoint:     strings.TrimRight(endpoint, "/") + "/",
+		region:       cfg.AWSRegion,
+		secretID:     cfg.AWSSecretsManagerSecretID,
+		accessKeyID:  cfg.AWSAccessKeyID,
+		secretKey:    cfg.AWSSecretAccessKey,
+		sessionToken: cfg.AWSSessionToken,
+		client:       &http.Client{Timeout: secretsFetchTimeout},
+		now:          time.Now,
+	}, nil
+}
+
+// Secrets implements SecretsProvider.
+func (p *awsSecretsManagerProvider) Secrets(ctx context.Context) (map[string]string, error) {
+	body, err := json.Marshal(map[string]string{"SecretId": p.secretID})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build Secrets Manager request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	signV4(req, body, "secretsmanager", p.region, p.accessKeyID, p.secretKey, p.sessionToken, p.now())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("AWS Secrets Manager request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("AWS Secrets Manager responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(errBody)))
+	}
+
+	var payload struct {
+		SecretString string `json:"SecretString"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("failed to decode Secrets Manager response: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(payload.SecretString), &fields); err != nil {
+		return nil, fmt.Errorf("secret %s is not a JSON object of settings: %w", p.secretID, err)
+	}
+	return stringFields(fields), nil
+}
+
+// signV4 adds an AWS Signature Version 4 Authorization header to req, for service in region.
+func signV4(req *http.Request, body []byte, service, region, accessKeyID, secretKey, sessionToken string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	if sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(req.Header.Get(name))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{req.Method, path, req.URL.RawQuery, canonicalHeaders.String(), signedHeaders, sha256Hex(body)}, "\n")
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", accessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
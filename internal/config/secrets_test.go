@@ -0,0 +1,135 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestSignV4MatchesAWSTestSuite(t *testing.T) {
+	// "get-vanilla" from the AWS Signature Version 4 test suite.
+	req := httptest.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	req.Header = http.Header{}
+	signV4(req, nil, "service", "us-east-1", "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestLoadSecretsPrecedence(t *testing.T) {
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/secret/data/seattle_info" || r.Header.Get("X-Vault-Token") != "vault-token" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"DB_PASSWORD":"from-vault","STRIPE_SECRET_KEY":"sk_vault","SMS_AUTH_TOKEN":"sms-vault"},"metadata":{"version":3}}}`))
+	}))
+	defer vault.Close()
+
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "vault_token")
+	stripeFile := filepath.Join(dir, "stripe_key")
+	if err := os.WriteFile(tokenFile, []byte("vault-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(stripeFile, []byte("sk_file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("VAULT_TOKEN_FILE", tokenFile)
+	t.Setenv("STRIPE_SECRET_KEY_FILE", stripeFile)
+	t.Setenv("SMS_AUTH_TOKEN", "sms-env")
+
+	v := viper.New()
+	v.SetDefault("DB_PASSWORD", "password")
+	v.AutomaticEnv()
+	cfg := &Config{
+		DBPassword:      "password",
+		SMSAuthToken:    "sms-env",
+		SecretsProvider: "vault",
+		VaultAddr:       vault.URL,
+		VaultSecretPath: "/secret/data/seattle_info",
+	}
+	if err := loadSecrets(v, cfg); err != nil {
+		t.Fatalf("loadSecrets: %v", err)
+	}
+
+	if cfg.DBPassword != "from-vault" {
+		t.Errorf("DBPassword = %q, want the Vault value over the default", cfg.DBPassword)
+	}
+	if cfg.StripeSecretKey != "sk_file" {
+		t.Errorf("StripeSecretKey = %q, want the _FILE value over Vault", cfg.StripeSecretKey)
+	}
+	if cfg.SMSAuthToken != "sms-env" {
+		t.Errorf("SMSAuthToken = %q, want the environment value over Vault", cfg.SMSAuthToken)
+	}
+}
+
+func TestLoadSecretsRejectsValueAndFile(t *testing.T) {
+	t.Setenv("DB_PASSWORD", "secret")
+	t.Setenv("DB_PASSWORD_FILE", filepath.Join(t.TempDir(), "db_password"))
+	v := viper.New()
+	v.AutomaticEnv()
+	if err := loadSecrets(v, &Config{}); err == nil || !strings.Contains(err.Error(), "DB_PASSWORD_FILE") {
+		t.Fatalf("expected a conflict error, got %v", err)
+	}
+}
+
+func TestAWSSecretsManagerProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct{ SecretId string }
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" || body.SecretId != "prod/seattle_info" ||
+			!strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"SecretString": `{"DB_PASSWORD":"from-aws","ROTATION":1}`})
+	}))
+	defer server.Close()
+
+	provider, err := newAWSSecretsManagerProvider(&Config{
+		AWSRegion:                 "us-west-2",
+		AWSAccessKeyID:            "AKID",
+		AWSSecretAccessKey:        "secret",
+		AWSSecretsManagerSecretID: "prod/seattle_info",
+		AWSSecretsManagerEndpoint: server.URL,
+	})
+	if err != nil {
+		t.Fatalf("newAWSSecretsManagerProvider: %v", err)
+	}
+	secrets, err := provider.Secrets(context.Background())
+	if err != nil {
+		t.Fatalf("Secrets: %v", err)
+	}
+	if secrets["DB_PASSWORD"] != "from-aws" || len(secrets) != 1 {
+		t.Errorf("secrets = %v, want only the string DB_PASSWORD", secrets)
+	}
+}
+
+func TestLoadSecretsReadsMetricsTokenFile(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "metrics_token")
+	if err := os.WriteFile(tokenFile, []byte("scrape-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("METRICS_TOKEN_FILE", tokenFile)
+
+	v := viper.New()
+	v.AutomaticEnv()
+	cfg := &Config{}
+	if err := loadSecrets(v, cfg); err != nil {
+		t.Fatalf("loadSecrets: %v", err)
+	}
+	if cfg.MetricsToken != "scrape-token" {
+		t.Errorf("MetricsToken = %q, want the METRICS_TOKEN_FILE value", cfg.MetricsToken)
+	}
+}
@@ -0,0 +1,95 @@
+// File: internal/config/secrets_vault.go
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// vaultProvider reads secrets from one HashiCorp Vault KV secret (version 1 or 2) whose fields are setting names.
+type vaultProvider struct {
+	addr      string
+	token     string
+	namespace string
+	path      string
+	client    *http.Client
+}
+
+func newVaultProvider(cfg *Config) (SecretsProvider, error) {
+	var missing []string
+	for _, setting := range []struct{ name, value string }{
+		{"VAULT_ADDR", cfg.VaultAddr},
+		{"VAULT_TOKEN", cfg.VaultToken},
+		{"VAULT_SECRET_PATH", cfg.VaultSecretPath},
+	} {
+		if strings.TrimSpace(setting.value) == "" {
+			missing = append(missing, setting.name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%s must be set", strings.Join(missing, ", "))
+	}
+	return &vaultProvider{
+		addr:      strings.TrimRight(cfg.VaultAddr, "/"),
+		token:     cfg.VaultToken,
+		namespace: cfg.VaultNamespace,
+		path:      strings.Trim(cfg.VaultSecretPath, "/"),
+		client:    &http.Client{Timeout: secretsFetchTimeout},
+	}, nil
+}
+
+// Secrets implements SecretsProvider.
+func (p *vaultProvider) Secrets(ctx context.Context) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.addr+"/v1/"+p.path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build Vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("Vault responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var payload struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("failed to decode Vault response: %w", err)
+	}
+	// KV version 2 nests the fields under data.data, next to data.metadata.
+	fields := payload.Data
+	if nested, ok := payload.Data["data"]; ok {
+		if _, v2 := payload.Data["metadata"]; v2 {
+			fields = nil
+			if err := json.Unmarshal(nested, &fields); err != nil {
+				return nil, fmt.Errorf("failed to decode Vault KV v2 data: %w", err)
+			}
+		}
+	}
+	return stringFields(fields), nil
+}
+
+// stringFields keeps the string values of a JSON object, which is how secret managers store settings.
+func stringFields(fields map[string]json.RawMessage) map[string]string {
+	secrets := make(map[string]string, len(fields))
+	for key, raw := range fields {
+		var value string
+		if json.Unmarshal(raw, &value) == nil {
+			secrets[key] = value
+		}
+	}
+	return secrets
+}
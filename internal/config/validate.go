@@ -0,0 +1,350 @@
+// File: internal/config/validate.go
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/platform/geo"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ValidationError lists every problem found in the configuration, so they can all be fixed at once.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid configuration (%d problems):\n  - %s", len(e.Problems), strings.Join(e.Problems, "\n  - "))
+}
+
+// Validate checks the configuration as a whole: values the server needs, formats (ports, URLs, schedules, time
+// zones), settings required by the features that are turned on, and options that contradict each other. It returns
+// a *ValidationError listing all the problems, or nil.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	// Server
+	v.oneOf("GIN_MODE", c.GinMode, "debug", "release", "test")
+	v.port("SERVER_PORT", c.ServerPort)
+	v.positive("SERVER_TIMEOUT_SECONDS", int(c.ServerTimeout/time.Second))
+
+	// Database
+	v.required("DB_HOST", c.DBHost)
+	v.port("DB_PORT", c.DBPort)
+	v.required("DB_USER", c.DBUser)
+	v.required("DB_NAME", c.DBName)
+	v.oneOf("DB_SSL_MODE", c.DBSSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+	v.timezone("DB_TIMEZONE", c.DBTimezone)
+	v.positive("DB_MAX_OPEN_CONNS", c.DBMaxOpenConns)
+	v.notNegative("DB_MAX_IDLE_CONNS", c.DBMaxIdleConns)
+	if c.DBMaxOpenConns > 0 && c.DBMaxIdleConns > c.DBMaxOpenConns {
+		v.add("DB_MAX_IDLE_CONNS (%d) must not exceed DB_MAX_OPEN_CONNS (%d)", c.DBMaxIdleConns, c.DBMaxOpenConns)
+	}
+	if c.DBReplicaDSNs != "" {
+		v.positive("DB_REPLICA_HEALTH_CHECK_SECONDS", int(c.DBReplicaHealthCheckInterval/time.Second))
+	}
+
+	// Logging
+	v.oneOf("LOG_LEVEL", strings.ToLower(strings.TrimSpace(c.LogLevel)), "debug", "info", "warn", "warning", "error", "dpanic", "panic", "fatal")
+	logsToFile := c.LogErrorFilePath != ""
+	for _, output := range strings.Split(c.LogOutputs, ",") {
+		switch output = strings.ToLower(strings.TrimSpace(output)); output {
+		case "", "stdout", "stderr":
+		case "file":
+			logsToFile = true
+			v.required("LOG_FILE_PATH (LOG_OUTPUTS includes file)", c.LogFilePath)
+		case "syslog":
+			v.oneOf("LOG_SYSLOG_NETWORK", c.LogSyslogNetwork, "", "udp", "tcp")
+			if (c.LogSyslogNetwork == "") != (c.LogSyslogAddress == "") {
+				v.add("LOG_SYSLOG_NETWORK and LOG_SYSLOG_ADDRESS must be set together (both empty uses the local syslog daemon)")
+			}
+		default:
+			v.add("LOG_OUTPUTS: unknown output %q (expected stdout, stderr, file or syslog)", output)
+		}
+	}
+	if logsToFile {
+		v.positive("LOG_FILE_MAX_SIZE_MB", c.LogFileMaxSizeMB)
+	}
+	if c.LogRequestBodies {
+		v.positive("LOG_BODY_MAX_BYTES", c.LogBodyMaxBytes)
+	}
+	for _, pair := range strings.Split(c.LogSampleRates, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		i := strings.LastIndex(pair, "=")
+		if i <= 0 {
+			v.add("LOG_SAMPLE_RATES: %q must be route=rate", strings.TrimSpace(pair))
+			continue
+		}
+		if rate, err := strconv.ParseFloat(strings.TrimSpace(pair[i+1:]), 64); err != nil || rate < 0 || rate > 1 {
+			v.add("LOG_SAMPLE_RATES: the rate in %q must be between 0 and 1", strings.TrimSpace(pair))
+		}
+	}
+
+	// Listings and platform policies
+	v.positive("DEFAULT_LISTING_LIFESPAN_DAYS", c.DefaultListingLifespanDays)
+	v.positive("MAX_LISTING_DISTANCE_KM", c.MaxListingDistanceKM)
+	v.notNegative("FIRST_POST_APPROVAL_ACTIVE_MONTHS", c.FirstPostApprovalActiveMonths)
+	if _, err := geo.NewServiceArea(c.ServiceAreaPolygon, c.ServiceAreaCenterLat, c.ServiceAreaCenterLon, c.ServiceAreaRadiusKM); err != nil {
+		v.add("SERVICE_AREA_POLYGON / SERVICE_AREA_CENTER_LAT / SERVICE_AREA_CENTER_LON: %v", err)
+	}
+	if c.ServiceAreaRadiusKM < 0 {
+		v.add("SERVICE_AREA_RADIUS_KM must not be negative, got %g", c.ServiceAreaRadiusKM)
+	}
+	if len(c.MaintenanceMessage) > 500 {
+		v.add("MAINTENANCE_MESSAGE must be at most 500 characters")
+	}
+	v.positive("LISTING_IMPORT_MAX_ROWS", c.ListingImportMaxRows)
+	v.notNegative("SEARCH_MAX_QUERY_COST", c.SearchMaxQueryCost)
+	v.file("SEARCH_SYNONYMS_FILE", c.SearchSynonymsFile)
+	v.notNegative("LISTING_CONTACT_REVEALS_PER_HOUR", c.ContactRevealsPerHour)
+	v.notNegative("ANONYMOUS_REQUESTS_PER_MINUTE", c.AnonymousRequestsPerMinute)
+	v.notNegative("LISTING_CONTACT_MESSAGES_PER_HOUR", c.ContactMessagesPerHour)
+	v.notNegative("RECENTLY_VIEWED_LIMIT", c.RecentlyViewedLimit)
+	v.timezone("EVENTS_TIMEZONE", c.EventsTimezone)
+
+	// Content moderation and spam
+	v.url("MODERATION_API_URL", c.ModerationAPIURL)
+	if c.ModerationAPIURL != "" {
+		v.positive("MODERATION_API_TIMEOUT_SECONDS", c.ModerationAPITimeoutSeconds)
+	}
+	v.positive("ANTISPAM_THRESHOLD", c.AntispamThreshold)
+
+	// CAPTCHA
+	v.oneOf("CAPTCHA_PROVIDER", c.CaptchaProvider, "", "recaptcha", "hcaptcha")
+	if c.CaptchaProvider != "" {
+		v.required("CAPTCHA_SECRET_KEY (CAPTCHA_PROVIDER is set)", c.CaptchaSecretKey)
+		v.url("CAPTCHA_VERIFY_URL", c.CaptchaVerifyURL)
+		v.positive("CAPTCHA_TIMEOUT_SECONDS", c.CaptchaTimeoutSeconds)
+		if c.CaptchaMinScore < 0 || c.CaptchaMinScore > 1 {
+			v.add("CAPTCHA_MIN_SCORE must be between 0 and 1, got %g", c.CaptchaMinScore)
+		}
+	}
+
+	// SMS
+	if c.SMSAccountSID != "" {
+		v.url("SMS_API_BASE_URL", c.SMSAPIBaseURL)
+		v.required("SMS_AUTH_TOKEN (SMS_ACCOUNT_SID is set)", c.SMSAuthToken)
+		v.required("SMS_FROM_NUMBER (SMS_ACCOUNT_SID is set)", c.SMSFromNumber)
+	}
+	v.positive("PHONE_VERIFICATION_MAX_ATTEMPTS", c.PhoneVerificationMaxAttempts)
+
+	// Email
+	if c.SMTPHost != "" {
+		v.port("SMTP_PORT", c.SMTPPort)
+		v.required("EMAIL_FROM_ADDRESS (SMTP_HOST is set)", c.EmailFromAddress)
+	}
+	if c.EmailFromAddress != "" && !strings.Contains(c.EmailFromAddress, "@") {
+		v.add("EMAIL_FROM_ADDRESS must be an email address, got %q", c.EmailFromAddress)
+	}
+	if strings.Contains(c.ContactRelayReplyDomain, "@") {
+		v.add("CONTACT_RELAY_REPLY_DOMAIN must be a domain name, got %q", c.ContactRelayReplyDomain)
+	}
+
+	// Webhooks and task queue
+	v.positive("WEBHOOK_MAX_ATTEMPTS", c.WebhookMaxAttempts)
+	v.positive("WEBHOOK_TIMEOUT_SECONDS", c.WebhookTimeoutSeconds)
+	v.positive("QUEUE_WORKERS", c.QueueWorkers)
+	v.positive("QUEUE_MAX_ATTEMPTS", c.QueueMaxAttempts)
+	v.positive("QUEUE_POLL_INTERVAL_MS", int(c.QueuePollInterval/time.Millisecond))
+	v.positive("QUEUE_TASK_TIMEOUT_SECONDS", int(c.QueueTaskTimeout/time.Second))
+
+	// Internal gRPC API
+	if c.GRPCEnabled {
+		v.port("GRPC_PORT", c.GRPCPort)
+		if c.GRPCPort == c.ServerPort {
+			v.add("GRPC_PORT and SERVER_PORT must differ, both are %s", c.GRPCPort)
+		}
+		hasTLS := c.GRPCTLSCertFile != "" || c.GRPCTLSKeyFile != ""
+		switch {
+		case c.GRPCAllowInsecure && c.GRPCTLSClientCAFile != "":
+			v.add("GRPC_ALLOW_INSECURE cannot be combined with GRPC_TLS_CLIENT_CA_FILE")
+		case !c.GRPCAllowInsecure:
+			v.required("GRPC_TLS_CERT_FILE (GRPC_ENABLED without GRPC_ALLOW_INSECURE)", c.GRPCTLSCertFile)
+			v.required("GRPC_TLS_KEY_FILE (GRPC_ENABLED without GRPC_ALLOW_INSECURE)", c.GRPCTLSKeyFile)
+			v.required("GRPC_TLS_CLIENT_CA_FILE (GRPC_ENABLED without GRPC_ALLOW_INSECURE)", c.GRPCTLSClientCAFile)
+		case hasTLS: // TLS without client certificates
+			v.required("GRPC_TLS_CERT_FILE (with GRPC_TLS_KEY_FILE)", c.GRPCTLSCertFile)
+			v.required("GRPC_TLS_KEY_FILE (with GRPC_TLS_CERT_FILE)", c.GRPCTLSKeyFile)
+		}
+		v.file("GRPC_TLS_CERT_FILE", c.GRPCTLSCertFile)
+		v.file("GRPC_TLS_KEY_FILE", c.GRPCTLSKeyFile)
+		v.file("GRPC_TLS_CLIENT_CA_FILE", c.GRPCTLSClientCAFile)
+	}
+
+	// Cron jobs
+	v.schedule("LISTING_EXPIRY_JOB_SCHEDULE", c.ListingExpiryJobSchedule)
+	v.schedule("SAVED_SEARCH_DIGEST_JOB_SCHEDULE", c.SavedSearchDigestJobSchedule)
+	v.schedule("WEBHOOK_DELIVERY_JOB_SCHEDULE", c.WebhookDeliveryJobSchedule)
+	v.schedule("TRENDING_JOB_SCHEDULE", c.TrendingJobSchedule)
+	v.schedule("IMAGE_CONSISTENCY_JOB_SCHEDULE", c.ImageConsistencyJobSchedule)
+	v.schedule("SCHEDULED_PUBLISH_JOB_SCHEDULE", c.ScheduledPublishJobSchedule)
+	v.schedule("FEATURED_EXPIRY_JOB_SCHEDULE", c.FeaturedExpiryJobSchedule)
+	v.schedule("LISTING_STATS_JOB_SCHEDULE", c.ListingStatsJobSchedule)
+	v.schedule("APPROVAL_SLA_JOB_SCHEDULE", c.ApprovalSLAJobSchedule)
+	v.notNegative("APPROVAL_SLA_HOURS", int(c.ApprovalSLA/time.Hour))
+	v.schedule("RENT_BACKFILL_JOB_SCHEDULE", c.RentBackfillJobSchedule)
+	v.notNegative("JOB_HISTORY_RETENTION_DAYS", c.JobHistoryRetentionDays)
+	v.positive("TRENDING_HALF_LIFE_HOURS", int(c.TrendingHalfLife/time.Hour))
+
+	// Two-factor authentication
+	if c.AdminTwoFactorRequired && c.TwoFactorEncryptionKey == "" {
+		v.add("TWO_FACTOR_ENCRYPTION_KEY is required when ADMIN_2FA_REQUIRED is true, or admins could not enroll")
+	}
+	v.positive("TWO_FACTOR_SESSION_TTL_HOURS", int(c.TwoFactorSessionTTL/time.Hour))
+
+	// Firebase
+	if v.required("FIREBASE_SERVICE_ACCOUNT_KEY_PATH", c.FirebaseServiceAccountKeyPath) {
+		v.file("FIREBASE_SERVICE_ACCOUNT_KEY_PATH", c.FirebaseServiceAccountKeyPath)
+	}
+
+	// Payments
+	if c.StripeSecretKey != "" {
+		v.url("STRIPE_API_BASE_URL", c.StripeAPIBaseURL)
+		v.required("STRIPE_WEBHOOK_SECRET (STRIPE_SECRET_KEY is set)", c.StripeWebhookSecret)
+		v.required("WEB_BASE_URL (STRIPE_SECRET_KEY is set, Checkout redirects to the web app)", c.WebBaseURL)
+		v.positive("FEATURED_PRICE_PER_DAY_CENTS", int(c.FeaturedPricePerDayCents))
+		if len(c.PaymentsCurrency) != 3 {
+			v.add("PAYMENTS_CURRENCY must be a three-letter ISO currency code, got %q", c.PaymentsCurrency)
+		}
+	}
+
+	// Web frontend and images
+	v.url("WEB_BASE_URL", c.WebBaseURL)
+	v.required("IMAGE_STORAGE_PATH", c.ImageStoragePath)
+	if !strings.HasPrefix(c.ImagePublicBaseURL, "/") {
+		v.url("IMAGE_PUBLIC_BASE_URL", c.ImagePublicBaseURL)
+	}
+	if c.ImageURLSigningSecret != "" {
+		v.positive("IMAGE_URL_TTL_SECONDS", int(c.ImageURLTTL/time.Second))
+	}
+	v.positive("UPLOAD_MAX_FILE_BYTES", int(c.UploadMaxFileBytes))
+	v.positive("UPLOAD_MAX_CHUNK_BYTES", int(c.UploadMaxChunkBytes))
+	v.positive("UPLOAD_SESSION_TTL_HOURS", int(c.UploadSessionTTL/time.Hour))
+	if c.ClamAVAddress != "" {
+		v.positive("VIRUS_SCAN_TIMEOUT_SECONDS", c.VirusScanTimeoutSeconds)
+	}
+
+	// Request limits
+	v.positive("MAX_REQUEST_BODY_BYTES", int(c.MaxRequestBodyBytes))
+	v.positive("MAX_UPLOAD_BODY_BYTES", int(c.MaxUploadBodyBytes))
+	v.positive("MAX_MULTIPART_PARTS", c.MaxMultipartParts)
+	v.positive("MULTIPART_MEMORY_BYTES", int(c.MultipartMemoryBytes))
+
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+// validator collects problems; its checks return whether the value passed.
+type validator struct {
+	problems []string
+}
+
+func (v *validator) add(format string, args ...interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+func (v *validator) required(name, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		v.add("%s is required", name)
+		return false
+	}
+	return true
+}
+
+func (v *validator) oneOf(name, value string, allowed ...string) bool {
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	v.add("%s must be one of %s, got %q", name, strings.Join(quoted(allowed), ", "), value)
+	return false
+}
+
+func (v *validator) positive(name string, value int) bool {
+	if value <= 0 {
+		v.add("%s must be greater than 0, got %d", name, value)
+		return false
+	}
+	return true
+}
+
+func (v *validator) notNegative(name string, value int) bool {
+	if value < 0 {
+		v.add("%s must not be negative, got %d", name, value)
+		return false
+	}
+	return true
+}
+
+func (v *validator) port(name, value string) bool {
+	if p, err := strconv.Atoi(value); err != nil || p < 1 || p > 65535 {
+		v.add("%s must be a port number between 1 and 65535, got %q", name, value)
+		return false
+	}
+	return true
+}
+
+// url accepts an empty value; callers check required settings separately.
+func (v *validator) url(name, value string) bool {
+	if value == "" {
+		return true
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add("%s must be an absolute http(s) URL, got %q", name, value)
+		return false
+	}
+	return true
+}
+
+// file accepts an empty value; callers check required settings separately.
+func (v *validator) file(name, path string) bool {
+	if path == "" {
+		return true
+	}
+	if _, err := os.Stat(path); err != nil {
+		v.add("%s: file %s cannot be read: %v", name, path, err)
+		return false
+	}
+	return true
+}
+
+func (v *validator) timezone(name, value string) bool {
+	if _, err := time.LoadLocation(value); err != nil {
+		v.add("%s must be an IANA time zone, got %q", name, value)
+		return false
+	}
+	return true
+}
+
+// schedule parses value as the jobs do (standard cron fields or descriptors such as @daily and @every 1m). An empty
+// schedule disables the job.
+func (v *validator) schedule(name, value string) bool {
+	if value == "" {
+		return true
+	}
+	if _, err := cron.ParseStandard(value); err != nil {
+		v.add("%s is not a valid cron schedule (%q): %v", name, value, err)
+		return false
+	}
+	return true
+}
+
+func quoted(values []string) []string {
+	q := make([]string, len(values))
+	for i, value := range values {
+		q[i] = strconv.Quote(value)
+	}
+	return q
+}
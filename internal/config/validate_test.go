@@ -0,0 +1,112 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func validConfig(t *testing.T) *Config {
+	t.Helper()
+	keyFile := filepath.Join(t.TempDir(), "firebase.json")
+	if err := os.WriteFile(keyFile, []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return &Config{
+		GinMode: "release", ServerPort: "8080", ServerTimeout: 30 * time.Second,
+		DBHost: "localhost", DBPort: "5432", DBUser: "postgres", DBName: "seattle_info_db", DBSSLMode: "disable", DBTimezone: "UTC",
+		DBMaxIdleConns: 10, DBMaxOpenConns: 100,
+		LogLevel: "info", LogOutputs: "stdout",
+		DefaultListingLifespanDays: 10, MaxListingDistanceKM: 50, ListingImportMaxRows: 1000,
+		EventsTimezone: "America/Los_Angeles", AntispamThreshold: 50, PhoneVerificationMaxAttempts: 5,
+		WebhookMaxAttempts: 6, WebhookTimeoutSeconds: 10,
+		QueueWorkers: 4, QueueMaxAttempts: 5, QueuePollInterval: time.Second, QueueTaskTimeout: 5 * time.Minute,
+		ListingExpiryJobSchedule: "@daily", SavedSearchDigestJobSchedule: "0 8 * * *", WebhookDeliveryJobSchedule: "@every 1m",
+		TrendingJobSchedule: "@every 15m", ImageConsistencyJobSchedule: "0 3 * * *", ScheduledPublishJobSchedule: "@every 1m",
+		FeaturedExpiryJobSchedule: "@hourly", ListingStatsJobSchedule: "15 0 * * *",
+		TrendingHalfLife: 48 * time.Hour, TwoFactorSessionTTL: 12 * time.Hour,
+		FirebaseServiceAccountKeyPath: keyFile,
+		ImageStoragePath:              "./images", ImagePublicBaseURL: "/static",
+		UploadMaxFileBytes: 20 << 20, UploadMaxChunkBytes: 5 << 20, UploadSessionTTL: 24 * time.Hour,
+		MaxRequestBodyBytes: 1 << 20, MaxUploadBodyBytes: 50 << 20, MaxMultipartParts: 100, MultipartMemoryBytes: 1 << 20,
+	}
+}
+
+func TestValidateAcceptsDefaults(t *testing.T) {
+	if err := validConfig(t).Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	cfg := validConfig(t)
+	cfg.ServerPort = "80a"
+	cfg.StripeSecretKey = "sk_test" // requires STRIPE_WEBHOOK_SECRET and WEB_BASE_URL
+	cfg.GRPCEnabled = true          // requires TLS files unless insecure...
+	cfg.GRPCAllowInsecure = true    // ...which cannot be combined with a client CA
+	cfg.GRPCTLSClientCAFile = "/nonexistent/ca.pem"
+	cfg.GRPCPort = "9090"
+	cfg.ModerationAPIURL = "moderation.local/check"
+	cfg.ModerationAPITimeoutSeconds = 5
+	cfg.FeaturedPricePerDayCents = 200
+	cfg.PaymentsCurrency = "usd"
+	cfg.ListingStatsJobSchedule = "every day"
+
+	err := cfg.Validate()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a *ValidationError, got %v", err)
+	}
+	for _, want := range []string{
+		"SERVER_PORT",
+		"STRIPE_WEBHOOK_SECRET",
+		"WEB_BASE_URL",
+		"GRPC_ALLOW_INSECURE cannot be combined",
+		"GRPC_TLS_CLIENT_CA_FILE: file",
+		"MODERATION_API_URL",
+		"LISTING_STATS_JOB_SCHEDULE",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %s:\n%v", want, err)
+		}
+	}
+	if len(verr.Problems) != 7 {
+		t.Errorf("got %d problems, want 7:\n%v", len(verr.Problems), err)
+	}
+}
+
+func TestValidateServiceArea(t *testing.T) {
+	cfg := validConfig(t)
+	cfg.ServiceAreaCenterLat, cfg.ServiceAreaCenterLon, cfg.ServiceAreaRadiusKM = 47.6062, -122.3321, 80
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	cfg.ServiceAreaPolygon = `{"type":"Polygon","coordinates":[[[-122.4,47.5],[-122.2,47.5],[-122.4,47.5]]]}`
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "SERVICE_AREA_POLYGON") {
+		t.Errorf("expected an invalid polygon to be reported, got %v", err)
+	}
+}
+
+func TestValidateRequiresGRPCClientCA(t *testing.T) {
+	dir := t.TempDir()
+	cfg := validConfig(t)
+	cfg.GRPCEnabled, cfg.GRPCPort = true, "9090"
+	cfg.GRPCTLSCertFile, cfg.GRPCTLSKeyFile = filepath.Join(dir, "server.pem"), filepath.Join(dir, "server.key")
+	for _, f := range []string{cfg.GRPCTLSCertFile, cfg.GRPCTLSKeyFile} {
+		if err := os.WriteFile(f, []byte("pem"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "GRPC_TLS_CLIENT_CA_FILE") {
+		t.Errorf("expected TLS without a client CA to be reported, got %v", err)
+	}
+
+	cfg.GRPCAllowInsecure = true // TLS without client certificates
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
@@ -0,0 +1,253 @@
+package filestorage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// UploadOffsetHeader carries the offset of a resumable upload part, and the bytes received in responses.
+const UploadOffsetHeader = "Upload-Offset"
+
+// CreateUploadRequest is the payload of POST /uploads.
+type CreateUploadRequest struct {
+	Filename string `json:"filename" binding:"required,max=255"` // Its extension decides the file type
+	Size     int64  `json:"size" binding:"required,gt=0"`        // Total size in bytes
+}
+
+// Handler serves stored images under IMAGE_PUBLIC_BASE_URL's path (/static) and takes resumable uploads.
+type Handler struct {
+	storage   *FileStorageService
+	imageURLs *ImageURLBuilder
+	maxAge    time.Duration
+	logger    *zap.Logger
+	accessLog *zap.Logger
+
+	uploadMaxFileBytes  int64
+	uploadMaxChunkBytes int64
+	uploadTTL           time.Duration
+}
+
+// NewHandler creates a new image handler.
+func NewHandler(storage *FileStorageService, cfg *config.Config, logger *zap.Logger) *Handler {
+	return &Handler{
+		storage:   storage,
+		imageURLs: NewImageURLBuilder(cfg),
+		maxAge:    cfg.ImageCacheMaxAge,
+		logger:    logger,
+		accessLog: logger.Named("ImageAccess"),
+
+		uploadMaxFileBytes:  cfg.UploadMaxFileBytes,
+		uploadMaxChunkBytes: cfg.UploadMaxChunkBytes,
+		uploadTTL:           cfg.UploadSessionTTL,
+	}
+}
+
+// RegisterRoutes serves GET and HEAD for every stored file below router's path.
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/*filepath", h.serveImage)
+	router.HEAD("/*filepath", h.serveImage)
+}
+
+// serveImage checks the URL signature (when signing is enabled), confines the path to the storage root and
+// serves the file with http.ServeContent, which answers HEAD, Range and If-Modified-Since requests.
+// Stored file names are never reused, so responses are cacheable for maxAge, but never past a signed URL's expiry.
+func (h *Handler) serveImage(c *gin.Context) {
+	start := time.Now()
+	storedPath := strings.TrimPrefix(c.Param("filepath"), "/")
+	outcome := "served"
+	defer func() { h.logAccess(c, storedPath, outcome, start) }()
+
+	expires := c.Query(ExpiresParam)
+	cacheFor := h.maxAge
+	if h.imageURLs.SigningEnabled() {
+		if !h.imageURLs.Verify(storedPath, expires, c.Query(SignatureParam)) {
+			outcome = "bad_signature"
+			common.RespondWithError(c, common.ErrForbidden.WithDetails("Image URL is unsigned or has expired."))
+			return
+		}
+		if exp, ok := ExpiresAt(expires); ok {
+			if untilExpiry := time.Until(exp); untilExpiry < cacheFor {
+				cacheFor = untilExpiry
+			}
+		}
+	}
+
+	file, err := h.storage.Open(storedPath)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidPath):
+			outcome = "path_rejected"
+			common.RespondWithError(c, common.ErrNotFound.WithDetails("Image not found."))
+		case errors.Is(err, ErrFileNotFound):
+			outcome = "not_found"
+			common.RespondWithError(c, common.ErrNotFound.WithDetails("Image not found."))
+		default:
+			outcome = "error"
+			h.logger.Error("Failed to open stored image", zap.String("path", storedPath), zap.Error(err))
+			common.RespondWithError(c, common.ErrInternalServer)
+		}
+		return
+	}
+	defer file.Close()
+
+	c.Header("Content-Type", file.ContentType)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d, immutable", int(cacheFor.Seconds())))
+	http.ServeContent(c.Writer, c.Request, file.Info.Name(), file.Info.ModTime(), file)
+}
+
+// logAccess writes one entry per image request to the ImageAccess logger, for spotting scraping and hotlinking.
+// Rejected paths are logged at warn level because they indicate traversal attempts.
+func (h *Handler) logAccess(c *gin.Context, storedPath, outcome string, start time.Time) {
+	fields := []zap.Field{
+		zap.String("outcome", outcome),
+		zap.String("method", c.Request.Method),
+		zap.String("path", storedPath),
+		zap.Int("status_code", c.Writer.Status()),
+		zap.Int("bytes", c.Writer.Size()),
+		zap.String("range", c.GetHeader("Range")),
+		zap.String("ip", c.ClientIP()),
+		zap.String("user_agent", c.Request.UserAgent()),
+		zap.String("referer", c.Request.Referer()),
+		zap.Duration("latency", time.Since(start)),
+	}
+	if outcome == "path_rejected" || outcome == "bad_signature" {
+		h.accessLog.Warn("Image request rejected", fields...)
+		return
+	}
+	h.accessLog.Info("Image request", fields...)
+}
+
+// RegisterUploadRoutes registers the resumable upload endpoints. router must require authentication.
+func (h *Handler) RegisterUploadRoutes(router *gin.RouterGroup) {
+	router.POST("", h.createUpload)
+	router.GET("/:token", h.getUpload)
+	router.PATCH("/:token", h.appendUpload)
+	router.POST("/:token/complete", h.completeUpload)
+	router.DELETE("/:token", h.abortUpload)
+}
+
+// createUpload starts a resumable upload and returns its token.
+func (h *Handler) createUpload(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized)
+		return
+	}
+	var req CreateUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	if req.Size > h.uploadMaxFileBytes {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails(fmt.Sprintf("Uploads may be at most %d bytes.", h.uploadMaxFileBytes)))
+		return
+	}
+	status, err := h.storage.CreateUpload(userID, req.Filename, req.Size, h.uploadTTL)
+	if err != nil {
+		h.respondUploadError(c, err, status)
+		return
+	}
+	common.RespondCreated(c, "Upload started.", h.uploadResponse(c, status))
+}
+
+// getUpload returns an upload's status; after a lost response, received says where to resume.
+func (h *Handler) getUpload(c *gin.Context) {
+	status, err := h.storage.GetUpload(common.GetUserIDFromContext(c), c.Param("token"))
+	if err != nil {
+		h.respondUploadError(c, err, status)
+		return
+	}
+	common.RespondOK(c, "Upload retrieved successfully.", h.uploadResponse(c, status))
+}
+
+// appendUpload stores the request body as the part of the upload starting at the Upload-Offset header.
+func (h *Handler) appendUpload(c *gin.Context) {
+	offset, err := strconv.ParseInt(c.GetHeader(UploadOffsetHeader), 10, 64)
+	if err != nil || offset < 0 {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("The Upload-Offset header must give the byte offset of the part."))
+		return
+	}
+	if apiErr := common.LimitRequestBody(c, h.uploadMaxChunkBytes); apiErr != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails(fmt.Sprintf("Parts may be at most %d bytes.", h.uploadMaxChunkBytes)))
+		return
+	}
+	part, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			common.RespondWithError(c, common.ErrBadRequest.WithDetails(fmt.Sprintf("Parts may be at most %d bytes.", h.uploadMaxChunkBytes)))
+			return
+		}
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("The part was not received completely. Resume from the offset GET /uploads/{token} reports."))
+		return
+	}
+	status, err := h.storage.AppendUpload(common.GetUserIDFromContext(c), c.Param("token"), offset, part)
+	if err != nil {
+		h.respondUploadError(c, err, status)
+		return
+	}
+	common.RespondOK(c, "Upload part stored.", h.uploadResponse(c, status))
+}
+
+// completeUpload finishes an upload once every byte is received; its token can then be attached to a listing.
+func (h *Handler) completeUpload(c *gin.Context) {
+	status, err := h.storage.CompleteUpload(common.GetUserIDFromContext(c), c.Param("token"))
+	if err != nil {
+		h.respondUploadError(c, err, status)
+		return
+	}
+	common.RespondOK(c, "Upload completed.", h.uploadResponse(c, status))
+}
+
+// abortUpload deletes an upload that will not be used.
+func (h *Handler) abortUpload(c *gin.Context) {
+	if err := h.storage.AbortUpload(common.GetUserIDFromContext(c), c.Param("token")); err != nil {
+		h.respondUploadError(c, err, nil)
+		return
+	}
+	common.RespondNoContent(c)
+}
+
+// uploadResponse sets the Upload-Offset header to the bytes received and returns the status as the response body.
+func (h *Handler) uploadResponse(c *gin.Context, status *UploadStatus) *UploadStatus {
+	c.Header(UploadOffsetHeader, strconv.FormatInt(status.Received, 10))
+	return status
+}
+
+// respondUploadError maps the errors of the upload methods to API errors. status, when known, tells the client
+// the offset to resume from.
+func (h *Handler) respondUploadError(c *gin.Context, err error, status *UploadStatus) {
+	if status != nil {
+		c.Header(UploadOffsetHeader, strconv.FormatInt(status.Received, 10))
+	}
+	switch {
+	case errors.Is(err, ErrUploadNotFound):
+		common.RespondWithError(c, common.ErrNotFound.WithDetails("Upload not found or expired."))
+	case errors.Is(err, ErrUploadFileType):
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Only JPEG, PNG, GIF and WebP images can be uploaded."))
+	case errors.Is(err, ErrUploadTooLarge):
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("The part goes past the declared size of the upload."))
+	case errors.Is(err, ErrUploadOffset) && status != nil && status.Completed:
+		common.RespondWithError(c, common.ErrConflict.WithDetails("The upload is already complete."))
+	case errors.Is(err, ErrUploadOffset) && status != nil:
+		common.RespondWithError(c, common.ErrConflict.WithDetails(fmt.Sprintf("Resume the upload at offset %d.", status.Received)))
+	case errors.Is(err, ErrUploadIncomplete) && status != nil:
+		common.RespondWithError(c, common.ErrConflict.WithDetails(fmt.Sprintf("Received %d of %d bytes.", status.Received, status.Size)))
+	default:
+		h.logger.Error("Resumable upload failed", zap.String("token", c.Param("token")), zap.Error(err))
+		common.RespondWithError(c, common.ErrInternalServer)
+	}
+}
@@ -0,0 +1,106 @@
+package filestorage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestImageServer(t *testing.T, cfg *config.Config) (*gin.Engine, string) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "listings"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "listings", "a.png"), []byte("0123456789"), 0o644))
+
+	storage, err := NewFileStorageService(root, zap.NewNop())
+	require.NoError(t, err)
+	cfg.ImageCacheMaxAge = 24 * time.Hour
+	router := gin.New()
+	NewHandler(storage, cfg, zap.NewNop()).RegisterRoutes(router.Group("/static"))
+	return router, root
+}
+
+func doRequest(router http.Handler, method, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestServeImage(t *testing.T) {
+	router, _ := newTestImageServer(t, &config.Config{ImagePublicBaseURL: "/static"})
+
+	w := doRequest(router, http.MethodGet, "/static/listings/a.png", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "public, max-age=86400, immutable", w.Header().Get("Cache-Control"))
+	assert.Equal(t, "0123456789", w.Body.String())
+
+	w = doRequest(router, http.MethodHead, "/static/listings/a.png", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "10", w.Header().Get("Content-Length"))
+	assert.Empty(t, w.Body.String())
+
+	w = doRequest(router, http.MethodGet, "/static/listings/a.png", http.Header{"Range": {"bytes=2-4"}})
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Equal(t, "234", w.Body.String())
+}
+
+func TestServeImageRejectsPathsOutsideRoot(t *testing.T) {
+	router, root := newTestImageServer(t, &config.Config{ImagePublicBaseURL: "/static"})
+
+	outside := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "secret.png"), []byte("x"), 0o644))
+	require.NoError(t, os.Symlink(filepath.Join(outside, "secret.png"), filepath.Join(root, "listings", "link.png")))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "listings", "notes.txt"), []byte("x"), 0o644))
+
+	for _, target := range []string{
+		"/static/listings/link.png",    // symlink leaving the root
+		"/static/listings/notes.txt",   // not an image
+		"/static/listings",             // directory
+		"/static/listings/missing.png", // does not exist
+	} {
+		w := doRequest(router, http.MethodGet, target, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, target)
+		assert.Empty(t, w.Header().Get("Cache-Control"), target)
+	}
+}
+
+func TestOpenRejectsTraversal(t *testing.T) {
+	storage, err := NewFileStorageService(t.TempDir(), zap.NewNop())
+	require.NoError(t, err)
+
+	_, err = storage.Open("../etc/passwd.png")
+	assert.ErrorIs(t, err, ErrInvalidPath)
+	_, err = storage.Open("listings/..\\..\\a.png")
+	assert.ErrorIs(t, err, ErrInvalidPath)
+	_, err = storage.Open(".hidden/a.png")
+	assert.ErrorIs(t, err, ErrFileNotFound)
+}
+
+func TestServeImageRequiresValidSignature(t *testing.T) {
+	cfg := &config.Config{ImagePublicBaseURL: "/static", ImageURLSigningSecret: "s3cret", ImageURLTTL: time.Hour}
+	router, _ := newTestImageServer(t, cfg)
+
+	w := doRequest(router, http.MethodGet, "/static/listings/a.png", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = doRequest(router, http.MethodGet, NewImageURLBuilder(cfg).URL("listings/a.png"), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, "public, max-age=86400, immutable", w.Header().Get("Cache-Control"), "capped at the URL's expiry")
+}
@@ -0,0 +1,61 @@
+package filestorage
+
+import (
+	"fmt"
+	"mime/multipart"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// PrivateDir is the storage sub-directory of files that are never served publicly, such as documents users send
+// for verification. Open never serves files below it; OpenPrivate opens them for the admins reviewing them.
+const PrivateDir = "private"
+
+// allowedDocumentExtensions are the file types SavePrivateFile accepts: photos or scans, and PDFs.
+var allowedDocumentExtensions = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".webp": "image/webp",
+	".pdf":  "application/pdf",
+}
+
+// SavePrivateFile saves an uploaded document below PrivateDir/subDir under a unique name, like SaveUploadedFile.
+// It returns the path relative to the storage path, e.g. "private/background-checks/uuid.pdf".
+func (s *FileStorageService) SavePrivateFile(fileHeader *multipart.FileHeader, subDir string) (string, error) {
+	return s.saveFile(fileHeader, path.Join(PrivateDir, subDir), allowedDocumentExtensions)
+}
+
+// OpenPrivate opens a file saved by SavePrivateFile. It returns ErrInvalidPath for paths outside PrivateDir and
+// ErrFileNotFound for anything that is not a stored document. The caller must Close it.
+func (s *FileStorageService) OpenPrivate(relativePath string) (*StoredFile, error) {
+	cleanRelativePath := path.Clean(relativePath)
+	if strings.ContainsAny(relativePath, "\\\x00") || !strings.HasPrefix(cleanRelativePath, PrivateDir+"/") ||
+		strings.Contains(relativePath, "..") {
+		return nil, ErrInvalidPath
+	}
+	contentType, ok := allowedDocumentExtensions[strings.ToLower(path.Ext(cleanRelativePath))]
+	if !ok {
+		return nil, ErrFileNotFound
+	}
+
+	f, err := os.Open(filepath.Join(s.storagePath, filepath.FromSlash(cleanRelativePath)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrFileNotFound
+		}
+		return nil, fmt.Errorf("failed to open private file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to stat private file: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		f.Close()
+		return nil, ErrFileNotFound
+	}
+	return &StoredFile{File: f, Info: info, ContentType: contentType}, nil
+}
@@ -0,0 +1,44 @@
+package filestorage
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPrivateFiles(t *testing.T) {
+	storage, err := NewFileStorageService(t.TempDir(), zap.NewNop())
+	require.NoError(t, err)
+
+	saved, err := storage.SavePrivateFile(newTestFileHeader(t, "document", "check.pdf", "pdf bytes", "application/pdf"), "background-checks")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(saved, "private/background-checks/"), saved)
+
+	_, err = storage.Open(saved)
+	assert.ErrorIs(t, err, ErrFileNotFound, "private files are never served")
+
+	photo, err := storage.SavePrivateFile(newTestFileHeader(t, "document", "id.png", "png bytes", "image/png"), "background-checks")
+	require.NoError(t, err)
+	_, err = storage.Open(photo)
+	assert.ErrorIs(t, err, ErrFileNotFound, "not even images")
+
+	file, err := storage.OpenPrivate(saved)
+	require.NoError(t, err)
+	data, err := io.ReadAll(file)
+	file.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "pdf bytes", string(data))
+	assert.Equal(t, "application/pdf", file.ContentType)
+
+	_, err = storage.OpenPrivate("listings/photo.png")
+	assert.ErrorIs(t, err, ErrInvalidPath)
+	_, err = storage.OpenPrivate("private/../../etc/passwd.pdf")
+	assert.ErrorIs(t, err, ErrInvalidPath)
+
+	_, err = storage.SaveUploadedFile(newTestFileHeader(t, "images", "check.pdf", "pdf bytes", "application/pdf"), "listings")
+	assert.Error(t, err, "public uploads stay images only")
+}
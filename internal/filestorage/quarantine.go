@@ -0,0 +1,67 @@
+package filestorage
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// QuarantineDir is the storage sub-directory uploaded files wait in until they pass the virus scan.
+// A file that will be served at "listings/uuid.jpg" is kept at "quarantine/listings/uuid.jpg"; Open never
+// serves files below it.
+const QuarantineDir = "quarantine"
+
+// QuarantinePath returns where the file to be served at relativePath is kept while it is quarantined.
+func QuarantinePath(relativePath string) string {
+	return path.Join(QuarantineDir, relativePath)
+}
+
+// ReleasedPath returns the path a file saved at quarantinePath is served at once released.
+func ReleasedPath(quarantinePath string) string {
+	return strings.TrimPrefix(quarantinePath, QuarantineDir+"/")
+}
+
+// OpenQuarantined opens the quarantined file to be served at relativePath, for scanning. The caller must close it.
+func (s *FileStorageService) OpenQuarantined(relativePath string) (*os.File, error) {
+	fullPath, err := s.quarantinedFullPath(relativePath)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(fullPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrFileNotFound
+		}
+		return nil, fmt.Errorf("failed to open quarantined file: %w", err)
+	}
+	return f, nil
+}
+
+// Release moves a quarantined file to relativePath, where it is served.
+func (s *FileStorageService) Release(relativePath string) error {
+	fullPath, err := s.quarantinedFullPath(relativePath)
+	if err != nil {
+		return err
+	}
+	destination := filepath.Join(s.storagePath, filepath.Clean(relativePath))
+	if err := os.MkdirAll(filepath.Dir(destination), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create directory for released file: %w", err)
+	}
+	if err := os.Rename(fullPath, destination); err != nil {
+		if os.IsNotExist(err) {
+			return ErrFileNotFound
+		}
+		return fmt.Errorf("failed to release quarantined file: %w", err)
+	}
+	return nil
+}
+
+func (s *FileStorageService) quarantinedFullPath(relativePath string) (string, error) {
+	cleanRelativePath := filepath.Clean(relativePath)
+	if cleanRelativePath == "." || strings.HasPrefix(cleanRelativePath, "..") || filepath.IsAbs(cleanRelativePath) {
+		return "", ErrInvalidPath
+	}
+	return filepath.Join(s.storagePath, QuarantineDir, cleanRelativePath), nil
+}
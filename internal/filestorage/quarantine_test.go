@@ -0,0 +1,46 @@
+package filestorage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestQuarantineRelease(t *testing.T) {
+	root := t.TempDir()
+	storage, err := NewFileStorageService(root, zap.NewNop())
+	require.NoError(t, err)
+
+	saved, err := storage.SaveUploadedFile(newTestFileHeader(t, "images", "photo.png", "png bytes", "image/png"), QuarantinePath("listings"))
+	require.NoError(t, err)
+	served := ReleasedPath(saved)
+	assert.Equal(t, "listings/"+filepath.Base(saved), served)
+
+	_, err = storage.Open(saved)
+	assert.ErrorIs(t, err, ErrFileNotFound, "quarantined files are never served")
+	_, err = storage.Open(served)
+	assert.ErrorIs(t, err, ErrFileNotFound, "nor is anything at their final path yet")
+
+	f, err := storage.OpenQuarantined(served)
+	require.NoError(t, err)
+	data, err := io.ReadAll(f)
+	f.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "png bytes", string(data))
+
+	require.NoError(t, storage.Release(served))
+	file, err := storage.Open(served)
+	require.NoError(t, err)
+	file.Close()
+	_, err = os.Stat(filepath.Join(root, saved))
+	assert.True(t, os.IsNotExist(err))
+	assert.ErrorIs(t, storage.Release(served), ErrFileNotFound, "a file is released once")
+
+	_, err = storage.OpenQuarantined("../service.go")
+	assert.ErrorIs(t, err, ErrInvalidPath)
+}
@@ -1,12 +1,16 @@
 package filestorage
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -16,6 +20,7 @@ import (
 type FileStorageService struct {
 	storagePath string // Base path for storing files, e.g., "./images"
 	logger      *zap.Logger
+	uploadMu    sync.Mutex // Serializes changes to resumable uploads
 }
 
 // NewFileStorageService creates a new FileStorageService.
@@ -32,11 +37,32 @@ func NewFileStorageService(storagePath string, logger *zap.Logger) (*FileStorage
 	return &FileStorageService{storagePath: storagePath, logger: logger}, nil
 }
 
+// allowedImageExtensions are the file types SaveUploadedFile accepts, with the Content-Type each is served with.
+// Stored files keep the validated extension, so it is the record of what a file contains.
+var allowedImageExtensions = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+}
+
+// Errors returned by Open.
+var (
+	ErrFileNotFound = errors.New("file not found")
+	ErrInvalidPath  = errors.New("path escapes the storage root")
+)
+
 // SaveUploadedFile saves a multipart file to a specified sub-directory within the storage path.
 // It generates a unique filename using UUID.
 // subDir is relative to the base storagePath, e.g., "listings", "avatars".
 // Returns the relative path of the saved file (e.g., "listings/uuid.jpg") or an error.
 func (s *FileStorageService) SaveUploadedFile(fileHeader *multipart.FileHeader, subDir string) (string, error) {
+	return s.saveFile(fileHeader, subDir, allowedImageExtensions)
+}
+
+// saveFile saves a multipart file with one of the allowed extensions below subDir, under a unique name.
+func (s *FileStorageService) saveFile(fileHeader *multipart.FileHeader, subDir string, allowed map[string]string) (string, error) {
 	if fileHeader == nil {
 		return "", fmt.Errorf("fileHeader cannot be nil")
 	}
@@ -63,11 +89,17 @@ func (s *FileStorageService) SaveUploadedFile(fileHeader *multipart.FileHeader,
 			extension = ".png"
 		case strings.HasPrefix(contentType, "image/gif"):
 			extension = ".gif"
+		case strings.HasPrefix(contentType, "application/pdf"):
+			extension = ".pdf"
 		default:
 			return "", fmt.Errorf("unsupported file type or missing extension: %s", contentType)
 		}
 
 	}
+	extension = strings.ToLower(extension)
+	if _, ok := allowed[extension]; !ok {
+		return "", fmt.Errorf("unsupported file type or missing extension: %s", extension)
+	}
 	uniqueFilename := uuid.New().String() + extension
 
 	// Construct the full destination path
@@ -137,4 +169,106 @@ func (s *FileStorageService) DeleteFile(relativePath string) error {
 
 	s.logger.Info("File deleted successfully", zap.String("path", fullPath))
 	return nil
-}
\ No newline at end of file
+}
+
+// FileInfo describes a stored file found by ListFiles.
+type FileInfo struct {
+	Path    string    // Relative to the storage path, slash-separated, e.g. "listings/uuid.jpg"
+	ModTime time.Time // When the file was written
+}
+
+// ListFiles returns the regular files directly inside subDir. A missing subDir yields no files.
+func (s *FileStorageService) ListFiles(subDir string) ([]FileInfo, error) {
+	cleanSubDir := filepath.Clean(subDir)
+	if strings.HasPrefix(cleanSubDir, "..") {
+		return nil, fmt.Errorf("invalid subDir path")
+	}
+	entries, err := os.ReadDir(filepath.Join(s.storagePath, cleanSubDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list files in %s: %w", cleanSubDir, err)
+	}
+	files := make([]FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue // Removed since ReadDir
+		}
+		files = append(files, FileInfo{
+			Path:    filepath.ToSlash(filepath.Join(cleanSubDir, entry.Name())),
+			ModTime: info.ModTime(),
+		})
+	}
+	return files, nil
+}
+
+// StoredFile is a stored file opened for serving. The caller must Close it.
+type StoredFile struct {
+	*os.File
+	Info        os.FileInfo
+	ContentType string
+}
+
+// Open opens a stored image for serving. relativePath is slash-separated, e.g. "listings/uuid.jpg".
+// It returns ErrInvalidPath when the path, after resolving symlinks, points outside the storage root,
+// and ErrFileNotFound for anything that is not a regular file with an image extension, including
+// directories, hidden files, quarantined files and private files.
+func (s *FileStorageService) Open(relativePath string) (*StoredFile, error) {
+	if strings.ContainsAny(relativePath, "\\\x00") {
+		return nil, ErrInvalidPath
+	}
+	for _, segment := range strings.Split(relativePath, "/") {
+		if segment == ".." {
+			return nil, ErrInvalidPath
+		}
+		if strings.HasPrefix(segment, ".") {
+			return nil, ErrFileNotFound
+		}
+	}
+	cleanRelativePath := path.Clean("/" + relativePath)
+	contentType, ok := allowedImageExtensions[strings.ToLower(path.Ext(cleanRelativePath))]
+	if !ok || strings.HasPrefix(cleanRelativePath, "/"+QuarantineDir+"/") || strings.HasPrefix(cleanRelativePath, "/"+PrivateDir+"/") {
+		return nil, ErrFileNotFound
+	}
+
+	root, err := filepath.Abs(s.storagePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve storage path: %w", err)
+	}
+	if root, err = filepath.EvalSymlinks(root); err != nil {
+		return nil, fmt.Errorf("failed to resolve storage path: %w", err)
+	}
+	fullPath, err := filepath.EvalSymlinks(filepath.Join(root, filepath.FromSlash(cleanRelativePath)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrFileNotFound
+		}
+		return nil, fmt.Errorf("failed to resolve file path: %w", err)
+	}
+	if rel, err := filepath.Rel(root, fullPath); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, ErrInvalidPath
+	}
+
+	f, err := os.Open(fullPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrFileNotFound
+		}
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		f.Close()
+		return nil, ErrFileNotFound
+	}
+	return &StoredFile{File: f, Info: info, ContentType: contentType}, nil
+}
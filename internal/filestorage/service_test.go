@@ -167,6 +167,28 @@ func TestFileStorageService_DeleteFile_NonExistent(t *testing.T) {
 	// Current implementation logs a warning and returns nil.
 }
 
+func TestFileStorageService_ListFiles(t *testing.T) {
+	fsService, cleanup := setupFileStorageService(t)
+	defer cleanup()
+
+	files, err := fsService.ListFiles("listings")
+	require.NoError(t, err, "A missing sub-directory has no files")
+	assert.Empty(t, files)
+
+	saved, err := fsService.SaveUploadedFile(newTestFileHeader(t, "image", "a.png", "png", "image/png"), "listings")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(testStoragePath, "listings", "nested"), os.ModePerm))
+
+	files, err = fsService.ListFiles("listings")
+	require.NoError(t, err)
+	require.Len(t, files, 1, "Directories are not listed")
+	assert.Equal(t, saved, files[0].Path)
+	assert.False(t, files[0].ModTime.IsZero())
+
+	_, err = fsService.ListFiles("../outside")
+	assert.Error(t, err)
+}
+
 func TestFileStorageService_DeleteFile_PathTraversal(t *testing.T) {
 	fsService, cleanup := setupFileStorageService(t)
 	defer cleanup()
@@ -0,0 +1,279 @@
+package filestorage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// uploadsDir is the storage sub-directory resumable uploads are assembled in, one directory per upload.
+// Its files have no image extension, so they are never served.
+const uploadsDir = "uploads"
+
+// Files of an upload's directory.
+const (
+	uploadSessionFile = "session.json"
+	uploadDataFile    = "data"
+)
+
+// Errors returned by the resumable upload methods.
+var (
+	ErrUploadNotFound   = errors.New("upload not found")
+	ErrUploadOffset     = errors.New("offset does not match the bytes received")
+	ErrUploadTooLarge   = errors.New("upload exceeds its declared size")
+	ErrUploadIncomplete = errors.New("upload is not complete")
+	ErrUploadFileType   = errors.New("unsupported file type")
+)
+
+// UploadStatus describes a resumable upload. Received is how many bytes are stored, which is also the offset
+// the next part must start at.
+type UploadStatus struct {
+	Token     string    `json:"upload_token"`
+	Filename  string    `json:"filename"`
+	Size      int64     `json:"size"`
+	Received  int64     `json:"received"`
+	Completed bool      `json:"completed"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// uploadSession is the state of an upload kept in its session file.
+type uploadSession struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Filename  string    `json:"filename"`
+	Extension string    `json:"extension"`
+	Size      int64     `json:"size"`
+	Completed bool      `json:"completed"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CreateUpload starts a resumable upload of an image of size bytes for userID, which must be finished and
+// attached to a listing within ttl. Expired uploads of any user are removed first.
+func (s *FileStorageService) CreateUpload(userID uuid.UUID, filename string, size int64, ttl time.Duration) (*UploadStatus, error) {
+	extension := strings.ToLower(filepath.Ext(filepath.Base(filename)))
+	if _, ok := allowedImageExtensions[extension]; !ok {
+		return nil, ErrUploadFileType
+	}
+	s.uploadMu.Lock()
+	defer s.uploadMu.Unlock()
+	s.removeExpiredUploads(time.Now())
+
+	token := uuid.New().String()
+	if err := os.MkdirAll(s.uploadPath(token), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create upload directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(s.uploadPath(token), uploadDataFile), nil, 0o600); err != nil {
+		os.RemoveAll(s.uploadPath(token))
+		return nil, fmt.Errorf("failed to create upload file: %w", err)
+	}
+	session := &uploadSession{UserID: userID, Filename: filepath.Base(filename), Extension: extension, Size: size, ExpiresAt: time.Now().Add(ttl).UTC()}
+	if err := s.writeUploadSession(token, session); err != nil {
+		os.RemoveAll(s.uploadPath(token))
+		return nil, err
+	}
+	return session.status(token, 0), nil
+}
+
+// GetUpload returns the status of one of userID's uploads.
+func (s *FileStorageService) GetUpload(userID uuid.UUID, token string) (*UploadStatus, error) {
+	s.uploadMu.Lock()
+	defer s.uploadMu.Unlock()
+	session, received, err := s.loadUpload(userID, token)
+	if err != nil {
+		return nil, err
+	}
+	return session.status(token, received), nil
+}
+
+// AppendUpload stores part, the bytes of the upload starting at offset. offset must equal the bytes received so
+// far; a client that lost a response asks GetUpload where to resume.
+func (s *FileStorageService) AppendUpload(userID uuid.UUID, token string, offset int64, part []byte) (*UploadStatus, error) {
+	s.uploadMu.Lock()
+	defer s.uploadMu.Unlock()
+	session, received, err := s.loadUpload(userID, token)
+	if err != nil {
+		return nil, err
+	}
+	if session.Completed || offset != received {
+		return session.status(token, received), ErrUploadOffset
+	}
+	if received+int64(len(part)) > session.Size {
+		return session.status(token, received), ErrUploadTooLarge
+	}
+
+	f, err := os.OpenFile(filepath.Join(s.uploadPath(token), uploadDataFile), os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open upload file: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(part); err != nil {
+		// Drop a partly written part so the next attempt can resend it from the same offset.
+		if truncErr := f.Truncate(received); truncErr != nil {
+			s.logger.Error("Failed to truncate upload after a failed write", zap.String("token", token), zap.Error(truncErr))
+		}
+		return nil, fmt.Errorf("failed to write upload part: %w", err)
+	}
+	return session.status(token, received+int64(len(part))), nil
+}
+
+// CompleteUpload marks an upload whose bytes have all been received as complete, so it can be attached.
+func (s *FileStorageService) CompleteUpload(userID uuid.UUID, token string) (*UploadStatus, error) {
+	s.uploadMu.Lock()
+	defer s.uploadMu.Unlock()
+	session, received, err := s.loadUpload(userID, token)
+	if err != nil {
+		return nil, err
+	}
+	if received != session.Size {
+		return session.status(token, received), ErrUploadIncomplete
+	}
+	if !session.Completed {
+		session.Completed = true
+		if err := s.writeUploadSession(token, session); err != nil {
+			return nil, err
+		}
+	}
+	return session.status(token, received), nil
+}
+
+// AbortUpload deletes one of userID's uploads.
+func (s *FileStorageService) AbortUpload(userID uuid.UUID, token string) error {
+	s.uploadMu.Lock()
+	defer s.uploadMu.Unlock()
+	if _, _, err := s.loadUpload(userID, token); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(s.uploadPath(token)); err != nil {
+		return fmt.Errorf("failed to delete upload: %w", err)
+	}
+	return nil
+}
+
+// ClaimUpload moves a complete upload of userID into subDir under a new unique name, like SaveUploadedFile, and
+// returns its relative path. The upload is gone afterwards.
+func (s *FileStorageService) ClaimUpload(userID uuid.UUID, token string, subDir string) (string, error) {
+	s.uploadMu.Lock()
+	defer s.uploadMu.Unlock()
+	session, _, err := s.loadUpload(userID, token)
+	if err != nil {
+		return "", err
+	}
+	if !session.Completed {
+		return "", ErrUploadIncomplete
+	}
+
+	cleanSubDir := filepath.Clean(subDir)
+	if strings.HasPrefix(cleanSubDir, "..") {
+		return "", fmt.Errorf("invalid subDir path")
+	}
+	if err := os.MkdirAll(filepath.Join(s.storagePath, cleanSubDir), os.ModePerm); err != nil {
+		return "", fmt.Errorf("failed to create directory %s: %w", cleanSubDir, err)
+	}
+	relativePath := filepath.Join(cleanSubDir, uuid.New().String()+session.Extension)
+	if err := os.Rename(filepath.Join(s.uploadPath(token), uploadDataFile), filepath.Join(s.storagePath, relativePath)); err != nil {
+		return "", fmt.Errorf("failed to move upload into place: %w", err)
+	}
+	if err := os.RemoveAll(s.uploadPath(token)); err != nil {
+		s.logger.Warn("Failed to delete claimed upload directory", zap.String("token", token), zap.Error(err))
+	}
+	s.logger.Info("Upload claimed", zap.String("token", token), zap.String("path", relativePath))
+	return filepath.ToSlash(relativePath), nil
+}
+
+// loadUpload reads an unexpired upload of userID and the number of bytes received. Uploads of other users are
+// reported as not found. The caller holds uploadMu.
+func (s *FileStorageService) loadUpload(userID uuid.UUID, token string) (*uploadSession, int64, error) {
+	if _, err := uuid.Parse(token); err != nil {
+		return nil, 0, ErrUploadNotFound // Also keeps the token from naming a path outside uploadsDir
+	}
+	session, err := s.readUploadSession(token)
+	if err != nil {
+		return nil, 0, err
+	}
+	if session.UserID != userID || time.Now().After(session.ExpiresAt) {
+		return nil, 0, ErrUploadNotFound
+	}
+	info, err := os.Stat(filepath.Join(s.uploadPath(token), uploadDataFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, 0, ErrUploadNotFound
+		}
+		return nil, 0, fmt.Errorf("failed to stat upload file: %w", err)
+	}
+	return session, info.Size(), nil
+}
+
+func (s *FileStorageService) readUploadSession(token string) (*uploadSession, error) {
+	data, err := os.ReadFile(filepath.Join(s.uploadPath(token), uploadSessionFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrUploadNotFound
+		}
+		return nil, fmt.Errorf("failed to read upload session: %w", err)
+	}
+	var session uploadSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to parse upload session: %w", err)
+	}
+	return &session, nil
+}
+
+// writeUploadSession replaces the session file through a rename, so readers never see a partial file.
+func (s *FileStorageService) writeUploadSession(token string, session *uploadSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to encode upload session: %w", err)
+	}
+	tmp := filepath.Join(s.uploadPath(token), uploadSessionFile+".tmp")
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write upload session: %w", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(s.uploadPath(token), uploadSessionFile)); err != nil {
+		return fmt.Errorf("failed to write upload session: %w", err)
+	}
+	return nil
+}
+
+// removeExpiredUploads deletes uploads that expired before now. Failures are logged; the next call retries them.
+// The caller holds uploadMu.
+func (s *FileStorageService) removeExpiredUploads(now time.Time) {
+	entries, err := os.ReadDir(filepath.Join(s.storagePath, uploadsDir))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			s.logger.Warn("Failed to list uploads", zap.Error(err))
+		}
+		return
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		session, err := s.readUploadSession(entry.Name())
+		if err == nil && now.Before(session.ExpiresAt) {
+			continue
+		}
+		if errors.Is(err, ErrUploadNotFound) {
+			// A directory without a session file is left by a crash during CreateUpload; give it a day.
+			if info, infoErr := entry.Info(); infoErr != nil || now.Sub(info.ModTime()) < 24*time.Hour {
+				continue
+			}
+		}
+		if err := os.RemoveAll(s.uploadPath(entry.Name())); err != nil {
+			s.logger.Warn("Failed to delete expired upload", zap.String("token", entry.Name()), zap.Error(err))
+		}
+	}
+}
+
+func (s *FileStorageService) uploadPath(token string) string {
+	return filepath.Join(s.storagePath, uploadsDir, token)
+}
+
+func (u *uploadSession) status(token string, received int64) *UploadStatus {
+	return &UploadStatus{Token: token, Filename: u.Filename, Size: u.Size, Received: received, Completed: u.Completed, ExpiresAt: u.ExpiresAt}
+}
@@ -0,0 +1,82 @@
+package filestorage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestResumableUpload(t *testing.T) {
+	root := t.TempDir()
+	storage, err := NewFileStorageService(root, zap.NewNop())
+	require.NoError(t, err)
+	owner, other := uuid.New(), uuid.New()
+
+	_, err = storage.CreateUpload(owner, "notes.txt", 10, time.Hour)
+	assert.ErrorIs(t, err, ErrUploadFileType)
+
+	upload, err := storage.CreateUpload(owner, "photo.JPG", 10, time.Hour)
+	require.NoError(t, err)
+	token := upload.Token
+
+	_, err = storage.GetUpload(other, token)
+	assert.ErrorIs(t, err, ErrUploadNotFound, "uploads are private to their owner")
+	_, err = storage.GetUpload(owner, "../../etc")
+	assert.ErrorIs(t, err, ErrUploadNotFound)
+
+	status, err := storage.AppendUpload(owner, token, 0, []byte("hello"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, status.Received)
+
+	status, err = storage.AppendUpload(owner, token, 0, []byte("hello"))
+	assert.ErrorIs(t, err, ErrUploadOffset, "a resent part is rejected")
+	assert.EqualValues(t, 5, status.Received, "and the offset to resume from is reported")
+	_, err = storage.AppendUpload(owner, token, 5, []byte("world!"))
+	assert.ErrorIs(t, err, ErrUploadTooLarge)
+
+	_, err = storage.CompleteUpload(owner, token)
+	assert.ErrorIs(t, err, ErrUploadIncomplete)
+	_, err = storage.ClaimUpload(owner, token, "listings")
+	assert.ErrorIs(t, err, ErrUploadIncomplete)
+
+	_, err = storage.AppendUpload(owner, token, 5, []byte("world"))
+	require.NoError(t, err)
+	status, err = storage.CompleteUpload(owner, token)
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+
+	_, err = storage.ClaimUpload(other, token, "listings")
+	assert.ErrorIs(t, err, ErrUploadNotFound)
+	path, err := storage.ClaimUpload(owner, token, "listings")
+	require.NoError(t, err)
+	assert.Equal(t, ".jpg", filepath.Ext(path))
+	data, err := os.ReadFile(filepath.Join(root, path))
+	require.NoError(t, err)
+	assert.Equal(t, "helloworld", string(data))
+
+	_, err = storage.GetUpload(owner, token)
+	assert.ErrorIs(t, err, ErrUploadNotFound, "a claimed upload is used up")
+}
+
+func TestExpiredUploadsAreRemoved(t *testing.T) {
+	root := t.TempDir()
+	storage, err := NewFileStorageService(root, zap.NewNop())
+	require.NoError(t, err)
+	owner := uuid.New()
+
+	expired, err := storage.CreateUpload(owner, "a.png", 10, -time.Minute)
+	require.NoError(t, err)
+	_, err = storage.GetUpload(owner, expired.Token)
+	assert.ErrorIs(t, err, ErrUploadNotFound)
+
+	_, err = storage.CreateUpload(owner, "b.png", 10, time.Hour)
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(root, uploadsDir, expired.Token))
+	assert.True(t, os.IsNotExist(err), "starting an upload removes expired ones")
+}
@@ -0,0 +1,46 @@
+package filestorage
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageURLBuilderUnsigned(t *testing.T) {
+	b := NewImageURLBuilder(&config.Config{ImagePublicBaseURL: "https://cdn.example.com/static/"})
+	assert.Equal(t, "https://cdn.example.com/static/listings/a.jpg", b.URL("/listings/a.jpg"))
+	assert.True(t, b.Verify("listings/a.jpg", "", ""), "nothing to verify when signing is off")
+}
+
+func TestImageURLBuilderSignsAndVerifies(t *testing.T) {
+	now := time.Unix(1_700_000_100, 0)
+	b := NewImageURLBuilder(&config.Config{ImagePublicBaseURL: "/static", ImageURLSigningSecret: "s3cret", ImageURLTTL: time.Hour})
+	b.now = func() time.Time { return now }
+
+	raw := b.URL("listings/a.jpg")
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "/static/listings/a.jpg", u.Path)
+	expires, sig := u.Query().Get(ExpiresParam), u.Query().Get(SignatureParam)
+
+	exp, ok := ExpiresAt(expires)
+	require.True(t, ok)
+	assert.GreaterOrEqual(t, exp.Sub(now), time.Hour)
+	assert.Less(t, exp.Sub(now), 2*time.Hour)
+
+	now = now.Add(10 * time.Minute)
+	assert.Equal(t, raw, b.URL("listings/a.jpg"), "URLs within one window are identical")
+
+	assert.True(t, b.Verify("listings/a.jpg", expires, sig))
+	assert.False(t, b.Verify("listings/b.jpg", expires, sig), "signature is bound to the path")
+	assert.False(t, b.Verify("listings/a.jpg", expires, ""))
+	assert.False(t, b.Verify("listings/a.jpg", "9999999999", sig), "expiry cannot be extended")
+
+	now = exp.Add(time.Second)
+	assert.False(t, b.Verify("listings/a.jpg", expires, sig), "expired")
+}
@@ -0,0 +1,76 @@
+// File: internal/grpcapi/errors.go
+package grpcapi
+
+import (
+	"context"
+	"net/http"
+
+	"seattle_info_backend/internal/common"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorInterceptor turns the service layer's APIErrors into gRPC status errors.
+func errorInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		if _, ok := status.FromError(err); ok {
+			return resp, err
+		}
+		return nil, toStatus(err)
+	}
+}
+
+// recoveryInterceptor stops a panicking handler from taking down the process, like gin.Recovery does for HTTP.
+func recoveryInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("Panic in gRPC handler", zap.String("method", info.FullMethod), zap.Any("panic", r), zap.Stack("stack"))
+				err = status.Error(codes.Internal, "internal error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func toStatus(err error) error {
+	apiErr, ok := common.IsAPIError(err)
+	if !ok {
+		return status.Error(codes.Internal, "internal error")
+	}
+	msg := apiErr.Message
+	if details, ok := apiErr.Details.(string); ok && details != "" {
+		msg = details
+	}
+	return status.Error(codeForHTTPStatus(apiErr.StatusCode), msg)
+}
+
+func codeForHTTPStatus(statusCode int) codes.Code {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
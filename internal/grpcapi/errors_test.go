@@ -0,0 +1,29 @@
+package grpcapi
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatusMapsAPIErrors(t *testing.T) {
+	st, _ := status.FromError(toStatus(common.ErrNotFound.WithDetails("Listing not found.")))
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "Listing not found.", st.Message())
+
+	wrapped := fmt.Errorf("lookup: %w", common.ErrForbidden)
+	st, _ = status.FromError(toStatus(wrapped))
+	assert.Equal(t, codes.PermissionDenied, st.Code())
+}
+
+func TestToStatusHidesUnknownErrors(t *testing.T) {
+	st, _ := status.FromError(toStatus(errors.New("pq: connection refused")))
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Equal(t, "internal error", st.Message())
+}
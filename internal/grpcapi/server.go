@@ -0,0 +1,166 @@
+// File: internal/grpcapi/server.go
+// Package grpcapi serves the internal gRPC API used by other services. It shares the service layer with the HTTP API.
+package grpcapi
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"seattle_info_backend/internal/category"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/grpcapi/internalv1"
+	"seattle_info_backend/internal/listing"
+	"seattle_info_backend/internal/shared"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Server runs the internal gRPC API on its own port.
+type Server struct {
+	grpcServer *grpc.Server
+	health     *health.Server
+	address    string
+	logger     *zap.Logger
+}
+
+// NewServer builds the gRPC server. It returns nil when GRPC_ENABLED is false.
+// Clients must present a certificate signed by GRPC_TLS_CLIENT_CA_FILE (mTLS) unless GRPC_ALLOW_INSECURE is set, which
+// allows TLS without client certificates, or no TLS at all.
+func NewServer(
+	cfg *config.Config,
+	logger *zap.Logger,
+	listingService listing.Service,
+	userService shared.Service,
+	categoryService category.Service,
+) (*Server, error) {
+	if !cfg.GRPCEnabled {
+		return nil, nil
+	}
+	logger = logger.Named("GRPCServer")
+
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(recoveryInterceptor(logger), loggingInterceptor(logger), errorInterceptor()),
+	}
+	creds, err := serverCredentials(cfg)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case creds == nil:
+		logger.Warn("gRPC server is running without TLS (GRPC_ALLOW_INSECURE=true); do not use this outside local development")
+	case cfg.GRPCTLSClientCAFile == "":
+		logger.Warn("gRPC server does not require client certificates (GRPC_ALLOW_INSECURE=true); any client that can reach it is served")
+	}
+	if creds != nil {
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	grpcServer := grpc.NewServer(opts...)
+	internalv1.RegisterInternalServiceServer(grpcServer, &internalService{
+		listingService:  listingService,
+		userService:     userService,
+		categoryService: categoryService,
+	})
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	return &Server{
+		grpcServer: grpcServer,
+		health:     healthServer,
+		address:    fmt.Sprintf("%s:%s", cfg.ServerHost, cfg.GRPCPort),
+		logger:     logger,
+	}, nil
+}
+
+// Start listens on the gRPC port and serves until Shutdown is called.
+func (s *Server) Start() error {
+	lis, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.address, err)
+	}
+	s.logger.Info("gRPC server starting", zap.String("address", s.address))
+	if err := s.grpcServer.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("gRPC server failed: %w", err)
+	}
+	return nil
+}
+
+// Shutdown stops accepting new calls and waits for in-flight ones to finish.
+// Calls still running when ctx is done are cancelled.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+		return ctx.Err()
+	}
+}
+
+// serverCredentials loads the server certificate and the client CA for mTLS. Without GRPC_ALLOW_INSECURE, both are
+// required: the API returns user data and has no other authentication.
+func serverCredentials(cfg *config.Config) (credentials.TransportCredentials, error) {
+	if cfg.GRPCTLSCertFile == "" || cfg.GRPCTLSKeyFile == "" {
+		if cfg.GRPCAllowInsecure {
+			return nil, nil
+		}
+		return nil, errors.New("gRPC is enabled but GRPC_TLS_CERT_FILE and GRPC_TLS_KEY_FILE are not set (set GRPC_ALLOW_INSECURE=true for local development)")
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.GRPCTLSCertFile, cfg.GRPCTLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gRPC TLS key pair: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.GRPCTLSClientCAFile == "" {
+		if cfg.GRPCAllowInsecure {
+			return credentials.NewTLS(tlsConfig), nil
+		}
+		return nil, errors.New("gRPC TLS is enabled but GRPC_TLS_CLIENT_CA_FILE is not set, so clients would not be authenticated (set GRPC_ALLOW_INSECURE=true to serve TLS without client certificates)")
+	}
+	caPEM, err := os.ReadFile(cfg.GRPCTLSClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gRPC client CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("gRPC client CA file contains no valid certificates")
+	}
+	tlsConfig.ClientCAs = pool
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+func loggingInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		fields := []zap.Field{zap.String("method", info.FullMethod), zap.Duration("latency", time.Since(start))}
+		if err != nil {
+			logger.Warn("gRPC call failed", append(fields, zap.Error(err))...)
+		} else {
+			logger.Debug("gRPC call", fields...)
+		}
+		return resp, err
+	}
+}
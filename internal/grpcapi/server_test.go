@@ -0,0 +1,73 @@
+package grpcapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCertificate writes a self-signed certificate and its key to dir and returns their paths.
+func writeCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "grpc.test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func TestServerCredentialsRequireClientCA(t *testing.T) {
+	certFile, keyFile := writeCertificate(t, t.TempDir())
+	cfg := &config.Config{GRPCTLSCertFile: certFile, GRPCTLSKeyFile: keyFile}
+
+	_, err := serverCredentials(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GRPC_TLS_CLIENT_CA_FILE")
+
+	cfg.GRPCTLSClientCAFile = certFile
+	creds, err := serverCredentials(cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, creds)
+
+	// TLS without client certificates only with GRPC_ALLOW_INSECURE
+	cfg.GRPCTLSClientCAFile, cfg.GRPCAllowInsecure = "", true
+	creds, err = serverCredentials(cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, creds)
+}
+
+func TestServerCredentialsRequireTLS(t *testing.T) {
+	_, err := serverCredentials(&config.Config{})
+	assert.Error(t, err)
+
+	creds, err := serverCredentials(&config.Config{GRPCAllowInsecure: true})
+	require.NoError(t, err)
+	assert.Nil(t, creds)
+}
@@ -0,0 +1,154 @@
+// File: internal/grpcapi/service.go
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	"seattle_info_backend/internal/category"
+	"seattle_info_backend/internal/grpcapi/internalv1"
+	"seattle_info_backend/internal/listing"
+	"seattle_info_backend/internal/shared"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// internalService implements internalv1.InternalServiceServer on top of the existing services.
+type internalService struct {
+	internalv1.UnimplementedInternalServiceServer
+
+	listingService  listing.Service
+	userService     shared.Service
+	categoryService category.Service
+}
+
+func (s *internalService) GetListing(ctx context.Context, req *internalv1.GetListingRequest) (*internalv1.Listing, error) {
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	l, err := s.listingService.AdminGetListingByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toListingProto(l), nil
+}
+
+func (s *internalService) ValidateListing(ctx context.Context, req *internalv1.ValidateListingRequest) (*internalv1.ValidateListingResponse, error) {
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	l, err := s.listingService.AdminGetListingByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []string
+	if l.Status != listing.StatusActive {
+		problems = append(problems, "listing status is "+string(l.Status))
+	}
+	if !l.IsAdminApproved {
+		problems = append(problems, "listing is not admin approved")
+	}
+	if !l.ExpiresAt.After(time.Now()) {
+		problems = append(problems, "listing has expired")
+	}
+	return &internalv1.ValidateListingResponse{
+		Valid:    len(problems) == 0,
+		Problems: problems,
+		Listing:  toListingProto(l),
+	}, nil
+}
+
+func (s *internalService) GetUser(ctx context.Context, req *internalv1.GetUserRequest) (*internalv1.User, error) {
+	id, err := parseID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.userService.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &internalv1.User{
+		Id:                u.ID.String(),
+		Email:             deref(u.Email),
+		FirstName:         deref(u.FirstName),
+		LastName:          deref(u.LastName),
+		Role:              u.Role,
+		ProfilePictureUrl: deref(u.ProfilePictureURL),
+		IsEmailVerified:   u.IsEmailVerified,
+		CreatedAt:         timestamppb.New(u.CreatedAt),
+	}, nil
+}
+
+func (s *internalService) GetCategory(ctx context.Context, req *internalv1.GetCategoryRequest) (*internalv1.Category, error) {
+	idOrSlug := req.GetIdOrSlug()
+	if idOrSlug == "" {
+		return nil, status.Error(codes.InvalidArgument, "id_or_slug is required")
+	}
+	var (
+		cat *category.Category
+		err error
+	)
+	if id, parseErr := uuid.Parse(idOrSlug); parseErr == nil {
+		cat, err = s.categoryService.GetCategoryByID(ctx, id, false)
+	} else {
+		cat, err = s.categoryService.GetCategoryBySlug(ctx, idOrSlug, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toCategoryProto(cat), nil
+}
+
+func toListingProto(l *listing.Listing) *internalv1.Listing {
+	out := &internalv1.Listing{
+		Id:              l.ID.String(),
+		UserId:          l.UserID.String(),
+		Category:        toCategoryProto(&l.Category),
+		Title:           l.Title,
+		Description:     l.Description,
+		Status:          string(l.Status),
+		IsAdminApproved: l.IsAdminApproved,
+		City:            deref(l.City),
+		State:           deref(l.State),
+		ZipCode:         deref(l.ZipCode),
+		Latitude:        l.Latitude,
+		Longitude:       l.Longitude,
+		ExpiresAt:       timestamppb.New(l.ExpiresAt),
+		CreatedAt:       timestamppb.New(l.CreatedAt),
+		UpdatedAt:       timestamppb.New(l.UpdatedAt),
+	}
+	if l.SubCategoryID != nil {
+		out.SubCategoryId = l.SubCategoryID.String()
+	}
+	return out
+}
+
+func toCategoryProto(c *category.Category) *internalv1.Category {
+	return &internalv1.Category{
+		Id:          c.ID.String(),
+		Name:        c.Name,
+		Slug:        c.Slug,
+		Description: deref(c.Description),
+	}
+}
+
+func parseID(raw string) (uuid.UUID, error) {
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "id must be a UUID")
+	}
+	return id, nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
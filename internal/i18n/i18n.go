@@ -0,0 +1,162 @@
+// File: internal/i18n/i18n.go
+// Package i18n provides translation bundles for API messages and Accept-Language negotiation.
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLanguage is used when a request does not ask for a supported language
+// and as the fallback when a key is missing from a bundle.
+const DefaultLanguage = "en"
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// Vars holds the values substituted for {name} placeholders in a message.
+type Vars map[string]string
+
+// Bundle holds the messages for every supported language.
+type Bundle struct {
+	messages  map[string]map[string]string
+	languages []string // DefaultLanguage first, as required by the matcher
+	matcher   language.Matcher
+}
+
+// Load reads every <lang>.json file in dir of fsys into a Bundle.
+// The bundle must contain DefaultLanguage.
+func Load(fsys fs.FS, dir string) (*Bundle, error) {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locale files: %w", err)
+	}
+
+	b := &Bundle{messages: make(map[string]map[string]string)}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read locale file %s: %w", file, err)
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("failed to parse locale file %s: %w", file, err)
+		}
+		lang := strings.TrimSuffix(path.Base(file), ".json")
+		b.messages[lang] = messages
+	}
+	if _, ok := b.messages[DefaultLanguage]; !ok {
+		return nil, fmt.Errorf("locale bundle for default language %q is missing", DefaultLanguage)
+	}
+
+	b.languages = append(b.languages, DefaultLanguage)
+	others := make([]string, 0, len(b.messages)-1)
+	for lang := range b.messages {
+		if lang != DefaultLanguage {
+			others = append(others, lang)
+		}
+	}
+	sort.Strings(others)
+	b.languages = append(b.languages, others...)
+
+	tags := make([]language.Tag, len(b.languages))
+	for i, lang := range b.languages {
+		tags[i] = language.Make(lang)
+	}
+	b.matcher = language.NewMatcher(tags)
+	return b, nil
+}
+
+// Languages returns the supported language codes, DefaultLanguage first.
+func (b *Bundle) Languages() []string {
+	return append([]string(nil), b.languages...)
+}
+
+// IsSupported reports whether the bundle has messages for lang.
+func (b *Bundle) IsSupported(lang string) bool {
+	_, ok := b.messages[lang]
+	return ok
+}
+
+// Match picks the best supported language for an Accept-Language header value
+// (or a single language tag). It returns DefaultLanguage when nothing matches.
+func (b *Bundle) Match(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLanguage
+	}
+	_, index, confidence := b.matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLanguage
+	}
+	return b.languages[index]
+}
+
+// Lookup returns the raw message for key in lang without falling back.
+func (b *Bundle) Lookup(lang, key string) (string, bool) {
+	msg, ok := b.messages[lang][key]
+	return msg, ok
+}
+
+// T returns the message for key in lang with vars substituted. It falls back to
+// DefaultLanguage, and to the key itself when no bundle has it.
+func (b *Bundle) T(lang, key string, vars Vars) string {
+	msg, ok := b.Lookup(lang, key)
+	if !ok {
+		if msg, ok = b.Lookup(DefaultLanguage, key); !ok {
+			return key
+		}
+	}
+	for name, value := range vars {
+		msg = strings.ReplaceAll(msg, "{"+name+"}", value)
+	}
+	return msg
+}
+
+var defaultBundle = mustLoadDefault()
+
+func mustLoadDefault() *Bundle {
+	b, err := Load(localeFS, "locales")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: %v", err))
+	}
+	return b
+}
+
+// Languages returns the languages of the embedded bundle.
+func Languages() []string { return defaultBundle.Languages() }
+
+// IsSupported reports whether the embedded bundle supports lang.
+func IsSupported(lang string) bool { return defaultBundle.IsSupported(lang) }
+
+// Match negotiates a language against the embedded bundle.
+func Match(acceptLanguage string) string { return defaultBundle.Match(acceptLanguage) }
+
+// Lookup returns a message from the embedded bundle without falling back.
+func Lookup(lang, key string) (string, bool) { return defaultBundle.Lookup(lang, key) }
+
+// T translates key using the embedded bundle.
+func T(lang, key string, vars Vars) string { return defaultBundle.T(lang, key, vars) }
+
+type contextKey struct{}
+
+// WithLanguage returns a copy of ctx carrying the negotiated language.
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, contextKey{}, lang)
+}
+
+// FromContext returns the language stored in ctx, or DefaultLanguage.
+func FromContext(ctx context.Context) string {
+	if lang, ok := ctx.Value(contextKey{}).(string); ok && lang != "" {
+		return lang
+	}
+	return DefaultLanguage
+}
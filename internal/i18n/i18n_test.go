@@ -0,0 +1,39 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchNegotiatesAcceptLanguage(t *testing.T) {
+	assert.Equal(t, "es", Match("es-MX,es;q=0.9,en;q=0.8"))
+	assert.Equal(t, "vi", Match("fr;q=0.9, vi;q=0.8"))
+	assert.Equal(t, "zh", Match("zh-CN"))
+	assert.Equal(t, DefaultLanguage, Match("de-DE"))
+	assert.Equal(t, DefaultLanguage, Match(""))
+	assert.Equal(t, DefaultLanguage, Match("not a header;;"))
+}
+
+func TestTSubstitutesAndFallsBack(t *testing.T) {
+	vars := Vars{"field": "email"}
+	assert.Equal(t, "The email field is required.", T("en", "validation.required", vars))
+	assert.Equal(t, "El campo email es obligatorio.", T("es", "validation.required", vars))
+	assert.Equal(t, "The email field is required.", T("xx", "validation.required", vars), "unknown language falls back to English")
+	assert.Equal(t, "missing.key", T("es", "missing.key", nil))
+}
+
+func TestEveryBundleHasTheDefaultKeys(t *testing.T) {
+	for key := range defaultBundle.messages[DefaultLanguage] {
+		for _, lang := range Languages() {
+			_, ok := Lookup(lang, key)
+			assert.True(t, ok, "%s is missing %s", lang, key)
+		}
+	}
+}
+
+func TestLanguageContext(t *testing.T) {
+	assert.Equal(t, DefaultLanguage, FromContext(context.Background()))
+	assert.Equal(t, "vi", FromContext(WithLanguage(context.Background(), "vi")))
+}
@@ -0,0 +1,65 @@
+// File: internal/jobrun/handler.go
+package jobrun
+
+import (
+	"net/http"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Handler struct holds dependencies for job history admin handlers.
+type Handler struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewHandler creates a new job history handler.
+func NewHandler(service Service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterAdminRoutes adds the job history to the admin API group, which already requires the admin role.
+func (h *Handler) RegisterAdminRoutes(router *gin.RouterGroup) {
+	router.GET("/jobs", h.adminListRuns)
+	router.POST("/jobs/:name/run", h.adminTriggerRun)
+}
+
+func (h *Handler) adminListRuns(c *gin.Context) {
+	var query ListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	page, pageSize := common.GetPaginationParams(c)
+	runs, pagination, err := h.service.ListRuns(c.Request.Context(), query, page, pageSize)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]RunResponse, len(runs))
+	for i := range runs {
+		responses[i] = ToRunResponse(&runs[i])
+	}
+	common.RespondPaginated(c, "Job history retrieved successfully.", responses, pagination)
+}
+
+func (h *Handler) adminTriggerRun(c *gin.Context) {
+	adminID := common.GetUserIDFromContext(c)
+	if adminID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	run, err := h.service.Trigger(c.Request.Context(), c.Param("name"), adminID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondSuccess(c, http.StatusAccepted, "Job started.", ToRunResponse(run))
+}
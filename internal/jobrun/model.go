@@ -0,0 +1,85 @@
+// File: internal/jobrun/model.go
+package jobrun
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is the state of a job run.
+type Status string
+
+const (
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// Trigger is what started a job run.
+type Trigger string
+
+const (
+	TriggerSchedule Trigger = "schedule" // The job's cron schedule
+	TriggerManual   Trigger = "manual"   // An admin, with POST /admin/jobs/:name/run
+)
+
+// Run is one execution of a background job. A run whose process stopped before it finished stays running.
+type Run struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	JobName        string     `gorm:"type:varchar(100);not null"`
+	Trigger        Trigger    `gorm:"type:varchar(20);not null"`
+	TriggeredBy    *uuid.UUID `gorm:"type:uuid"` // The admin who triggered a manual run
+	Status         Status     `gorm:"type:varchar(20);not null;default:'running'"`
+	StartedAt      time.Time  `gorm:"not null"`
+	FinishedAt     *time.Time
+	ItemsProcessed int     `gorm:"not null;default:0"` // What the job counts, e.g. listings expired or digests sent
+	Error          *string `gorm:"type:text"`
+}
+
+// TableName specifies the table name for GORM.
+func (Run) TableName() string {
+	return "job_runs"
+}
+
+// ListQuery filters the job history. Empty fields match every run.
+type ListQuery struct {
+	Name   string `form:"name"`
+	Status string `form:"status" binding:"omitempty,oneof=running succeeded failed"`
+}
+
+// --- Response DTOs ---
+
+// RunResponse is the API representation of a job run.
+type RunResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	JobName        string     `json:"job_name"`
+	Trigger        Trigger    `json:"trigger"`
+	TriggeredBy    *uuid.UUID `json:"triggered_by,omitempty"`
+	Status         Status     `json:"status"`
+	StartedAt      time.Time  `json:"started_at"`
+	FinishedAt     *time.Time `json:"finished_at,omitempty"`
+	DurationMS     *int64     `json:"duration_ms,omitempty"`
+	ItemsProcessed int        `json:"items_processed"`
+	Error          *string    `json:"error,omitempty"`
+}
+
+// ToRunResponse converts a Run model to a RunResponse DTO.
+func ToRunResponse(r *Run) RunResponse {
+	resp := RunResponse{
+		ID:             r.ID,
+		JobName:        r.JobName,
+		Trigger:        r.Trigger,
+		TriggeredBy:    r.TriggeredBy,
+		Status:         r.Status,
+		StartedAt:      r.StartedAt,
+		FinishedAt:     r.FinishedAt,
+		ItemsProcessed: r.ItemsProcessed,
+		Error:          r.Error,
+	}
+	if r.FinishedAt != nil {
+		ms := r.FinishedAt.Sub(r.StartedAt).Milliseconds()
+		resp.DurationMS = &ms
+	}
+	return resp
+}
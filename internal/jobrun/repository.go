@@ -0,0 +1,102 @@
+// File: internal/jobrun/repository.go
+package jobrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for job history data operations.
+type Repository interface {
+	Create(ctx context.Context, run *Run) error
+	Finish(ctx context.Context, run *Run) error
+	// FindRunning returns the job's most recent run that started after since and has not finished, or nil.
+	FindRunning(ctx context.Context, name string, since time.Time) (*Run, error)
+	Find(ctx context.Context, query ListQuery, page, pageSize int) ([]Run, *common.Pagination, error)
+	DeleteStartedBefore(ctx context.Context, name string, before time.Time) error
+}
+
+// GORMRepository implements the job history Repository interface using GORM.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+// NewGORMRepository creates a new GORM job history repository.
+func NewGORMRepository(db *gorm.DB) Repository {
+	return &GORMRepository{db: db}
+}
+
+// Create inserts a new run.
+func (r *GORMRepository) Create(ctx context.Context, run *Run) error {
+	if err := r.db.WithContext(ctx).Create(run).Error; err != nil {
+		return fmt.Errorf("failed to create job run: %w", err)
+	}
+	return nil
+}
+
+// Finish stores the outcome of a run.
+func (r *GORMRepository) Finish(ctx context.Context, run *Run) error {
+	err := r.db.WithContext(ctx).Model(&Run{}).Where("id = ?", run.ID).Updates(map[string]interface{}{
+		"status":          run.Status,
+		"finished_at":     run.FinishedAt,
+		"items_processed": run.ItemsProcessed,
+		"error":           run.Error,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to finish job run: %w", err)
+	}
+	return nil
+}
+
+// FindRunning retrieves the job's latest unfinished run started after since.
+func (r *GORMRepository) FindRunning(ctx context.Context, name string, since time.Time) (*Run, error) {
+	var run Run
+	err := r.db.WithContext(ctx).
+		Where("job_name = ? AND status = ? AND started_at > ?", name, StatusRunning, since).
+		Order("started_at DESC").
+		First(&run).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find running job run: %w", err)
+	}
+	return &run, nil
+}
+
+// Find retrieves the job history matching query, most recently started first.
+func (r *GORMRepository) Find(ctx context.Context, query ListQuery, page, pageSize int) ([]Run, *common.Pagination, error) {
+	var runs []Run
+	var totalItems int64
+
+	dbQuery := r.db.WithContext(ctx).Model(&Run{})
+	if query.Name != "" {
+		dbQuery = dbQuery.Where("job_name = ?", query.Name)
+	}
+	if query.Status != "" {
+		dbQuery = dbQuery.Where("status = ?", query.Status)
+	}
+	if err := dbQuery.Count(&totalItems).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to count job runs: %w", err)
+	}
+
+	offset := (page - 1) * pageSize
+	if err := dbQuery.Order("started_at DESC").Offset(offset).Limit(pageSize).Find(&runs).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to list job runs: %w", err)
+	}
+	return runs, common.NewPagination(totalItems, page, pageSize), nil
+}
+
+// DeleteStartedBefore deletes the job's runs that started before the given time.
+func (r *GORMRepository) DeleteStartedBefore(ctx context.Context, name string, before time.Time) error {
+	if err := r.db.WithContext(ctx).Where("job_name = ? AND started_at < ?", name, before).Delete(&Run{}).Error; err != nil {
+		return fmt.Errorf("failed to delete old job runs: %w", err)
+	}
+	return nil
+}
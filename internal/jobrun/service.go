@@ -0,0 +1,217 @@
+// File: internal/jobrun/service.go
+package jobrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"seattle_info_backend/internal/audit"
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// recordTimeout bounds writing a run's outcome, which happens after the job's own context may have expired.
+const recordTimeout = 10 * time.Second
+
+// JobFunc does a job's work and returns how many items it processed.
+type JobFunc func(ctx context.Context) (int, error)
+
+// Job is a background job known to the Service.
+type Job struct {
+	Name    string
+	Timeout time.Duration // Each run gets a context that expires after Timeout
+	Run     JobFunc
+	// Local is set for jobs whose work only affects the process running them, such as refreshing an in-memory
+	// cache. Their runs in other processes do not count as the job already running.
+	Local bool
+}
+
+// Service records the history of background jobs and runs them on demand.
+// Jobs register themselves when they are constructed and call RunScheduled from their cron schedule.
+type Service interface {
+	Register(job Job)
+	RunScheduled(name string)
+	Trigger(ctx context.Context, name string, adminID uuid.UUID) (*Run, error)
+	ListRuns(ctx context.Context, query ListQuery, page, pageSize int) ([]Run, *common.Pagination, error)
+}
+
+// ServiceImplementation implements the job history Service interface.
+type ServiceImplementation struct {
+	repo         Repository
+	auditService audit.Service
+	logger       *zap.Logger
+	retention    time.Duration // JOB_HISTORY_RETENTION_DAYS; 0 keeps every run
+
+	mu      sync.RWMutex
+	jobs    map[string]Job
+	running map[string]bool // Local jobs running in this process
+}
+
+// NewService creates a new job history service.
+func NewService(repo Repository, auditService audit.Service, cfg *config.Config, logger *zap.Logger) Service {
+	return &ServiceImplementation{
+		repo:         repo,
+		auditService: auditService,
+		logger:       logger.Named("JobRuns"),
+		retention:    time.Duration(cfg.JobHistoryRetentionDays) * 24 * time.Hour,
+		jobs:         make(map[string]Job),
+		running:      make(map[string]bool),
+	}
+}
+
+// Register makes a job known under job.Name.
+func (s *ServiceImplementation) Register(job Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.Name] = job
+}
+
+// jobNames returns the registered job names in alphabetical order. Callers hold s.mu.
+func (s *ServiceImplementation) jobNames() []string {
+	names := make([]string, 0, len(s.jobs))
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RunScheduled runs a job from its schedule and waits for it to finish. The run is skipped while another run of
+// the job is in progress, in this or, unless the job is Local, another process. If the history cannot be written the job still runs.
+func (s *ServiceImplementation) RunScheduled(name string) {
+	job, run, err := s.start(context.Background(), name, TriggerSchedule, nil)
+	if err != nil {
+		if errors.Is(err, common.ErrConflict) {
+			s.logger.Info("Skipping scheduled job run, the job is already running", zap.String("job", name))
+			return
+		}
+		if errors.Is(err, common.ErrNotFound) {
+			s.logger.Error("Scheduled job is not registered", zap.String("job", name))
+			return
+		}
+		s.logger.Warn("Failed to record job run, running it untracked", zap.String("job", name), zap.Error(err))
+	}
+	s.execute(job, run)
+}
+
+// Trigger starts a run of a job outside its schedule, in the background, and records it in the audit log.
+func (s *ServiceImplementation) Trigger(ctx context.Context, name string, adminID uuid.UUID) (*Run, error) {
+	job, run, err := s.start(ctx, name, TriggerManual, &adminID)
+	if err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return nil, err
+		}
+		s.done(name)
+		s.logger.Error("Failed to record manual job run", zap.String("job", name), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not start job.")
+	}
+
+	_ = s.auditService.Record(ctx, audit.Event{
+		ActorID:    &adminID,
+		Action:     audit.ActionJobRun,
+		EntityType: audit.EntityJob,
+		EntityID:   name,
+		Changes:    map[string]audit.FieldChange{"run_id": {To: run.ID}},
+	})
+	s.logger.Info("Job triggered manually", zap.String("job", name), zap.String("runID", run.ID.String()), zap.String("adminID", adminID.String()))
+
+	started := *run
+	go s.execute(job, run)
+	return &started, nil
+}
+
+// ListRuns returns a page of the job history, most recently started first.
+func (s *ServiceImplementation) ListRuns(ctx context.Context, query ListQuery, page, pageSize int) ([]Run, *common.Pagination, error) {
+	runs, pagination, err := s.repo.Find(ctx, query, page, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to list job runs", zap.Error(err))
+		return nil, nil, common.ErrInternalServer.WithDetails("Could not retrieve job history.")
+	}
+	return runs, pagination, nil
+}
+
+// start looks up the job, marks it running in this process and records a new run of it. Runs left unfinished for
+// longer than the job's timeout are assumed abandoned and do not block a new one.
+// An APIError means the job cannot run now. Any other error means the run could not be recorded: the job is still
+// marked running and returned, and the caller either executes it or calls done.
+func (s *ServiceImplementation) start(ctx context.Context, name string, trigger Trigger, triggeredBy *uuid.UUID) (Job, *Run, error) {
+	s.mu.Lock()
+	job, ok := s.jobs[name]
+	if !ok {
+		names := s.jobNames()
+		s.mu.Unlock()
+		return job, nil, common.ErrNotFound.WithDetails(fmt.Sprintf("Job not found. Known jobs: %s.", strings.Join(names, ", ")))
+	}
+	if s.running[name] {
+		s.mu.Unlock()
+		return job, nil, common.ErrConflict.WithDetails("The job is already running.")
+	}
+	s.running[name] = true
+	s.mu.Unlock()
+
+	now := time.Now()
+	if !job.Local {
+		running, err := s.repo.FindRunning(ctx, name, now.Add(-job.Timeout))
+		if err != nil {
+			return job, nil, err
+		}
+		if running != nil {
+			s.done(name)
+			return job, nil, common.ErrConflict.WithDetails("The job is already running.")
+		}
+	}
+
+	run := &Run{JobName: name, Trigger: trigger, TriggeredBy: triggeredBy, Status: StatusRunning, StartedAt: now}
+	if err := s.repo.Create(ctx, run); err != nil {
+		return job, nil, err
+	}
+	return job, run, nil
+}
+
+// done marks the job as no longer running in this process.
+func (s *ServiceImplementation) done(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, name)
+}
+
+// execute runs the job and, when run is not nil, records its outcome and deletes the job's runs that are past the
+// retention period.
+func (s *ServiceImplementation) execute(job Job, run *Run) {
+	defer s.done(job.Name)
+	ctx, cancel := context.WithTimeout(context.Background(), job.Timeout)
+	defer cancel()
+
+	items, err := job.Run(ctx)
+	if run == nil {
+		return
+	}
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.ItemsProcessed = items
+	run.Status = StatusSucceeded
+	if err != nil {
+		msg := err.Error()
+		run.Status = StatusFailed
+		run.Error = &msg
+	}
+	recordCtx, cancelRecord := context.WithTimeout(context.Background(), recordTimeout)
+	defer cancelRecord()
+	if err := s.repo.Finish(recordCtx, run); err != nil {
+		s.logger.Error("Failed to record job run outcome", zap.String("job", job.Name), zap.String("runID", run.ID.String()), zap.Error(err))
+	}
+	if s.retention > 0 {
+		if err := s.repo.DeleteStartedBefore(recordCtx, job.Name, finishedAt.Add(-s.retention)); err != nil {
+			s.logger.Warn("Failed to delete old job runs", zap.String("job", job.Name), zap.Error(err))
+		}
+	}
+}
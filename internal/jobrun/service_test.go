@@ -0,0 +1,162 @@
+package jobrun
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/audit"
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeRepo struct {
+	mu       sync.Mutex
+	runs     []*Run
+	finished chan *Run
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{finished: make(chan *Run, 10)}
+}
+
+func (f *fakeRepo) Create(_ context.Context, run *Run) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	run.ID = uuid.New()
+	f.runs = append(f.runs, run)
+	return nil
+}
+
+func (f *fakeRepo) Finish(_ context.Context, run *Run) error {
+	f.finished <- run
+	return nil
+}
+
+func (f *fakeRepo) FindRunning(_ context.Context, name string, since time.Time) (*Run, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, r := range f.runs {
+		if r.JobName == name && r.Status == StatusRunning && r.StartedAt.After(since) {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeRepo) Find(context.Context, ListQuery, int, int) ([]Run, *common.Pagination, error) {
+	return nil, nil, nil
+}
+
+func (f *fakeRepo) DeleteStartedBefore(context.Context, string, time.Time) error {
+	return nil
+}
+
+type recordedEvents struct {
+	audit.Service
+	events []audit.Event
+}
+
+func (r *recordedEvents) Record(_ context.Context, event audit.Event) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func newTestService(repo Repository) (*ServiceImplementation, *recordedEvents) {
+	events := &recordedEvents{}
+	svc := NewService(repo, events, &config.Config{JobHistoryRetentionDays: 30}, zap.NewNop()).(*ServiceImplementation)
+	return svc, events
+}
+
+func TestRunScheduledRecordsOutcome(t *testing.T) {
+	repo := newFakeRepo()
+	svc, _ := newTestService(repo)
+	svc.Register(Job{Name: "expiry", Timeout: time.Minute, Run: func(context.Context) (int, error) { return 7, nil }})
+	svc.Register(Job{Name: "digest", Timeout: time.Minute, Run: func(context.Context) (int, error) { return 0, errors.New("smtp down") }})
+
+	svc.RunScheduled("expiry")
+	run := <-repo.finished
+	assert.Equal(t, StatusSucceeded, run.Status)
+	assert.Equal(t, TriggerSchedule, run.Trigger)
+	assert.Equal(t, 7, run.ItemsProcessed)
+	assert.NotNil(t, run.FinishedAt)
+
+	svc.RunScheduled("digest")
+	run = <-repo.finished
+	assert.Equal(t, StatusFailed, run.Status)
+	require.NotNil(t, run.Error)
+	assert.Equal(t, "smtp down", *run.Error)
+}
+
+func TestTriggerRunsJobInBackground(t *testing.T) {
+	repo := newFakeRepo()
+	svc, events := newTestService(repo)
+	release := make(chan struct{})
+	svc.Register(Job{Name: "expiry", Timeout: time.Minute, Run: func(context.Context) (int, error) {
+		<-release
+		return 3, nil
+	}})
+	adminID := uuid.New()
+
+	run, err := svc.Trigger(context.Background(), "expiry", adminID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusRunning, run.Status)
+	assert.Equal(t, TriggerManual, run.Trigger)
+	assert.Equal(t, &adminID, run.TriggeredBy)
+	require.Len(t, events.events, 1)
+	assert.Equal(t, audit.ActionJobRun, events.events[0].Action)
+	assert.Equal(t, "expiry", events.events[0].EntityID)
+
+	_, err = svc.Trigger(context.Background(), "expiry", adminID)
+	assert.ErrorIs(t, err, common.ErrConflict, "a job runs once at a time")
+
+	close(release)
+	finished := <-repo.finished
+	assert.Equal(t, StatusSucceeded, finished.Status)
+	assert.Equal(t, 3, finished.ItemsProcessed)
+}
+
+func TestTriggerUnknownJob(t *testing.T) {
+	svc, events := newTestService(newFakeRepo())
+	_, err := svc.Trigger(context.Background(), "nope", uuid.New())
+	assert.ErrorIs(t, err, common.ErrNotFound)
+	assert.Empty(t, events.events)
+}
+
+func TestRunningElsewhere(t *testing.T) {
+	repo := newFakeRepo()
+	// A run recorded by another process, and one abandoned long ago.
+	repo.runs = []*Run{
+		{JobName: "expiry", Status: StatusRunning, StartedAt: time.Now().Add(-time.Minute)},
+		{JobName: "trending", Status: StatusRunning, StartedAt: time.Now().Add(-time.Minute)},
+		{JobName: "digest", Status: StatusRunning, StartedAt: time.Now().Add(-2 * time.Hour)},
+	}
+	svc, _ := newTestService(repo)
+	ran := make(map[string]bool)
+	var mu sync.Mutex
+	for _, job := range []Job{{Name: "expiry"}, {Name: "trending", Local: true}, {Name: "digest"}} {
+		name := job.Name
+		job.Timeout = time.Hour
+		job.Run = func(context.Context) (int, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			ran[name] = true
+			return 0, nil
+		}
+		svc.Register(job)
+	}
+
+	svc.RunScheduled("expiry")
+	svc.RunScheduled("trending")
+	svc.RunScheduled("digest")
+	assert.False(t, ran["expiry"], "skipped while running in another process")
+	assert.True(t, ran["trending"], "local jobs only check this process")
+	assert.True(t, ran["digest"], "runs older than the timeout are abandoned")
+}
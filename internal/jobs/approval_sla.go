@@ -0,0 +1,186 @@
+// File: internal/jobs/approval_sla.go
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/jobrun"
+	"seattle_info_backend/internal/listing"
+	"seattle_info_backend/internal/notification"
+	"seattle_info_backend/internal/platform/email"
+	"seattle_info_backend/internal/shared"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// ApprovalSLAJob periodically checks how long listings have been waiting in pending_approval and alerts the admins,
+// with a notification and an email, when the longest wait exceeds APPROVAL_SLA_HOURS.
+type ApprovalSLAJob struct {
+	listingService      listing.Service
+	userService         shared.Service
+	notificationService notification.Service
+	emailSender         email.Sender
+	logger              *zap.Logger
+	runs                jobrun.Service
+	cfg                 *config.Config
+	cronScheduler       *cron.Cron
+
+	mu          sync.Mutex
+	lastAlertAt time.Time // Zero while the backlog is within the SLA
+}
+
+// NewApprovalSLAJob creates a new ApprovalSLAJob.
+func NewApprovalSLAJob(
+	listingService listing.Service,
+	userService shared.Service,
+	notificationService notification.Service,
+	emailSender email.Sender,
+	runs jobrun.Service,
+	logger *zap.Logger,
+	cfg *config.Config,
+) *ApprovalSLAJob {
+	cronLogger := NewCronLogger(logger.Named("cron"))
+	scheduler := cron.New(cron.WithLogger(cronLogger), cron.WithChain(cron.SkipIfStillRunning(cronLogger)))
+
+	j := &ApprovalSLAJob{
+		listingService:      listingService,
+		userService:         userService,
+		notificationService: notificationService,
+		emailSender:         emailSender,
+		runs:                runs,
+		logger:              logger.Named("ApprovalSLAJob"),
+		cfg:                 cfg,
+		cronScheduler:       scheduler,
+	}
+	runs.Register(jobrun.Job{Name: JobApprovalSLA, Timeout: 5 * time.Minute, Run: j.runJob})
+	return j
+}
+
+// SetupAndStart schedules and starts the cron job.
+func (j *ApprovalSLAJob) SetupAndStart() error {
+	jobSpec := j.cfg.ApprovalSLAJobSchedule
+	if jobSpec == "" {
+		j.logger.Warn("Approval SLA job schedule not defined (APPROVAL_SLA_JOB_SCHEDULE). Admins will not be alerted about old pending listings.")
+		return nil
+	}
+	if j.cfg.ApprovalSLA <= 0 {
+		j.logger.Info("APPROVAL_SLA_HOURS is 0; approval SLA alerts are disabled.")
+		return nil
+	}
+
+	jobID, err := j.cronScheduler.AddFunc(jobSpec, func() { j.runs.RunScheduled(JobApprovalSLA) })
+	if err != nil {
+		j.logger.Error("Failed to schedule approval SLA job", zap.String("spec", jobSpec), zap.Error(err))
+		return err
+	}
+
+	j.logger.Info("Approval SLA job scheduled", zap.String("spec", jobSpec), zap.Duration("sla", j.cfg.ApprovalSLA), zap.Any("jobID", jobID))
+	j.cronScheduler.Start()
+	return nil
+}
+
+// runJob is the actual work performed by the job. It returns the number of admins alerted.
+// While the SLA stays breached, the alert is repeated once per SLA period rather than on every run.
+func (j *ApprovalSLAJob) runJob(ctx context.Context) (int, error) {
+	if j.cfg.ApprovalSLA <= 0 {
+		return 0, nil
+	}
+	backlog, err := j.listingService.GetApprovalBacklog(ctx)
+	if err != nil {
+		j.logger.Error("Approval SLA job run failed", zap.Error(err))
+		return 0, err
+	}
+
+	now := time.Now()
+	age := backlog.OldestAge(now)
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if age <= j.cfg.ApprovalSLA {
+		j.lastAlertAt = time.Time{}
+		j.logger.Debug("Approval backlog within SLA", zap.Int64("pending", backlog.Pending), zap.Duration("oldest_age", age))
+		return 0, nil
+	}
+	if !j.lastAlertAt.IsZero() && now.Sub(j.lastAlertAt) < j.cfg.ApprovalSLA {
+		return 0, nil
+	}
+
+	j.logger.Warn("Approval SLA breached",
+		zap.Int64("pending", backlog.Pending),
+		zap.Duration("oldest_age", age),
+		zap.Duration("sla", j.cfg.ApprovalSLA))
+	alerted, err := j.alertAdmins(ctx, backlog.Pending, age)
+	if err != nil {
+		j.logger.Error("Approval SLA job run failed", zap.Error(err))
+		return alerted, err
+	}
+	j.lastAlertAt = now
+	return alerted, nil
+}
+
+// alertAdmins sends every admin a notification and, when they have an email address, an email about the backlog.
+// Failures for one admin are logged and do not stop the others.
+func (j *ApprovalSLAJob) alertAdmins(ctx context.Context, pending int64, age time.Duration) (int, error) {
+	admins, err := j.findAdmins(ctx)
+	if err != nil {
+		return 0, err
+	}
+	hours := int(age / time.Hour)
+	message := fmt.Sprintf("%d listings are waiting for approval; the oldest has waited %d hours, over the %d-hour SLA.",
+		pending, hours, int(j.cfg.ApprovalSLA/time.Hour))
+
+	alerted := 0
+	for _, admin := range admins {
+		if _, err := j.notificationService.CreateNotification(ctx, admin.ID, notification.ApprovalSLABreached, message, nil); err != nil {
+			j.logger.Warn("Failed to notify admin of approval SLA breach", zap.Error(err), zap.String("adminID", admin.ID.String()))
+		}
+		if admin.Email != nil && *admin.Email != "" && j.emailSender != nil {
+			err := j.emailSender.Send(ctx, email.Message{
+				To:      *admin.Email,
+				Subject: fmt.Sprintf("Approval queue: a listing has waited %d hours", hours),
+				Body:    message + "\n\nReview pending listings in the admin dashboard.\n",
+			})
+			if err != nil {
+				j.logger.Warn("Failed to email admin about approval SLA breach", zap.Error(err), zap.String("adminID", admin.ID.String()))
+			}
+		}
+		alerted++
+	}
+	return alerted, nil
+}
+
+// findAdmins returns every user with the admin role.
+func (j *ApprovalSLAJob) findAdmins(ctx context.Context) ([]*shared.User, error) {
+	role := common.RoleAdmin
+	var admins []*shared.User
+	for page := 1; ; page++ {
+		query := shared.UserSearchQuery{PaginationQuery: common.PaginationQuery{Page: page, PageSize: common.MaxPageSize}, Role: &role}
+		users, pagination, err := j.userService.SearchUsers(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		admins = append(admins, users...)
+		if pagination == nil || !pagination.HasNext {
+			return admins, nil
+		}
+	}
+}
+
+// Stop gracefully stops the cron scheduler.
+func (j *ApprovalSLAJob) Stop() {
+	if j.cronScheduler != nil {
+		j.logger.Info("Stopping approval SLA job scheduler...")
+		stopCtx := j.cronScheduler.Stop()
+		select {
+		case <-stopCtx.Done():
+			j.logger.Info("Approval SLA job scheduler stopped gracefully.")
+		case <-time.After(10 * time.Second):
+			j.logger.Warn("Approval SLA job scheduler stop timed out.")
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// File: internal/jobs/featured_expiry.go
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/jobrun"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// FeaturedExpiryJob periodically clears the featured flag of listings whose feature period has ended.
+type FeaturedExpiryJob struct {
+	listingService listing.Service
+	logger         *zap.Logger
+	runs           jobrun.Service
+	cfg            *config.Config
+	cronScheduler  *cron.Cron
+}
+
+// NewFeaturedExpiryJob creates a new FeaturedExpiryJob.
+func NewFeaturedExpiryJob(
+	listingService listing.Service,
+	runs jobrun.Service,
+	logger *zap.Logger,
+	cfg *config.Config,
+) *FeaturedExpiryJob {
+	cronLogger := NewCronLogger(logger.Named("cron"))
+	scheduler := cron.New(cron.WithLogger(cronLogger), cron.WithChain(cron.SkipIfStillRunning(cronLogger)))
+
+	j := &FeaturedExpiryJob{
+		listingService: listingService,
+		runs:           runs,
+		logger:         logger.Named("FeaturedExpiryJob"),
+		cfg:            cfg,
+		cronScheduler:  scheduler,
+	}
+	runs.Register(jobrun.Job{Name: JobFeaturedExpiry, Timeout: 5 * time.Minute, Run: j.runJob})
+	return j
+}
+
+// SetupAndStart schedules and starts the cron job.
+func (j *FeaturedExpiryJob) SetupAndStart() error {
+	jobSpec := j.cfg.FeaturedExpiryJobSchedule
+	if jobSpec == "" {
+		j.logger.Warn("Featured expiry job schedule not defined (FEATURED_EXPIRY_JOB_SCHEDULE). Ended feature periods are only ignored, not cleared.")
+		return nil
+	}
+
+	jobID, err := j.cronScheduler.AddFunc(jobSpec, func() { j.runs.RunScheduled(JobFeaturedExpiry) })
+	if err != nil {
+		j.logger.Error("Failed to schedule featured expiry job", zap.String("spec", jobSpec), zap.Error(err))
+		return err
+	}
+
+	j.logger.Info("Featured expiry job scheduled", zap.String("spec", jobSpec), zap.Any("jobID", jobID))
+	j.cronScheduler.Start()
+	return nil
+}
+
+// runJob is the actual work performed by the job. It returns the number of listings unfeatured.
+func (j *FeaturedExpiryJob) runJob(ctx context.Context) (int, error) {
+	j.logger.Debug("Starting featured expiry job run...")
+
+	cleared, err := j.listingService.ExpireFeaturedListings(ctx)
+	if err != nil {
+		j.logger.Error("Featured expiry job run failed", zap.Error(err))
+		return 0, err
+	}
+	j.logger.Debug("Featured expiry job run completed", zap.Int("listings_unfeatured", cleared))
+	return cleared, nil
+}
+
+// Stop gracefully stops the cron scheduler.
+func (j *FeaturedExpiryJob) Stop() {
+	if j.cronScheduler != nil {
+		j.logger.Info("Stopping featured expiry job scheduler...")
+		stopCtx := j.cronScheduler.Stop()
+		select {
+		case <-stopCtx.Done():
+			j.logger.Info("Featured expiry job scheduler stopped gracefully.")
+		case <-time.After(10 * time.Second):
+			j.logger.Warn("Featured expiry job scheduler stop timed out.")
+		}
+	}
+}
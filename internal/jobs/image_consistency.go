@@ -0,0 +1,91 @@
+// File: internal/jobs/image_consistency.go
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/jobrun"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// ImageConsistencyJob periodically cross-checks listing images against stored files and removes
+// files no listing refers to and images whose file is gone.
+type ImageConsistencyJob struct {
+	listingService listing.Service
+	logger         *zap.Logger
+	runs           jobrun.Service
+	cfg            *config.Config
+	cronScheduler  *cron.Cron
+}
+
+// NewImageConsistencyJob creates a new ImageConsistencyJob.
+func NewImageConsistencyJob(
+	listingService listing.Service,
+	runs jobrun.Service,
+	logger *zap.Logger,
+	cfg *config.Config,
+) *ImageConsistencyJob {
+	cronLogger := NewCronLogger(logger.Named("cron"))
+	scheduler := cron.New(cron.WithLogger(cronLogger), cron.WithChain(cron.SkipIfStillRunning(cronLogger)))
+
+	j := &ImageConsistencyJob{
+		listingService: listingService,
+		runs:           runs,
+		logger:         logger.Named("ImageConsistencyJob"),
+		cfg:            cfg,
+		cronScheduler:  scheduler,
+	}
+	runs.Register(jobrun.Job{Name: JobImageConsistency, Timeout: 30 * time.Minute, Run: j.runJob})
+	return j
+}
+
+// SetupAndStart schedules and starts the cron job.
+func (j *ImageConsistencyJob) SetupAndStart() error {
+	jobSpec := j.cfg.ImageConsistencyJobSchedule
+	if jobSpec == "" {
+		j.logger.Info("Image consistency job schedule not defined (IMAGE_CONSISTENCY_JOB_SCHEDULE). Job will not run.")
+		return nil
+	}
+
+	jobID, err := j.cronScheduler.AddFunc(jobSpec, func() { j.runs.RunScheduled(JobImageConsistency) })
+	if err != nil {
+		j.logger.Error("Failed to schedule image consistency job", zap.String("spec", jobSpec), zap.Error(err))
+		return err
+	}
+
+	j.logger.Info("Image consistency job scheduled", zap.String("spec", jobSpec), zap.Any("jobID", jobID))
+	j.cronScheduler.Start()
+	return nil
+}
+
+// runJob is the actual work performed by the job. It returns the number of orphaned files and image records deleted.
+func (j *ImageConsistencyJob) runJob(ctx context.Context) (int, error) {
+	j.logger.Info("Starting image consistency job run...")
+
+	report, err := j.listingService.CheckImageConsistency(ctx, false)
+	if err != nil {
+		j.logger.Error("Image consistency job run failed", zap.Error(err))
+		return 0, err
+	}
+	j.logger.Info("Image consistency job run completed", zap.Int("files_deleted", report.FilesDeleted), zap.Int("images_deleted", report.ImagesDeleted))
+	return report.FilesDeleted + report.ImagesDeleted, nil
+}
+
+// Stop gracefully stops the cron scheduler.
+func (j *ImageConsistencyJob) Stop() {
+	if j.cronScheduler != nil {
+		j.logger.Info("Stopping image consistency job scheduler...")
+		stopCtx := j.cronScheduler.Stop()
+		select {
+		case <-stopCtx.Done():
+			j.logger.Info("Image consistency job scheduler stopped gracefully.")
+		case <-time.After(10 * time.Second):
+			j.logger.Warn("Image consistency job scheduler stop timed out.")
+		}
+	}
+}
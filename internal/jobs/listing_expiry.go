@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/jobrun"
 	"seattle_info_backend/internal/listing" // For listing.Service
 
 	"github.com/robfig/cron/v3"
@@ -17,6 +18,7 @@ import (
 type ListingExpiryJob struct {
 	listingService listing.Service
 	logger         *zap.Logger
+	runs           jobrun.Service
 	cfg            *config.Config
 	cronScheduler  *cron.Cron
 }
@@ -24,6 +26,7 @@ type ListingExpiryJob struct {
 // NewListingExpiryJob creates a new ListingExpiryJob.
 func NewListingExpiryJob(
 	listingService listing.Service,
+	runs jobrun.Service,
 	logger *zap.Logger,
 	cfg *config.Config,
 ) *ListingExpiryJob {
@@ -31,12 +34,15 @@ func NewListingExpiryJob(
 	// cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DefaultLogger))) // Skip if previous run is still active
 	scheduler := cron.New(cron.WithLogger(NewCronLogger(logger.Named("cron"))))
 
-	return &ListingExpiryJob{
+	j := &ListingExpiryJob{
 		listingService: listingService,
+		runs:           runs,
 		logger:         logger.Named("ListingExpiryJob"), // Named logger for context
 		cfg:            cfg,
 		cronScheduler:  scheduler,
 	}
+	runs.Register(jobrun.Job{Name: JobListingExpiry, Timeout: 5 * time.Minute, Run: j.runJob})
+	return j
 }
 
 // SetupAndStart schedules and starts the cron job.
@@ -47,7 +53,7 @@ func (j *ListingExpiryJob) SetupAndStart() error {
 		return nil // Not a fatal error, just won't run
 	}
 
-	jobID, err := j.cronScheduler.AddFunc(jobSpec, j.runJob)
+	jobID, err := j.cronScheduler.AddFunc(jobSpec, func() { j.runs.RunScheduled(JobListingExpiry) })
 	if err != nil {
 		j.logger.Error("Failed to schedule listing expiry job", zap.String("spec", jobSpec), zap.Error(err))
 		return err
@@ -58,18 +64,17 @@ func (j *ListingExpiryJob) SetupAndStart() error {
 	return nil
 }
 
-// runJob is the actual work performed by the cron job.
-func (j *ListingExpiryJob) runJob() {
+// runJob is the actual work performed by the job. It returns the number of listings expired.
+func (j *ListingExpiryJob) runJob(ctx context.Context) (int, error) {
 	j.logger.Info("Starting listing expiry job run...")
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute) // Job timeout
-	defer cancel()
 
 	expiredCount, err := j.listingService.ExpireListings(ctx)
 	if err != nil {
 		j.logger.Error("Listing expiry job run failed", zap.Error(err))
-	} else {
-		j.logger.Info("Listing expiry job run completed", zap.Int("listings_expired", expiredCount))
+		return 0, err
 	}
+	j.logger.Info("Listing expiry job run completed", zap.Int("listings_expired", expiredCount))
+	return expiredCount, nil
 }
 
 // Stop gracefully stops the cron scheduler.
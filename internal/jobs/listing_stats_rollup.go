@@ -0,0 +1,92 @@
+// File: internal/jobs/listing_stats_rollup.go
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/jobrun"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// ListingStatsRollupJob rolls up the previous UTC day's listing views, contact reveals and search impressions
+// into the owner analytics served by GET /listings/:id/analytics.
+type ListingStatsRollupJob struct {
+	listingService listing.Service
+	logger         *zap.Logger
+	runs           jobrun.Service
+	cfg            *config.Config
+	cronScheduler  *cron.Cron
+}
+
+// NewListingStatsRollupJob creates a new ListingStatsRollupJob.
+func NewListingStatsRollupJob(
+	listingService listing.Service,
+	runs jobrun.Service,
+	logger *zap.Logger,
+	cfg *config.Config,
+) *ListingStatsRollupJob {
+	cronLogger := NewCronLogger(logger.Named("cron"))
+	scheduler := cron.New(cron.WithLogger(cronLogger), cron.WithChain(cron.SkipIfStillRunning(cronLogger)))
+
+	j := &ListingStatsRollupJob{
+		listingService: listingService,
+		runs:           runs,
+		logger:         logger.Named("ListingStatsRollupJob"),
+		cfg:            cfg,
+		cronScheduler:  scheduler,
+	}
+	runs.Register(jobrun.Job{Name: JobListingStatsRollup, Timeout: 5 * time.Minute, Run: j.runJob})
+	return j
+}
+
+// SetupAndStart schedules and starts the cron job.
+func (j *ListingStatsRollupJob) SetupAndStart() error {
+	jobSpec := j.cfg.ListingStatsJobSchedule
+	if jobSpec == "" {
+		j.logger.Warn("Listing stats rollup job schedule not defined (LISTING_STATS_JOB_SCHEDULE). Listing analytics will not be updated.")
+		return nil
+	}
+
+	jobID, err := j.cronScheduler.AddFunc(jobSpec, func() { j.runs.RunScheduled(JobListingStatsRollup) })
+	if err != nil {
+		j.logger.Error("Failed to schedule listing stats rollup job", zap.String("spec", jobSpec), zap.Error(err))
+		return err
+	}
+
+	j.logger.Info("Listing stats rollup job scheduled", zap.String("spec", jobSpec), zap.Any("jobID", jobID))
+	j.cronScheduler.Start()
+	return nil
+}
+
+// runJob is the actual work performed by the job. It returns the number of listings rolled up.
+func (j *ListingStatsRollupJob) runJob(ctx context.Context) (int, error) {
+	j.logger.Debug("Starting listing stats rollup job run...")
+
+	yesterday := time.Now().UTC().AddDate(0, 0, -1)
+	rolledUp, err := j.listingService.RollupListingStats(ctx, yesterday)
+	if err != nil {
+		j.logger.Error("Listing stats rollup job run failed", zap.Error(err))
+		return 0, err
+	}
+	j.logger.Debug("Listing stats rollup job run completed", zap.Int("listings_rolled_up", rolledUp))
+	return rolledUp, nil
+}
+
+// Stop gracefully stops the cron scheduler.
+func (j *ListingStatsRollupJob) Stop() {
+	if j.cronScheduler != nil {
+		j.logger.Info("Stopping listing stats rollup job scheduler...")
+		stopCtx := j.cronScheduler.Stop()
+		select {
+		case <-stopCtx.Done():
+			j.logger.Info("Listing stats rollup job scheduler stopped gracefully.")
+		case <-time.After(10 * time.Second):
+			j.logger.Warn("Listing stats rollup job scheduler stop timed out.")
+		}
+	}
+}
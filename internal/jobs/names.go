@@ -0,0 +1,16 @@
+// File: internal/jobs/names.go
+package jobs
+
+// Names the jobs are recorded under in the job history and triggered by with POST /admin/jobs/:name/run.
+const (
+	JobListingExpiry      = "listing_expiry"
+	JobSavedSearchDigest  = "saved_search_digest"
+	JobWebhookDelivery    = "webhook_delivery"
+	JobImageConsistency   = "image_consistency"
+	JobScheduledPublish   = "scheduled_publish"
+	JobFeaturedExpiry     = "featured_expiry"
+	JobListingStatsRollup = "listing_stats_rollup"
+	JobTrendingListings   = "trending_listings"
+	JobApprovalSLA        = "approval_sla"
+	JobRentBackfill       = "rent_backfill"
+)
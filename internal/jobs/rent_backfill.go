@@ -0,0 +1,91 @@
+// File: internal/jobs/rent_backfill.go
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/jobrun"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// RentBackfillJob periodically fills the numeric rent of housing listings from their free-text rent_details
+// and logs the rents it cannot read for an admin to fix.
+type RentBackfillJob struct {
+	listingService listing.Service
+	logger         *zap.Logger
+	runs           jobrun.Service
+	cfg            *config.Config
+	cronScheduler  *cron.Cron
+}
+
+// NewRentBackfillJob creates a new RentBackfillJob.
+func NewRentBackfillJob(
+	listingService listing.Service,
+	runs jobrun.Service,
+	logger *zap.Logger,
+	cfg *config.Config,
+) *RentBackfillJob {
+	cronLogger := NewCronLogger(logger.Named("cron"))
+	scheduler := cron.New(cron.WithLogger(cronLogger), cron.WithChain(cron.SkipIfStillRunning(cronLogger)))
+
+	j := &RentBackfillJob{
+		listingService: listingService,
+		runs:           runs,
+		logger:         logger.Named("RentBackfillJob"),
+		cfg:            cfg,
+		cronScheduler:  scheduler,
+	}
+	runs.Register(jobrun.Job{Name: JobRentBackfill, Timeout: 30 * time.Minute, Run: j.runJob})
+	return j
+}
+
+// SetupAndStart schedules and starts the cron job.
+func (j *RentBackfillJob) SetupAndStart() error {
+	jobSpec := j.cfg.RentBackfillJobSchedule
+	if jobSpec == "" {
+		j.logger.Info("Rent backfill job schedule not defined (RENT_BACKFILL_JOB_SCHEDULE). Job will not run.")
+		return nil
+	}
+
+	jobID, err := j.cronScheduler.AddFunc(jobSpec, func() { j.runs.RunScheduled(JobRentBackfill) })
+	if err != nil {
+		j.logger.Error("Failed to schedule rent backfill job", zap.String("spec", jobSpec), zap.Error(err))
+		return err
+	}
+
+	j.logger.Info("Rent backfill job scheduled", zap.String("spec", jobSpec), zap.Any("jobID", jobID))
+	j.cronScheduler.Start()
+	return nil
+}
+
+// runJob is the actual work performed by the job. It returns the number of rents stored.
+func (j *RentBackfillJob) runJob(ctx context.Context) (int, error) {
+	j.logger.Info("Starting rent backfill job run...")
+
+	report, err := j.listingService.BackfillRentAmounts(ctx, false)
+	if err != nil {
+		j.logger.Error("Rent backfill job run failed", zap.Error(err))
+		return 0, err
+	}
+	j.logger.Info("Rent backfill job run completed", zap.Int("updated", report.Updated), zap.Int("unparseable", report.Unparseable))
+	return report.Updated, nil
+}
+
+// Stop gracefully stops the cron scheduler.
+func (j *RentBackfillJob) Stop() {
+	if j.cronScheduler != nil {
+		j.logger.Info("Stopping rent backfill job scheduler...")
+		stopCtx := j.cronScheduler.Stop()
+		select {
+		case <-stopCtx.Done():
+			j.logger.Info("Rent backfill job scheduler stopped gracefully.")
+		case <-time.After(10 * time.Second):
+			j.logger.Warn("Rent backfill job scheduler stop timed out.")
+		}
+	}
+}
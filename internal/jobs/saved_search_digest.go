@@ -0,0 +1,89 @@
+// File: internal/jobs/saved_search_digest.go
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/jobrun"
+	"seattle_info_backend/internal/savedsearch"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// SavedSearchDigestJob periodically notifies users about new matches for their saved searches.
+type SavedSearchDigestJob struct {
+	savedSearchService savedsearch.Service
+	logger             *zap.Logger
+	runs               jobrun.Service
+	cfg                *config.Config
+	cronScheduler      *cron.Cron
+}
+
+// NewSavedSearchDigestJob creates a new SavedSearchDigestJob.
+func NewSavedSearchDigestJob(
+	savedSearchService savedsearch.Service,
+	runs jobrun.Service,
+	logger *zap.Logger,
+	cfg *config.Config,
+) *SavedSearchDigestJob {
+	scheduler := cron.New(cron.WithLogger(NewCronLogger(logger.Named("cron"))))
+
+	j := &SavedSearchDigestJob{
+		savedSearchService: savedSearchService,
+		runs:               runs,
+		logger:             logger.Named("SavedSearchDigestJob"),
+		cfg:                cfg,
+		cronScheduler:      scheduler,
+	}
+	runs.Register(jobrun.Job{Name: JobSavedSearchDigest, Timeout: 10 * time.Minute, Run: j.runJob})
+	return j
+}
+
+// SetupAndStart schedules and starts the cron job.
+func (j *SavedSearchDigestJob) SetupAndStart() error {
+	jobSpec := j.cfg.SavedSearchDigestJobSchedule
+	if jobSpec == "" {
+		j.logger.Warn("Saved search digest job schedule not defined (SAVED_SEARCH_DIGEST_JOB_SCHEDULE). Job will not run.")
+		return nil
+	}
+
+	jobID, err := j.cronScheduler.AddFunc(jobSpec, func() { j.runs.RunScheduled(JobSavedSearchDigest) })
+	if err != nil {
+		j.logger.Error("Failed to schedule saved search digest job", zap.String("spec", jobSpec), zap.Error(err))
+		return err
+	}
+
+	j.logger.Info("Saved search digest job scheduled", zap.String("spec", jobSpec), zap.Any("jobID", jobID))
+	j.cronScheduler.Start()
+	return nil
+}
+
+// runJob is the actual work performed by the job. It returns the number of digests sent.
+func (j *SavedSearchDigestJob) runJob(ctx context.Context) (int, error) {
+	j.logger.Info("Starting saved search digest job run...")
+
+	sent, err := j.savedSearchService.SendDailyDigests(ctx)
+	if err != nil {
+		j.logger.Error("Saved search digest job run failed", zap.Error(err))
+		return 0, err
+	}
+	j.logger.Info("Saved search digest job run completed", zap.Int("digests_sent", sent))
+	return sent, nil
+}
+
+// Stop gracefully stops the cron scheduler.
+func (j *SavedSearchDigestJob) Stop() {
+	if j.cronScheduler != nil {
+		j.logger.Info("Stopping saved search digest job scheduler...")
+		stopCtx := j.cronScheduler.Stop()
+		select {
+		case <-stopCtx.Done():
+			j.logger.Info("Saved search digest job scheduler stopped gracefully.")
+		case <-time.After(10 * time.Second):
+			j.logger.Warn("Saved search digest job scheduler stop timed out.")
+		}
+	}
+}
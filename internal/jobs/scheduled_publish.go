@@ -0,0 +1,90 @@
+// File: internal/jobs/scheduled_publish.go
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/jobrun"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// ScheduledPublishJob periodically makes scheduled listings whose publish time has come live.
+type ScheduledPublishJob struct {
+	listingService listing.Service
+	logger         *zap.Logger
+	runs           jobrun.Service
+	cfg            *config.Config
+	cronScheduler  *cron.Cron
+}
+
+// NewScheduledPublishJob creates a new ScheduledPublishJob.
+func NewScheduledPublishJob(
+	listingService listing.Service,
+	runs jobrun.Service,
+	logger *zap.Logger,
+	cfg *config.Config,
+) *ScheduledPublishJob {
+	cronLogger := NewCronLogger(logger.Named("cron"))
+	scheduler := cron.New(cron.WithLogger(cronLogger), cron.WithChain(cron.SkipIfStillRunning(cronLogger)))
+
+	j := &ScheduledPublishJob{
+		listingService: listingService,
+		runs:           runs,
+		logger:         logger.Named("ScheduledPublishJob"),
+		cfg:            cfg,
+		cronScheduler:  scheduler,
+	}
+	runs.Register(jobrun.Job{Name: JobScheduledPublish, Timeout: 5 * time.Minute, Run: j.runJob})
+	return j
+}
+
+// SetupAndStart schedules and starts the cron job.
+func (j *ScheduledPublishJob) SetupAndStart() error {
+	jobSpec := j.cfg.ScheduledPublishJobSchedule
+	if jobSpec == "" {
+		j.logger.Warn("Scheduled publish job schedule not defined (SCHEDULED_PUBLISH_JOB_SCHEDULE). Scheduled listings will not go live.")
+		return nil
+	}
+
+	jobID, err := j.cronScheduler.AddFunc(jobSpec, func() { j.runs.RunScheduled(JobScheduledPublish) })
+	if err != nil {
+		j.logger.Error("Failed to schedule scheduled publish job", zap.String("spec", jobSpec), zap.Error(err))
+		return err
+	}
+
+	j.logger.Info("Scheduled publish job scheduled", zap.String("spec", jobSpec), zap.Any("jobID", jobID))
+	j.cronScheduler.Start()
+	return nil
+}
+
+// runJob is the actual work performed by the job. It returns the number of listings published.
+func (j *ScheduledPublishJob) runJob(ctx context.Context) (int, error) {
+	j.logger.Debug("Starting scheduled publish job run...")
+
+	published, err := j.listingService.PublishScheduledListings(ctx)
+	if err != nil {
+		j.logger.Error("Scheduled publish job run failed", zap.Error(err))
+		return 0, err
+	}
+	j.logger.Debug("Scheduled publish job run completed", zap.Int("listings_published", published))
+	return published, nil
+}
+
+// Stop gracefully stops the cron scheduler.
+func (j *ScheduledPublishJob) Stop() {
+	if j.cronScheduler != nil {
+		j.logger.Info("Stopping scheduled publish job scheduler...")
+		stopCtx := j.cronScheduler.Stop()
+		select {
+		case <-stopCtx.Done():
+			j.logger.Info("Scheduled publish job scheduler stopped gracefully.")
+		case <-time.After(10 * time.Second):
+			j.logger.Warn("Scheduled publish job scheduler stop timed out.")
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// File: internal/jobs/trending_listings.go
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/jobrun"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// TrendingListingsJob periodically recomputes the trending listings ranking served by GET /listings/trending.
+type TrendingListingsJob struct {
+	listingService listing.Service
+	logger         *zap.Logger
+	runs           jobrun.Service
+	cfg            *config.Config
+	cronScheduler  *cron.Cron
+}
+
+// NewTrendingListingsJob creates a new TrendingListingsJob.
+func NewTrendingListingsJob(
+	listingService listing.Service,
+	runs jobrun.Service,
+	logger *zap.Logger,
+	cfg *config.Config,
+) *TrendingListingsJob {
+	cronLogger := NewCronLogger(logger.Named("cron"))
+	scheduler := cron.New(cron.WithLogger(cronLogger), cron.WithChain(cron.SkipIfStillRunning(cronLogger)))
+
+	j := &TrendingListingsJob{
+		listingService: listingService,
+		runs:           runs,
+		logger:         logger.Named("TrendingListingsJob"),
+		cfg:            cfg,
+		cronScheduler:  scheduler,
+	}
+	runs.Register(jobrun.Job{Name: JobTrendingListings, Timeout: 5 * time.Minute, Run: j.runJob, Local: true})
+	return j
+}
+
+// SetupAndStart schedules and starts the cron job.
+func (j *TrendingListingsJob) SetupAndStart() error {
+	jobSpec := j.cfg.TrendingJobSchedule
+	if jobSpec == "" {
+		j.logger.Warn("Trending listings job schedule not defined (TRENDING_JOB_SCHEDULE). The ranking is only computed on first request.")
+		return nil
+	}
+
+	jobID, err := j.cronScheduler.AddFunc(jobSpec, func() { j.runs.RunScheduled(JobTrendingListings) })
+	if err != nil {
+		j.logger.Error("Failed to schedule trending listings job", zap.String("spec", jobSpec), zap.Error(err))
+		return err
+	}
+
+	j.logger.Info("Trending listings job scheduled", zap.String("spec", jobSpec), zap.Any("jobID", jobID))
+	j.cronScheduler.Start()
+	return nil
+}
+
+// runJob is the actual work performed by the job. It returns the number of listings ranked.
+func (j *TrendingListingsJob) runJob(ctx context.Context) (int, error) {
+	j.logger.Debug("Starting trending listings job run...")
+
+	ranked, err := j.listingService.RefreshTrendingListings(ctx)
+	if err != nil {
+		j.logger.Error("Trending listings job run failed", zap.Error(err))
+		return 0, err
+	}
+	j.logger.Debug("Trending listings job run completed", zap.Int("listings_ranked", ranked))
+	return ranked, nil
+}
+
+// Stop gracefully stops the cron scheduler.
+func (j *TrendingListingsJob) Stop() {
+	if j.cronScheduler != nil {
+		j.logger.Info("Stopping trending listings job scheduler...")
+		stopCtx := j.cronScheduler.Stop()
+		select {
+		case <-stopCtx.Done():
+			j.logger.Info("Trending listings job scheduler stopped gracefully.")
+		case <-time.After(10 * time.Second):
+			j.logger.Warn("Trending listings job scheduler stop timed out.")
+		}
+	}
+}
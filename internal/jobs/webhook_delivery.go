@@ -0,0 +1,91 @@
+// File: internal/jobs/webhook_delivery.go
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/jobrun"
+	"seattle_info_backend/internal/webhook"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// WebhookDeliveryJob periodically sends webhook deliveries that are overdue because their queue task was lost.
+type WebhookDeliveryJob struct {
+	webhookService webhook.Service
+	logger         *zap.Logger
+	runs           jobrun.Service
+	cfg            *config.Config
+	cronScheduler  *cron.Cron
+}
+
+// NewWebhookDeliveryJob creates a new WebhookDeliveryJob.
+func NewWebhookDeliveryJob(
+	webhookService webhook.Service,
+	runs jobrun.Service,
+	logger *zap.Logger,
+	cfg *config.Config,
+) *WebhookDeliveryJob {
+	cronLogger := NewCronLogger(logger.Named("cron"))
+	// Runs are frequent; skip a tick rather than send the same deliveries twice.
+	scheduler := cron.New(cron.WithLogger(cronLogger), cron.WithChain(cron.SkipIfStillRunning(cronLogger)))
+
+	j := &WebhookDeliveryJob{
+		webhookService: webhookService,
+		runs:           runs,
+		logger:         logger.Named("WebhookDeliveryJob"),
+		cfg:            cfg,
+		cronScheduler:  scheduler,
+	}
+	runs.Register(jobrun.Job{Name: JobWebhookDelivery, Timeout: 5 * time.Minute, Run: j.runJob})
+	return j
+}
+
+// SetupAndStart schedules and starts the cron job.
+func (j *WebhookDeliveryJob) SetupAndStart() error {
+	jobSpec := j.cfg.WebhookDeliveryJobSchedule
+	if jobSpec == "" {
+		j.logger.Warn("Webhook delivery job schedule not defined (WEBHOOK_DELIVERY_JOB_SCHEDULE). Job will not run.")
+		return nil
+	}
+
+	jobID, err := j.cronScheduler.AddFunc(jobSpec, func() { j.runs.RunScheduled(JobWebhookDelivery) })
+	if err != nil {
+		j.logger.Error("Failed to schedule webhook delivery job", zap.String("spec", jobSpec), zap.Error(err))
+		return err
+	}
+
+	j.logger.Info("Webhook delivery job scheduled", zap.String("spec", jobSpec), zap.Any("jobID", jobID))
+	j.cronScheduler.Start()
+	return nil
+}
+
+// runJob is the actual work performed by the job. It returns the number of deliveries that succeeded.
+func (j *WebhookDeliveryJob) runJob(ctx context.Context) (int, error) {
+	j.logger.Debug("Starting webhook delivery job run...")
+
+	sent, err := j.webhookService.ProcessDueDeliveries(ctx)
+	if err != nil {
+		j.logger.Error("Webhook delivery job run failed", zap.Error(err))
+		return 0, err
+	}
+	j.logger.Debug("Webhook delivery job run completed", zap.Int("deliveries_succeeded", sent))
+	return sent, nil
+}
+
+// Stop gracefully stops the cron scheduler.
+func (j *WebhookDeliveryJob) Stop() {
+	if j.cronScheduler != nil {
+		j.logger.Info("Stopping webhook delivery job scheduler...")
+		stopCtx := j.cronScheduler.Stop()
+		select {
+		case <-stopCtx.Done():
+			j.logger.Info("Webhook delivery job scheduler stopped gracefully.")
+		case <-time.After(10 * time.Second):
+			j.logger.Warn("Webhook delivery job scheduler stop timed out.")
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// File: internal/listing/analytics.go
+package listing
+
+import (
+	"context"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// analyticsDays is how many days GET /listings/:id/analytics covers, ending with the last rolled-up day.
+const analyticsDays = 30
+
+// DailyImpressions counts how often a listing appeared in search results on one UTC day.
+type DailyImpressions struct {
+	ListingID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Day         time.Time `gorm:"type:date;primaryKey"`
+	Impressions int       `gorm:"not null;default:0"`
+}
+
+func (DailyImpressions) TableName() string {
+	return "listing_daily_impressions"
+}
+
+// DailyStats is one day of a listing's owner analytics, rolled up nightly by RollupListingStats.
+type DailyStats struct {
+	ListingID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Day               time.Time `gorm:"type:date;primaryKey"`
+	Views             int       `gorm:"not null;default:0"`
+	ContactReveals    int       `gorm:"not null;default:0"`
+	SearchImpressions int       `gorm:"not null;default:0"`
+	CheckIns          int       `gorm:"not null;default:0"`
+}
+
+func (DailyStats) TableName() string {
+	return "listing_stats_daily"
+}
+
+// AnalyticsCounts are the counters reported for a day or a whole period.
+type AnalyticsCounts struct {
+	Views             int `json:"views"`
+	ContactReveals    int `json:"contact_reveals"`
+	SearchImpressions int `json:"search_impressions"`
+	CheckIns          int `json:"check_ins"`
+}
+
+// AnalyticsDay is one day of ListingAnalyticsResponse.
+type AnalyticsDay struct {
+	Date string `json:"date"` // YYYY-MM-DD, UTC
+	AnalyticsCounts
+}
+
+// ListingAnalyticsResponse is the response of GET /listings/:id/analytics.
+type ListingAnalyticsResponse struct {
+	ListingID uuid.UUID       `json:"listing_id"`
+	From      string          `json:"from"`
+	To        string          `json:"to"`
+	Totals    AnalyticsCounts `json:"totals"`
+	Days      []AnalyticsDay  `json:"days"` // Every day from From to To, oldest first; days without activity are zero
+}
+
+// RecordSearchImpressions counts one search impression for each listing in a page of search results.
+// The viewer's own listings are skipped. Failures are logged and never reach the searcher.
+func (s *ServiceImplementation) RecordSearchImpressions(ctx context.Context, listings []Listing, viewerID *uuid.UUID) {
+	ids := make([]uuid.UUID, 0, len(listings))
+	for _, l := range listings {
+		if viewerID != nil && *viewerID == l.UserID {
+			continue
+		}
+		ids = append(ids, l.ID)
+	}
+	if len(ids) == 0 {
+		return
+	}
+	if err := s.repo.IncrementDailyImpressions(ctx, ids, time.Now().UTC()); err != nil {
+		s.logger.Warn("Failed to record search impressions", zap.Int("listings", len(ids)), zap.Error(err))
+	}
+}
+
+// RollupListingStats writes the owner analytics of one UTC day into listing_stats_daily. Running it again for the
+// same day replaces that day's rows, so a missed night can be caught up. It returns the number of listings rolled up.
+func (s *ServiceImplementation) RollupListingStats(ctx context.Context, day time.Time) (int, error) {
+	rows, err := s.repo.RollupDailyStats(ctx, day.UTC().Truncate(24*time.Hour))
+	if err != nil {
+		s.logger.Error("Failed to roll up listing stats", zap.Time("day", day), zap.Error(err))
+		return 0, common.ErrInternalServer.WithDetails("Could not roll up listing stats.")
+	}
+	return int(rows), nil
+}
+
+// GetListingAnalytics returns the daily analytics of the last 30 rolled-up days for the listing's owner.
+// Today is not included until the nightly rollup has run.
+func (s *ServiceImplementation) GetListingAnalytics(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*ListingAnalyticsResponse, error) {
+	listing, err := s.repo.FindByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if listing.UserID != userID {
+		return nil, common.ErrForbidden.WithDetails("You do not have permission to view this listing's analytics.")
+	}
+
+	to := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+	from := to.AddDate(0, 0, -(analyticsDays - 1))
+	stats, err := s.repo.FindDailyStats(ctx, id, from, to)
+	if err != nil {
+		s.logger.Error("Failed to load listing stats", zap.String("listingID", id.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve listing analytics.")
+	}
+	return buildListingAnalytics(id, from, to, stats), nil
+}
+
+// buildListingAnalytics lays stats out as one entry per day from from to to, filling days without a row with zeros.
+func buildListingAnalytics(id uuid.UUID, from, to time.Time, stats []DailyStats) *ListingAnalyticsResponse {
+	byDay := make(map[string]DailyStats, len(stats))
+	for _, st := range stats {
+		byDay[st.Day.UTC().Format("2006-01-02")] = st
+	}
+
+	resp := &ListingAnalyticsResponse{ListingID: id, From: from.Format("2006-01-02"), To: to.Format("2006-01-02")}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		st := byDay[date]
+		counts := AnalyticsCounts{Views: st.Views, ContactReveals: st.ContactReveals, SearchImpressions: st.SearchImpressions, CheckIns: st.CheckIns}
+		resp.Days = append(resp.Days, AnalyticsDay{Date: date, AnalyticsCounts: counts})
+		resp.Totals.Views += counts.Views
+		resp.Totals.ContactReveals += counts.ContactReveals
+		resp.Totals.SearchImpressions += counts.SearchImpressions
+		resp.Totals.CheckIns += counts.CheckIns
+	}
+	return resp
+}
@@ -0,0 +1,28 @@
+package listing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildListingAnalyticsFillsMissingDays(t *testing.T) {
+	id := uuid.New()
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+
+	resp := buildListingAnalytics(id, from, to, []DailyStats{
+		{ListingID: id, Day: from, Views: 4, SearchImpressions: 20},
+		{ListingID: id, Day: to, Views: 1, ContactReveals: 2},
+	})
+
+	assert.Equal(t, "2024-06-01", resp.From)
+	assert.Equal(t, "2024-06-03", resp.To)
+	require.Len(t, resp.Days, 3)
+	assert.Equal(t, "2024-06-02", resp.Days[1].Date)
+	assert.Equal(t, AnalyticsCounts{}, resp.Days[1].AnalyticsCounts)
+	assert.Equal(t, AnalyticsCounts{Views: 5, ContactReveals: 2, SearchImpressions: 20}, resp.Totals)
+}
@@ -0,0 +1,36 @@
+// File: internal/listing/approval_backlog.go
+package listing
+
+import (
+	"context"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"go.uber.org/zap"
+)
+
+// ApprovalBacklog describes the listings waiting in pending_approval for an admin decision.
+type ApprovalBacklog struct {
+	Pending            int64      // Listings in pending_approval
+	OldestPendingSince *time.Time // When the longest-waiting of them entered pending_approval; nil when none wait
+}
+
+// OldestAge returns how long the longest-waiting listing has been pending at now, or zero when none are.
+func (b *ApprovalBacklog) OldestAge(now time.Time) time.Duration {
+	if b.OldestPendingSince == nil || now.Before(*b.OldestPendingSince) {
+		return 0
+	}
+	return now.Sub(*b.OldestPendingSince)
+}
+
+// GetApprovalBacklog returns the size and age of the approval queue. Listings enter pending_approval on submission
+// by a first-time poster or when moderation or spam scoring holds them; a database trigger records when.
+func (s *ServiceImplementation) GetApprovalBacklog(ctx context.Context) (*ApprovalBacklog, error) {
+	backlog, err := s.repo.ApprovalBacklog(ctx)
+	if err != nil {
+		s.logger.Error("Failed to read approval backlog", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not read the approval backlog.")
+	}
+	return backlog, nil
+}
@@ -0,0 +1,113 @@
+// File: internal/listing/babysitting.go
+package listing
+
+import (
+	"strings"
+
+	"seattle_info_backend/internal/common"
+
+	"gorm.io/gorm"
+)
+
+// applyProfileTo validates the provider profile of the request and sets the fields of d that it contains; fields
+// it leaves out keep their value. Availability without a timezone is in defaultZone.
+func (r *CreateListingBabysittingDetailsRequest) applyProfileTo(d *ListingDetailsBabysitting, defaultZone string) error {
+	if r.Availability != nil {
+		if err := r.Availability.normalizeAs("Availability", defaultZone); err != nil {
+			return err
+		}
+		d.Availability = r.Availability
+	}
+	if r.Certifications != nil {
+		certifications, seen := make([]string, 0, len(r.Certifications)), make(map[string]bool, len(r.Certifications))
+		for _, c := range r.Certifications {
+			c = strings.TrimSpace(c)
+			if c == "" {
+				return common.ErrBadRequest.WithDetails("certifications must not be blank.")
+			}
+			if !seen[strings.ToLower(c)] {
+				seen[strings.ToLower(c)] = true
+				certifications = append(certifications, c)
+			}
+		}
+		d.Certifications = certifications
+	}
+	if r.HourlyRate != nil {
+		d.HourlyRate = r.HourlyRate
+	}
+	if r.YearsExperience != nil {
+		d.YearsExperience = r.YearsExperience
+	}
+	return nil
+}
+
+// babysittingRequest turns babysitting details back into the request that produces them, for listing documents.
+func (d *ListingDetailsBabysitting) babysittingRequest() *CreateListingBabysittingDetailsRequest {
+	return &CreateListingBabysittingDetailsRequest{
+		LanguagesSpoken: d.LanguagesSpoken,
+		Availability:    d.Availability,
+		HourlyRate:      d.HourlyRate,
+		YearsExperience: d.YearsExperience,
+		Certifications:  d.Certifications,
+	}
+}
+
+// validateAvailabilityWindow checks the available_day, available_start and available_end filters of query.
+func validateAvailabilityWindow(query ListingSearchQuery) error {
+	if query.AvailableDay == "" {
+		if query.AvailableStart != "" || query.AvailableEnd != "" {
+			return common.ErrBadRequest.WithDetails("available_start and available_end require available_day.")
+		}
+		return nil
+	}
+	if !validWeekdayName(query.AvailableDay) {
+		return common.ErrBadRequest.WithDetails("available_day must be a day of the week, e.g. saturday.")
+	}
+	if (query.AvailableStart != "" && !validClock(query.AvailableStart, false)) || (query.AvailableEnd != "" && !validClock(query.AvailableEnd, true)) {
+		return common.ErrBadRequest.WithDetails("available_start and available_end must be HH:MM times, e.g. 18:00.")
+	}
+	if query.AvailableStart != "" && query.AvailableEnd != "" && query.AvailableEnd <= query.AvailableStart {
+		return common.ErrBadRequest.WithDetails("available_end must be after available_start.")
+	}
+	return nil
+}
+
+// validWeekdayName reports whether name is a day of the week, in any case.
+func validWeekdayName(name string) bool {
+	for _, day := range weekdays {
+		if strings.EqualFold(name, day.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyBabysittingFilters restricts dbQuery to babysitting listings matching the availability window and
+// max_hourly_rate filters. The window must fall within one period of the provider's availability that day; a
+// window given by only its start or end must include that time. Times are "HH:MM" strings, so they compare in order.
+func applyBabysittingFilters(dbQuery *gorm.DB, queryParams ListingSearchQuery) *gorm.DB {
+	if queryParams.AvailableDay != "" {
+		start, end := queryParams.AvailableStart, queryParams.AvailableEnd
+		conditions := []string{"b.listing_id = listings.id"}
+		if start != "" {
+			conditions = append(conditions, "period ->> 'open' <= @start")
+			if end == "" {
+				conditions = append(conditions, "@start < period ->> 'close'")
+			}
+		}
+		if end != "" {
+			conditions = append(conditions, "@end <= period ->> 'close'")
+			if start == "" {
+				conditions = append(conditions, "period ->> 'open' < @end")
+			}
+		}
+		dbQuery = dbQuery.Where("EXISTS (SELECT 1 FROM listing_details_babysitting b, "+
+			"jsonb_array_elements(b.availability -> @day) AS period WHERE "+strings.Join(conditions, " AND ")+")",
+			map[string]interface{}{"day": strings.ToLower(queryParams.AvailableDay), "start": start, "end": end})
+	}
+	if queryParams.MaxHourlyRate != nil {
+		dbQuery = dbQuery.Where("EXISTS (SELECT 1 FROM listing_details_babysitting b WHERE b.listing_id = listings.id AND b.hourly_rate <= ?)",
+			*queryParams.MaxHourlyRate)
+	}
+	return dbQuery
+}
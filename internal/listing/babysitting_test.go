@@ -0,0 +1,52 @@
+package listing
+
+import (
+	"testing"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyProfileTo(t *testing.T) {
+	rate, years := 22.5, 4
+	details := &ListingDetailsBabysitting{}
+	req := &CreateListingBabysittingDetailsRequest{
+		Availability:    &BusinessHours{Saturday: []OpeningPeriod{{Open: "18:00", Close: "23:00"}, {Open: "09:00", Close: "12:00"}}},
+		HourlyRate:      &rate,
+		YearsExperience: &years,
+		Certifications:  []string{" CPR ", "First Aid", "cpr"},
+	}
+	require.NoError(t, req.applyProfileTo(details, "America/Los_Angeles"))
+	assert.Equal(t, "America/Los_Angeles", details.Availability.Timezone)
+	assert.Equal(t, "09:00", details.Availability.Saturday[0].Open, "periods are sorted")
+	assert.Equal(t, []string{"CPR", "First Aid"}, []string(details.Certifications))
+	assert.Equal(t, 22.5, *details.HourlyRate)
+
+	require.NoError(t, (&CreateListingBabysittingDetailsRequest{}).applyProfileTo(details, "America/Los_Angeles"))
+	assert.Equal(t, 4, *details.YearsExperience, "fields left out keep their value")
+
+	overlapping := &CreateListingBabysittingDetailsRequest{Availability: &BusinessHours{Monday: []OpeningPeriod{{Open: "09:00", Close: "12:00"}, {Open: "11:00", Close: "13:00"}}}}
+	err := overlapping.applyProfileTo(details, "America/Los_Angeles")
+	var apiErr *common.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "Availability on Monday overlap.", apiErr.Details)
+
+	assert.Error(t, (&CreateListingBabysittingDetailsRequest{Certifications: []string{" "}}).applyProfileTo(details, "America/Los_Angeles"))
+}
+
+func TestValidateAvailabilityWindow(t *testing.T) {
+	assert.NoError(t, validateAvailabilityWindow(ListingSearchQuery{}))
+	assert.NoError(t, validateAvailabilityWindow(ListingSearchQuery{AvailableDay: "Saturday"}))
+	assert.NoError(t, validateAvailabilityWindow(ListingSearchQuery{AvailableDay: "friday", AvailableStart: "18:00", AvailableEnd: "24:00"}))
+
+	for name, q := range map[string]ListingSearchQuery{
+		"times without a day": {AvailableStart: "18:00"},
+		"unknown day":         {AvailableDay: "someday"},
+		"bad time":            {AvailableDay: "friday", AvailableStart: "6pm"},
+		"end before start":    {AvailableDay: "friday", AvailableStart: "18:00", AvailableEnd: "17:00"},
+	} {
+		assert.ErrorIs(t, validateAvailabilityWindow(q), common.ErrBadRequest, name)
+	}
+}
@@ -0,0 +1,318 @@
+// File: internal/listing/backgroundcheck.go
+package listing
+
+import (
+	"context"
+	"fmt"
+	"mime/multipart"
+	"path/filepath"
+	"time"
+
+	"seattle_info_backend/internal/audit"
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/filestorage"
+	"seattle_info_backend/internal/notification"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// backgroundCheckDocumentsDir is the sub-directory of filestorage.PrivateDir background-check documents are saved in.
+	backgroundCheckDocumentsDir = "background-checks"
+	// maxBackgroundCheckNoteLength caps the provider's note on a background check, in characters.
+	maxBackgroundCheckNoteLength = 1000
+)
+
+// verifiedFirstOrder sorts babysitting listings with a verified background check ahead of the others.
+const verifiedFirstOrder = "EXISTS (SELECT 1 FROM listing_details_babysitting b WHERE b.listing_id = listings.id AND b.verified_badge) DESC"
+
+// BackgroundCheckStatus is where a background check is in review.
+type BackgroundCheckStatus string
+
+const (
+	BackgroundCheckPending  BackgroundCheckStatus = "pending"  // Waiting for an admin
+	BackgroundCheckVerified BackgroundCheckStatus = "verified" // The listing shows the verified badge
+	BackgroundCheckRejected BackgroundCheckStatus = "rejected" // The document did not pass; the provider may send another
+	BackgroundCheckRevoked  BackgroundCheckStatus = "revoked"  // Verified once, withdrawn later by an admin
+)
+
+// Decisions on a background check.
+const (
+	BackgroundCheckVerify = "verify" // Pending checks only
+	BackgroundCheckReject = "reject" // Pending checks only
+	BackgroundCheckRevoke = "revoke" // Verified checks only
+)
+
+// BackgroundCheck is a document a babysitting provider sent to prove a background check, and the admin's
+// decision on it. A listing has at most one pending check.
+type BackgroundCheck struct {
+	common.BaseModel
+	ListingID    uuid.UUID             `gorm:"type:uuid;not null"`
+	UserID       uuid.UUID             `gorm:"type:uuid;not null"`
+	DocumentPath string                `gorm:"type:text;not null"` // Below filestorage.PrivateDir; never served publicly
+	DocumentName string                `gorm:"type:varchar(255);not null"`
+	Note         *string               `gorm:"type:text"` // From the provider
+	Status       BackgroundCheckStatus `gorm:"type:varchar(20);not null;default:'pending'"`
+	ReviewedBy   *uuid.UUID            `gorm:"type:uuid"`
+	ReviewedAt   *time.Time
+	ReviewNote   *string `gorm:"type:text"` // Sent to the provider
+}
+
+// TableName specifies the table name for GORM.
+func (BackgroundCheck) TableName() string {
+	return "babysitting_background_checks"
+}
+
+// ReviewBackgroundCheckRequest is the payload of POST /admin/background-checks/:id/review.
+type ReviewBackgroundCheckRequest struct {
+	Decision string  `json:"decision" binding:"required,oneof=verify reject revoke"`
+	Note     *string `json:"note,omitempty" binding:"omitempty,max=2000"` // Sent to the provider
+}
+
+// BackgroundCheckListQuery filters GET /admin/background-checks.
+type BackgroundCheckListQuery struct {
+	Status BackgroundCheckStatus `form:"status" binding:"omitempty,oneof=pending verified rejected revoked"` // Defaults to pending
+}
+
+// BackgroundCheckResponse is the API representation of a background check. The document itself is only
+// available to admins, from GET /admin/background-checks/:id/document.
+type BackgroundCheckResponse struct {
+	ID           uuid.UUID             `json:"id"`
+	ListingID    uuid.UUID             `json:"listing_id"`
+	DocumentName string                `json:"document_name"`
+	Note         *string               `json:"note,omitempty"`
+	Status       BackgroundCheckStatus `json:"status"`
+	ReviewedAt   *time.Time            `json:"reviewed_at,omitempty"`
+	ReviewNote   *string               `json:"review_note,omitempty"`
+	CreatedAt    time.Time             `json:"created_at"`
+	Listing      *ListingResponse      `json:"listing,omitempty"` // Admin list only
+}
+
+// ToBackgroundCheckResponse converts a BackgroundCheck model to a BackgroundCheckResponse DTO.
+func ToBackgroundCheckResponse(c *BackgroundCheck) BackgroundCheckResponse {
+	return BackgroundCheckResponse{
+		ID:           c.ID,
+		ListingID:    c.ListingID,
+		DocumentName: c.DocumentName,
+		Note:         c.Note,
+		Status:       c.Status,
+		ReviewedAt:   c.ReviewedAt,
+		ReviewNote:   c.ReviewNote,
+		CreatedAt:    c.CreatedAt,
+	}
+}
+
+// SubmitBackgroundCheck stores the owner's background-check document for their babysitting listing and queues it
+// for an admin. A listing that is verified or already waiting for review cannot submit another.
+func (s *ServiceImplementation) SubmitBackgroundCheck(ctx context.Context, listingID uuid.UUID, userID uuid.UUID, document *multipart.FileHeader, note *string) (*BackgroundCheck, error) {
+	l, err := s.repo.FindByID(ctx, listingID, true)
+	if err != nil {
+		return nil, err
+	}
+	if l.UserID != userID {
+		return nil, common.ErrForbidden.WithDetails("You do not have permission to request verification for this listing.")
+	}
+	if l.BabysittingDetails == nil {
+		return nil, common.ErrBadRequest.WithDetails("Only babysitting listings can be verified.")
+	}
+	latest, err := s.repo.FindLatestBackgroundCheck(ctx, listingID)
+	if err != nil {
+		s.logger.Error("Failed to find background check", zap.String("listingID", listingID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not submit the background check.")
+	}
+	if latest != nil {
+		switch latest.Status {
+		case BackgroundCheckPending:
+			return nil, common.ErrConflict.WithDetails("A background check for this listing is already waiting for review.")
+		case BackgroundCheckVerified:
+			return nil, common.ErrConflict.WithDetails("This listing is already verified.")
+		}
+	}
+
+	relativePath, err := s.fileStorageService.SavePrivateFile(document, backgroundCheckDocumentsDir)
+	if err != nil {
+		s.logger.Warn("Failed to save background-check document", zap.Error(err), zap.String("filename", document.Filename))
+		return nil, common.ErrBadRequest.WithDetails(fmt.Sprintf("Failed to save document %s: %s", document.Filename, err.Error()))
+	}
+	check := &BackgroundCheck{
+		ListingID:    listingID,
+		UserID:       userID,
+		DocumentPath: relativePath,
+		DocumentName: filepath.Base(document.Filename),
+		Note:         trimmedOrNil(note),
+		Status:       BackgroundCheckPending,
+	}
+	if err := s.repo.CreateBackgroundCheck(ctx, check); err != nil {
+		if delErr := s.fileStorageService.DeleteFile(relativePath); delErr != nil {
+			s.logger.Error("Failed to delete background-check document", zap.String("path", relativePath), zap.Error(delErr))
+		}
+		if _, ok := common.IsAPIError(err); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to store background check", zap.String("listingID", listingID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not submit the background check.")
+	}
+
+	s.logger.Info("Background check submitted", zap.String("listingID", listingID.String()), zap.String("checkID", check.ID.String()))
+	return check, nil
+}
+
+// GetBackgroundCheck returns the latest background check of the owner's listing.
+func (s *ServiceImplementation) GetBackgroundCheck(ctx context.Context, listingID uuid.UUID, userID uuid.UUID) (*BackgroundCheck, error) {
+	l, err := s.repo.FindByID(ctx, listingID, false)
+	if err != nil {
+		return nil, err
+	}
+	if l.UserID != userID {
+		return nil, common.ErrForbidden.WithDetails("You do not have permission to view this listing's verification.")
+	}
+	check, err := s.repo.FindLatestBackgroundCheck(ctx, listingID)
+	if err != nil {
+		s.logger.Error("Failed to find background check", zap.String("listingID", listingID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve the background check.")
+	}
+	if check == nil {
+		return nil, common.ErrNotFound.WithDetails("No background check has been submitted for this listing.")
+	}
+	return check, nil
+}
+
+// ListBackgroundChecks returns a page of background checks in the given status, pending by default, oldest
+// first, each with its listing.
+func (s *ServiceImplementation) ListBackgroundChecks(ctx context.Context, query BackgroundCheckListQuery, page, pageSize int) ([]BackgroundCheckResponse, *common.Pagination, error) {
+	status := query.Status
+	if status == "" {
+		status = BackgroundCheckPending
+	}
+	checks, pagination, err := s.repo.FindBackgroundChecksByStatus(ctx, status, page, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to list background checks", zap.String("status", string(status)), zap.Error(err))
+		return nil, nil, common.ErrInternalServer.WithDetails("Could not retrieve background checks.")
+	}
+
+	ids := make([]uuid.UUID, 0, len(checks))
+	for _, c := range checks {
+		ids = append(ids, c.ListingID)
+	}
+	listings, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("Failed to load listings of background checks", zap.Error(err))
+		return nil, nil, common.ErrInternalServer.WithDetails("Could not retrieve background checks.")
+	}
+	byID := make(map[uuid.UUID]*Listing, len(listings))
+	for i := range listings {
+		byID[listings[i].ID] = &listings[i]
+	}
+
+	responses := make([]BackgroundCheckResponse, 0, len(checks))
+	for i := range checks {
+		response := ToBackgroundCheckResponse(&checks[i])
+		if l, ok := byID[checks[i].ListingID]; ok {
+			listingResponse := ToListingResponse(l, s.imageURLs)
+			response.Listing = &listingResponse
+		}
+		responses = append(responses, response)
+	}
+	return responses, pagination, nil
+}
+
+// OpenBackgroundCheckDocument opens the document of a background check for an admin. The caller must Close it.
+func (s *ServiceImplementation) OpenBackgroundCheckDocument(ctx context.Context, checkID uuid.UUID) (*BackgroundCheck, *filestorage.StoredFile, error) {
+	check, err := s.repo.FindBackgroundCheckByID(ctx, checkID)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := s.fileStorageService.OpenPrivate(check.DocumentPath)
+	if err != nil {
+		s.logger.Error("Failed to open background-check document", zap.String("checkID", checkID.String()), zap.Error(err))
+		return nil, nil, common.ErrNotFound.WithDetails("The document of this background check is missing.")
+	}
+	return check, file, nil
+}
+
+// ReviewBackgroundCheck records an admin's decision on a background check: verify shows the verified badge on the
+// listing, reject lets the provider send another document, and revoke withdraws an earlier verification. The
+// owner is notified of the decision.
+func (s *ServiceImplementation) ReviewBackgroundCheck(ctx context.Context, checkID uuid.UUID, adminID uuid.UUID, req ReviewBackgroundCheckRequest) (*BackgroundCheck, error) {
+	check, err := s.repo.FindBackgroundCheckByID(ctx, checkID)
+	if err != nil {
+		return nil, err
+	}
+	from := check.Status
+	var badge *bool
+	switch req.Decision {
+	case BackgroundCheckVerify, BackgroundCheckReject:
+		if from != BackgroundCheckPending {
+			return nil, common.ErrConflict.WithDetails(fmt.Sprintf("Only pending background checks can be verified or rejected; this one is %s.", from))
+		}
+		check.Status = BackgroundCheckRejected
+		if req.Decision == BackgroundCheckVerify {
+			verified := true
+			check.Status, badge = BackgroundCheckVerified, &verified
+		}
+	default:
+		if from != BackgroundCheckVerified {
+			return nil, common.ErrConflict.WithDetails(fmt.Sprintf("Only verified background checks can be revoked; this one is %s.", from))
+		}
+		verified := false
+		check.Status, badge = BackgroundCheckRevoked, &verified
+	}
+	l, err := s.repo.FindByID(ctx, check.ListingID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	check.ReviewedBy = &adminID
+	check.ReviewedAt = &now
+	check.ReviewNote = trimmedOrNil(req.Note)
+	changes := map[string]audit.FieldChange{"background_check_status": {From: from, To: check.Status}}
+	if badge != nil {
+		changes["verified_badge"] = audit.FieldChange{From: !*badge, To: *badge}
+	}
+	entry, err := audit.NewEntry(audit.Event{
+		ActorID:    &adminID,
+		Action:     audit.ActionListingBackgroundCheck,
+		EntityType: audit.EntityListing,
+		EntityID:   l.ID.String(),
+		Changes:    changes,
+		Note:       check.ReviewNote,
+	})
+	if err != nil {
+		s.logger.Error("Failed to encode background check audit entry", zap.String("checkID", checkID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not review the background check.")
+	}
+	if err := s.repo.UpdateBackgroundCheck(ctx, check, from, badge, entry); err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to review background check", zap.String("checkID", checkID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not review the background check.")
+	}
+
+	s.notifyOwner(ctx, l, notification.BackgroundCheckReviewed, backgroundCheckMessage(l, check))
+	s.logger.Info("Background check reviewed",
+		zap.String("checkID", checkID.String()),
+		zap.String("listingID", l.ID.String()),
+		zap.String("adminID", adminID.String()),
+		zap.String("decision", req.Decision))
+	return check, nil
+}
+
+// backgroundCheckMessage tells the owner the decision on their background check.
+func backgroundCheckMessage(l *Listing, c *BackgroundCheck) string {
+	var message string
+	switch c.Status {
+	case BackgroundCheckVerified:
+		message = fmt.Sprintf("Your background check was verified. Your listing '%s' now shows the verified badge.", l.Title)
+	case BackgroundCheckRevoked:
+		message = fmt.Sprintf("The verified badge of your listing '%s' has been removed.", l.Title)
+	default:
+		message = fmt.Sprintf("Your background check for '%s' could not be verified. You can send another document.", l.Title)
+	}
+	if c.ReviewNote != nil {
+		message += " Note from our team: " + *c.ReviewNote
+	}
+	return message
+}
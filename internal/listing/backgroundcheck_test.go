@@ -0,0 +1,128 @@
+package listing
+
+import (
+	"context"
+	"testing"
+
+	"seattle_info_backend/internal/audit"
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/filestorage"
+	"seattle_info_backend/internal/notification"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// backgroundCheckRepository keeps one babysitting listing and its background checks in memory.
+type backgroundCheckRepository struct {
+	Repository
+	listing Listing
+	checks  []*BackgroundCheck
+	entries []*audit.Entry
+}
+
+func (r *backgroundCheckRepository) FindByID(_ context.Context, id uuid.UUID, _ bool) (*Listing, error) {
+	if id != r.listing.ID {
+		return nil, common.ErrNotFound.WithDetails("Listing not found.")
+	}
+	l := r.listing
+	return &l, nil
+}
+
+func (r *backgroundCheckRepository) CreateBackgroundCheck(_ context.Context, check *BackgroundCheck) error {
+	check.ID = uuid.New()
+	r.checks = append(r.checks, check)
+	return nil
+}
+
+func (r *backgroundCheckRepository) UpdateBackgroundCheck(_ context.Context, check *BackgroundCheck, _ BackgroundCheckStatus, badge *bool, entry *audit.Entry) error {
+	if badge != nil {
+		r.listing.BabysittingDetails.VerifiedBadge = *badge
+		r.listing.BabysittingDetails.VerifiedAt = nil
+		if *badge {
+			r.listing.BabysittingDetails.VerifiedAt = check.ReviewedAt
+		}
+	}
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *backgroundCheckRepository) FindLatestBackgroundCheck(_ context.Context, _ uuid.UUID) (*BackgroundCheck, error) {
+	if len(r.checks) == 0 {
+		return nil, nil
+	}
+	return r.checks[len(r.checks)-1], nil
+}
+
+func (r *backgroundCheckRepository) FindBackgroundCheckByID(_ context.Context, id uuid.UUID) (*BackgroundCheck, error) {
+	for _, c := range r.checks {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, common.ErrNotFound.WithDetails("Background check not found.")
+}
+
+func TestBackgroundCheckFlow(t *testing.T) {
+	owner, admin := uuid.New(), uuid.New()
+	repo := &backgroundCheckRepository{listing: Listing{UserID: owner, Title: "Evening sitter", BabysittingDetails: &ListingDetailsBabysitting{}}}
+	repo.listing.ID = uuid.New()
+	storage, err := filestorage.NewFileStorageService(t.TempDir(), zap.NewNop())
+	require.NoError(t, err)
+	notifications := &sentNotifications{}
+	svc := &ServiceImplementation{repo: repo, fileStorageService: storage, notificationService: notifications, cfg: &config.Config{}, logger: zap.NewNop()}
+	ctx := context.Background()
+
+	_, err = svc.SubmitBackgroundCheck(ctx, repo.listing.ID, uuid.New(), uploadedFiles(t, "check.jpg")[0], nil)
+	assert.ErrorIs(t, err, common.ErrForbidden, "only the owner can submit")
+
+	check, err := svc.SubmitBackgroundCheck(ctx, repo.listing.ID, owner, uploadedFiles(t, "check.jpg")[0], nil)
+	require.NoError(t, err)
+	assert.Equal(t, BackgroundCheckPending, check.Status)
+	assert.Equal(t, "check.jpg", check.DocumentName)
+	_, err = storage.Open(check.DocumentPath)
+	assert.ErrorIs(t, err, filestorage.ErrFileNotFound, "documents are never served publicly")
+
+	_, err = svc.SubmitBackgroundCheck(ctx, repo.listing.ID, owner, uploadedFiles(t, "again.jpg")[0], nil)
+	assert.ErrorIs(t, err, common.ErrConflict, "one check waits for review at a time")
+
+	_, document, err := svc.OpenBackgroundCheckDocument(ctx, check.ID)
+	require.NoError(t, err)
+	document.Close()
+
+	_, err = svc.ReviewBackgroundCheck(ctx, check.ID, admin, ReviewBackgroundCheckRequest{Decision: BackgroundCheckRevoke})
+	assert.ErrorIs(t, err, common.ErrConflict, "pending checks cannot be revoked")
+
+	verified, err := svc.ReviewBackgroundCheck(ctx, check.ID, admin, ReviewBackgroundCheckRequest{Decision: BackgroundCheckVerify})
+	require.NoError(t, err)
+	assert.Equal(t, BackgroundCheckVerified, verified.Status)
+	assert.True(t, repo.listing.BabysittingDetails.VerifiedBadge)
+	assert.NotNil(t, repo.listing.BabysittingDetails.VerifiedAt)
+	assert.Equal(t, notification.BackgroundCheckReviewed, notifications.types[0])
+	assert.Contains(t, notifications.messages[0], "verified badge")
+
+	_, err = svc.SubmitBackgroundCheck(ctx, repo.listing.ID, owner, uploadedFiles(t, "again.jpg")[0], nil)
+	assert.ErrorIs(t, err, common.ErrConflict, "verified listings need no new check")
+
+	_, err = svc.ReviewBackgroundCheck(ctx, check.ID, admin, ReviewBackgroundCheckRequest{Decision: BackgroundCheckRevoke})
+	require.NoError(t, err)
+	assert.False(t, repo.listing.BabysittingDetails.VerifiedBadge)
+	assert.Nil(t, repo.listing.BabysittingDetails.VerifiedAt)
+	assert.Len(t, repo.entries, 2)
+
+	_, err = svc.SubmitBackgroundCheck(ctx, repo.listing.ID, owner, uploadedFiles(t, "new.jpg")[0], nil)
+	assert.NoError(t, err, "a revoked badge can be earned again")
+}
+
+func TestSubmitBackgroundCheckRequiresBabysittingListing(t *testing.T) {
+	owner := uuid.New()
+	repo := &backgroundCheckRepository{listing: Listing{UserID: owner}}
+	repo.listing.ID = uuid.New()
+	svc := &ServiceImplementation{repo: repo, logger: zap.NewNop()}
+
+	_, err := svc.SubmitBackgroundCheck(context.Background(), repo.listing.ID, owner, uploadedFiles(t, "check.jpg")[0], nil)
+	assert.ErrorIs(t, err, common.ErrBadRequest)
+}
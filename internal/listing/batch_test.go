@@ -0,0 +1,45 @@
+package listing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseListingIDs(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+
+	ids, err := parseListingIDs(a.String() + ", " + b.String() + ",")
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{a, b}, ids)
+
+	_, err = parseListingIDs(a.String() + ",not-a-uuid")
+	assert.Error(t, err)
+
+	_, err = parseListingIDs(" , ")
+	assert.Error(t, err, "an empty list is rejected")
+
+	tooMany := make([]string, MaxBatchListingIDs+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.NewString()
+	}
+	_, err = parseListingIDs(strings.Join(tooMany, ","))
+	assert.Error(t, err)
+}
+
+func TestIsVisibleTo(t *testing.T) {
+	owner, other := uuid.New(), uuid.New()
+
+	active := &Listing{UserID: owner, Status: StatusActive}
+	assert.True(t, isVisibleTo(active, nil))
+
+	for _, status := range []ListingStatus{StatusDraft, StatusPendingApproval, StatusScheduled, StatusExpired, StatusRejected, StatusAdminRemoved} {
+		l := &Listing{UserID: owner, Status: status}
+		assert.False(t, isVisibleTo(l, nil), status)
+		assert.False(t, isVisibleTo(l, &other), status)
+		assert.True(t, isVisibleTo(l, &owner), status)
+	}
+}
@@ -0,0 +1,184 @@
+// File: internal/listing/bulk.go
+package listing
+
+import (
+	"context"
+	"fmt"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MaxBulkUpdateListings caps how many listings one bulk update may change.
+const MaxBulkUpdateListings = 200
+
+// Outcomes of a bulk update for one listing.
+const (
+	BulkUpdateUpdated   = "updated"   // At least one masked field changed
+	BulkUpdateUnchanged = "unchanged" // The listing already had the new values
+	BulkUpdateSkipped   = "skipped"   // Not one of the caller's active listings, or moved outside the service area
+)
+
+// BulkUpdateListingsRequest sets the fields named in FieldMask to Values on the caller's active listings,
+// or on the ones in ListingIDs when given.
+type BulkUpdateListingsRequest struct {
+	FieldMask  []string          `json:"field_mask" binding:"required,min=1,dive,oneof=contact_name contact_email contact_phone address_line1 address_line2 city state zip_code latitude longitude"`
+	Values     BulkListingValues `json:"values"`
+	ListingIDs []uuid.UUID       `json:"listing_ids,omitempty" binding:"omitempty,max=200"`
+}
+
+// BulkListingValues holds the new values of the masked fields. A masked field that is null or left out is cleared;
+// values of fields outside the mask are ignored.
+type BulkListingValues struct {
+	ContactName  *string  `json:"contact_name,omitempty" binding:"omitempty,max=150"`
+	ContactEmail *string  `json:"contact_email,omitempty" binding:"omitempty,email,max=255"`
+	ContactPhone *string  `json:"contact_phone,omitempty" binding:"omitempty,max=50"`
+	AddressLine1 *string  `json:"address_line1,omitempty" binding:"omitempty,max=255"`
+	AddressLine2 *string  `json:"address_line2,omitempty" binding:"omitempty,max=255"`
+	City         *string  `json:"city,omitempty" binding:"omitempty,max=100"`
+	State        *string  `json:"state,omitempty" binding:"omitempty,max=50"`
+	ZipCode      *string  `json:"zip_code,omitempty" binding:"omitempty,max=20"`
+	Latitude     *float64 `json:"latitude,omitempty" binding:"omitempty,latitude"`
+	Longitude    *float64 `json:"longitude,omitempty" binding:"omitempty,longitude"`
+}
+
+// BulkUpdateResult reports what a bulk update did to one listing.
+type BulkUpdateResult struct {
+	ListingID     uuid.UUID `json:"listing_id"`
+	Status        string    `json:"status"`
+	ChangedFields []string  `json:"changed_fields,omitempty"`
+	Reason        string    `json:"reason,omitempty"`
+}
+
+// bulkStringField is a masked field stored in a nullable text column of the same name.
+type bulkStringField struct {
+	name    string
+	listing func(l *Listing) **string
+	value   func(v *BulkListingValues) *string
+}
+
+var bulkStringFields = []bulkStringField{
+	{"contact_name", func(l *Listing) **string { return &l.ContactName }, func(v *BulkListingValues) *string { return v.ContactName }},
+	{"contact_email", func(l *Listing) **string { return &l.ContactEmail }, func(v *BulkListingValues) *string { return v.ContactEmail }},
+	{"contact_phone", func(l *Listing) **string { return &l.ContactPhone }, func(v *BulkListingValues) *string { return v.ContactPhone }},
+	{"address_line1", func(l *Listing) **string { return &l.AddressLine1 }, func(v *BulkListingValues) *string { return v.AddressLine1 }},
+	{"address_line2", func(l *Listing) **string { return &l.AddressLine2 }, func(v *BulkListingValues) *string { return v.AddressLine2 }},
+	{"city", func(l *Listing) **string { return &l.City }, func(v *BulkListingValues) *string { return v.City }},
+	{"state", func(l *Listing) **string { return &l.State }, func(v *BulkListingValues) *string { return v.State }},
+	{"zip_code", func(l *Listing) **string { return &l.ZipCode }, func(v *BulkListingValues) *string { return v.ZipCode }},
+}
+
+// parseFieldMask returns the mask as a set, rejecting a mask that moves only one coordinate or sets only one of them.
+func parseFieldMask(req BulkUpdateListingsRequest) (map[string]bool, error) {
+	mask := make(map[string]bool, len(req.FieldMask))
+	for _, field := range req.FieldMask {
+		mask[field] = true
+	}
+	if mask["latitude"] != mask["longitude"] {
+		return nil, common.ErrBadRequest.WithDetails("latitude and longitude must be updated together.")
+	}
+	if mask["latitude"] && (req.Values.Latitude == nil) != (req.Values.Longitude == nil) {
+		return nil, common.ErrBadRequest.WithDetails("Set both latitude and longitude, or clear both.")
+	}
+	return mask, nil
+}
+
+// applyBulkValues sets the masked fields of l and returns the names of those whose value changed.
+func applyBulkValues(l *Listing, mask map[string]bool, values *BulkListingValues) []string {
+	var changed []string
+	for _, field := range bulkStringFields {
+		if !mask[field.name] {
+			continue
+		}
+		current, value := field.listing(l), field.value(values)
+		if !equalStringPtr(*current, value) {
+			*current = value
+			changed = append(changed, field.name)
+		}
+	}
+	if mask["latitude"] {
+		if !equalFloatPtr(l.Latitude, values.Latitude) {
+			l.Latitude = values.Latitude
+			changed = append(changed, "latitude")
+		}
+		if !equalFloatPtr(l.Longitude, values.Longitude) {
+			l.Longitude = values.Longitude
+			changed = append(changed, "longitude")
+		}
+	}
+	return changed
+}
+
+func equalStringPtr(a, b *string) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+func equalFloatPtr(a, b *float64) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+// BulkUpdateMyListings sets the masked fields on the user's active listings (or the requested subset of them)
+// in one transaction: either every changed listing is saved or none is. Search reads the listings table,
+// so the new values are searchable as soon as the transaction commits.
+func (s *ServiceImplementation) BulkUpdateMyListings(ctx context.Context, userID uuid.UUID, req BulkUpdateListingsRequest) ([]BulkUpdateResult, error) {
+	mask, err := parseFieldMask(req)
+	if err != nil {
+		return nil, err
+	}
+
+	listings, err := s.repo.FindActiveByUserID(ctx, userID, req.ListingIDs, MaxBulkUpdateListings+1)
+	if err != nil {
+		s.logger.Error("Failed to load listings for bulk update", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not update listings.")
+	}
+	if len(listings) > MaxBulkUpdateListings {
+		return nil, common.ErrBadRequest.WithDetails(fmt.Sprintf("At most %d listings can be updated at once. Pass listing_ids to update them in parts.", MaxBulkUpdateListings))
+	}
+
+	found := make(map[uuid.UUID]bool, len(listings))
+	results := make([]BulkUpdateResult, 0, len(listings))
+	var changedListings []*Listing
+	for i := range listings {
+		l := &listings[i]
+		found[l.ID] = true
+		changed := applyBulkValues(l, mask, &req.Values)
+		if len(changed) == 0 {
+			results = append(results, BulkUpdateResult{ListingID: l.ID, Status: BulkUpdateUnchanged})
+			continue
+		}
+		if mask["latitude"] && s.checkServiceArea(l) != nil {
+			results = append(results, BulkUpdateResult{ListingID: l.ID, Status: BulkUpdateSkipped, Reason: "The new location is outside the area this site serves."})
+			continue
+		}
+		applyQualityScore(l, l.Category.Slug)
+		changedListings = append(changedListings, l)
+		results = append(results, BulkUpdateResult{ListingID: l.ID, Status: BulkUpdateUpdated, ChangedFields: changed})
+	}
+	for _, id := range req.ListingIDs {
+		if !found[id] {
+			found[id] = true // Report duplicated IDs once
+			results = append(results, BulkUpdateResult{ListingID: id, Status: BulkUpdateSkipped, Reason: "Not one of your active listings."})
+		}
+	}
+
+	if len(changedListings) > 0 {
+		columns := make([]string, 0, len(mask)+2)
+		for field := range mask {
+			columns = append(columns, field)
+		}
+		columns = append(columns, "quality_score", "quality_hints")
+		if err := s.repo.UpdateFields(ctx, changedListings, columns); err != nil {
+			s.logger.Error("Failed to apply bulk listing update", zap.Error(err), zap.String("userID", userID.String()))
+			return nil, common.ErrInternalServer.WithDetails("Could not update listings. No listing was changed.")
+		}
+	}
+
+	s.logger.Info("Bulk listing update applied",
+		zap.String("userID", userID.String()),
+		zap.Strings("fields", req.FieldMask),
+		zap.Int("updated", len(changedListings)),
+		zap.Int("considered", len(listings)))
+	return results, nil
+}
@@ -0,0 +1,95 @@
+package listing
+
+import (
+	"context"
+	"testing"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// bulkRepository serves a user's active listings and records bulk writes.
+type bulkRepository struct {
+	Repository
+	listings []Listing
+	updated  []*Listing
+	columns  []string
+}
+
+func (r *bulkRepository) FindActiveByUserID(_ context.Context, userID uuid.UUID, ids []uuid.UUID, limit int) ([]Listing, error) {
+	var found []Listing
+	for _, l := range r.listings {
+		if l.UserID != userID || l.Status != StatusActive {
+			continue
+		}
+		if len(ids) > 0 && !containsID(ids, l.ID) {
+			continue
+		}
+		found = append(found, l)
+	}
+	if len(found) > limit {
+		found = found[:limit]
+	}
+	return found, nil
+}
+
+func (r *bulkRepository) UpdateFields(_ context.Context, listings []*Listing, columns []string) error {
+	r.updated, r.columns = listings, columns
+	return nil
+}
+
+func TestBulkUpdateMyListings(t *testing.T) {
+	owner := uuid.New()
+	oldPhone, newPhone := "206-555-0100", "206-555-0199"
+	withOldPhone := Listing{UserID: owner, Status: StatusActive, ContactPhone: &oldPhone}
+	withOldPhone.ID = uuid.New()
+	withNewPhone := Listing{UserID: owner, Status: StatusActive, ContactPhone: &newPhone}
+	withNewPhone.ID = uuid.New()
+	draft := Listing{UserID: owner, Status: StatusDraft, ContactPhone: &oldPhone}
+	draft.ID = uuid.New()
+
+	repo := &bulkRepository{listings: []Listing{withOldPhone, withNewPhone, draft}}
+	svc := &ServiceImplementation{repo: repo, logger: zap.NewNop()}
+
+	results, err := svc.BulkUpdateMyListings(context.Background(), owner, BulkUpdateListingsRequest{
+		FieldMask: []string{"contact_phone", "city"},
+		Values:    BulkListingValues{ContactPhone: &newPhone},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2, "only active listings are updated")
+	assert.Equal(t, BulkUpdateResult{ListingID: withOldPhone.ID, Status: BulkUpdateUpdated, ChangedFields: []string{"contact_phone"}}, results[0])
+	assert.Equal(t, BulkUpdateResult{ListingID: withNewPhone.ID, Status: BulkUpdateUnchanged}, results[1])
+
+	require.Len(t, repo.updated, 1)
+	assert.Equal(t, newPhone, *repo.updated[0].ContactPhone)
+	assert.Contains(t, repo.updated[0].QualityHints, QualityHintAddLocation, "the quality score is recomputed")
+	assert.ElementsMatch(t, []string{"contact_phone", "city", "quality_score", "quality_hints"}, repo.columns)
+
+	results, err = svc.BulkUpdateMyListings(context.Background(), owner, BulkUpdateListingsRequest{
+		FieldMask:  []string{"contact_phone"},
+		ListingIDs: []uuid.UUID{draft.ID},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []BulkUpdateResult{{ListingID: draft.ID, Status: BulkUpdateSkipped, Reason: "Not one of your active listings."}}, results)
+}
+
+func TestBulkUpdateRequiresBothCoordinates(t *testing.T) {
+	lat := 47.61
+	svc := &ServiceImplementation{repo: &bulkRepository{}, logger: zap.NewNop()}
+
+	_, err := svc.BulkUpdateMyListings(context.Background(), uuid.New(), BulkUpdateListingsRequest{
+		FieldMask: []string{"latitude"},
+		Values:    BulkListingValues{Latitude: &lat},
+	})
+	assert.ErrorIs(t, err, common.ErrBadRequest)
+
+	_, err = svc.BulkUpdateMyListings(context.Background(), uuid.New(), BulkUpdateListingsRequest{
+		FieldMask: []string{"latitude", "longitude"},
+		Values:    BulkListingValues{Latitude: &lat},
+	})
+	assert.ErrorIs(t, err, common.ErrBadRequest)
+}
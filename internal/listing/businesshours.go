@@ -0,0 +1,178 @@
+// File: internal/listing/businesshours.go
+package listing
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"seattle_info_backend/internal/common"
+)
+
+// maxOpeningPeriodsPerDay caps the opening periods of one day, e.g. a morning and an evening shift.
+const maxOpeningPeriodsPerDay = 4
+
+// openNowCondition matches listings whose business hours include the current time in their time zone, given as
+// @now. Opening times are "HH:MM" strings, so they compare in order; to_char's FMday gives the lowercase day name.
+const openNowCondition = "listings.business_hours IS NOT NULL AND EXISTS (" +
+	"SELECT 1 FROM jsonb_array_elements(listings.business_hours -> to_char(CAST(@now AS timestamptz) AT TIME ZONE (listings.business_hours ->> 'timezone'), 'FMday')) AS period " +
+	"WHERE period ->> 'open' <= to_char(CAST(@now AS timestamptz) AT TIME ZONE (listings.business_hours ->> 'timezone'), 'HH24:MI') " +
+	"AND to_char(CAST(@now AS timestamptz) AT TIME ZONE (listings.business_hours ->> 'timezone'), 'HH24:MI') < period ->> 'close')"
+
+// BusinessHours is a business listing's weekly opening hours, stored as JSONB. Each day lists the periods the
+// business is open as wall-clock times in Timezone; a day without periods is closed.
+type BusinessHours struct {
+	Timezone  string          `json:"timezone,omitempty"` // IANA zone; defaults to EVENTS_TIMEZONE, the region's zone
+	Monday    []OpeningPeriod `json:"monday,omitempty"`
+	Tuesday   []OpeningPeriod `json:"tuesday,omitempty"`
+	Wednesday []OpeningPeriod `json:"wednesday,omitempty"`
+	Thursday  []OpeningPeriod `json:"thursday,omitempty"`
+	Friday    []OpeningPeriod `json:"friday,omitempty"`
+	Saturday  []OpeningPeriod `json:"saturday,omitempty"`
+	Sunday    []OpeningPeriod `json:"sunday,omitempty"`
+}
+
+// OpeningPeriod is a span of one day during which a business is open, as "HH:MM" times.
+// Close is after Open; "24:00" closes at midnight. Hours past midnight belong to the next day.
+type OpeningPeriod struct {
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+// UnmarshalJSON decodes business hours, rejecting unknown members such as misspelt day names.
+func (h *BusinessHours) UnmarshalJSON(data []byte) error {
+	type plain BusinessHours // Without this method, to avoid recursion
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var decoded plain
+	if err := dec.Decode(&decoded); err != nil {
+		return fmt.Errorf("invalid business hours: %w", err)
+	}
+	*h = BusinessHours(decoded)
+	return nil
+}
+
+// Value implements the driver.Valuer interface for BusinessHours.
+func (h BusinessHours) Value() (driver.Value, error) {
+	b, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface for BusinessHours.
+func (h *BusinessHours) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, h)
+	case string:
+		return json.Unmarshal([]byte(v), h)
+	default:
+		return errors.New("failed to scan BusinessHours: invalid type")
+	}
+}
+
+// weekdays lists the days of the week in the order the API presents them.
+var weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+
+// periods returns the opening periods of day.
+func (h *BusinessHours) periods(day time.Weekday) *[]OpeningPeriod {
+	switch day {
+	case time.Monday:
+		return &h.Monday
+	case time.Tuesday:
+		return &h.Tuesday
+	case time.Wednesday:
+		return &h.Wednesday
+	case time.Thursday:
+		return &h.Thursday
+	case time.Friday:
+		return &h.Friday
+	case time.Saturday:
+		return &h.Saturday
+	default:
+		return &h.Sunday
+	}
+}
+
+// normalize validates the hours, sorts each day's periods and sets Timezone, defaulting to defaultZone.
+func (h *BusinessHours) normalize(defaultZone string) error {
+	return h.normalizeAs("Business hours", defaultZone)
+}
+
+// normalizeAs is normalize for weekly hours that error messages call what, e.g. "Availability".
+func (h *BusinessHours) normalizeAs(what string, defaultZone string) error {
+	if h.Timezone == "" {
+		h.Timezone = defaultZone
+	}
+	loc, err := time.LoadLocation(h.Timezone)
+	if err != nil {
+		return common.ErrBadRequest.WithDetails(what + " timezone must be an IANA time zone name, e.g. America/Los_Angeles.")
+	}
+	h.Timezone = loc.String()
+
+	for _, day := range weekdays {
+		periods, name := h.periods(day), day.String()
+		if len(*periods) > maxOpeningPeriodsPerDay {
+			return common.ErrBadRequest.WithDetails(fmt.Sprintf("%s allow at most %d periods on %s.", what, maxOpeningPeriodsPerDay, name))
+		}
+		for _, p := range *periods {
+			if !validClock(p.Open, false) || !validClock(p.Close, true) {
+				return common.ErrBadRequest.WithDetails(fmt.Sprintf("%s on %s must be HH:MM times, e.g. {\"open\": \"09:00\", \"close\": \"17:30\"}.", what, name))
+			}
+			if p.Close <= p.Open {
+				return common.ErrBadRequest.WithDetails(fmt.Sprintf("%s on %s close before they open. Use \"24:00\" to close at midnight and list later hours on the next day.", what, name))
+			}
+		}
+		sort.Slice(*periods, func(i, j int) bool { return (*periods)[i].Open < (*periods)[j].Open })
+		for i := 1; i < len(*periods); i++ {
+			if (*periods)[i].Open < (*periods)[i-1].Close {
+				return common.ErrBadRequest.WithDetails(fmt.Sprintf("%s on %s overlap.", what, name))
+			}
+		}
+	}
+	return nil
+}
+
+// validClock reports whether s is an "HH:MM" time of day; allowMidnight also accepts "24:00".
+func validClock(s string, allowMidnight bool) bool {
+	if allowMidnight && s == "24:00" {
+		return true
+	}
+	_, err := time.Parse("15:04", s)
+	return err == nil && len(s) == len("15:04")
+}
+
+// OpenAt reports whether the business is open at t.
+func (h *BusinessHours) OpenAt(t time.Time) bool {
+	loc, err := time.LoadLocation(h.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := t.In(loc)
+	clock := local.Format("15:04")
+	for _, p := range *h.periods(local.Weekday()) {
+		if p.Open <= clock && clock < p.Close {
+			return true
+		}
+	}
+	return false
+}
+
+// applyBusinessHours validates hours and sets them on l, a listing in the category with categorySlug.
+// Only business listings have opening hours.
+func (s *ServiceImplementation) applyBusinessHours(l *Listing, categorySlug string, hours *BusinessHours) error {
+	if categorySlug != "businesses" {
+		return common.ErrBadRequest.WithDetails("Business hours can only be set on business listings.")
+	}
+	if err := hours.normalize(s.cfg.EventsTimezone); err != nil {
+		return err
+	}
+	l.BusinessHours = hours
+	return nil
+}
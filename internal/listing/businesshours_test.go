@@ -0,0 +1,50 @@
+package listing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusinessHoursNormalize(t *testing.T) {
+	hours := BusinessHours{Monday: []OpeningPeriod{{Open: "17:00", Close: "22:00"}, {Open: "11:00", Close: "14:30"}}, Friday: []OpeningPeriod{{Open: "11:00", Close: "24:00"}}}
+	require.NoError(t, hours.normalize("America/Los_Angeles"))
+	assert.Equal(t, "America/Los_Angeles", hours.Timezone)
+	assert.Equal(t, "11:00", hours.Monday[0].Open, "periods are sorted")
+
+	invalid := map[string]BusinessHours{
+		"unknown zone":   {Timezone: "Mars/Olympus", Monday: []OpeningPeriod{{Open: "09:00", Close: "17:00"}}},
+		"not HH:MM":      {Monday: []OpeningPeriod{{Open: "9:00", Close: "17:00"}}},
+		"24:00 opening":  {Monday: []OpeningPeriod{{Open: "24:00", Close: "24:00"}}},
+		"closes earlier": {Monday: []OpeningPeriod{{Open: "22:00", Close: "02:00"}}},
+		"overlapping":    {Monday: []OpeningPeriod{{Open: "09:00", Close: "13:00"}, {Open: "12:00", Close: "17:00"}}},
+		"too many":       {Monday: []OpeningPeriod{{"06:00", "07:00"}, {"08:00", "09:00"}, {"10:00", "11:00"}, {"12:00", "13:00"}, {"14:00", "15:00"}}},
+	}
+	for name, h := range invalid {
+		assert.ErrorIs(t, h.normalize("America/Los_Angeles"), common.ErrBadRequest, name)
+	}
+}
+
+func TestBusinessHoursOpenAt(t *testing.T) {
+	hours := BusinessHours{Timezone: "America/Los_Angeles", Monday: []OpeningPeriod{{Open: "09:00", Close: "17:00"}}, Tuesday: []OpeningPeriod{{Open: "20:00", Close: "24:00"}}}
+	seattle, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+
+	assert.True(t, hours.OpenAt(time.Date(2024, 6, 3, 9, 0, 0, 0, seattle)), "opens at 09:00 on Monday")
+	assert.False(t, hours.OpenAt(time.Date(2024, 6, 3, 17, 0, 0, 0, seattle)), "closed from 17:00")
+	assert.True(t, hours.OpenAt(time.Date(2024, 6, 3, 20, 0, 0, 0, time.UTC)), "compared in the business's time zone: 13:00 in Seattle")
+	assert.True(t, hours.OpenAt(time.Date(2024, 6, 4, 23, 59, 0, 0, seattle)), "24:00 closes at midnight")
+	assert.False(t, hours.OpenAt(time.Date(2024, 6, 5, 10, 0, 0, 0, seattle)), "no hours on Wednesday")
+}
+
+func TestBusinessHoursRejectsUnknownDays(t *testing.T) {
+	var hours BusinessHours
+	assert.Error(t, json.Unmarshal([]byte(`{"mon": [{"open": "09:00", "close": "17:00"}]}`), &hours))
+	require.NoError(t, json.Unmarshal([]byte(`{"monday": [{"open": "09:00", "close": "17:00"}]}`), &hours))
+	assert.Len(t, hours.Monday, 1)
+}
@@ -0,0 +1,195 @@
+// File: internal/listing/checkin.go
+package listing
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/platform/crypto"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// checkinCodeBytes is the randomness of a check-in code; the encoded code is 32 characters.
+const checkinCodeBytes = 24
+
+// CheckinCode is the secret an event's organizer shows at the door, usually rendered as a QR code.
+type CheckinCode struct {
+	ListingID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Code      string    `gorm:"type:varchar(64);not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (CheckinCode) TableName() string {
+	return "event_checkin_codes"
+}
+
+// Checkin records that a user scanned an event's check-in code on the day of one of its occurrences.
+type Checkin struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	ListingID      uuid.UUID `gorm:"type:uuid;not null"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null"`
+	OccurrenceDate time.Time `gorm:"type:date;not null"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
+
+func (Checkin) TableName() string {
+	return "event_checkins"
+}
+
+// CheckinRequest is the payload of POST /listings/:id/checkin.
+type CheckinRequest struct {
+	Code string `json:"code" binding:"required,max=64"`
+}
+
+// CheckinCodeResponse is the response of GET and POST /listings/:id/checkin-code.
+type CheckinCodeResponse struct {
+	ListingID     uuid.UUID `json:"listing_id"`
+	Code          string    `json:"code"`
+	CheckinURL    string    `json:"checkin_url,omitempty"` // What the QR code should encode; empty when WEB_BASE_URL is not set
+	CreatedAt     time.Time `json:"created_at"`
+	OccursToday   bool      `json:"occurs_today"`
+	CheckinsToday int64     `json:"checkins_today"`
+}
+
+// CheckinResponse is the response of POST /listings/:id/checkin.
+type CheckinResponse struct {
+	ListingID        uuid.UUID `json:"listing_id"`
+	OccurrenceDate   string    `json:"occurrence_date"` // YYYY-MM-DD in the event's time zone
+	AlreadyCheckedIn bool      `json:"already_checked_in"`
+}
+
+// GetCheckinCode returns the check-in code of the owner's event, creating it on first use.
+func (s *ServiceImplementation) GetCheckinCode(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*CheckinCodeResponse, error) {
+	listing, err := s.ownedEvent(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	code, err := s.repo.FindCheckinCode(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to find check-in code", zap.String("listingID", id.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve the check-in code.")
+	}
+	if code == nil {
+		if code, err = s.newCheckinCode(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return s.checkinCodeResponse(ctx, listing, code)
+}
+
+// RotateCheckinCode replaces the check-in code of the owner's event, e.g. after it was shared too widely.
+// Codes scanned before the rotation stay recorded.
+func (s *ServiceImplementation) RotateCheckinCode(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*CheckinCodeResponse, error) {
+	listing, err := s.ownedEvent(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	code, err := s.newCheckinCode(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.checkinCodeResponse(ctx, listing, code)
+}
+
+// CheckIn records the user's attendance at today's occurrence of an event when code is its check-in code.
+// Scanning again the same day reports the earlier check-in instead of counting twice.
+func (s *ServiceImplementation) CheckIn(ctx context.Context, id uuid.UUID, userID uuid.UUID, code string) (*CheckinResponse, error) {
+	listing, err := s.GetListingByID(ctx, id, &userID)
+	if err != nil {
+		return nil, err
+	}
+	if listing.EventDetails == nil {
+		return nil, common.ErrBadRequest.WithDetails("Only events have check-in codes.")
+	}
+	if listing.Status != StatusActive {
+		return nil, common.ErrBadRequest.WithDetails("This event is not open for check-in.")
+	}
+
+	stored, err := s.repo.FindCheckinCode(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to find check-in code", zap.String("listingID", id.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not check in.")
+	}
+	if stored == nil || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(code)), []byte(stored.Code)) != 1 {
+		return nil, common.ErrForbidden.WithDetails("The check-in code is not valid for this event.")
+	}
+	if listing.UserID == userID {
+		return nil, common.ErrBadRequest.WithDetails("Organizers cannot check in to their own event.")
+	}
+
+	today, ok := listing.EventDetails.occursOn(time.Now().In(listing.EventDetails.Location(s.eventsLocation())))
+	if !ok {
+		return nil, common.ErrBadRequest.WithDetails("This event does not take place today.")
+	}
+	created, err := s.repo.CreateCheckin(ctx, &Checkin{ListingID: id, UserID: userID, OccurrenceDate: today})
+	if err != nil {
+		s.logger.Error("Failed to record check-in", zap.String("listingID", id.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not check in.")
+	}
+	return &CheckinResponse{ListingID: id, OccurrenceDate: today.Format("2006-01-02"), AlreadyCheckedIn: !created}, nil
+}
+
+// ownedEvent loads an event listing of the user for the check-in code endpoints.
+func (s *ServiceImplementation) ownedEvent(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*Listing, error) {
+	listing, err := s.repo.FindByID(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if listing.UserID != userID {
+		return nil, common.ErrForbidden.WithDetails("You do not have permission to manage this event's check-ins.")
+	}
+	if listing.EventDetails == nil {
+		return nil, common.ErrBadRequest.WithDetails("Only events have check-in codes.")
+	}
+	return listing, nil
+}
+
+// newCheckinCode generates and stores a fresh check-in code for the event, replacing any earlier one.
+func (s *ServiceImplementation) newCheckinCode(ctx context.Context, id uuid.UUID) (*CheckinCode, error) {
+	random, err := crypto.GenerateSecureRandomString(checkinCodeBytes)
+	if err != nil {
+		s.logger.Error("Failed to generate check-in code", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not create a check-in code.")
+	}
+	code := &CheckinCode{ListingID: id, Code: random, CreatedAt: time.Now()}
+	if err := s.repo.SaveCheckinCode(ctx, code); err != nil {
+		s.logger.Error("Failed to save check-in code", zap.String("listingID", id.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not create a check-in code.")
+	}
+	return code, nil
+}
+
+// checkinCodeResponse describes code along with today's check-ins of the event.
+func (s *ServiceImplementation) checkinCodeResponse(ctx context.Context, listing *Listing, code *CheckinCode) (*CheckinCodeResponse, error) {
+	resp := &CheckinCodeResponse{ListingID: listing.ID, Code: code.Code, CreatedAt: code.CreatedAt}
+	if base := strings.TrimRight(s.cfg.WebBaseURL, "/"); base != "" {
+		resp.CheckinURL = base + "/events/" + listing.ID.String() + "/checkin?code=" + code.Code
+	}
+
+	today, ok := listing.EventDetails.occursOn(time.Now().In(listing.EventDetails.Location(s.eventsLocation())))
+	resp.OccursToday = ok
+	if ok {
+		count, err := s.repo.CountCheckins(ctx, listing.ID, today)
+		if err != nil {
+			s.logger.Error("Failed to count check-ins", zap.String("listingID", listing.ID.String()), zap.Error(err))
+			return nil, common.ErrInternalServer.WithDetails("Could not retrieve the check-in code.")
+		}
+		resp.CheckinsToday = count
+	}
+	return resp, nil
+}
+
+// occursOn reports whether the event has an occurrence on now's calendar date, in now's location, and returns that date.
+func (e *ListingDetailsEvents) occursOn(now time.Time) (time.Time, bool) {
+	today := dateOnly(now, now.Location())
+	next, ok := e.OccurrenceAfter(today)
+	if !ok || !dateOnly(next, now.Location()).Equal(today) {
+		return time.Time{}, false
+	}
+	return today, true
+}
@@ -0,0 +1,33 @@
+package listing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOccursOn(t *testing.T) {
+	loc := time.FixedZone("PDT", -7*3600)
+	weekly := EventRecursWeekly
+	eventTime := "19:00:00"
+	event := &ListingDetailsEvents{
+		EventDate:           time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC),
+		EventTime:           &eventTime,
+		RecurrenceFrequency: &weekly,
+		RecurrenceInterval:  1,
+	}
+
+	day, ok := event.occursOn(time.Date(2024, 6, 10, 22, 30, 0, 0, loc))
+	assert.True(t, ok, "an occurrence that already started still counts for the whole day")
+	assert.Equal(t, "2024-06-10", day.Format("2006-01-02"))
+
+	_, ok = event.occursOn(time.Date(2024, 6, 11, 9, 0, 0, 0, loc))
+	assert.False(t, ok, "no occurrence on the day after")
+
+	event.RecurrenceFrequency = nil
+	_, ok = event.occursOn(time.Date(2024, 6, 10, 9, 0, 0, 0, loc))
+	assert.False(t, ok, "one-off events only occur on their date")
+	_, ok = event.occursOn(time.Date(2024, 6, 3, 23, 0, 0, 0, loc))
+	assert.True(t, ok)
+}
@@ -0,0 +1,86 @@
+// File: internal/listing/contact.go
+package listing
+
+import (
+	"context"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// contactRevealWindow is the period over which a user's contact reveals are counted for rate limiting.
+const contactRevealWindow = time.Hour
+
+// ContactReveal records that a user asked to see a listing's contact details.
+type ContactReveal struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	ListingID uuid.UUID `gorm:"type:uuid;not null"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (ContactReveal) TableName() string {
+	return "listing_contact_reveals"
+}
+
+// ContactDetailsResponse is the response of POST /listings/:id/contact-reveal.
+type ContactDetailsResponse struct {
+	ListingID    uuid.UUID `json:"listing_id"`
+	ContactName  *string   `json:"contact_name,omitempty"`
+	ContactEmail *string   `json:"contact_email,omitempty"`
+	ContactPhone *string   `json:"contact_phone,omitempty"`
+}
+
+// RevealContact returns the contact details of a listing the user may view and records the reveal for the owner's
+// analytics. Revealing the same listing again is not recorded and does not count towards the hourly limit.
+// Owners see their own details without a record.
+func (s *ServiceImplementation) RevealContact(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*ContactDetailsResponse, error) {
+	listing, err := s.GetListingByID(ctx, id, &userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if listing.UserID != userID {
+		revealed, err := s.repo.HasContactReveal(ctx, id, userID)
+		if err != nil {
+			s.logger.Error("Failed to check contact reveal", zap.String("listingID", id.String()), zap.Error(err))
+			return nil, common.ErrInternalServer.WithDetails("Could not reveal contact details.")
+		}
+		if !revealed {
+			if err := s.checkContactRevealLimit(ctx, userID); err != nil {
+				return nil, err
+			}
+			if err := s.repo.CreateContactReveal(ctx, &ContactReveal{ListingID: id, UserID: userID}); err != nil {
+				s.logger.Error("Failed to record contact reveal", zap.String("listingID", id.String()), zap.Error(err))
+				return nil, common.ErrInternalServer.WithDetails("Could not reveal contact details.")
+			}
+		}
+	}
+
+	return &ContactDetailsResponse{
+		ListingID:    listing.ID,
+		ContactName:  listing.ContactName,
+		ContactEmail: listing.ContactEmail,
+		ContactPhone: listing.ContactPhone,
+	}, nil
+}
+
+// checkContactRevealLimit rejects the reveal once the user has revealed cfg.ContactRevealsPerHour listings in the last hour.
+func (s *ServiceImplementation) checkContactRevealLimit(ctx context.Context, userID uuid.UUID) error {
+	limit := s.cfg.ContactRevealsPerHour
+	if limit <= 0 {
+		return nil
+	}
+	count, err := s.repo.CountContactRevealsSince(ctx, userID, time.Now().Add(-contactRevealWindow))
+	if err != nil {
+		s.logger.Error("Failed to count contact reveals", zap.String("userID", userID.String()), zap.Error(err))
+		return common.ErrInternalServer.WithDetails("Could not reveal contact details.")
+	}
+	if count >= int64(limit) {
+		return common.ErrTooManyRequests.WithDetails("You have viewed the contact details of too many listings. Please try again later.")
+	}
+	return nil
+}
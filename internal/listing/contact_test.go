@@ -0,0 +1,95 @@
+package listing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// contactRevealRepository keeps one listing and its contact reveals in memory.
+type contactRevealRepository struct {
+	Repository
+	listing *Listing
+	reveals []ContactReveal
+}
+
+func (r *contactRevealRepository) FindByID(_ context.Context, id uuid.UUID, _ bool) (*Listing, error) {
+	if r.listing.ID != id {
+		return nil, common.ErrNotFound
+	}
+	return r.listing, nil
+}
+
+func (r *contactRevealRepository) HasContactReveal(_ context.Context, listingID, userID uuid.UUID) (bool, error) {
+	for _, rv := range r.reveals {
+		if rv.ListingID == listingID && rv.UserID == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *contactRevealRepository) CountContactRevealsSince(_ context.Context, userID uuid.UUID, since time.Time) (int64, error) {
+	var n int64
+	for _, rv := range r.reveals {
+		if rv.UserID == userID && !rv.CreatedAt.Before(since) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *contactRevealRepository) CreateContactReveal(_ context.Context, reveal *ContactReveal) error {
+	reveal.CreatedAt = time.Now()
+	r.reveals = append(r.reveals, *reveal)
+	return nil
+}
+
+func TestRevealContact(t *testing.T) {
+	email, phone := "jo@example.com", "206-555-0100"
+	l := &Listing{UserID: uuid.New(), Status: StatusActive, ContactEmail: &email, ContactPhone: &phone}
+	l.ID = uuid.New()
+	viewer := uuid.New()
+	repo := &contactRevealRepository{listing: l}
+	svc := &ServiceImplementation{repo: repo, cfg: &config.Config{ContactRevealsPerHour: 2}, logger: zap.NewNop()}
+
+	contact, err := svc.RevealContact(context.Background(), l.ID, viewer)
+	require.NoError(t, err)
+	assert.Equal(t, &email, contact.ContactEmail)
+	assert.Equal(t, &phone, contact.ContactPhone)
+	require.Len(t, repo.reveals, 1)
+
+	_, err = svc.RevealContact(context.Background(), l.ID, viewer)
+	require.NoError(t, err)
+	assert.Len(t, repo.reveals, 1, "revealing the same listing again is not recorded")
+
+	_, err = svc.RevealContact(context.Background(), l.ID, l.UserID)
+	require.NoError(t, err)
+	assert.Len(t, repo.reveals, 1, "owners are not recorded")
+
+	repo.reveals = append(repo.reveals, ContactReveal{ListingID: uuid.New(), UserID: viewer, CreatedAt: time.Now()})
+	other := uuid.New()
+	repo.reveals = append(repo.reveals, ContactReveal{ListingID: uuid.New(), UserID: other, CreatedAt: time.Now()},
+		ContactReveal{ListingID: uuid.New(), UserID: other, CreatedAt: time.Now()})
+	_, err = svc.RevealContact(context.Background(), l.ID, other)
+	assert.ErrorIs(t, err, common.ErrTooManyRequests)
+
+	_, err = svc.RevealContact(context.Background(), l.ID, viewer)
+	assert.NoError(t, err, "a listing revealed before stays available over the limit")
+}
+
+func TestToListingResponseOmitsContactDetails(t *testing.T) {
+	email := "jo@example.com"
+	l := &Listing{ContactEmail: &email}
+
+	assert.Nil(t, ToListingResponse(l, nil).ContactEmail)
+	assert.Equal(t, &email, ToOwnerListingResponse(l, nil).ContactEmail)
+}
@@ -0,0 +1,30 @@
+// File: internal/listing/etag.go
+package listing
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// listingETag returns the strong entity tag of a listing's current version.
+// It changes whenever the listing is saved, because every save bumps updated_at.
+// updated_at is truncated to microseconds, the precision Postgres stores it with.
+func listingETag(l *Listing) string {
+	return fmt.Sprintf(`"%s-%x"`, l.ID, l.UpdatedAt.UTC().Truncate(time.Microsecond).UnixMicro())
+}
+
+// ifMatchSatisfied evaluates an If-Match header against the current entity tag (RFC 9110 §13.1.1).
+// An empty header is always satisfied. Weak tags never match because If-Match uses strong comparison.
+func ifMatchSatisfied(ifMatch, etag string) bool {
+	if ifMatch == "" {
+		return true
+	}
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package listing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListingETagIgnoresSubMicrosecondPrecision(t *testing.T) {
+	l := &Listing{}
+	l.ID = uuid.New()
+	l.UpdatedAt = time.Date(2024, 3, 1, 9, 30, 0, 123456789, time.UTC)
+	etag := listingETag(l)
+
+	l.UpdatedAt = time.Date(2024, 3, 1, 9, 30, 0, 123456000, time.UTC)
+	assert.Equal(t, etag, listingETag(l), "Postgres stores microseconds")
+
+	l.UpdatedAt = l.UpdatedAt.Add(time.Microsecond)
+	assert.NotEqual(t, etag, listingETag(l))
+}
+
+func TestIfMatchSatisfied(t *testing.T) {
+	etag := `"abc-1"`
+	assert.True(t, ifMatchSatisfied("", etag), "no precondition")
+	assert.True(t, ifMatchSatisfied(etag, etag))
+	assert.True(t, ifMatchSatisfied(`"other", "abc-1"`, etag))
+	assert.True(t, ifMatchSatisfied("*", etag))
+	assert.False(t, ifMatchSatisfied(`"abc-0"`, etag))
+	assert.False(t, ifMatchSatisfied(`W/"abc-1"`, etag), "weak tags never match If-Match")
+}
@@ -0,0 +1,100 @@
+// File: internal/listing/eventtime.go
+package listing
+
+import (
+	"time"
+
+	"seattle_info_backend/internal/common"
+)
+
+// EventDetailsResponse is the API representation of an event's details. Dates are in the event's time zone;
+// starts_at and next_occurrence are RFC 3339 times with that zone's offset.
+type EventDetailsResponse struct {
+	EventDate           string                    `json:"event_date"` // YYYY-MM-DD
+	EventTime           *string                   `json:"event_time,omitempty"`
+	Timezone            string                    `json:"timezone"`
+	StartsAt            time.Time                 `json:"starts_at"` // First occurrence; midnight for all-day events
+	OrganizerName       *string                   `json:"organizer_name,omitempty"`
+	VenueName           *string                   `json:"venue_name,omitempty"`
+	RecurrenceFrequency *EventRecurrenceFrequency `json:"recurrence_frequency"`
+	RecurrenceInterval  int                       `json:"recurrence_interval"`
+	RecurrenceUntil     *string                   `json:"recurrence_until"` // YYYY-MM-DD
+	NextOccurrence      *time.Time                `json:"next_occurrence,omitempty"`
+}
+
+// ToEventDetailsResponse converts event details to an EventDetailsResponse DTO.
+func ToEventDetailsResponse(e *ListingDetailsEvents) *EventDetailsResponse {
+	if e == nil {
+		return nil
+	}
+	loc := e.Location(time.UTC)
+	resp := &EventDetailsResponse{
+		EventDate:           e.EventDate.Format(eventDateLayout),
+		EventTime:           e.EventTime,
+		Timezone:            loc.String(),
+		StartsAt:            e.StartsAt.In(loc),
+		OrganizerName:       e.OrganizerName,
+		VenueName:           e.VenueName,
+		RecurrenceFrequency: e.RecurrenceFrequency,
+		RecurrenceInterval:  e.RecurrenceInterval,
+	}
+	if e.RecurrenceUntil != nil {
+		until := e.RecurrenceUntil.Format(eventDateLayout)
+		resp.RecurrenceUntil = &until
+	}
+	if e.NextOccurrence != nil {
+		next := e.NextOccurrence.In(loc)
+		resp.NextOccurrence = &next
+	}
+	return resp
+}
+
+// Location returns the time zone the event's date and time are in. Events without a known Timezone use fallback.
+func (e *ListingDetailsEvents) Location(fallback *time.Location) *time.Location {
+	if e.Timezone == "" {
+		return fallback
+	}
+	loc, err := time.LoadLocation(e.Timezone)
+	if err != nil {
+		return fallback
+	}
+	return loc
+}
+
+// applyScheduleTo sets the date, time and time zone of e from the request and recomputes StartsAt.
+// The zone is Timezone, else e's current zone, else defaultZone. StartsAt, an RFC 3339 time with any offset, takes
+// precedence over EventDate and EventTime and is converted to that zone. Fields left out keep their value.
+func (r *CreateListingEventDetailsRequest) applyScheduleTo(e *ListingDetailsEvents, defaultZone string) error {
+	zone := e.Timezone
+	if r.Timezone != nil && *r.Timezone != "" {
+		zone = *r.Timezone
+	}
+	if zone == "" {
+		zone = defaultZone
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return common.ErrBadRequest.WithDetails("Event timezone must be an IANA time zone name, e.g. America/Los_Angeles.")
+	}
+
+	if r.StartsAt != nil {
+		local := r.StartsAt.In(loc)
+		startTime := local.Format(eventTimeLayout)
+		e.EventDate = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+		e.EventTime = &startTime
+	} else {
+		if r.EventDate != "" {
+			eventDate, err := time.Parse(eventDateLayout, r.EventDate)
+			if err != nil {
+				return common.ErrBadRequest.WithDetails("Event date must be in the format YYYY-MM-DD.")
+			}
+			e.EventDate = eventDate
+		}
+		if r.EventTime != nil {
+			e.EventTime = r.EventTime
+		}
+	}
+	e.Timezone = loc.String()
+	e.StartsAt = e.startOn(dateOnly(e.EventDate, loc)).UTC()
+	return nil
+}
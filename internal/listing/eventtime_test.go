@@ -0,0 +1,77 @@
+package listing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyScheduleToConvertsStartsAtToTheEventZone(t *testing.T) {
+	zone := "Europe/Berlin"
+	startsAt := time.Date(2025, 3, 30, 23, 30, 0, 0, time.UTC) // 01:30 the next day in Berlin, after the switch to CEST
+	details := &ListingDetailsEvents{}
+
+	req := &CreateListingEventDetailsRequest{StartsAt: &startsAt, Timezone: &zone}
+	require.NoError(t, req.applyScheduleTo(details, "America/Los_Angeles"))
+
+	assert.Equal(t, "Europe/Berlin", details.Timezone)
+	assert.Equal(t, "2025-03-31", details.EventDate.Format(eventDateLayout))
+	require.NotNil(t, details.EventTime)
+	assert.Equal(t, "01:30:00", *details.EventTime)
+	assert.True(t, startsAt.Equal(details.StartsAt))
+}
+
+func TestApplyScheduleToUsesTheDefaultZoneAcrossDST(t *testing.T) {
+	eventTime := "18:00:00"
+	winter := &ListingDetailsEvents{}
+	require.NoError(t, (&CreateListingEventDetailsRequest{EventDate: "2025-03-08", EventTime: &eventTime}).applyScheduleTo(winter, "America/Los_Angeles"))
+	summer := &ListingDetailsEvents{}
+	require.NoError(t, (&CreateListingEventDetailsRequest{EventDate: "2025-03-10", EventTime: &eventTime}).applyScheduleTo(summer, "America/Los_Angeles"))
+
+	assert.Equal(t, "America/Los_Angeles", winter.Timezone)
+	assert.Equal(t, time.Date(2025, 3, 9, 2, 0, 0, 0, time.UTC), winter.StartsAt, "PST is UTC-8")
+	assert.Equal(t, time.Date(2025, 3, 11, 1, 0, 0, 0, time.UTC), summer.StartsAt, "PDT is UTC-7")
+
+	// An update that only changes the time keeps the date and zone.
+	later := "20:15:00"
+	require.NoError(t, (&CreateListingEventDetailsRequest{EventTime: &later}).applyScheduleTo(summer, "UTC"))
+	assert.Equal(t, "America/Los_Angeles", summer.Timezone)
+	assert.Equal(t, time.Date(2025, 3, 11, 3, 15, 0, 0, time.UTC), summer.StartsAt)
+}
+
+func TestApplyScheduleToRejectsUnknownZones(t *testing.T) {
+	zone := "Mars/Olympus_Mons"
+	err := (&CreateListingEventDetailsRequest{EventDate: "2025-03-10", Timezone: &zone}).applyScheduleTo(&ListingDetailsEvents{}, "UTC")
+	assert.Error(t, err)
+}
+
+func TestToEventDetailsResponseUsesTheEventOffset(t *testing.T) {
+	eventTime := "18:00:00"
+	next := time.Date(2025, 7, 4, 1, 0, 0, 0, time.UTC)
+	details := &ListingDetailsEvents{
+		EventDate:      time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC),
+		EventTime:      &eventTime,
+		Timezone:       "America/Los_Angeles",
+		StartsAt:       time.Date(2025, 1, 4, 2, 0, 0, 0, time.UTC),
+		NextOccurrence: &next,
+	}
+
+	resp := ToEventDetailsResponse(details)
+	assert.Equal(t, "2025-01-03", resp.EventDate)
+	assert.Equal(t, "2025-01-03T18:00:00-08:00", resp.StartsAt.Format(time.RFC3339))
+	require.NotNil(t, resp.NextOccurrence)
+	assert.Equal(t, "2025-07-03T18:00:00-07:00", resp.NextOccurrence.Format(time.RFC3339))
+}
+
+func TestOccurrenceAfterInTheEventZone(t *testing.T) {
+	details := &ListingDetailsEvents{EventDate: time.Date(2025, 11, 2, 0, 0, 0, 0, time.UTC), Timezone: "America/Los_Angeles"}
+	loc := details.Location(time.UTC)
+
+	// Nov 2 is the day DST ends; 23:30 that night in Seattle (PST) is 07:30 UTC on Nov 3.
+	_, ok := details.OccurrenceAfter(time.Date(2025, 11, 3, 7, 30, 0, 0, time.UTC).In(loc))
+	assert.True(t, ok, "an all-day event lasts until midnight in its own zone")
+	_, ok = details.OccurrenceAfter(time.Date(2025, 11, 3, 8, 30, 0, 0, time.UTC).In(loc))
+	assert.False(t, ok)
+}
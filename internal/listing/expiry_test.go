@@ -0,0 +1,107 @@
+package listing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/webhook"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// expiryRepository keeps overdue listings in memory and can fail the nth ExpireBatch call.
+type expiryRepository struct {
+	Repository
+	overdue   []Listing
+	pending   []Listing
+	batches   int
+	failBatch int
+}
+
+func (r *expiryRepository) ExpireBatch(_ context.Context, _ time.Time, limit int) ([]uuid.UUID, error) {
+	r.batches++
+	if r.batches == r.failBatch {
+		return nil, errors.New("connection reset")
+	}
+	n := min(limit, len(r.overdue))
+	ids := make([]uuid.UUID, n)
+	for i, l := range r.overdue[:n] {
+		l.Status = StatusExpired
+		r.pending = append(r.pending, l)
+		ids[i] = l.ID
+	}
+	r.overdue = r.overdue[n:]
+	return ids, nil
+}
+
+func (r *expiryRepository) FindPendingExpiryEvents(_ context.Context, limit int) ([]Listing, error) {
+	return r.pending[:min(limit, len(r.pending))], nil
+}
+
+func (r *expiryRepository) ClearPendingExpiryEvents(_ context.Context, ids []uuid.UUID) error {
+	r.pending = r.pending[len(ids):]
+	return nil
+}
+
+// dispatchedEvents records the webhook events emitted.
+type dispatchedEvents struct {
+	webhook.Service
+	listingIDs []uuid.UUID
+}
+
+func (d *dispatchedEvents) Dispatch(_ context.Context, _ string, data interface{}) {
+	d.listingIDs = append(d.listingIDs, data.(map[string]interface{})["listing_id"].(uuid.UUID))
+}
+
+func newExpiryRepository(n int) *expiryRepository {
+	repo := &expiryRepository{}
+	for i := 0; i < n; i++ {
+		l := Listing{Status: StatusActive}
+		l.ID = uuid.New()
+		repo.overdue = append(repo.overdue, l)
+	}
+	return repo
+}
+
+func TestExpireListingsInBatches(t *testing.T) {
+	repo := newExpiryRepository(expiryBatchSize*2 + 3)
+	events := &dispatchedEvents{}
+	svc := &ServiceImplementation{repo: repo, webhookService: events, cfg: &config.Config{}, logger: zap.NewNop()}
+
+	count, err := svc.ExpireListings(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, expiryBatchSize*2+3, count)
+	assert.Equal(t, 3, repo.batches)
+	assert.Len(t, events.listingIDs, count, "one event per expired listing")
+	assert.Empty(t, repo.pending)
+}
+
+func TestExpireListingsResumesInterruptedRun(t *testing.T) {
+	repo := newExpiryRepository(expiryBatchSize + 10)
+	repo.failBatch = 2
+	events := &dispatchedEvents{}
+	svc := &ServiceImplementation{repo: repo, webhookService: events, cfg: &config.Config{}, logger: zap.NewNop()}
+
+	count, err := svc.ExpireListings(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, expiryBatchSize, count, "the first batch stays expired")
+	assert.Len(t, events.listingIDs, expiryBatchSize)
+
+	// Simulate a run that stopped after expiring a batch but before emitting its events.
+	repo.failBatch = 0
+	leftover := repo.overdue[0]
+	repo.overdue = repo.overdue[1:]
+	repo.pending = append(repo.pending, leftover)
+
+	count, err = svc.ExpireListings(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 9, count)
+	assert.Len(t, events.listingIDs, expiryBatchSize+10, "no listing's event is lost")
+	assert.Contains(t, events.listingIDs, leftover.ID)
+}
@@ -0,0 +1,234 @@
+// File: internal/listing/export.go
+package listing
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/filestorage"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// exportBatchSize is the number of listings ExportListings loads per query.
+const exportBatchSize = 500
+
+// ExportListingsQuery holds the query parameters of GET /admin/listings/export.
+type ExportListingsQuery struct {
+	Format     string `form:"format"`      // csv (default) or json
+	Status     string `form:"status"`      // One listing status
+	CategoryID string `form:"category_id"` // Category UUID
+	From       string `form:"from"`        // YYYY-MM-DD, first creation day included (UTC)
+	To         string `form:"to"`          // YYYY-MM-DD, last creation day included (UTC)
+}
+
+// ExportFilter selects the listings of an export. Nil fields do not filter.
+type ExportFilter struct {
+	Status        *ListingStatus
+	CategoryID    *uuid.UUID
+	CreatedFrom   *time.Time // Inclusive
+	CreatedBefore *time.Time // Exclusive
+}
+
+// Filter validates the query and converts it to an ExportFilter.
+func (q ExportListingsQuery) Filter() (ExportFilter, error) {
+	var filter ExportFilter
+	if q.Status != "" {
+		status := ListingStatus(q.Status)
+		switch status {
+		case StatusPendingApproval, StatusActive, StatusExpired, StatusRejected, StatusAdminRemoved, StatusDraft, StatusScheduled:
+			filter.Status = &status
+		default:
+			return filter, common.ErrBadRequest.WithDetails(fmt.Sprintf("Invalid status '%s'.", q.Status))
+		}
+	}
+	if q.CategoryID != "" {
+		id, err := uuid.Parse(q.CategoryID)
+		if err != nil {
+			return filter, common.ErrBadRequest.WithDetails("Invalid category_id format.")
+		}
+		filter.CategoryID = &id
+	}
+	if q.From != "" {
+		from, err := time.Parse("2006-01-02", q.From)
+		if err != nil {
+			return filter, common.ErrBadRequest.WithDetails("Invalid 'from' date. Use YYYY-MM-DD.")
+		}
+		filter.CreatedFrom = &from
+	}
+	if q.To != "" {
+		to, err := time.Parse("2006-01-02", q.To)
+		if err != nil {
+			return filter, common.ErrBadRequest.WithDetails("Invalid 'to' date. Use YYYY-MM-DD.")
+		}
+		before := to.AddDate(0, 0, 1)
+		filter.CreatedBefore = &before
+	}
+	if filter.CreatedFrom != nil && filter.CreatedBefore != nil && !filter.CreatedFrom.Before(*filter.CreatedBefore) {
+		return filter, common.ErrBadRequest.WithDetails("'from' must not be after 'to'.")
+	}
+	return filter, nil
+}
+
+// ExportListings passes every listing matching filter to fn, oldest first, one batch at a time,
+// so that an export never holds more than exportBatchSize listings in memory.
+// An error returned by fn stops the export and is returned as is.
+func (s *ServiceImplementation) ExportListings(ctx context.Context, filter ExportFilter, fn func([]Listing) error) error {
+	var after *Listing
+	total := 0
+	for {
+		batch, err := s.repo.FindForExport(ctx, filter, after, exportBatchSize)
+		if err != nil {
+			s.logger.Error("Failed to load listings for export", zap.Error(err), zap.Int("exported", total))
+			return common.ErrInternalServer.WithDetails("Could not export listings.")
+		}
+		if len(batch) == 0 {
+			break
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+		total += len(batch)
+		if len(batch) < exportBatchSize {
+			break
+		}
+		after = &batch[len(batch)-1]
+	}
+	s.logger.Info("Listings exported", zap.Int("count", total))
+	return nil
+}
+
+// listingExportWriter renders a listing export in one format.
+type listingExportWriter interface {
+	contentType() string
+	fileExtension() string
+	begin() error
+	write(listings []Listing) error
+	end() error
+}
+
+func newListingExportWriter(format string, w io.Writer, imageURLs *filestorage.ImageURLBuilder) (listingExportWriter, bool) {
+	switch strings.ToLower(format) {
+	case "", "csv":
+		return &csvExportWriter{w: csv.NewWriter(w)}, true
+	case "json":
+		return &jsonExportWriter{w: w, imageURLs: imageURLs}, true
+	default:
+		return nil, false
+	}
+}
+
+// exportCSVHeader lists the columns of a CSV export. Category details are flattened; images are left out.
+var exportCSVHeader = []string{
+	"id", "title", "description", "status", "category", "sub_category", "user_id", "user_email",
+	"contact_name", "contact_email", "contact_phone", "address_line1", "address_line2", "city", "state", "zip_code",
+	"latitude", "longitude", "price", "price_currency", "price_period", "is_admin_approved",
+	"expires_at", "created_at", "updated_at",
+}
+
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (e *csvExportWriter) contentType() string   { return "text/csv; charset=utf-8" }
+func (e *csvExportWriter) fileExtension() string { return "csv" }
+
+func (e *csvExportWriter) begin() error {
+	if err := e.w.Write(exportCSVHeader); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvExportWriter) write(listings []Listing) error {
+	for i := range listings {
+		if err := e.w.Write(exportCSVRecord(&listings[i])); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvExportWriter) end() error { return nil }
+
+func exportCSVRecord(l *Listing) []string {
+	str := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	num := func(f *float64) string {
+		if f == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*f, 'f', -1, 64)
+	}
+	var subCategory, userEmail, pricePeriod string
+	if l.SubCategory != nil {
+		subCategory = l.SubCategory.Slug
+	}
+	if l.User != nil {
+		userEmail = str(l.User.Email)
+	}
+	if l.PricePeriod != nil {
+		pricePeriod = string(*l.PricePeriod)
+	}
+	return []string{
+		l.ID.String(), l.Title, l.Description, string(l.Status), l.Category.Slug, subCategory, l.UserID.String(), userEmail,
+		str(l.ContactName), str(l.ContactEmail), str(l.ContactPhone), str(l.AddressLine1), str(l.AddressLine2), str(l.City), str(l.State), str(l.ZipCode),
+		num(l.Latitude), num(l.Longitude), num(l.PriceAmount), str(l.PriceCurrency), pricePeriod, strconv.FormatBool(l.IsAdminApproved),
+		l.ExpiresAt.UTC().Format(time.RFC3339), l.CreatedAt.UTC().Format(time.RFC3339), l.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// jsonExportWriter writes a JSON array of the listings as admins see them in the API.
+type jsonExportWriter struct {
+	w         io.Writer
+	imageURLs *filestorage.ImageURLBuilder
+	written   int
+}
+
+func (e *jsonExportWriter) contentType() string   { return "application/json; charset=utf-8" }
+func (e *jsonExportWriter) fileExtension() string { return "json" }
+
+func (e *jsonExportWriter) begin() error {
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+func (e *jsonExportWriter) write(listings []Listing) error {
+	for i := range listings {
+		encoded, err := json.Marshal(ToOwnerListingResponse(&listings[i], e.imageURLs))
+		if err != nil {
+			return err
+		}
+		separator := ",\n"
+		if e.written == 0 {
+			separator = "\n"
+		}
+		if _, err := io.WriteString(e.w, separator); err != nil {
+			return err
+		}
+		if _, err := e.w.Write(encoded); err != nil {
+			return err
+		}
+		e.written++
+	}
+	return nil
+}
+
+func (e *jsonExportWriter) end() error {
+	_, err := io.WriteString(e.w, "\n]\n")
+	return err
+}
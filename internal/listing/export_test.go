@@ -0,0 +1,201 @@
+package listing
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/filestorage"
+	"seattle_info_backend/internal/user"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestExportListingsQueryFilter(t *testing.T) {
+	categoryID := uuid.New()
+	filter, err := ExportListingsQuery{Status: "active", CategoryID: categoryID.String(), From: "2024-03-01", To: "2024-03-31"}.Filter()
+	require.NoError(t, err)
+	assert.Equal(t, StatusActive, *filter.Status)
+	assert.Equal(t, categoryID, *filter.CategoryID)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), *filter.CreatedFrom)
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), *filter.CreatedBefore, "'to' includes the whole day")
+
+	filter, err = ExportListingsQuery{}.Filter()
+	require.NoError(t, err)
+	assert.Equal(t, ExportFilter{}, filter)
+
+	for _, q := range []ExportListingsQuery{
+		{Status: "archived"},
+		{CategoryID: "events"},
+		{From: "03/01/2024"},
+		{From: "2024-03-02", To: "2024-03-01"},
+	} {
+		_, err := q.Filter()
+		assert.ErrorIs(t, err, common.ErrBadRequest, "%+v", q)
+	}
+}
+
+// exportRepository pages through in-memory listings; other Repository methods are not used by exports.
+type exportRepository struct {
+	Repository
+	listings []Listing
+	calls    int
+	failAt   int
+	delay    time.Duration // Per batch
+}
+
+func (r *exportRepository) FindForExport(_ context.Context, _ ExportFilter, after *Listing, limit int) ([]Listing, error) {
+	r.calls++
+	time.Sleep(r.delay)
+	if r.calls == r.failAt {
+		return nil, errors.New("connection reset")
+	}
+	start := 0
+	if after != nil {
+		for i := range r.listings {
+			if r.listings[i].ID == after.ID {
+				start = i + 1
+			}
+		}
+	}
+	end := start + limit
+	if end > len(r.listings) {
+		end = len(r.listings)
+	}
+	return r.listings[start:end], nil
+}
+
+func TestExportListingsBatches(t *testing.T) {
+	listings := make([]Listing, exportBatchSize*2+1)
+	for i := range listings {
+		listings[i].ID = uuid.New()
+	}
+	repo := &exportRepository{listings: listings}
+	svc := &ServiceImplementation{repo: repo, logger: zap.NewNop()}
+
+	var batchSizes []int
+	var seen []uuid.UUID
+	err := svc.ExportListings(context.Background(), ExportFilter{}, func(batch []Listing) error {
+		batchSizes = append(batchSizes, len(batch))
+		for _, l := range batch {
+			seen = append(seen, l.ID)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{exportBatchSize, exportBatchSize, 1}, batchSizes)
+	assert.Len(t, seen, len(listings))
+	assert.Equal(t, listings[len(listings)-1].ID, seen[len(seen)-1])
+
+	repo = &exportRepository{listings: listings, failAt: 2}
+	svc.repo = repo
+	err = svc.ExportListings(context.Background(), ExportFilter{}, func([]Listing) error { return nil })
+	assert.ErrorIs(t, err, common.ErrInternalServer)
+
+	stop := errors.New("client went away")
+	err = svc.ExportListings(context.Background(), ExportFilter{}, func([]Listing) error { return stop })
+	assert.ErrorIs(t, err, stop)
+}
+
+func TestListingExportWriters(t *testing.T) {
+	city := "Seattle"
+	price := 25.5
+	created := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	l := Listing{
+		Title:       "Bike, barely used",
+		Description: "Line one\nline two",
+		Status:      StatusActive,
+		City:        &city,
+		PriceAmount: &price,
+		ExpiresAt:   created.AddDate(0, 0, 30),
+	}
+	l.ID = uuid.New()
+	l.CreatedAt = created
+	l.Category.Slug = "for-sale"
+	l.User = &user.User{} // Always preloaded by FindForExport
+	imageURLs := filestorage.NewImageURLBuilder(&config.Config{})
+
+	buf := new(bytes.Buffer)
+	w, ok := newListingExportWriter("", buf, imageURLs)
+	require.True(t, ok, "CSV is the default format")
+	require.NoError(t, w.begin())
+	require.NoError(t, w.write([]Listing{l, l}))
+	require.NoError(t, w.end())
+	records, err := csv.NewReader(buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, exportCSVHeader, records[0])
+	record := make(map[string]string)
+	for i, column := range records[0] {
+		record[column] = records[1][i]
+	}
+	assert.Equal(t, "Bike, barely used", record["title"])
+	assert.Equal(t, "Line one\nline two", record["description"])
+	assert.Equal(t, "for-sale", record["category"])
+	assert.Equal(t, "Seattle", record["city"])
+	assert.Equal(t, "25.5", record["price"])
+	assert.Equal(t, "", record["sub_category"])
+	assert.Equal(t, "2024-03-01T10:00:00Z", record["created_at"])
+
+	for _, batches := range [][][]Listing{nil, {{l}, {l, l}}} {
+		buf.Reset()
+		w, ok = newListingExportWriter("JSON", buf, imageURLs)
+		require.True(t, ok)
+		require.NoError(t, w.begin())
+		for _, batch := range batches {
+			require.NoError(t, w.write(batch))
+		}
+		require.NoError(t, w.end())
+		var decoded []ListingResponse
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded), buf.String())
+		if batches == nil {
+			assert.Empty(t, decoded)
+			continue
+		}
+		require.Len(t, decoded, 3)
+		assert.Equal(t, l.ID, decoded[2].ID)
+	}
+
+	_, ok = newListingExportWriter("xml", buf, imageURLs)
+	assert.False(t, ok)
+}
+
+func TestAdminExportListingsOutlivesWriteTimeout(t *testing.T) {
+	listings := make([]Listing, exportBatchSize*2+1)
+	for i := range listings {
+		listings[i].ID = uuid.New()
+		listings[i].User = &user.User{}
+	}
+	svc := &ServiceImplementation{repo: &exportRepository{listings: listings, delay: 100 * time.Millisecond}, logger: zap.NewNop()}
+	h := &Handler{service: svc, logger: zap.NewNop(), imageURLs: filestorage.NewImageURLBuilder(&config.Config{})}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/export", h.adminExportListings)
+	server := httptest.NewUnstartedServer(router)
+	server.Config.WriteTimeout = 150 * time.Millisecond // The export takes about 300ms
+	server.Start()
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/export?format=csv")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err, "the export must not be cut off")
+	rows, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, rows, len(listings)+1, "header and every listing")
+}
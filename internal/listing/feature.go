@@ -0,0 +1,142 @@
+// File: internal/listing/feature.go
+package listing
+
+import (
+	"context"
+	"time"
+
+	"seattle_info_backend/internal/audit"
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// featuredFirstOrder sorts listings that are currently featured ahead of the others.
+// It does not rely on the expiry job having cleared featured_until.
+const featuredFirstOrder = "(listings.featured_until IS NOT NULL AND listings.featured_until > NOW()) DESC"
+
+// IsFeatured reports whether the listing is featured at now.
+func (l *Listing) IsFeatured(now time.Time) bool {
+	return l.FeaturedUntil != nil && l.FeaturedUntil.After(now)
+}
+
+// featuredUntilAfter returns the end of a feature period of days bought at now. A listing that is
+// still featured is extended from its current end, so buying again never shortens the period.
+func featuredUntilAfter(l *Listing, days int, now time.Time) time.Time {
+	start := now
+	if l.IsFeatured(now) {
+		start = *l.FeaturedUntil
+	}
+	return start.AddDate(0, 0, days)
+}
+
+// FeatureListing features an active listing for the given number of days. actorID is the admin who did
+// it, or nil for a partner; note is recorded in the audit log with the change.
+func (s *ServiceImplementation) FeatureListing(ctx context.Context, id uuid.UUID, days int, actorID *uuid.UUID, note *string) (*Listing, error) {
+	listing, err := s.repo.FindByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if listing.Status != StatusActive {
+		return nil, common.ErrConflict.WithDetails("Only active listings can be featured.")
+	}
+
+	previous := listing.FeaturedUntil
+	featuredUntil := featuredUntilAfter(listing, days, time.Now())
+	if err := s.repo.SetFeaturedUntil(ctx, id, &featuredUntil); err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to feature listing", zap.Error(err), zap.String("listingID", id.String()))
+		return nil, common.ErrInternalServer.WithDetails("Failed to feature listing.")
+	}
+	listing.FeaturedUntil = &featuredUntil
+
+	s.recordFeatureChange(ctx, audit.ActionListingFeature, listing, previous, actorID, note)
+	s.logger.Info("Listing featured", zap.String("listingID", id.String()), zap.Int("days", days), zap.Time("featuredUntil", featuredUntil))
+	return listing, nil
+}
+
+// UnfeatureListing ends a listing's feature period early.
+func (s *ServiceImplementation) UnfeatureListing(ctx context.Context, id uuid.UUID, adminID uuid.UUID) (*Listing, error) {
+	listing, err := s.repo.FindByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if listing.FeaturedUntil == nil {
+		return listing, nil
+	}
+
+	previous := listing.FeaturedUntil
+	if err := s.repo.SetFeaturedUntil(ctx, id, nil); err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to unfeature listing", zap.Error(err), zap.String("listingID", id.String()))
+		return nil, common.ErrInternalServer.WithDetails("Failed to unfeature listing.")
+	}
+	listing.FeaturedUntil = nil
+
+	s.recordFeatureChange(ctx, audit.ActionListingUnfeature, listing, previous, &adminID, nil)
+	s.logger.Info("Listing unfeatured", zap.String("listingID", id.String()), zap.String("adminID", adminID.String()))
+	return listing, nil
+}
+
+// RevokeFeature takes back days of a listing's feature period, e.g. when the purchase is refunded.
+// The period ends now if fewer days than that are left.
+func (s *ServiceImplementation) RevokeFeature(ctx context.Context, id uuid.UUID, days int, note *string) (*Listing, error) {
+	listing, err := s.repo.FindByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !listing.IsFeatured(now) {
+		return listing, nil
+	}
+
+	previous := listing.FeaturedUntil
+	var featuredUntil *time.Time
+	if shortened := previous.AddDate(0, 0, -days); shortened.After(now) {
+		featuredUntil = &shortened
+	}
+	if err := s.repo.SetFeaturedUntil(ctx, id, featuredUntil); err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to revoke listing feature days", zap.Error(err), zap.String("listingID", id.String()))
+		return nil, common.ErrInternalServer.WithDetails("Failed to unfeature listing.")
+	}
+	listing.FeaturedUntil = featuredUntil
+
+	s.recordFeatureChange(ctx, audit.ActionListingUnfeature, listing, previous, nil, note)
+	s.logger.Info("Listing feature days revoked", zap.String("listingID", id.String()), zap.Int("days", days))
+	return listing, nil
+}
+
+// ExpireFeaturedListings clears the feature period of listings whose featured_until has passed.
+func (s *ServiceImplementation) ExpireFeaturedListings(ctx context.Context) (int, error) {
+	cleared, err := s.repo.ClearExpiredFeatured(ctx, time.Now())
+	if err != nil {
+		s.logger.Error("Failed to expire featured listings", zap.Error(err))
+		return 0, err
+	}
+	s.logger.Info("Featured listings expiry completed", zap.Int64("unfeatured_count", cleared))
+	return int(cleared), nil
+}
+
+// recordFeatureChange adds a change of featured_until to the audit log.
+func (s *ServiceImplementation) recordFeatureChange(ctx context.Context, action string, listing *Listing, previous *time.Time, actorID *uuid.UUID, note *string) {
+	if s.auditService == nil {
+		return
+	}
+	// Record logs its own failures; the change itself has already been saved.
+	_ = s.auditService.Record(ctx, audit.Event{
+		ActorID:    actorID,
+		Action:     action,
+		EntityType: audit.EntityListing,
+		EntityID:   listing.ID.String(),
+		Changes:    map[string]audit.FieldChange{"featured_until": {From: previous, To: listing.FeaturedUntil}},
+		Note:       note,
+	})
+}
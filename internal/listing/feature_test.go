@@ -0,0 +1,29 @@
+package listing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeaturedUntilAfter(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	inTwoDays := now.AddDate(0, 0, 2)
+	yesterday := now.AddDate(0, 0, -1)
+
+	assert.Equal(t, now.AddDate(0, 0, 7), featuredUntilAfter(&Listing{}, 7, now))
+	assert.Equal(t, inTwoDays.AddDate(0, 0, 7), featuredUntilAfter(&Listing{FeaturedUntil: &inTwoDays}, 7, now),
+		"a listing that is still featured is extended from the end of its current period")
+	assert.Equal(t, now.AddDate(0, 0, 7), featuredUntilAfter(&Listing{FeaturedUntil: &yesterday}, 7, now),
+		"an ended period is not extended")
+}
+
+func TestListingIsFeatured(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+
+	assert.False(t, (&Listing{}).IsFeatured(now))
+	assert.True(t, (&Listing{FeaturedUntil: &later}).IsFeatured(now))
+	assert.False(t, (&Listing{FeaturedUntil: &now}).IsFeatured(now))
+}
@@ -0,0 +1,122 @@
+// File: internal/listing/feed.go
+package listing
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	feedTitle             = "Seattle Info - Recent Listings"
+	feedDescription       = "The newest community listings on Seattle Info."
+	feedItemSummaryLength = 500
+	atomNamespace         = "http://www.w3.org/2005/Atom"
+)
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language,omitempty"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	AtomLink      atomLink  `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+// atomLink is the rel="self" link RSS validators expect on syndicated feeds.
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	Description string  `xml:"description"`
+	Category    string  `xml:"category,omitempty"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+// feedLastModified is the newest creation or update time among the listings; zero when there are none.
+func feedLastModified(listings []Listing) time.Time {
+	var latest time.Time
+	for i := range listings {
+		if listings[i].UpdatedAt.After(latest) {
+			latest = listings[i].UpdatedAt
+		}
+		if listings[i].CreatedAt.After(latest) {
+			latest = listings[i].CreatedAt
+		}
+	}
+	return latest
+}
+
+// renderRecentListingsFeed renders listings as an RSS 2.0 feed. selfURL is the feed's own URL and
+// listingURL maps a listing to the page items link to.
+func renderRecentListingsFeed(listings []Listing, selfURL, siteURL, language string, listingURL func(*Listing) string) ([]byte, error) {
+	channel := rssChannel{
+		Title:       feedTitle,
+		Link:        siteURL,
+		Description: feedDescription,
+		Language:    language,
+		AtomLink:    atomLink{Href: selfURL, Rel: "self", Type: "application/rss+xml"},
+		Items:       make([]rssItem, 0, len(listings)),
+	}
+	if lastModified := feedLastModified(listings); !lastModified.IsZero() {
+		channel.LastBuildDate = lastModified.UTC().Format(time.RFC1123Z)
+	}
+
+	for i := range listings {
+		l := &listings[i]
+		link := listingURL(l)
+		channel.Items = append(channel.Items, rssItem{
+			Title:       l.Title,
+			Link:        link,
+			GUID:        rssGUID{Value: link, IsPermaLink: true},
+			Description: truncateRunes(strings.TrimSpace(l.Description), feedItemSummaryLength),
+			Category:    l.Category.Name,
+			PubDate:     l.CreatedAt.UTC().Format(time.RFC1123Z),
+		})
+	}
+
+	body, err := xml.MarshalIndent(rssFeed{Version: "2.0", AtomNS: atomNamespace, Channel: channel}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render listings feed: %w", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// requestBaseURL returns the scheme and host the client used, honouring X-Forwarded-Proto from a proxy.
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https") {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+func truncateRunes(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	runes := []rune(s)
+	return strings.TrimSpace(string(runes[:max])) + "…"
+}
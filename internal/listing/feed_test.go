@@ -0,0 +1,54 @@
+package listing
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/category"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderRecentListingsFeed(t *testing.T) {
+	created := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	l := Listing{Title: "Bike for sale & more", Description: "Barely used <road> bike", Category: category.Category{Name: "For Sale"}}
+	l.ID = uuid.New()
+	l.CreatedAt = created
+	l.UpdatedAt = created.Add(time.Hour)
+
+	body, err := renderRecentListingsFeed([]Listing{l}, "https://api.example.com/api/v1/feeds/recent.xml", "https://api.example.com", "en",
+		func(l *Listing) string { return "https://api.example.com/api/v1/listings/" + l.ID.String() })
+	require.NoError(t, err)
+
+	var feed rssFeed
+	require.NoError(t, xml.Unmarshal(body, &feed))
+	require.Len(t, feed.Channel.Items, 1)
+	item := feed.Channel.Items[0]
+	assert.Equal(t, "Bike for sale & more", item.Title)
+	assert.Equal(t, "Barely used <road> bike", item.Description)
+	assert.Equal(t, "https://api.example.com/api/v1/listings/"+l.ID.String(), item.Link)
+	assert.Equal(t, "For Sale", item.Category)
+	assert.Equal(t, "Fri, 01 Mar 2024 09:30:00 +0000", item.PubDate)
+	assert.Equal(t, "Fri, 01 Mar 2024 10:30:00 +0000", feed.Channel.LastBuildDate)
+	assert.Contains(t, string(body), `xmlns:atom="http://www.w3.org/2005/Atom"`)
+}
+
+func TestNotModified(t *testing.T) {
+	lastModified := time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+	request := func(header, value string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/feeds/recent.xml", nil)
+		r.Header.Set(header, value)
+		return r
+	}
+
+	assert.True(t, notModified(request("If-None-Match", `"abc"`), `"abc"`, lastModified))
+	assert.True(t, notModified(request("If-None-Match", `"x", W/"abc"`), `"abc"`, lastModified))
+	assert.False(t, notModified(request("If-None-Match", `"old"`), `"abc"`, lastModified))
+	assert.True(t, notModified(request("If-Modified-Since", lastModified.Format(http.TimeFormat)), `"abc"`, lastModified))
+	assert.False(t, notModified(request("If-Modified-Since", lastModified.Add(-time.Minute).Format(http.TimeFormat)), `"abc"`, lastModified))
+}
@@ -3,16 +3,23 @@ package listing
 
 import (
 	"encoding/json"
-	"errors" // Go standard errors
 
 	// "mime/multipart" // Removed as direct usage isn't present; type is resolved via service interface
 	// "strconv" // Removed
 	// "seattle_info_backend/internal/auth" // REMOVED
 	"seattle_info_backend/internal/common"
 	"seattle_info_backend/internal/config" // Added for ImagePublicBaseURL
+	"seattle_info_backend/internal/filestorage"
 
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"mime"
+	"net/http"
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 
@@ -45,6 +52,9 @@ type Handler struct {
 	cfg     *config.Config // Added to access ImagePublicBaseURL
 	// tokenService auth.TokenService // REMOVED
 	validator *validator.Validate
+	imageURLs *filestorage.ImageURLBuilder
+
+	uploadLimits common.MultipartLimits // Of the multipart requests that create and update listings
 }
 
 // NewHandler creates a new listing handler.
@@ -54,25 +64,49 @@ func NewHandler(service Service, logger *zap.Logger, cfg *config.Config) *Handle
 		logger:  logger,
 		cfg:     cfg, // Added
 		// tokenService: tokenService, // REMOVED
-		validator: validator.New(),
+		validator: common.NewValidator(),
+		imageURLs: filestorage.NewImageURLBuilder(cfg),
+
+		uploadLimits: common.MultipartLimits{MaxBytes: cfg.MaxUploadBodyBytes, MaxParts: cfg.MaxMultipartParts, MaxMemory: cfg.MultipartMemoryBytes},
 	}
 }
 
 // RegisterRoutes sets up the routes for listing operations.
-func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMW gin.HandlerFunc, adminRoleMW gin.HandlerFunc) { // Pass middlewares
+// browseMW guards the public routes, authenticating callers who send a token and trimming the responses of those
+// who do not; anonymousSessionMW identifies the anonymous session of listing page viewers.
+// captchaMW guards creating and publishing listings.
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMW, browseMW, anonymousSessionMW, adminRoleMW, captchaMW gin.HandlerFunc) { // Pass middlewares
 	listingGroup := router.Group("/listings")
 	{
-		listingGroup.GET("", h.searchListings)
-		listingGroup.GET("/:id", h.getListingByID)
-		listingGroup.GET("/recent", h.getRecentListings) // New Public Route
+		listingGroup.GET("", browseMW, h.searchListings)
+		listingGroup.GET("/map-clusters", browseMW, h.getMapClusters)
+		listingGroup.GET("/:id", browseMW, anonymousSessionMW, h.getListingByID)
+		listingGroup.GET("/by-slug/:slug", browseMW, anonymousSessionMW, h.getListingBySlug)
+		listingGroup.GET("/:id/related", browseMW, h.getRelatedListings)
+		listingGroup.GET("/:id/og", h.getListingShareMetadata)
+		listingGroup.GET("/recent", browseMW, h.getRecentListings) // New Public Route
+		listingGroup.GET("/trending", browseMW, h.getTrendingListings)
+		listingGroup.GET("/events/calendar.ics", h.getEventsCalendar)
 
 		authedListingGroup := listingGroup.Group("")
 		authedListingGroup.Use(authMW) // Apply general auth
 		{
-			authedListingGroup.POST("", h.createListing)
+			authedListingGroup.POST("", captchaMW, h.createListing)
 			authedListingGroup.PUT("/:id", h.updateListing)
+			authedListingGroup.PATCH("/:id", h.patchListing)
 			authedListingGroup.DELETE("/:id", h.deleteListing)
+			authedListingGroup.POST("/:id/publish", captchaMW, h.publishListing)
+			authedListingGroup.POST("/:id/contact-reveal", h.revealContact)
+			authedListingGroup.POST("/:id/appeal", h.appealTakedown)
+			authedListingGroup.POST("/:id/questions", captchaMW, h.askQuestion)
+			authedListingGroup.GET("/:id/analytics", h.getListingAnalytics)
+			authedListingGroup.GET("/:id/checkin-code", h.getCheckinCode)
+			authedListingGroup.POST("/:id/checkin-code", h.rotateCheckinCode)
+			authedListingGroup.POST("/:id/checkin", h.checkIn)
+			authedListingGroup.POST("/:id/background-check", h.submitBackgroundCheck)
+			authedListingGroup.GET("/:id/background-check", h.getBackgroundCheck)
 			authedListingGroup.GET("/my-listings", h.getMyListings) // New route for user's own listings
+			authedListingGroup.POST("/my-listings/bulk-update", h.bulkUpdateMyListings)
 		}
 
 		adminListingGroup := listingGroup.Group("/admin")
@@ -84,6 +118,8 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMW gin.HandlerFunc
 			adminListingGroup.POST("/:id/approve", h.adminApproveListing)
 		}
 	}
+
+	router.POST("/questions/:id/answer", authMW, h.answerQuestion)
 }
 
 func (h *Handler) createListing(c *gin.Context) {
@@ -93,10 +129,10 @@ func (h *Handler) createListing(c *gin.Context) {
 		return
 	}
 
-	// Set a max memory limit for the multipart form
-	if err := c.Request.ParseMultipartForm(50 << 20); err != nil { // 50MB
-		h.logger.Warn("Create listing: Failed to parse multipart form", zap.Error(err), zap.String("userID", userID.String()))
-		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid request format or files too large: "+err.Error()))
+	// Images beyond MULTIPART_MEMORY_BYTES are streamed to temporary files rather than held in memory
+	if apiErr := common.ParseMultipartForm(c, h.uploadLimits); apiErr != nil {
+		h.logger.Warn("Create listing: Failed to parse multipart form", zap.Error(apiErr), zap.String("userID", userID.String()))
+		common.RespondWithError(c, apiErr)
 		return
 	}
 
@@ -116,26 +152,21 @@ func (h *Handler) createListing(c *gin.Context) {
 			zap.String("userID", userID.String()),
 			zap.String("rawData", jsonData), // Log the bad data for debugging
 		)
-		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid JSON format in 'data' field: "+err.Error()))
+		common.RespondWithError(c, common.BindingError(err))
 		return
 	}
 
 	// --- Step 3: Manually validate the populated struct ---
 	if err := h.validator.Struct(req); err != nil { // Assuming h.validator exists
 		h.logger.Warn("Create listing: Validation failed", zap.Error(err), zap.String("userID", userID.String()))
-		var ve validator.ValidationErrors
-		if errors.As(err, &ve) {
-			// Use your existing validation error formatting helper
-			common.RespondWithError(c, common.NewValidationAPIError(common.FormatValidationErrors(ve)))
-			return
-		}
-		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid data: "+err.Error()))
+		common.RespondWithError(c, common.BindingError(err))
 		return
 	}
 
 	// --- Step 4: Access the uploaded files ---
 	form := c.Request.MultipartForm
 	images := form.File["images"] // "images" is the field name for file uploads
+	req.ImageTexts = ImageTexts{AltTexts: form.Value["image_alt_text"], Captions: form.Value["image_caption"]}
 
 	// --- (Your Original Request) Log the successfully parsed content ---
 	var fileInfo []string
@@ -158,7 +189,7 @@ func (h *Handler) createListing(c *gin.Context) {
 		return
 	}
 
-	common.RespondCreated(c, "Listing created successfully.", ToListingResponse(listing, true, h.cfg.ImagePublicBaseURL))
+	common.RespondCreated(c, "Listing created successfully.", ToOwnerListingResponse(listing, h.imageURLs))
 }
 
 func (h *Handler) getListingByID(c *gin.Context) {
@@ -180,18 +211,179 @@ func (h *Handler) getListingByID(c *gin.Context) {
 		common.RespondWithError(c, err)
 		return
 	}
-	isAuthenticatedForContact := authenticatedUserID != nil
-	common.RespondOK(c, "Listing retrieved successfully.", ToListingResponse(listing, isAuthenticatedForContact, h.cfg.ImagePublicBaseURL))
+	h.respondWithListing(c, listing, authenticatedUserID)
+}
+
+// getListingBySlug serves a listing by its slug, for readable web URLs. It responds like getListingByID.
+func (h *Handler) getListingBySlug(c *gin.Context) {
+	var authenticatedUserID *uuid.UUID
+	userIDFromCtx := common.GetUserIDFromContext(c)
+	if userIDFromCtx != uuid.Nil {
+		authenticatedUserID = &userIDFromCtx
+	}
+
+	listing, err := h.service.GetListingBySlug(c.Request.Context(), c.Param("slug"), authenticatedUserID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	h.respondWithListing(c, listing, authenticatedUserID)
+}
+
+// respondWithListing records a view of the listing and sends it, honouring conditional request headers.
+func (h *Handler) respondWithListing(c *gin.Context, listing *Listing, authenticatedUserID *uuid.UUID) {
+	h.service.RecordListingView(c.Request.Context(), listing, authenticatedUserID)
+	if sessionID := common.GetAnonymousSessionIDFromContext(c); authenticatedUserID == nil && sessionID != uuid.Nil {
+		h.service.RecordAnonymousView(c.Request.Context(), listing, sessionID)
+	}
+
+	// Contact details depend on the caller, so shared caches must not serve one caller's copy to another.
+	etag := listingETag(listing)
+	lastModified := listing.UpdatedAt.UTC().Truncate(time.Second)
+	c.Header("ETag", etag)
+	c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+	c.Header("Cache-Control", "private, no-cache")
+	c.Header("Vary", "Authorization")
+	if notModified(c.Request, etag, lastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	page, pageSize := questionPageParams(c)
+	questions, pagination, err := h.service.ListQuestions(c.Request.Context(), listing, authenticatedUserID, page, pageSize)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+
+	var resp ListingResponse
+	if authenticatedUserID != nil && *authenticatedUserID == listing.UserID {
+		resp = ToOwnerListingResponse(listing, h.imageURLs)
+	} else {
+		resp = ToListingResponse(listing, h.imageURLs)
+	}
+	resp.Questions = ToQuestionPage(questions, pagination)
+	common.RespondOK(c, "Listing retrieved successfully.", common.FormatHints(c, common.SparseFields(c, resp)))
+}
+
+// questionPageParams reads ?questions_page= and ?questions_page_size=, which page through the questions embedded in
+// a listing response.
+func questionPageParams(c *gin.Context) (page, pageSize int) {
+	page, err := strconv.Atoi(c.Query("questions_page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+	pageSize, err = strconv.Atoi(c.Query("questions_page_size"))
+	if err != nil || pageSize <= 0 {
+		pageSize = defaultQuestionPageSize
+	}
+	if pageSize > common.MaxPageSize {
+		pageSize = common.MaxPageSize
+	}
+	return page, pageSize
+}
+
+// getListingShareMetadata returns the Open Graph and Twitter card metadata of a listing, for rich link previews.
+func (h *Handler) getListingShareMetadata(c *gin.Context) {
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
+
+	var authenticatedUserID *uuid.UUID
+	userIDFromCtx := common.GetUserIDFromContext(c)
+	if userIDFromCtx != uuid.Nil {
+		authenticatedUserID = &userIDFromCtx
+	}
+
+	listing, err := h.service.GetListingByID(c.Request.Context(), listingID, authenticatedUserID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+
+	baseURL := requestBaseURL(c.Request)
+	webBaseURL := strings.TrimSuffix(h.cfg.WebBaseURL, "/")
+	if webBaseURL == "" {
+		webBaseURL = baseURL
+	}
+	var imageURL string
+	if images := listing.servedImages(); len(images) > 0 { // Images are loaded in sort order
+		imageURL = h.imageURLs.URL(images[0].ImagePath)
+		if strings.HasPrefix(imageURL, "/") {
+			imageURL = baseURL + imageURL // Link unfurlers need absolute URLs
+		}
+	}
+
+	if listing.Status == StatusActive {
+		maxAge := shareMaxAge
+		if h.imageURLs.SigningEnabled() && h.cfg.ImageURLTTL > 0 && h.cfg.ImageURLTTL < maxAge {
+			maxAge = h.cfg.ImageURLTTL // Cached previews must not outlive their signed image URLs
+		}
+		c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+	} else {
+		c.Header("Cache-Control", "private, no-cache") // Only the owner can see other listings
+	}
+	common.RespondOK(c, "Share metadata retrieved successfully.", toShareMetadata(listing, webBaseURL+"/listings/"+listing.Slug, imageURL))
+}
+
+// getRelatedListings returns active listings similar to the given one. ?limit caps the count (default 6, max 20).
+func (h *Handler) getRelatedListings(c *gin.Context) {
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
+	limit := DefaultRelatedListings
+	if raw := c.Query("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			common.RespondWithError(c, common.ErrBadRequest.WithDetails("limit must be a positive integer."))
+			return
+		}
+	}
+
+	var authenticatedUserID *uuid.UUID
+	userIDFromCtx := common.GetUserIDFromContext(c)
+	if userIDFromCtx != uuid.Nil {
+		authenticatedUserID = &userIDFromCtx
+	}
+
+	listings, err := h.service.GetRelatedListings(c.Request.Context(), listingID, authenticatedUserID, limit)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	listingResponses := make([]ListingResponse, len(listings))
+	for i := range listings {
+		listingResponses[i] = ToListingResponse(&listings[i], h.imageURLs)
+	}
+	common.RespondOK(c, "Related listings retrieved successfully.", common.FormatHints(c, common.SparseFields(c, listingResponses)))
 }
 
 func (h *Handler) searchListings(c *gin.Context) {
+	if rawIDs, ok := c.GetQuery("ids"); ok {
+		h.getListingsByIDs(c, rawIDs)
+		return
+	}
+
 	var query ListingSearchQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
 		h.logger.Warn("Search listings: Invalid query parameters", zap.Error(err))
-		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid query parameters: "+err.Error()))
+		common.RespondWithError(c, common.BindingError(err))
 		return
 	}
 	query.Page, query.PageSize = common.GetPaginationParams(c)
+	query.Attributes = c.QueryMap("attr")
+	query.AttributesMin = c.QueryMap("attr_min")
+	query.AttributesMax = c.QueryMap("attr_max")
+	includes, err := includesFromQuery(c)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	query.Includes = includes
 
 	var authenticatedUserID *uuid.UUID
 	userIDFromCtx := common.GetUserIDFromContext(c)
@@ -204,19 +396,82 @@ func (h *Handler) searchListings(c *gin.Context) {
 		common.RespondWithError(c, err)
 		return
 	}
+	h.service.RecordSearchImpressions(c.Request.Context(), listings, authenticatedUserID)
+	listingResponses := make([]ListingResponse, len(listings))
+	for i, l := range listings {
+		listingResponses[i] = ToListingResponse(&l, h.imageURLs)
+	}
+	common.RespondPaginated(c, "Listings retrieved successfully.", common.FormatHints(c, common.SparseFields(c, listingResponses)), pagination)
+}
+
+// getMapClusters serves GET /listings/map-clusters: the listings matching the search filters inside bbox,
+// aggregated into one pin per grid cell for the zoom level.
+func (h *Handler) getMapClusters(c *gin.Context) {
+	var query MapClusterQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Warn("Map clusters: Invalid query parameters", zap.Error(err))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	query.Attributes = c.QueryMap("attr")
+	query.AttributesMin = c.QueryMap("attr_min")
+	query.AttributesMax = c.QueryMap("attr_max")
+
+	clusters, err := h.service.GetMapClusters(c.Request.Context(), query)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Map clusters retrieved successfully.", clusters)
+}
+
+// getListingsByIDs serves GET /listings?ids=a,b,c. Other search parameters are ignored.
+func (h *Handler) getListingsByIDs(c *gin.Context, rawIDs string) {
+	ids, err := parseListingIDs(rawIDs)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+
+	var authenticatedUserID *uuid.UUID
+	userIDFromCtx := common.GetUserIDFromContext(c)
+	if userIDFromCtx != uuid.Nil {
+		authenticatedUserID = &userIDFromCtx
+	}
+
+	listings, err := h.service.GetListingsByIDs(c.Request.Context(), ids, authenticatedUserID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
 	listingResponses := make([]ListingResponse, len(listings))
-	isAuthenticatedForContact := authenticatedUserID != nil
 	for i, l := range listings {
-		listingResponses[i] = ToListingResponse(&l, isAuthenticatedForContact, h.cfg.ImagePublicBaseURL)
-		// If distance needs to be added from a gorm:"-" field:
-		// distanceVal, ok := c.Get(fmt.Sprintf("distance_listing_%s", l.ID.String())) // Example of how service might pass it
-		// if ok {
-		//     if distFloat, okFloat := distanceVal.(float64); okFloat {
-		//         listingResponses[i].Distance = &distFloat
-		//     }
-		// }
+		listingResponses[i] = ToListingResponse(&l, h.imageURLs)
+	}
+	common.RespondOK(c, "Listings retrieved successfully.", common.FormatHints(c, common.SparseFields(c, listingResponses)))
+}
+
+// parseListingIDs parses a comma-separated list of listing UUIDs.
+func parseListingIDs(raw string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := uuid.Parse(part)
+		if err != nil {
+			return nil, common.ErrBadRequest.WithDetails("Invalid listing ID format in ids: " + part)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, common.ErrBadRequest.WithDetails("ids must contain at least one listing ID.")
+	}
+	if len(ids) > MaxBatchListingIDs {
+		return nil, common.ErrBadRequest.WithDetails(fmt.Sprintf("At most %d listing IDs can be requested at once.", MaxBatchListingIDs))
 	}
-	common.RespondPaginated(c, "Listings retrieved successfully.", listingResponses, pagination)
+	return ids, nil
 }
 
 func (h *Handler) getMyListings(c *gin.Context) {
@@ -231,12 +486,18 @@ func (h *Handler) getMyListings(c *gin.Context) {
 	// Bind query parameters like status, category_slug, and include_expired
 	if err := c.ShouldBindQuery(&query); err != nil {
 		h.logger.Warn("Get my listings: Invalid query parameters", zap.Error(err), zap.String("userID", userID.String()))
-		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid query parameters: "+err.Error()))
+		common.RespondWithError(c, common.BindingError(err))
 		return
 	}
 
 	// Populate pagination parameters
 	query.Page, query.PageSize = common.GetPaginationParams(c)
+	includes, err := includesFromQuery(c)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	query.Includes = includes
 
 	listings, pagination, err := h.service.GetUserListings(c.Request.Context(), userID, query)
 	if err != nil {
@@ -248,10 +509,10 @@ func (h *Handler) getMyListings(c *gin.Context) {
 	listingResponses := make([]ListingResponse, len(listings))
 	for i, l := range listings {
 		// For "my listings", the user is authenticated and is the owner, so they should see full details.
-		listingResponses[i] = ToListingResponse(&l, true, h.cfg.ImagePublicBaseURL)
+		listingResponses[i] = ToOwnerListingResponse(&l, h.imageURLs)
 	}
 
-	common.RespondPaginated(c, "Successfully retrieved your listings.", listingResponses, pagination)
+	common.RespondPaginated(c, "Successfully retrieved your listings.", common.FormatHints(c, common.SparseFields(c, listingResponses)), pagination)
 }
 
 func (h *Handler) updateListing(c *gin.Context) {
@@ -266,9 +527,9 @@ func (h *Handler) updateListing(c *gin.Context) {
 		return
 	}
 
-	if err := c.Request.ParseMultipartForm(50 << 20); err != nil { // Same memory limit as create
-		h.logger.Warn("Update listing: Failed to parse multipart form", zap.Error(err), zap.String("listingID", listingID.String()))
-		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid request format or files too large: "+err.Error()))
+	if apiErr := common.ParseMultipartForm(c, h.uploadLimits); apiErr != nil { // Same limits as create
+		h.logger.Warn("Update listing: Failed to parse multipart form", zap.Error(apiErr), zap.String("listingID", listingID.String()))
+		common.RespondWithError(c, apiErr)
 		return
 	}
 
@@ -279,12 +540,7 @@ func (h *Handler) updateListing(c *gin.Context) {
 	// If `RemoveImageIDs` is sent like `remove_image_ids=id1&remove_image_ids=id2`, Gin can bind it to a slice.
 	if err := c.ShouldBindWith(&req, binding.FormMultipart); err != nil {
 		h.logger.Warn("Update listing: Invalid form data", zap.Error(err), zap.String("listingID", listingID.String()))
-		var ve validator.ValidationErrors
-		if errors.As(err, &ve) {
-			common.RespondWithError(c, common.NewValidationAPIError(common.FormatValidationErrors(ve)))
-			return
-		}
-		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid form data: "+err.Error()))
+		common.RespondWithError(c, common.BindingError(err))
 		return
 	}
 
@@ -307,6 +563,8 @@ func (h *Handler) updateListing(c *gin.Context) {
 	}
 	// After this, req.Latitude and req.Longitude are populated correctly for both JSON and multipart/form-data
 
+	req.IfMatch = c.GetHeader("If-Match")
+
 	// Access newly uploaded files
 	form := c.Request.MultipartForm
 	newImages := form.File["images"] // Field name for new images
@@ -318,7 +576,63 @@ func (h *Handler) updateListing(c *gin.Context) {
 		common.RespondWithError(c, err)
 		return
 	}
-	common.RespondOK(c, "Listing updated successfully.", ToListingResponse(listing, true, h.cfg.ImagePublicBaseURL))
+	c.Header("ETag", listingETag(listing))
+	common.RespondOK(c, "Listing updated successfully.", ToOwnerListingResponse(listing, h.imageURLs))
+}
+
+// patchListing applies a JSON Merge Patch (RFC 7386). Unlike PUT, a null member clears the field.
+func (h *Handler) patchListing(c *gin.Context) {
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrInternalServer.WithDetails("User ID not found."))
+		return
+	}
+	if ct := c.ContentType(); ct != "application/merge-patch+json" && ct != "application/json" {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Content-Type must be application/merge-patch+json."))
+		return
+	}
+
+	var patch map[string]interface{}
+	if err := json.NewDecoder(c.Request.Body).Decode(&patch); err != nil || patch == nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Request body must be a JSON object."))
+		return
+	}
+
+	listing, err := h.service.PatchListing(c.Request.Context(), listingID, userID, patch, c.GetHeader("If-Match"))
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	c.Header("ETag", listingETag(listing))
+	common.RespondOK(c, "Listing updated successfully.", ToOwnerListingResponse(listing, h.imageURLs))
+}
+
+// bulkUpdateMyListings sets shared fields, such as the contact phone or address, on the caller's active listings.
+func (h *Handler) bulkUpdateMyListings(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrInternalServer.WithDetails("User ID not found."))
+		return
+	}
+
+	var req BulkUpdateListingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Bulk update listings: Invalid request body", zap.Error(err), zap.String("userID", userID.String()))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	results, err := h.service.BulkUpdateMyListings(c.Request.Context(), userID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Listings updated successfully.", results)
 }
 
 func (h *Handler) deleteListing(c *gin.Context) {
@@ -339,88 +653,910 @@ func (h *Handler) deleteListing(c *gin.Context) {
 	common.RespondNoContent(c)
 }
 
-// --- Admin Handlers ---
-func (h *Handler) adminGetListingByID(c *gin.Context) {
+func (h *Handler) publishListing(c *gin.Context) {
 	listingID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
 		return
 	}
-	listing, err := h.service.AdminGetListingByID(c.Request.Context(), listingID)
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrInternalServer.WithDetails("User ID not found."))
+		return
+	}
+	listing, err := h.service.PublishListing(c.Request.Context(), listingID, userID)
 	if err != nil {
 		common.RespondWithError(c, err)
 		return
 	}
-	common.RespondOK(c, "Admin: Listing retrieved successfully.", ToListingResponse(listing, true, h.cfg.ImagePublicBaseURL))
+	common.RespondOK(c, "Listing published successfully.", ToOwnerListingResponse(listing, h.imageURLs))
 }
 
-func (h *Handler) adminUpdateListingStatus(c *gin.Context) {
+// revealContact returns a listing's contact details, which listing responses leave out, to a signed-in user.
+func (h *Handler) revealContact(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized)
+		return
+	}
 	listingID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
 		return
 	}
-	var req AdminUpdateListingStatusRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Warn("Admin update listing status: Invalid request body", zap.Error(err), zap.String("listingID", listingID.String()))
-		var ve validator.ValidationErrors
-		if errors.As(err, &ve) {
-			common.RespondWithError(c, common.NewValidationAPIError(common.FormatValidationErrors(ve)))
-			return
-		}
-		common.RespondWithError(c, common.ErrBadRequest.WithDetails(err.Error()))
-		return
-	}
-	listing, err := h.service.AdminUpdateListingStatus(c.Request.Context(), listingID, req.Status, req.AdminNotes)
+
+	contact, err := h.service.RevealContact(c.Request.Context(), listingID, userID)
 	if err != nil {
 		common.RespondWithError(c, err)
 		return
 	}
-	common.RespondOK(c, "Admin: Listing status updated successfully.", ToListingResponse(listing, true, h.cfg.ImagePublicBaseURL))
+	common.RespondOK(c, "Contact details retrieved successfully.", contact)
 }
 
-func (h *Handler) adminApproveListing(c *gin.Context) {
+// getListingAnalytics returns the owner's daily analytics of a listing for the last 30 days.
+func (h *Handler) getListingAnalytics(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized)
+		return
+	}
 	listingID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
 		return
 	}
-	listing, err := h.service.AdminApproveListing(c.Request.Context(), listingID)
+
+	analytics, err := h.service.GetListingAnalytics(c.Request.Context(), listingID, userID)
 	if err != nil {
 		common.RespondWithError(c, err)
 		return
 	}
-	common.RespondOK(c, "Admin: Listing approved successfully.", ToListingResponse(listing, true, h.cfg.ImagePublicBaseURL))
+	common.RespondOK(c, "Listing analytics retrieved successfully.", analytics)
 }
 
-func (h *Handler) getRecentListings(c *gin.Context) {
-	page, pageSize := common.GetPaginationParams(c)
+// getCheckinCode returns the check-in code of the organizer's event, for showing at the door as a QR code.
+func (h *Handler) getCheckinCode(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized)
+		return
+	}
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
 
-	listings, pagination, err := h.service.GetRecentListings(c.Request.Context(), page, pageSize)
+	code, err := h.service.GetCheckinCode(c.Request.Context(), listingID, userID)
 	if err != nil {
-		common.RespondWithError(c, err) // Service layer should return appropriate common.APIError
+		common.RespondWithError(c, err)
 		return
 	}
-	// For public recent listings, contact info is hidden by the service layer (ToListingResponse called with false)
-	common.RespondPaginated(c, "Recent listings retrieved successfully.", listings, pagination)
+	common.RespondOK(c, "Check-in code retrieved successfully.", code)
 }
 
-// RegisterEventRoutes sets up the routes for event specific listing operations.
-func (h *Handler) RegisterEventRoutes(router *gin.RouterGroup) {
-	// The router group passed here is expected to be something like /api/v1/events
-	router.GET("/upcoming", h.getUpcomingEvents)
+// rotateCheckinCode replaces the check-in code of the organizer's event.
+func (h *Handler) rotateCheckinCode(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized)
+		return
+	}
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
+
+	code, err := h.service.RotateCheckinCode(c.Request.Context(), listingID, userID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Check-in code rotated successfully.", code)
 }
 
-func (h *Handler) getUpcomingEvents(c *gin.Context) {
-	page, pageSize := common.GetPaginationParams(c)
-	// Default page_size for events as per issue is 10.
-	// common.GetPaginationParams uses 10 if 'page_size' is not provided or invalid, so this should be fine.
+// checkIn records the caller's attendance at today's occurrence of an event from its scanned check-in code.
+func (h *Handler) checkIn(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized)
+		return
+	}
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
+	var req CheckinRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
 
-	events, pagination, err := h.service.GetUpcomingEvents(c.Request.Context(), page, pageSize)
+	checkin, err := h.service.CheckIn(c.Request.Context(), listingID, userID, req.Code)
 	if err != nil {
-		common.RespondWithError(c, err) // Service layer should return appropriate common.APIError
+		common.RespondWithError(c, err)
 		return
 	}
-	// Contact info is hidden by the service layer (ToListingResponse called with false)
-	common.RespondPaginated(c, "Upcoming events retrieved successfully.", events, pagination)
+	if checkin.AlreadyCheckedIn {
+		common.RespondOK(c, "You have already checked in to this event today.", checkin)
+		return
+	}
+	common.RespondOK(c, "Checked in successfully.", checkin)
+}
+
+// --- Admin Handlers ---
+func (h *Handler) adminGetListingByID(c *gin.Context) {
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
+	listing, err := h.service.AdminGetListingByID(c.Request.Context(), listingID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Admin: Listing retrieved successfully.", common.FormatHints(c, common.SparseFields(c, ToOwnerListingResponse(listing, h.imageURLs))))
+}
+
+func (h *Handler) adminUpdateListingStatus(c *gin.Context) {
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
+	var req AdminUpdateListingStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Admin update listing status: Invalid request body", zap.Error(err), zap.String("listingID", listingID.String()))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	listing, err := h.service.AdminUpdateListingStatus(c.Request.Context(), listingID, req.Status, req.AdminNotes, req.RejectionReason)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Admin: Listing status updated successfully.", ToOwnerListingResponse(listing, h.imageURLs))
+}
+
+func (h *Handler) adminApproveListing(c *gin.Context) {
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
+	listing, err := h.service.AdminApproveListing(c.Request.Context(), listingID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Admin: Listing approved successfully.", ToOwnerListingResponse(listing, h.imageURLs))
+}
+
+// adminCheckImageConsistency reports listing images whose file is gone and stored files no listing refers to.
+// It is a dry run: nothing is removed, which the scheduled image consistency job does.
+func (h *Handler) adminCheckImageConsistency(c *gin.Context) {
+	report, err := h.service.CheckImageConsistency(c.Request.Context(), true)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Consistency check completed (dry run).", report)
+}
+
+// adminCheckRentBackfill reports which housing rents the rent backfill job can read and which need fixing by hand.
+// It is a dry run: nothing is stored, which the rent backfill job does.
+func (h *Handler) adminCheckRentBackfill(c *gin.Context) {
+	report, err := h.service.BackfillRentAmounts(c.Request.Context(), true)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Rent backfill check completed (dry run).", report)
+}
+
+// adminExportListings streams the listings matching the query as a CSV or JSON download.
+// Listings are written batch by batch and flushed as they go, so the response is sent chunked. The server's
+// WriteTimeout does not apply: a large export takes longer, and would otherwise be cut off mid-file.
+func (h *Handler) adminExportListings(c *gin.Context) {
+	var query ExportListingsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	filter, err := query.Filter()
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	writer, ok := newListingExportWriter(query.Format, c.Writer, h.imageURLs)
+	if !ok {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid format. Use 'csv' or 'json'."))
+		return
+	}
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Warn("Could not clear the write deadline of the listing export", zap.Error(err))
+	}
+
+	// Headers are sent with the first batch, so that a failure to load it can still be reported as an error.
+	started := false
+	start := func() error {
+		started = true
+		c.Header("Content-Type", writer.contentType())
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="listings-%s.%s"`, time.Now().UTC().Format("20060102T150405Z"), writer.fileExtension()))
+		c.Header("Cache-Control", "no-store")
+		c.Status(http.StatusOK)
+		return writer.begin()
+	}
+	err = h.service.ExportListings(c.Request.Context(), filter, func(batch []Listing) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := writer.write(batch); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	})
+	if err == nil && !started {
+		err = start()
+	}
+	if err == nil {
+		err = writer.end()
+	}
+	if err != nil {
+		if !started {
+			common.RespondWithError(c, err)
+			return
+		}
+		// The status line is already sent; the client sees a truncated file.
+		h.logger.Warn("Listing export aborted", zap.Error(err))
+		return
+	}
+	c.Writer.Flush()
+}
+
+// adminUpdateListing edits any listing. The body takes the fields of PUT /listings/:id as JSON; images cannot be uploaded here.
+func (h *Handler) adminUpdateListing(c *gin.Context) {
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
+	adminID := common.GetUserIDFromContext(c)
+	if adminID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	var req AdminUpdateListingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Admin update listing: Invalid request body", zap.Error(err), zap.String("listingID", listingID.String()))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	req.IfMatch = c.GetHeader("If-Match")
+
+	listing, err := h.service.AdminUpdateListing(c.Request.Context(), listingID, adminID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	c.Header("ETag", listingETag(listing))
+	common.RespondOK(c, "Admin: Listing updated successfully.", ToOwnerListingResponse(listing, h.imageURLs))
+}
+
+// adminFeatureListing features a listing for the requested number of days.
+func (h *Handler) adminFeatureListing(c *gin.Context) {
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
+	adminID := common.GetUserIDFromContext(c)
+	if adminID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	var req FeatureListingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	listing, err := h.service.FeatureListing(c.Request.Context(), listingID, req.Days, &adminID, req.Reference)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Admin: Listing featured successfully.", ToOwnerListingResponse(listing, h.imageURLs))
+}
+
+// adminUnfeatureListing ends a listing's feature period.
+func (h *Handler) adminUnfeatureListing(c *gin.Context) {
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
+	adminID := common.GetUserIDFromContext(c)
+	if adminID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	listing, err := h.service.UnfeatureListing(c.Request.Context(), listingID, adminID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Admin: Listing unfeatured successfully.", ToOwnerListingResponse(listing, h.imageURLs))
+}
+
+// adminTakeDownListing removes a listing for a reason and notifies its owner, who can appeal.
+func (h *Handler) adminTakeDownListing(c *gin.Context) {
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
+	adminID := common.GetUserIDFromContext(c)
+	if adminID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	var req TakedownRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	takedown, err := h.service.TakeDownListing(c.Request.Context(), listingID, adminID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondCreated(c, "Admin: Listing taken down successfully.", ToTakedownResponse(takedown))
+}
+
+// appealTakedown records the owner's appeal against the removal of their listing.
+func (h *Handler) appealTakedown(c *gin.Context) {
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	var req AppealRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	takedown, err := h.service.AppealTakedown(c.Request.Context(), listingID, userID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Appeal submitted successfully.", ToTakedownResponse(takedown))
+}
+
+// askQuestion posts a public question about a listing.
+func (h *Handler) askQuestion(c *gin.Context) {
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	var req AskQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	question, err := h.service.AskQuestion(c.Request.Context(), listingID, userID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondCreated(c, "Question posted successfully.", ToQuestionResponse(question))
+}
+
+// answerQuestion sets the listing owner's answer to a question.
+func (h *Handler) answerQuestion(c *gin.Context) {
+	questionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid question ID format."))
+		return
+	}
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	var req AnswerQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	question, err := h.service.AnswerQuestion(c.Request.Context(), questionID, userID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Question answered successfully.", ToQuestionResponse(question))
+}
+
+// adminListAppeals lists takedowns awaiting a decision, or in another status given by ?status=.
+func (h *Handler) adminListAppeals(c *gin.Context) {
+	var query AppealListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	page, pageSize := common.GetPaginationParams(c)
+
+	appeals, pagination, err := h.service.ListAppeals(c.Request.Context(), query, page, pageSize)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondPaginated(c, "Admin: Appeals retrieved successfully.", appeals, pagination)
+}
+
+// adminResolveAppeal reinstates the listing of an appeal or keeps it removed.
+func (h *Handler) adminResolveAppeal(c *gin.Context) {
+	takedownID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid appeal ID format."))
+		return
+	}
+	adminID := common.GetUserIDFromContext(c)
+	if adminID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	var req ResolveAppealRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	takedown, err := h.service.ResolveAppeal(c.Request.Context(), takedownID, adminID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Admin: Appeal resolved successfully.", ToTakedownResponse(takedown))
+}
+
+// submitBackgroundCheck takes the owner's background-check document for a babysitting listing, as the multipart
+// file "document" with an optional "note".
+func (h *Handler) submitBackgroundCheck(c *gin.Context) {
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	if apiErr := common.ParseMultipartForm(c, h.uploadLimits); apiErr != nil {
+		common.RespondWithError(c, apiErr)
+		return
+	}
+	documents := c.Request.MultipartForm.File["document"]
+	if len(documents) != 1 {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Send exactly one file as 'document'."))
+		return
+	}
+	var note *string
+	if notes := c.Request.MultipartForm.Value["note"]; len(notes) > 0 {
+		if utf8.RuneCountInString(notes[0]) > maxBackgroundCheckNoteLength {
+			common.RespondWithError(c, common.ErrBadRequest.WithDetails(fmt.Sprintf("note must be at most %d characters.", maxBackgroundCheckNoteLength)))
+			return
+		}
+		note = &notes[0]
+	}
+
+	check, err := h.service.SubmitBackgroundCheck(c.Request.Context(), listingID, userID, documents[0], note)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondCreated(c, "Background check submitted successfully.", ToBackgroundCheckResponse(check))
+}
+
+// getBackgroundCheck returns the latest background check of the owner's listing.
+func (h *Handler) getBackgroundCheck(c *gin.Context) {
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	check, err := h.service.GetBackgroundCheck(c.Request.Context(), listingID, userID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Background check retrieved successfully.", ToBackgroundCheckResponse(check))
+}
+
+// adminListBackgroundChecks lists background checks awaiting review, or in another status given by ?status=.
+func (h *Handler) adminListBackgroundChecks(c *gin.Context) {
+	var query BackgroundCheckListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	page, pageSize := common.GetPaginationParams(c)
+
+	checks, pagination, err := h.service.ListBackgroundChecks(c.Request.Context(), query, page, pageSize)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondPaginated(c, "Admin: Background checks retrieved successfully.", checks, pagination)
+}
+
+// adminGetBackgroundCheckDocument downloads the document of a background check. It is always sent as an
+// attachment and never cached, as it holds personal data.
+func (h *Handler) adminGetBackgroundCheckDocument(c *gin.Context) {
+	checkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid background check ID format."))
+		return
+	}
+
+	check, file, err := h.service.OpenBackgroundCheckDocument(c.Request.Context(), checkID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	defer file.Close()
+
+	c.Header("Content-Type", file.ContentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": check.DocumentName}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", "private, no-store")
+	http.ServeContent(c.Writer, c.Request, file.Info.Name(), file.Info.ModTime(), file)
+}
+
+// adminReviewBackgroundCheck verifies, rejects or revokes a background check.
+func (h *Handler) adminReviewBackgroundCheck(c *gin.Context) {
+	checkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid background check ID format."))
+		return
+	}
+	adminID := common.GetUserIDFromContext(c)
+	if adminID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	var req ReviewBackgroundCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	check, err := h.service.ReviewBackgroundCheck(c.Request.Context(), checkID, adminID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Admin: Background check reviewed successfully.", ToBackgroundCheckResponse(check))
+}
+
+// partnerFeatureListing features a listing on behalf of a partner, typically once a customer has paid for it.
+// The API key is noted in the audit log along with the partner's reference.
+func (h *Handler) partnerFeatureListing(c *gin.Context) {
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
+	var req FeatureListingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	value, _ := c.Get(common.APIKeyIDKey)
+	apiKeyID, _ := value.(uuid.UUID)
+	note := "Featured through partner API key " + apiKeyID.String()
+	if req.Reference != nil {
+		note += ", reference " + *req.Reference
+	}
+
+	listing, err := h.service.FeatureListing(c.Request.Context(), listingID, req.Days, nil, &note)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Listing featured successfully.", ToListingResponse(listing, h.imageURLs))
+}
+
+func (h *Handler) getRecentListings(c *gin.Context) {
+	page, pageSize := common.GetPaginationParams(c)
+	includes, err := includesFromQuery(c)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+
+	listings, pagination, err := h.service.GetRecentListings(c.Request.Context(), page, pageSize, includes)
+	if err != nil {
+		common.RespondWithError(c, err) // Service layer should return appropriate common.APIError
+		return
+	}
+	// For public recent listings, contact info is hidden by the service layer (ToListingResponse called with false)
+	common.RespondPaginated(c, "Recent listings retrieved successfully.", common.FormatHints(c, common.SparseFields(c, listings)), pagination)
+}
+
+// trendingMaxAge is how long clients and proxies may reuse the trending listings.
+const trendingMaxAge = 5 * time.Minute
+
+// getTrendingListings returns active listings ranked by recent views, for a "Popular now" section.
+func (h *Handler) getTrendingListings(c *gin.Context) {
+	page, pageSize := common.GetPaginationParams(c)
+
+	listings, pagination, err := h.service.GetTrendingListings(c.Request.Context(), page, pageSize)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(trendingMaxAge.Seconds())))
+	common.RespondPaginated(c, "Trending listings retrieved successfully.", common.FormatHints(c, common.SparseFields(c, listings)), pagination)
+}
+
+// RegisterPartnerRoutes sets up the read-only listing routes exposed to partner API keys.
+// The router group passed here is expected to be /api/v1/partner, already guarded by API key middleware.
+func (h *Handler) RegisterPartnerRoutes(router *gin.RouterGroup) {
+	router.GET("/listings", h.searchListings)
+	router.GET("/listings/:id", h.getListingByID)
+}
+
+// RegisterPartnerFeatureRoutes sets up the route partners call after a customer buys a featured placement.
+// The router group passed here is expected to be /api/v1/partner, guarded by API key middleware requiring the feature scope.
+func (h *Handler) RegisterPartnerFeatureRoutes(router *gin.RouterGroup) {
+	router.POST("/listings/:id/feature", h.partnerFeatureListing)
+}
+
+// RegisterAdminRoutes sets up the listing routes of the admin API.
+// The router group passed here is expected to be /api/v1/admin, already guarded by auth and admin role middleware.
+func (h *Handler) RegisterAdminRoutes(router *gin.RouterGroup) {
+	router.PUT("/listings/:id", h.adminUpdateListing)
+	router.GET("/listings/export", h.adminExportListings)
+	router.POST("/listings/:id/feature", h.adminFeatureListing)
+	router.DELETE("/listings/:id/feature", h.adminUnfeatureListing)
+	router.POST("/listings/:id/takedown", h.adminTakeDownListing)
+	router.GET("/appeals", h.adminListAppeals)
+	router.POST("/appeals/:id/resolve", h.adminResolveAppeal)
+	router.GET("/background-checks", h.adminListBackgroundChecks)
+	router.GET("/background-checks/:id/document", h.adminGetBackgroundCheckDocument)
+	router.POST("/background-checks/:id/review", h.adminReviewBackgroundCheck)
+	router.POST("/maintenance/consistency-check", h.adminCheckImageConsistency)
+	router.POST("/maintenance/rent-backfill", h.adminCheckRentBackfill)
+}
+
+// RegisterUserRoutes sets up the listing routes of the signed-in user's account.
+// The router group passed here is expected to be /api/v1/users/me, already guarded by auth middleware.
+func (h *Handler) RegisterUserRoutes(router *gin.RouterGroup) {
+	router.GET("/recently-viewed", h.getRecentlyViewed)
+	router.DELETE("/recently-viewed", h.clearRecentlyViewed)
+}
+
+// getRecentlyViewed lists the listings the user viewed most recently, for a "Continue browsing" row.
+func (h *Handler) getRecentlyViewed(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	listings, err := h.service.GetRecentlyViewed(c.Request.Context(), userID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Recently viewed listings retrieved successfully.", common.FormatHints(c, common.SparseFields(c, listings)))
+}
+
+// clearRecentlyViewed deletes the user's history of viewed listings.
+func (h *Handler) clearRecentlyViewed(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	if err := h.service.ClearRecentlyViewed(c.Request.Context(), userID); err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondNoContent(c)
+}
+
+// RegisterAnonymousSessionRoutes sets up the listing routes of an anonymous session.
+// The router group passed here is expected to be /api/v1/anonymous-sessions/current, already guarded by
+// middleware that requires an anonymous session.
+func (h *Handler) RegisterAnonymousSessionRoutes(router *gin.RouterGroup) {
+	router.GET("/recently-viewed", h.getAnonymousRecentlyViewed)
+	router.DELETE("/recently-viewed", h.clearAnonymousRecentlyViewed)
+}
+
+// getAnonymousRecentlyViewed lists the listings viewed most recently in the caller's anonymous session.
+func (h *Handler) getAnonymousRecentlyViewed(c *gin.Context) {
+	sessionID := common.GetAnonymousSessionIDFromContext(c)
+	if sessionID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("Anonymous session not found."))
+		return
+	}
+
+	listings, err := h.service.GetAnonymousRecentlyViewed(c.Request.Context(), sessionID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Recently viewed listings retrieved successfully.", common.FormatHints(c, common.SparseFields(c, listings)))
+}
+
+// clearAnonymousRecentlyViewed deletes the history of viewed listings of the caller's anonymous session.
+func (h *Handler) clearAnonymousRecentlyViewed(c *gin.Context) {
+	sessionID := common.GetAnonymousSessionIDFromContext(c)
+	if sessionID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("Anonymous session not found."))
+		return
+	}
+
+	if err := h.service.ClearAnonymousRecentlyViewed(c.Request.Context(), sessionID); err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondNoContent(c)
+}
+
+// RegisterNeighborhoodRoutes sets up the public neighborhood list on the given group, e.g. /api/v1/neighborhoods.
+func (h *Handler) RegisterNeighborhoodRoutes(router *gin.RouterGroup) {
+	router.GET("", h.getNeighborhoods)
+}
+
+// getNeighborhoods lists the neighborhoods whose slugs can be passed as the neighborhood search filter.
+func (h *Handler) getNeighborhoods(c *gin.Context) {
+	neighborhoods, err := h.service.GetNeighborhoods(c.Request.Context())
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Neighborhoods retrieved successfully.", neighborhoods)
+}
+
+// RegisterFeedRoutes sets up the public syndication feeds.
+// The router group passed here is expected to be /api/v1/feeds.
+func (h *Handler) RegisterFeedRoutes(router *gin.RouterGroup) {
+	router.GET("/recent.xml", h.getRecentListingsFeed)
+}
+
+// feedMaxAge is how long clients and proxies may reuse the recent listings feed.
+const feedMaxAge = 5 * time.Minute
+
+// getRecentListingsFeed serves the newest listings as RSS, optionally filtered by ?category=<slug>.
+// It answers conditional requests (If-None-Match / If-Modified-Since) with 304 Not Modified.
+func (h *Handler) getRecentListingsFeed(c *gin.Context) {
+	categorySlug := strings.TrimSpace(c.Query("category"))
+	listings, err := h.service.GetRecentListingsFeed(c.Request.Context(), categorySlug)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+
+	baseURL := requestBaseURL(c.Request)
+	body, err := renderRecentListingsFeed(listings, baseURL+c.Request.URL.RequestURI(), baseURL, common.GetLanguageFromContext(c),
+		func(l *Listing) string { return baseURL + "/api/v1/listings/" + l.ID.String() })
+	if err != nil {
+		h.logger.Error("Failed to render recent listings feed", zap.Error(err))
+		common.RespondWithError(c, common.ErrInternalServer.WithDetails("Could not render the feed."))
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	lastModified := feedLastModified(listings).UTC().Truncate(time.Second)
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(feedMaxAge.Seconds())))
+	c.Header("ETag", etag)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+	}
+	if notModified(c.Request, etag, lastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/rss+xml; charset=utf-8", body)
+}
+
+// notModified evaluates conditional GET headers. If-None-Match takes precedence over If-Modified-Since (RFC 9110).
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		if since, err := http.ParseTime(ims); err == nil {
+			return !lastModified.After(since)
+		}
+	}
+	return false
+}
+
+// RegisterEventRoutes sets up the routes for event specific listing operations.
+func (h *Handler) RegisterEventRoutes(router *gin.RouterGroup) {
+	// The router group passed here is expected to be something like /api/v1/events
+	router.GET("/upcoming", h.getUpcomingEvents)
+}
+
+func (h *Handler) getUpcomingEvents(c *gin.Context) {
+	page, pageSize := common.GetPaginationParams(c)
+	// Default page_size for events as per issue is 10.
+	// common.GetPaginationParams uses 10 if 'page_size' is not provided or invalid, so this should be fine.
+
+	includes, err := includesFromQuery(c)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+
+	events, pagination, err := h.service.GetUpcomingEvents(c.Request.Context(), page, pageSize, includes)
+	if err != nil {
+		common.RespondWithError(c, err) // Service layer should return appropriate common.APIError
+		return
+	}
+	// Contact info is hidden by the service layer (ToListingResponse called with false)
+	common.RespondPaginated(c, "Upcoming events retrieved successfully.", common.FormatHints(c, common.SparseFields(c, events)), pagination)
+}
+
+// getEventsCalendar serves upcoming events as an iCalendar feed that calendar apps can subscribe to.
+func (h *Handler) getEventsCalendar(c *gin.Context) {
+	ics, builtAt, err := h.service.GetEventsCalendar(c.Request.Context())
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+
+	etag := fmt.Sprintf(`"%x"`, builtAt.UnixNano())
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.cfg.EventsCalendarCacheTTL.Seconds())))
+	c.Header("ETag", etag)
+	c.Header("Last-Modified", builtAt.UTC().Format(http.TimeFormat))
+	if notModified(c.Request, etag, builtAt.UTC().Truncate(time.Second)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Header("Content-Disposition", `inline; filename="events.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", ics)
 }
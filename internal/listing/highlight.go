@@ -0,0 +1,169 @@
+// File: internal/listing/highlight.go
+package listing
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	// highlightFragmentRunes is the approximate length of a description snippet.
+	highlightFragmentRunes = 150
+	// maxHighlightFragments caps how many description snippets a result carries.
+	maxHighlightFragments = 3
+	// highlightWordSlack is how far a snippet may grow to avoid cutting a word, e.g. in text without spaces.
+	highlightWordSlack = 20
+
+	highlightPreTag  = "<em>"
+	highlightPostTag = "</em>"
+)
+
+// Keys of Listing.Highlights.
+const (
+	HighlightTitle       = "title"
+	HighlightDescription = "description"
+)
+
+// span is a half-open range of rune offsets.
+type span struct{ start, end int }
+
+// applyHighlights sets the highlights of keyword search results: the title and snippets of the description with
+// every match of a search spelling wrapped in <em>, matched case-insensitively like the search itself.
+// Text outside the tags is HTML-escaped, so the highlights can be rendered as HTML.
+func applyHighlights(listings []Listing, terms []string) {
+	for i := range listings {
+		l := &listings[i]
+		highlights := map[string][]string{}
+		title := []rune(l.Title)
+		if matches := findMatches(title, terms); len(matches) > 0 {
+			highlights[HighlightTitle] = []string{markMatches(title, matches)}
+		}
+		if fragments := highlightFragments([]rune(l.Description), terms); len(fragments) > 0 {
+			highlights[HighlightDescription] = fragments
+		}
+		if len(highlights) > 0 {
+			l.Highlights = highlights
+		}
+	}
+}
+
+// findMatches returns the spans of text that match any term, ignoring case, sorted and with overlaps merged.
+func findMatches(text []rune, terms []string) []span {
+	lowered := make([]rune, len(text))
+	for i, r := range text {
+		lowered[i] = unicode.ToLower(r)
+	}
+
+	var found []span
+	for _, term := range terms {
+		needle := []rune(strings.ToLower(strings.TrimSpace(term)))
+		if len(needle) == 0 {
+			continue
+		}
+		for i := 0; i+len(needle) <= len(lowered); i++ {
+			if runesEqual(lowered[i:i+len(needle)], needle) {
+				found = append(found, span{i, i + len(needle)})
+			}
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+
+	// Sort by start (insertion sort: there are few matches) and merge overlapping spans.
+	for i := 1; i < len(found); i++ {
+		for j := i; j > 0 && found[j].start < found[j-1].start; j-- {
+			found[j], found[j-1] = found[j-1], found[j]
+		}
+	}
+	merged := []span{found[0]}
+	for _, s := range found[1:] {
+		last := &merged[len(merged)-1]
+		if s.start <= last.end {
+			if s.end > last.end {
+				last.end = s.end
+			}
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
+
+func runesEqual(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// markMatches renders text with the matches wrapped in highlight tags and everything else HTML-escaped.
+// The matches must be sorted, non-overlapping and inside text.
+func markMatches(text []rune, matches []span) string {
+	var b strings.Builder
+	pos := 0
+	for _, m := range matches {
+		b.WriteString(html.EscapeString(string(text[pos:m.start])))
+		b.WriteString(highlightPreTag)
+		b.WriteString(html.EscapeString(string(text[m.start:m.end])))
+		b.WriteString(highlightPostTag)
+		pos = m.end
+	}
+	b.WriteString(html.EscapeString(string(text[pos:])))
+	return b.String()
+}
+
+// highlightFragments cuts snippets of about highlightFragmentRunes around the matches in text, widened to whole
+// words where possible, and returns up to maxHighlightFragments of them with their matches marked.
+func highlightFragments(text []rune, terms []string) []string {
+	matches := findMatches(text, terms)
+	var fragments []string
+	for i := 0; i < len(matches) && len(fragments) < maxHighlightFragments; {
+		first := matches[i]
+		start := first.start - (highlightFragmentRunes-(first.end-first.start))/2
+		if start < 0 {
+			start = 0
+		}
+		end := start + highlightFragmentRunes
+		if end < first.end {
+			end = first.end
+		}
+		if end > len(text) {
+			end = len(text)
+		}
+		for slack := highlightWordSlack; slack > 0 && start > 0 && !unicode.IsSpace(text[start-1]); slack-- {
+			start--
+		}
+		for slack := highlightWordSlack; slack > 0 && end < len(text) && !unicode.IsSpace(text[end]); slack-- {
+			end++
+		}
+
+		// Every match that starts inside the fragment belongs to it; one that runs past the end widens it.
+		var inside []span
+		for ; i < len(matches) && matches[i].start < end; i++ {
+			m := matches[i]
+			if m.end > end {
+				end = m.end
+			}
+			inside = append(inside, span{m.start - start, m.end - start})
+		}
+		fragment := text[start:end]
+		lead := 0
+		for lead < len(fragment) && unicode.IsSpace(fragment[lead]) {
+			lead++
+		}
+		trail := len(fragment)
+		for trail > lead && unicode.IsSpace(fragment[trail-1]) {
+			trail--
+		}
+		for j := range inside {
+			inside[j].start -= lead
+			inside[j].end -= lead
+		}
+		fragments = append(fragments, markMatches(fragment[lead:trail], inside))
+	}
+	return fragments
+}
@@ -0,0 +1,40 @@
+package listing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyHighlights(t *testing.T) {
+	listings := []Listing{
+		{Title: "Cap Hill <b>loft</b> for rent", Description: "Sunny loft near Capitol Hill station & the park."},
+		{Title: "Garage sale", Description: "Tools and furniture."},
+	}
+	applyHighlights(listings, []string{"cap hill", "capitol hill"})
+
+	assert.Equal(t, []string{"<em>Cap Hill</em> &lt;b&gt;loft&lt;/b&gt; for rent"}, listings[0].Highlights[HighlightTitle],
+		"matches are wrapped ignoring case and the rest is escaped")
+	assert.Equal(t, []string{"Sunny loft near <em>Capitol Hill</em> station &amp; the park."}, listings[0].Highlights[HighlightDescription],
+		"place name synonyms are highlighted too")
+	assert.Nil(t, listings[1].Highlights, "listings without a match carry no highlights")
+}
+
+func TestHighlightFragments(t *testing.T) {
+	filler := strings.Repeat("lorem ipsum dolor sit amet ", 20)
+	text := []rune(filler + "bike one " + filler + "bike two " + filler + "bike three " + filler + "bike four " + filler)
+
+	fragments := highlightFragments(text, []string{"BIKE"})
+	require.Len(t, fragments, maxHighlightFragments)
+	for _, fragment := range fragments {
+		assert.Equal(t, 1, strings.Count(fragment, "<em>bike</em>"))
+		assert.Equal(t, strings.TrimSpace(fragment), fragment)
+		assert.LessOrEqual(t, len([]rune(fragment)), highlightFragmentRunes+2*highlightWordSlack+len("<em></em>"))
+	}
+	assert.Contains(t, fragments[0], "<em>bike</em> one")
+
+	assert.Equal(t, []string{"a <em>bike</em> and a <em>bike</em>"}, highlightFragments([]rune("a bike and a bike"), []string{"bike", " "}),
+		"nearby matches share a fragment and blank terms are ignored")
+}
@@ -0,0 +1,103 @@
+// File: internal/listing/housing.go
+package listing
+
+import (
+	"math"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"gorm.io/gorm"
+)
+
+// HousingPetPolicy is which pets a housing listing allows.
+type HousingPetPolicy string
+
+const (
+	HousingNoPets      HousingPetPolicy = "no_pets"
+	HousingCatsOnly    HousingPetPolicy = "cats_only"
+	HousingDogsOnly    HousingPetPolicy = "dogs_only"
+	HousingCatsAndDogs HousingPetPolicy = "cats_and_dogs"
+)
+
+// HousingParking is the parking that comes with a housing listing.
+type HousingParking string
+
+const (
+	HousingParkingNone      HousingParking = "none"
+	HousingParkingStreet    HousingParking = "street"
+	HousingParkingOffStreet HousingParking = "off_street"
+	HousingParkingGarage    HousingParking = "garage"
+)
+
+// monthlyRentSQL is the rent of a listing_details_housing row h per month, else the listing's structured price.
+// It is NULL when neither is set.
+const monthlyRentSQL = `COALESCE(h.rent_amount * CASE h.rent_period
+	WHEN 'daily' THEN 365 / 12.0 WHEN 'weekly' THEN 52 / 12.0 WHEN 'yearly' THEN 1 / 12.0 ELSE 1 END, listings.price_amount)`
+
+// applyAmenitiesTo sets the amenity fields of d that the request contains; fields it leaves out keep their value.
+func (r *CreateListingHousingDetailsRequest) applyAmenitiesTo(d *ListingDetailsHousing) error {
+	if r.Bathrooms != nil && math.Mod(*r.Bathrooms*2, 1) != 0 {
+		return common.ErrBadRequest.WithDetails("bathrooms must be a whole or half number, e.g. 1.5.")
+	}
+	if r.AvailableFrom != nil {
+		availableFrom, err := time.Parse(eventDateLayout, *r.AvailableFrom)
+		if err != nil {
+			return common.ErrBadRequest.WithDetails("available_from must be a date in YYYY-MM-DD format.")
+		}
+		d.AvailableFrom = &availableFrom
+	}
+	if r.Bedrooms != nil {
+		d.Bedrooms = r.Bedrooms
+	}
+	if r.Bathrooms != nil {
+		d.Bathrooms = r.Bathrooms
+	}
+	if r.SquareFeet != nil {
+		d.SquareFeet = r.SquareFeet
+	}
+	if r.PetPolicy != nil {
+		d.PetPolicy = r.PetPolicy
+	}
+	if r.Parking != nil {
+		d.Parking = r.Parking
+	}
+	return nil
+}
+
+// housingRequest turns housing details back into the request that produces them, for listing documents.
+func (d *ListingDetailsHousing) housingRequest() *CreateListingHousingDetailsRequest {
+	req := &CreateListingHousingDetailsRequest{
+		PropertyType: d.PropertyType,
+		RentDetails:  d.RentDetails,
+		SalePrice:    d.SalePrice,
+		Bedrooms:     d.Bedrooms,
+		Bathrooms:    d.Bathrooms,
+		SquareFeet:   d.SquareFeet,
+		PetPolicy:    d.PetPolicy,
+		Parking:      d.Parking,
+	}
+	// A numeric rent that rent_details states is left out, so that a patch of rent_details alone re-reads it
+	// instead of being overwritten from the old amount.
+	if d.RentAmount != nil && d.RentPeriod != nil && !statesRent(d.RentDetails, *d.RentAmount, *d.RentPeriod) {
+		req.RentAmount, req.RentPeriod = d.RentAmount, d.RentPeriod
+	}
+	if d.AvailableFrom != nil {
+		availableFrom := d.AvailableFrom.Format(eventDateLayout)
+		req.AvailableFrom = &availableFrom
+	}
+	return req
+}
+
+// applyHousingFilters restricts dbQuery to housing listings matching the min_bedrooms and max_rent filters.
+func applyHousingFilters(dbQuery *gorm.DB, queryParams ListingSearchQuery) *gorm.DB {
+	if queryParams.MinBedrooms != nil {
+		dbQuery = dbQuery.Where("EXISTS (SELECT 1 FROM listing_details_housing h WHERE h.listing_id = listings.id AND h.bedrooms >= ?)",
+			*queryParams.MinBedrooms)
+	}
+	if queryParams.MaxRent != nil {
+		dbQuery = dbQuery.Where("EXISTS (SELECT 1 FROM listing_details_housing h WHERE h.listing_id = listings.id AND h.property_type = ? AND "+
+			monthlyRentSQL+" <= ?)", HousingForRent, *queryParams.MaxRent)
+	}
+	return dbQuery
+}
@@ -0,0 +1,27 @@
+package listing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyAmenitiesTo(t *testing.T) {
+	beds, baths, parking := 2, 1.5, HousingParkingGarage
+	availableFrom := "2024-07-01"
+	details := &ListingDetailsHousing{PropertyType: HousingForRent}
+	req := &CreateListingHousingDetailsRequest{Bedrooms: &beds, Bathrooms: &baths, Parking: &parking, AvailableFrom: &availableFrom}
+	require.NoError(t, req.applyAmenitiesTo(details))
+	assert.Equal(t, 2, *details.Bedrooms)
+	assert.Equal(t, 1.5, *details.Bathrooms)
+	assert.Equal(t, "2024-07-01", details.AvailableFrom.Format("2006-01-02"))
+
+	require.NoError(t, (&CreateListingHousingDetailsRequest{}).applyAmenitiesTo(details))
+	assert.Equal(t, 2, *details.Bedrooms, "fields left out keep their value")
+
+	quarter := 1.25
+	assert.Error(t, (&CreateListingHousingDetailsRequest{Bathrooms: &quarter}).applyAmenitiesTo(details))
+
+	assert.Equal(t, req.AvailableFrom, details.housingRequest().AvailableFrom, "documents round-trip the date")
+}
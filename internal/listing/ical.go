@@ -0,0 +1,140 @@
+// File: internal/listing/ical.go
+package listing
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // EVENTS_TIMEZONE must resolve in minimal containers without a zoneinfo database
+	"unicode/utf8"
+)
+
+const (
+	icalProductID      = "-//Seattle Info//Community Events//EN"
+	icalCalendarName   = "Seattle Info Community Events"
+	icalUIDDomain      = "seattle-info"
+	icalDateLayout     = "20060102"
+	icalLocalLayout    = "20060102T150405"
+	icalUTCLayout      = "20060102T150405Z"
+	icalMaxLineOctets  = 75
+	icalRefreshPeriod  = "PT1H"
+	icalLineTerminator = "\r\n"
+)
+
+// renderEventsCalendar writes event listings as an RFC 5545 VCALENDAR. Timed events carry a TZID for their
+// own time zone, or loc when they have none, so recurring events keep their local time across DST changes;
+// events without a time are all-day.
+func renderEventsCalendar(listings []Listing, loc *time.Location, generatedAt time.Time) []byte {
+	var buf bytes.Buffer
+	w := func(name, value string) { writeICalLine(&buf, name+":"+value) }
+
+	w("BEGIN", "VCALENDAR")
+	w("VERSION", "2.0")
+	w("PRODID", icalProductID)
+	w("CALSCALE", "GREGORIAN")
+	w("METHOD", "PUBLISH")
+	w("X-WR-CALNAME", escapeICalText(icalCalendarName))
+	w("X-WR-TIMEZONE", loc.String())
+	w("REFRESH-INTERVAL;VALUE=DURATION", icalRefreshPeriod)
+	w("X-PUBLISHED-TTL", icalRefreshPeriod)
+
+	for i := range listings {
+		l := &listings[i]
+		details := l.EventDetails
+		if details == nil {
+			continue
+		}
+		eventLoc := details.Location(loc)
+		first := dateOnly(details.EventDate, eventLoc)
+
+		w("BEGIN", "VEVENT")
+		w("UID", l.ID.String()+"@"+icalUIDDomain)
+		w("DTSTAMP", generatedAt.UTC().Format(icalUTCLayout))
+		if details.EventTime == nil {
+			w("DTSTART;VALUE=DATE", first.Format(icalDateLayout))
+			if !details.IsRecurring() {
+				w("DTEND;VALUE=DATE", first.AddDate(0, 0, 1).Format(icalDateLayout))
+			}
+		} else {
+			w("DTSTART;TZID="+eventLoc.String(), details.startOn(first).Format(icalLocalLayout))
+		}
+		if rule := details.RRule(eventLoc); rule != "" {
+			w("RRULE", rule)
+		}
+		w("SUMMARY", escapeICalText(l.Title))
+		if description := eventDescription(l); description != "" {
+			w("DESCRIPTION", escapeICalText(description))
+		}
+		if location := eventLocation(l); location != "" {
+			w("LOCATION", escapeICalText(location))
+		}
+		if lat, lon, ok := l.publicCoordinates(); ok {
+			w("GEO", strconv.FormatFloat(lat, 'f', -1, 64)+";"+strconv.FormatFloat(lon, 'f', -1, 64))
+		}
+		w("CREATED", l.CreatedAt.UTC().Format(icalUTCLayout))
+		w("LAST-MODIFIED", l.UpdatedAt.UTC().Format(icalUTCLayout))
+		w("END", "VEVENT")
+	}
+
+	w("END", "VCALENDAR")
+	return buf.Bytes()
+}
+
+// eventDescription is the listing description followed by the organizer, when known.
+func eventDescription(l *Listing) string {
+	description := strings.TrimSpace(l.Description)
+	if organizer := l.EventDetails.OrganizerName; organizer != nil && *organizer != "" {
+		if description != "" {
+			description += "\n\n"
+		}
+		description += "Organizer: " + *organizer
+	}
+	return description
+}
+
+// eventLocation joins the venue and the listing address, as its location privacy allows, into one line.
+func eventLocation(l *Listing) string {
+	var parts []string
+	add := func(s *string) {
+		if s != nil && strings.TrimSpace(*s) != "" {
+			parts = append(parts, strings.TrimSpace(*s))
+		}
+	}
+	add(l.EventDetails.VenueName)
+	add(l.publicAddressLine())
+	add(l.City)
+	add(l.State)
+	add(l.ZipCode)
+	return strings.Join(parts, ", ")
+}
+
+// escapeICalText escapes a TEXT property value (RFC 5545 section 3.3.11).
+func escapeICalText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// writeICalLine writes a content line, folding it at 75 octets without splitting UTF-8 characters.
+func writeICalLine(buf *bytes.Buffer, line string) {
+	limit := icalMaxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString(icalLineTerminator)
+		buf.WriteByte(' ')
+		line = line[cut:]
+		limit = icalMaxLineOctets - 1 // The leading space counts towards the continuation line
+	}
+	buf.WriteString(line)
+	buf.WriteString(icalLineTerminator)
+}
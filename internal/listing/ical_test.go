@@ -0,0 +1,92 @@
+package listing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string { return &s }
+
+func unfoldICal(b []byte) string {
+	return strings.ReplaceAll(string(b), "\r\n ", "")
+}
+
+func TestRenderEventsCalendarRecurringTimedEvent(t *testing.T) {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+
+	weekly := EventRecursWeekly
+	until := time.Date(2024, 6, 27, 0, 0, 0, 0, time.UTC)
+	id := uuid.New()
+	listings := []Listing{{
+		Title:        "Board games, snacks; fun",
+		Description:  "Bring a friend\nor two",
+		AddressLine1: strPtr("123 Pike St"),
+		City:         strPtr("Seattle"),
+		EventDetails: &ListingDetailsEvents{
+			EventDate:           time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+			EventTime:           strPtr("18:30:00"),
+			VenueName:           strPtr("Community Hall"),
+			RecurrenceFrequency: &weekly,
+			RecurrenceInterval:  2,
+			RecurrenceUntil:     &until,
+		},
+	}}
+	listings[0].ID = id
+
+	ics := renderEventsCalendar(listings, loc, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	out := unfoldICal(ics)
+
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.Contains(t, out, "UID:"+id.String()+"@seattle-info\r\n")
+	assert.Contains(t, out, "DTSTART;TZID=America/Los_Angeles:20240104T183000\r\n")
+	assert.Contains(t, out, "RRULE:FREQ=WEEKLY;INTERVAL=2;UNTIL=20240628T065959Z\r\n")
+	assert.Contains(t, out, `SUMMARY:Board games\, snacks\; fun`+"\r\n")
+	assert.Contains(t, out, `DESCRIPTION:Bring a friend\nor two`+"\r\n")
+	assert.Contains(t, out, `LOCATION:Community Hall\, 123 Pike St\, Seattle`+"\r\n")
+	assert.True(t, strings.HasSuffix(out, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+}
+
+func TestRenderEventsCalendarUsesTheEventZone(t *testing.T) {
+	listings := []Listing{{
+		Title:        "Visiting choir",
+		EventDetails: &ListingDetailsEvents{EventDate: time.Date(2024, 7, 20, 0, 0, 0, 0, time.UTC), EventTime: strPtr("19:00:00"), Timezone: "America/New_York"},
+	}}
+
+	out := unfoldICal(renderEventsCalendar(listings, time.UTC, time.Now()))
+
+	assert.Contains(t, out, "DTSTART;TZID=America/New_York:20240720T190000\r\n")
+}
+
+func TestRenderEventsCalendarAllDayEvent(t *testing.T) {
+	listings := []Listing{{
+		Title:        "Street fair",
+		EventDetails: &ListingDetailsEvents{EventDate: time.Date(2024, 7, 20, 0, 0, 0, 0, time.UTC)},
+	}}
+
+	out := unfoldICal(renderEventsCalendar(listings, time.UTC, time.Now()))
+
+	assert.Contains(t, out, "DTSTART;VALUE=DATE:20240720\r\n")
+	assert.Contains(t, out, "DTEND;VALUE=DATE:20240721\r\n")
+	assert.NotContains(t, out, "RRULE")
+}
+
+func TestWriteICalLineFoldsLongLinesOnRuneBoundaries(t *testing.T) {
+	var buf bytes.Buffer
+	line := "SUMMARY:" + strings.Repeat("é", 80)
+	writeICalLine(&buf, line)
+
+	physical := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	require.Greater(t, len(physical), 1)
+	for _, l := range physical {
+		assert.LessOrEqual(t, len(l), icalMaxLineOctets)
+		assert.True(t, strings.ToValidUTF8(l, "?") == l, "line splits a UTF-8 sequence: %q", l)
+	}
+	assert.Equal(t, line+"\r\n", strings.ReplaceAll(buf.String(), "\r\n ", ""))
+}
@@ -0,0 +1,254 @@
+// File: internal/listing/images.go
+package listing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/filestorage"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// listingImagesDir is the storage sub-directory listing images are saved in.
+	listingImagesDir = "listings"
+	// imageConsistencyBatchSize is the number of listing images CheckImageConsistency loads per query.
+	imageConsistencyBatchSize = 1000
+	// maxReportedInconsistencies caps the paths listed in an ImageConsistencyReport; the counts are always complete.
+	maxReportedInconsistencies = 100
+	// defaultOrphanImageGracePeriod is used when ORPHAN_IMAGE_GRACE_HOURS is not positive.
+	defaultOrphanImageGracePeriod = 24 * time.Hour
+	// Length limits of an image's alt text and caption, in characters.
+	maxImageAltTextLength = 250
+	maxImageCaptionLength = 500
+)
+
+// ImageTexts holds the alt texts and captions of uploaded images as parallel form fields: the nth image_alt_text
+// and image_caption belong to the nth file in "images". Empty and missing values leave an image without one.
+type ImageTexts struct {
+	AltTexts []string `form:"image_alt_text" json:"-"`
+	Captions []string `form:"image_caption" json:"-"`
+}
+
+// validate checks the texts of imageCount uploaded images.
+func (t ImageTexts) validate(imageCount int) error {
+	if len(t.AltTexts) > imageCount || len(t.Captions) > imageCount {
+		return common.ErrBadRequest.WithDetails("There are more image_alt_text or image_caption values than uploaded images.")
+	}
+	for i, altText := range t.AltTexts {
+		if utf8.RuneCountInString(strings.TrimSpace(altText)) > maxImageAltTextLength {
+			return common.ErrBadRequest.WithDetails(fmt.Sprintf("The alt text of image %d must be at most %d characters.", i+1, maxImageAltTextLength))
+		}
+	}
+	for i, caption := range t.Captions {
+		if utf8.RuneCountInString(strings.TrimSpace(caption)) > maxImageCaptionLength {
+			return common.ErrBadRequest.WithDetails(fmt.Sprintf("The caption of image %d must be at most %d characters.", i+1, maxImageCaptionLength))
+		}
+	}
+	return nil
+}
+
+// textAt returns the trimmed ith value of values, or nil when it is missing or empty.
+func textAt(values []string, i int) *string {
+	if i >= len(values) {
+		return nil
+	}
+	text := strings.TrimSpace(values[i])
+	if text == "" {
+		return nil
+	}
+	return &text
+}
+
+// saveImages stores uploaded images with their texts, which must have been validated, numbering them from firstSortOrder. If one fails, the images
+// already saved by this call are deleted again so that a failed request leaves no files behind.
+// With virus scanning enabled the files are quarantined; queueImageScans must be called once the images are stored.
+func (s *ServiceImplementation) saveImages(images []*multipart.FileHeader, texts ImageTexts, listing *Listing, firstSortOrder int) ([]ListingImage, error) {
+	dir, scanStatus := s.newImageLocation()
+	saved := make([]ListingImage, 0, len(images))
+	for i, imageFile := range images {
+		relativePath, err := s.fileStorageService.SaveUploadedFile(imageFile, dir)
+		if err != nil {
+			s.logger.Error("Failed to save uploaded image", zap.Error(err), zap.String("filename", imageFile.Filename))
+			s.discardImages(saved)
+			return nil, common.ErrBadRequest.WithDetails(fmt.Sprintf("Failed to save image %s: %s", imageFile.Filename, err.Error()))
+		}
+		saved = append(saved, ListingImage{
+			ID:         uuid.New(), // Known before the listing is stored, for the scan task
+			ListingID:  listing.ID,
+			ImagePath:  filestorage.ReleasedPath(relativePath),
+			SortOrder:  firstSortOrder + i,
+			AltText:    textAt(texts.AltTexts, i),
+			Caption:    textAt(texts.Captions, i),
+			ScanStatus: scanStatus,
+		})
+	}
+	return saved, nil
+}
+
+// addImages stores uploaded files and then attaches ownerID's completed resumable uploads, numbering the images
+// from firstSortOrder. texts cover the files first, then the uploads. If one fails, the images already added by
+// this call are deleted again; attached uploads are used up either way.
+func (s *ServiceImplementation) addImages(ownerID uuid.UUID, files []*multipart.FileHeader, uploadTokens []string, texts ImageTexts, listing *Listing, firstSortOrder int) ([]ListingImage, error) {
+	added, err := s.saveImages(files, texts, listing, firstSortOrder)
+	if err != nil {
+		return nil, err
+	}
+	dir, scanStatus := s.newImageLocation()
+	for i, token := range uploadTokens {
+		n := len(files) + i
+		relativePath, err := s.fileStorageService.ClaimUpload(ownerID, token, dir)
+		if err != nil {
+			s.discardImages(added)
+			if errors.Is(err, filestorage.ErrUploadNotFound) || errors.Is(err, filestorage.ErrUploadIncomplete) {
+				return nil, common.ErrBadRequest.WithDetails(fmt.Sprintf("Upload %s is not a completed upload of the listing's owner. Complete it first, or upload the image again if it expired.", token))
+			}
+			s.logger.Error("Failed to attach resumable upload", zap.String("token", token), zap.Error(err))
+			return nil, common.ErrInternalServer.WithDetails("Could not attach uploaded image.")
+		}
+		added = append(added, ListingImage{
+			ID:         uuid.New(),
+			ListingID:  listing.ID,
+			ImagePath:  filestorage.ReleasedPath(relativePath),
+			SortOrder:  firstSortOrder + n,
+			AltText:    textAt(texts.AltTexts, n),
+			Caption:    textAt(texts.Captions, n),
+			ScanStatus: scanStatus,
+		})
+	}
+	return added, nil
+}
+
+// discardImages deletes the files of images that will not be (or are no longer) stored on a listing.
+// Failures are logged; the consistency check job removes whatever is left behind.
+func (s *ServiceImplementation) discardImages(images []ListingImage) {
+	for _, img := range images {
+		if img.ImagePath == "" || img.ScanStatus == ScanInfected {
+			continue
+		}
+		path := img.ImagePath
+		if img.ScanStatus == ScanPending {
+			path = filestorage.QuarantinePath(path)
+		}
+		if err := s.fileStorageService.DeleteFile(path); err != nil {
+			s.logger.Error("Failed to delete image file", zap.String("path", path), zap.Error(err))
+		}
+	}
+}
+
+// ImageConsistencyReport is the outcome of CheckImageConsistency.
+type ImageConsistencyReport struct {
+	DryRun        bool               `json:"dry_run"`
+	FilesChecked  int                `json:"files_checked"`
+	ImagesChecked int                `json:"images_checked"`
+	OrphanedFiles int                `json:"orphaned_files"` // Files no listing image refers to
+	MissingFiles  int                `json:"missing_files"`  // Listing images whose file is gone
+	FilesDeleted  int                `json:"files_deleted"`
+	ImagesDeleted int                `json:"images_deleted"`
+	Orphaned      []string           `json:"orphaned_file_paths"`      // Up to maxReportedInconsistencies entries
+	Missing       []MissingImageFile `json:"images_with_missing_file"` // Up to maxReportedInconsistencies entries
+}
+
+// MissingImageFile is a listing image whose file is not in storage.
+type MissingImageFile struct {
+	ImageID   uuid.UUID `json:"image_id"`
+	ListingID uuid.UUID `json:"listing_id"`
+	Path      string    `json:"path"`
+}
+
+// CheckImageConsistency cross-checks listing images against the files in storage, in both directions:
+// files no listing image refers to (e.g. left by a crash between saving an upload and storing the
+// listing) and listing images whose file is gone. Unless dryRun is set, both are removed.
+//
+// Files younger than ORPHAN_IMAGE_GRACE_HOURS are never reported, as they may belong to a request
+// still in progress; neither are images added after the files were listed.
+func (s *ServiceImplementation) CheckImageConsistency(ctx context.Context, dryRun bool) (*ImageConsistencyReport, error) {
+	started := time.Now()
+	files, err := s.fileStorageService.ListFiles(listingImagesDir)
+	if err != nil {
+		s.logger.Error("Failed to list listing image files", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not list stored images.")
+	}
+	onDisk := make(map[string]bool, len(files))
+	for _, f := range files {
+		onDisk[f.Path] = true
+	}
+	report := &ImageConsistencyReport{DryRun: dryRun, FilesChecked: len(files), Orphaned: []string{}, Missing: []MissingImageFile{}}
+
+	referenced := make(map[string]bool)
+	var afterID uuid.UUID
+	for {
+		images, err := s.repo.FindImagesAfter(ctx, afterID, imageConsistencyBatchSize)
+		if err != nil {
+			s.logger.Error("Failed to scan listing images", zap.Error(err))
+			return nil, common.ErrInternalServer.WithDetails("Could not scan listing images.")
+		}
+		if len(images) == 0 {
+			break
+		}
+		afterID = images[len(images)-1].ID
+
+		var missingIDs []uuid.UUID
+		for _, img := range images {
+			report.ImagesChecked++
+			referenced[img.ImagePath] = true
+			// Pending images are still in quarantine and infected ones were deleted on purpose.
+			if onDisk[img.ImagePath] || !img.CreatedAt.Before(started) || img.ScanStatus == ScanPending || img.ScanStatus == ScanInfected {
+				continue
+			}
+			report.MissingFiles++
+			if len(report.Missing) < maxReportedInconsistencies {
+				report.Missing = append(report.Missing, MissingImageFile{ImageID: img.ID, ListingID: img.ListingID, Path: img.ImagePath})
+			}
+			missingIDs = append(missingIDs, img.ID)
+		}
+		if !dryRun && len(missingIDs) > 0 {
+			if err := s.repo.DeleteImages(ctx, missingIDs); err != nil {
+				s.logger.Error("Failed to delete listing images with missing files", zap.Error(err))
+			} else {
+				report.ImagesDeleted += len(missingIDs)
+			}
+		}
+	}
+
+	gracePeriod := s.cfg.OrphanImageGracePeriod
+	if gracePeriod <= 0 {
+		gracePeriod = defaultOrphanImageGracePeriod
+	}
+	cutoff := started.Add(-gracePeriod)
+	for _, f := range files {
+		if referenced[f.Path] || !f.ModTime.Before(cutoff) {
+			continue
+		}
+		report.OrphanedFiles++
+		if len(report.Orphaned) < maxReportedInconsistencies {
+			report.Orphaned = append(report.Orphaned, f.Path)
+		}
+		if dryRun {
+			continue
+		}
+		if err := s.fileStorageService.DeleteFile(f.Path); err != nil {
+			s.logger.Error("Failed to delete orphaned image file", zap.String("path", f.Path), zap.Error(err))
+			continue
+		}
+		report.FilesDeleted++
+	}
+
+	s.logger.Info("Image consistency check completed",
+		zap.Bool("dryRun", dryRun),
+		zap.Int("files_checked", report.FilesChecked),
+		zap.Int("images_checked", report.ImagesChecked),
+		zap.Int("orphaned_files", report.OrphanedFiles),
+		zap.Int("missing_files", report.MissingFiles),
+		zap.Int("files_deleted", report.FilesDeleted),
+		zap.Int("images_deleted", report.ImagesDeleted))
+	return report, nil
+}
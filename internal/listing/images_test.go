@@ -0,0 +1,159 @@
+package listing
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/filestorage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func uploadedFiles(t *testing.T, filenames ...string) []*multipart.FileHeader {
+	t.Helper()
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	for _, name := range filenames {
+		part, err := writer.CreateFormFile("images", name)
+		require.NoError(t, err)
+		_, err = part.Write([]byte("image data"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	form, err := multipart.NewReader(body, writer.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err)
+	return form.File["images"]
+}
+
+func TestSaveImagesRemovesSavedFilesOnFailure(t *testing.T) {
+	storage, err := filestorage.NewFileStorageService(t.TempDir(), zap.NewNop())
+	require.NoError(t, err)
+	svc := &ServiceImplementation{fileStorageService: storage, logger: zap.NewNop()}
+
+	_, err = svc.saveImages(uploadedFiles(t, "a.jpg", "b.png", "c.exe"), ImageTexts{}, &Listing{}, 0)
+	require.Error(t, err)
+	files, err := storage.ListFiles(listingImagesDir)
+	require.NoError(t, err)
+	assert.Empty(t, files, "images saved before the failing one are deleted")
+
+	images, err := svc.saveImages(uploadedFiles(t, "a.jpg", "b.png"), ImageTexts{}, &Listing{}, 3)
+	require.NoError(t, err)
+	require.Len(t, images, 2)
+	assert.Equal(t, 3, images[0].SortOrder)
+	assert.Equal(t, 4, images[1].SortOrder)
+
+	svc.discardImages(images)
+	files, err = storage.ListFiles(listingImagesDir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestImageTexts(t *testing.T) {
+	storage, err := filestorage.NewFileStorageService(t.TempDir(), zap.NewNop())
+	require.NoError(t, err)
+	svc := &ServiceImplementation{fileStorageService: storage, logger: zap.NewNop()}
+
+	texts := ImageTexts{AltTexts: []string{" Front of the shop ", ""}, Captions: []string{"", "Opening day"}}
+	require.NoError(t, texts.validate(2))
+	images, err := svc.saveImages(uploadedFiles(t, "a.jpg", "b.png"), texts, &Listing{}, 0)
+	require.NoError(t, err)
+	require.Len(t, images, 2)
+	require.NotNil(t, images[0].AltText)
+	assert.Equal(t, "Front of the shop", *images[0].AltText)
+	assert.Nil(t, images[0].Caption)
+	assert.Nil(t, images[1].AltText)
+	assert.Equal(t, "Opening day", *images[1].Caption)
+	svc.discardImages(images)
+
+	assert.Error(t, ImageTexts{AltTexts: []string{"a", "b"}}.validate(1), "more texts than images")
+	assert.Error(t, ImageTexts{AltTexts: []string{strings.Repeat("x", maxImageAltTextLength+1)}}.validate(1))
+	assert.Error(t, ImageTexts{Captions: []string{strings.Repeat("x", maxImageCaptionLength+1)}}.validate(1))
+	assert.NoError(t, ImageTexts{Captions: []string{strings.Repeat("é", maxImageCaptionLength)}}.validate(1), "limits count characters, not bytes")
+}
+
+// imageRepository serves listing images from memory; other Repository methods are not used by the consistency check.
+type imageRepository struct {
+	Repository
+	images []ListingImage
+}
+
+func (r *imageRepository) FindImagesAfter(_ context.Context, afterID uuid.UUID, limit int) ([]ListingImage, error) {
+	if afterID != uuid.Nil {
+		return nil, nil // All images fit in the first batch
+	}
+	return r.images, nil
+}
+
+func (r *imageRepository) DeleteImages(_ context.Context, ids []uuid.UUID) error {
+	kept := r.images[:0]
+	for _, img := range r.images {
+		if !containsID(ids, img.ID) {
+			kept = append(kept, img)
+		}
+	}
+	r.images = kept
+	return nil
+}
+
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCheckImageConsistency(t *testing.T) {
+	root := t.TempDir()
+	storage, err := filestorage.NewFileStorageService(root, zap.NewNop())
+	require.NoError(t, err)
+	saved, err := storage.SaveUploadedFile(uploadedFiles(t, "a.jpg")[0], listingImagesDir)
+	require.NoError(t, err)
+	orphan, err := storage.SaveUploadedFile(uploadedFiles(t, "b.jpg")[0], listingImagesDir)
+	require.NoError(t, err)
+	recent, err := storage.SaveUploadedFile(uploadedFiles(t, "c.jpg")[0], listingImagesDir)
+	require.NoError(t, err)
+	old := time.Now().Add(-48 * time.Hour)
+	for _, p := range []string{saved, orphan} {
+		require.NoError(t, os.Chtimes(filepath.Join(root, p), old, old))
+	}
+
+	kept := ListingImage{ID: uuid.New(), ListingID: uuid.New(), ImagePath: saved, CreatedAt: old}
+	broken := ListingImage{ID: uuid.New(), ListingID: uuid.New(), ImagePath: "listings/gone.jpg", CreatedAt: old}
+	repo := &imageRepository{images: []ListingImage{kept, broken}}
+	svc := &ServiceImplementation{repo: repo, fileStorageService: storage, cfg: &config.Config{OrphanImageGracePeriod: 24 * time.Hour}, logger: zap.NewNop()}
+
+	report, err := svc.CheckImageConsistency(context.Background(), true)
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.FilesChecked)
+	assert.Equal(t, 2, report.ImagesChecked)
+	assert.Equal(t, []string{orphan}, report.Orphaned, "the recent unreferenced file is within the grace period")
+	require.Len(t, report.Missing, 1)
+	assert.Equal(t, broken.ID, report.Missing[0].ImageID)
+	assert.Zero(t, report.FilesDeleted+report.ImagesDeleted, "a dry run changes nothing")
+	assert.Len(t, repo.images, 2)
+
+	report, err = svc.CheckImageConsistency(context.Background(), false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.FilesDeleted)
+	assert.Equal(t, 1, report.ImagesDeleted)
+	assert.Equal(t, []ListingImage{kept}, repo.images)
+	files, err := storage.ListFiles(listingImagesDir)
+	require.NoError(t, err)
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	assert.ElementsMatch(t, []string{saved, recent}, paths)
+}
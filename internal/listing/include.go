@@ -0,0 +1,89 @@
+// File: internal/listing/include.go
+package listing
+
+import (
+	"fmt"
+	"strings"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Associations list endpoints load on request, named in the include query parameter.
+const (
+	IncludeUser         = "user"
+	IncludeCategory     = "category" // Category and sub-category
+	IncludeDetails      = "details"  // Babysitting, housing, event and job details
+	IncludeImages       = "images"
+	IncludeNeighborhood = "neighborhood"
+)
+
+var validIncludes = []string{IncludeUser, IncludeCategory, IncludeDetails, IncludeImages, IncludeNeighborhood}
+
+// Includes selects the associations a listing query loads. A nil Includes loads all of them.
+type Includes map[string]bool
+
+// Has reports whether the association name is loaded.
+func (in Includes) Has(name string) bool {
+	return in == nil || in[name]
+}
+
+// ParseIncludes parses a comma-separated include value such as "user,images". An empty value selects none.
+func ParseIncludes(raw string) (Includes, error) {
+	in := Includes{}
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		valid := false
+		for _, v := range validIncludes {
+			valid = valid || v == name
+		}
+		if !valid {
+			return nil, common.ErrBadRequest.WithDetails(fmt.Sprintf("Unknown include '%s'. Valid values: %s.", name, strings.Join(validIncludes, ", ")))
+		}
+		in[name] = true
+	}
+	return in, nil
+}
+
+// includesFromQuery reads ?include= for a list endpoint. Without the parameter every association is loaded,
+// as before it existed; "include=" loads none.
+func includesFromQuery(c *gin.Context) (Includes, error) {
+	raw, ok := c.GetQuery("include")
+	if !ok {
+		return nil, nil
+	}
+	return ParseIncludes(raw)
+}
+
+// preloadIncludes applies the preloads selected by in to a listing query.
+func (r *GORMRepository) preloadIncludes(query *gorm.DB, in Includes) *gorm.DB {
+	if in == nil {
+		return r.preloader(query)
+	}
+	if in.Has(IncludeUser) {
+		query = query.Preload("User")
+	}
+	if in.Has(IncludeCategory) {
+		query = query.Preload("Category").Preload("SubCategory")
+	}
+	if in.Has(IncludeDetails) {
+		query = query.Preload("BabysittingDetails").
+			Preload("HousingDetails").
+			Preload("EventDetails").
+			Preload("JobDetails")
+	}
+	if in.Has(IncludeImages) {
+		query = query.Preload("Images", func(db *gorm.DB) *gorm.DB {
+			return db.Where("listing_images.scan_status <> ?", ScanInfected).Order("listing_images.sort_order ASC")
+		})
+	}
+	if in.Has(IncludeNeighborhood) {
+		query = query.Preload("Neighborhood")
+	}
+	return query
+}
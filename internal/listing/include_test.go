@@ -0,0 +1,68 @@
+package listing
+
+import (
+	"encoding/json"
+	"testing"
+
+	"seattle_info_backend/internal/category"
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/filestorage"
+	"seattle_info_backend/internal/user"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIncludes(t *testing.T) {
+	in, err := ParseIncludes(" User,images ,")
+	require.NoError(t, err)
+	assert.Equal(t, Includes{IncludeUser: true, IncludeImages: true}, in)
+	assert.True(t, in.Has(IncludeUser))
+	assert.False(t, in.Has(IncludeDetails))
+
+	in, err = ParseIncludes("")
+	require.NoError(t, err)
+	assert.False(t, in.Has(IncludeCategory), "an empty include loads nothing")
+	assert.True(t, Includes(nil).Has(IncludeCategory), "no include loads everything")
+
+	_, err = ParseIncludes("user,owner")
+	assert.ErrorIs(t, err, common.ErrBadRequest)
+}
+
+func TestListingResponseOmitsAssociationsNotLoaded(t *testing.T) {
+	imageURLs := filestorage.NewImageURLBuilder(&config.Config{})
+	l := &Listing{Title: "Desk"}
+	l.ID = uuid.New()
+
+	encoded, err := json.Marshal(ToListingResponse(l, imageURLs))
+	require.NoError(t, err)
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(encoded, &fields))
+	assert.NotContains(t, fields, "user")
+	assert.NotContains(t, fields, "category")
+
+	l.User = &user.User{}
+	l.User.ID = uuid.New()
+	l.Category = category.Category{BaseModel: common.BaseModel{ID: uuid.New()}, Name: "For Sale"}
+	resp := ToListingResponse(l, imageURLs)
+	require.NotNil(t, resp.User)
+	assert.Equal(t, l.User.ID, resp.User.ID)
+	require.NotNil(t, resp.Category)
+	assert.Equal(t, "For Sale", resp.Category.Name)
+}
+
+func TestListingResponseNeighborhood(t *testing.T) {
+	l := &Listing{Title: "Desk"}
+	assert.Nil(t, ToListingResponse(l, nil).Neighborhood)
+
+	l.Neighborhood = &Neighborhood{ID: uuid.New(), Name: "Capitol Hill", Slug: "capitol-hill"}
+	resp := ToListingResponse(l, nil)
+	require.NotNil(t, resp.Neighborhood)
+	assert.Equal(t, "capitol-hill", resp.Neighborhood.Slug)
+
+	in, err := ParseIncludes("neighborhood")
+	require.NoError(t, err)
+	assert.True(t, in.Has(IncludeNeighborhood))
+}
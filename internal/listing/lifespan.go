@@ -0,0 +1,68 @@
+// File: internal/listing/lifespan.go
+package listing
+
+import (
+	"context"
+	"time"
+
+	"seattle_info_backend/internal/appconfig"
+	"seattle_info_backend/internal/category"
+
+	"go.uber.org/zap"
+)
+
+// computeExpiresAt returns the expiry time of l, a listing in cat, that goes live at start.
+// Events expire at the end of their event day, or of their last occurrence when they repeat until a date;
+// other listings after the category's lifespan_days, or the default lifespan when it has none.
+func (s *ServiceImplementation) computeExpiresAt(ctx context.Context, cat *category.Category, l *Listing, start time.Time) time.Time {
+	if end, ok := eventExpiresAt(cat, l, s.eventsLocation()); ok {
+		return end
+	}
+	return start.AddDate(0, 0, s.lifespanDays(ctx, cat))
+}
+
+// lifespanDays returns how many days listings in cat stay live: the category's override when set, else
+// DEFAULT_LISTING_LIFESPAN_DAYS from app_configurations, falling back to the value from .env.
+func (s *ServiceImplementation) lifespanDays(ctx context.Context, cat *category.Category) int {
+	if cat != nil && cat.LifespanDays != nil && *cat.LifespanDays > 0 {
+		return *cat.LifespanDays
+	}
+	lifespanDays := s.cfg.DefaultListingLifespanDays
+	configLifespan, err := s.appConfig.GetInt(ctx, appconfig.KeyDefaultListingLifespanDays)
+	if err == nil && configLifespan > 0 {
+		lifespanDays = configLifespan
+	} else if err != nil {
+		s.logger.Warn("Could not parse DEFAULT_LISTING_LIFESPAN_DAYS from app_configurations, using default from .env", zap.Error(err))
+	}
+	return lifespanDays
+}
+
+// eventExpiresAt returns midnight after the last day of an event listing in its time zone, or loc when it has none.
+// It returns false for listings outside the events category, events without details and events that repeat
+// without an end date, which expire after the lifespan instead.
+func eventExpiresAt(cat *category.Category, l *Listing, loc *time.Location) (time.Time, bool) {
+	if cat == nil || cat.Slug != "events" || l.EventDetails == nil {
+		return time.Time{}, false
+	}
+	lastDay := l.EventDetails.EventDate
+	if l.EventDetails.IsRecurring() {
+		if l.EventDetails.RecurrenceUntil == nil {
+			return time.Time{}, false
+		}
+		lastDay = *l.EventDetails.RecurrenceUntil
+	}
+	if lastDay.IsZero() {
+		return time.Time{}, false
+	}
+	return dateOnly(lastDay, l.EventDetails.Location(loc)).AddDate(0, 0, 1), true
+}
+
+// eventsLocation returns the EVENTS_TIMEZONE location event dates are entered in, or UTC when it is invalid.
+func (s *ServiceImplementation) eventsLocation() *time.Location {
+	loc, err := time.LoadLocation(s.cfg.EventsTimezone)
+	if err != nil {
+		s.logger.Warn("Invalid EVENTS_TIMEZONE, falling back to UTC", zap.String("timezone", s.cfg.EventsTimezone), zap.Error(err))
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,64 @@
+package listing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/appconfig"
+	"seattle_info_backend/internal/category"
+	"seattle_info_backend/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// lifespanConfig serves DEFAULT_LISTING_LIFESPAN_DAYS from app_configurations.
+type lifespanConfig struct {
+	appconfig.Service
+	days int
+}
+
+func (c lifespanConfig) GetInt(_ context.Context, _ string) (int, error) {
+	return c.days, nil
+}
+
+func TestComputeExpiresAt(t *testing.T) {
+	svc := &ServiceImplementation{
+		appConfig: lifespanConfig{days: 30},
+		cfg:       &config.Config{DefaultListingLifespanDays: 14, EventsTimezone: "America/Los_Angeles"},
+		logger:    zap.NewNop(),
+	}
+	ctx := context.Background()
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	sixty := 60
+
+	forSale := &category.Category{Slug: "for-sale"}
+	assert.Equal(t, start.AddDate(0, 0, 30), svc.computeExpiresAt(ctx, forSale, &Listing{}, start), "the default lifespan")
+
+	housing := &category.Category{Slug: "housing", LifespanDays: &sixty}
+	assert.Equal(t, start.AddDate(0, 0, 60), svc.computeExpiresAt(ctx, housing, &Listing{}, start), "the category overrides the default")
+
+	events := &category.Category{Slug: "events", LifespanDays: &sixty}
+	seattle, _ := time.LoadLocation("America/Los_Angeles")
+	oneOff := &Listing{EventDetails: &ListingDetailsEvents{EventDate: time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)}}
+	assert.WithinDuration(t, time.Date(2025, 6, 21, 0, 0, 0, 0, seattle), svc.computeExpiresAt(ctx, events, oneOff, start), 0,
+		"events expire at the end of their event day")
+
+	weekly := EventRecursWeekly
+	until := time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC)
+	repeating := &Listing{EventDetails: &ListingDetailsEvents{
+		EventDate:           time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC),
+		RecurrenceFrequency: &weekly,
+		RecurrenceInterval:  1,
+		RecurrenceUntil:     &until,
+	}}
+	assert.WithinDuration(t, time.Date(2025, 9, 1, 0, 0, 0, 0, seattle), svc.computeExpiresAt(ctx, events, repeating, start), 0,
+		"repeating events expire after their end date")
+
+	repeating.EventDetails.RecurrenceUntil = nil
+	assert.Equal(t, start.AddDate(0, 0, 60), svc.computeExpiresAt(ctx, events, repeating, start),
+		"events repeating without an end date use the lifespan")
+	assert.Equal(t, start.AddDate(0, 0, 60), svc.computeExpiresAt(ctx, events, &Listing{}, start),
+		"drafts without event details use the lifespan")
+}
@@ -0,0 +1,101 @@
+// File: internal/listing/locationprivacy.go
+package listing
+
+import (
+	"math"
+	"regexp"
+	"strings"
+)
+
+// LocationPrivacy is how precisely a listing's location is shown to anyone but its owner and admins.
+type LocationPrivacy string
+
+const (
+	LocationExact        LocationPrivacy = "exact"        // Full address and coordinates
+	LocationStreet       LocationPrivacy = "street"       // Street without house number or unit; coordinates rounded to about 100 m
+	LocationNeighborhood LocationPrivacy = "neighborhood" // No address lines or coordinates; the neighborhood, city and ZIP code remain
+)
+
+// streetLevelGridDegrees is the grid street-level coordinates are snapped to. The listings location trigger snaps
+// public_location, which searches and map clusters use, to the same grid.
+const streetLevelGridDegrees = 0.001
+
+// houseNumberPattern matches the house number at the start of an address line, e.g. "1234 " or "12-B ".
+var houseNumberPattern = regexp.MustCompile(`^\s*\d+[A-Za-z]?(-\w+)?\s+`)
+
+// orExact returns p, or LocationExact when p is not set.
+func (p LocationPrivacy) orExact() LocationPrivacy {
+	if p == "" {
+		return LocationExact
+	}
+	return p
+}
+
+// applyLocationPrivacy hides what the listing's LocationPrivacy keeps from other viewers.
+func applyLocationPrivacy(resp *ListingResponse, l *Listing) {
+	resp.LocationPrivacy = l.LocationPrivacy.orExact()
+	switch resp.LocationPrivacy {
+	case LocationStreet:
+		resp.AddressLine1 = streetOnly(l.AddressLine1)
+		resp.AddressLine2 = nil
+		resp.Latitude = snapToGrid(l.Latitude, streetLevelGridDegrees)
+		resp.Longitude = snapToGrid(l.Longitude, streetLevelGridDegrees)
+		if resp.Latitude != nil && resp.Longitude != nil {
+			resp.Location = &PostGISPoint{Lat: *resp.Latitude, Lon: *resp.Longitude}
+		} else {
+			resp.Location = nil
+		}
+	case LocationNeighborhood:
+		resp.AddressLine1, resp.AddressLine2 = nil, nil
+		resp.Latitude, resp.Longitude, resp.Location = nil, nil, nil
+	}
+}
+
+// publicAddressLine returns the listing's first address line as other viewers may see it.
+func (l *Listing) publicAddressLine() *string {
+	switch l.LocationPrivacy.orExact() {
+	case LocationStreet:
+		return streetOnly(l.AddressLine1)
+	case LocationNeighborhood:
+		return nil
+	default:
+		return l.AddressLine1
+	}
+}
+
+// publicCoordinates returns the listing's coordinates as other viewers may see them, or false when they are hidden.
+func (l *Listing) publicCoordinates() (lat, lon float64, ok bool) {
+	if l.Latitude == nil || l.Longitude == nil {
+		return 0, 0, false
+	}
+	switch l.LocationPrivacy.orExact() {
+	case LocationStreet:
+		return *snapToGrid(l.Latitude, streetLevelGridDegrees), *snapToGrid(l.Longitude, streetLevelGridDegrees), true
+	case LocationNeighborhood:
+		return 0, 0, false
+	default:
+		return *l.Latitude, *l.Longitude, true
+	}
+}
+
+// streetOnly drops the house number from an address line. Lines that are only a number are dropped entirely.
+func streetOnly(line *string) *string {
+	if line == nil {
+		return nil
+	}
+	street := strings.TrimSpace(houseNumberPattern.ReplaceAllString(*line+" ", ""))
+	if street == "" {
+		return nil
+	}
+	return &street
+}
+
+// snapToGrid rounds v to the nearest multiple of step.
+func snapToGrid(v *float64, step float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	snapped := math.Round(*v/step) * step
+	snapped = math.Round(snapped*1e6) / 1e6 // Drop floating point noise such as 47.609000000000002
+	return &snapped
+}
@@ -0,0 +1,61 @@
+package listing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func privacyTestListing(privacy LocationPrivacy) *Listing {
+	line1, line2 := "1234 Pike St", "Apt 5"
+	lat, lon := 47.609123, -122.340678
+	return &Listing{
+		LocationPrivacy: privacy,
+		AddressLine1:    &line1,
+		AddressLine2:    &line2,
+		Latitude:        &lat,
+		Longitude:       &lon,
+		Location:        &PostGISPoint{Lat: lat, Lon: lon},
+	}
+}
+
+func TestToListingResponseAppliesLocationPrivacy(t *testing.T) {
+	exact := ToListingResponse(privacyTestListing(""), nil)
+	assert.Equal(t, LocationExact, exact.LocationPrivacy, "listings default to exact")
+	assert.Equal(t, "1234 Pike St", *exact.AddressLine1)
+	assert.Equal(t, 47.609123, *exact.Latitude)
+
+	street := ToListingResponse(privacyTestListing(LocationStreet), nil)
+	require.NotNil(t, street.AddressLine1)
+	assert.Equal(t, "Pike St", *street.AddressLine1)
+	assert.Nil(t, street.AddressLine2)
+	assert.Equal(t, 47.609, *street.Latitude)
+	assert.Equal(t, -122.341, *street.Longitude)
+	assert.Equal(t, &PostGISPoint{Lat: 47.609, Lon: -122.341}, street.Location)
+
+	neighborhood := ToListingResponse(privacyTestListing(LocationNeighborhood), nil)
+	assert.Nil(t, neighborhood.AddressLine1)
+	assert.Nil(t, neighborhood.Latitude)
+	assert.Nil(t, neighborhood.Location)
+
+	owner := ToOwnerListingResponse(privacyTestListing(LocationNeighborhood), nil)
+	assert.Equal(t, LocationNeighborhood, owner.LocationPrivacy)
+	assert.Equal(t, "Apt 5", *owner.AddressLine2, "owners see the exact location")
+	assert.Equal(t, 47.609123, *owner.Latitude)
+}
+
+func TestStreetOnly(t *testing.T) {
+	for in, want := range map[string]string{
+		"1234 Pike St":    "Pike St",
+		"12-B 3rd Ave NW": "3rd Ave NW",
+		"Pike Place":      "Pike Place",
+	} {
+		got := streetOnly(&in)
+		require.NotNil(t, got, in)
+		assert.Equal(t, want, *got, in)
+	}
+	number := "1234"
+	assert.Nil(t, streetOnly(&number))
+	assert.Nil(t, streetOnly(nil))
+}
@@ -0,0 +1,64 @@
+// File: internal/listing/mapclusters.go
+package listing
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"seattle_info_backend/internal/common"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// maxMapZoom is the highest web map zoom level accepted by the map-clusters endpoint.
+	maxMapZoom = 22
+	// mapClusterCellsPerTile is how many grid cells span one 256px map tile, i.e. one cluster per 64px.
+	mapClusterCellsPerTile = 4
+	// maxMapClusters caps the number of clusters returned for one viewport; the largest are kept.
+	maxMapClusters = 1000
+)
+
+// MapClusterQuery is the query for GET /listings/map-clusters. It takes the listing search filters plus the map zoom.
+type MapClusterQuery struct {
+	ListingSearchQuery
+	Zoom *int `form:"zoom" json:"zoom"`
+}
+
+// MapCluster is a group of listings in one grid cell, drawn as a single map pin.
+type MapCluster struct {
+	Count     int64   `json:"count"`
+	Latitude  float64 `json:"latitude"`  // Centroid of the clustered listings
+	Longitude float64 `json:"longitude"` // Centroid of the clustered listings
+}
+
+// mapClusterGridSize returns the grid cell size in degrees for a zoom level. The world is 360 degrees wide
+// at zoom 0 and every zoom level halves the width of a tile.
+func mapClusterGridSize(zoom int) float64 {
+	return 360 / math.Pow(2, float64(zoom)) / mapClusterCellsPerTile
+}
+
+// GetMapClusters groups the listings matching the search filters inside the viewport into grid cells sized
+// for the zoom level, so zoomed-out maps draw one pin per cell instead of one per listing.
+func (s *ServiceImplementation) GetMapClusters(ctx context.Context, query MapClusterQuery) ([]MapCluster, error) {
+	if query.BBox == "" {
+		return nil, common.ErrBadRequest.WithDetails("bbox is required.")
+	}
+	if query.Zoom == nil {
+		return nil, common.ErrBadRequest.WithDetails("zoom is required.")
+	}
+	if *query.Zoom < 0 || *query.Zoom > maxMapZoom {
+		return nil, common.ErrBadRequest.WithDetails(fmt.Sprintf("zoom must be between 0 and %d.", maxMapZoom))
+	}
+	if err := s.prepareSearchQuery(ctx, &query.ListingSearchQuery); err != nil {
+		return nil, err
+	}
+
+	clusters, err := s.repo.MapClusters(ctx, query.ListingSearchQuery, mapClusterGridSize(*query.Zoom), maxMapClusters)
+	if err != nil {
+		s.logger.Error("Failed to cluster listings for map", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve map clusters.")
+	}
+	return clusters, nil
+}
@@ -0,0 +1,13 @@
+package listing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapClusterGridSizeHalvesPerZoomLevel(t *testing.T) {
+	assert.InDelta(t, 90, mapClusterGridSize(0), 1e-9, "four cells span the world at zoom 0")
+	assert.InDelta(t, 45, mapClusterGridSize(1), 1e-9)
+	assert.InDelta(t, 360.0/4096/4, mapClusterGridSize(12), 1e-12)
+}
@@ -3,6 +3,7 @@ package listing
 
 import (
 	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -10,6 +11,8 @@ import (
 
 	"seattle_info_backend/internal/category" // For Category and SubCategory response in Listing
 	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/filestorage"
+	"seattle_info_backend/internal/platform/geo"
 	"seattle_info_backend/internal/shared"
 	"seattle_info_backend/internal/user" // For user.User
 
@@ -61,6 +64,38 @@ func (p *PostGISPoint) Scan(value interface{}) error {
 	return errors.New("failed to scan PostGISPoint: invalid format, expected POINT(lon lat)")
 }
 
+// ListingAttributes holds a listing's values for its category's custom attributes, stored as JSONB.
+type ListingAttributes map[string]interface{}
+
+// Value implements the driver.Valuer interface for ListingAttributes.
+func (a ListingAttributes) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface for ListingAttributes.
+func (a *ListingAttributes) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	case nil:
+		*a = ListingAttributes{}
+		return nil
+	default:
+		return errors.New("failed to scan ListingAttributes: invalid type")
+	}
+	return json.Unmarshal(b, a)
+}
+
 // --- Main Listing Model ---
 type ListingStatus string
 
@@ -70,8 +105,57 @@ const (
 	StatusExpired         ListingStatus = "expired"
 	StatusRejected        ListingStatus = "rejected"
 	StatusAdminRemoved    ListingStatus = "admin_removed"
+	StatusDraft           ListingStatus = "draft"
+	StatusScheduled       ListingStatus = "scheduled" // Published, waiting for PublishAt to go live
 )
 
+// PricePeriod describes what a listing's price covers.
+type PricePeriod string
+
+const (
+	PriceOneTime PricePeriod = "one_time"
+	PriceHourly  PricePeriod = "hourly"
+	PriceDaily   PricePeriod = "daily"
+	PriceWeekly  PricePeriod = "weekly"
+	PriceMonthly PricePeriod = "monthly"
+	PriceYearly  PricePeriod = "yearly"
+)
+
+// RejectionReason tells a listing's owner why an admin declined it.
+type RejectionReason string
+
+const (
+	RejectionSpam              RejectionReason = "spam"
+	RejectionProhibitedContent RejectionReason = "prohibited_content"
+	RejectionDuplicate         RejectionReason = "duplicate"
+	RejectionIncomplete        RejectionReason = "incomplete"
+	RejectionWrongCategory     RejectionReason = "wrong_category"
+	RejectionMisleading        RejectionReason = "misleading"
+	RejectionOther             RejectionReason = "other"
+)
+
+// rejectionReasonDescriptions are the explanations sent to owners in rejection notifications.
+var rejectionReasonDescriptions = map[RejectionReason]string{
+	RejectionSpam:              "it looks like spam",
+	RejectionProhibitedContent: "it offers something that is not allowed on the site",
+	RejectionDuplicate:         "it duplicates another listing",
+	RejectionIncomplete:        "it is missing important information",
+	RejectionWrongCategory:     "it was posted in the wrong category",
+	RejectionMisleading:        "it contains misleading information",
+	RejectionOther:             "it does not meet our posting guidelines",
+}
+
+// Description returns the owner-facing explanation of the reason.
+func (r RejectionReason) Description() string {
+	if d, ok := rejectionReasonDescriptions[r]; ok {
+		return d
+	}
+	return rejectionReasonDescriptions[RejectionOther]
+}
+
+// DefaultPriceCurrency is used when a price is given without a currency.
+const DefaultPriceCurrency = "USD"
+
 type Listing struct {
 	common.BaseModel
 	UserID        uuid.UUID             `gorm:"type:uuid;not null"`
@@ -81,6 +165,7 @@ type Listing struct {
 	SubCategoryID *uuid.UUID            `gorm:"type:uuid"`
 	SubCategory   *category.SubCategory `gorm:"foreignKey:SubCategoryID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
 	Title         string                `gorm:"type:varchar(255);not null"`
+	Slug          string                `gorm:"type:varchar(100);not null;uniqueIndex:idx_listings_slug"` // Readable URL name, kept in step with Title
 	Description   string                `gorm:"type:text;not null"`
 	Status        ListingStatus         `gorm:"type:varchar(50);not null;default:'active'"`
 	ContactName   *string               `gorm:"type:varchar(150)"`
@@ -95,12 +180,38 @@ type Listing struct {
 	Longitude     *float64              `gorm:"type:decimal(11,8)"`
 	Location      *PostGISPoint         `gorm:"-"`
 	LocationWKT   string                `gorm:"column:location_wkt;->:false"`
+	DistanceKM    *float64              `gorm:"column:distance_km;->"` // Populated only by location-aware searches
+	Highlights    map[string][]string   `gorm:"-"`                     // Populated only by keyword searches, see applyHighlights
+	PriceAmount   *float64              `gorm:"type:numeric(12,2)"`
+	PriceCurrency *string               `gorm:"type:varchar(3)"` // ISO 4217 code, e.g. USD
+	PricePeriod   *PricePeriod          `gorm:"type:varchar(20)"`
+	Attributes    ListingAttributes     `gorm:"type:jsonb;not null;default:'{}'"` // Values for the category's custom attributes
+
+	// Set by admins for special cases: the listing may have coordinates outside the service area (SERVICE_AREA_*)
+	OutsideServiceAreaAllowed bool `gorm:"not null;default:false"`
+
+	// How precisely other viewers see the address and coordinates; the database keeps public_location in step
+	LocationPrivacy LocationPrivacy `gorm:"type:varchar(20);not null;default:'exact'"`
+
+	BusinessHours *BusinessHours `gorm:"type:jsonb"` // Business listings only
 
 	ExpiresAt          time.Time                  `gorm:"not null"`
+	PublishAt          *time.Time                 // Scheduled go-live time; the listing stays hidden until then
+	FeaturedUntil      *time.Time                 // Sorted ahead of other listings until then
 	IsAdminApproved    bool                       `gorm:"not null;default:false"`
+	ModerationFlags    pq.StringArray             `gorm:"type:text[]"`        // Reasons content moderation sent this listing to review
+	SpamScore          int                        `gorm:"not null;default:0"` // Heuristic spam score from when the listing was published
+	QualityScore       int                        `gorm:"not null;default:0"` // Completeness from 0 to 100, see computeQuality
+	QualityHints       pq.StringArray             `gorm:"type:text[]"`        // What the owner could add to raise QualityScore
+	CreatedIP          *string                    `gorm:"type:varchar(45)"`   // Client address the listing was submitted from
+	AdminNotes         *string                    `gorm:"type:text"`          // Notes from the latest admin status decision
+	RejectionReason    *RejectionReason           `gorm:"type:varchar(50)"`   // Set only while the listing is rejected
+	NeighborhoodID     *uuid.UUID                 `gorm:"type:uuid;->"`       // Assigned from the location by a database trigger
+	Neighborhood       *Neighborhood              `gorm:"foreignKey:NeighborhoodID;references:ID"`
 	BabysittingDetails *ListingDetailsBabysitting `gorm:"foreignKey:ListingID;references:ID;constraint:OnDelete:CASCADE;"`
 	HousingDetails     *ListingDetailsHousing     `gorm:"foreignKey:ListingID;references:ID;constraint:OnDelete:CASCADE;"`
 	EventDetails       *ListingDetailsEvents      `gorm:"foreignKey:ListingID;references:ID;constraint:OnDelete:CASCADE;"`
+	JobDetails         *ListingDetailsJobs        `gorm:"foreignKey:ListingID;references:ID;constraint:OnDelete:CASCADE;"`
 	Images             []ListingImage             `gorm:"foreignKey:ListingID;constraint:OnDelete:CASCADE;"`
 }
 
@@ -108,6 +219,17 @@ func (Listing) TableName() string {
 	return "listings"
 }
 
+// DailyViews counts the detail page views of a listing on one UTC day.
+type DailyViews struct {
+	ListingID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Day       time.Time `gorm:"type:date;primaryKey"`
+	Views     int       `gorm:"not null;default:0"`
+}
+
+func (DailyViews) TableName() string {
+	return "listing_daily_views"
+}
+
 // --- Listing Image Model ---
 type ListingImage struct {
 	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
@@ -115,28 +237,56 @@ type ListingImage struct {
 	ImagePath string    `json:"-" gorm:"type:text;not null"` // Relative path within IMAGE_STORAGE_PATH, not directly exposed
 	ImageURL  string    `json:"image_url" gorm:"-"`          // Dynamically generated, not stored in DB
 	SortOrder int       `json:"sort_order" gorm:"default:0"`
+	AltText   *string   `json:"alt_text,omitempty" gorm:"type:varchar(250)"` // Describes the image for screen readers
+	Caption   *string   `json:"caption,omitempty" gorm:"type:varchar(500)"`
 	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"` // For GORM to auto-update
+
+	// The virus scan result, kept for audit. The columns are create-only so that saving a listing loaded before
+	// the scan finished does not undo it; the scan updates them through Repository.UpdateImageScan.
+	ScanStatus ImageScanStatus `json:"scan_status" gorm:"<-:create;type:varchar(20);not null;default:'unscanned'"`
+	ScanResult *string         `json:"scan_result,omitempty" gorm:"<-:create;type:text"` // Signature of the malware found
+	ScannedAt  *time.Time      `json:"scanned_at,omitempty" gorm:"<-:create"`
 }
 
+// ImageScanStatus is where a listing image is in the virus scan.
+type ImageScanStatus string
+
+const (
+	ScanUnscanned ImageScanStatus = "unscanned" // Added while scanning was disabled; served as is
+	ScanPending   ImageScanStatus = "pending"   // Quarantined until the scan task runs
+	ScanClean     ImageScanStatus = "clean"
+	ScanInfected  ImageScanStatus = "infected" // The file was deleted; the row is kept as a record and never loaded with its listing
+)
+
 func (ListingImage) TableName() string {
 	return "listing_images"
 }
 
 // PopulateImageURL generates the full URL for an image.
-// It needs the base URL from config. This function would typically be called
+// The URL is signed and expiring when image URL signing is configured.
+// This function would typically be called
 // in the service layer or when transforming the model to a response DTO.
-func (li *ListingImage) PopulateImageURL(baseURL string) {
+func (li *ListingImage) PopulateImageURL(imageURLs *filestorage.ImageURLBuilder) {
 	if li.ImagePath != "" {
-		// Ensure no double slashes if baseURL already ends with / and ImagePath starts with /
-		li.ImageURL = strings.TrimSuffix(baseURL, "/") + "/" + strings.TrimPrefix(li.ImagePath, "/")
+		li.ImageURL = imageURLs.URL(li.ImagePath)
 	}
 }
 
 // --- Listing Detail Models ---
 type ListingDetailsBabysitting struct {
-	ListingID       uuid.UUID      `gorm:"type:uuid;primaryKey"`
-	LanguagesSpoken pq.StringArray `gorm:"type:text[]"`
+	ListingID       uuid.UUID      `gorm:"type:uuid;primaryKey" json:"-"`
+	LanguagesSpoken pq.StringArray `gorm:"type:text[]" json:"languages_spoken"`
+
+	// Provider profile
+	Availability    *BusinessHours `gorm:"type:jsonb" json:"availability,omitempty"` // Weekly hours the provider can take bookings
+	HourlyRate      *float64       `gorm:"type:numeric(8,2)" json:"hourly_rate,omitempty"`
+	YearsExperience *int           `gorm:"type:smallint" json:"years_experience,omitempty"`
+	Certifications  pq.StringArray `gorm:"type:text[]" json:"certifications,omitempty"` // e.g. "CPR", "First Aid"
+
+	// Set by admins when they verify the provider's background check (see backgroundcheck.go); never by owners
+	VerifiedBadge bool       `gorm:"not null;default:false" json:"verified_badge"`
+	VerifiedAt    *time.Time `json:"verified_at,omitempty"`
 }
 
 func (ListingDetailsBabysitting) TableName() string {
@@ -151,66 +301,213 @@ const (
 )
 
 type ListingDetailsHousing struct {
-	ListingID    uuid.UUID           `gorm:"type:uuid;primaryKey"`
-	PropertyType HousingPropertyType `gorm:"type:varchar(50);not null"`
-	RentDetails  *string             `gorm:"type:varchar(255)"`
-	SalePrice    *float64            `gorm:"type:numeric(12,2)"`
+	ListingID     uuid.UUID           `json:"-" gorm:"type:uuid;primaryKey"`
+	PropertyType  HousingPropertyType `json:"property_type" gorm:"type:varchar(50);not null"`
+	RentDetails   *string             `json:"rent_details,omitempty" gorm:"type:varchar(255)"` // Deprecated free text; rewritten to match RentAmount when that is set
+	SalePrice     *float64            `json:"sale_price,omitempty" gorm:"type:numeric(12,2)"`
+	RentAmount    *float64            `json:"rent_amount,omitempty" gorm:"type:numeric(12,2)"`
+	RentPeriod    *PricePeriod        `json:"rent_period,omitempty" gorm:"type:varchar(20)"` // daily, weekly, monthly or yearly
+	Bedrooms      *int                `json:"bedrooms,omitempty" gorm:"type:smallint"`       // 0 for studios
+	Bathrooms     *float64            `json:"bathrooms,omitempty" gorm:"type:numeric(3,1)"`
+	SquareFeet    *int                `json:"square_feet,omitempty"`
+	PetPolicy     *HousingPetPolicy   `json:"pet_policy,omitempty" gorm:"type:varchar(20)"`
+	Parking       *HousingParking     `json:"parking,omitempty" gorm:"type:varchar(20)"`
+	AvailableFrom *time.Time          `json:"available_from,omitempty" gorm:"type:date"`
 }
 
 func (ListingDetailsHousing) TableName() string {
 	return "listing_details_housing"
 }
 
+type EventRecurrenceFrequency string
+
+const (
+	EventRecursWeekly  EventRecurrenceFrequency = "weekly"
+	EventRecursMonthly EventRecurrenceFrequency = "monthly"
+)
+
 type ListingDetailsEvents struct {
-	ListingID     uuid.UUID `gorm:"type:uuid;primaryKey"`
-	EventDate     time.Time `gorm:"type:date;not null"`
-	EventTime     *string   `gorm:"type:time"`
-	OrganizerName *string   `gorm:"type:varchar(150)"`
-	VenueName     *string   `gorm:"type:varchar(255)"`
+	ListingID           uuid.UUID                 `gorm:"type:uuid;primaryKey"`
+	EventDate           time.Time                 `gorm:"type:date;not null"` // First occurrence for recurring events
+	EventTime           *string                   `gorm:"type:time"`
+	Timezone            string                    `gorm:"type:varchar(64);not null"` // IANA zone EventDate and EventTime are in
+	StartsAt            time.Time                 `gorm:"not null"`                  // EventDate and EventTime in Timezone, stored in UTC
+	OrganizerName       *string                   `gorm:"type:varchar(150)"`
+	VenueName           *string                   `gorm:"type:varchar(255)"`
+	RecurrenceFrequency *EventRecurrenceFrequency `gorm:"type:varchar(10)"`   // Nil for one-off events
+	RecurrenceInterval  int                       `gorm:"not null;default:1"` // Repeat every N weeks or months
+	RecurrenceUntil     *time.Time                `gorm:"type:date"`          // Last date an occurrence may fall on; nil repeats indefinitely
+	NextOccurrence      *time.Time                `gorm:"-"`                  // Set when listing upcoming events
 }
 
 func (ListingDetailsEvents) TableName() string {
 	return "listing_details_events"
 }
 
+type JobEmploymentType string
+
+const (
+	JobFullTime   JobEmploymentType = "full_time"
+	JobPartTime   JobEmploymentType = "part_time"
+	JobContract   JobEmploymentType = "contract"
+	JobTemporary  JobEmploymentType = "temporary"
+	JobInternship JobEmploymentType = "internship"
+)
+
+type JobWorkplaceType string
+
+const (
+	JobOnSite JobWorkplaceType = "on_site"
+	JobRemote JobWorkplaceType = "remote"
+	JobHybrid JobWorkplaceType = "hybrid"
+)
+
+type ListingDetailsJobs struct {
+	ListingID      uuid.UUID         `json:"-" gorm:"type:uuid;primaryKey"`
+	EmploymentType JobEmploymentType `json:"employment_type" gorm:"type:varchar(30);not null"`
+	WorkplaceType  JobWorkplaceType  `json:"workplace_type" gorm:"type:varchar(20);not null"`
+	SalaryMin      *float64          `json:"salary_min,omitempty" gorm:"type:numeric(12,2)"`
+	SalaryMax      *float64          `json:"salary_max,omitempty" gorm:"type:numeric(12,2)"`
+	SalaryCurrency *string           `json:"salary_currency,omitempty" gorm:"type:varchar(3)"`
+	SalaryPeriod   *PricePeriod      `json:"salary_period,omitempty" gorm:"type:varchar(20)"` // hourly, daily, weekly, monthly or yearly
+	ApplicationURL *string           `json:"application_url,omitempty" gorm:"type:varchar(500)"`
+}
+
+func (ListingDetailsJobs) TableName() string {
+	return "listing_details_jobs"
+}
+
 // --- DTOs for API ---
 type CreateListingBabysittingDetailsRequest struct {
 	LanguagesSpoken []string `json:"languages_spoken" binding:"omitempty,dive,max=50"`
+
+	// Provider profile; on update, fields left out keep their value
+	Availability    *BusinessHours `json:"availability,omitempty"`
+	HourlyRate      *float64       `json:"hourly_rate,omitempty" binding:"omitempty,gt=0,max=1000"`
+	YearsExperience *int           `json:"years_experience,omitempty" binding:"omitempty,min=0,max=80"`
+	Certifications  []string       `json:"certifications,omitempty" binding:"omitempty,max=20,dive,min=1,max=100"`
 }
 
 type CreateListingHousingDetailsRequest struct {
-	PropertyType HousingPropertyType `json:"property_type" binding:"required,oneof=for_rent for_sale"`
-	RentDetails  *string             `json:"rent_details,omitempty" binding:"omitempty,max=255"`
-	SalePrice    *float64            `json:"sale_price,omitempty" binding:"omitempty,gt=0"`
+	PropertyType  HousingPropertyType `json:"property_type" binding:"required,oneof=for_rent for_sale"`
+	RentDetails   *string             `json:"rent_details,omitempty" binding:"omitempty,max=255"` // Deprecated; send rent_amount and rent_period
+	SalePrice     *float64            `json:"sale_price,omitempty" binding:"omitempty,gt=0"`
+	RentAmount    *float64            `json:"rent_amount,omitempty" binding:"omitempty,gt=0"`
+	RentPeriod    *PricePeriod        `json:"rent_period,omitempty" binding:"omitempty,oneof=daily weekly monthly yearly"`
+	Bedrooms      *int                `json:"bedrooms,omitempty" binding:"omitempty,min=0,max=50"`
+	Bathrooms     *float64            `json:"bathrooms,omitempty" binding:"omitempty,min=0,max=50"` // In steps of 0.5
+	SquareFeet    *int                `json:"square_feet,omitempty" binding:"omitempty,min=1,max=1000000"`
+	PetPolicy     *HousingPetPolicy   `json:"pet_policy,omitempty" binding:"omitempty,oneof=no_pets cats_only dogs_only cats_and_dogs"`
+	Parking       *HousingParking     `json:"parking,omitempty" binding:"omitempty,oneof=none street off_street garage"`
+	AvailableFrom *string             `json:"available_from,omitempty" binding:"omitempty,datetime=2006-01-02"`
 }
 
+// CreateListingEventDetailsRequest describes an event. EventDate and EventTime are in Timezone (EVENTS_TIMEZONE when
+// left out); StartsAt, an RFC 3339 time with an offset, can be sent instead of both.
 type CreateListingEventDetailsRequest struct {
-	EventDate     string  `json:"event_date" binding:"required,datetime=2006-01-02"`
-	EventTime     *string `json:"event_time,omitempty" binding:"omitempty,datetime=15:04:05"`
-	OrganizerName *string `json:"organizer_name,omitempty" binding:"omitempty,max=150"`
-	VenueName     *string `json:"venue_name,omitempty" binding:"omitempty,max=255"`
+	EventDate     string     `json:"event_date,omitempty" binding:"required_without=StartsAt,omitempty,datetime=2006-01-02"`
+	EventTime     *string    `json:"event_time,omitempty" binding:"omitempty,datetime=15:04:05"`
+	StartsAt      *time.Time `json:"starts_at,omitempty"`
+	Timezone      *string    `json:"timezone,omitempty" binding:"omitempty,timezone"`
+	OrganizerName *string    `json:"organizer_name,omitempty" binding:"omitempty,max=150"`
+	VenueName     *string    `json:"venue_name,omitempty" binding:"omitempty,max=255"`
+	// Recurrence makes the event repeat; on update it replaces the existing rule.
+	Recurrence *EventRecurrenceRequest `json:"recurrence,omitempty"`
+}
+
+// EventRecurrenceRequest is an RRULE-style repeat rule: every Interval weeks or months from the event date, until Until.
+type EventRecurrenceRequest struct {
+	Frequency EventRecurrenceFrequency `json:"frequency" binding:"required,oneof=weekly monthly" validate:"required,oneof=weekly monthly"`
+	Interval  int                      `json:"interval,omitempty" binding:"omitempty,min=1,max=12" validate:"omitempty,min=1,max=12"`
+	Until     *string                  `json:"until,omitempty" binding:"omitempty,datetime=2006-01-02" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type CreateListingJobDetailsRequest struct {
+	EmploymentType JobEmploymentType `json:"employment_type" binding:"required,oneof=full_time part_time contract temporary internship" validate:"required,oneof=full_time part_time contract temporary internship"`
+	WorkplaceType  JobWorkplaceType  `json:"workplace_type" binding:"required,oneof=on_site remote hybrid" validate:"required,oneof=on_site remote hybrid"`
+	SalaryMin      *float64          `json:"salary_min,omitempty" binding:"omitempty,gte=0" validate:"omitempty,gte=0"`
+	SalaryMax      *float64          `json:"salary_max,omitempty" binding:"omitempty,gte=0" validate:"omitempty,gte=0"`
+	SalaryCurrency *string           `json:"salary_currency,omitempty" binding:"omitempty,len=3,alpha" validate:"omitempty,len=3,alpha"`
+	SalaryPeriod   *PricePeriod      `json:"salary_period,omitempty" binding:"omitempty,oneof=hourly daily weekly monthly yearly" validate:"omitempty,oneof=hourly daily weekly monthly yearly"`
+	ApplicationURL *string           `json:"application_url,omitempty" binding:"omitempty,url,max=500" validate:"omitempty,url,max=500"`
+}
+
+// toModel builds job details from the request, defaulting the salary currency when a salary is given.
+func (r *CreateListingJobDetailsRequest) toModel() *ListingDetailsJobs {
+	details := &ListingDetailsJobs{
+		EmploymentType: r.EmploymentType,
+		WorkplaceType:  r.WorkplaceType,
+		SalaryMin:      r.SalaryMin,
+		SalaryMax:      r.SalaryMax,
+		SalaryPeriod:   r.SalaryPeriod,
+		ApplicationURL: r.ApplicationURL,
+	}
+	if r.SalaryCurrency != nil {
+		currency := strings.ToUpper(*r.SalaryCurrency)
+		details.SalaryCurrency = &currency
+	} else if r.SalaryMin != nil || r.SalaryMax != nil {
+		currency := DefaultPriceCurrency
+		details.SalaryCurrency = &currency
+	}
+	return details
+}
+
+// PriceRequest is a structured price. Currency defaults to USD and period to one_time.
+type PriceRequest struct {
+	Amount   float64     `json:"amount" binding:"gte=0,lte=9999999999" validate:"gte=0,lte=9999999999"`
+	Currency string      `json:"currency,omitempty" binding:"omitempty,len=3,alpha" validate:"omitempty,len=3,alpha"`
+	Period   PricePeriod `json:"period,omitempty" binding:"omitempty,oneof=one_time hourly daily weekly monthly yearly" validate:"omitempty,oneof=one_time hourly daily weekly monthly yearly"`
+}
+
+// applyTo sets the listing's price columns from the request, filling in defaults.
+func (p *PriceRequest) applyTo(l *Listing) {
+	amount := p.Amount
+	currency := strings.ToUpper(p.Currency)
+	if currency == "" {
+		currency = DefaultPriceCurrency
+	}
+	period := p.Period
+	if period == "" {
+		period = PriceOneTime
+	}
+	l.PriceAmount = &amount
+	l.PriceCurrency = &currency
+	l.PricePeriod = &period
 }
 
 type CreateListingRequest struct {
-	CategoryID    uuid.UUID  `json:"category_id" validate:"required"`
-	SubCategoryID *uuid.UUID `json:"sub_category_id,omitempty"`
-	Title         string     `json:"title" validate:"required,min=5,max=255"`
-	Description   string     `json:"description" validate:"required,min=20"`
-	ContactName   *string    `json:"contact_name,omitempty" validate:"omitempty,max=150"`
-	ContactEmail  *string    `json:"contact_email,omitempty" validate:"omitempty,email,max=255"`
-	ContactPhone  *string    `json:"contact_phone,omitempty" validate:"omitempty,max=50"`
-	AddressLine1  *string    `json:"address_line1,omitempty" validate:"omitempty,max=255"`
-	AddressLine2  *string    `json:"address_line2,omitempty" validate:"omitempty,max=255"`
-	City          *string    `json:"city,omitempty" validate:"omitempty,max=100"`
-	State         *string    `json:"state,omitempty" validate:"omitempty,max=50"`
-	ZipCode       *string    `json:"zip_code,omitempty" validate:"omitempty,max=20"`
-	Latitude      *float64   `json:"latitude,omitempty" validate:"omitempty,latitude"`
-	Longitude     *float64   `json:"longitude,omitempty" validate:"omitempty,longitude"`
+	CategoryID    uuid.UUID              `json:"category_id" validate:"required"`
+	SubCategoryID *uuid.UUID             `json:"sub_category_id,omitempty"`
+	Title         string                 `json:"title" validate:"required,min=5,max=255"`
+	Description   string                 `json:"description" validate:"required,min=20"`
+	ContactName   *string                `json:"contact_name,omitempty" validate:"omitempty,max=150"`
+	ContactEmail  *string                `json:"contact_email,omitempty" validate:"omitempty,email,max=255"`
+	ContactPhone  *string                `json:"contact_phone,omitempty" validate:"omitempty,max=50"`
+	AddressLine1  *string                `json:"address_line1,omitempty" validate:"omitempty,max=255"`
+	AddressLine2  *string                `json:"address_line2,omitempty" validate:"omitempty,max=255"`
+	City          *string                `json:"city,omitempty" validate:"omitempty,max=100"`
+	State         *string                `json:"state,omitempty" validate:"omitempty,max=50"`
+	ZipCode       *string                `json:"zip_code,omitempty" validate:"omitempty,max=20"`
+	Latitude      *float64               `json:"latitude,omitempty" validate:"omitempty,latitude"`
+	Longitude     *float64               `json:"longitude,omitempty" validate:"omitempty,longitude"`
+	Price         *PriceRequest          `json:"price,omitempty" validate:"omitempty"`
+	Attributes    map[string]interface{} `json:"attributes,omitempty"` // Checked against the category's attribute schema
+	Draft         bool                   `json:"draft,omitempty"`      // Save without publishing; required details are checked on publish
+	PublishAt     *time.Time             `json:"publish_at,omitempty"` // Go live at this future time instead of on publish
 
 	// Nested details are perfectly handled by JSON unmarshalling.
 	BabysittingDetails *CreateListingBabysittingDetailsRequest `json:"babysitting_details,omitempty" validate:"omitempty"`
 	HousingDetails     *CreateListingHousingDetailsRequest     `json:"housing_details,omitempty" validate:"omitempty"`
 	EventDetails       *CreateListingEventDetailsRequest       `json:"event_details,omitempty" validate:"omitempty"`
+	JobDetails         *CreateListingJobDetailsRequest         `json:"job_details,omitempty" validate:"omitempty"`
+	BusinessHours      *BusinessHours                          `json:"business_hours,omitempty"` // Business listings only
+
+	// How precisely other viewers see the location: exact (default), street or neighborhood.
+	LocationPrivacy *LocationPrivacy `json:"location_privacy,omitempty" validate:"omitempty,oneof=exact street neighborhood"`
+
+	// Completed resumable uploads to add as images, after the uploaded files.
+	UploadTokens []string `json:"upload_tokens,omitempty" validate:"omitempty,max=20,dive,uuid"`
+	ImageTexts            // Read from the multipart form by the handler
 }
 
 type UpdateListingRequest struct {
@@ -230,28 +527,55 @@ type UpdateListingRequest struct {
 	Longitude          *float64                                `json:"longitude,omitempty" form:"-" binding:"omitempty,longitude"`
 	LatitudeStr        *string                                 `form:"latitude" json:"-"`
 	LongitudeStr       *string                                 `form:"longitude" json:"-"`
+	LocationPrivacy    *LocationPrivacy                        `json:"location_privacy,omitempty" form:"location_privacy" binding:"omitempty,oneof=exact street neighborhood"`
 	BabysittingDetails *CreateListingBabysittingDetailsRequest `json:"babysitting_details,omitempty"`
 	HousingDetails     *CreateListingHousingDetailsRequest     `json:"housing_details,omitempty"`
 	EventDetails       *CreateListingEventDetailsRequest       `json:"event_details,omitempty"`
+	JobDetails         *CreateListingJobDetailsRequest         `json:"job_details,omitempty"`
+	BusinessHours      *BusinessHours                          `json:"business_hours,omitempty"` // Replaces the weekly hours when present
+	ClearBusinessHours bool                                    `json:"clear_business_hours,omitempty"`
+	Price              *PriceRequest                           `json:"price,omitempty"`
+	RemovePrice        bool                                    `json:"remove_price,omitempty"`
+	RemoveRecurrence   bool                                    `json:"remove_recurrence,omitempty"` // Turns a recurring event back into a one-off
+	PublishAt          *time.Time                              `json:"publish_at,omitempty"`        // Reschedules a listing that is not live yet
+	Attributes         map[string]interface{}                  `json:"attributes,omitempty"`        // Replaces all attribute values when present
 	// Images are handled via multipart/form-data in the handler for new uploads.
 	// Existing images to remove might be specified by their IDs.
 	RemoveImageIDs []uuid.UUID `json:"remove_image_ids,omitempty"`
+	// Completed resumable uploads to add as images, after the uploaded files.
+	UploadTokens []string `form:"upload_token" json:"upload_tokens,omitempty" binding:"omitempty,max=20,dive,uuid"`
+	// Alt texts and captions of the uploaded images.
+	ImageTexts
+	// IfMatch carries the request's If-Match header; the update is rejected when it no longer matches the listing's ETag.
+	IfMatch string `json:"-" form:"-"`
 }
 
 type ListingImageResponse struct {
 	ID        uuid.UUID `json:"id"`
 	ImageURL  string    `json:"image_url"`
 	SortOrder int       `json:"sort_order"`
+	AltText   *string   `json:"alt_text,omitempty"`
+	Caption   *string   `json:"caption,omitempty"`
+
+	ScanStatus ImageScanStatus `json:"scan_status,omitempty"` // Owner and admin views only; pending images have no URL yet
+}
+
+type PriceResponse struct {
+	Amount   float64     `json:"amount"`
+	Currency string      `json:"currency"`
+	Period   PricePeriod `json:"period"`
 }
 
 type ListingResponse struct {
-	ID                 uuid.UUID                     `json:"id"`
-	UserID             uuid.UUID                     `json:"user_id"`
-	User               shared.UserResponse           `json:"user"`
+	ID     uuid.UUID            `json:"id"`
+	UserID uuid.UUID            `json:"user_id"`
+	User   *shared.UserResponse `json:"user,omitempty"` // Omitted when not included
+
 	CategoryID         uuid.UUID                     `json:"category_id"`
-	Category           category.CategoryResponse     `json:"category"`
+	Category           *category.CategoryResponse    `json:"category,omitempty"` // Omitted when not included
 	SubCategory        *category.SubCategoryResponse `json:"sub_category,omitempty"`
 	Title              string                        `json:"title"`
+	Slug               string                        `json:"slug"`
 	Description        string                        `json:"description"`
 	Status             ListingStatus                 `json:"status"`
 	ContactName        *string                       `json:"contact_name,omitempty"`
@@ -265,34 +589,67 @@ type ListingResponse struct {
 	Latitude           *float64                      `json:"latitude,omitempty"`
 	Longitude          *float64                      `json:"longitude,omitempty"`
 	Location           *PostGISPoint                 `json:"location,omitempty"`
+	LocationPrivacy    LocationPrivacy               `json:"location_privacy"` // Address lines and coordinates are trimmed to it for other viewers
 	Distance           *float64                      `json:"distance_km,omitempty"`
+	Highlights         map[string][]string           `json:"highlights,omitempty"`   // Matches of q in the title and description, wrapped in <em>
+	Neighborhood       *NeighborhoodResponse         `json:"neighborhood,omitempty"` // Omitted when not included or outside every neighborhood
+	Price              *PriceResponse                `json:"price,omitempty"`
+	Attributes         map[string]interface{}        `json:"attributes,omitempty"`
 	ExpiresAt          time.Time                     `json:"expires_at"`
+	PublishAt          *time.Time                    `json:"publish_at,omitempty"`
+	FeaturedUntil      *time.Time                    `json:"featured_until,omitempty"`
+	IsFeatured         bool                          `json:"is_featured"`
 	IsAdminApproved    bool                          `json:"is_admin_approved"`
+	ModerationFlags    []string                      `json:"moderation_flags,omitempty"` // Owner and admins only
+	AdminNotes         *string                       `json:"admin_notes,omitempty"`      // Owner and admins only
+	SpamScore          *int                          `json:"spam_score,omitempty"`       // Owner and admins only
+	QualityScore       *int                          `json:"quality_score,omitempty"`    // Owner and admins only
+	QualityHints       []string                      `json:"quality_hints,omitempty"`    // Owner and admins only; see the QualityHint constants
+	RejectionReason    *RejectionReason              `json:"rejection_reason,omitempty"` // Owner and admins only
 	CreatedAt          time.Time                     `json:"created_at"`
 	UpdatedAt          time.Time                     `json:"updated_at"`
 	BabysittingDetails *ListingDetailsBabysitting    `json:"babysitting_details,omitempty"`
 	HousingDetails     *ListingDetailsHousing        `json:"housing_details,omitempty"`
-	EventDetails       *ListingDetailsEvents         `json:"event_details,omitempty"`
+	EventDetails       *EventDetailsResponse         `json:"event_details,omitempty"`
+	JobDetails         *ListingDetailsJobs           `json:"job_details,omitempty"`
+	BusinessHours      *BusinessHours                `json:"business_hours,omitempty"`
+	IsOpenNow          *bool                         `json:"is_open_now,omitempty"` // Listings with business hours only
 	Images             []ListingImageResponse        `json:"images,omitempty"`
+	Questions          *QuestionPage                 `json:"questions,omitempty"` // Single listing responses only
+
+	OutsideServiceAreaAllowed *bool `json:"outside_service_area_allowed,omitempty"` // Owner and admins only
 }
 
-func ToListingResponse(listing *Listing, isAuthenticated bool, imageBaseURL string) ListingResponse {
-	// Manually create a shared.User from the listing.User
-	sharedUser := &shared.User{
-		ID:                listing.User.ID,
-		Email:             listing.User.Email,
-		FirstName:         listing.User.FirstName,
-		LastName:          listing.User.LastName,
-		ProfilePictureURL: listing.User.ProfilePictureURL,
-		AuthProvider:      listing.User.AuthProvider,
-		IsEmailVerified:   listing.User.IsEmailVerified,
-		Role:              listing.User.Role,
-		CreatedAt:         listing.User.CreatedAt,
-		UpdatedAt:         listing.User.UpdatedAt,
-		LastLoginAt:       listing.User.LastLoginAt,
+// ToListingResponse converts a listing for public responses. The contact email and phone are left out; callers
+// get them from POST /listings/:id/contact-reveal, and owners and admins through ToOwnerListingResponse.
+// The address and coordinates are trimmed to the listing's LocationPrivacy.
+func ToListingResponse(listing *Listing, imageURLs *filestorage.ImageURLBuilder) ListingResponse {
+	// Associations that were not loaded (see Includes) are left out of the response.
+	var userResp *shared.UserResponse
+	if listing.User != nil {
+		// Manually create a shared.User from the listing.User
+		sharedUser := &shared.User{
+			ID:                listing.User.ID,
+			Email:             listing.User.Email,
+			FirstName:         listing.User.FirstName,
+			LastName:          listing.User.LastName,
+			ProfilePictureURL: listing.User.ProfilePictureURL,
+			AuthProvider:      listing.User.AuthProvider,
+			IsEmailVerified:   listing.User.IsEmailVerified,
+			PhoneVerified:     listing.User.PhoneVerified,
+			Role:              listing.User.Role,
+			CreatedAt:         listing.User.CreatedAt,
+			UpdatedAt:         listing.User.UpdatedAt,
+			LastLoginAt:       listing.User.LastLoginAt,
+		}
+		resp := shared.ToUserResponse(sharedUser) // Pass shared.User to ToUserResponse
+		userResp = &resp
+	}
+	var catResp *category.CategoryResponse
+	if listing.Category.ID != uuid.Nil {
+		resp := category.ToCategoryResponse(&listing.Category, imageURLs)
+		catResp = &resp
 	}
-	userResp := shared.ToUserResponse(sharedUser) // Pass shared.User to ToUserResponse
-	catResp := category.ToCategoryResponse(&listing.Category)
 	var subCatResp *category.SubCategoryResponse
 	if listing.SubCategory != nil {
 		tempSubCatResp := category.ToSubCategoryResponse(listing.SubCategory)
@@ -307,6 +664,7 @@ func ToListingResponse(listing *Listing, isAuthenticated bool, imageBaseURL stri
 		Category:           catResp,
 		SubCategory:        subCatResp,
 		Title:              listing.Title,
+		Slug:               listing.Slug,
 		Description:        listing.Description,
 		Status:             listing.Status,
 		ContactName:        listing.ContactName,
@@ -318,58 +676,180 @@ func ToListingResponse(listing *Listing, isAuthenticated bool, imageBaseURL stri
 		Latitude:           listing.Latitude,
 		Longitude:          listing.Longitude,
 		Location:           listing.Location,
+		Distance:           listing.DistanceKM,
+		Highlights:         listing.Highlights,
+		Neighborhood:       ToNeighborhoodResponse(listing.Neighborhood),
 		ExpiresAt:          listing.ExpiresAt,
+		PublishAt:          listing.PublishAt,
+		FeaturedUntil:      listing.FeaturedUntil,
+		IsFeatured:         listing.IsFeatured(time.Now()),
 		IsAdminApproved:    listing.IsAdminApproved,
 		CreatedAt:          listing.CreatedAt,
 		UpdatedAt:          listing.UpdatedAt,
 		BabysittingDetails: listing.BabysittingDetails,
 		HousingDetails:     listing.HousingDetails,
-		EventDetails:       listing.EventDetails,
+		EventDetails:       ToEventDetailsResponse(listing.EventDetails),
+		JobDetails:         listing.JobDetails,
+		Attributes:         listing.Attributes,
 		// Images will be populated below
 	}
+	applyLocationPrivacy(&resp, listing)
 
-	if len(listing.Images) > 0 {
-		resp.Images = make([]ListingImageResponse, len(listing.Images))
-		for i, img := range listing.Images {
-			img.PopulateImageURL(imageBaseURL) // Use the PopulateImageURL method
+	if listing.PriceAmount != nil {
+		resp.Price = &PriceResponse{Amount: *listing.PriceAmount, Currency: DefaultPriceCurrency, Period: PriceOneTime}
+		if listing.PriceCurrency != nil {
+			resp.Price.Currency = *listing.PriceCurrency
+		}
+		if listing.PricePeriod != nil {
+			resp.Price.Period = *listing.PricePeriod
+		}
+	}
+	if listing.BusinessHours != nil {
+		isOpen := listing.BusinessHours.OpenAt(time.Now())
+		resp.BusinessHours = listing.BusinessHours
+		resp.IsOpenNow = &isOpen
+	}
+
+	if images := listing.servedImages(); len(images) > 0 {
+		resp.Images = make([]ListingImageResponse, len(images))
+		for i, img := range images {
+			img.PopulateImageURL(imageURLs) // Use the PopulateImageURL method
 			resp.Images[i] = ListingImageResponse{
 				ID:        img.ID,
 				ImageURL:  img.ImageURL,
 				SortOrder: img.SortOrder,
+				AltText:   img.AltText,
+				Caption:   img.Caption,
 			}
 		}
 	}
 
-	if isAuthenticated {
-		resp.ContactEmail = listing.ContactEmail
-		resp.ContactPhone = listing.ContactPhone
+	return resp
+}
+
+// servedImages returns the images of l that are not waiting for the virus scan.
+func (l *Listing) servedImages() []ListingImage {
+	images := make([]ListingImage, 0, len(l.Images))
+	for _, img := range l.Images {
+		if img.ScanStatus != ScanPending {
+			images = append(images, img)
+		}
+	}
+	return images
+}
+
+// ToOwnerListingResponse is ToListingResponse for the listing's owner or an admin.
+// It adds the contact details, the exact location, the notes and rejection reason of the latest admin decision,
+// the moderation flags and the spam score, which other viewers never see.
+func ToOwnerListingResponse(listing *Listing, imageURLs *filestorage.ImageURLBuilder) ListingResponse {
+	resp := ToListingResponse(listing, imageURLs)
+	resp.AddressLine1, resp.AddressLine2 = listing.AddressLine1, listing.AddressLine2
+	resp.Latitude, resp.Longitude, resp.Location = listing.Latitude, listing.Longitude, listing.Location
+	resp.ContactEmail = listing.ContactEmail
+	resp.ContactPhone = listing.ContactPhone
+	resp.AdminNotes = listing.AdminNotes
+	resp.RejectionReason = listing.RejectionReason
+	resp.ModerationFlags = listing.ModerationFlags
+	resp.SpamScore = &listing.SpamScore
+	resp.QualityScore = &listing.QualityScore
+	resp.QualityHints = listing.QualityHints
+	resp.OutsideServiceAreaAllowed = &listing.OutsideServiceAreaAllowed
+	if len(listing.Images) > 0 {
+		resp.Images = make([]ListingImageResponse, len(listing.Images))
+		for i, img := range listing.Images {
+			if img.ScanStatus != ScanPending {
+				img.PopulateImageURL(imageURLs)
+			}
+			resp.Images[i] = ListingImageResponse{
+				ID:         img.ID,
+				ImageURL:   img.ImageURL,
+				SortOrder:  img.SortOrder,
+				AltText:    img.AltText,
+				Caption:    img.Caption,
+				ScanStatus: img.ScanStatus,
+			}
+		}
 	}
 	return resp
 }
 
 type AdminUpdateListingStatusRequest struct {
-	Status     ListingStatus `json:"status" binding:"required,oneof=pending_approval active expired rejected admin_removed"`
-	AdminNotes *string       `json:"admin_notes,omitempty"`
+	Status          ListingStatus    `json:"status" binding:"required,oneof=pending_approval active expired rejected admin_removed"`
+	AdminNotes      *string          `json:"admin_notes,omitempty" binding:"omitempty,max=2000"`
+	RejectionReason *RejectionReason `json:"rejection_reason,omitempty" binding:"required_if=Status rejected,omitempty,oneof=spam prohibited_content duplicate incomplete wrong_category misleading other"`
+}
+
+// FeatureListingRequest is the payload for featuring a listing, by an admin or a partner after a purchase.
+type FeatureListingRequest struct {
+	Days      int     `json:"days" binding:"required,min=1,max=90"`
+	Reference *string `json:"reference,omitempty" binding:"omitempty,max=200"` // E.g. the partner's order ID, kept in the audit log
+}
+
+// AdminUpdateListingRequest is the payload for PUT /admin/listings/:id. It takes the same fields as an owner's update.
+type AdminUpdateListingRequest struct {
+	UpdateListingRequest
+	NotifyOwner bool    `json:"notify_owner"`                                // Tells the owner which fields were changed
+	Note        *string `json:"note,omitempty" binding:"omitempty,max=1000"` // Reason for the edit; stored in the audit log and included in the notification
+	// Allows, or with false stops allowing, coordinates outside the service area for this listing
+	AllowOutsideServiceArea *bool `json:"allow_outside_service_area,omitempty"`
 }
 
 type ListingSearchQuery struct {
 	common.PaginationQuery
-	SearchTerm     string   `form:"q"`
-	CategoryID     *string  `form:"category_id"`
-	SubCategoryID  *string  `form:"sub_category_id"`
-	UserID         *string  `form:"user_id"`
-	Status         string   `form:"status"`
-	Latitude       *float64 `form:"lat"`
-	Longitude      *float64 `form:"lon"`
-	MaxDistanceKM  *float64 `form:"max_distance_km"`
-	SortBy         string   `form:"sort_by"`
-	SortOrder      string   `form:"sort_order"`
-	IncludeExpired bool     `form:"include_expired"`
+	SearchTerm     string   `form:"q" json:"q,omitempty"`
+	CategoryID     *string  `form:"category_id" json:"category_id,omitempty"`
+	SubCategoryID  *string  `form:"sub_category_id" json:"sub_category_id,omitempty"`
+	UserID         *string  `form:"user_id" json:"user_id,omitempty"`
+	Status         string   `form:"status" json:"status,omitempty"`
+	Latitude       *float64 `form:"lat" json:"lat,omitempty"`
+	Longitude      *float64 `form:"lon" json:"lon,omitempty"`
+	MaxDistanceKM  *float64 `form:"max_distance_km" json:"max_distance_km,omitempty"`
+	BBox           string   `form:"bbox" json:"bbox,omitempty"`                 // "minLon,minLat,maxLon,maxLat" viewport filter
+	Polygon        string   `form:"polygon" json:"polygon,omitempty"`           // GeoJSON Polygon geometry filter
+	Neighborhood   string   `form:"neighborhood" json:"neighborhood,omitempty"` // Neighborhood slug
+	MinPrice       *float64 `form:"min_price" json:"min_price,omitempty"`
+	MaxPrice       *float64 `form:"max_price" json:"max_price,omitempty"`
+	Currency       string   `form:"currency" json:"currency,omitempty"` // Restricts price filtering to one currency
+	SortBy         string   `form:"sort_by" json:"sort_by,omitempty"`
+	SortOrder      string   `form:"sort_order" json:"sort_order,omitempty"`
+	IncludeExpired bool     `form:"include_expired" json:"include_expired,omitempty"`
+	OpenNow        bool     `form:"open_now" json:"open_now,omitempty"` // Listings whose business hours include the current time
+	// CreatedBefore is a cursor for paging by recency: the created_at of the last listing of the previous page.
+	CreatedBefore *time.Time `form:"created_before" json:"created_before,omitempty"`
+
+	// Housing filters; they only match housing listings.
+	MinBedrooms *int     `form:"min_bedrooms" json:"min_bedrooms,omitempty"` // At least this many bedrooms
+	MaxRent     *float64 `form:"max_rent" json:"max_rent,omitempty"`         // Rentals whose rent is known and at most this
+
+	// Babysitting filters; they only match babysitting listings. available_day alone matches providers available
+	// at some time that day; with available_start and available_end, only those available for the whole window.
+	AvailableDay   string   `form:"available_day" json:"available_day,omitempty"`     // Weekday name, e.g. "saturday"
+	AvailableStart string   `form:"available_start" json:"available_start,omitempty"` // HH:MM; requires available_day
+	AvailableEnd   string   `form:"available_end" json:"available_end,omitempty"`     // HH:MM, "24:00" for midnight; requires available_day
+	MaxHourlyRate  *float64 `form:"max_hourly_rate" json:"max_hourly_rate,omitempty"` // Providers whose hourly rate is known and at most this
+
+	// Category attribute filters, bound by the handler from attr[key], attr_min[key] and attr_max[key].
+	// They require category_id, since attributes are defined per category.
+	Attributes    map[string]string `form:"-" json:"attr,omitempty"`
+	AttributesMin map[string]string `form:"-" json:"attr_min,omitempty"`
+	AttributesMax map[string]string `form:"-" json:"attr_max,omitempty"`
+
+	BoundingBox      *geo.BoundingBox           `form:"-" json:"-"` // Parsed from BBox by the service layer
+	CreatedAfter     *time.Time                 `form:"-" json:"-"` // Used internally, e.g. by saved-search digests
+	AttributeFilters []category.AttributeFilter `form:"-" json:"-"` // Resolved from the attribute maps by the service layer
+	Includes         Includes                   `form:"-" json:"-"` // Parsed from ?include= by the handler; nil loads every association
+	SearchVariants   []string                   `form:"-" json:"-"` // Spellings of SearchTerm to match, expanded with place name synonyms by the service layer
+}
+
+// HasAttributeFilters reports whether any category attribute filter was requested.
+func (q ListingSearchQuery) HasAttributeFilters() bool {
+	return len(q.Attributes) > 0 || len(q.AttributesMin) > 0 || len(q.AttributesMax) > 0
 }
 
 type UserListingsQuery struct {
 	common.PaginationQuery
-	Status        *string `form:"status"`
-	CategorySlug  *string `form:"form:category_slug"`
-	IncludeExpired bool   `form:"include_expired"`
+	Status         *string  `form:"status"`
+	CategorySlug   *string  `form:"form:category_slug"`
+	IncludeExpired bool     `form:"include_expired"`
+	Includes       Includes `form:"-"` // Parsed from ?include= by the handler
 }
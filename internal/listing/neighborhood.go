@@ -0,0 +1,55 @@
+// File: internal/listing/neighborhood.go
+package listing
+
+import (
+	"context"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Neighborhood is a named area of Seattle. Its boundary polygon stays in the database, where the listings
+// location trigger uses it to assign each listing to the neighborhood containing it.
+type Neighborhood struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Name      string    `gorm:"type:varchar(100);not null"`
+	Slug      string    `gorm:"type:varchar(100);not null;uniqueIndex"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Neighborhood) TableName() string {
+	return "neighborhoods"
+}
+
+// NeighborhoodResponse is the client representation of a neighborhood.
+type NeighborhoodResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	Slug string    `json:"slug"`
+}
+
+// ToNeighborhoodResponse converts a neighborhood, returning nil for a listing outside every neighborhood.
+func ToNeighborhoodResponse(n *Neighborhood) *NeighborhoodResponse {
+	if n == nil {
+		return nil
+	}
+	return &NeighborhoodResponse{ID: n.ID, Name: n.Name, Slug: n.Slug}
+}
+
+// GetNeighborhoods returns every neighborhood listings can be filtered by, ordered by name.
+func (s *ServiceImplementation) GetNeighborhoods(ctx context.Context) ([]NeighborhoodResponse, error) {
+	neighborhoods, err := s.repo.FindNeighborhoods(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list neighborhoods", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve neighborhoods.")
+	}
+	responses := make([]NeighborhoodResponse, len(neighborhoods))
+	for i := range neighborhoods {
+		responses[i] = *ToNeighborhoodResponse(&neighborhoods[i])
+	}
+	return responses, nil
+}
@@ -0,0 +1,228 @@
+// File: internal/listing/patch.go
+package listing
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	"seattle_info_backend/internal/audit"
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+// listingDocument is the editable state of a listing as seen by PATCH /listings/:id.
+// A merge patch is applied to the document built from the current listing; the result replaces that state.
+type listingDocument struct {
+	SubCategoryID      *uuid.UUID                              `json:"sub_category_id,omitempty"`
+	Title              string                                  `json:"title" binding:"required,min=5,max=255"`
+	Description        string                                  `json:"description" binding:"required,min=20"`
+	ContactName        *string                                 `json:"contact_name,omitempty" binding:"omitempty,max=150"`
+	ContactEmail       *string                                 `json:"contact_email,omitempty" binding:"omitempty,email,max=255"`
+	ContactPhone       *string                                 `json:"contact_phone,omitempty" binding:"omitempty,max=50"`
+	AddressLine1       *string                                 `json:"address_line1,omitempty" binding:"omitempty,max=255"`
+	AddressLine2       *string                                 `json:"address_line2,omitempty" binding:"omitempty,max=255"`
+	City               *string                                 `json:"city,omitempty" binding:"omitempty,max=100"`
+	State              *string                                 `json:"state,omitempty" binding:"omitempty,max=50"`
+	ZipCode            *string                                 `json:"zip_code,omitempty" binding:"omitempty,max=20"`
+	Latitude           *float64                                `json:"latitude,omitempty" binding:"omitempty,latitude"`
+	Longitude          *float64                                `json:"longitude,omitempty" binding:"omitempty,longitude"`
+	LocationPrivacy    LocationPrivacy                         `json:"location_privacy,omitempty" binding:"omitempty,oneof=exact street neighborhood"`
+	Price              *PriceRequest                           `json:"price,omitempty"`
+	Attributes         map[string]interface{}                  `json:"attributes,omitempty"`
+	BabysittingDetails *CreateListingBabysittingDetailsRequest `json:"babysitting_details,omitempty"`
+	HousingDetails     *CreateListingHousingDetailsRequest     `json:"housing_details,omitempty"`
+	EventDetails       *CreateListingEventDetailsRequest       `json:"event_details,omitempty"`
+	JobDetails         *CreateListingJobDetailsRequest         `json:"job_details,omitempty"`
+	BusinessHours      *BusinessHours                          `json:"business_hours,omitempty"`
+}
+
+// newListingDocument describes the listing's current editable state.
+func newListingDocument(l *Listing) listingDocument {
+	doc := listingDocument{
+		SubCategoryID: l.SubCategoryID,
+		Title:         l.Title,
+		Description:   l.Description,
+		ContactName:   l.ContactName,
+		ContactEmail:  l.ContactEmail,
+		ContactPhone:  l.ContactPhone,
+		AddressLine1:  l.AddressLine1,
+		AddressLine2:  l.AddressLine2,
+		City:          l.City,
+		State:         l.State,
+		ZipCode:       l.ZipCode,
+		Latitude:      l.Latitude,
+		Longitude:     l.Longitude,
+		Attributes:    l.Attributes,
+		BusinessHours: l.BusinessHours,
+	}
+	doc.LocationPrivacy = l.LocationPrivacy.orExact()
+	if l.PriceAmount != nil {
+		doc.Price = &PriceRequest{Amount: *l.PriceAmount}
+		if l.PriceCurrency != nil {
+			doc.Price.Currency = *l.PriceCurrency
+		}
+		if l.PricePeriod != nil {
+			doc.Price.Period = *l.PricePeriod
+		}
+	}
+	if d := l.BabysittingDetails; d != nil {
+		doc.BabysittingDetails = d.babysittingRequest()
+	}
+	if d := l.HousingDetails; d != nil {
+		doc.HousingDetails = d.housingRequest()
+	}
+	if d := l.EventDetails; d != nil {
+		doc.EventDetails = &CreateListingEventDetailsRequest{
+			EventDate:     d.EventDate.Format(eventDateLayout),
+			EventTime:     d.EventTime,
+			OrganizerName: d.OrganizerName,
+			VenueName:     d.VenueName,
+		}
+		if d.Timezone != "" {
+			timezone := d.Timezone
+			doc.EventDetails.Timezone = &timezone
+		}
+		if d.IsRecurring() {
+			doc.EventDetails.Recurrence = &EventRecurrenceRequest{Frequency: *d.RecurrenceFrequency, Interval: d.RecurrenceInterval}
+			if d.RecurrenceUntil != nil {
+				until := d.RecurrenceUntil.Format(eventDateLayout)
+				doc.EventDetails.Recurrence.Until = &until
+			}
+		}
+	}
+	if d := l.JobDetails; d != nil {
+		doc.JobDetails = &CreateListingJobDetailsRequest{
+			EmploymentType: d.EmploymentType,
+			WorkplaceType:  d.WorkplaceType,
+			SalaryMin:      d.SalaryMin,
+			SalaryMax:      d.SalaryMax,
+			SalaryCurrency: d.SalaryCurrency,
+			SalaryPeriod:   d.SalaryPeriod,
+			ApplicationURL: d.ApplicationURL,
+		}
+	}
+	return doc
+}
+
+// toUpdateRequest turns the merged document into an update that sets every field it contains.
+// Attributes are always sent so that required ones are checked even when the patch removed them.
+func (d *listingDocument) toUpdateRequest() UpdateListingRequest {
+	attributes := d.Attributes
+	if attributes == nil {
+		attributes = map[string]interface{}{}
+	}
+	privacy := d.LocationPrivacy.orExact() // Removing it goes back to the default
+	return UpdateListingRequest{
+		SubCategoryID:      d.SubCategoryID,
+		Title:              &d.Title,
+		Description:        &d.Description,
+		ContactName:        d.ContactName,
+		ContactEmail:       d.ContactEmail,
+		ContactPhone:       d.ContactPhone,
+		AddressLine1:       d.AddressLine1,
+		AddressLine2:       d.AddressLine2,
+		City:               d.City,
+		State:              d.State,
+		ZipCode:            d.ZipCode,
+		Latitude:           d.Latitude,
+		Longitude:          d.Longitude,
+		LocationPrivacy:    &privacy,
+		Price:              d.Price,
+		RemovePrice:        d.Price == nil,
+		Attributes:         attributes,
+		BabysittingDetails: d.BabysittingDetails,
+		HousingDetails:     d.HousingDetails,
+		EventDetails:       d.EventDetails,
+		JobDetails:         d.JobDetails,
+		BusinessHours:      d.BusinessHours,
+		ClearBusinessHours: d.BusinessHours == nil,
+		RemoveRecurrence:   d.EventDetails != nil && d.EventDetails.Recurrence == nil,
+	}
+}
+
+// clearEditableFields resets everything a listingDocument covers, so that applying the merged document
+// leaves exactly what the document contains. Detail objects are rebuilt from the document.
+func (l *Listing) clearEditableFields() {
+	l.ContactName, l.ContactEmail, l.ContactPhone = nil, nil, nil
+	l.AddressLine1, l.AddressLine2 = nil, nil
+	l.City, l.State, l.ZipCode = nil, nil, nil
+	l.Latitude, l.Longitude, l.Location = nil, nil, nil
+	l.BabysittingDetails, l.HousingDetails, l.EventDetails, l.JobDetails = nil, nil, nil, nil
+}
+
+// listingDocumentFields returns the listing's document in its JSON form, keyed by member name.
+func listingDocumentFields(l *Listing) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(newListingDocument(l))
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// diffListingDocuments lists the document members whose values differ between before and after.
+// A member missing on one side is reported with a nil value there.
+func diffListingDocuments(before, after map[string]interface{}) map[string]audit.FieldChange {
+	changes := map[string]audit.FieldChange{}
+	for key, from := range before {
+		if to := after[key]; !reflect.DeepEqual(from, to) {
+			changes[key] = audit.FieldChange{From: from, To: to}
+		}
+	}
+	for key, to := range after {
+		if _, ok := before[key]; !ok {
+			changes[key] = audit.FieldChange{From: nil, To: to}
+		}
+	}
+	return changes
+}
+
+// mergeListingPatch applies patch to the listing's current document and validates the result.
+func mergeListingPatch(l *Listing, patch map[string]interface{}) (*listingDocument, error) {
+	target, err := listingDocumentFields(l)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := json.Marshal(mergePatch(target, patch))
+	if err != nil {
+		return nil, err
+	}
+
+	var doc listingDocument
+	dec := json.NewDecoder(bytes.NewReader(merged))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, common.ErrBadRequest.WithDetails("Invalid merge patch: " + err.Error())
+	}
+	if err := binding.Validator.ValidateStruct(&doc); err != nil {
+		return nil, common.BindingError(err)
+	}
+	return &doc, nil
+}
+
+// mergePatch implements the MergePatch algorithm of RFC 7386: objects merge recursively,
+// null removes a member and any other value replaces the target.
+func mergePatch(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = map[string]interface{}{}
+	}
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = mergePatch(targetObj[key], value)
+	}
+	return targetObj
+}
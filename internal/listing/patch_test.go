@@ -0,0 +1,124 @@
+package listing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/audit"
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergePatchRFC7386Examples(t *testing.T) {
+	cases := []struct{ target, patch, want string }{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, tc := range cases {
+		var target, patch interface{}
+		require.NoError(t, json.Unmarshal([]byte(tc.target), &target))
+		require.NoError(t, json.Unmarshal([]byte(tc.patch), &patch))
+		got, err := json.Marshal(mergePatch(target, patch))
+		require.NoError(t, err)
+		assert.JSONEq(t, tc.want, string(got), "%s + %s", tc.target, tc.patch)
+	}
+}
+
+func patchTestListing() *Listing {
+	phone := "206-555-0100"
+	venue := "Town Hall"
+	amount, currency, period := 25.0, "USD", PriceOneTime
+	l := &Listing{
+		Title:         "Community potluck",
+		Description:   "Bring a dish and meet your neighbours.",
+		ContactPhone:  &phone,
+		PriceAmount:   &amount,
+		PriceCurrency: &currency,
+		PricePeriod:   &period,
+		EventDetails: &ListingDetailsEvents{
+			EventDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			VenueName: &venue,
+		},
+	}
+	l.ID = uuid.New()
+	return l
+}
+
+func TestMergeListingPatchClearsNullMembers(t *testing.T) {
+	doc, err := mergeListingPatch(patchTestListing(), map[string]interface{}{
+		"contact_phone": nil,
+		"price":         nil,
+		"event_details": map[string]interface{}{"venue_name": nil, "organizer_name": "Parks Dept"},
+	})
+	require.NoError(t, err)
+
+	assert.Nil(t, doc.ContactPhone)
+	assert.Nil(t, doc.Price)
+	assert.Equal(t, "Community potluck", doc.Title, "members absent from the patch are kept")
+	require.NotNil(t, doc.EventDetails)
+	assert.Equal(t, "2024-06-01", doc.EventDetails.EventDate)
+	assert.Nil(t, doc.EventDetails.VenueName)
+	require.NotNil(t, doc.EventDetails.OrganizerName)
+	assert.Equal(t, "Parks Dept", *doc.EventDetails.OrganizerName)
+
+	req := doc.toUpdateRequest()
+	assert.True(t, req.RemovePrice)
+	assert.True(t, req.RemoveRecurrence)
+	assert.NotNil(t, req.Attributes)
+}
+
+func TestMergeListingPatchRejectsInvalidDocuments(t *testing.T) {
+	_, err := mergeListingPatch(patchTestListing(), map[string]interface{}{"title": nil})
+	apiErr, ok := common.IsAPIError(err)
+	require.True(t, ok)
+	assert.Equal(t, "VALIDATION_ERROR", apiErr.Code, "title is required")
+
+	_, err = mergeListingPatch(patchTestListing(), map[string]interface{}{"colour": "blue"})
+	apiErr, ok = common.IsAPIError(err)
+	require.True(t, ok)
+	assert.Equal(t, "BAD_REQUEST", apiErr.Code, "unknown members are rejected")
+}
+
+func TestDiffListingDocumentsReportsChangedMembers(t *testing.T) {
+	original := patchTestListing()
+	before, err := listingDocumentFields(original)
+	require.NoError(t, err)
+
+	edited := patchTestListing()
+	edited.Title = "Community potluck dinner"
+	edited.ContactPhone = nil
+	city := "Seattle"
+	edited.City = &city
+	after, err := listingDocumentFields(edited)
+	require.NoError(t, err)
+
+	changes := diffListingDocuments(before, after)
+	assert.Len(t, changes, 3, "unchanged members are left out")
+	assert.Equal(t, "Community potluck", changes["title"].From)
+	assert.Equal(t, "Community potluck dinner", changes["title"].To)
+	assert.Equal(t, "206-555-0100", changes["contact_phone"].From)
+	assert.Nil(t, changes["contact_phone"].To)
+	assert.Nil(t, changes["city"].From)
+	assert.Equal(t, "Seattle", changes["city"].To)
+}
+
+func TestAdminEditMessageListsChangedFields(t *testing.T) {
+	changes := map[string]audit.FieldChange{"title": {}, "contact_email": {}}
+	assert.Equal(t, "An administrator updated your listing 'Potluck'. Changed: contact email, title.",
+		adminEditMessage("Potluck", changes, nil))
+
+	note := " Fixed a typo. "
+	assert.Equal(t, "An administrator updated your listing 'Potluck'. Changed: contact email, title. Note: Fixed a typo.",
+		adminEditMessage("Potluck", changes, &note))
+}
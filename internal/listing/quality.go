@@ -0,0 +1,89 @@
+// File: internal/listing/quality.go
+package listing
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Quality hints tell an owner what to add to a listing to raise its quality score.
+const (
+	QualityHintAddPhotos          = "add_photos"
+	QualityHintAddMorePhotos      = "add_more_photos"
+	QualityHintExpandDescription  = "expand_description"
+	QualityHintAddContact         = "add_contact"
+	QualityHintAddLocation        = "add_location"
+	QualityHintAddCategoryDetails = "add_category_details"
+)
+
+const (
+	// qualityPhotoCount is the number of photos that earns full credit.
+	qualityPhotoCount = 3
+	// qualityDescriptionLength is the description length, in characters, that earns full credit.
+	qualityDescriptionLength = 150
+)
+
+// qualityOrder breaks ties between otherwise equal search results in favour of more complete listings.
+const qualityOrder = "listings.quality_score DESC"
+
+// qualityCheck is one criterion of the quality score. A listing that fails it misses points and gets hint.
+type qualityCheck struct {
+	hint   string
+	points int
+	passes func(l *Listing, categorySlug string) bool
+}
+
+// qualityChecks add up to 100 points. Keep them in step with the backfill in migration 000038.
+var qualityChecks = []qualityCheck{
+	{QualityHintAddPhotos, 20, func(l *Listing, _ string) bool { return len(l.Images) > 0 }},
+	{QualityHintAddMorePhotos, 10, func(l *Listing, _ string) bool { return len(l.Images) >= qualityPhotoCount }},
+	{QualityHintExpandDescription, 20, func(l *Listing, _ string) bool {
+		return utf8.RuneCountInString(strings.TrimSpace(l.Description)) >= qualityDescriptionLength
+	}},
+	{QualityHintAddContact, 15, func(l *Listing, _ string) bool { return nonEmpty(l.ContactEmail) || nonEmpty(l.ContactPhone) }},
+	{QualityHintAddLocation, 20, func(l *Listing, _ string) bool { return l.Latitude != nil && l.Longitude != nil }},
+	{QualityHintAddCategoryDetails, 15, hasCategoryDetails},
+}
+
+// hasCategoryDetails reports whether a listing has the optional details of its category filled in beyond what
+// publishing requires. Categories without details always pass.
+func hasCategoryDetails(l *Listing, categorySlug string) bool {
+	switch categorySlug {
+	case "baby-sitting":
+		return l.BabysittingDetails != nil && len(l.BabysittingDetails.LanguagesSpoken) > 0
+	case "housing":
+		return l.HousingDetails != nil
+	case "events":
+		return l.EventDetails != nil && nonEmpty(l.EventDetails.VenueName)
+	case "jobs":
+		return l.JobDetails != nil
+	default:
+		return true
+	}
+}
+
+func nonEmpty(s *string) bool {
+	return s != nil && strings.TrimSpace(*s) != ""
+}
+
+// computeQuality scores how complete a listing is, from 0 to 100, and lists the hints for the checks it fails.
+func computeQuality(l *Listing, categorySlug string) (int, []string) {
+	score := 0
+	var hints []string
+	for _, check := range qualityChecks {
+		if check.passes(l, categorySlug) {
+			score += check.points
+		} else {
+			hints = append(hints, check.hint)
+		}
+	}
+	return score, hints
+}
+
+// applyQualityScore stores the quality score and hints of a listing about to be saved. Search results that
+// are otherwise equal are ordered by the score.
+func applyQualityScore(l *Listing, categorySlug string) {
+	var hints []string
+	l.QualityScore, hints = computeQuality(l, categorySlug)
+	l.QualityHints = hints
+}
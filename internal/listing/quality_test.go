@@ -0,0 +1,52 @@
+package listing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeQuality(t *testing.T) {
+	bare := &Listing{Title: "Bike", Description: "Red bike."}
+	score, hints := computeQuality(bare, "for-sale")
+	assert.Equal(t, 15, score, "only the category details check passes for a category without details")
+	assert.Equal(t, []string{
+		QualityHintAddPhotos, QualityHintAddMorePhotos, QualityHintExpandDescription, QualityHintAddContact, QualityHintAddLocation,
+	}, hints)
+
+	phone, lat, lon := "206-555-0100", 47.61, -122.33
+	complete := &Listing{
+		Description:  strings.Repeat("Lightly used road bike. ", 7),
+		ContactPhone: &phone,
+		Latitude:     &lat,
+		Longitude:    &lon,
+		Images:       make([]ListingImage, 3),
+	}
+	score, hints = computeQuality(complete, "for-sale")
+	assert.Equal(t, 100, score)
+	assert.Empty(t, hints)
+
+	score, hints = computeQuality(complete, "events")
+	assert.Equal(t, 85, score)
+	assert.Equal(t, []string{QualityHintAddCategoryDetails}, hints)
+
+	venue := "Town Hall"
+	complete.EventDetails = &ListingDetailsEvents{VenueName: &venue}
+	score, _ = computeQuality(complete, "events")
+	assert.Equal(t, 100, score)
+}
+
+func TestQualityHintsOnlyInOwnerView(t *testing.T) {
+	l := patchTestListing()
+	applyQualityScore(l, "")
+	assert.NotEmpty(t, l.QualityHints)
+
+	public := ToListingResponse(l, nil)
+	assert.Nil(t, public.QualityScore)
+	assert.Nil(t, public.QualityHints)
+
+	owner := ToOwnerListingResponse(l, nil)
+	assert.Equal(t, l.QualityScore, *owner.QualityScore)
+	assert.Equal(t, []string(l.QualityHints), owner.QualityHints)
+}
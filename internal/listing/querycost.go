@@ -0,0 +1,147 @@
+// File: internal/listing/querycost.go
+package listing
+
+import (
+	"seattle_info_backend/internal/common"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// wideSearchRadiusKM is the radius beyond which the distance filter barely narrows a Seattle search.
+	// Over-budget searches are simplified to this radius.
+	wideSearchRadiusKM = 100.0
+
+	// Per-row weights of the search cost. A row costs 1 to read; each feature below adds to that.
+	textVariantRowCost     = 1.0  // One LIKE over title and description per spelling of q
+	distanceRowCost        = 0.5  // ST_Distance is computed for every row when lat and lon are given
+	wideRadiusRowCost      = 1.0  // A wide (or no) radius leaves the spatial index little to prune
+	polygonRowCost         = 1.0  // ST_Within against a GeoJSON polygon
+	attributeFilterRowCost = 0.25 // One JSONB lookup per category attribute filter
+	openNowRowCost         = 0.25 // One JSONB lookup of the day's business hours
+	housingFilterRowCost   = 0.25 // One lookup of the housing details per min_bedrooms or max_rent filter
+	babysittingRowCost     = 0.25 // One lookup of the babysitting details per availability or max_hourly_rate filter
+)
+
+// SearchCost is an estimate of how much work a listing search makes the database do, in rows read weighted
+// by the per-row work of the filters. Deep offsets dominate it: every skipped row is read and sorted too.
+type SearchCost struct {
+	Rows       int      `json:"rows"`                 // Rows read: the offset plus one page
+	RowCost    float64  `json:"row_cost"`             // Work per row
+	Total      float64  `json:"total"`                // Rows × RowCost
+	Limit      float64  `json:"limit"`                // SEARCH_MAX_QUERY_COST
+	Simplified []string `json:"simplified,omitempty"` // Simplifications made before the estimate
+}
+
+// QueryTooExpensiveDetails is the body of a QUERY_TOO_EXPENSIVE error: the estimate and how to page instead.
+type QueryTooExpensiveDetails struct {
+	Message string     `json:"message"`
+	Cost    SearchCost `json:"cost"`
+	Advice  []string   `json:"advice"`
+}
+
+// estimateSearchCost estimates the cost of a prepared search query, i.e. after prepareSearchQuery.
+func estimateSearchCost(query ListingSearchQuery) SearchCost {
+	page := common.PaginationQuery{Page: query.Page, PageSize: query.PageSize}
+	rows := page.Offset() + page.Limit()
+
+	rowCost := 1.0
+	if query.SearchTerm != "" {
+		variants := len(query.SearchVariants)
+		if variants == 0 {
+			variants = 1
+		}
+		rowCost += textVariantRowCost * float64(variants)
+	}
+	if query.Latitude != nil && query.Longitude != nil {
+		rowCost += distanceRowCost
+		if query.MaxDistanceKM == nil || *query.MaxDistanceKM <= 0 || *query.MaxDistanceKM > wideSearchRadiusKM {
+			rowCost += wideRadiusRowCost
+		}
+	}
+	if query.Polygon != "" {
+		rowCost += polygonRowCost
+	}
+	rowCost += attributeFilterRowCost * float64(len(query.AttributeFilters))
+	if query.OpenNow {
+		rowCost += openNowRowCost
+	}
+	if query.MinBedrooms != nil {
+		rowCost += housingFilterRowCost
+	}
+	if query.MaxRent != nil {
+		rowCost += housingFilterRowCost
+	}
+	if query.AvailableDay != "" {
+		rowCost += babysittingRowCost
+	}
+	if query.MaxHourlyRate != nil {
+		rowCost += babysittingRowCost
+	}
+
+	return SearchCost{Rows: rows, RowCost: rowCost, Total: float64(rows) * rowCost}
+}
+
+// simplifySearch makes an over-budget query cheaper in ways that barely change its results: it matches only the
+// spelling of q that was typed, not its place name synonyms, and narrows a wide radius to wideSearchRadiusKM.
+// It returns the names of the simplifications made.
+func simplifySearch(query *ListingSearchQuery) []string {
+	var simplified []string
+	if len(query.SearchVariants) > 1 {
+		query.SearchVariants = query.SearchVariants[:1] // The typed spelling comes first
+		simplified = append(simplified, "synonyms")
+	}
+	if query.Latitude != nil && query.Longitude != nil &&
+		(query.MaxDistanceKM == nil || *query.MaxDistanceKM <= 0 || *query.MaxDistanceKM > wideSearchRadiusKM) {
+		radius := wideSearchRadiusKM
+		query.MaxDistanceKM = &radius
+		simplified = append(simplified, "radius")
+	}
+	return simplified
+}
+
+// checkSearchCost keeps a search within SEARCH_MAX_QUERY_COST: it simplifies a query that is over budget and
+// rejects it with QUERY_TOO_EXPENSIVE if that is not enough. Every estimate is logged for tuning the limit.
+func (s *ServiceImplementation) checkSearchCost(query *ListingSearchQuery) error {
+	limit := float64(s.cfg.SearchMaxQueryCost)
+	cost := estimateSearchCost(*query)
+	cost.Limit = limit
+	if limit <= 0 || cost.Total <= limit {
+		s.logger.Debug("Search cost estimated", zap.Int("rows", cost.Rows), zap.Float64("rowCost", cost.RowCost), zap.Float64("cost", cost.Total))
+		return nil
+	}
+
+	simplified := simplifySearch(query)
+	if len(simplified) > 0 {
+		before := cost.Total
+		cost = estimateSearchCost(*query)
+		cost.Limit, cost.Simplified = limit, simplified
+		s.logger.Info("Simplified expensive search",
+			zap.Strings("simplified", simplified),
+			zap.Float64("costBefore", before),
+			zap.Float64("cost", cost.Total),
+			zap.Float64("limit", limit))
+		if cost.Total <= limit {
+			return nil
+		}
+	}
+
+	s.logger.Warn("Rejected expensive search",
+		zap.Int("rows", cost.Rows),
+		zap.Int("page", query.Page),
+		zap.Int("pageSize", query.PageSize),
+		zap.Float64("rowCost", cost.RowCost),
+		zap.Float64("cost", cost.Total),
+		zap.Float64("limit", limit),
+		zap.Bool("text", query.SearchTerm != ""),
+		zap.Bool("geo", query.Latitude != nil && query.Longitude != nil))
+	return common.ErrQueryTooExpensive.WithDetails(QueryTooExpensiveDetails{
+		Message: "This search reads too many rows to skip to the requested page.",
+		Cost:    cost,
+		Advice: []string{
+			"Page with a cursor instead of a deep page number: sort by created_at descending and pass the created_at of the last listing you received as created_before, keeping page at 1.",
+			"Use a larger page_size so fewer pages are needed.",
+			"Narrow the search with a smaller max_distance_km, a bbox or a category.",
+		},
+	})
+}
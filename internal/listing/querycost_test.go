@@ -0,0 +1,70 @@
+package listing
+
+import (
+	"testing"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestEstimateSearchCost(t *testing.T) {
+	lat, lon, radius := 47.61, -122.33, 5000.0
+
+	cheap := estimateSearchCost(ListingSearchQuery{PaginationQuery: common.PaginationQuery{Page: 1, PageSize: 20}})
+	assert.Equal(t, 20, cheap.Rows)
+	assert.Equal(t, 1.0, cheap.RowCost)
+
+	deep := estimateSearchCost(ListingSearchQuery{
+		PaginationQuery: common.PaginationQuery{Page: 2000, PageSize: 5},
+		SearchTerm:      "cap hill",
+		SearchVariants:  []string{"cap hill", "capitol hill"},
+		Latitude:        &lat,
+		Longitude:       &lon,
+		MaxDistanceKM:   &radius,
+	})
+	assert.Equal(t, 10000, deep.Rows, "every skipped row is read")
+	assert.Equal(t, 1+2*textVariantRowCost+distanceRowCost+wideRadiusRowCost, deep.RowCost)
+	assert.Equal(t, float64(deep.Rows)*deep.RowCost, deep.Total)
+}
+
+func TestCheckSearchCost(t *testing.T) {
+	lat, lon := 47.61, -122.33
+	newQuery := func(page int) ListingSearchQuery {
+		radius := 5000.0
+		return ListingSearchQuery{
+			PaginationQuery: common.PaginationQuery{Page: page, PageSize: 10},
+			SearchTerm:      "cap hill",
+			SearchVariants:  []string{"cap hill", "capitol hill"},
+			Latitude:        &lat,
+			Longitude:       &lon,
+			MaxDistanceKM:   &radius,
+		}
+	}
+	svc := &ServiceImplementation{cfg: &config.Config{SearchMaxQueryCost: 1000}, logger: zap.NewNop()}
+
+	query := newQuery(1)
+	require.NoError(t, svc.checkSearchCost(&query))
+	assert.Len(t, query.SearchVariants, 2, "affordable searches are left alone")
+
+	// 400 rows at 4.5 per row is over budget; without synonyms and the huge radius they cost 400 × 2.5 = 1000.
+	query = newQuery(40)
+	require.NoError(t, svc.checkSearchCost(&query))
+	assert.Equal(t, []string{"cap hill"}, query.SearchVariants)
+	assert.Equal(t, wideSearchRadiusKM, *query.MaxDistanceKM)
+
+	query = newQuery(1000)
+	err := svc.checkSearchCost(&query)
+	require.ErrorIs(t, err, common.ErrQueryTooExpensive)
+	details, ok := common.ErrQueryTooExpensive.Details.(QueryTooExpensiveDetails)
+	require.True(t, ok)
+	assert.Equal(t, []string{"synonyms", "radius"}, details.Cost.Simplified)
+	assert.Contains(t, details.Advice[0], "created_before")
+
+	svc.cfg.SearchMaxQueryCost = 0
+	query = newQuery(1000)
+	assert.NoError(t, svc.checkSearchCost(&query), "a limit of 0 disables the check")
+}
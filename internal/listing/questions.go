@@ -0,0 +1,170 @@
+// File: internal/listing/questions.go
+package listing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/notification"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// defaultQuestionPageSize is how many questions a listing response embeds when the caller does not ask otherwise.
+const defaultQuestionPageSize = 5
+
+// Question is a public question about a listing and its owner's answer.
+// Answered questions are shown to everyone; unanswered ones only to the owner and the asker.
+type Question struct {
+	common.BaseModel
+	ListingID  uuid.UUID `gorm:"type:uuid;not null"`
+	AskerID    uuid.UUID `gorm:"type:uuid;not null"`
+	Body       string    `gorm:"type:text;not null"`
+	Answer     *string   `gorm:"type:text"`
+	AnsweredAt *time.Time
+}
+
+// TableName specifies the table name for GORM.
+func (Question) TableName() string {
+	return "listing_questions"
+}
+
+// AskQuestionRequest is the payload of POST /listings/:id/questions.
+type AskQuestionRequest struct {
+	Body string `json:"body" binding:"required,min=5,max=1000"`
+}
+
+// AnswerQuestionRequest is the payload of POST /questions/:id/answer.
+type AnswerQuestionRequest struct {
+	Answer string `json:"answer" binding:"required,min=1,max=2000"`
+}
+
+// QuestionFilter selects the questions of a listing that FindQuestions returns.
+type QuestionFilter struct {
+	ListingID         uuid.UUID
+	AskerID           *uuid.UUID // Also return this user's unanswered questions
+	IncludeUnanswered bool       // Return every unanswered question
+}
+
+// QuestionResponse is the API representation of a question.
+type QuestionResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	ListingID  uuid.UUID  `json:"listing_id"`
+	AskerID    uuid.UUID  `json:"asker_id"`
+	Body       string     `json:"body"`
+	Answer     *string    `json:"answer,omitempty"`
+	AnsweredAt *time.Time `json:"answered_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// QuestionPage is a page of a listing's questions, embedded in the listing response.
+type QuestionPage struct {
+	Items      []QuestionResponse `json:"items"`
+	Pagination *common.Pagination `json:"pagination"`
+}
+
+// ToQuestionResponse converts a Question model to a QuestionResponse DTO.
+func ToQuestionResponse(q *Question) QuestionResponse {
+	return QuestionResponse{
+		ID:         q.ID,
+		ListingID:  q.ListingID,
+		AskerID:    q.AskerID,
+		Body:       q.Body,
+		Answer:     q.Answer,
+		AnsweredAt: q.AnsweredAt,
+		CreatedAt:  q.CreatedAt,
+	}
+}
+
+// ToQuestionPage converts a page of questions to a QuestionPage DTO.
+func ToQuestionPage(questions []Question, pagination *common.Pagination) *QuestionPage {
+	items := make([]QuestionResponse, 0, len(questions))
+	for i := range questions {
+		items = append(items, ToQuestionResponse(&questions[i]))
+	}
+	return &QuestionPage{Items: items, Pagination: pagination}
+}
+
+// AskQuestion posts a question about an active listing and notifies its owner. Owners cannot ask about their
+// own listings.
+func (s *ServiceImplementation) AskQuestion(ctx context.Context, listingID uuid.UUID, askerID uuid.UUID, req AskQuestionRequest) (*Question, error) {
+	l, err := s.GetListingByID(ctx, listingID, &askerID)
+	if err != nil {
+		return nil, err
+	}
+	if l.UserID == askerID {
+		return nil, common.ErrBadRequest.WithDetails("You cannot ask a question about your own listing.")
+	}
+	if l.Status != StatusActive {
+		return nil, common.ErrBadRequest.WithDetails("Questions can only be asked about active listings.")
+	}
+
+	question := &Question{ListingID: l.ID, AskerID: askerID, Body: strings.TrimSpace(req.Body)}
+	if err := s.repo.CreateQuestion(ctx, question); err != nil {
+		s.logger.Error("Failed to create question", zap.String("listingID", listingID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not post question.")
+	}
+
+	s.notifyOwner(ctx, l, notification.ListingQuestionAsked, fmt.Sprintf("Someone asked a question about your listing '%s'.", l.Title))
+	return question, nil
+}
+
+// AnswerQuestion sets the owner's answer to a question, making it public. Answering again replaces the answer;
+// the asker is notified of the first answer only.
+func (s *ServiceImplementation) AnswerQuestion(ctx context.Context, questionID uuid.UUID, userID uuid.UUID, req AnswerQuestionRequest) (*Question, error) {
+	question, err := s.repo.FindQuestionByID(ctx, questionID)
+	if err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to get question", zap.String("questionID", questionID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not answer question.")
+	}
+	l, err := s.repo.FindByID(ctx, question.ListingID, false)
+	if err != nil {
+		return nil, err
+	}
+	if l.UserID != userID {
+		return nil, common.ErrForbidden.WithDetails("Only the listing's owner can answer its questions.")
+	}
+
+	firstAnswer := question.Answer == nil
+	answer := strings.TrimSpace(req.Answer)
+	now := time.Now()
+	question.Answer = &answer
+	question.AnsweredAt = &now
+	if err := s.repo.UpdateQuestionAnswer(ctx, question); err != nil {
+		s.logger.Error("Failed to answer question", zap.String("questionID", questionID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not answer question.")
+	}
+
+	if firstAnswer && s.notificationService != nil {
+		message := fmt.Sprintf("Your question about '%s' was answered.", l.Title)
+		if _, err := s.notificationService.CreateNotification(ctx, question.AskerID, notification.ListingQuestionAnswered, message, &l.ID); err != nil {
+			s.logger.Error("Failed to send question answered notification",
+				zap.Error(err),
+				zap.String("questionID", questionID.String()),
+				zap.String("userID", question.AskerID.String()))
+		}
+	}
+	return question, nil
+}
+
+// ListQuestions returns a page of the listing's questions visible to viewerID (nil for anonymous viewers), newest
+// first: the answered ones, plus the viewer's own unanswered questions, or all of them for the owner.
+func (s *ServiceImplementation) ListQuestions(ctx context.Context, l *Listing, viewerID *uuid.UUID, page, pageSize int) ([]Question, *common.Pagination, error) {
+	filter := QuestionFilter{ListingID: l.ID, AskerID: viewerID}
+	if viewerID != nil && *viewerID == l.UserID {
+		filter.IncludeUnanswered = true
+	}
+	questions, pagination, err := s.repo.FindQuestions(ctx, filter, page, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to list questions", zap.String("listingID", l.ID.String()), zap.Error(err))
+		return nil, nil, common.ErrInternalServer.WithDetails("Could not retrieve questions.")
+	}
+	return questions, pagination, nil
+}
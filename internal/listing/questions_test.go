@@ -0,0 +1,124 @@
+package listing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/notification"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// questionRepository keeps one listing and its questions in memory.
+type questionRepository struct {
+	Repository
+	listing    Listing
+	questions  []*Question
+	lastFilter QuestionFilter
+}
+
+func (r *questionRepository) FindByID(_ context.Context, id uuid.UUID, _ bool) (*Listing, error) {
+	if id != r.listing.ID {
+		return nil, common.ErrNotFound.WithDetails("Listing not found.")
+	}
+	l := r.listing
+	return &l, nil
+}
+
+func (r *questionRepository) CreateQuestion(_ context.Context, question *Question) error {
+	question.ID = uuid.New()
+	r.questions = append(r.questions, question)
+	return nil
+}
+
+func (r *questionRepository) UpdateQuestionAnswer(_ context.Context, _ *Question) error {
+	return nil
+}
+
+func (r *questionRepository) FindQuestionByID(_ context.Context, id uuid.UUID) (*Question, error) {
+	for _, q := range r.questions {
+		if q.ID == id {
+			return q, nil
+		}
+	}
+	return nil, common.ErrNotFound.WithDetails("Question not found.")
+}
+
+func (r *questionRepository) FindQuestions(_ context.Context, filter QuestionFilter, page, pageSize int) ([]Question, *common.Pagination, error) {
+	r.lastFilter = filter
+	return nil, common.NewPagination(0, page, pageSize), nil
+}
+
+func TestQuestionFlow(t *testing.T) {
+	owner, asker := uuid.New(), uuid.New()
+	repo := &questionRepository{listing: Listing{UserID: owner, Title: "Bike", Status: StatusActive, ExpiresAt: time.Now().Add(24 * time.Hour)}}
+	repo.listing.ID = uuid.New()
+	notifications := &sentNotifications{}
+	svc := &ServiceImplementation{
+		repo:                repo,
+		notificationService: notifications,
+		cfg:                 &config.Config{},
+		logger:              zap.NewNop(),
+	}
+	ctx := context.Background()
+
+	_, err := svc.AskQuestion(ctx, repo.listing.ID, owner, AskQuestionRequest{Body: "Is it still for sale?"})
+	assert.ErrorIs(t, err, common.ErrBadRequest, "owners cannot ask about their own listing")
+
+	question, err := svc.AskQuestion(ctx, repo.listing.ID, asker, AskQuestionRequest{Body: "  What size is the frame?  "})
+	require.NoError(t, err)
+	assert.Equal(t, "What size is the frame?", question.Body)
+	require.Len(t, notifications.types, 1)
+	assert.Equal(t, notification.ListingQuestionAsked, notifications.types[0])
+
+	_, err = svc.AnswerQuestion(ctx, question.ID, asker, AnswerQuestionRequest{Answer: "Large."})
+	assert.ErrorIs(t, err, common.ErrForbidden, "only the owner answers")
+
+	answered, err := svc.AnswerQuestion(ctx, question.ID, owner, AnswerQuestionRequest{Answer: " 58 cm. "})
+	require.NoError(t, err)
+	assert.Equal(t, "58 cm.", *answered.Answer)
+	require.NotNil(t, answered.AnsweredAt)
+	require.Len(t, notifications.types, 2)
+	assert.Equal(t, notification.ListingQuestionAnswered, notifications.types[1])
+
+	_, err = svc.AnswerQuestion(ctx, question.ID, owner, AnswerQuestionRequest{Answer: "58 cm, size L."})
+	require.NoError(t, err)
+	assert.Len(t, notifications.types, 2, "editing an answer does not notify the asker again")
+}
+
+func TestAskQuestionRequiresActiveListing(t *testing.T) {
+	owner := uuid.New()
+	repo := &questionRepository{listing: Listing{UserID: owner, Status: StatusExpired}}
+	repo.listing.ID = uuid.New()
+	svc := &ServiceImplementation{repo: repo, logger: zap.NewNop()}
+
+	_, err := svc.AskQuestion(context.Background(), repo.listing.ID, uuid.New(), AskQuestionRequest{Body: "Is it still for sale?"})
+	assert.Error(t, err)
+	assert.Empty(t, repo.questions)
+}
+
+func TestListQuestionsVisibility(t *testing.T) {
+	owner, viewer := uuid.New(), uuid.New()
+	repo := &questionRepository{listing: Listing{UserID: owner, Status: StatusActive}}
+	repo.listing.ID = uuid.New()
+	svc := &ServiceImplementation{repo: repo, logger: zap.NewNop()}
+	ctx := context.Background()
+
+	_, _, err := svc.ListQuestions(ctx, &repo.listing, nil, 1, 5)
+	require.NoError(t, err)
+	assert.Equal(t, QuestionFilter{ListingID: repo.listing.ID}, repo.lastFilter, "anonymous viewers see answered questions only")
+
+	_, _, err = svc.ListQuestions(ctx, &repo.listing, &viewer, 1, 5)
+	require.NoError(t, err)
+	assert.Equal(t, QuestionFilter{ListingID: repo.listing.ID, AskerID: &viewer}, repo.lastFilter, "viewers also see their own questions")
+
+	_, _, err = svc.ListQuestions(ctx, &repo.listing, &owner, 1, 5)
+	require.NoError(t, err)
+	assert.True(t, repo.lastFilter.IncludeUnanswered, "the owner sees every question")
+}
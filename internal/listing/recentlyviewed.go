@@ -0,0 +1,175 @@
+// File: internal/listing/recentlyviewed.go
+package listing
+
+import (
+	"context"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Tables of the histories of viewed listings.
+const (
+	recentViewsTable          = "listing_recent_views"
+	anonymousRecentViewsTable = "listing_anonymous_recent_views"
+)
+
+// RecentView is a listing in a user's history of viewed listings, with the time of the last view.
+type RecentView struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ListingID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ViewedAt  time.Time `gorm:"not null"`
+}
+
+func (RecentView) TableName() string {
+	return recentViewsTable
+}
+
+// AnonymousRecentView is a listing in the history of an anonymous session. The history is merged into the
+// user's when the visitor signs in.
+type AnonymousRecentView struct {
+	SessionID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ListingID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ViewedAt  time.Time `gorm:"not null"`
+}
+
+func (AnonymousRecentView) TableName() string {
+	return anonymousRecentViewsTable
+}
+
+// RecentlyViewedResponse is an item of a history of viewed listings: the listing and when it was last viewed.
+type RecentlyViewedResponse struct {
+	ViewedAt time.Time `json:"viewed_at"`
+	ListingResponse
+}
+
+// recordRecentView adds the listing to the user's history of viewed listings, which keeps the
+// cfg.RecentlyViewedLimit most recent ones. Failures are logged and never reach the viewer.
+func (s *ServiceImplementation) recordRecentView(ctx context.Context, userID, listingID uuid.UUID) {
+	limit := s.cfg.RecentlyViewedLimit
+	if limit <= 0 {
+		return
+	}
+	view := RecentView{UserID: userID, ListingID: listingID, ViewedAt: time.Now().UTC()}
+	if err := s.repo.RecordRecentView(ctx, view, limit); err != nil {
+		s.logger.Warn("Failed to record recently viewed listing",
+			zap.String("userID", userID.String()),
+			zap.String("listingID", listingID.String()),
+			zap.Error(err))
+	}
+}
+
+// GetRecentlyViewed returns the listings the user viewed most recently, most recent first.
+// Listings that are no longer active or visible are left out; they reappear if they come back.
+func (s *ServiceImplementation) GetRecentlyViewed(ctx context.Context, userID uuid.UUID) ([]RecentlyViewedResponse, error) {
+	limit := s.cfg.RecentlyViewedLimit
+	if limit <= 0 {
+		return []RecentlyViewedResponse{}, nil
+	}
+
+	views, err := s.repo.FindRecentViews(ctx, userID, limit)
+	if err != nil {
+		s.logger.Error("Failed to load recently viewed listings", zap.String("userID", userID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve recently viewed listings.")
+	}
+	return s.toRecentlyViewed(ctx, views)
+}
+
+// toRecentlyViewed loads the listings of views, in the order of views.
+func (s *ServiceImplementation) toRecentlyViewed(ctx context.Context, views []RecentView) ([]RecentlyViewedResponse, error) {
+	ids := make([]uuid.UUID, 0, len(views))
+	for _, v := range views {
+		ids = append(ids, v.ListingID)
+	}
+	listings, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("Failed to load recently viewed listings", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve recently viewed listings.")
+	}
+	byID := make(map[uuid.UUID]*Listing, len(listings))
+	for i := range listings {
+		byID[listings[i].ID] = &listings[i]
+	}
+
+	responses := make([]RecentlyViewedResponse, 0, len(views))
+	for _, v := range views {
+		if l, ok := byID[v.ListingID]; ok {
+			responses = append(responses, RecentlyViewedResponse{ViewedAt: v.ViewedAt, ListingResponse: ToListingResponse(l, s.imageURLs)})
+		}
+	}
+	return responses, nil
+}
+
+// ClearRecentlyViewed deletes the user's history of viewed listings.
+func (s *ServiceImplementation) ClearRecentlyViewed(ctx context.Context, userID uuid.UUID) error {
+	if err := s.repo.DeleteRecentViews(ctx, userID); err != nil {
+		s.logger.Error("Failed to clear recently viewed listings", zap.String("userID", userID.String()), zap.Error(err))
+		return common.ErrInternalServer.WithDetails("Could not clear recently viewed listings.")
+	}
+	return nil
+}
+
+// RecordAnonymousView adds an active listing to the recently viewed listings of an anonymous session.
+// Failures are logged and never reach the viewer.
+func (s *ServiceImplementation) RecordAnonymousView(ctx context.Context, l *Listing, sessionID uuid.UUID) {
+	limit := s.cfg.RecentlyViewedLimit
+	if limit <= 0 || l.Status != StatusActive {
+		return
+	}
+	view := AnonymousRecentView{SessionID: sessionID, ListingID: l.ID, ViewedAt: time.Now().UTC()}
+	if err := s.repo.RecordAnonymousRecentView(ctx, view, limit); err != nil {
+		s.logger.Warn("Failed to record anonymously viewed listing",
+			zap.String("sessionID", sessionID.String()),
+			zap.String("listingID", l.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// GetAnonymousRecentlyViewed is GetRecentlyViewed for an anonymous session.
+func (s *ServiceImplementation) GetAnonymousRecentlyViewed(ctx context.Context, sessionID uuid.UUID) ([]RecentlyViewedResponse, error) {
+	limit := s.cfg.RecentlyViewedLimit
+	if limit <= 0 {
+		return []RecentlyViewedResponse{}, nil
+	}
+
+	anonymousViews, err := s.repo.FindAnonymousRecentViews(ctx, sessionID, limit)
+	if err != nil {
+		s.logger.Error("Failed to load anonymously viewed listings", zap.String("sessionID", sessionID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve recently viewed listings.")
+	}
+	views := make([]RecentView, 0, len(anonymousViews))
+	for _, v := range anonymousViews {
+		views = append(views, RecentView{ListingID: v.ListingID, ViewedAt: v.ViewedAt})
+	}
+	return s.toRecentlyViewed(ctx, views)
+}
+
+// ClearAnonymousRecentlyViewed deletes the history of viewed listings of an anonymous session.
+func (s *ServiceImplementation) ClearAnonymousRecentlyViewed(ctx context.Context, sessionID uuid.UUID) error {
+	if err := s.repo.DeleteAnonymousRecentViews(ctx, sessionID); err != nil {
+		s.logger.Error("Failed to clear anonymously viewed listings", zap.String("sessionID", sessionID.String()), zap.Error(err))
+		return common.ErrInternalServer.WithDetails("Could not clear recently viewed listings.")
+	}
+	return nil
+}
+
+// MergeAnonymousRecentlyViewed moves the history of an anonymous session into the user's and returns the number
+// of listings moved. Views of the user's own listings are dropped.
+func (s *ServiceImplementation) MergeAnonymousRecentlyViewed(ctx context.Context, sessionID, userID uuid.UUID) (int, error) {
+	keep := s.cfg.RecentlyViewedLimit
+	if keep <= 0 {
+		return 0, nil
+	}
+	merged, err := s.repo.MergeAnonymousRecentViews(ctx, sessionID, userID, keep)
+	if err != nil {
+		s.logger.Error("Failed to merge anonymously viewed listings",
+			zap.String("sessionID", sessionID.String()),
+			zap.String("userID", userID.String()),
+			zap.Error(err))
+		return 0, common.ErrInternalServer.WithDetails("Could not merge recently viewed listings.")
+	}
+	return int(merged), nil
+}
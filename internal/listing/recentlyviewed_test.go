@@ -0,0 +1,99 @@
+package listing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/config"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// recentViewsRepository keeps recent views in memory and serves listings by ID.
+type recentViewsRepository struct {
+	Repository
+	views    []RecentView
+	listings []Listing
+	keep     int
+}
+
+func (r *recentViewsRepository) IncrementDailyViews(context.Context, uuid.UUID, time.Time) error {
+	return nil
+}
+
+func (r *recentViewsRepository) RecordRecentView(_ context.Context, view RecentView, keep int) error {
+	r.views = append(r.views, view)
+	r.keep = keep
+	return nil
+}
+
+func (r *recentViewsRepository) FindRecentViews(_ context.Context, userID uuid.UUID, limit int) ([]RecentView, error) {
+	var views []RecentView
+	for i := len(r.views) - 1; i >= 0 && len(views) < limit; i-- {
+		if r.views[i].UserID == userID {
+			views = append(views, r.views[i])
+		}
+	}
+	return views, nil
+}
+
+func (r *recentViewsRepository) FindByIDs(_ context.Context, ids []uuid.UUID) ([]Listing, error) {
+	var found []Listing
+	for _, l := range r.listings {
+		if containsID(ids, l.ID) {
+			found = append(found, l)
+		}
+	}
+	return found, nil
+}
+
+func TestRecordListingViewRemembersSignedInViewers(t *testing.T) {
+	owner, viewer := uuid.New(), uuid.New()
+	l := &Listing{UserID: owner, Status: StatusActive}
+	l.ID = uuid.New()
+	repo := &recentViewsRepository{}
+	svc := &ServiceImplementation{repo: repo, cfg: &config.Config{RecentlyViewedLimit: 10}, logger: zap.NewNop()}
+
+	svc.RecordListingView(context.Background(), l, nil)
+	svc.RecordListingView(context.Background(), l, &owner)
+	assert.Empty(t, repo.views, "anonymous and owner views are not remembered")
+
+	svc.RecordListingView(context.Background(), l, &viewer)
+	require.Len(t, repo.views, 1)
+	assert.Equal(t, viewer, repo.views[0].UserID)
+	assert.Equal(t, l.ID, repo.views[0].ListingID)
+	assert.Equal(t, 10, repo.keep)
+
+	svc.cfg.RecentlyViewedLimit = 0
+	svc.RecordListingView(context.Background(), l, &viewer)
+	assert.Len(t, repo.views, 1, "a limit of 0 disables the history")
+}
+
+func TestGetRecentlyViewed(t *testing.T) {
+	userID := uuid.New()
+	first := Listing{Title: "First", Status: StatusActive}
+	first.ID = uuid.New()
+	second := Listing{Title: "Second", Status: StatusActive}
+	second.ID = uuid.New()
+	now := time.Now().UTC()
+	repo := &recentViewsRepository{
+		listings: []Listing{first, second},
+		views: []RecentView{
+			{UserID: userID, ListingID: first.ID, ViewedAt: now.Add(-time.Hour)},
+			{UserID: uuid.New(), ListingID: first.ID, ViewedAt: now},
+			{UserID: userID, ListingID: second.ID, ViewedAt: now},
+		},
+	}
+	svc := &ServiceImplementation{repo: repo, cfg: &config.Config{RecentlyViewedLimit: 10}, logger: zap.NewNop()}
+
+	viewed, err := svc.GetRecentlyViewed(context.Background(), userID)
+	require.NoError(t, err)
+	require.Len(t, viewed, 2)
+	assert.Equal(t, "Second", viewed[0].Title, "most recent first")
+	assert.Equal(t, now, viewed[0].ViewedAt)
+	assert.Equal(t, "First", viewed[1].Title)
+}
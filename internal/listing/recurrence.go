@@ -0,0 +1,168 @@
+// File: internal/listing/recurrence.go
+package listing
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/common"
+)
+
+const (
+	eventDateLayout = "2006-01-02"
+	eventTimeLayout = "15:04:05"
+
+	// maxOccurrenceSteps bounds the scan for the next occurrence after jumping close to the reference date.
+	maxOccurrenceSteps = 3
+)
+
+// IsRecurring reports whether the event repeats.
+func (e *ListingDetailsEvents) IsRecurring() bool {
+	return e.RecurrenceFrequency != nil
+}
+
+// applyRecurrence sets the repeat rule of an event from a request, checking it against the event date.
+func (e *ListingDetailsEvents) applyRecurrence(req *EventRecurrenceRequest) error {
+	freq := req.Frequency
+	if freq != EventRecursWeekly && freq != EventRecursMonthly {
+		return common.ErrBadRequest.WithDetails("Recurrence frequency must be 'weekly' or 'monthly'.")
+	}
+	interval := req.Interval
+	if interval == 0 {
+		interval = 1
+	}
+	if interval < 1 || interval > 12 {
+		return common.ErrBadRequest.WithDetails("Recurrence interval must be between 1 and 12.")
+	}
+
+	var until *time.Time
+	if req.Until != nil && *req.Until != "" {
+		u, err := time.Parse(eventDateLayout, *req.Until)
+		if err != nil {
+			return common.ErrBadRequest.WithDetails("Recurrence end date must be in the format YYYY-MM-DD.")
+		}
+		if u.Before(dateOnly(e.EventDate, time.UTC)) {
+			return common.ErrBadRequest.WithDetails("Recurrence end date must not be before the event date.")
+		}
+		until = &u
+	}
+
+	e.RecurrenceFrequency = &freq
+	e.RecurrenceInterval = interval
+	e.RecurrenceUntil = until
+	return nil
+}
+
+// clearRecurrence turns the event back into a one-off.
+func (e *ListingDetailsEvents) clearRecurrence() {
+	e.RecurrenceFrequency = nil
+	e.RecurrenceInterval = 1
+	e.RecurrenceUntil = nil
+}
+
+// OccurrenceAfter returns the start of the first occurrence that has not yet passed at now, in now's location.
+// Events without a time count as all-day, so they stay upcoming for the whole day.
+// It returns false when a one-off event is over or a recurring event has run past its end date.
+func (e *ListingDetailsEvents) OccurrenceAfter(now time.Time) (time.Time, bool) {
+	loc := now.Location()
+	first := dateOnly(e.EventDate, loc)
+	today := dateOnly(now, loc)
+
+	if !e.IsRecurring() {
+		return e.startOn(first), e.notPassed(first, today, now)
+	}
+
+	interval := e.RecurrenceInterval
+	if interval < 1 {
+		interval = 1
+	}
+
+	// Jump to the last occurrence on or before today rather than walking every one since the first.
+	n := 0
+	if today.After(first) {
+		switch *e.RecurrenceFrequency {
+		case EventRecursWeekly:
+			n = int(today.Sub(first).Hours()/24) / (7 * interval)
+		case EventRecursMonthly:
+			months := (today.Year()-first.Year())*12 + int(today.Month()-first.Month())
+			n = months / interval
+		}
+	}
+
+	for step := 0; step < maxOccurrenceSteps; step, n = step+1, n+1 {
+		day := e.nthOccurrence(first, n*interval)
+		if e.RecurrenceUntil != nil && day.After(dateOnly(*e.RecurrenceUntil, loc)) {
+			return time.Time{}, false
+		}
+		if e.notPassed(day, today, now) {
+			return e.startOn(day), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// RRule renders the repeat rule as an iCalendar RRULE value, or "" for one-off events.
+// UNTIL is a date for all-day events and, as RFC 5545 requires for zoned start times,
+// the end of the last day in loc converted to UTC otherwise.
+func (e *ListingDetailsEvents) RRule(loc *time.Location) string {
+	if !e.IsRecurring() {
+		return ""
+	}
+	parts := []string{"FREQ=" + strings.ToUpper(string(*e.RecurrenceFrequency))}
+	if e.RecurrenceInterval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", e.RecurrenceInterval))
+	}
+	if e.RecurrenceUntil != nil {
+		if e.EventTime == nil {
+			parts = append(parts, "UNTIL="+e.RecurrenceUntil.Format("20060102"))
+		} else {
+			endOfDay := dateOnly(*e.RecurrenceUntil, loc).AddDate(0, 0, 1).Add(-time.Second)
+			parts = append(parts, "UNTIL="+endOfDay.UTC().Format("20060102T150405Z"))
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+// nthOccurrence returns the date that is offset weeks or months after first.
+// Monthly events on days a month lacks (e.g. the 31st) fall on that month's last day.
+func (e *ListingDetailsEvents) nthOccurrence(first time.Time, offset int) time.Time {
+	if *e.RecurrenceFrequency == EventRecursWeekly {
+		return first.AddDate(0, 0, 7*offset)
+	}
+	monthStart := time.Date(first.Year(), first.Month()+time.Month(offset), 1, 0, 0, 0, 0, first.Location())
+	lastDay := monthStart.AddDate(0, 1, -1).Day()
+	day := first.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(monthStart.Year(), monthStart.Month(), day, 0, 0, 0, 0, first.Location())
+}
+
+// notPassed reports whether the occurrence on day is still upcoming at now.
+func (e *ListingDetailsEvents) notPassed(day, today, now time.Time) bool {
+	if day.After(today) {
+		return true
+	}
+	if day.Before(today) {
+		return false
+	}
+	return e.EventTime == nil || !e.startOn(day).Before(now)
+}
+
+// startOn combines a date with the event time; all-day events start at midnight.
+func (e *ListingDetailsEvents) startOn(day time.Time) time.Time {
+	if e.EventTime == nil {
+		return day
+	}
+	t, err := time.Parse(eventTimeLayout, *e.EventTime)
+	if err != nil {
+		return day
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), t.Second(), 0, day.Location())
+}
+
+// dateOnly returns midnight of t's calendar date in loc. DATE columns carry no zone, so the date components are kept as-is.
+func dateOnly(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
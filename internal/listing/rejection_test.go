@@ -0,0 +1,50 @@
+package listing
+
+import (
+	"testing"
+
+	"seattle_info_backend/internal/user"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminUpdateListingStatusRequestRequiresReasonForRejection(t *testing.T) {
+	reason := RejectionDuplicate
+	unknown := RejectionReason("too_expensive")
+
+	assert.Error(t, binding.Validator.ValidateStruct(&AdminUpdateListingStatusRequest{Status: StatusRejected}))
+	assert.NoError(t, binding.Validator.ValidateStruct(&AdminUpdateListingStatusRequest{Status: StatusRejected, RejectionReason: &reason}))
+	assert.Error(t, binding.Validator.ValidateStruct(&AdminUpdateListingStatusRequest{Status: StatusRejected, RejectionReason: &unknown}))
+	assert.NoError(t, binding.Validator.ValidateStruct(&AdminUpdateListingStatusRequest{Status: StatusActive}))
+}
+
+func TestRejectionMessage(t *testing.T) {
+	reason := RejectionIncomplete
+	l := &Listing{Title: "Bike for sale", RejectionReason: &reason}
+	assert.Equal(t, "Your listing 'Bike for sale' was not approved because it is missing important information.", rejectionMessage(l))
+
+	notes := " Please add a price and photos. "
+	l.AdminNotes = &notes
+	assert.Equal(t, "Your listing 'Bike for sale' was not approved because it is missing important information. Note from our team: Please add a price and photos.", rejectionMessage(l))
+
+	assert.Contains(t, rejectionMessage(&Listing{Title: "Bike for sale"}), RejectionOther.Description())
+}
+
+func TestToOwnerListingResponseIncludesAdminDecision(t *testing.T) {
+	reason := RejectionSpam
+	notes := "Posted five times today."
+	l := patchTestListing()
+	l.Status = StatusRejected
+	l.RejectionReason = &reason
+	l.AdminNotes = &notes
+	l.User = &user.User{}
+
+	public := ToListingResponse(l, nil)
+	assert.Nil(t, public.AdminNotes)
+	assert.Nil(t, public.RejectionReason)
+
+	owner := ToOwnerListingResponse(l, nil)
+	assert.Equal(t, &notes, owner.AdminNotes)
+	assert.Equal(t, &reason, owner.RejectionReason)
+}
@@ -0,0 +1,19 @@
+package listing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelatedQueryText(t *testing.T) {
+	l := &Listing{Title: "Mountain bike", Description: "Lightly used, 21 gears."}
+	assert.Equal(t, "Mountain bike Lightly used, 21 gears.", relatedQueryText(l))
+
+	l.Description = strings.Repeat("gears ", 200)
+	text := relatedQueryText(l)
+	assert.LessOrEqual(t, len(text), maxRelatedQueryLength)
+	assert.True(t, strings.HasPrefix(text, "Mountain bike gears"))
+	assert.True(t, strings.HasSuffix(text, "gears"), "the text is cut at a word boundary")
+}
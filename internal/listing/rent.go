@@ -0,0 +1,217 @@
+// File: internal/listing/rent.go
+package listing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// rentBackfillBatchSize is how many housing rows BackfillRentAmounts reads at a time.
+const rentBackfillBatchSize = 500
+
+var (
+	// rentNumberPattern matches an amount such as "1,500", "1500.00" or "2.1k".
+	rentNumberPattern = regexp.MustCompile(`\d[\d,]*(\.\d+)?(\s*k\b)?`)
+	// rentRangeSuffix matches the rest of a range such as "1500 - 1700" or "1500 to 1700" after its first amount.
+	rentRangeSuffix = regexp.MustCompile(`^\s*(-|–|to)\s*\$?\d`)
+	// rentPeriodPatterns recognise the period a rent is quoted for. Without any, rent is taken to be monthly.
+	rentPeriodPatterns = []struct {
+		period  PricePeriod
+		pattern *regexp.Regexp
+	}{
+		{PriceMonthly, regexp.MustCompile(`month|/\s*mo\b|\bpcm\b|\bpm\b`)},
+		{PriceWeekly, regexp.MustCompile(`week|/\s*wk\b|\bpw\b`)},
+		{PriceDaily, regexp.MustCompile(`\bday\b|daily|night`)},
+		{PriceYearly, regexp.MustCompile(`year|annual|/\s*yr\b`)},
+	}
+	// rentPeriodNames is how formatRent writes each period.
+	rentPeriodNames = map[PricePeriod]string{PriceDaily: "day", PriceWeekly: "week", PriceMonthly: "month", PriceYearly: "year"}
+)
+
+// RentBackfillReport is the outcome of BackfillRentAmounts.
+type RentBackfillReport struct {
+	DryRun      bool              `json:"dry_run"`
+	Checked     int               `json:"checked"`      // Housing listings with rent_details but no rent_amount
+	Parsed      int               `json:"parsed"`       // Of those, the ones whose rent_details could be read
+	Updated     int               `json:"updated"`      // Rows given a rent_amount; 0 on a dry run
+	Unparseable int               `json:"unparseable"`  // Rows left for an admin to fix by hand
+	NeedsReview []UnparseableRent `json:"needs_review"` // Up to maxReportedInconsistencies entries
+}
+
+// UnparseableRent is a housing listing whose rent_details could not be read as an amount.
+type UnparseableRent struct {
+	ListingID   uuid.UUID `json:"listing_id"`
+	RentDetails string    `json:"rent_details"`
+}
+
+// parseRentDetails reads the amount and period from free-text rent such as "$1,500/month", "2.1k pcm" or
+// "$450 per week". When the text has several numbers, e.g. "2br/1ba $1,800", the one after a dollar sign is used.
+// Ranges, texts without an amount and texts with several numbers but no dollar sign cannot be read.
+func parseRentDetails(text string) (float64, PricePeriod, bool) {
+	lower := strings.ToLower(text)
+	matches := rentNumberPattern.FindAllStringIndex(lower, -1)
+	var match []int
+	for _, m := range matches {
+		if strings.HasSuffix(strings.TrimSpace(lower[:m[0]]), "$") {
+			match = m
+			break
+		}
+	}
+	if match == nil {
+		if len(matches) != 1 {
+			return 0, "", false
+		}
+		match = matches[0]
+	}
+	if rentRangeSuffix.MatchString(lower[match[1]:]) {
+		return 0, "", false
+	}
+
+	number := strings.TrimSpace(lower[match[0]:match[1]])
+	multiplier := 1.0
+	if strings.HasSuffix(number, "k") {
+		number, multiplier = strings.TrimSpace(strings.TrimSuffix(number, "k")), 1000
+	}
+	amount, err := strconv.ParseFloat(strings.ReplaceAll(number, ",", ""), 64)
+	if err != nil || amount <= 0 {
+		return 0, "", false
+	}
+	amount = math.Round(amount*multiplier*100) / 100
+
+	period, first := PriceMonthly, len(lower)
+	for _, p := range rentPeriodPatterns {
+		if loc := p.pattern.FindStringIndex(lower); loc != nil && loc[0] < first {
+			period, first = p.period, loc[0]
+		}
+	}
+	return amount, period, true
+}
+
+// formatRent writes a rent the way parseRentDetails reads it, e.g. "$1,500/month".
+func formatRent(amount float64, period PricePeriod) string {
+	amount = math.Round(amount*100) / 100
+	whole := int64(amount)
+	digits := strconv.FormatInt(whole, 10)
+	var b strings.Builder
+	b.WriteString("$")
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	if cents := int(math.Round((amount - float64(whole)) * 100)); cents > 0 {
+		b.WriteString(fmt.Sprintf(".%02d", cents))
+	}
+	b.WriteString("/" + rentPeriodNames[period])
+	return b.String()
+}
+
+// statesRent reports whether the free-text rent reads as amount per period.
+func statesRent(text *string, amount float64, period PricePeriod) bool {
+	if text == nil {
+		return false
+	}
+	parsed, parsedPeriod, ok := parseRentDetails(*text)
+	return ok && math.Abs(parsed-amount) < 0.005 && parsedPeriod == period
+}
+
+// applyRentTo sets the rent of d from the request. rent_amount and rent_period are authoritative. rent_details is
+// deprecated but still written for older clients: it is rewritten to state the numeric rent unless it already does.
+// A request with only rent_details sets the numeric rent parsed from it, or clears it when it cannot be read.
+func (r *CreateListingHousingDetailsRequest) applyRentTo(d *ListingDetailsHousing) {
+	if r.RentDetails != nil {
+		d.RentDetails = r.RentDetails
+	}
+	if r.RentAmount == nil && (r.RentPeriod == nil || d.RentAmount == nil) {
+		if r.RentDetails != nil {
+			d.RentAmount, d.RentPeriod = nil, nil
+			if amount, period, ok := parseRentDetails(*r.RentDetails); ok {
+				d.RentAmount, d.RentPeriod = &amount, &period
+			}
+		}
+		return
+	}
+
+	amount := *d.RentAmount
+	if r.RentAmount != nil {
+		amount = *r.RentAmount
+	}
+	// The period defaults to the one already set, then to the one rent_details states, then to monthly.
+	period := PriceMonthly
+	if r.RentPeriod != nil {
+		period = *r.RentPeriod
+	} else if d.RentPeriod != nil {
+		period = *d.RentPeriod
+	} else if d.RentDetails != nil {
+		if _, stated, ok := parseRentDetails(*d.RentDetails); ok {
+			period = stated
+		}
+	}
+	d.RentAmount, d.RentPeriod = &amount, &period
+	if !statesRent(d.RentDetails, amount, period) {
+		details := formatRent(amount, period)
+		d.RentDetails = &details
+	}
+}
+
+// BackfillRentAmounts fills rent_amount and rent_period of housing listings written before they existed, from their
+// free-text rent_details. Texts that cannot be read are logged and reported so that an admin can fix them by hand;
+// they are checked again on every run. Unless dryRun is set, the parsed amounts are stored.
+func (s *ServiceImplementation) BackfillRentAmounts(ctx context.Context, dryRun bool) (*RentBackfillReport, error) {
+	report := &RentBackfillReport{DryRun: dryRun, NeedsReview: []UnparseableRent{}}
+	var afterID uuid.UUID
+	for {
+		rows, err := s.repo.FindHousingWithoutRentAmountAfter(ctx, afterID, rentBackfillBatchSize)
+		if err != nil {
+			s.logger.Error("Failed to scan housing rents", zap.Error(err))
+			return nil, common.ErrInternalServer.WithDetails("Could not scan housing rents.")
+		}
+		if len(rows) == 0 {
+			break
+		}
+		afterID = rows[len(rows)-1].ListingID
+
+		for _, row := range rows {
+			report.Checked++
+			amount, period, ok := parseRentDetails(*row.RentDetails)
+			if !ok {
+				report.Unparseable++
+				if len(report.NeedsReview) < maxReportedInconsistencies {
+					report.NeedsReview = append(report.NeedsReview, UnparseableRent{ListingID: row.ListingID, RentDetails: *row.RentDetails})
+				}
+				if !dryRun {
+					s.logger.Warn("Rent needs review: rent_details could not be parsed",
+						zap.String("listingID", row.ListingID.String()), zap.String("rentDetails", *row.RentDetails))
+				}
+				continue
+			}
+			report.Parsed++
+			if dryRun {
+				continue
+			}
+			if err := s.repo.SetRentAmount(ctx, row.ListingID, amount, period); err != nil {
+				s.logger.Error("Failed to store parsed rent", zap.String("listingID", row.ListingID.String()), zap.Error(err))
+				continue
+			}
+			report.Updated++
+		}
+	}
+
+	s.logger.Info("Rent backfill completed",
+		zap.Bool("dryRun", dryRun),
+		zap.Int("checked", report.Checked),
+		zap.Int("parsed", report.Parsed),
+		zap.Int("updated", report.Updated),
+		zap.Int("unparseable", report.Unparseable))
+	return report, nil
+}
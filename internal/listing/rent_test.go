@@ -0,0 +1,64 @@
+package listing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRentDetails(t *testing.T) {
+	for text, want := range map[string]struct {
+		amount float64
+		period PricePeriod
+	}{
+		"$1,500/month":           {1500, PriceMonthly},
+		"1850":                   {1850, PriceMonthly},
+		"$450 per week":          {450, PriceWeekly},
+		"2.1k pcm":               {2100, PriceMonthly},
+		"2br/1ba $1,800 / mo":    {1800, PriceMonthly},
+		"$95 a night":            {95, PriceDaily},
+		"$24,000 per year, util": {24000, PriceYearly},
+		"$1,234.50/month":        {1234.5, PriceMonthly},
+	} {
+		amount, period, ok := parseRentDetails(text)
+		require.True(t, ok, text)
+		assert.Equal(t, want.amount, amount, text)
+		assert.Equal(t, want.period, period, text)
+	}
+
+	for _, text := range []string{"Contact for price", "$1500 - $1700", "1500 to 1700 monthly", "2 bed 1 bath 1500", ""} {
+		_, _, ok := parseRentDetails(text)
+		assert.False(t, ok, text)
+	}
+}
+
+func TestFormatRent(t *testing.T) {
+	assert.Equal(t, "$1,500/month", formatRent(1500, PriceMonthly))
+	assert.Equal(t, "$950/week", formatRent(950, PriceWeekly))
+	assert.Equal(t, "$1,234,567.05/year", formatRent(1234567.05, PriceYearly))
+}
+
+func TestApplyRentTo(t *testing.T) {
+	text := "$1,500/month"
+	d := &ListingDetailsHousing{}
+	(&CreateListingHousingDetailsRequest{RentDetails: &text}).applyRentTo(d)
+	require.NotNil(t, d.RentAmount, "rent_details alone is parsed")
+	assert.Equal(t, 1500.0, *d.RentAmount)
+	assert.Equal(t, PriceMonthly, *d.RentPeriod)
+	assert.Nil(t, d.housingRequest().RentAmount, "a rent that rent_details states is left out of the document")
+
+	amount := 1650.0
+	(&CreateListingHousingDetailsRequest{RentAmount: &amount}).applyRentTo(d)
+	assert.Equal(t, 1650.0, *d.RentAmount)
+	assert.Equal(t, "$1,650/month", *d.RentDetails, "rent_details is rewritten for older clients")
+
+	weekly := PriceWeekly
+	(&CreateListingHousingDetailsRequest{RentPeriod: &weekly}).applyRentTo(d)
+	assert.Equal(t, 1650.0, *d.RentAmount, "a new period keeps the amount")
+	assert.Equal(t, "$1,650/week", *d.RentDetails)
+
+	custom := "Call us"
+	(&CreateListingHousingDetailsRequest{RentDetails: &custom}).applyRentTo(d)
+	assert.Nil(t, d.RentAmount, "unreadable rent_details clears the numeric rent")
+}
@@ -5,11 +5,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"seattle_info_backend/internal/audit"
+	"seattle_info_backend/internal/category"
 	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/platform/database"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -20,16 +24,79 @@ import (
 type Repository interface {
 	Create(ctx context.Context, listing *Listing) error
 	FindByID(ctx context.Context, id uuid.UUID, preloadAssociations bool) (*Listing, error)
+	FindBySlug(ctx context.Context, slug string) (*Listing, error)
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]Listing, error)
+	FindActiveByUserID(ctx context.Context, userID uuid.UUID, ids []uuid.UUID, limit int) ([]Listing, error)
 	Update(ctx context.Context, listing *Listing) error
+	UpdateFields(ctx context.Context, listings []*Listing, columns []string) error
 	Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error // UserID for ownership check
 	Search(ctx context.Context, query ListingSearchQuery) ([]Listing, *common.Pagination, error)
-	UpdateStatus(ctx context.Context, id uuid.UUID, status ListingStatus, adminNotes *string) error
-	FindExpiredListings(ctx context.Context, now time.Time) ([]Listing, error)
+	MapClusters(ctx context.Context, query ListingSearchQuery, gridSize float64, limit int) ([]MapCluster, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status ListingStatus, adminNotes *string, rejectionReason *RejectionReason) error
+	Publish(ctx context.Context, listing *Listing) error
+	// ExpireBatch expires up to limit listings whose expires_at is not after now and marks their expiry event
+	// pending. It returns the IDs of the listings it expired.
+	ExpireBatch(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error)
+	FindPendingExpiryEvents(ctx context.Context, limit int) ([]Listing, error)
+	ClearPendingExpiryEvents(ctx context.Context, ids []uuid.UUID) error
+	FindDueScheduledListings(ctx context.Context, now time.Time) ([]Listing, error)
+	ActivateScheduled(ctx context.Context, id uuid.UUID, expiresAt time.Time) error
+	SetFeaturedUntil(ctx context.Context, id uuid.UUID, featuredUntil *time.Time) error
+	ClearExpiredFeatured(ctx context.Context, now time.Time) (int64, error)
+	ApprovalBacklog(ctx context.Context) (*ApprovalBacklog, error)
 	CountListingsByUserIDAndStatus(ctx context.Context, userID uuid.UUID, status ListingStatus) (int64, error)
 	CountListingsByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
-	GetRecentListings(ctx context.Context, page, pageSize int, currentUserID *uuid.UUID) ([]Listing, *common.Pagination, error)
-	GetUpcomingEvents(ctx context.Context, page, pageSize int) ([]Listing, *common.Pagination, error)
+	GetRecentListings(ctx context.Context, page, pageSize int, categorySlug string, currentUserID *uuid.UUID, includes Includes) ([]Listing, *common.Pagination, error)
+	GetUpcomingEvents(ctx context.Context, page, pageSize int, includes Includes) ([]Listing, *common.Pagination, error)
 	FindByUserID(ctx context.Context, userID uuid.UUID, query UserListingsQuery) ([]Listing, *common.Pagination, error)
+	FindRelated(ctx context.Context, source *Listing, limit int) ([]Listing, error)
+	IncrementDailyViews(ctx context.Context, listingID uuid.UUID, day time.Time) error
+	FindActiveDailyViewsSince(ctx context.Context, since time.Time) ([]DailyViews, error)
+	RecordRecentView(ctx context.Context, view RecentView, keep int) error
+	FindRecentViews(ctx context.Context, userID uuid.UUID, limit int) ([]RecentView, error)
+	DeleteRecentViews(ctx context.Context, userID uuid.UUID) error
+	RecordAnonymousRecentView(ctx context.Context, view AnonymousRecentView, keep int) error
+	FindAnonymousRecentViews(ctx context.Context, sessionID uuid.UUID, limit int) ([]AnonymousRecentView, error)
+	DeleteAnonymousRecentViews(ctx context.Context, sessionID uuid.UUID) error
+	MergeAnonymousRecentViews(ctx context.Context, sessionID, userID uuid.UUID, keep int) (int64, error)
+	// CreateTakedown removes the takedown's listing, records the takedown and writes entry, in one transaction.
+	CreateTakedown(ctx context.Context, takedown *Takedown, entry *audit.Entry) error
+	// UpdateTakedown saves a takedown still in fromStatus, sets its listing to listingStatus when not nil and
+	// writes entry, in one transaction. It returns ErrConflict when the takedown has moved on meanwhile.
+	UpdateTakedown(ctx context.Context, takedown *Takedown, fromStatus TakedownStatus, listingStatus *ListingStatus, entry *audit.Entry) error
+	FindLatestTakedown(ctx context.Context, listingID uuid.UUID) (*Takedown, error)
+	FindTakedownByID(ctx context.Context, id uuid.UUID) (*Takedown, error)
+	FindTakedownsByStatus(ctx context.Context, status TakedownStatus, page, pageSize int) ([]Takedown, *common.Pagination, error)
+	CreateBackgroundCheck(ctx context.Context, check *BackgroundCheck) error
+	// UpdateBackgroundCheck saves a check still in fromStatus, sets the verified badge of its listing when badge
+	// is not nil and writes entry, in one transaction. It returns ErrConflict when the check has moved on meanwhile.
+	UpdateBackgroundCheck(ctx context.Context, check *BackgroundCheck, fromStatus BackgroundCheckStatus, badge *bool, entry *audit.Entry) error
+	FindLatestBackgroundCheck(ctx context.Context, listingID uuid.UUID) (*BackgroundCheck, error)
+	FindBackgroundCheckByID(ctx context.Context, id uuid.UUID) (*BackgroundCheck, error)
+	FindBackgroundChecksByStatus(ctx context.Context, status BackgroundCheckStatus, page, pageSize int) ([]BackgroundCheck, *common.Pagination, error)
+	// CreateQuestion and UpdateQuestionAnswer also bump the listing's updated_at, as its questions are part of it.
+	CreateQuestion(ctx context.Context, question *Question) error
+	UpdateQuestionAnswer(ctx context.Context, question *Question) error
+	FindQuestionByID(ctx context.Context, id uuid.UUID) (*Question, error)
+	FindQuestions(ctx context.Context, filter QuestionFilter, page, pageSize int) ([]Question, *common.Pagination, error)
+	CreateContactReveal(ctx context.Context, reveal *ContactReveal) error
+	HasContactReveal(ctx context.Context, listingID, userID uuid.UUID) (bool, error)
+	CountContactRevealsSince(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error)
+	FindCheckinCode(ctx context.Context, listingID uuid.UUID) (*CheckinCode, error)
+	SaveCheckinCode(ctx context.Context, code *CheckinCode) error
+	CreateCheckin(ctx context.Context, checkin *Checkin) (bool, error)
+	CountCheckins(ctx context.Context, listingID uuid.UUID, occurrenceDate time.Time) (int64, error)
+	IncrementDailyImpressions(ctx context.Context, listingIDs []uuid.UUID, day time.Time) error
+	RollupDailyStats(ctx context.Context, day time.Time) (int64, error)
+	FindDailyStats(ctx context.Context, listingID uuid.UUID, from, to time.Time) ([]DailyStats, error)
+	FindNeighborhoods(ctx context.Context) ([]Neighborhood, error)
+	FindImagesAfter(ctx context.Context, afterID uuid.UUID, limit int) ([]ListingImage, error)
+	FindHousingWithoutRentAmountAfter(ctx context.Context, afterListingID uuid.UUID, limit int) ([]ListingDetailsHousing, error)
+	SetRentAmount(ctx context.Context, listingID uuid.UUID, amount float64, period PricePeriod) error
+	DeleteImages(ctx context.Context, ids []uuid.UUID) error
+	FindImageByID(ctx context.Context, id uuid.UUID) (*ListingImage, error)
+	UpdateImageScan(ctx context.Context, id uuid.UUID, status ImageScanStatus, result *string, scannedAt time.Time) error
+	FindForExport(ctx context.Context, filter ExportFilter, after *Listing, limit int) ([]Listing, error)
 }
 
 // GORMRepository implements the listing Repository interface using GORM.
@@ -50,14 +117,23 @@ func (r *GORMRepository) preloader(query *gorm.DB) *gorm.DB {
 		Preload("BabysittingDetails").
 		Preload("HousingDetails").
 		Preload("EventDetails").
+		Preload("JobDetails").
 		Preload("Images", func(db *gorm.DB) *gorm.DB { // Preload images and order them
-			return db.Order("listing_images.sort_order ASC")
-		})
+			return db.Where("listing_images.scan_status <> ?", ScanInfected).Order("listing_images.sort_order ASC")
+		}).
+		Preload("Neighborhood")
 }
 
 // Create inserts a new listing and its details into the database within a transaction.
 func (r *GORMRepository) Create(ctx context.Context, listing *Listing) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The slug is built from the ID, so the ID is chosen here rather than by the database.
+		if listing.ID == uuid.Nil {
+			listing.ID = uuid.New()
+		}
+		if err := assignSlug(tx, listing); err != nil {
+			return err
+		}
 		// Create the main listing record
 		if err := tx.Create(listing).Error; err != nil {
 			if errors.Is(err, gorm.ErrDuplicatedKey) || strings.Contains(err.Error(), "unique constraint") {
@@ -88,6 +164,12 @@ func (r *GORMRepository) Create(ctx context.Context, listing *Listing) error {
 				return fmt.Errorf("failed to create event details: %w", err)
 			}
 		}
+		if listing.JobDetails != nil {
+			listing.JobDetails.ListingID = listing.ID
+			if err := tx.Create(listing.JobDetails).Error; err != nil {
+				return fmt.Errorf("failed to create job details: %w", err)
+			}
+		}
 		return nil
 	})
 }
@@ -109,6 +191,90 @@ func (r *GORMRepository) FindByID(ctx context.Context, id uuid.UUID, preloadAsso
 	return &listing, nil
 }
 
+// FindBySlug retrieves a listing, with associations preloaded, by its slug.
+func (r *GORMRepository) FindBySlug(ctx context.Context, slug string) (*Listing, error) {
+	var listing Listing
+	err := r.preloader(r.db.WithContext(ctx)).First(&listing, "listings.slug = ?", slug).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Listing not found.")
+		}
+		return nil, fmt.Errorf("failed to find listing by slug: %w", err)
+	}
+	return &listing, nil
+}
+
+// assignSlug gives the listing the first free slug for its title and ID. A slug that still fits the
+// title is kept, so links to the listing only change when its title does.
+func assignSlug(tx *gorm.DB, listing *Listing) error {
+	candidates := slugCandidates(listing.Title, listing.ID)
+	for _, candidate := range candidates {
+		if candidate == listing.Slug {
+			return nil
+		}
+	}
+	for _, candidate := range candidates {
+		var taken int64
+		if err := tx.Model(&Listing{}).Where("slug = ? AND id <> ?", candidate, listing.ID).Count(&taken).Error; err != nil {
+			return fmt.Errorf("failed to check listing slug: %w", err)
+		}
+		if taken == 0 {
+			listing.Slug = candidate
+			return nil
+		}
+	}
+	return common.ErrConflict.WithDetails("Could not find a free slug for the listing.")
+}
+
+// inVisibleCategory leaves out listings whose category or sub-category an admin has hidden.
+// It applies to browsing; a hidden category's listings can still be opened directly.
+func inVisibleCategory(query *gorm.DB) *gorm.DB {
+	return query.
+		Where("listings.category_id IN (SELECT id FROM categories WHERE is_active)").
+		Where("(listings.sub_category_id IS NULL OR listings.sub_category_id IN (SELECT id FROM sub_categories WHERE is_active))")
+}
+
+// FindByIDs retrieves the listings with the given IDs, with associations preloaded.
+// IDs that do not exist are skipped; the result is in no particular order.
+func (r *GORMRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]Listing, error) {
+	var listings []Listing
+	if len(ids) == 0 {
+		return listings, nil
+	}
+	if err := r.preloader(r.db.WithContext(ctx)).Where("listings.id IN ?", ids).Find(&listings).Error; err != nil {
+		return nil, fmt.Errorf("failed to find listings by IDs: %w", err)
+	}
+	return listings, nil
+}
+
+// FindActiveByUserID retrieves up to limit of a user's active listings with their associations, newest first.
+// When ids is not empty only those listings are considered.
+func (r *GORMRepository) FindActiveByUserID(ctx context.Context, userID uuid.UUID, ids []uuid.UUID, limit int) ([]Listing, error) {
+	var listings []Listing
+	query := r.preloader(r.db.WithContext(ctx)).
+		Where("listings.user_id = ? AND listings.status = ?", userID, StatusActive)
+	if len(ids) > 0 {
+		query = query.Where("listings.id IN ?", ids)
+	}
+	if err := query.Order("listings.created_at DESC").Limit(limit).Find(&listings).Error; err != nil {
+		return nil, fmt.Errorf("failed to find active listings for user %s: %w", userID, err)
+	}
+	return listings, nil
+}
+
+// UpdateFields writes the given columns of every listing in one transaction; if any write fails, none is kept.
+// Associations are not saved.
+func (r *GORMRepository) UpdateFields(ctx context.Context, listings []*Listing, columns []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, listing := range listings {
+			if err := tx.Model(listing).Select(columns).Updates(listing).Error; err != nil {
+				return fmt.Errorf("failed to update listing %s: %w", listing.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
 // Update modifies an existing listing and its details in the database within a transaction.
 func (r *GORMRepository) Update(ctx context.Context, listing *Listing) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
@@ -117,7 +283,11 @@ func (r *GORMRepository) Update(ctx context.Context, listing *Listing) error {
 		// For full association handling (create, update, delete based on the state of listing.Images),
 		// we use Save with FullSaveAssociations.
 		// The service layer is responsible for preparing the listing.Images slice with the final desired state.
-		if err := tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(listing).Error; err != nil {
+		if err := assignSlug(tx, listing); err != nil {
+			return err
+		}
+		// Neighborhoods are reference data assigned by the database, never saved through a listing.
+		if err := tx.Session(&gorm.Session{FullSaveAssociations: true}).Omit("Neighborhood").Save(listing).Error; err != nil {
 			return fmt.Errorf("failed to update listing and its associations: %w", err)
 		}
 
@@ -175,6 +345,18 @@ func (r *GORMRepository) Update(ctx context.Context, listing *Listing) error {
 			tx.Where("listing_id = ?", listing.ID).Delete(&ListingDetailsEvents{})
 		}
 
+		if listing.JobDetails != nil {
+			listing.JobDetails.ListingID = listing.ID
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "listing_id"}},
+				DoUpdates: clause.AssignmentColumns(getUpdatableColumns(ListingDetailsJobs{})),
+			}).Create(listing.JobDetails).Error; err != nil {
+				return fmt.Errorf("failed to upsert job details: %w", err)
+			}
+		} else {
+			tx.Where("listing_id = ?", listing.ID).Delete(&ListingDetailsJobs{})
+		}
+
 		return nil
 	})
 }
@@ -188,11 +370,13 @@ func getUpdatableColumns(model interface{}) []string {
 	// For now, list them manually based on your models.
 	switch model.(type) {
 	case ListingDetailsBabysitting:
-		fieldNames = []string{"languages_spoken"}
+		fieldNames = []string{"languages_spoken", "availability", "hourly_rate", "years_experience", "certifications"}
 	case ListingDetailsHousing:
-		fieldNames = []string{"property_type", "rent_details", "sale_price"}
+		fieldNames = []string{"property_type", "rent_details", "sale_price", "rent_amount", "rent_period", "bedrooms", "bathrooms", "square_feet", "pet_policy", "parking", "available_from"}
 	case ListingDetailsEvents:
-		fieldNames = []string{"event_date", "event_time", "organizer_name", "venue_name"}
+		fieldNames = []string{"event_date", "event_time", "timezone", "starts_at", "organizer_name", "venue_name", "recurrence_frequency", "recurrence_interval", "recurrence_until"}
+	case ListingDetailsJobs:
+		fieldNames = []string{"employment_type", "workplace_type", "salary_min", "salary_max", "salary_currency", "salary_period", "application_url"}
 	}
 	return fieldNames
 }
@@ -220,18 +404,23 @@ func (r *GORMRepository) Delete(ctx context.Context, id uuid.UUID, userID uuid.U
 	return nil
 }
 
-// Search retrieves listings based on query parameters, including location-based search.
-func (r *GORMRepository) Search(ctx context.Context, queryParams ListingSearchQuery) ([]Listing, *common.Pagination, error) {
-	var listings []Listing
-	var totalItems int64
-
-	dbQuery := r.db.WithContext(ctx).Model(&Listing{})
-	dbQuery = r.preloader(dbQuery) // Apply preloads
-
-	// --- Apply Filters ---
+// applySearchFilters restricts dbQuery to the listings matching queryParams. It is shared by Search
+// and MapClusters so the map shows the same listings as the result list.
+func applySearchFilters(dbQuery *gorm.DB, queryParams ListingSearchQuery) *gorm.DB {
 	if queryParams.SearchTerm != "" {
-		searchTerm := "%" + strings.ToLower(queryParams.SearchTerm) + "%"
-		dbQuery = dbQuery.Where("LOWER(listings.title) LIKE ? OR LOWER(listings.description) LIKE ?", searchTerm, searchTerm)
+		variants := queryParams.SearchVariants
+		if len(variants) == 0 {
+			variants = []string{strings.ToLower(queryParams.SearchTerm)}
+		}
+		// Any spelling may match, e.g. "cap hill" also finds listings that say "Capitol Hill".
+		conditions := make([]string, len(variants))
+		args := make([]interface{}, 0, 2*len(variants))
+		for i, variant := range variants {
+			searchTerm := "%" + variant + "%"
+			conditions[i] = "LOWER(listings.title) LIKE ? OR LOWER(listings.description) LIKE ?"
+			args = append(args, searchTerm, searchTerm)
+		}
+		dbQuery = dbQuery.Where("("+strings.Join(conditions, " OR ")+")", args...)
 	}
 	if queryParams.CategoryID != nil && *queryParams.CategoryID != "" {
 		dbQuery = dbQuery.Where("listings.category_id = ?", *queryParams.CategoryID)
@@ -242,6 +431,37 @@ func (r *GORMRepository) Search(ctx context.Context, queryParams ListingSearchQu
 	if queryParams.UserID != nil && *queryParams.UserID != "" {
 		dbQuery = dbQuery.Where("listings.user_id = ?", *queryParams.UserID)
 	}
+	if queryParams.Neighborhood != "" {
+		dbQuery = dbQuery.Where("listings.neighborhood_id = (SELECT id FROM neighborhoods WHERE slug = ?)", strings.ToLower(queryParams.Neighborhood))
+	}
+	if queryParams.CreatedAfter != nil {
+		// A scheduled listing is new from the time it went live.
+		dbQuery = dbQuery.Where("COALESCE(listings.publish_at, listings.created_at) > ?", *queryParams.CreatedAfter)
+	}
+	if queryParams.CreatedBefore != nil {
+		dbQuery = dbQuery.Where("listings.created_at < ?", *queryParams.CreatedBefore)
+	}
+	// Price filters only match listings that have a price; unpriced listings are excluded.
+	if queryParams.MinPrice != nil {
+		dbQuery = dbQuery.Where("listings.price_amount >= ?", *queryParams.MinPrice)
+	}
+	if queryParams.MaxPrice != nil {
+		dbQuery = dbQuery.Where("listings.price_amount <= ?", *queryParams.MaxPrice)
+	}
+	if queryParams.Currency != "" {
+		dbQuery = dbQuery.Where("listings.price_currency = ?", strings.ToUpper(queryParams.Currency))
+	}
+	dbQuery = applyHousingFilters(dbQuery, queryParams)
+	dbQuery = applyBabysittingFilters(dbQuery, queryParams)
+	for _, f := range queryParams.AttributeFilters {
+		dbQuery = applyAttributeFilter(dbQuery, f)
+	}
+	if queryParams.OpenNow {
+		dbQuery = dbQuery.Where(openNowCondition, map[string]interface{}{"now": time.Now().UTC()})
+	}
+	// Drafts and scheduled listings are private to their owner and never appear in search results.
+	dbQuery = dbQuery.Where("listings.status NOT IN (?)", []ListingStatus{StatusDraft, StatusScheduled})
+	dbQuery = inVisibleCategory(dbQuery)
 	if queryParams.Status != "" {
 		dbQuery = dbQuery.Where("listings.status = ?", queryParams.Status)
 	} else if !queryParams.IncludeExpired {
@@ -250,27 +470,48 @@ func (r *GORMRepository) Search(ctx context.Context, queryParams ListingSearchQu
 		dbQuery = dbQuery.Where("listings.expires_at > ?", time.Now())
 	}
 
-	// Location-based filtering and sorting
-	// Using ST_DWithin for distance filtering and ST_Distance for sorting by distance.
-	// These require PostGIS functions.
+	// Location filters use public_location, the location as fuzzed for the listing's location_privacy, so that
+	// narrowing a search cannot pin down a listing more precisely than its page shows.
+	// ST_DWithin checks if geometries are within a certain distance (in meters for geography).
+	if queryParams.Latitude != nil && queryParams.Longitude != nil && queryParams.MaxDistanceKM != nil && *queryParams.MaxDistanceKM > 0 {
+		userLocation := fmt.Sprintf("SRID=4326;POINT(%f %f)", *queryParams.Longitude, *queryParams.Latitude)
+		dbQuery = dbQuery.Where("ST_DWithin(listings.public_location, ST_GeographyFromText(?), ?)", userLocation, *queryParams.MaxDistanceKM*1000)
+	}
+	// Viewport filtering for map clients. public_location is a geography column, so cast to geometry for ST_Within.
+	if queryParams.BoundingBox != nil {
+		bbox := queryParams.BoundingBox
+		dbQuery = dbQuery.Where("ST_Within(listings.public_location::geometry, ST_MakeEnvelope(?, ?, ?, ?, 4326))",
+			bbox.MinLon, bbox.MinLat, bbox.MaxLon, bbox.MaxLat)
+	}
+	if queryParams.Polygon != "" {
+		dbQuery = dbQuery.Where("ST_Within(listings.public_location::geometry, ST_SetSRID(ST_GeomFromGeoJSON(?), 4326))", queryParams.Polygon)
+	}
+	return dbQuery
+}
+
+// Search retrieves listings based on query parameters, including location-based search.
+func (r *GORMRepository) Search(ctx context.Context, queryParams ListingSearchQuery) ([]Listing, *common.Pagination, error) {
+	var listings []Listing
+	var totalItems int64
+
+	dbQuery := r.db.WithContext(database.ReadFromReplica(ctx)).Model(&Listing{})
+	dbQuery = r.preloadIncludes(dbQuery, queryParams.Includes) // Apply the requested preloads
+
+	// --- Apply Filters ---
+	dbQuery = applySearchFilters(dbQuery, queryParams)
+
+	// Location-based sorting. ST_Distance requires PostGIS.
+	selectClause := "listings.*, ST_AsText(location) AS location_wkt"
+	var selectArgs []interface{}
 	if queryParams.Latitude != nil && queryParams.Longitude != nil {
 		userLocation := fmt.Sprintf("SRID=4326;POINT(%f %f)", *queryParams.Longitude, *queryParams.Latitude)
 
-		if queryParams.MaxDistanceKM != nil && *queryParams.MaxDistanceKM > 0 {
-			maxDistanceMeters := *queryParams.MaxDistanceKM * 1000
-			// ST_DWithin checks if geometries are within a certain distance (in meters for geography).
-			dbQuery = dbQuery.Where("ST_DWithin(listings.location, ST_GeographyFromText(?), ?)", userLocation, maxDistanceMeters)
-		}
+		// ST_Distance returns meters for geography; expose it as distance_km so it is scanned into Listing.DistanceKM.
+		selectClause += ", ST_Distance(listings.public_location, ST_GeographyFromText(?)) / 1000.0 AS distance_km"
+		selectArgs = append(selectArgs, userLocation)
 
-		// Add distance calculation to the select clause if sorting by distance or for display
-		// The alias 'distance_km' can be used in sorting and will be scanned into the ListingResponse.
-		// Note: GORM might not directly scan into a non-model field. This might require a custom struct for results or careful handling.
-		// For simplicity, we might just sort and rely on frontend to know the user's location if distance display is needed.
-		// Or, we can add a 'Distance' field to Listing model with `gorm:"-"` (not a DB column) and populate it.
-		// Let's assume for now we just sort by it. For displaying, it would need a Scan.
 		if queryParams.SortBy == "distance" {
-			// ST_Distance returns distance in meters for geography type.
-			dbQuery = dbQuery.Order(gorm.Expr("ST_Distance(listings.location, ST_GeographyFromText(?))", userLocation))
+			dbQuery = dbQuery.Order(gorm.Expr("ST_Distance(listings.public_location, ST_GeographyFromText(?))", userLocation))
 		}
 	}
 
@@ -292,21 +533,28 @@ func (r *GORMRepository) Search(ctx context.Context, queryParams ListingSearchQu
 			"created_at": "listings.created_at",
 			"expires_at": "listings.expires_at",
 			"title":      "listings.title",
+			"price":      "listings.price_amount",
 			// Add more as needed
 		}
 		if dbSortField, ok := validSortableFields[queryParams.SortBy]; ok {
-			dbQuery = dbQuery.Order(fmt.Sprintf("%s %s", dbSortField, sortOrder))
+			// Unpriced listings sort after priced ones in either direction.
+			dbQuery = dbQuery.Order(fmt.Sprintf("%s %s NULLS LAST", dbSortField, sortOrder)).Order(qualityOrder)
 		} else {
 			// Default sort if SortBy is invalid or not "distance"
 			dbQuery = dbQuery.Order("listings.created_at DESC")
 		}
 	} else if queryParams.SortBy != "distance" { // Default sort if no sort_by is specified
+		dbQuery = dbQuery.Order(featuredFirstOrder).Order(verifiedFirstOrder)
+		if queryParams.SearchTerm != "" {
+			// Every match of a search term is equally relevant, so the more complete listings come first.
+			dbQuery = dbQuery.Order(qualityOrder)
+		}
 		dbQuery = dbQuery.Order("listings.created_at DESC")
 	}
 	// Secondary sort for proximity (BR2.1: if distance is primary, recency is secondary)
 	// If sorting by distance, we can add a secondary sort by created_at DESC.
 	if queryParams.SortBy == "distance" {
-		dbQuery = dbQuery.Order("listings.created_at DESC") // This adds to existing order by distance
+		dbQuery = dbQuery.Order(qualityOrder).Order("listings.created_at DESC") // This adds to existing order by distance
 	}
 
 	// --- Apply Pagination ---
@@ -314,8 +562,8 @@ func (r *GORMRepository) Search(ctx context.Context, queryParams ListingSearchQu
 	dbQuery = dbQuery.Offset((pagination.CurrentPage - 1) * pagination.PageSize).Limit(pagination.PageSize) // Correct offset calculation
 
 	dbQuery = dbQuery.
-		Omit("location").                                         // ① drop geometry
-		Select("listings.*, ST_AsText(location) AS location_wkt") // ② add WKT
+		Omit("location").                   // ① drop geometry
+		Select(selectClause, selectArgs...) // ② add WKT (and distance_km when searching by location)
 
 	// Find needs to be called before iterating and parsing WKT
 	if err := dbQuery.Find(&listings).Error; err != nil {
@@ -337,11 +585,108 @@ func (r *GORMRepository) Search(ctx context.Context, queryParams ListingSearchQu
 	return listings, pagination, nil
 }
 
+// MapClusters groups the listings matching queryParams into cells of gridSize degrees with ST_SnapToGrid and
+// returns each cell's listing count and centroid, largest clusters first. Listings without a location are skipped.
+func (r *GORMRepository) MapClusters(ctx context.Context, queryParams ListingSearchQuery, gridSize float64, limit int) ([]MapCluster, error) {
+	var clusters []MapCluster
+	dbQuery := r.db.WithContext(database.ReadFromReplica(ctx)).Model(&Listing{})
+	dbQuery = applySearchFilters(dbQuery, queryParams).Where("listings.public_location IS NOT NULL")
+
+	// Clustered from public_location, so a cluster of one listing does not reveal a location its page hides.
+	err := dbQuery.
+		Select("COUNT(*) AS count, " +
+			"ST_Y(ST_Centroid(ST_Collect(listings.public_location::geometry))) AS latitude, " +
+			"ST_X(ST_Centroid(ST_Collect(listings.public_location::geometry))) AS longitude").
+		Group(fmt.Sprintf("ST_SnapToGrid(listings.public_location::geometry, %g)", gridSize)).
+		Order("count DESC").
+		Limit(limit).
+		Scan(&clusters).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to cluster listings: %w", err)
+	}
+	return clusters, nil
+}
+
+// Weights of the related listings score. Text similarity dominates; a shared subcategory and nearby location break ties.
+const (
+	relatedSubCategoryBoost = 0.25
+	relatedProximityWeight  = 0.25
+	relatedDistanceScaleKM  = 10.0 // proximity halves at this distance
+)
+
+// FindRelated retrieves up to limit active listings in the source's category, most similar first.
+// Similarity is the full-text rank of each listing against any of the words in the source's title and description,
+// plus a boost for the same subcategory and for proximity when the source has a location.
+func (r *GORMRepository) FindRelated(ctx context.Context, source *Listing, limit int) ([]Listing, error) {
+	var listings []Listing
+
+	// plainto_tsquery ANDs the words together; rewrite it as an OR query so partial overlap still ranks.
+	scoreSQL := `ts_rank(to_tsvector('english', listings.title || ' ' || listings.description),
+		replace(plainto_tsquery('english', ?)::text, '&', '|')::tsquery, 32)`
+	scoreArgs := []interface{}{relatedQueryText(source)}
+	if source.SubCategoryID != nil {
+		scoreSQL += " + CASE WHEN listings.sub_category_id = ? THEN ? ELSE 0 END"
+		scoreArgs = append(scoreArgs, *source.SubCategoryID, relatedSubCategoryBoost)
+	}
+	selectClause := "listings.*, ST_AsText(listings.location) AS location_wkt"
+	var selectArgs []interface{}
+	if source.Latitude != nil && source.Longitude != nil {
+		// Distances are between public locations on both ends, so they reveal neither listing's exact location.
+		sourcePoint := "(SELECT source.public_location FROM listings source WHERE source.id = ?)"
+		scoreSQL += " + COALESCE(? / (1 + ST_Distance(listings.public_location, " + sourcePoint + ") / 1000.0 / ?), 0)"
+		scoreArgs = append(scoreArgs, relatedProximityWeight, source.ID, relatedDistanceScaleKM)
+		selectClause += ", ST_Distance(listings.public_location, " + sourcePoint + ") / 1000.0 AS distance_km"
+		selectArgs = append(selectArgs, source.ID)
+	}
+
+	err := inVisibleCategory(r.preloader(r.db.WithContext(ctx).Model(&Listing{}))).
+		Where("listings.category_id = ? AND listings.id <> ?", source.CategoryID, source.ID).
+		Where("listings.status = ? AND listings.expires_at > ?", StatusActive, time.Now()).
+		Order(gorm.Expr("("+scoreSQL+") DESC", scoreArgs...)).
+		Order("listings.created_at DESC").
+		Limit(limit).
+		Omit("location").
+		Select(selectClause, selectArgs...).
+		Find(&listings).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find related listings: %w", err)
+	}
+
+	for i := range listings {
+		if listings[i].LocationWKT != "" {
+			point, err := parseWKT(listings[i].LocationWKT)
+			if err != nil {
+				continue
+			}
+			listings[i].Location = point
+		}
+	}
+	return listings, nil
+}
+
+// maxRelatedQueryLength bounds the text the related listings query is built from.
+const maxRelatedQueryLength = 500
+
+// relatedQueryText is the text whose words related listings are ranked against: the title, then the start of the description.
+func relatedQueryText(l *Listing) string {
+	text := l.Title + " " + l.Description
+	if len(text) > maxRelatedQueryLength {
+		text = text[:maxRelatedQueryLength]
+		if i := strings.LastIndexByte(text, ' '); i > 0 {
+			text = text[:i]
+		}
+	}
+	return text
+}
+
 // UpdateStatus updates the status of a listing (typically by an admin).
-func (r *GORMRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status ListingStatus, adminNotes *string) error {
-	updates := map[string]interface{}{"status": status}
-	// TODO: If adminNotes is a field on Listing model, add it to updates:
-	// if adminNotes != nil { updates["admin_notes"] = *adminNotes }
+// adminNotes and rejectionReason replace the stored ones; nil clears them.
+func (r *GORMRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status ListingStatus, adminNotes *string, rejectionReason *RejectionReason) error {
+	updates := map[string]interface{}{
+		"status":           status,
+		"admin_notes":      adminNotes,
+		"rejection_reason": rejectionReason,
+	}
 
 	result := r.db.WithContext(ctx).Model(&Listing{}).Where("id = ?", id).Updates(updates)
 	if result.Error != nil {
@@ -353,6 +698,26 @@ func (r *GORMRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status
 	return nil
 }
 
+// Publish moves a draft listing to its published or scheduled status and starts its lifespan.
+func (r *GORMRepository) Publish(ctx context.Context, listing *Listing) error {
+	updates := map[string]interface{}{
+		"status":            listing.Status,
+		"publish_at":        listing.PublishAt,
+		"is_admin_approved": listing.IsAdminApproved,
+		"expires_at":        listing.ExpiresAt,
+		"moderation_flags":  listing.ModerationFlags,
+		"spam_score":        listing.SpamScore,
+	}
+	result := r.db.WithContext(ctx).Model(&Listing{}).Where("id = ? AND status = ?", listing.ID, StatusDraft).Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to publish listing: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrConflict.WithDetails("Listing is no longer a draft.")
+	}
+	return nil
+}
+
 func parseWKT(wkt string) (*PostGISPoint, error) {
 	// Expected format: "POINT(-122.315804 47.615135)"
 	wkt = strings.TrimSpace(wkt)
@@ -382,13 +747,121 @@ func parseWKT(wkt string) (*PostGISPoint, error) {
 	}, nil
 }
 
-// FindExpiredListings retrieves listings whose expires_at is in the past and status is not 'expired'.
-func (r *GORMRepository) FindExpiredListings(ctx context.Context, now time.Time) ([]Listing, error) {
+// ExpireBatch expires the listings that are overdue the longest in one statement. Drafts and scheduled listings
+// have not started their lifespan and are never expired. Expiry is not an admin decision, so admin notes are kept,
+// but a rejection reason only applies to rejected listings and is cleared. SKIP LOCKED keeps concurrent runs from
+// waiting on each other's batches.
+func (r *GORMRepository) ExpireBatch(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE listings
+		SET status = ?, rejection_reason = NULL, expiry_event_pending = TRUE
+		WHERE id IN (
+			SELECT id FROM listings
+			WHERE expires_at <= ? AND status NOT IN ?
+			ORDER BY expires_at ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id`,
+		StatusExpired,
+		now, []ListingStatus{StatusExpired, StatusDraft, StatusScheduled},
+		limit,
+	).Scan(&ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire listings: %w", err)
+	}
+	return ids, nil
+}
+
+// FindPendingExpiryEvents retrieves up to limit expired listings whose expiry event has not been emitted yet.
+func (r *GORMRepository) FindPendingExpiryEvents(ctx context.Context, limit int) ([]Listing, error) {
+	var listings []Listing
+	if err := r.db.WithContext(ctx).Where("expiry_event_pending").Order("expires_at ASC").Limit(limit).Find(&listings).Error; err != nil {
+		return nil, fmt.Errorf("failed to find listings with pending expiry events: %w", err)
+	}
+	return listings, nil
+}
+
+// ClearPendingExpiryEvents records that the expiry events of the given listings have been emitted.
+func (r *GORMRepository) ClearPendingExpiryEvents(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).Model(&Listing{}).Where("id IN ?", ids).UpdateColumn("expiry_event_pending", false).Error
+	if err != nil {
+		return fmt.Errorf("failed to clear pending expiry events: %w", err)
+	}
+	return nil
+}
+
+// FindDueScheduledListings retrieves scheduled listings whose publish_at is not after now, with their category
+// and event details, which their expiry depends on.
+func (r *GORMRepository) FindDueScheduledListings(ctx context.Context, now time.Time) ([]Listing, error) {
 	var listings []Listing
 	err := r.db.WithContext(ctx).
-		Where("expires_at <= ? AND status != ?", now, StatusExpired).
+		Preload("Category").
+		Preload("EventDetails").
+		Where("status = ? AND publish_at <= ?", StatusScheduled, now).
+		Order("publish_at ASC").
 		Find(&listings).Error
-	return listings, err
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due scheduled listings: %w", err)
+	}
+	return listings, nil
+}
+
+// ActivateScheduled makes a scheduled listing active and starts its lifespan.
+func (r *GORMRepository) ActivateScheduled(ctx context.Context, id uuid.UUID, expiresAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&Listing{}).
+		Where("id = ? AND status = ?", id, StatusScheduled).
+		Updates(map[string]interface{}{"status": StatusActive, "expires_at": expiresAt})
+	if result.Error != nil {
+		return fmt.Errorf("failed to activate scheduled listing: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrConflict.WithDetails("Listing is no longer scheduled.")
+	}
+	return nil
+}
+
+// SetFeaturedUntil sets or, with nil, clears the time until which a listing is featured.
+func (r *GORMRepository) SetFeaturedUntil(ctx context.Context, id uuid.UUID, featuredUntil *time.Time) error {
+	result := r.db.WithContext(ctx).Model(&Listing{}).Where("id = ?", id).Update("featured_until", featuredUntil)
+	if result.Error != nil {
+		return fmt.Errorf("failed to set listing featured_until: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound.WithDetails("Listing not found.")
+	}
+	return nil
+}
+
+// ClearExpiredFeatured unfeatures every listing whose featured_until is not after now, returning how many were.
+func (r *GORMRepository) ClearExpiredFeatured(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&Listing{}).
+		Where("featured_until IS NOT NULL AND featured_until <= ?", now).
+		Update("featured_until", nil)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to clear expired featured listings: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// ApprovalBacklog counts the listings in pending_approval and finds when the longest-waiting one entered it.
+func (r *GORMRepository) ApprovalBacklog(ctx context.Context) (*ApprovalBacklog, error) {
+	var row struct {
+		Pending            int64
+		OldestPendingSince *time.Time
+	}
+	err := r.db.WithContext(ctx).Model(&Listing{}).
+		Select("COUNT(*) AS pending, MIN(pending_since) AS oldest_pending_since").
+		Where("status = ?", StatusPendingApproval).
+		Scan(&row).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to read approval backlog: %w", err)
+	}
+	return &ApprovalBacklog{Pending: row.Pending, OldestPendingSince: row.OldestPendingSince}, nil
 }
 
 // CountListingsByUserIDAndStatus counts listings for a user with a specific status.
@@ -398,24 +871,28 @@ func (r *GORMRepository) CountListingsByUserIDAndStatus(ctx context.Context, use
 	return count, err
 }
 
-// CountListingsByUserID counts all listings for a user, regardless of status.
+// CountListingsByUserID counts all submitted listings for a user, regardless of status. Drafts are not counted.
 func (r *GORMRepository) CountListingsByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&Listing{}).Where("user_id = ?", userID).Count(&count).Error
+	err := r.db.WithContext(ctx).Model(&Listing{}).Where("user_id = ? AND status <> ?", userID, StatusDraft).Count(&count).Error
 	return count, err
 }
 
-// GetRecentListings retrieves recent, active, non-event listings.
-func (r *GORMRepository) GetRecentListings(ctx context.Context, page, pageSize int, currentUserID *uuid.UUID) ([]Listing, *common.Pagination, error) {
+// GetRecentListings retrieves recent, active, non-event listings, optionally limited to one category slug.
+func (r *GORMRepository) GetRecentListings(ctx context.Context, page, pageSize int, categorySlug string, currentUserID *uuid.UUID, includes Includes) ([]Listing, *common.Pagination, error) {
 	var listings []Listing
 	var total int64
 
 	// Base query for recent listings
-	baseQuery := r.db.WithContext(ctx).Model(&Listing{}).
+	baseQuery := r.db.WithContext(database.ReadFromReplica(ctx)).Model(&Listing{}).
 		Joins("JOIN categories ON categories.id = listings.category_id").
 		Where("categories.slug != ?", "events"). // Exclude events
 		Where("listings.status = ?", StatusActive).
 		Where("listings.expires_at > ?", time.Now())
+	baseQuery = inVisibleCategory(baseQuery)
+	if categorySlug != "" {
+		baseQuery = baseQuery.Where("categories.slug = ?", categorySlug)
+	}
 
 	// Note: currentUserID is passed but not used in the original query.
 	// If it's meant to filter or modify behavior, that logic would be added here or to baseQuery.
@@ -440,16 +917,12 @@ func (r *GORMRepository) GetRecentListings(ctx context.Context, page, pageSize i
 	}
 
 	// Main data query - apply location trick here
-	dataQuerySession := baseQuery // Start from the same base conditions
+	dataQuerySession := r.preloadIncludes(baseQuery, includes) // Start from the same base conditions
 	err := dataQuerySession.
+		Order(featuredFirstOrder).
 		Order("listings.created_at DESC").
 		Limit(pageSize). // Use the potentially adjusted pageSize
 		Offset(offset).
-		Preload("User").
-		Preload("Category").
-		Preload("SubCategory").
-		Preload("BabysittingDetails").
-		Preload("HousingDetails").
 		// Apply the location trick
 		Omit("location").                                                   // Tell GORM to skip trying to scan the 'location' column directly
 		Select("listings.*, ST_AsText(listings.location) AS location_wkt"). // Select WKT into LocationWKT
@@ -478,68 +951,68 @@ func (r *GORMRepository) GetRecentListings(ctx context.Context, page, pageSize i
 	return listings, pagination, nil
 }
 
-// GetUpcomingEvents retrieves upcoming event listings.
-func (r *GORMRepository) GetUpcomingEvents(ctx context.Context, page, pageSize int) ([]Listing, *common.Pagination, error) {
+// GetUpcomingEvents retrieves upcoming event listings ordered by their next occurrence.
+// Recurring events are expanded to the next date they take place on, so their EventDetails.NextOccurrence
+// may be later than EventDate. Because that date is computed per event, paging happens after the fetch.
+func (r *GORMRepository) GetUpcomingEvents(ctx context.Context, page, pageSize int, includes Includes) ([]Listing, *common.Pagination, error) {
 	var listings []Listing
-	var total int64
 
-	now := time.Now()
-	// It's generally better to use the time.Time object directly with GORM
-	// for date/time comparisons if your database column types support it (e.g., TIMESTAMP, DATE, TIME).
-	// GORM and most drivers handle the formatting.
-	// However, your original query uses formatted strings, so I'll stick to that pattern
-	// but be aware that direct time.Time objects are often cleaner.
-	currentDate := now.Format("2006-01-02")
-	currentTime := now.Format("15:04:05")
-
-	// Base query (without select modifications yet for count)
-	baseQuery := r.db.WithContext(ctx).Model(&Listing{}).
+	now := time.Now().UTC()
+
+	query := r.preloadIncludes(r.db.WithContext(database.ReadFromReplica(ctx)).Model(&Listing{}), includes)
+	if !includes.Has(IncludeDetails) {
+		query = query.Preload("EventDetails") // Needed to order the events
+	}
+	query = inVisibleCategory(query)
+	err := query.
 		Joins("JOIN categories ON categories.id = listings.category_id").
 		Joins("JOIN listing_details_events ON listing_details_events.listing_id = listings.id").
 		Where("categories.slug = ?", "events").
 		Where("listings.status = ?", StatusActive).
 		Where("listings.is_admin_approved = ?", true).
-		Where("listings.expires_at > ?", now). // Use 'now' directly
-		Where("(listing_details_events.event_date > ?) OR (listing_details_events.event_date = ? AND (listing_details_events.event_time IS NULL OR listing_details_events.event_time >= ?))", currentDate, currentDate, currentTime)
-
-	// Count total records
-	// Create a new GORM session from baseQuery for counting to avoid interference
-	countQuerySession := baseQuery
-	if err := countQuerySession.Count(&total).Error; err != nil {
-		return nil, nil, fmt.Errorf("counting upcoming events failed: %w", err)
-	}
-
-	pagination := common.NewPagination(total, page, pageSize)
-	offset := (page - 1) * pageSize
-	if page <= 0 { // Or pagination.CurrentPage <= 0
-		offset = 0
-	}
-	if pageSize <= 0 { // Default pageSize if not provided or invalid
-		pageSize = 10 // Or use pagination.PageSize
-	}
-
-	// Main data query - apply location trick here
-	dataQuerySession := baseQuery // Start from the same base conditions
-	err := dataQuerySession.
-		Order("listing_details_events.event_date ASC, listing_details_events.event_time ASC").
-		Limit(pageSize). // Use the potentially adjusted pageSize
-		Offset(offset).
-		Preload("User").
-		Preload("Category").
-		Preload("SubCategory").
-		Preload("EventDetails").
+		Where("listings.expires_at > ?", now).
+		// Timed events are compared by their UTC start; dates by the current date in each event's own zone.
+		Where("(listing_details_events.recurrence_frequency IS NULL AND ((listing_details_events.event_time IS NOT NULL AND listing_details_events.starts_at >= @now) OR "+
+			"(listing_details_events.event_time IS NULL AND listing_details_events.event_date >= (CAST(@now AS timestamptz) AT TIME ZONE listing_details_events.timezone)::date))) OR "+
+			"(listing_details_events.recurrence_frequency IS NOT NULL AND (listing_details_events.recurrence_until IS NULL OR "+
+			"listing_details_events.recurrence_until >= (CAST(@now AS timestamptz) AT TIME ZONE listing_details_events.timezone)::date))",
+			map[string]interface{}{"now": now}).
 		// Apply the location trick
 		Omit("location").                                                   // Tell GORM to skip trying to scan the 'location' column directly
 		Select("listings.*, ST_AsText(listings.location) AS location_wkt"). // Select WKT into LocationWKT
 		Find(&listings).Error
-
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) { // Handle case where no records are found gracefully
-			return []Listing{}, pagination, nil
-		}
 		return nil, nil, fmt.Errorf("fetching upcoming events failed: %w", err)
 	}
 
+	upcoming := listings[:0]
+	for i := range listings {
+		details := listings[i].EventDetails
+		if details == nil {
+			continue
+		}
+		next, ok := details.OccurrenceAfter(now.In(details.Location(time.UTC)))
+		if !ok {
+			continue
+		}
+		details.NextOccurrence = &next
+		upcoming = append(upcoming, listings[i])
+	}
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		return upcoming[i].EventDetails.NextOccurrence.Before(*upcoming[j].EventDetails.NextOccurrence)
+	})
+
+	pagination := common.NewPagination(int64(len(upcoming)), page, pageSize)
+	offset := (pagination.CurrentPage - 1) * pagination.PageSize
+	if offset > len(upcoming) {
+		offset = len(upcoming)
+	}
+	limit := offset + pagination.PageSize
+	if limit > len(upcoming) {
+		limit = len(upcoming)
+	}
+	listings = upcoming[offset:limit]
+
 	// Post-fetch processing to parse WKT
 	for i := range listings {
 		if listings[i].LocationWKT != "" {
@@ -562,8 +1035,8 @@ func (r *GORMRepository) FindByUserID(ctx context.Context, userID uuid.UUID, que
 	var listings []Listing
 	var totalItems int64
 
-	dbQuery := r.db.WithContext(ctx).Model(&Listing{})
-	dbQuery = r.preloader(dbQuery) // Apply common preloads
+	dbQuery := r.db.WithContext(database.ReadFromReplica(ctx)).Model(&Listing{})
+	dbQuery = r.preloadIncludes(dbQuery, query.Includes) // Apply the requested preloads
 
 	// Filter by UserID (mandatory)
 	dbQuery = dbQuery.Where("listings.user_id = ?", userID)
@@ -573,11 +1046,11 @@ func (r *GORMRepository) FindByUserID(ctx context.Context, userID uuid.UUID, que
 		dbQuery = dbQuery.Where("listings.status = ?", *query.Status)
 	} else { // No specific status provided
 		if query.IncludeExpired {
-			// Show active, pending, AND expired. Exclude rejected/admin_removed.
-			dbQuery = dbQuery.Where("listings.status IN (?)", []ListingStatus{StatusActive, StatusPendingApproval, StatusExpired})
+			// Show active, pending, draft, scheduled AND expired. Exclude rejected/admin_removed.
+			dbQuery = dbQuery.Where("listings.status IN (?)", []ListingStatus{StatusActive, StatusPendingApproval, StatusDraft, StatusScheduled, StatusExpired})
 		} else {
-			// Default: only show active or pending, exclude expired
-			dbQuery = dbQuery.Where("listings.status IN (?)", []ListingStatus{StatusActive, StatusPendingApproval})
+			// Default: only show active, pending, draft or scheduled, exclude expired
+			dbQuery = dbQuery.Where("listings.status IN (?)", []ListingStatus{StatusActive, StatusPendingApproval, StatusDraft, StatusScheduled})
 		}
 	}
 
@@ -632,3 +1105,714 @@ func (r *GORMRepository) FindByUserID(ctx context.Context, userID uuid.UUID, que
 	}
 	return listings, pagination, nil
 }
+
+// applyAttributeFilter adds a category attribute condition on the listings.attributes JSONB column.
+// Equality uses containment so it can use the GIN index. Range comparisons only cast values that are
+// JSON numbers; dates are stored as YYYY-MM-DD and compare as text.
+func applyAttributeFilter(dbQuery *gorm.DB, f category.AttributeFilter) *gorm.DB {
+	isNumber := f.Type == category.AttributeTypeNumber
+	switch f.Op {
+	case category.AttributeFilterGte, category.AttributeFilterLte:
+		op := ">="
+		if f.Op == category.AttributeFilterLte {
+			op = "<="
+		}
+		if isNumber {
+			return dbQuery.Where(
+				"CASE WHEN jsonb_typeof(listings.attributes -> ?) = 'number' THEN (listings.attributes ->> ?)::numeric END "+op+" ?",
+				f.Key, f.Key, f.Value)
+		}
+		return dbQuery.Where("listings.attributes ->> ? "+op+" ?", f.Key, f.Value)
+	default:
+		if isNumber {
+			return dbQuery.Where("listings.attributes @> jsonb_build_object(?::text, ?::numeric)", f.Key, f.Value)
+		}
+		return dbQuery.Where("listings.attributes @> jsonb_build_object(?::text, ?::text)", f.Key, f.Value)
+	}
+}
+
+// IncrementDailyViews adds one view to the listing's count for day.
+func (r *GORMRepository) IncrementDailyViews(ctx context.Context, listingID uuid.UUID, day time.Time) error {
+	err := r.db.WithContext(ctx).Exec(`
+		INSERT INTO listing_daily_views (listing_id, day, views) VALUES (?, ?, 1)
+		ON CONFLICT (listing_id, day) DO UPDATE SET views = listing_daily_views.views + 1`,
+		listingID, day.Format("2006-01-02")).Error
+	if err != nil {
+		return fmt.Errorf("failed to increment listing views: %w", err)
+	}
+	return nil
+}
+
+// FindActiveDailyViewsSince retrieves the daily view counts from since onwards for listings that are currently active.
+func (r *GORMRepository) FindActiveDailyViewsSince(ctx context.Context, since time.Time) ([]DailyViews, error) {
+	var views []DailyViews
+	err := r.db.WithContext(ctx).
+		Joins("JOIN listings ON listings.id = listing_daily_views.listing_id").
+		Where("listing_daily_views.day >= ?", since.Format("2006-01-02")).
+		Where("listings.status = ? AND listings.expires_at > ?", StatusActive, time.Now()).
+		Scopes(inVisibleCategory).
+		Find(&views).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find listing views: %w", err)
+	}
+	return views, nil
+}
+
+// RecordRecentView saves the view in the user's history, replacing an earlier view of the same listing,
+// and trims the history to the keep most recent listings.
+func (r *GORMRepository) RecordRecentView(ctx context.Context, view RecentView, keep int) error {
+	return r.recordView(ctx, recentViewsTable, "user_id", view.UserID, view.ListingID, view.ViewedAt, keep)
+}
+
+// RecordAnonymousRecentView is RecordRecentView for the history of an anonymous session.
+func (r *GORMRepository) RecordAnonymousRecentView(ctx context.Context, view AnonymousRecentView, keep int) error {
+	return r.recordView(ctx, anonymousRecentViewsTable, "session_id", view.SessionID, view.ListingID, view.ViewedAt, keep)
+}
+
+// recordView upserts a view into a history table whose rows are keyed by ownerColumn and listing_id, and keeps
+// the owner's keep most recent rows. table and ownerColumn are constants, never request input.
+func (r *GORMRepository) recordView(ctx context.Context, table, ownerColumn string, ownerID, listingID uuid.UUID, viewedAt time.Time, keep int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(fmt.Sprintf(`
+			INSERT INTO %[1]s (%[2]s, listing_id, viewed_at) VALUES (?, ?, ?)
+			ON CONFLICT (%[2]s, listing_id) DO UPDATE SET viewed_at = EXCLUDED.viewed_at`, table, ownerColumn),
+			ownerID, listingID, viewedAt).Error
+		if err != nil {
+			return fmt.Errorf("failed to record recent view: %w", err)
+		}
+		if err := trimViews(tx, table, ownerColumn, ownerID, keep); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// trimViews deletes all but the keep most recent rows of the owner's history in table.
+func trimViews(tx *gorm.DB, table, ownerColumn string, ownerID uuid.UUID, keep int) error {
+	err := tx.Exec(fmt.Sprintf(`
+		DELETE FROM %[1]s WHERE %[2]s = ? AND listing_id NOT IN (
+			SELECT listing_id FROM %[1]s WHERE %[2]s = ? ORDER BY viewed_at DESC LIMIT ?)`, table, ownerColumn),
+		ownerID, ownerID, keep).Error
+	if err != nil {
+		return fmt.Errorf("failed to trim recent views: %w", err)
+	}
+	return nil
+}
+
+// FindRecentViews retrieves up to limit of the user's most recent views of listings that are active and visible,
+// most recent first.
+func (r *GORMRepository) FindRecentViews(ctx context.Context, userID uuid.UUID, limit int) ([]RecentView, error) {
+	var views []RecentView
+	if err := r.findViews(ctx, recentViewsTable, "user_id", userID, limit, &views); err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+// FindAnonymousRecentViews is FindRecentViews for the history of an anonymous session.
+func (r *GORMRepository) FindAnonymousRecentViews(ctx context.Context, sessionID uuid.UUID, limit int) ([]AnonymousRecentView, error) {
+	var views []AnonymousRecentView
+	if err := r.findViews(ctx, anonymousRecentViewsTable, "session_id", sessionID, limit, &views); err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+// findViews loads the owner's most recent views of active, visible listings from a history table into dest.
+func (r *GORMRepository) findViews(ctx context.Context, table, ownerColumn string, ownerID uuid.UUID, limit int, dest interface{}) error {
+	err := r.db.WithContext(ctx).
+		Joins(fmt.Sprintf("JOIN listings ON listings.id = %s.listing_id", table)).
+		Where(fmt.Sprintf("%s.%s = ?", table, ownerColumn), ownerID).
+		Where("listings.status = ? AND listings.expires_at > ?", StatusActive, time.Now()).
+		Scopes(inVisibleCategory).
+		Order(fmt.Sprintf("%s.viewed_at DESC", table)).
+		Limit(limit).
+		Find(dest).Error
+	if err != nil {
+		return fmt.Errorf("failed to find recent views: %w", err)
+	}
+	return nil
+}
+
+// DeleteRecentViews clears the user's history of viewed listings.
+func (r *GORMRepository) DeleteRecentViews(ctx context.Context, userID uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&RecentView{}).Error; err != nil {
+		return fmt.Errorf("failed to delete recent views: %w", err)
+	}
+	return nil
+}
+
+// DeleteAnonymousRecentViews clears the history of viewed listings of an anonymous session.
+func (r *GORMRepository) DeleteAnonymousRecentViews(ctx context.Context, sessionID uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Where("session_id = ?", sessionID).Delete(&AnonymousRecentView{}).Error; err != nil {
+		return fmt.Errorf("failed to delete anonymous recent views: %w", err)
+	}
+	return nil
+}
+
+// MergeAnonymousRecentViews moves the history of an anonymous session into the user's history in one transaction.
+// Views of the user's own listings are dropped; a listing in both histories keeps its latest view. The user's history
+// is then trimmed to keep listings. It returns the number of views moved.
+func (r *GORMRepository) MergeAnonymousRecentViews(ctx context.Context, sessionID, userID uuid.UUID, keep int) (int64, error) {
+	var merged int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`
+			INSERT INTO listing_recent_views (user_id, listing_id, viewed_at)
+			SELECT ?, v.listing_id, v.viewed_at FROM listing_anonymous_recent_views v
+			JOIN listings ON listings.id = v.listing_id
+			WHERE v.session_id = ? AND listings.user_id <> ?
+			ON CONFLICT (user_id, listing_id) DO UPDATE SET viewed_at = GREATEST(listing_recent_views.viewed_at, EXCLUDED.viewed_at)`,
+			userID, sessionID, userID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to merge anonymous recent views: %w", result.Error)
+		}
+		merged = result.RowsAffected
+		if err := trimViews(tx, recentViewsTable, "user_id", userID, keep); err != nil {
+			return err
+		}
+		if err := tx.Where("session_id = ?", sessionID).Delete(&AnonymousRecentView{}).Error; err != nil {
+			return fmt.Errorf("failed to delete merged anonymous recent views: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return merged, nil
+}
+
+// CreateTakedown removes the takedown's listing, records the takedown and writes entry, in one transaction.
+// A listing with an open takedown cannot be taken down again.
+func (r *GORMRepository) CreateTakedown(ctx context.Context, takedown *Takedown, entry *audit.Entry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(takedown).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) || strings.Contains(err.Error(), "unique constraint") {
+				return common.ErrConflict.WithDetails("The listing has already been removed.")
+			}
+			return fmt.Errorf("failed to create takedown: %w", err)
+		}
+		if err := setListingStatus(tx, takedown.ListingID, StatusAdminRemoved); err != nil {
+			return err
+		}
+		if err := tx.Create(entry).Error; err != nil {
+			return fmt.Errorf("failed to write takedown audit entry: %w", err)
+		}
+		return nil
+	})
+}
+
+// UpdateTakedown saves a takedown still in fromStatus, sets its listing to listingStatus when not nil and writes
+// entry, in one transaction. It returns ErrConflict when the takedown has moved on meanwhile.
+func (r *GORMRepository) UpdateTakedown(ctx context.Context, takedown *Takedown, fromStatus TakedownStatus, listingStatus *ListingStatus, entry *audit.Entry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Takedown{}).
+			Where("id = ? AND status = ?", takedown.ID, fromStatus).
+			Updates(map[string]interface{}{
+				"status":          takedown.Status,
+				"appeal_message":  takedown.AppealMessage,
+				"appealed_at":     takedown.AppealedAt,
+				"resolved_by":     takedown.ResolvedBy,
+				"resolved_at":     takedown.ResolvedAt,
+				"resolution_note": takedown.ResolutionNote,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update takedown: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return common.ErrConflict.WithDetails("The takedown was changed by another request.")
+		}
+		if listingStatus != nil {
+			if err := setListingStatus(tx, takedown.ListingID, *listingStatus); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(entry).Error; err != nil {
+			return fmt.Errorf("failed to write takedown audit entry: %w", err)
+		}
+		return nil
+	})
+}
+
+// setListingStatus sets the status of a listing within tx.
+func setListingStatus(tx *gorm.DB, listingID uuid.UUID, status ListingStatus) error {
+	result := tx.Model(&Listing{}).Where("id = ?", listingID).Update("status", status)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update listing status: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound.WithDetails("Listing not found.")
+	}
+	return nil
+}
+
+// FindLatestTakedown retrieves the most recent takedown of a listing.
+func (r *GORMRepository) FindLatestTakedown(ctx context.Context, listingID uuid.UUID) (*Takedown, error) {
+	var takedown Takedown
+	err := r.db.WithContext(ctx).Where("listing_id = ?", listingID).Order("created_at DESC").First(&takedown).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("This listing has not been removed by an admin.")
+		}
+		return nil, fmt.Errorf("failed to find takedown: %w", err)
+	}
+	return &takedown, nil
+}
+
+// FindTakedownByID retrieves a takedown by its ID.
+func (r *GORMRepository) FindTakedownByID(ctx context.Context, id uuid.UUID) (*Takedown, error) {
+	var takedown Takedown
+	if err := r.db.WithContext(ctx).First(&takedown, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Takedown not found.")
+		}
+		return nil, fmt.Errorf("failed to find takedown: %w", err)
+	}
+	return &takedown, nil
+}
+
+// FindTakedownsByStatus retrieves a page of takedowns in a status, oldest appeal first and then oldest takedown first.
+func (r *GORMRepository) FindTakedownsByStatus(ctx context.Context, status TakedownStatus, page, pageSize int) ([]Takedown, *common.Pagination, error) {
+	query := r.db.WithContext(ctx).Model(&Takedown{}).Where("status = ?", status)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to count takedowns: %w", err)
+	}
+	pagination := common.NewPagination(total, page, pageSize)
+
+	var takedowns []Takedown
+	err := query.
+		Order("appealed_at ASC NULLS LAST").
+		Order("created_at ASC").
+		Offset((pagination.CurrentPage - 1) * pagination.PageSize).
+		Limit(pagination.PageSize).
+		Find(&takedowns).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find takedowns: %w", err)
+	}
+	return takedowns, pagination, nil
+}
+
+// CreateBackgroundCheck inserts a background check. A listing with a pending check cannot get another.
+func (r *GORMRepository) CreateBackgroundCheck(ctx context.Context, check *BackgroundCheck) error {
+	if err := r.db.WithContext(ctx).Create(check).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) || strings.Contains(err.Error(), "unique constraint") {
+			return common.ErrConflict.WithDetails("A background check for this listing is already waiting for review.")
+		}
+		return fmt.Errorf("failed to create background check: %w", err)
+	}
+	return nil
+}
+
+// UpdateBackgroundCheck saves a check still in fromStatus, sets the verified badge of its listing when badge is
+// not nil and writes entry, in one transaction. It returns ErrConflict when the check has moved on meanwhile.
+// Setting the badge bumps the listing's updated_at, as it is part of the listing.
+func (r *GORMRepository) UpdateBackgroundCheck(ctx context.Context, check *BackgroundCheck, fromStatus BackgroundCheckStatus, badge *bool, entry *audit.Entry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&BackgroundCheck{}).
+			Where("id = ? AND status = ?", check.ID, fromStatus).
+			Updates(map[string]interface{}{
+				"status":      check.Status,
+				"reviewed_by": check.ReviewedBy,
+				"reviewed_at": check.ReviewedAt,
+				"review_note": check.ReviewNote,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update background check: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return common.ErrConflict.WithDetails("The background check was changed by another request.")
+		}
+		if badge != nil {
+			var verifiedAt *time.Time
+			if *badge {
+				verifiedAt = check.ReviewedAt
+			}
+			result := tx.Model(&ListingDetailsBabysitting{}).
+				Where("listing_id = ?", check.ListingID).
+				Updates(map[string]interface{}{"verified_badge": *badge, "verified_at": verifiedAt})
+			if result.Error != nil {
+				return fmt.Errorf("failed to update verified badge: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return common.ErrConflict.WithDetails("The listing no longer has babysitting details.")
+			}
+			if err := touchListing(tx, check.ListingID); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(entry).Error; err != nil {
+			return fmt.Errorf("failed to write background check audit entry: %w", err)
+		}
+		return nil
+	})
+}
+
+// FindLatestBackgroundCheck retrieves the most recent background check of a listing, or nil when it has none.
+func (r *GORMRepository) FindLatestBackgroundCheck(ctx context.Context, listingID uuid.UUID) (*BackgroundCheck, error) {
+	var check BackgroundCheck
+	err := r.db.WithContext(ctx).Where("listing_id = ?", listingID).Order("created_at DESC").First(&check).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find background check: %w", err)
+	}
+	return &check, nil
+}
+
+// FindBackgroundCheckByID retrieves a background check by its ID.
+func (r *GORMRepository) FindBackgroundCheckByID(ctx context.Context, id uuid.UUID) (*BackgroundCheck, error) {
+	var check BackgroundCheck
+	if err := r.db.WithContext(ctx).First(&check, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Background check not found.")
+		}
+		return nil, fmt.Errorf("failed to find background check: %w", err)
+	}
+	return &check, nil
+}
+
+// FindBackgroundChecksByStatus retrieves a page of background checks in a status, oldest first.
+func (r *GORMRepository) FindBackgroundChecksByStatus(ctx context.Context, status BackgroundCheckStatus, page, pageSize int) ([]BackgroundCheck, *common.Pagination, error) {
+	query := r.db.WithContext(ctx).Model(&BackgroundCheck{}).Where("status = ?", status)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to count background checks: %w", err)
+	}
+	pagination := common.NewPagination(total, page, pageSize)
+
+	var checks []BackgroundCheck
+	err := query.
+		Order("created_at ASC").
+		Offset((pagination.CurrentPage - 1) * pagination.PageSize).
+		Limit(pagination.PageSize).
+		Find(&checks).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find background checks: %w", err)
+	}
+	return checks, pagination, nil
+}
+
+// CreateQuestion inserts a question and bumps its listing's updated_at, in one transaction.
+func (r *GORMRepository) CreateQuestion(ctx context.Context, question *Question) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(question).Error; err != nil {
+			return fmt.Errorf("failed to create question: %w", err)
+		}
+		return touchListing(tx, question.ListingID)
+	})
+}
+
+// UpdateQuestionAnswer saves a question's answer and bumps its listing's updated_at, in one transaction.
+func (r *GORMRepository) UpdateQuestionAnswer(ctx context.Context, question *Question) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(question).Updates(map[string]interface{}{
+			"answer":      question.Answer,
+			"answered_at": question.AnsweredAt,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to save answer: %w", err)
+		}
+		return touchListing(tx, question.ListingID)
+	})
+}
+
+// touchListing bumps a listing's updated_at, so that its ETag changes, without running hooks.
+func touchListing(tx *gorm.DB, listingID uuid.UUID) error {
+	if err := tx.Model(&Listing{}).Where("id = ?", listingID).UpdateColumn("updated_at", time.Now()).Error; err != nil {
+		return fmt.Errorf("failed to touch listing: %w", err)
+	}
+	return nil
+}
+
+// FindQuestionByID retrieves a question by its ID.
+func (r *GORMRepository) FindQuestionByID(ctx context.Context, id uuid.UUID) (*Question, error) {
+	var question Question
+	if err := r.db.WithContext(ctx).First(&question, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Question not found.")
+		}
+		return nil, fmt.Errorf("failed to find question: %w", err)
+	}
+	return &question, nil
+}
+
+// FindQuestions retrieves a page of a listing's questions selected by filter, newest first.
+func (r *GORMRepository) FindQuestions(ctx context.Context, filter QuestionFilter, page, pageSize int) ([]Question, *common.Pagination, error) {
+	var questions []Question
+	var totalItems int64
+
+	dbQuery := r.db.WithContext(ctx).Model(&Question{}).Where("listing_id = ?", filter.ListingID)
+	switch {
+	case filter.IncludeUnanswered:
+	case filter.AskerID != nil:
+		dbQuery = dbQuery.Where("answered_at IS NOT NULL OR asker_id = ?", *filter.AskerID)
+	default:
+		dbQuery = dbQuery.Where("answered_at IS NOT NULL")
+	}
+	if err := dbQuery.Count(&totalItems).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to count questions: %w", err)
+	}
+
+	offset := (page - 1) * pageSize
+	if err := dbQuery.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&questions).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to list questions: %w", err)
+	}
+	return questions, common.NewPagination(totalItems, page, pageSize), nil
+}
+
+// CreateContactReveal records a contact reveal. A reveal of the same listing by the same user already recorded is kept.
+func (r *GORMRepository) CreateContactReveal(ctx context.Context, reveal *ContactReveal) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "listing_id"}, {Name: "user_id"}},
+		DoNothing: true,
+	}).Create(reveal).Error
+	if err != nil {
+		return fmt.Errorf("failed to record contact reveal: %w", err)
+	}
+	return nil
+}
+
+// HasContactReveal reports whether the user has already revealed the listing's contact details.
+func (r *GORMRepository) HasContactReveal(ctx context.Context, listingID, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&ContactReveal{}).
+		Where("listing_id = ? AND user_id = ?", listingID, userID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check contact reveal: %w", err)
+	}
+	return count > 0, nil
+}
+
+// CountContactRevealsSince counts the listings whose contact details the user revealed from since onwards.
+func (r *GORMRepository) CountContactRevealsSince(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&ContactReveal{}).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count contact reveals: %w", err)
+	}
+	return count, nil
+}
+
+// FindCheckinCode retrieves the check-in code of an event, or nil when it has none yet.
+func (r *GORMRepository) FindCheckinCode(ctx context.Context, listingID uuid.UUID) (*CheckinCode, error) {
+	var code CheckinCode
+	if err := r.db.WithContext(ctx).First(&code, "listing_id = ?", listingID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find check-in code: %w", err)
+	}
+	return &code, nil
+}
+
+// SaveCheckinCode stores the check-in code of an event, replacing the one it had.
+func (r *GORMRepository) SaveCheckinCode(ctx context.Context, code *CheckinCode) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "listing_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"code", "created_at"}),
+	}).Create(code).Error
+	if err != nil {
+		return fmt.Errorf("failed to save check-in code: %w", err)
+	}
+	return nil
+}
+
+// CreateCheckin records a check-in and reports whether it is new. A check-in of the same user to the same
+// occurrence already recorded is kept.
+func (r *GORMRepository) CreateCheckin(ctx context.Context, checkin *Checkin) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "listing_id"}, {Name: "user_id"}, {Name: "occurrence_date"}},
+		DoNothing: true,
+	}).Create(checkin)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to record check-in: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CountCheckins counts the users who checked in to the event's occurrence on occurrenceDate.
+func (r *GORMRepository) CountCheckins(ctx context.Context, listingID uuid.UUID, occurrenceDate time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Checkin{}).
+		Where("listing_id = ? AND occurrence_date = ?", listingID, occurrenceDate.Format("2006-01-02")).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count check-ins: %w", err)
+	}
+	return count, nil
+}
+
+// IncrementDailyImpressions adds one search impression to each listing's count for day.
+func (r *GORMRepository) IncrementDailyImpressions(ctx context.Context, listingIDs []uuid.UUID, day time.Time) error {
+	rows := make([]DailyImpressions, len(listingIDs))
+	for i, id := range listingIDs {
+		rows[i] = DailyImpressions{ListingID: id, Day: day, Impressions: 1}
+	}
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "listing_id"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"impressions": gorm.Expr("listing_daily_impressions.impressions + 1")}),
+	}).Create(&rows).Error
+	if err != nil {
+		return fmt.Errorf("failed to increment search impressions: %w", err)
+	}
+	return nil
+}
+
+// RollupDailyStats replaces the listing_stats_daily rows of day with the views, contact reveals, search
+// impressions and event check-ins recorded on that UTC day, and returns the number of listings with activity.
+func (r *GORMRepository) RollupDailyStats(ctx context.Context, day time.Time) (int64, error) {
+	date := day.Format("2006-01-02")
+	result := r.db.WithContext(ctx).Exec(`
+		INSERT INTO listing_stats_daily (listing_id, day, views, contact_reveals, search_impressions, check_ins)
+		SELECT listing_id, ?::date, SUM(views), SUM(contact_reveals), SUM(search_impressions), SUM(check_ins) FROM (
+			SELECT listing_id, views, 0 AS contact_reveals, 0 AS search_impressions, 0 AS check_ins
+			FROM listing_daily_views WHERE day = ?
+			UNION ALL
+			SELECT listing_id, 0, COUNT(*), 0, 0
+			FROM listing_contact_reveals WHERE created_at >= ? AND created_at < ? GROUP BY listing_id
+			UNION ALL
+			SELECT listing_id, 0, 0, impressions, 0
+			FROM listing_daily_impressions WHERE day = ?
+			UNION ALL
+			SELECT listing_id, 0, 0, 0, COUNT(*)
+			FROM event_checkins WHERE created_at >= ? AND created_at < ? GROUP BY listing_id
+		) activity
+		GROUP BY listing_id
+		ON CONFLICT (listing_id, day) DO UPDATE SET
+			views = EXCLUDED.views,
+			contact_reveals = EXCLUDED.contact_reveals,
+			search_impressions = EXCLUDED.search_impressions,
+			check_ins = EXCLUDED.check_ins`,
+		date, date, day, day.AddDate(0, 0, 1), date, day, day.AddDate(0, 0, 1))
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to roll up listing stats: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// FindDailyStats retrieves the listing's rolled-up stats from from to to (inclusive), oldest first.
+func (r *GORMRepository) FindDailyStats(ctx context.Context, listingID uuid.UUID, from, to time.Time) ([]DailyStats, error) {
+	var stats []DailyStats
+	err := r.db.WithContext(ctx).
+		Where("listing_id = ? AND day BETWEEN ? AND ?", listingID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("day ASC").
+		Find(&stats).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find listing stats: %w", err)
+	}
+	return stats, nil
+}
+
+// FindNeighborhoods retrieves every neighborhood, ordered by name.
+func (r *GORMRepository) FindNeighborhoods(ctx context.Context) ([]Neighborhood, error) {
+	var neighborhoods []Neighborhood
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&neighborhoods).Error; err != nil {
+		return nil, fmt.Errorf("failed to find neighborhoods: %w", err)
+	}
+	return neighborhoods, nil
+}
+
+// FindImagesAfter returns up to limit listing images with an ID greater than afterID, in ID order,
+// so that every image can be visited in batches.
+func (r *GORMRepository) FindImagesAfter(ctx context.Context, afterID uuid.UUID, limit int) ([]ListingImage, error) {
+	var images []ListingImage
+	if err := r.db.WithContext(ctx).Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(&images).Error; err != nil {
+		return nil, fmt.Errorf("failed to find listing images: %w", err)
+	}
+	return images, nil
+}
+
+// FindHousingWithoutRentAmountAfter returns up to limit housing details with a rent_details text but no rent_amount
+// and a listing ID greater than afterListingID, in listing ID order, so that they can be visited in batches.
+func (r *GORMRepository) FindHousingWithoutRentAmountAfter(ctx context.Context, afterListingID uuid.UUID, limit int) ([]ListingDetailsHousing, error) {
+	var rows []ListingDetailsHousing
+	err := r.db.WithContext(ctx).
+		Where("rent_amount IS NULL AND TRIM(COALESCE(rent_details, '')) <> '' AND listing_id > ?", afterListingID).
+		Order("listing_id ASC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find housing rents: %w", err)
+	}
+	return rows, nil
+}
+
+// SetRentAmount stores the numeric rent of a housing listing that has none yet. A rent set in the meantime is kept.
+func (r *GORMRepository) SetRentAmount(ctx context.Context, listingID uuid.UUID, amount float64, period PricePeriod) error {
+	err := r.db.WithContext(ctx).Model(&ListingDetailsHousing{}).
+		Where("listing_id = ? AND rent_amount IS NULL", listingID).
+		Updates(map[string]interface{}{"rent_amount": amount, "rent_period": period}).Error
+	if err != nil {
+		return fmt.Errorf("failed to set rent amount: %w", err)
+	}
+	return nil
+}
+
+// DeleteImages removes listing image rows. The files are left to the caller.
+func (r *GORMRepository) DeleteImages(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&ListingImage{}).Error; err != nil {
+		return fmt.Errorf("failed to delete listing images: %w", err)
+	}
+	return nil
+}
+
+// FindImageByID returns a listing image, including one rejected by the virus scan.
+func (r *GORMRepository) FindImageByID(ctx context.Context, id uuid.UUID) (*ListingImage, error) {
+	var image ListingImage
+	if err := r.db.WithContext(ctx).First(&image, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Listing image not found.")
+		}
+		return nil, fmt.Errorf("failed to find listing image: %w", err)
+	}
+	return &image, nil
+}
+
+// UpdateImageScan records the virus scan result of a pending image. Images that are no longer pending are left alone.
+func (r *GORMRepository) UpdateImageScan(ctx context.Context, id uuid.UUID, status ImageScanStatus, result *string, scannedAt time.Time) error {
+	// The scan columns are create-only for GORM, so they are set with SQL.
+	err := r.db.WithContext(ctx).Exec(`
+		UPDATE listing_images SET scan_status = ?, scan_result = ?, scanned_at = ?, updated_at = ?
+		WHERE id = ? AND scan_status = ?`,
+		status, result, scannedAt, scannedAt, id, ScanPending).Error
+	if err != nil {
+		return fmt.Errorf("failed to record scan of listing image %s: %w", id, err)
+	}
+	return nil
+}
+
+// FindForExport returns up to limit listings matching filter that were created after the listing
+// after (nil for the first batch), ordered by creation time and ID so that batches never overlap.
+func (r *GORMRepository) FindForExport(ctx context.Context, filter ExportFilter, after *Listing, limit int) ([]Listing, error) {
+	dbQuery := r.preloader(r.db.WithContext(ctx).Model(&Listing{}))
+	if filter.Status != nil {
+		dbQuery = dbQuery.Where("listings.status = ?", *filter.Status)
+	}
+	if filter.CategoryID != nil {
+		dbQuery = dbQuery.Where("listings.category_id = ?", *filter.CategoryID)
+	}
+	if filter.CreatedFrom != nil {
+		dbQuery = dbQuery.Where("listings.created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedBefore != nil {
+		dbQuery = dbQuery.Where("listings.created_at < ?", *filter.CreatedBefore)
+	}
+	if after != nil {
+		dbQuery = dbQuery.Where("(listings.created_at, listings.id) > (?, ?)", after.CreatedAt, after.ID)
+	}
+
+	var listings []Listing
+	if err := dbQuery.Order("listings.created_at ASC, listings.id ASC").Limit(limit).Find(&listings).Error; err != nil {
+		return nil, fmt.Errorf("failed to find listings for export: %w", err)
+	}
+	return listings, nil
+}
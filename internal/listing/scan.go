@@ -0,0 +1,124 @@
+// File: internal/listing/scan.go
+package listing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/filestorage"
+	"seattle_info_backend/internal/notification"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// TaskScanImage is the queue task type that virus-scans a quarantined listing image.
+const TaskScanImage = "listing.scan_image"
+
+// scanImageTask is the payload of a TaskScanImage task. Path is kept so that the file of an image deleted
+// before its scan can still be removed.
+type scanImageTask struct {
+	ImageID uuid.UUID `json:"image_id"`
+	Path    string    `json:"path"`
+}
+
+// newImageLocation returns the directory new listing images are saved in and their scan status. With scanning
+// enabled they are quarantined until the scan task releases them.
+func (s *ServiceImplementation) newImageLocation() (string, ImageScanStatus) {
+	if s.scanner == nil {
+		return listingImagesDir, ScanUnscanned
+	}
+	return filestorage.QuarantinePath(listingImagesDir), ScanPending
+}
+
+// queueImageScans enqueues a scan of every pending image among images, which must be stored. An image whose
+// task cannot be enqueued stays quarantined; it is logged so that the scan can be queued by hand.
+func (s *ServiceImplementation) queueImageScans(ctx context.Context, images []ListingImage) {
+	for _, img := range images {
+		if img.ScanStatus != ScanPending {
+			continue
+		}
+		if err := s.tasks.Enqueue(ctx, TaskScanImage, scanImageTask{ImageID: img.ID, Path: img.ImagePath}); err != nil {
+			s.logger.Error("Failed to enqueue image scan; the image stays quarantined",
+				zap.Error(err), zap.String("imageID", img.ID.String()), zap.String("listingID", img.ListingID.String()))
+		}
+	}
+}
+
+// HandleScanImageTask scans a quarantined listing image. A clean image is released and served; an infected one
+// is deleted, recorded as infected and its owner notified. Scanner failures fail the task, so it is retried.
+func (s *ServiceImplementation) HandleScanImageTask(ctx context.Context, payload []byte) error {
+	var task scanImageTask
+	if err := json.Unmarshal(payload, &task); err != nil {
+		return fmt.Errorf("invalid %s payload: %w", TaskScanImage, err)
+	}
+	img, err := s.repo.FindImageByID(ctx, task.ImageID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			// The image or its listing was deleted, or the listing was never stored.
+			if err := s.fileStorageService.DeleteFile(filestorage.QuarantinePath(task.Path)); err != nil {
+				s.logger.Warn("Failed to delete quarantined file of a deleted image", zap.String("path", task.Path), zap.Error(err))
+			}
+			return nil
+		}
+		return err
+	}
+	if img.ScanStatus != ScanPending {
+		return nil
+	}
+	if s.scanner == nil {
+		s.logger.Warn("Virus scanning is disabled; releasing a quarantined image unscanned", zap.String("imageID", img.ID.String()))
+		return s.releaseImage(ctx, img, ScanUnscanned)
+	}
+
+	file, err := s.fileStorageService.OpenQuarantined(img.ImagePath)
+	if errors.Is(err, filestorage.ErrFileNotFound) {
+		// A previous attempt released the file but failed to record it.
+		if served, openErr := s.fileStorageService.Open(img.ImagePath); openErr == nil {
+			served.Close()
+			return s.repo.UpdateImageScan(ctx, img.ID, ScanClean, nil, time.Now().UTC())
+		}
+		return fmt.Errorf("quarantined file of image %s is missing", img.ID)
+	}
+	if err != nil {
+		return err
+	}
+	result, err := s.scanner.Scan(ctx, file)
+	file.Close()
+	if err != nil {
+		return fmt.Errorf("failed to scan image %s: %w", img.ID, err)
+	}
+	if !result.Infected {
+		return s.releaseImage(ctx, img, ScanClean)
+	}
+
+	s.logger.Warn("Uploaded image is infected",
+		zap.String("imageID", img.ID.String()), zap.String("listingID", img.ListingID.String()), zap.String("signature", result.Signature))
+	if err := s.fileStorageService.DeleteFile(filestorage.QuarantinePath(img.ImagePath)); err != nil {
+		return err
+	}
+	signature := result.Signature
+	if err := s.repo.UpdateImageScan(ctx, img.ID, ScanInfected, &signature, time.Now().UTC()); err != nil {
+		return err
+	}
+	l, err := s.repo.FindByID(ctx, img.ListingID, false)
+	if err != nil {
+		s.logger.Error("Failed to load listing to notify its owner of an infected image", zap.String("listingID", img.ListingID.String()), zap.Error(err))
+		return nil
+	}
+	s.notifyOwner(ctx, l, notification.ListingImageRejected,
+		fmt.Sprintf("An image you added to your listing \"%s\" was removed because our virus scan found malware in it (%s).", l.Title, result.Signature))
+	return nil
+}
+
+// releaseImage moves a scanned image out of quarantine and records status.
+func (s *ServiceImplementation) releaseImage(ctx context.Context, img *ListingImage, status ImageScanStatus) error {
+	if err := s.fileStorageService.Release(img.ImagePath); err != nil {
+		return fmt.Errorf("failed to release image %s: %w", img.ID, err)
+	}
+	return s.repo.UpdateImageScan(ctx, img.ID, status, nil, time.Now().UTC())
+}
@@ -0,0 +1,157 @@
+package listing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/filestorage"
+	"seattle_info_backend/internal/notification"
+	"seattle_info_backend/internal/queue"
+	"seattle_info_backend/internal/virusscan"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// scanRepository keeps one listing and its images in memory.
+type scanRepository struct {
+	Repository
+	listing Listing
+	images  map[uuid.UUID]*ListingImage
+}
+
+func (r *scanRepository) FindByID(_ context.Context, id uuid.UUID, _ bool) (*Listing, error) {
+	l := r.listing
+	return &l, nil
+}
+
+func (r *scanRepository) FindImageByID(_ context.Context, id uuid.UUID) (*ListingImage, error) {
+	img, ok := r.images[id]
+	if !ok {
+		return nil, common.ErrNotFound.WithDetails("Listing image not found.")
+	}
+	copied := *img
+	return &copied, nil
+}
+
+func (r *scanRepository) UpdateImageScan(_ context.Context, id uuid.UUID, status ImageScanStatus, result *string, scannedAt time.Time) error {
+	if img := r.images[id]; img != nil && img.ScanStatus == ScanPending {
+		img.ScanStatus, img.ScanResult, img.ScannedAt = status, result, &scannedAt
+	}
+	return nil
+}
+
+// recordedTasks collects enqueued tasks.
+type recordedTasks struct {
+	queue.Service
+	payloads [][]byte
+}
+
+func (q *recordedTasks) Enqueue(_ context.Context, taskType string, payload interface{}, _ ...queue.Option) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	q.payloads = append(q.payloads, encoded)
+	return nil
+}
+
+// signatureScanner reports files containing "EICAR" as infected.
+type signatureScanner struct{}
+
+func (signatureScanner) Scan(_ context.Context, r io.Reader) (*virusscan.Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(string(data), "EICAR") {
+		return &virusscan.Result{Infected: true, Signature: "Eicar-Test-Signature"}, nil
+	}
+	return &virusscan.Result{}, nil
+}
+
+func TestImageScan(t *testing.T) {
+	root := t.TempDir()
+	storage, err := filestorage.NewFileStorageService(root, zap.NewNop())
+	require.NoError(t, err)
+	repo := &scanRepository{listing: Listing{UserID: uuid.New(), Title: "Bike"}, images: map[uuid.UUID]*ListingImage{}}
+	repo.listing.ID = uuid.New()
+	tasks := &recordedTasks{}
+	notifications := &sentNotifications{}
+	svc := &ServiceImplementation{
+		repo:                repo,
+		fileStorageService:  storage,
+		notificationService: notifications,
+		tasks:               tasks,
+		scanner:             signatureScanner{},
+		logger:              zap.NewNop(),
+	}
+	ctx := context.Background()
+
+	images, err := svc.saveImages(uploadedFiles(t, "a.jpg", "b.png"), ImageTexts{}, &repo.listing, 0)
+	require.NoError(t, err)
+	require.Len(t, images, 2)
+	for i := range images {
+		assert.Equal(t, ScanPending, images[i].ScanStatus)
+		assert.True(t, strings.HasPrefix(images[i].ImagePath, listingImagesDir+"/"), "images keep the path they will be served at")
+		repo.images[images[i].ID] = &images[i]
+	}
+	_, err = storage.Open(images[0].ImagePath)
+	assert.ErrorIs(t, err, filestorage.ErrFileNotFound, "quarantined images are not served")
+	infectedPath := filepath.Join(root, filestorage.QuarantinePath(images[1].ImagePath))
+	require.NoError(t, os.WriteFile(infectedPath, []byte("X5O!P%@AP EICAR"), 0o600))
+
+	svc.queueImageScans(ctx, images)
+	require.Len(t, tasks.payloads, 2)
+	for _, payload := range tasks.payloads {
+		require.NoError(t, svc.HandleScanImageTask(ctx, payload))
+	}
+
+	assert.Equal(t, ScanClean, repo.images[images[0].ID].ScanStatus)
+	served, err := storage.Open(images[0].ImagePath)
+	require.NoError(t, err, "clean images are released")
+	served.Close()
+
+	infected := repo.images[images[1].ID]
+	assert.Equal(t, ScanInfected, infected.ScanStatus)
+	require.NotNil(t, infected.ScanResult)
+	assert.Equal(t, "Eicar-Test-Signature", *infected.ScanResult)
+	_, err = os.Stat(infectedPath)
+	assert.True(t, os.IsNotExist(err), "infected files are deleted")
+	require.Len(t, notifications.types, 1)
+	assert.Equal(t, notification.ListingImageRejected, notifications.types[0])
+
+	require.NoError(t, svc.HandleScanImageTask(ctx, tasks.payloads[0]), "a repeated task does nothing")
+	assert.Len(t, notifications.types, 1)
+}
+
+func TestImageScanOfDeletedImage(t *testing.T) {
+	root := t.TempDir()
+	storage, err := filestorage.NewFileStorageService(root, zap.NewNop())
+	require.NoError(t, err)
+	tasks := &recordedTasks{}
+	svc := &ServiceImplementation{
+		repo:               &scanRepository{images: map[uuid.UUID]*ListingImage{}},
+		fileStorageService: storage,
+		tasks:              tasks,
+		scanner:            signatureScanner{},
+		logger:             zap.NewNop(),
+	}
+
+	images, err := svc.saveImages(uploadedFiles(t, "a.jpg"), ImageTexts{}, &Listing{}, 0)
+	require.NoError(t, err)
+	svc.queueImageScans(context.Background(), images)
+	require.Len(t, tasks.payloads, 1)
+	require.NoError(t, svc.HandleScanImageTask(context.Background(), tasks.payloads[0]))
+	_, err = os.Stat(filepath.Join(root, filestorage.QuarantinePath(images[0].ImagePath)))
+	assert.True(t, os.IsNotExist(err), "the quarantined file of an image that was never stored is removed")
+}
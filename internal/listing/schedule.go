@@ -0,0 +1,90 @@
+// File: internal/listing/schedule.go
+package listing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/webhook"
+
+	"go.uber.org/zap"
+)
+
+// maxScheduleAhead caps how far in the future a listing can be scheduled to go live.
+const maxScheduleAhead = 90 * 24 * time.Hour
+
+// validatePublishAt checks a requested go-live time.
+func validatePublishAt(publishAt time.Time, now time.Time) error {
+	if !publishAt.After(now) {
+		return common.ErrBadRequest.WithDetails("publish_at must be in the future.")
+	}
+	if publishAt.After(now.Add(maxScheduleAhead)) {
+		return common.ErrBadRequest.WithDetails(fmt.Sprintf("publish_at must be within %d days.", int(maxScheduleAhead.Hours()/24)))
+	}
+	return nil
+}
+
+// applySchedule holds back a listing that would go live now but has a later PublishAt.
+// Listings waiting for review keep their status; approval schedules them instead.
+func applySchedule(l *Listing, now time.Time) {
+	if l.Status != StatusActive || l.PublishAt == nil || !l.PublishAt.After(now) {
+		return
+	}
+	l.Status = StatusScheduled
+}
+
+// goLiveAt returns when a listing's lifespan starts: its PublishAt when that is later than now, else now.
+func goLiveAt(l *Listing, now time.Time) time.Time {
+	if l.PublishAt != nil && l.PublishAt.After(now) {
+		return *l.PublishAt
+	}
+	return now
+}
+
+// reschedule changes when a listing that is not live yet goes live.
+func (s *ServiceImplementation) reschedule(ctx context.Context, l *Listing, publishAt time.Time) error {
+	switch l.Status {
+	case StatusDraft, StatusScheduled, StatusPendingApproval:
+	default:
+		return common.ErrBadRequest.WithDetails("publish_at can only be changed before a listing goes live.")
+	}
+	now := time.Now()
+	if err := validatePublishAt(publishAt, now); err != nil {
+		return err
+	}
+	l.PublishAt = &publishAt
+	if l.Status == StatusScheduled {
+		l.ExpiresAt = s.computeExpiresAt(ctx, &l.Category, l, publishAt)
+	}
+	return nil
+}
+
+// PublishScheduledListings makes the scheduled listings whose publish time has come active,
+// and tells their owners and webhook subscribers that they are live.
+func (s *ServiceImplementation) PublishScheduledListings(ctx context.Context) (int, error) {
+	due, err := s.repo.FindDueScheduledListings(ctx, time.Now())
+	if err != nil {
+		s.logger.Error("Failed to find due scheduled listings", zap.Error(err))
+		return 0, err
+	}
+
+	count := 0
+	for i := range due {
+		l := &due[i]
+		expiresAt := s.computeExpiresAt(ctx, &l.Category, l, time.Now())
+		if err := s.repo.ActivateScheduled(ctx, l.ID, expiresAt); err != nil {
+			s.logger.Error("Failed to publish scheduled listing", zap.Error(err), zap.String("listingID", l.ID.String()))
+			continue
+		}
+		l.Status = StatusActive
+		l.ExpiresAt = expiresAt
+		s.logger.Info("Scheduled listing published", zap.String("listingID", l.ID.String()))
+		s.notifyListingSubmitted(ctx, l)
+		s.emitListingEvent(ctx, webhook.EventListingCreated, l)
+		count++
+	}
+	s.logger.Info("Scheduled publish job completed", zap.Int("published_count", count), zap.Int("found_due", len(due)))
+	return count, nil
+}
@@ -0,0 +1,44 @@
+package listing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePublishAt(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, validatePublishAt(now.Add(time.Hour), now))
+	assert.NoError(t, validatePublishAt(now.Add(maxScheduleAhead), now))
+	assert.Error(t, validatePublishAt(now, now), "the publish time must be in the future")
+	assert.Error(t, validatePublishAt(now.Add(-time.Minute), now))
+	assert.Error(t, validatePublishAt(now.Add(maxScheduleAhead+time.Second), now), "too far ahead")
+}
+
+func TestApplySchedule(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(30 * 24 * time.Hour)
+	later := now.Add(48 * time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	active := &Listing{Status: StatusActive, ExpiresAt: expiresAt, PublishAt: &later}
+	applySchedule(active, now)
+	assert.Equal(t, StatusScheduled, active.Status)
+	assert.Equal(t, later, goLiveAt(active, now), "the lifespan starts at the publish time")
+
+	pending := &Listing{Status: StatusPendingApproval, ExpiresAt: expiresAt, PublishAt: &later}
+	applySchedule(pending, now)
+	assert.Equal(t, StatusPendingApproval, pending.Status, "approval schedules listings under review")
+
+	past := &Listing{Status: StatusActive, ExpiresAt: expiresAt, PublishAt: &earlier}
+	applySchedule(past, now)
+	assert.Equal(t, StatusActive, past.Status)
+	assert.Equal(t, expiresAt, past.ExpiresAt)
+	assert.Equal(t, now, goLiveAt(past, now))
+
+	unscheduled := &Listing{Status: StatusActive, ExpiresAt: expiresAt}
+	applySchedule(unscheduled, now)
+	assert.Equal(t, StatusActive, unscheduled.Status)
+}
@@ -6,14 +6,29 @@ import (
 	"errors"
 	"fmt"
 	"mime/multipart" // Added for image handling
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
+	"seattle_info_backend/internal/abuse"
+	"seattle_info_backend/internal/antispam"
+	"seattle_info_backend/internal/appconfig"
+	"seattle_info_backend/internal/audit"
 	"seattle_info_backend/internal/category"
 	"seattle_info_backend/internal/common"
 	"seattle_info_backend/internal/config"
 	"seattle_info_backend/internal/filestorage" // Added for image handling
+	"seattle_info_backend/internal/moderation"
 	"seattle_info_backend/internal/notification"
+	"seattle_info_backend/internal/platform/clientinfo"
+	"seattle_info_backend/internal/platform/geo"
+	"seattle_info_backend/internal/platform/placenames"
+	"seattle_info_backend/internal/queue"
+	"seattle_info_backend/internal/shared"
 	"seattle_info_backend/internal/user"
+	"seattle_info_backend/internal/virusscan"
+	"seattle_info_backend/internal/webhook"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -23,20 +38,77 @@ import (
 type Service interface {
 	CreateListing(ctx context.Context, userID uuid.UUID, req CreateListingRequest, images []*multipart.FileHeader) (*Listing, error)
 	GetListingByID(ctx context.Context, id uuid.UUID, authenticatedUserID *uuid.UUID) (*Listing, error)
+	GetListingBySlug(ctx context.Context, slug string, authenticatedUserID *uuid.UUID) (*Listing, error)
+	GetListingsByIDs(ctx context.Context, ids []uuid.UUID, authenticatedUserID *uuid.UUID) ([]Listing, error)
+	GetRelatedListings(ctx context.Context, id uuid.UUID, authenticatedUserID *uuid.UUID, limit int) ([]Listing, error)
 	UpdateListing(ctx context.Context, id uuid.UUID, userID uuid.UUID, req UpdateListingRequest, newImages []*multipart.FileHeader) (*Listing, error)
+	PatchListing(ctx context.Context, id uuid.UUID, userID uuid.UUID, patch map[string]interface{}, ifMatch string) (*Listing, error)
+	BulkUpdateMyListings(ctx context.Context, userID uuid.UUID, req BulkUpdateListingsRequest) ([]BulkUpdateResult, error)
 	DeleteListing(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	PublishListing(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*Listing, error)
 	SearchListings(ctx context.Context, query ListingSearchQuery, authenticatedUserID *uuid.UUID) ([]Listing, *common.Pagination, error)
+	GetMapClusters(ctx context.Context, query MapClusterQuery) ([]MapCluster, error)
+	GetNeighborhoods(ctx context.Context) ([]NeighborhoodResponse, error)
 	GetUserListings(ctx context.Context, userID uuid.UUID, query UserListingsQuery) ([]Listing, *common.Pagination, error)
-	GetRecentListings(ctx context.Context, page, pageSize int) ([]ListingResponse, *common.Pagination, error)
-	GetUpcomingEvents(ctx context.Context, page, pageSize int) ([]ListingResponse, *common.Pagination, error)
+	GetRecentListings(ctx context.Context, page, pageSize int, includes Includes) ([]ListingResponse, *common.Pagination, error)
+	GetRecentListingsFeed(ctx context.Context, categorySlug string) ([]Listing, error)
+	GetUpcomingEvents(ctx context.Context, page, pageSize int, includes Includes) ([]ListingResponse, *common.Pagination, error)
+	GetEventsCalendar(ctx context.Context) ([]byte, time.Time, error)
+	GetTrendingListings(ctx context.Context, page, pageSize int) ([]ListingResponse, *common.Pagination, error)
+	RecordListingView(ctx context.Context, l *Listing, viewerID *uuid.UUID)
+	GetRecentlyViewed(ctx context.Context, userID uuid.UUID) ([]RecentlyViewedResponse, error)
+	ClearRecentlyViewed(ctx context.Context, userID uuid.UUID) error
+	RecordAnonymousView(ctx context.Context, l *Listing, sessionID uuid.UUID)
+	GetAnonymousRecentlyViewed(ctx context.Context, sessionID uuid.UUID) ([]RecentlyViewedResponse, error)
+	ClearAnonymousRecentlyViewed(ctx context.Context, sessionID uuid.UUID) error
+	MergeAnonymousRecentlyViewed(ctx context.Context, sessionID, userID uuid.UUID) (int, error)
+	RevealContact(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*ContactDetailsResponse, error)
+	AskQuestion(ctx context.Context, listingID uuid.UUID, askerID uuid.UUID, req AskQuestionRequest) (*Question, error)
+	AnswerQuestion(ctx context.Context, questionID uuid.UUID, userID uuid.UUID, req AnswerQuestionRequest) (*Question, error)
+	ListQuestions(ctx context.Context, l *Listing, viewerID *uuid.UUID, page, pageSize int) ([]Question, *common.Pagination, error)
+	RecordSearchImpressions(ctx context.Context, listings []Listing, viewerID *uuid.UUID)
+	GetListingAnalytics(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*ListingAnalyticsResponse, error)
+	BackfillRentAmounts(ctx context.Context, dryRun bool) (*RentBackfillReport, error)
+	GetCheckinCode(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*CheckinCodeResponse, error)
+	RotateCheckinCode(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*CheckinCodeResponse, error)
+	CheckIn(ctx context.Context, id uuid.UUID, userID uuid.UUID, code string) (*CheckinResponse, error)
 
 	// Admin specific
-	AdminUpdateListingStatus(ctx context.Context, id uuid.UUID, status ListingStatus, adminNotes *string) (*Listing, error)
+	AdminUpdateListingStatus(ctx context.Context, id uuid.UUID, status ListingStatus, adminNotes *string, rejectionReason *RejectionReason) (*Listing, error)
 	AdminApproveListing(ctx context.Context, id uuid.UUID) (*Listing, error)
 	AdminGetListingByID(ctx context.Context, id uuid.UUID) (*Listing, error)
+	AdminUpdateListing(ctx context.Context, id uuid.UUID, adminID uuid.UUID, req AdminUpdateListingRequest) (*Listing, error)
+	ExportListings(ctx context.Context, filter ExportFilter, fn func([]Listing) error) error
+
+	// Featuring (admins and partners)
+	FeatureListing(ctx context.Context, id uuid.UUID, days int, actorID *uuid.UUID, note *string) (*Listing, error)
+	UnfeatureListing(ctx context.Context, id uuid.UUID, adminID uuid.UUID) (*Listing, error)
+	RevokeFeature(ctx context.Context, id uuid.UUID, days int, note *string) (*Listing, error)
+
+	// Takedowns and appeals
+	TakeDownListing(ctx context.Context, id uuid.UUID, adminID uuid.UUID, req TakedownRequest) (*Takedown, error)
+	AppealTakedown(ctx context.Context, listingID uuid.UUID, userID uuid.UUID, req AppealRequest) (*Takedown, error)
+	ListAppeals(ctx context.Context, query AppealListQuery, page, pageSize int) ([]TakedownResponse, *common.Pagination, error)
+	ResolveAppeal(ctx context.Context, takedownID uuid.UUID, adminID uuid.UUID, req ResolveAppealRequest) (*Takedown, error)
+
+	// Background checks of babysitting providers
+	SubmitBackgroundCheck(ctx context.Context, listingID uuid.UUID, userID uuid.UUID, document *multipart.FileHeader, note *string) (*BackgroundCheck, error)
+	GetBackgroundCheck(ctx context.Context, listingID uuid.UUID, userID uuid.UUID) (*BackgroundCheck, error)
+	ListBackgroundChecks(ctx context.Context, query BackgroundCheckListQuery, page, pageSize int) ([]BackgroundCheckResponse, *common.Pagination, error)
+	OpenBackgroundCheckDocument(ctx context.Context, checkID uuid.UUID) (*BackgroundCheck, *filestorage.StoredFile, error)
+	ReviewBackgroundCheck(ctx context.Context, checkID uuid.UUID, adminID uuid.UUID, req ReviewBackgroundCheckRequest) (*BackgroundCheck, error)
 
 	// Jobs related (can be called by cron jobs)
 	ExpireListings(ctx context.Context) (int, error)
+	PublishScheduledListings(ctx context.Context) (int, error)
+	ExpireFeaturedListings(ctx context.Context) (int, error)
+	RefreshTrendingListings(ctx context.Context) (int, error)
+	RollupListingStats(ctx context.Context, day time.Time) (int, error)
+	CheckImageConsistency(ctx context.Context, dryRun bool) (*ImageConsistencyReport, error)
+	GetApprovalBacklog(ctx context.Context) (*ApprovalBacklog, error)
+
+	// HandleScanImageTask is the queue handler for TaskScanImage.
+	HandleScanImageTask(ctx context.Context, payload []byte) error
 }
 
 // ServiceImplementation implements the listing Service interface.
@@ -46,8 +118,29 @@ type ServiceImplementation struct {
 	categoryService     category.Service
 	notificationService notification.Service
 	fileStorageService  *filestorage.FileStorageService // Added
+	moderator           moderation.Moderator
+	spamScorer          antispam.Scorer
+	placeNames          *placenames.Synonyms
+	appConfig           appconfig.Service
+	webhookService      webhook.Service
+	auditService        audit.Service
+	abuseService        abuse.Service
+	tasks               queue.Service
+	scanner             virusscan.Scanner // Nil when virus scanning is disabled
 	cfg                 *config.Config
 	logger              *zap.Logger
+	imageURLs           *filestorage.ImageURLBuilder
+	serviceArea         *geo.ServiceArea // Nil when listings may be placed anywhere
+
+	// The rendered events calendar is shared by every subscriber until cfg.EventsCalendarCacheTTL passes.
+	calendarMu      sync.Mutex
+	calendarICS     []byte
+	calendarBuiltAt time.Time
+
+	// The trending ranking is recomputed by the trending job and served from memory in between.
+	trendingMu       sync.RWMutex
+	trendingListings []Listing
+	trendingBuiltAt  time.Time
 }
 
 // NewService creates a new listing service.
@@ -57,17 +150,41 @@ func NewService(
 	categoryService category.Service,
 	notificationService notification.Service,
 	fileStorageService *filestorage.FileStorageService, // Added
+	moderator moderation.Moderator,
+	spamScorer antispam.Scorer,
+	placeNames *placenames.Synonyms,
+	appConfig appconfig.Service,
+	webhookService webhook.Service,
+	auditService audit.Service,
+	abuseService abuse.Service,
+	tasks queue.Service,
+	scanner virusscan.Scanner,
 	cfg *config.Config,
 	logger *zap.Logger,
-) Service { 
+) Service {
+	serviceArea, err := geo.NewServiceArea(cfg.ServiceAreaPolygon, cfg.ServiceAreaCenterLat, cfg.ServiceAreaCenterLon, cfg.ServiceAreaRadiusKM)
+	if err != nil {
+		logger.Error("Invalid service area configuration; listing coordinates will not be checked", zap.Error(err))
+	}
 	return &ServiceImplementation{
 		repo:                repo,
 		userRepo:            userRepo,
 		categoryService:     categoryService,
 		notificationService: notificationService,
 		fileStorageService:  fileStorageService, // Added
+		moderator:           moderator,
+		spamScorer:          spamScorer,
+		placeNames:          placeNames,
+		appConfig:           appConfig,
+		webhookService:      webhookService,
+		auditService:        auditService,
+		abuseService:        abuseService,
+		tasks:               tasks,
+		scanner:             scanner,
 		cfg:                 cfg,
 		logger:              logger,
+		imageURLs:           filestorage.NewImageURLBuilder(cfg),
+		serviceArea:         serviceArea,
 	}
 }
 
@@ -78,157 +195,153 @@ func (s *ServiceImplementation) CreateListing(ctx context.Context, userID uuid.U
 		s.logger.Warn("Invalid category ID during listing creation", zap.String("categoryID", req.CategoryID.String()), zap.Error(err))
 		return nil, common.ErrBadRequest.WithDetails("Invalid category ID provided.")
 	}
+	if !cat.IsActive {
+		return nil, common.ErrBadRequest.WithDetails("This category is not accepting new listings.")
+	}
+	if req.PublishAt != nil {
+		if err := validatePublishAt(*req.PublishAt, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	if err := req.ImageTexts.validate(len(images) + len(req.UploadTokens)); err != nil {
+		return nil, err
+	}
 	if req.SubCategoryID != nil && *req.SubCategoryID != uuid.Nil {
-		foundSubCat := false
-		for _, sc := range cat.SubCategories {
-			if sc.ID == *req.SubCategoryID {
-				foundSubCat = true
+		var foundSubCat *category.SubCategory
+		for i := range cat.SubCategories {
+			if cat.SubCategories[i].ID == *req.SubCategoryID {
+				foundSubCat = &cat.SubCategories[i]
 				break
 			}
 		}
-		if !foundSubCat {
+		if foundSubCat == nil {
 			s.logger.Warn("Invalid subcategory ID for the given category",
 				zap.String("categoryID", req.CategoryID.String()),
 				zap.String("subCategoryID", req.SubCategoryID.String()))
 			return nil, common.ErrBadRequest.WithDetails("Subcategory does not belong to the specified category.")
 		}
-	} else if cat.Name == "Businesses" && (req.SubCategoryID == nil || *req.SubCategoryID == uuid.Nil) {
-		return nil, common.ErrBadRequest.WithDetails("Subcategory is required for 'Business' listings.")
-	}
-
-	switch cat.Slug {
-	case "baby-sitting":
-		if req.BabysittingDetails == nil || len(req.BabysittingDetails.LanguagesSpoken) == 0 {
-			return nil, common.ErrBadRequest.WithDetails("Languages spoken are required for Baby Sitting listings.")
-		}
-	case "housing":
-		if req.HousingDetails == nil {
-			return nil, common.ErrBadRequest.WithDetails("Housing details (property type) are required for Housing listings.")
-		}
-		if req.HousingDetails.PropertyType == HousingForRent && (req.HousingDetails.RentDetails == nil || *req.HousingDetails.RentDetails == "") {
-			return nil, common.ErrBadRequest.WithDetails("Rent details are required for 'Property for Rent' housing listings.")
-		}
-		if req.HousingDetails.PropertyType == HousingForSale && (req.HousingDetails.SalePrice == nil || *req.HousingDetails.SalePrice <= 0) {
-			return nil, common.ErrBadRequest.WithDetails("A valid sale price is required for 'Property for Sale' housing listings.")
-		}
-	case "events":
-		if req.EventDetails == nil {
-			return nil, common.ErrBadRequest.WithDetails("Event details (date) are required for Event listings.")
-		}
-	}
-
-	postingUser, err := s.userRepo.FindByID(ctx, userID)
-	if err != nil {
-		s.logger.Error("User not found when creating listing", zap.String("userID", userID.String()), zap.Error(err))
-		return nil, common.ErrInternalServer.WithDetails("Could not retrieve user details.")
-	}
-
-	listingStatus := StatusActive
-	isAdminApproved := true
-
-	firstPostModelActiveUntil, err := s.getPlatformConfigDate("FIRST_POST_APPROVAL_MODEL_ACTIVE_UNTIL")
-	isFirstPostModelActive := false
-	if err == nil && time.Now().Before(*firstPostModelActiveUntil) {
-		isFirstPostModelActive = true
-	} else if err != nil {
-		s.logger.Warn("Could not parse FIRST_POST_APPROVAL_MODEL_ACTIVE_UNTIL, assuming model is not active", zap.Error(err))
-	}
-
-	if isFirstPostModelActive && !postingUser.IsFirstPostApproved {
-		userPostCount, err := s.repo.CountListingsByUserID(ctx, userID)
-		if err != nil {
-			s.logger.Error("Failed to count user listings for first post check", zap.Error(err), zap.String("userID", userID.String()))
-			return nil, common.ErrInternalServer.WithDetails("Could not verify posting eligibility.")
-		}
-
-		if userPostCount == 0 {
-			listingStatus = StatusPendingApproval
-			isAdminApproved = false
-			s.logger.Info("First post by user, marking for admin approval", zap.String("userID", userID.String()))
-		}
-		if userPostCount > 0 {
-			s.logger.Warn("User attempting to submit multiple posts before first approval", zap.String("userID", userID.String()))
-			return nil, common.ErrForbidden.WithDetails("You must wait for your first post to be approved before submitting another.")
+		if !foundSubCat.IsActive {
+			return nil, common.ErrBadRequest.WithDetails("This subcategory is not accepting new listings.")
 		}
 	}
 
-	lifespanDays := s.cfg.DefaultListingLifespanDays
-	configLifespan, err := s.getPlatformConfigInt("DEFAULT_LISTING_LIFESPAN_DAYS")
-	if err == nil && configLifespan > 0 {
-		lifespanDays = configLifespan
-	} else if err != nil {
-		s.logger.Warn("Could not parse DEFAULT_LISTING_LIFESPAN_DAYS from app_configurations, using default from .env", zap.Error(err))
-	}
-	expiresAt := time.Now().AddDate(0, 0, lifespanDays)
-
 	newListing := &Listing{
-		UserID:          userID,
-		CategoryID:      req.CategoryID,
-		SubCategoryID:   req.SubCategoryID,
-		Title:           req.Title,
-		Description:     req.Description,
-		Status:          listingStatus,
-		ContactName:     req.ContactName,
-		ContactEmail:    req.ContactEmail,
-		ContactPhone:    req.ContactPhone,
-		AddressLine1:    req.AddressLine1,
-		AddressLine2:    req.AddressLine2,
-		City:            req.City,
-		State:           req.State,
-		ZipCode:         req.ZipCode,
-		Latitude:        req.Latitude,
-		Longitude:       req.Longitude,
-		ExpiresAt:       expiresAt,
-		IsAdminApproved: isAdminApproved,
+		UserID:        userID,
+		CategoryID:    req.CategoryID,
+		SubCategoryID: req.SubCategoryID,
+		Title:         req.Title,
+		Description:   req.Description,
+		Status:        StatusDraft,
+		ContactName:   req.ContactName,
+		ContactEmail:  req.ContactEmail,
+		ContactPhone:  req.ContactPhone,
+		AddressLine1:  req.AddressLine1,
+		AddressLine2:  req.AddressLine2,
+		City:          req.City,
+		State:         req.State,
+		ZipCode:       req.ZipCode,
+		Latitude:      req.Latitude,
+		Longitude:     req.Longitude,
+		PublishAt:     req.PublishAt,
+	}
+	if req.LocationPrivacy != nil {
+		newListing.LocationPrivacy = *req.LocationPrivacy
+	}
+	if ip := clientinfo.FromContext(ctx).IP; ip != "" {
+		newListing.CreatedIP = &ip
 	}
 	if req.Latitude != nil && req.Longitude != nil {
 		newListing.Location = &PostGISPoint{Lat: *req.Latitude, Lon: *req.Longitude}
 	}
-
-	// Process and save images
-	if len(images) > 0 {
-		newListing.Images = make([]ListingImage, 0, len(images))
-		for i, imageFile := range images {
-			// Define a subdirectory for listing images, e.g., "listings"
-			relativePath, err := s.fileStorageService.SaveUploadedFile(imageFile, "listings")
-			if err != nil {
-				s.logger.Error("Failed to save uploaded image", zap.Error(err), zap.String("filename", imageFile.Filename))
-				// Potentially rollback previously saved images or handle error more gracefully
-				return nil, common.ErrBadRequest.WithDetails(fmt.Sprintf("Failed to save image %s: %s", imageFile.Filename, err.Error()))
-			}
-			newListing.Images = append(newListing.Images, ListingImage{
-				ImagePath: relativePath,
-				SortOrder: i, // Simple sort order based on upload sequence
-			})
+	if err := s.checkServiceArea(newListing); err != nil {
+		return nil, err
+	}
+	if req.Price != nil {
+		req.Price.applyTo(newListing)
+	}
+	if req.BusinessHours != nil {
+		if err := s.applyBusinessHours(newListing, cat.Slug, req.BusinessHours); err != nil {
+			return nil, err
 		}
 	}
+	if err := s.applyAttributes(ctx, newListing, req.Attributes, !req.Draft); err != nil {
+		return nil, err
+	}
 
 	if req.BabysittingDetails != nil {
 		newListing.BabysittingDetails = &ListingDetailsBabysitting{
 			LanguagesSpoken: req.BabysittingDetails.LanguagesSpoken,
 		}
+		if err := req.BabysittingDetails.applyProfileTo(newListing.BabysittingDetails, s.cfg.EventsTimezone); err != nil {
+			return nil, err
+		}
 	}
 	if req.HousingDetails != nil {
 		newListing.HousingDetails = &ListingDetailsHousing{
 			PropertyType: req.HousingDetails.PropertyType,
-			RentDetails:  req.HousingDetails.RentDetails,
 			SalePrice:    req.HousingDetails.SalePrice,
 		}
+		req.HousingDetails.applyRentTo(newListing.HousingDetails)
+		if err := req.HousingDetails.applyAmenitiesTo(newListing.HousingDetails); err != nil {
+			return nil, err
+		}
 	}
 	if req.EventDetails != nil {
-		eventDate, _ := time.Parse("2006-01-02", req.EventDetails.EventDate)
 		newListing.EventDetails = &ListingDetailsEvents{
-			EventDate:     eventDate,
-			EventTime:     req.EventDetails.EventTime,
-			OrganizerName: req.EventDetails.OrganizerName,
-			VenueName:     req.EventDetails.VenueName,
+			OrganizerName:      req.EventDetails.OrganizerName,
+			VenueName:          req.EventDetails.VenueName,
+			RecurrenceInterval: 1,
+		}
+		if err := req.EventDetails.applyScheduleTo(newListing.EventDetails, s.cfg.EventsTimezone); err != nil {
+			return nil, err
+		}
+		if req.EventDetails.Recurrence != nil {
+			if err := newListing.EventDetails.applyRecurrence(req.EventDetails.Recurrence); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if req.JobDetails != nil {
+		if err := validateSalaryRange(req.JobDetails); err != nil {
+			return nil, err
+		}
+		newListing.JobDetails = req.JobDetails.toModel()
+	}
+
+	// Drafts may be saved incomplete; the required details are enforced on publish instead.
+	if !req.Draft {
+		if err := validateRequiredDetails(cat, newListing); err != nil {
+			return nil, err
+		}
+		newListing.Status, newListing.IsAdminApproved, err = s.resolvePublishStatus(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		s.applyModeration(ctx, newListing)
+		s.applySpamScore(ctx, newListing)
+		applySchedule(newListing, time.Now())
+	}
+	newListing.ExpiresAt = s.computeExpiresAt(ctx, cat, newListing, goLiveAt(newListing, time.Now()))
+
+	// Process and save images
+	if len(images) > 0 || len(req.UploadTokens) > 0 {
+		newListing.Images, err = s.addImages(userID, images, req.UploadTokens, req.ImageTexts, newListing, 0)
+		if err != nil {
+			return nil, err
 		}
 	}
 
+	applyQualityScore(newListing, cat.Slug)
+
 	if err := s.repo.Create(ctx, newListing); err != nil {
 		s.logger.Error("Failed to create listing in repository", zap.Error(err))
+		s.discardImages(newListing.Images)
 		return nil, err
 	}
+	s.queueImageScans(ctx, newListing.Images)
+	if s.abuseService != nil {
+		s.abuseService.RecordEvent(ctx, userID, abuse.EventListingCreated)
+	}
 
 	createdListing, err := s.repo.FindByID(ctx, newListing.ID, true)
 	if err != nil {
@@ -238,26 +351,13 @@ func (s *ServiceImplementation) CreateListing(ctx context.Context, userID uuid.U
 
 	s.logger.Info("Listing created successfully", zap.String("listingID", createdListing.ID.String()), zap.String("status", string(createdListing.Status)))
 
-	if s.notificationService != nil {
-		var notifType notification.NotificationType
-		var notifMessage string
-
-		if createdListing.Status == StatusPendingApproval || !createdListing.IsAdminApproved {
-			notifType = notification.ListingCreatedPendingApproval
-			notifMessage = fmt.Sprintf("Your listing '%s' has been submitted and is pending review.", createdListing.Title)
-		} else {
-			notifType = notification.ListingCreatedLive
-			notifMessage = fmt.Sprintf("Your listing '%s' has been successfully created and is now live!", createdListing.Title)
-		}
-
-		_, errNotif := s.notificationService.CreateNotification(ctx, createdListing.UserID, notifType, notifMessage, &createdListing.ID)
-		if errNotif != nil {
-			s.logger.Error("Failed to send listing creation notification",
-				zap.Error(errNotif),
-				zap.String("listingID", createdListing.ID.String()),
-				zap.String("userID", createdListing.UserID.String()),
-			)
-		}
+	if createdListing.Status != StatusDraft {
+		s.recordFirstListing(ctx, userID)
+	}
+	// Scheduled listings are announced when they go live.
+	if createdListing.Status != StatusDraft && createdListing.Status != StatusScheduled {
+		s.notifyListingSubmitted(ctx, createdListing)
+		s.emitListingEvent(ctx, webhook.EventListingCreated, createdListing)
 	}
 	return createdListing, nil
 }
@@ -268,23 +368,112 @@ func (s *ServiceImplementation) GetListingByID(ctx context.Context, id uuid.UUID
 	if err != nil {
 		return nil, err
 	}
+	if err := s.checkViewable(listing, authenticatedUserID); err != nil {
+		return nil, err
+	}
+	return listing, nil
+}
 
-	if listing.Status == StatusPendingApproval {
-		isOwner := authenticatedUserID != nil && listing.UserID == *authenticatedUserID
-		if !isOwner {
-			s.logger.Warn("Attempt to view pending listing by non-owner/non-admin",
-				zap.String("listingID", id.String()),
-				zap.Any("viewerID", authenticatedUserID),
-			)
-			return nil, common.ErrNotFound.WithDetails("Listing not found or access denied.")
+// GetListingBySlug retrieves a listing by its slug, with the same visibility rules as GetListingByID.
+func (s *ServiceImplementation) GetListingBySlug(ctx context.Context, slug string, authenticatedUserID *uuid.UUID) (*Listing, error) {
+	listing, err := s.repo.FindBySlug(ctx, slug)
+	if err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to get listing by slug", zap.String("slug", slug), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve listing.")
+	}
+	if err := s.checkViewable(listing, authenticatedUserID); err != nil {
+		return nil, err
+	}
+	return listing, nil
+}
+
+// checkViewable hides the listings isVisibleTo keeps to their owner from everyone else.
+func (s *ServiceImplementation) checkViewable(listing *Listing, authenticatedUserID *uuid.UUID) error {
+	if isVisibleTo(listing, authenticatedUserID) {
+		return nil
+	}
+	if listing.Status == StatusExpired {
+		return common.ErrNotFound.WithDetails("Listing not found or has expired.")
+	}
+	s.logger.Warn("Attempt to view owner-only listing by non-owner/non-admin",
+		zap.String("listingID", listing.ID.String()),
+		zap.Any("viewerID", authenticatedUserID),
+	)
+	return common.ErrNotFound.WithDetails("Listing not found or access denied.")
+}
+
+// MaxBatchListingIDs caps how many IDs GetListingsByIDs accepts in one call.
+const MaxBatchListingIDs = 100
+
+// GetListingsByIDs retrieves several listings at once, in the order of ids.
+// The visibility rules of GetListingByID apply to each listing; listings the caller may not see are left out.
+func (s *ServiceImplementation) GetListingsByIDs(ctx context.Context, ids []uuid.UUID, authenticatedUserID *uuid.UUID) ([]Listing, error) {
+	if len(ids) > MaxBatchListingIDs {
+		return nil, common.ErrBadRequest.WithDetails(fmt.Sprintf("At most %d listing IDs can be requested at once.", MaxBatchListingIDs))
+	}
+
+	found, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("Failed to get listings by IDs", zap.Int("count", len(ids)), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve listings.")
+	}
+	byID := make(map[uuid.UUID]Listing, len(found))
+	for _, l := range found {
+		byID[l.ID] = l
+	}
+
+	listings := make([]Listing, 0, len(found))
+	for _, id := range ids {
+		l, ok := byID[id]
+		if !ok || !isVisibleTo(&l, authenticatedUserID) {
+			continue
 		}
+		listings = append(listings, l)
+		delete(byID, id) // a repeated ID is returned once
 	}
+	return listings, nil
+}
+
+// Bounds of the limit accepted by GetRelatedListings.
+const (
+	DefaultRelatedListings = 6
+	MaxRelatedListings     = 20
+)
 
-	if listing.Status == StatusExpired && (authenticatedUserID == nil || listing.UserID != *authenticatedUserID) {
-		return nil, common.ErrNotFound.WithDetails("Listing not found or has expired.")
+// GetRelatedListings returns up to limit active listings similar to the given one, for a "similar listings" section.
+// The visibility rules of GetListingByID apply to the source listing.
+func (s *ServiceImplementation) GetRelatedListings(ctx context.Context, id uuid.UUID, authenticatedUserID *uuid.UUID, limit int) ([]Listing, error) {
+	if limit <= 0 {
+		limit = DefaultRelatedListings
+	}
+	if limit > MaxRelatedListings {
+		limit = MaxRelatedListings
+	}
+	source, err := s.GetListingByID(ctx, id, authenticatedUserID)
+	if err != nil {
+		return nil, err
+	}
+	related, err := s.repo.FindRelated(ctx, source, limit)
+	if err != nil {
+		s.logger.Error("Failed to find related listings", zap.String("listingID", id.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve related listings.")
 	}
+	return related, nil
+}
 
-	return listing, nil
+// isVisibleTo reports whether a non-admin viewer may see the listing: drafts, pending, scheduled, expired, rejected
+// and removed listings are only visible to their owner, who can still appeal a takedown.
+// GetListingByID, GetListingBySlug and GetListingsByIDs all apply it.
+func isVisibleTo(l *Listing, viewerID *uuid.UUID) bool {
+	switch l.Status {
+	case StatusDraft, StatusPendingApproval, StatusScheduled, StatusExpired, StatusRejected, StatusAdminRemoved:
+		return viewerID != nil && l.UserID == *viewerID
+	default:
+		return true
+	}
 }
 
 // AdminGetListingByID retrieves a listing by ID for admin purposes, bypassing some visibility rules.
@@ -305,6 +494,31 @@ func (s *ServiceImplementation) UpdateListing(ctx context.Context, id uuid.UUID,
 	// For now, file operations will happen outside the main repo transaction.
 	// If repo.Update also handles ListingImages, then it's fine. Otherwise, manual transaction management here is better.
 
+	existingListing, err := s.findListingForEdit(ctx, id, userID, req.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+	return s.applyUpdate(ctx, existingListing, req, newImages)
+}
+
+// PatchListing applies an RFC 7386 JSON Merge Patch to a listing. Members set to null are cleared.
+func (s *ServiceImplementation) PatchListing(ctx context.Context, id uuid.UUID, userID uuid.UUID, patch map[string]interface{}, ifMatch string) (*Listing, error) {
+	existingListing, err := s.findListingForEdit(ctx, id, userID, ifMatch)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := mergeListingPatch(existingListing, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	// The merged document describes the whole editable state, so anything it leaves out is cleared.
+	existingListing.clearEditableFields()
+	return s.applyUpdate(ctx, existingListing, doc.toUpdateRequest(), nil)
+}
+
+// findListingForEdit loads a listing with its associations for an update by userID, enforcing ownership and If-Match.
+func (s *ServiceImplementation) findListingForEdit(ctx context.Context, id uuid.UUID, userID uuid.UUID, ifMatch string) (*Listing, error) {
 	existingListing, err := s.repo.FindByID(ctx, id, true) // Preload images as well
 	if err != nil {
 		return nil, err
@@ -317,10 +531,23 @@ func (s *ServiceImplementation) UpdateListing(ctx context.Context, id uuid.UUID,
 			zap.String("ownerUserID", existingListing.UserID.String()))
 		return nil, common.ErrForbidden.WithDetails("You do not have permission to update this listing.")
 	}
+	if !ifMatchSatisfied(ifMatch, listingETag(existingListing)) {
+		return nil, common.ErrPreconditionFailed.WithDetails("The listing was modified by another request. Fetch it again and retry.")
+	}
+	return existingListing, nil
+}
+
+// applyUpdate applies req to a loaded listing the caller may edit, saves it and returns the reloaded listing.
+// Nil fields in req leave the listing unchanged.
+func (s *ServiceImplementation) applyUpdate(ctx context.Context, existingListing *Listing, req UpdateListingRequest, newImages []*multipart.FileHeader) (*Listing, error) {
+	id := existingListing.ID
 
 	if req.CategoryID != nil && *req.CategoryID != existingListing.CategoryID {
 		return nil, common.ErrBadRequest.WithDetails("Changing the main category of a listing is not allowed. Please create a new listing.")
 	}
+	if err := req.ImageTexts.validate(len(newImages) + len(req.UploadTokens)); err != nil {
+		return nil, err
+	}
 	if req.SubCategoryID != nil {
 		cat, errCat := s.categoryService.GetCategoryByID(ctx, existingListing.CategoryID, true)
 		if errCat != nil {
@@ -345,6 +572,12 @@ func (s *ServiceImplementation) UpdateListing(ctx context.Context, id uuid.UUID,
 		existingListing.SubCategoryID = nil
 	}
 
+	if req.PublishAt != nil {
+		if err := s.reschedule(ctx, existingListing, *req.PublishAt); err != nil {
+			return nil, err
+		}
+	}
+
 	if req.Title != nil {
 		existingListing.Title = *req.Title
 	}
@@ -375,6 +608,22 @@ func (s *ServiceImplementation) UpdateListing(ctx context.Context, id uuid.UUID,
 	if req.ZipCode != nil {
 		existingListing.ZipCode = req.ZipCode
 	}
+	if req.LocationPrivacy != nil {
+		existingListing.LocationPrivacy = *req.LocationPrivacy
+	}
+
+	if req.RemovePrice {
+		existingListing.PriceAmount = nil
+		existingListing.PriceCurrency = nil
+		existingListing.PricePeriod = nil
+	} else if req.Price != nil {
+		req.Price.applyTo(existingListing)
+	}
+	if req.Attributes != nil {
+		if err := s.applyAttributes(ctx, existingListing, req.Attributes, existingListing.Status != StatusDraft); err != nil {
+			return nil, err
+		}
+	}
 
 	locationChanged := false
 	if req.Latitude != nil {
@@ -392,6 +641,11 @@ func (s *ServiceImplementation) UpdateListing(ctx context.Context, id uuid.UUID,
 		existingListing.Latitude = nil
 		existingListing.Longitude = nil
 	}
+	if locationChanged {
+		if err := s.checkServiceArea(existingListing); err != nil {
+			return nil, err
+		}
+	}
 
 	if existingListing.Category.Slug == "" {
 		cat, catErr := s.categoryService.GetCategoryByID(ctx, existingListing.CategoryID, false)
@@ -405,6 +659,14 @@ func (s *ServiceImplementation) UpdateListing(ctx context.Context, id uuid.UUID,
 		existingListing.Category = *cat
 	}
 
+	if req.ClearBusinessHours {
+		existingListing.BusinessHours = nil
+	} else if req.BusinessHours != nil {
+		if err := s.applyBusinessHours(existingListing, existingListing.Category.Slug, req.BusinessHours); err != nil {
+			return nil, err
+		}
+	}
+
 	if existingListing.Category.Slug != "" {
 		switch existingListing.Category.Slug {
 		case "baby-sitting":
@@ -413,6 +675,9 @@ func (s *ServiceImplementation) UpdateListing(ctx context.Context, id uuid.UUID,
 					existingListing.BabysittingDetails = &ListingDetailsBabysitting{ListingID: existingListing.ID}
 				}
 				existingListing.BabysittingDetails.LanguagesSpoken = req.BabysittingDetails.LanguagesSpoken
+				if err := req.BabysittingDetails.applyProfileTo(existingListing.BabysittingDetails, s.cfg.EventsTimezone); err != nil {
+					return nil, err
+				}
 			}
 		case "housing":
 			if req.HousingDetails != nil {
@@ -420,31 +685,21 @@ func (s *ServiceImplementation) UpdateListing(ctx context.Context, id uuid.UUID,
 					existingListing.HousingDetails = &ListingDetailsHousing{ListingID: existingListing.ID}
 				}
 				existingListing.HousingDetails.PropertyType = req.HousingDetails.PropertyType
-				if req.HousingDetails.RentDetails != nil {
-					existingListing.HousingDetails.RentDetails = req.HousingDetails.RentDetails
-				}
+				req.HousingDetails.applyRentTo(existingListing.HousingDetails)
 				if req.HousingDetails.SalePrice != nil {
 					existingListing.HousingDetails.SalePrice = req.HousingDetails.SalePrice
 				}
+				if err := req.HousingDetails.applyAmenitiesTo(existingListing.HousingDetails); err != nil {
+					return nil, err
+				}
 			}
 		case "events":
 			if req.EventDetails != nil {
 				if existingListing.EventDetails == nil {
 					existingListing.EventDetails = &ListingDetailsEvents{ListingID: existingListing.ID}
 				}
-				if req.EventDetails.EventDate != "" {
-					eventDate, errDate := time.Parse("2006-01-02", req.EventDetails.EventDate)
-					if errDate != nil {
-						s.logger.Warn("Invalid event_date format during listing update",
-							zap.String("listingID", id.String()),
-							zap.String("eventDate", req.EventDetails.EventDate),
-							zap.Error(errDate))
-					} else {
-						existingListing.EventDetails.EventDate = eventDate
-					}
-				}
-				if req.EventDetails.EventTime != nil {
-					existingListing.EventDetails.EventTime = req.EventDetails.EventTime
+				if err := req.EventDetails.applyScheduleTo(existingListing.EventDetails, s.cfg.EventsTimezone); err != nil {
+					return nil, err
 				}
 				if req.EventDetails.OrganizerName != nil {
 					existingListing.EventDetails.OrganizerName = req.EventDetails.OrganizerName
@@ -452,18 +707,54 @@ func (s *ServiceImplementation) UpdateListing(ctx context.Context, id uuid.UUID,
 				if req.EventDetails.VenueName != nil {
 					existingListing.EventDetails.VenueName = req.EventDetails.VenueName
 				}
+				if req.EventDetails.Recurrence != nil {
+					if err := existingListing.EventDetails.applyRecurrence(req.EventDetails.Recurrence); err != nil {
+						return nil, err
+					}
+				}
+			}
+			if existingListing.EventDetails != nil {
+				if req.RemoveRecurrence {
+					existingListing.EventDetails.clearRecurrence()
+				} else if until := existingListing.EventDetails.RecurrenceUntil; until != nil && until.Before(existingListing.EventDetails.EventDate) {
+					return nil, common.ErrBadRequest.WithDetails("Recurrence end date must not be before the event date.")
+				}
+			}
+			// Events expire after their event date, so moving the date moves the expiry of a live listing too.
+			if req.EventDetails != nil || req.RemoveRecurrence {
+				switch existingListing.Status {
+				case StatusActive, StatusScheduled, StatusPendingApproval:
+					if end, ok := eventExpiresAt(&existingListing.Category, existingListing, s.eventsLocation()); ok {
+						existingListing.ExpiresAt = end
+					}
+				}
+			}
+		case "jobs":
+			if req.JobDetails != nil {
+				if err := validateSalaryRange(req.JobDetails); err != nil {
+					return nil, err
+				}
+				jobDetails := req.JobDetails.toModel()
+				jobDetails.ListingID = existingListing.ID
+				existingListing.JobDetails = jobDetails
 			}
 		}
 	}
 
+	// Re-check edited text of submitted listings; drafts are checked when published.
+	if (req.Title != nil || req.Description != nil) && existingListing.Status != StatusDraft {
+		s.applyModeration(ctx, existingListing)
+	}
+
 	if existingListing.Status == StatusRejected || existingListing.Status == StatusAdminRemoved {
 		// Business logic for re-approval or state change on edit can be added here.
 	}
 
-	// Handle image deletions
+	// Handle image deletions. The files are only deleted once the update is stored, so a failed
+	// update leaves the listing's images intact.
+	var removedImages []ListingImage
 	if len(req.RemoveImageIDs) > 0 {
 		imagesToKeep := []ListingImage{}
-		imagePathsToDelete := []string{}
 		for _, img := range existingListing.Images {
 			shouldRemove := false
 			for _, removeID := range req.RemoveImageIDs {
@@ -473,28 +764,20 @@ func (s *ServiceImplementation) UpdateListing(ctx context.Context, id uuid.UUID,
 				}
 			}
 			if shouldRemove {
-				imagePathsToDelete = append(imagePathsToDelete, img.ImagePath)
+				removedImages = append(removedImages, img)
 			} else {
 				imagesToKeep = append(imagesToKeep, img)
 			}
 		}
 		existingListing.Images = imagesToKeep
-
-		// Actual deletion from DB will be handled by GORM's association update if configured,
-		// or needs explicit delete calls. For files, we delete them now.
-		for _, path := range imagePathsToDelete {
-			if err := s.fileStorageService.DeleteFile(path); err != nil {
-				s.logger.Error("Failed to delete image file during update", zap.String("path", path), zap.Error(err))
-				// Continue with other operations, but log the error.
-			}
-		}
 		// Note: The repository's Update method needs to correctly handle the removal of ListingImage records
 		// from the database when existingListing.Images slice is updated. This might involve GORM's
 		// full replacement of associations or specific logic in the repo.
 	}
 
 	// Handle new image uploads
-	if len(newImages) > 0 {
+	var addedImages []ListingImage
+	if len(newImages) > 0 || len(req.UploadTokens) > 0 {
 		// Determine the current max sort order to append new images correctly
 		currentMaxSortOrder := -1
 		for _, img := range existingListing.Images {
@@ -503,31 +786,26 @@ func (s *ServiceImplementation) UpdateListing(ctx context.Context, id uuid.UUID,
 			}
 		}
 
-		for _, imageFile := range newImages {
-			relativePath, errFile := s.fileStorageService.SaveUploadedFile(imageFile, "listings")
-			if errFile != nil {
-				s.logger.Error("Failed to save new uploaded image during update", zap.Error(errFile), zap.String("filename", imageFile.Filename))
-				return nil, common.ErrBadRequest.WithDetails(fmt.Sprintf("Failed to save new image %s: %s", imageFile.Filename, errFile.Error()))
-			}
-			currentMaxSortOrder++
-			newListingImage := ListingImage{
-				ListingID: existingListing.ID, // Ensure ListingID is set
-				ImagePath: relativePath,
-				SortOrder: currentMaxSortOrder,
-			}
-			existingListing.Images = append(existingListing.Images, newListingImage)
+		var errSave error
+		addedImages, errSave = s.addImages(existingListing.UserID, newImages, req.UploadTokens, req.ImageTexts, existingListing, currentMaxSortOrder+1)
+		if errSave != nil {
+			return nil, errSave
 		}
+		existingListing.Images = append(existingListing.Images, addedImages...)
 	}
 
+	applyQualityScore(existingListing, existingListing.Category.Slug)
+
 	// The s.repo.Update method needs to be robust enough to handle updates to existing ListingImage entries (e.g. SortOrder changes if implemented)
 	// and creation of new ListingImage entries, and deletion of ones removed from existingListing.Images.
 	// This typically involves GORM's `Session(&gorm.Session{FullSaveAssociations: true})` or specific association handling in the repo.
 	if err := s.repo.Update(ctx, existingListing); err != nil {
 		s.logger.Error("Failed to update listing in repository", zap.Error(err), zap.String("listingID", id.String()))
-		// If files were saved but DB update failed, they need to be rolled back (deleted). This is complex.
-		// For now, we rely on the overall operation failing.
+		s.discardImages(addedImages)
 		return nil, err
 	}
+	s.discardImages(removedImages)
+	s.queueImageScans(ctx, addedImages)
 
 	updatedListing, err := s.repo.FindByID(ctx, existingListing.ID, true)
 	if err != nil {
@@ -587,28 +865,81 @@ func (s *ServiceImplementation) DeleteListing(ctx context.Context, id uuid.UUID,
 
 // SearchListings performs a search for listings based on various criteria.
 func (s *ServiceImplementation) SearchListings(ctx context.Context, query ListingSearchQuery, authenticatedUserID *uuid.UUID) ([]Listing, *common.Pagination, error) {
-	if query.MaxDistanceKM == nil {
-		maxDistConfig, err := s.getPlatformConfigInt("MAX_LISTING_DISTANCE_KM")
-		if err == nil && maxDistConfig > 0 {
-			floatMaxDist := float64(maxDistConfig)
-			query.MaxDistanceKM = &floatMaxDist
-		} else if err != nil {
-			s.logger.Warn("Could not parse MAX_LISTING_DISTANCE_KM, not applying default distance filter", zap.Error(err))
-		}
+	if err := s.prepareSearchQuery(ctx, &query); err != nil {
+		return nil, nil, err
 	}
 
 	if query.Latitude != nil && query.Longitude != nil && query.SortBy == "" {
 		query.SortBy = "distance"
 	}
+	if err := s.checkSearchCost(&query); err != nil {
+		return nil, nil, err
+	}
 
 	listings, pagination, err := s.repo.Search(ctx, query)
 	if err != nil {
 		s.logger.Error("Failed to search listings", zap.Error(err))
 		return nil, nil, common.ErrInternalServer.WithDetails("Could not retrieve listings.")
 	}
+	if query.SearchTerm != "" {
+		terms := query.SearchVariants
+		if len(terms) == 0 {
+			terms = []string{query.SearchTerm}
+		}
+		applyHighlights(listings, terms)
+	}
 	return listings, pagination, nil
 }
 
+// prepareSearchQuery validates and resolves the search filters shared by SearchListings and GetMapClusters:
+// the parsed bounding box, attribute filters, place name spellings and the default search radius.
+func (s *ServiceImplementation) prepareSearchQuery(ctx context.Context, query *ListingSearchQuery) error {
+	if query.BBox != "" {
+		bbox, err := geo.ParseBoundingBox(query.BBox)
+		if err != nil {
+			return common.ErrBadRequest.WithDetails("Invalid bbox: " + err.Error())
+		}
+		query.BoundingBox = bbox
+	}
+	if query.Polygon != "" {
+		if err := geo.ValidateGeoJSONPolygon(query.Polygon); err != nil {
+			return common.ErrBadRequest.WithDetails("Invalid polygon: " + err.Error())
+		}
+	}
+	if err := ValidatePriceRange(*query); err != nil {
+		return err
+	}
+	if query.SearchTerm != "" && s.placeNames != nil {
+		query.SearchVariants = s.placeNames.Expand(query.SearchTerm)
+	}
+	if query.HasAttributeFilters() {
+		if query.CategoryID == nil || *query.CategoryID == "" {
+			return common.ErrBadRequest.WithDetails("Attribute filters require category_id.")
+		}
+		categoryID, err := uuid.Parse(*query.CategoryID)
+		if err != nil {
+			return common.ErrBadRequest.WithDetails("Invalid category_id format.")
+		}
+		query.AttributeFilters, err = s.categoryService.BuildAttributeFilters(ctx, categoryID, query.Attributes, query.AttributesMin, query.AttributesMax)
+		if err != nil {
+			return err
+		}
+	}
+
+	if query.MaxDistanceKM == nil {
+		maxDistConfig, err := s.appConfig.GetInt(ctx, appconfig.KeyMaxListingDistanceKM)
+		if err != nil {
+			s.logger.Warn("Could not read MAX_LISTING_DISTANCE_KM from app_configurations, using default from .env", zap.Error(err))
+			maxDistConfig = s.cfg.MaxListingDistanceKM
+		}
+		if maxDistConfig > 0 {
+			floatMaxDist := float64(maxDistConfig)
+			query.MaxDistanceKM = &floatMaxDist
+		}
+	}
+	return nil
+}
+
 // GetUserListings retrieves listings for a specific user.
 func (s *ServiceImplementation) GetUserListings(ctx context.Context, userID uuid.UUID, query UserListingsQuery) ([]Listing, *common.Pagination, error) {
 	// Set IncludeExpired to true by default for user's own listings
@@ -631,7 +962,14 @@ func (s *ServiceImplementation) GetUserListings(ctx context.Context, userID uuid
 }
 
 // AdminUpdateListingStatus handles admin updates to a listing's status.
-func (s *ServiceImplementation) AdminUpdateListingStatus(ctx context.Context, id uuid.UUID, newStatus ListingStatus, adminNotes *string) (*Listing, error) {
+// The notes and rejection reason are kept on the listing for its owner; a reason is only stored for rejections.
+func (s *ServiceImplementation) AdminUpdateListingStatus(ctx context.Context, id uuid.UUID, newStatus ListingStatus, adminNotes *string, rejectionReason *RejectionReason) (*Listing, error) {
+	if newStatus != StatusRejected {
+		rejectionReason = nil
+	} else if rejectionReason == nil {
+		return nil, common.ErrBadRequest.WithDetails("A rejection reason is required when rejecting a listing.")
+	}
+
 	listingBeforeUpdate, err := s.repo.FindByID(ctx, id, true) // Preload associations
 	if err != nil {
 		s.logger.Warn("AdminUpdateListingStatus: Listing not found before update", zap.String("listingID", id.String()), zap.Error(err))
@@ -639,6 +977,9 @@ func (s *ServiceImplementation) AdminUpdateListingStatus(ctx context.Context, id
 	}
 	originalStatus := listingBeforeUpdate.Status
 	originalIsAdminApproved := listingBeforeUpdate.IsAdminApproved
+	if newStatus == StatusActive && listingBeforeUpdate.PublishAt != nil && listingBeforeUpdate.PublishAt.After(time.Now()) {
+		newStatus = StatusScheduled // Approved before its publish time; the scheduler makes it live
+	}
 
 	userWasUpdated := false
 	if newStatus == StatusActive && originalStatus == StatusPendingApproval && listingBeforeUpdate.User != nil && !listingBeforeUpdate.User.IsFirstPostApproved {
@@ -661,13 +1002,13 @@ func (s *ServiceImplementation) AdminUpdateListingStatus(ctx context.Context, id
 	}
 
 	// Update listing status
-	if err := s.repo.UpdateStatus(ctx, id, newStatus, adminNotes); err != nil {
+	if err := s.repo.UpdateStatus(ctx, id, newStatus, adminNotes, rejectionReason); err != nil {
 		s.logger.Error("Failed to admin update listing status in repo", zap.Error(err), zap.String("listingID", id.String()))
 		return nil, err
 	}
 
-	// If status is now Active, ensure IsAdminApproved is true
-	if newStatus == StatusActive {
+	// If status is now Active (or scheduled to be), ensure IsAdminApproved is true
+	if newStatus == StatusActive || newStatus == StatusScheduled {
 		// Fetch the listing again to get the result of UpdateStatus
 		tempListingForApprovalUpdate, findErr := s.repo.FindByID(ctx, id, false) // No need to preload here
 		if findErr == nil {
@@ -683,17 +1024,16 @@ func (s *ServiceImplementation) AdminUpdateListingStatus(ctx context.Context, id
 		}
 	}
 
-
 	updatedListing, err := s.repo.FindByID(ctx, id, true)
 	if err != nil {
 		s.logger.Error("AdminUpdateListingStatus: Failed to reload listing after update", zap.String("listingID", id.String()), zap.Error(err))
 		return nil, err
 	}
 
-	if s.notificationService != nil &&
-		(originalStatus == StatusPendingApproval || !originalIsAdminApproved) &&
-		updatedListing.Status == StatusActive && updatedListing.IsAdminApproved {
+	becameApproved := (originalStatus == StatusPendingApproval || !originalIsAdminApproved) &&
+		updatedListing.Status == StatusActive && updatedListing.IsAdminApproved
 
+	if s.notificationService != nil && becameApproved {
 		notifType := notification.ListingApprovedLive
 		notifMessage := fmt.Sprintf("Great news! Your listing '%s' has been approved and is now live.", updatedListing.Title)
 
@@ -706,64 +1046,437 @@ func (s *ServiceImplementation) AdminUpdateListingStatus(ctx context.Context, id
 			)
 		}
 	}
+	if becameApproved {
+		s.emitListingEvent(ctx, webhook.EventListingApproved, updatedListing)
+	}
+	if originalStatus != StatusRejected && updatedListing.Status == StatusRejected {
+		s.notifyListingRejected(ctx, updatedListing)
+	}
 
 	s.logger.Info("Admin updated listing status", zap.String("listingID", id.String()), zap.String("newStatus", string(newStatus)), zap.Bool("userFirstPostApprovedUpdated", userWasUpdated))
 	return updatedListing, nil
 }
 
+// AdminUpdateListing lets an admin edit any listing regardless of ownership. The changed fields are recorded
+// in the audit log and, when req.NotifyOwner is set, listed in a notification to the owner.
+func (s *ServiceImplementation) AdminUpdateListing(ctx context.Context, id uuid.UUID, adminID uuid.UUID, req AdminUpdateListingRequest) (*Listing, error) {
+	existingListing, err := s.repo.FindByID(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if !ifMatchSatisfied(req.IfMatch, listingETag(existingListing)) {
+		return nil, common.ErrPreconditionFailed.WithDetails("The listing was modified by another request. Fetch it again and retry.")
+	}
+	before, err := listingDocumentFields(existingListing)
+	if err != nil {
+		s.logger.Error("AdminUpdateListing: Failed to describe listing before edit", zap.String("listingID", id.String()), zap.Error(err))
+		return nil, common.ErrInternalServer
+	}
+	imagesBefore := len(existingListing.Images)
+	allowedBefore := existingListing.OutsideServiceAreaAllowed
+	if req.AllowOutsideServiceArea != nil {
+		existingListing.OutsideServiceAreaAllowed = *req.AllowOutsideServiceArea
+	}
+
+	updatedListing, err := s.applyUpdate(ctx, existingListing, req.UpdateListingRequest, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := listingDocumentFields(updatedListing)
+	if err != nil {
+		// The edit is saved; only the change description is lost.
+		s.logger.Error("AdminUpdateListing: Failed to describe listing after edit", zap.String("listingID", id.String()), zap.Error(err))
+		return updatedListing, nil
+	}
+	changes := diffListingDocuments(before, after)
+	if imagesAfter := len(updatedListing.Images); imagesAfter != imagesBefore {
+		changes["image_count"] = audit.FieldChange{From: imagesBefore, To: imagesAfter}
+	}
+	if allowedAfter := updatedListing.OutsideServiceAreaAllowed; allowedAfter != allowedBefore {
+		changes["outside_service_area_allowed"] = audit.FieldChange{From: allowedBefore, To: allowedAfter}
+	}
+	if len(changes) == 0 {
+		return updatedListing, nil
+	}
+
+	if s.auditService != nil {
+		// Record logs its own failures; the edit itself has already been saved.
+		_ = s.auditService.Record(ctx, audit.Event{
+			ActorID:    &adminID,
+			Action:     audit.ActionListingAdminEdit,
+			EntityType: audit.EntityListing,
+			EntityID:   id.String(),
+			Changes:    changes,
+			Note:       req.Note,
+		})
+	}
+	if req.NotifyOwner && s.notificationService != nil {
+		message := adminEditMessage(updatedListing.Title, changes, req.Note)
+		if _, err := s.notificationService.CreateNotification(ctx, updatedListing.UserID, notification.ListingEditedByAdmin, message, &updatedListing.ID); err != nil {
+			s.logger.Error("Failed to send listing edited notification",
+				zap.Error(err),
+				zap.String("listingID", updatedListing.ID.String()),
+				zap.String("userID", updatedListing.UserID.String()),
+			)
+		}
+	}
+
+	s.logger.Info("Admin edited listing",
+		zap.String("listingID", id.String()),
+		zap.String("adminID", adminID.String()),
+		zap.Int("changedFields", len(changes)),
+	)
+	return updatedListing, nil
+}
+
+// adminEditMessage tells a listing's owner which fields an admin changed, e.g. "title, contact email".
+func adminEditMessage(title string, changes map[string]audit.FieldChange, note *string) string {
+	fields := make([]string, 0, len(changes))
+	for key := range changes {
+		fields = append(fields, strings.ReplaceAll(key, "_", " "))
+	}
+	sort.Strings(fields)
+	message := fmt.Sprintf("An administrator updated your listing '%s'. Changed: %s.", title, strings.Join(fields, ", "))
+	if note != nil && strings.TrimSpace(*note) != "" {
+		message += " Note: " + strings.TrimSpace(*note)
+	}
+	return message
+}
 
 // AdminApproveListing approves a listing.
 func (s *ServiceImplementation) AdminApproveListing(ctx context.Context, id uuid.UUID) (*Listing, error) {
-	return s.AdminUpdateListingStatus(ctx, id, StatusActive, nil)
+	return s.AdminUpdateListingStatus(ctx, id, StatusActive, nil, nil)
 }
 
-// ExpireListings finds and marks overdue listings as expired.
+// expiryBatchSize is how many listings ExpireListings expires per statement.
+const expiryBatchSize = 500
+
+// ExpireListings marks overdue listings as expired, expiryBatchSize at a time, and emits a listing.expired event
+// for each. Listings are expired and flagged for their event in the same statement, and the flag is cleared once
+// the events are emitted, so a run that is interrupted loses no events: the next run emits them first. An event
+// may then be emitted twice, if the run stopped between emitting it and clearing the flag.
 func (s *ServiceImplementation) ExpireListings(ctx context.Context) (int, error) {
-	now := time.Now()
-	expiredListings, err := s.repo.FindExpiredListings(ctx, now)
+	resumed, err := s.emitPendingExpiryEvents(ctx)
 	if err != nil {
-		s.logger.Error("Failed to find expired listings", zap.Error(err))
 		return 0, err
 	}
+	if resumed > 0 {
+		s.logger.Info("Emitted expiry events left over by an interrupted run", zap.Int("listings", resumed))
+	}
 
+	now := time.Now()
 	count := 0
-	for _, listing := range expiredListings {
-		listing.Status = StatusExpired
-		if err := s.repo.UpdateStatus(ctx, listing.ID, StatusExpired, nil); err != nil {
-			s.logger.Error("Failed to update listing to expired", zap.Error(err), zap.String("listingID", listing.ID.String()))
-		} else {
-			s.logger.Info("Listing expired and status updated", zap.String("listingID", listing.ID.String()))
-			count++
+	for {
+		ids, err := s.repo.ExpireBatch(ctx, now, expiryBatchSize)
+		if err != nil {
+			s.logger.Error("Failed to expire listings", zap.Error(err), zap.Int("expired_so_far", count))
+			return count, err
+		}
+		count += len(ids)
+		if _, err := s.emitPendingExpiryEvents(ctx); err != nil {
+			return count, err
+		}
+		if len(ids) < expiryBatchSize {
+			break
 		}
 	}
-	s.logger.Info("Listing expiry job completed", zap.Int("expired_count", count), zap.Int("found_to_expire", len(expiredListings)))
+	s.logger.Info("Listing expiry job completed", zap.Int("expired_count", count))
 	return count, nil
 }
 
-func (s *ServiceImplementation) getPlatformConfigDate(key string) (*time.Time, error) {
-	if key == "FIRST_POST_APPROVAL_MODEL_ACTIVE_UNTIL" {
-		activeMonths := s.cfg.FirstPostApprovalActiveMonths
-		if activeMonths > 0 {
-			val := time.Now().AddDate(0, activeMonths, 0)
-			return &val, nil
+// emitPendingExpiryEvents emits the listing.expired events that are still pending and returns how many it emitted.
+func (s *ServiceImplementation) emitPendingExpiryEvents(ctx context.Context) (int, error) {
+	emitted := 0
+	for {
+		listings, err := s.repo.FindPendingExpiryEvents(ctx, expiryBatchSize)
+		if err != nil {
+			s.logger.Error("Failed to find pending expiry events", zap.Error(err))
+			return emitted, err
+		}
+		if len(listings) == 0 {
+			return emitted, nil
+		}
+		ids := make([]uuid.UUID, len(listings))
+		for i := range listings {
+			s.emitListingEvent(ctx, webhook.EventListingExpired, &listings[i])
+			ids[i] = listings[i].ID
+		}
+		if err := s.repo.ClearPendingExpiryEvents(ctx, ids); err != nil {
+			s.logger.Error("Failed to clear pending expiry events", zap.Error(err))
+			return emitted, err
+		}
+		emitted += len(listings)
+	}
+}
+
+// PublishListing validates a draft and makes it live (or sends it for approval under the first-post model).
+func (s *ServiceImplementation) PublishListing(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*Listing, error) {
+	draft, err := s.repo.FindByID(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if draft.UserID != userID {
+		s.logger.Warn("User attempted to publish a listing they do not own",
+			zap.String("listingID", id.String()),
+			zap.String("userID", userID.String()))
+		return nil, common.ErrForbidden.WithDetails("You do not have permission to publish this listing.")
+	}
+	if draft.Status != StatusDraft {
+		return nil, common.ErrConflict.WithDetails("Only draft listings can be published.")
+	}
+
+	cat, err := s.categoryService.GetCategoryByID(ctx, draft.CategoryID, true)
+	if err != nil {
+		s.logger.Error("Failed to load category for draft publish", zap.Error(err), zap.String("listingID", id.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not verify listing category.")
+	}
+	if err := validateRequiredDetails(cat, draft); err != nil {
+		return nil, err
+	}
+	if err := s.applyAttributes(ctx, draft, draft.Attributes, true); err != nil {
+		return nil, err
+	}
+
+	draft.Status, draft.IsAdminApproved, err = s.resolvePublishStatus(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.applyModeration(ctx, draft)
+	s.applySpamScore(ctx, draft)
+	if draft.PublishAt != nil && !draft.PublishAt.After(time.Now()) {
+		draft.PublishAt = nil // A schedule saved with the draft has passed; publish now
+	}
+	applySchedule(draft, time.Now())
+	draft.ExpiresAt = s.computeExpiresAt(ctx, cat, draft, goLiveAt(draft, time.Now()))
+	if err := s.repo.Publish(ctx, draft); err != nil {
+		s.logger.Error("Failed to publish draft listing", zap.Error(err), zap.String("listingID", id.String()))
+		return nil, err
+	}
+
+	published, err := s.repo.FindByID(ctx, id, true)
+	if err != nil {
+		s.logger.Error("Failed to reload published listing", zap.Error(err), zap.String("listingID", id.String()))
+		return nil, err
+	}
+
+	s.logger.Info("Draft listing published", zap.String("listingID", id.String()), zap.String("status", string(published.Status)))
+	s.recordFirstListing(ctx, userID)
+	if published.Status != StatusScheduled {
+		s.notifyListingSubmitted(ctx, published)
+		s.emitListingEvent(ctx, webhook.EventListingCreated, published)
+	}
+	return published, nil
+}
+
+// applyAttributes validates attribute values against the listing category's schema and stores the
+// normalized values on l. Required attributes are only enforced when requireAll is set.
+func (s *ServiceImplementation) applyAttributes(ctx context.Context, l *Listing, values map[string]interface{}, requireAll bool) error {
+	normalized, err := s.categoryService.ValidateListingAttributes(ctx, l.CategoryID, values, requireAll)
+	if err != nil {
+		return err
+	}
+	l.Attributes = normalized
+	return nil
+}
+
+// validateRequiredDetails enforces the per-category requirements a listing must meet before it goes public.
+func validateRequiredDetails(cat *category.Category, l *Listing) error {
+	if cat.Name == "Businesses" && (l.SubCategoryID == nil || *l.SubCategoryID == uuid.Nil) {
+		return common.ErrBadRequest.WithDetails("Subcategory is required for 'Business' listings.")
+	}
+
+	switch cat.Slug {
+	case "baby-sitting":
+		if l.BabysittingDetails == nil || len(l.BabysittingDetails.LanguagesSpoken) == 0 {
+			return common.ErrBadRequest.WithDetails("Languages spoken are required for Baby Sitting listings.")
+		}
+	case "housing":
+		if l.HousingDetails == nil {
+			return common.ErrBadRequest.WithDetails("Housing details (property type) are required for Housing listings.")
+		}
+		if l.HousingDetails.PropertyType == HousingForRent && (l.HousingDetails.RentDetails == nil || *l.HousingDetails.RentDetails == "") {
+			return common.ErrBadRequest.WithDetails("Rent (rent_amount, or rent_details) is required for 'Property for Rent' housing listings.")
+		}
+		if l.HousingDetails.PropertyType == HousingForSale && (l.HousingDetails.SalePrice == nil || *l.HousingDetails.SalePrice <= 0) {
+			return common.ErrBadRequest.WithDetails("A valid sale price is required for 'Property for Sale' housing listings.")
+		}
+	case "events":
+		if l.EventDetails == nil {
+			return common.ErrBadRequest.WithDetails("Event details (date) are required for Event listings.")
+		}
+	case "jobs":
+		if l.JobDetails == nil {
+			return common.ErrBadRequest.WithDetails("Job details (employment type and workplace type) are required for Jobs listings.")
+		}
+	}
+	return nil
+}
+
+// validateSalaryRange rejects a job salary range whose minimum exceeds its maximum.
+func validateSalaryRange(req *CreateListingJobDetailsRequest) error {
+	if req.SalaryMin != nil && req.SalaryMax != nil && *req.SalaryMin > *req.SalaryMax {
+		return common.ErrBadRequest.WithDetails("salary_min must not be greater than salary_max.")
+	}
+	return nil
+}
+
+// resolvePublishStatus applies the first-post approval model to decide the status of a listing going public.
+func (s *ServiceImplementation) resolvePublishStatus(ctx context.Context, userID uuid.UUID) (ListingStatus, bool, error) {
+	postingUser, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("User not found when creating listing", zap.String("userID", userID.String()), zap.Error(err))
+		return "", false, common.ErrInternalServer.WithDetails("Could not retrieve user details.")
+	}
+
+	firstPostModelActiveUntil, err := s.appConfig.GetDate(ctx, appconfig.KeyFirstPostApprovalModelActiveUntil)
+	isFirstPostModelActive := false
+	if err == nil && time.Now().Before(firstPostModelActiveUntil) {
+		isFirstPostModelActive = true
+	} else if err != nil {
+		s.logger.Warn("Could not parse FIRST_POST_APPROVAL_MODEL_ACTIVE_UNTIL, assuming model is not active", zap.Error(err))
+	}
+
+	if isFirstPostModelActive && !postingUser.IsFirstPostApproved {
+		userPostCount, err := s.repo.CountListingsByUserID(ctx, userID)
+		if err != nil {
+			s.logger.Error("Failed to count user listings for first post check", zap.Error(err), zap.String("userID", userID.String()))
+			return "", false, common.ErrInternalServer.WithDetails("Could not verify posting eligibility.")
+		}
+
+		if userPostCount > 0 {
+			s.logger.Warn("User attempting to submit multiple posts before first approval", zap.String("userID", userID.String()))
+			return "", false, common.ErrForbidden.WithDetails("You must wait for your first post to be approved before submitting another.")
 		}
+		s.logger.Info("First post by user, marking for admin approval", zap.String("userID", userID.String()))
+		return StatusPendingApproval, false, nil
+	}
+	return StatusActive, true, nil
+}
+
+// applyModeration runs the listing's text through the moderation pipeline. Flagged listings are
+// sent back to pending_approval with the reasons recorded for admin review.
+func (s *ServiceImplementation) applyModeration(ctx context.Context, l *Listing) {
+	if s.moderator == nil {
+		return
+	}
+	result, err := s.moderator.Moderate(ctx, l.Title+"\n"+l.Description)
+	if err != nil {
+		s.logger.Error("Content moderation failed", zap.Error(err), zap.String("listingID", l.ID.String()))
+		return
+	}
+	if !result.Flagged {
+		return
+	}
+
+	s.logger.Info("Listing flagged by content moderation",
+		zap.String("listingID", l.ID.String()),
+		zap.Strings("reasons", result.Reasons))
+	l.Status = StatusPendingApproval
+	l.IsAdminApproved = false
+	l.ModerationFlags = result.Reasons
+}
+
+// notifyListingSubmitted tells the owner whether their newly submitted listing is live or pending review.
+func (s *ServiceImplementation) notifyListingSubmitted(ctx context.Context, l *Listing) {
+	if s.notificationService == nil {
+		return
+	}
+
+	var notifType notification.NotificationType
+	var notifMessage string
+	if l.Status == StatusPendingApproval || !l.IsAdminApproved {
+		notifType = notification.ListingCreatedPendingApproval
+		notifMessage = fmt.Sprintf("Your listing '%s' has been submitted and is pending review.", l.Title)
+	} else {
+		notifType = notification.ListingCreatedLive
+		notifMessage = fmt.Sprintf("Your listing '%s' has been successfully created and is now live!", l.Title)
+	}
+
+	if _, err := s.notificationService.CreateNotification(ctx, l.UserID, notifType, notifMessage, &l.ID); err != nil {
+		s.logger.Error("Failed to send listing creation notification",
+			zap.Error(err),
+			zap.String("listingID", l.ID.String()),
+			zap.String("userID", l.UserID.String()),
+		)
+	}
+}
+
+// recordFirstListing completes the owner's "first listing posted" onboarding step, if it was not already.
+func (s *ServiceImplementation) recordFirstListing(ctx context.Context, userID uuid.UUID) {
+	if s.userRepo == nil {
+		return
+	}
+	if err := s.userRepo.CompleteOnboardingStep(ctx, userID, shared.OnboardingFirstListingPosted); err != nil {
+		s.logger.Warn("Failed to record onboarding step", zap.String("userID", userID.String()), zap.Error(err))
+	}
+}
+
+// notifyListingRejected tells the owner why their listing was declined.
+func (s *ServiceImplementation) notifyListingRejected(ctx context.Context, l *Listing) {
+	if s.notificationService == nil {
+		return
+	}
+	if _, err := s.notificationService.CreateNotification(ctx, l.UserID, notification.ListingRejected, rejectionMessage(l), &l.ID); err != nil {
+		s.logger.Error("Failed to send listing rejected notification",
+			zap.Error(err),
+			zap.String("listingID", l.ID.String()),
+			zap.String("userID", l.UserID.String()),
+		)
+	}
+}
+
+// rejectionMessage explains a rejection to the listing's owner, including the admin's notes when there are any.
+func rejectionMessage(l *Listing) string {
+	reason := RejectionOther
+	if l.RejectionReason != nil {
+		reason = *l.RejectionReason
+	}
+	message := fmt.Sprintf("Your listing '%s' was not approved because %s.", l.Title, reason.Description())
+	if l.AdminNotes != nil && strings.TrimSpace(*l.AdminNotes) != "" {
+		message += " Note from our team: " + strings.TrimSpace(*l.AdminNotes)
 	}
-	return nil, errors.New("config key not found or not a date: " + key)
+	return message
 }
 
-func (s *ServiceImplementation) getPlatformConfigInt(key string) (int, error) {
-	if key == "DEFAULT_LISTING_LIFESPAN_DAYS" {
-		return s.cfg.DefaultListingLifespanDays, nil
+// ValidatePriceRange rejects negative or inverted min_price/max_price filters and negative housing filters.
+func ValidatePriceRange(query ListingSearchQuery) error {
+	if (query.MinPrice != nil && *query.MinPrice < 0) || (query.MaxPrice != nil && *query.MaxPrice < 0) {
+		return common.ErrBadRequest.WithDetails("min_price and max_price must not be negative.")
+	}
+	if query.MinPrice != nil && query.MaxPrice != nil && *query.MinPrice > *query.MaxPrice {
+		return common.ErrBadRequest.WithDetails("min_price must not be greater than max_price.")
 	}
-	if key == "MAX_LISTING_DISTANCE_KM" {
-		return s.cfg.MaxListingDistanceKM, nil
+	if query.Currency != "" && len(query.Currency) != 3 {
+		return common.ErrBadRequest.WithDetails("currency must be a 3-letter ISO 4217 code.")
 	}
-	return 0, errors.New("config key not found or not an int: " + key)
+	if (query.MinBedrooms != nil && *query.MinBedrooms < 0) || (query.MaxRent != nil && *query.MaxRent < 0) {
+		return common.ErrBadRequest.WithDetails("min_bedrooms and max_rent must not be negative.")
+	}
+	if query.MaxHourlyRate != nil && *query.MaxHourlyRate < 0 {
+		return common.ErrBadRequest.WithDetails("max_hourly_rate must not be negative.")
+	}
+	return validateAvailabilityWindow(query)
+}
+
+// emitListingEvent enqueues a webhook delivery describing l for every endpoint subscribed to event.
+func (s *ServiceImplementation) emitListingEvent(ctx context.Context, event string, l *Listing) {
+	if s.webhookService == nil {
+		return
+	}
+	s.webhookService.Dispatch(ctx, event, map[string]interface{}{
+		"listing_id":  l.ID,
+		"user_id":     l.UserID,
+		"category_id": l.CategoryID,
+		"title":       l.Title,
+		"status":      l.Status,
+		"expires_at":  l.ExpiresAt,
+	})
 }
 
-// GetRecentListings retrieves recent non-event listings.
-func (s *ServiceImplementation) GetRecentListings(ctx context.Context, page, pageSize int) ([]ListingResponse, *common.Pagination, error) {
-	listings, pagination, err := s.repo.GetRecentListings(ctx, page, pageSize, nil)
+// GetRecentListings retrieves recent non-event listings, loading only the associations in includes.
+func (s *ServiceImplementation) GetRecentListings(ctx context.Context, page, pageSize int, includes Includes) ([]ListingResponse, *common.Pagination, error) {
+	listings, pagination, err := s.repo.GetRecentListings(ctx, page, pageSize, "", nil, includes)
 	if err != nil {
 		s.logger.Error("Failed to get recent listings from repository", zap.Error(err))
 		return nil, nil, common.ErrInternalServer.WithDetails("Could not retrieve recent listings.")
@@ -771,16 +1484,35 @@ func (s *ServiceImplementation) GetRecentListings(ctx context.Context, page, pag
 
 	listingResponses := make([]ListingResponse, len(listings))
 	for i, l := range listings {
-		// Pass h.cfg.ImagePublicBaseURL for image URL construction
-		listingResponses[i] = ToListingResponse(&l, false, s.cfg.ImagePublicBaseURL)
+		listingResponses[i] = ToListingResponse(&l, s.imageURLs)
 	}
 
 	return listingResponses, pagination, nil
 }
 
-// GetUpcomingEvents retrieves upcoming event listings.
-func (s *ServiceImplementation) GetUpcomingEvents(ctx context.Context, page, pageSize int) ([]ListingResponse, *common.Pagination, error) {
-	listings, pagination, err := s.repo.GetUpcomingEvents(ctx, page, pageSize)
+// maxFeedItems caps how many listings the recent listings feed includes.
+const maxFeedItems = 50
+
+// GetRecentListingsFeed returns the newest listings for the RSS feed, optionally limited to a category slug.
+// It uses the same query as GetRecentListings.
+func (s *ServiceImplementation) GetRecentListingsFeed(ctx context.Context, categorySlug string) ([]Listing, error) {
+	if categorySlug != "" {
+		if _, err := s.categoryService.GetCategoryBySlug(ctx, categorySlug, false); err != nil {
+			return nil, err
+		}
+	}
+
+	listings, _, err := s.repo.GetRecentListings(ctx, 1, maxFeedItems, categorySlug, nil, nil)
+	if err != nil {
+		s.logger.Error("Failed to get recent listings for feed", zap.String("categorySlug", categorySlug), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve recent listings.")
+	}
+	return listings, nil
+}
+
+// GetUpcomingEvents retrieves upcoming event listings, loading only the associations in includes.
+func (s *ServiceImplementation) GetUpcomingEvents(ctx context.Context, page, pageSize int, includes Includes) ([]ListingResponse, *common.Pagination, error) {
+	listings, pagination, err := s.repo.GetUpcomingEvents(ctx, page, pageSize, includes)
 	if err != nil {
 		s.logger.Error("Failed to get upcoming events from repository", zap.Error(err))
 		return nil, nil, common.ErrInternalServer.WithDetails("Could not retrieve upcoming events.")
@@ -788,8 +1520,33 @@ func (s *ServiceImplementation) GetUpcomingEvents(ctx context.Context, page, pag
 
 	listingResponses := make([]ListingResponse, len(listings))
 	for i, l := range listings {
-		listingResponses[i] = ToListingResponse(&l, false, s.cfg.ImagePublicBaseURL)
+		listingResponses[i] = ToListingResponse(&l, s.imageURLs)
 	}
 
 	return listingResponses, pagination, nil
 }
+
+// maxCalendarEvents caps how many upcoming events the .ics feed includes.
+const maxCalendarEvents = 500
+
+// GetEventsCalendar returns the upcoming active, approved events as an iCalendar feed and the time it was rendered.
+// The feed is cached in memory for cfg.EventsCalendarCacheTTL.
+func (s *ServiceImplementation) GetEventsCalendar(ctx context.Context) ([]byte, time.Time, error) {
+	s.calendarMu.Lock()
+	defer s.calendarMu.Unlock()
+
+	if s.calendarICS != nil && time.Since(s.calendarBuiltAt) < s.cfg.EventsCalendarCacheTTL {
+		return s.calendarICS, s.calendarBuiltAt, nil
+	}
+
+	listings, _, err := s.repo.GetUpcomingEvents(ctx, 1, maxCalendarEvents, nil)
+	if err != nil {
+		s.logger.Error("Failed to get upcoming events for calendar feed", zap.Error(err))
+		return nil, time.Time{}, common.ErrInternalServer.WithDetails("Could not build the events calendar.")
+	}
+
+	builtAt := time.Now()
+	s.calendarICS = renderEventsCalendar(listings, s.eventsLocation(), builtAt)
+	s.calendarBuiltAt = builtAt
+	return s.calendarICS, s.calendarBuiltAt, nil
+}
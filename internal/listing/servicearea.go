@@ -0,0 +1,18 @@
+// File: internal/listing/servicearea.go
+package listing
+
+import (
+	"seattle_info_backend/internal/common"
+)
+
+// checkServiceArea rejects coordinates outside the configured service area, unless an admin has allowed them for
+// this listing. Listings without coordinates pass.
+func (s *ServiceImplementation) checkServiceArea(l *Listing) error {
+	if l.Latitude == nil || l.Longitude == nil || l.OutsideServiceAreaAllowed {
+		return nil
+	}
+	if !s.serviceArea.Contains(*l.Latitude, *l.Longitude) {
+		return common.ErrBadRequest.WithDetails("The listing's location is outside the area this site serves. Check the latitude and longitude, or contact support if the listing belongs here.")
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package listing
+
+import (
+	"testing"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/platform/geo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckServiceArea(t *testing.T) {
+	area, err := geo.NewServiceArea("", 47.6062, -122.3321, 80)
+	require.NoError(t, err)
+	svc := &ServiceImplementation{serviceArea: area}
+	lat, lon := 47.6062, -122.3321
+	portlandLat, portlandLon := 45.5152, -122.6784
+
+	assert.NoError(t, svc.checkServiceArea(&Listing{}), "listings without coordinates pass")
+	assert.NoError(t, svc.checkServiceArea(&Listing{Latitude: &lat, Longitude: &lon}))
+	assert.ErrorIs(t, svc.checkServiceArea(&Listing{Latitude: &portlandLat, Longitude: &portlandLon}), common.ErrBadRequest)
+	assert.NoError(t, svc.checkServiceArea(&Listing{Latitude: &portlandLat, Longitude: &portlandLon, OutsideServiceAreaAllowed: true}), "admin override")
+
+	unrestricted := &ServiceImplementation{}
+	assert.NoError(t, unrestricted.checkServiceArea(&Listing{Latitude: &portlandLat, Longitude: &portlandLon}), "no service area configured")
+}
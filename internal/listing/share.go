@@ -0,0 +1,56 @@
+// File: internal/listing/share.go
+package listing
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	shareSiteName          = "Seattle Info"
+	shareDescriptionLength = 200
+	// shareMaxAge is how long clients and proxies may reuse the share metadata of an active listing.
+	shareMaxAge = 5 * time.Minute
+)
+
+// ShareMetadataResponse describes how a listing is previewed when a link to it is shared.
+// OpenGraph and Twitter map meta tag names to their content, ready to be rendered as
+// <meta property="og:..."> and <meta name="twitter:..."> tags.
+type ShareMetadataResponse struct {
+	Title        string            `json:"title"`
+	Description  string            `json:"description"`
+	ImageURL     *string           `json:"image_url,omitempty"`
+	CanonicalURL string            `json:"canonical_url"`
+	OpenGraph    map[string]string `json:"open_graph"`
+	Twitter      map[string]string `json:"twitter"`
+}
+
+// toShareMetadata builds the share preview of a listing. canonicalURL is the listing's web page and
+// imageURL the absolute URL of its first image, or empty when it has none.
+func toShareMetadata(l *Listing, canonicalURL, imageURL string) ShareMetadataResponse {
+	description := truncateRunes(strings.Join(strings.Fields(l.Description), " "), shareDescriptionLength)
+	resp := ShareMetadataResponse{
+		Title:        l.Title,
+		Description:  description,
+		CanonicalURL: canonicalURL,
+		OpenGraph: map[string]string{
+			"og:type":        "website",
+			"og:site_name":   shareSiteName,
+			"og:title":       l.Title,
+			"og:description": description,
+			"og:url":         canonicalURL,
+		},
+		Twitter: map[string]string{
+			"twitter:card":        "summary",
+			"twitter:title":       l.Title,
+			"twitter:description": description,
+		},
+	}
+	if imageURL != "" {
+		resp.ImageURL = &imageURL
+		resp.OpenGraph["og:image"] = imageURL
+		resp.Twitter["twitter:card"] = "summary_large_image"
+		resp.Twitter["twitter:image"] = imageURL
+	}
+	return resp
+}
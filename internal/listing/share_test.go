@@ -0,0 +1,30 @@
+package listing
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToShareMetadata(t *testing.T) {
+	l := &Listing{Title: "Vintage Armchair", Description: "Comfortable   vintage armchair,\n\ngood condition. " + strings.Repeat("Pick up in Ballard. ", 20)}
+
+	meta := toShareMetadata(l, "https://seattleinfo.example.com/listings/vintage-armchair-3f2a9c1e", "https://cdn.example.com/static/listings/chair.jpg")
+	assert.Equal(t, "Vintage Armchair", meta.Title)
+	assert.True(t, strings.HasPrefix(meta.Description, "Comfortable vintage armchair, good condition. Pick up"), "whitespace is collapsed")
+	assert.True(t, strings.HasSuffix(meta.Description, "…"))
+	assert.LessOrEqual(t, utf8.RuneCountInString(meta.Description), shareDescriptionLength+1)
+	require.NotNil(t, meta.ImageURL)
+	assert.Equal(t, "https://cdn.example.com/static/listings/chair.jpg", meta.OpenGraph["og:image"])
+	assert.Equal(t, meta.CanonicalURL, meta.OpenGraph["og:url"])
+	assert.Equal(t, "summary_large_image", meta.Twitter["twitter:card"])
+
+	meta = toShareMetadata(&Listing{Title: "Free couch", Description: "Curb alert"}, "https://seattleinfo.example.com/listings/free-couch-3f2a9c1e", "")
+	assert.Nil(t, meta.ImageURL)
+	assert.Equal(t, "Curb alert", meta.Description)
+	assert.NotContains(t, meta.OpenGraph, "og:image")
+	assert.Equal(t, "summary", meta.Twitter["twitter:card"])
+}
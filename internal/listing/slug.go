@@ -0,0 +1,35 @@
+// File: internal/listing/slug.go
+package listing
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gosimple/slug"
+)
+
+// maxSlugTitleLength caps the part of a listing slug taken from its title.
+const maxSlugTitleLength = 60
+
+// slugIDLengths are the lengths of the ID suffix tried, shortest first, when a listing's slug is assigned.
+// The last one is the whole ID, so a slug is always found.
+var slugIDLengths = []int{8, 12, 16, 32}
+
+// slugCandidates returns the slugs a listing may have, e.g. "2br-apartment-in-ballard-3f2a9c1e", in order
+// of preference. Each is the title made URL-safe followed by a prefix of the listing's ID.
+func slugCandidates(title string, id uuid.UUID) []string {
+	base := slug.Make(title)
+	if len(base) > maxSlugTitleLength {
+		base = strings.TrimRight(base[:maxSlugTitleLength], "-")
+	}
+	hexID := strings.ReplaceAll(id.String(), "-", "")
+	candidates := make([]string, len(slugIDLengths))
+	for i, n := range slugIDLengths {
+		if base == "" {
+			candidates[i] = hexID[:n]
+		} else {
+			candidates[i] = base + "-" + hexID[:n]
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,30 @@
+package listing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugCandidates(t *testing.T) {
+	id := uuid.MustParse("3f2a9c1e-7b4d-4e0a-9c55-0123456789ab")
+
+	candidates := slugCandidates("2BR Apartment in Ballard!", id)
+	assert.Equal(t, []string{
+		"2br-apartment-in-ballard-3f2a9c1e",
+		"2br-apartment-in-ballard-3f2a9c1e7b4d",
+		"2br-apartment-in-ballard-3f2a9c1e7b4d4e0a",
+		"2br-apartment-in-ballard-3f2a9c1e7b4d4e0a9c550123456789ab",
+	}, candidates)
+
+	assert.Equal(t, "3f2a9c1e", slugCandidates("!!!", id)[0], "a title without usable characters leaves just the ID")
+	assert.Equal(t, "pho-tai-3f2a9c1e", slugCandidates("Phở tái", id)[0])
+
+	long := slugCandidates(strings.Repeat("garage sale ", 20), id)
+	for _, candidate := range long {
+		assert.LessOrEqual(t, len(candidate), 100, "slugs fit the column")
+		assert.NotContains(t, candidate, "--")
+	}
+}
@@ -0,0 +1,57 @@
+// File: internal/listing/spam.go
+package listing
+
+import (
+	"context"
+
+	"seattle_info_backend/internal/antispam"
+	"seattle_info_backend/internal/platform/clientinfo"
+
+	"go.uber.org/zap"
+)
+
+// applySpamScore scores a listing being published and holds it for admin review when the score
+// reaches the threshold. The rules that fired are added to its moderation flags for the reviewer.
+// Scoring failures are logged and never block posting.
+func (s *ServiceImplementation) applySpamScore(ctx context.Context, l *Listing) {
+	if s.spamScorer == nil {
+		return
+	}
+
+	sub := antispam.Submission{
+		ListingID: l.ID,
+		UserID:    l.UserID,
+		IP:        clientinfo.FromContext(ctx).IP,
+		Text:      l.Title + "\n" + l.Description,
+	}
+	if sub.IP == "" && l.CreatedIP != nil {
+		sub.IP = *l.CreatedIP
+	}
+	if l.ContactEmail != nil {
+		sub.ContactEmail = *l.ContactEmail
+	}
+	if l.ContactPhone != nil {
+		sub.ContactPhone = *l.ContactPhone
+	}
+	if owner, err := s.userRepo.FindByID(ctx, l.UserID); err == nil && owner.Email != nil {
+		sub.UserEmail = *owner.Email
+	}
+
+	result, err := s.spamScorer.Score(ctx, sub)
+	if err != nil {
+		s.logger.Error("Spam scoring failed", zap.Error(err), zap.String("listingID", l.ID.String()))
+		return
+	}
+	l.SpamScore = result.Score
+	if !result.Flagged {
+		return
+	}
+
+	s.logger.Info("Listing held for review by spam scoring",
+		zap.String("listingID", l.ID.String()),
+		zap.Int("score", result.Score),
+		zap.Strings("rules", result.Reasons()))
+	l.Status = StatusPendingApproval
+	l.IsAdminApproved = false
+	l.ModerationFlags = append(l.ModerationFlags, result.Reasons()...)
+}
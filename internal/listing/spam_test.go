@@ -0,0 +1,81 @@
+package listing
+
+import (
+	"context"
+	"testing"
+
+	"seattle_info_backend/internal/antispam"
+	"seattle_info_backend/internal/platform/clientinfo"
+	"seattle_info_backend/internal/user"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type stubUserRepo struct {
+	user.Repository
+	email string
+}
+
+func (r stubUserRepo) FindByID(context.Context, uuid.UUID) (*user.User, error) {
+	return &user.User{Email: &r.email}, nil
+}
+
+type recordingScorer struct {
+	result *antispam.Result
+	got    antispam.Submission
+}
+
+func (s *recordingScorer) Score(_ context.Context, sub antispam.Submission) (*antispam.Result, error) {
+	s.got = sub
+	return s.result, nil
+}
+
+func TestApplySpamScoreHoldsFlaggedListings(t *testing.T) {
+	scorer := &recordingScorer{result: &antispam.Result{
+		Score:   80,
+		Hits:    []antispam.Hit{{Rule: antispam.RuleUserVelocity}, {Rule: antispam.RuleLinks}},
+		Flagged: true,
+	}}
+	svc := &ServiceImplementation{userRepo: stubUserRepo{email: "jo@example.com"}, spamScorer: scorer, logger: zap.NewNop()}
+	phone := "206-555-0100"
+	l := &Listing{Title: "Bike", Description: "Cheap", ContactPhone: &phone, Status: StatusActive, IsAdminApproved: true,
+		ModerationFlags: pq.StringArray{"blocked_word:scam"}}
+	ctx := clientinfo.WithInfo(context.Background(), clientinfo.Info{IP: "203.0.113.7"})
+
+	svc.applySpamScore(ctx, l)
+
+	assert.Equal(t, "jo@example.com", scorer.got.UserEmail)
+	assert.Equal(t, "203.0.113.7", scorer.got.IP)
+	assert.Equal(t, "206-555-0100", scorer.got.ContactPhone)
+	assert.Equal(t, "Bike\nCheap", scorer.got.Text)
+	assert.Equal(t, 80, l.SpamScore)
+	assert.Equal(t, StatusPendingApproval, l.Status)
+	assert.False(t, l.IsAdminApproved)
+	assert.Equal(t, pq.StringArray{"blocked_word:scam", "spam:velocity_user", "spam:links"}, l.ModerationFlags)
+}
+
+func TestApplySpamScoreRecordsScoreBelowThreshold(t *testing.T) {
+	scorer := &recordingScorer{result: &antispam.Result{Score: 30, Hits: []antispam.Hit{{Rule: antispam.RuleDisposableEmail}}}}
+	svc := &ServiceImplementation{userRepo: stubUserRepo{}, spamScorer: scorer, logger: zap.NewNop()}
+	createdIP := "198.51.100.4"
+	l := &Listing{Status: StatusActive, IsAdminApproved: true, CreatedIP: &createdIP}
+
+	svc.applySpamScore(context.Background(), l)
+
+	assert.Equal(t, "198.51.100.4", scorer.got.IP, "falls back to the address the listing was created from")
+	assert.Equal(t, 30, l.SpamScore)
+	assert.Equal(t, StatusActive, l.Status)
+	assert.Empty(t, l.ModerationFlags)
+}
+
+func TestModerationFlagsAreOwnerOnly(t *testing.T) {
+	l := patchTestListing()
+	l.User = &user.User{}
+	l.ModerationFlags = pq.StringArray{"blocked_word:scam", "spam:links"}
+
+	assert.Nil(t, ToListingResponse(l, nil).ModerationFlags)
+	assert.Equal(t, []string{"blocked_word:scam", "spam:links"}, ToOwnerListingResponse(l, nil).ModerationFlags)
+}
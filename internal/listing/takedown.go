@@ -0,0 +1,390 @@
+// File: internal/listing/takedown.go
+package listing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/audit"
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/notification"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// TakedownReason is why an admin removed a listing.
+type TakedownReason string
+
+const (
+	TakedownDMCA TakedownReason = "dmca" // A copyright holder's DMCA notice
+	TakedownScam TakedownReason = "scam"
+	TakedownTOS  TakedownReason = "tos" // Any other violation of the terms of service
+)
+
+// takedownReasonDescriptions are the explanations sent to owners in takedown notifications.
+var takedownReasonDescriptions = map[TakedownReason]string{
+	TakedownDMCA: "we received a copyright (DMCA) notice about it",
+	TakedownScam: "it appears to be a scam",
+	TakedownTOS:  "it violates our terms of service",
+}
+
+// Description returns the owner-facing explanation of the reason.
+func (r TakedownReason) Description() string {
+	if d, ok := takedownReasonDescriptions[r]; ok {
+		return d
+	}
+	return takedownReasonDescriptions[TakedownTOS]
+}
+
+// TakedownStatus is where a takedown is in the appeal workflow.
+type TakedownStatus string
+
+const (
+	TakedownActive   TakedownStatus = "active"   // The listing is removed and the owner has not appealed
+	TakedownAppealed TakedownStatus = "appealed" // The owner's appeal waits for an admin
+	TakedownUpheld   TakedownStatus = "upheld"   // The appeal was denied; the listing stays removed
+	TakedownReversed TakedownStatus = "reversed" // The appeal was granted and the listing reinstated
+)
+
+// Decisions on an appeal.
+const (
+	AppealReinstate = "reinstate"
+	AppealUphold    = "uphold"
+)
+
+// Takedown records an admin's removal of a listing and the owner's appeal against it.
+// A listing has at most one takedown that is active or appealed.
+type Takedown struct {
+	common.BaseModel
+	ListingID      uuid.UUID      `gorm:"type:uuid;not null"`
+	AdminID        *uuid.UUID     `gorm:"type:uuid"`
+	Reason         TakedownReason `gorm:"type:varchar(20);not null"`
+	Note           *string        `gorm:"type:text"`
+	PreviousStatus ListingStatus  `gorm:"type:varchar(50);not null"` // Restored when an appeal is granted
+	Status         TakedownStatus `gorm:"type:varchar(20);not null;default:'active'"`
+	AppealMessage  *string        `gorm:"type:text"`
+	AppealedAt     *time.Time
+	ResolvedBy     *uuid.UUID `gorm:"type:uuid"`
+	ResolvedAt     *time.Time
+	ResolutionNote *string `gorm:"type:text"`
+}
+
+// TableName specifies the table name for GORM.
+func (Takedown) TableName() string {
+	return "listing_takedowns"
+}
+
+// TakedownRequest is the payload of POST /admin/listings/:id/takedown.
+type TakedownRequest struct {
+	Reason TakedownReason `json:"reason" binding:"required,oneof=dmca scam tos"`
+	Note   *string        `json:"note,omitempty" binding:"omitempty,max=2000"` // Sent to the owner
+}
+
+// AppealRequest is the payload of POST /listings/:id/appeal.
+type AppealRequest struct {
+	Message string `json:"message" binding:"required,min=10,max=4000"`
+}
+
+// ResolveAppealRequest is the payload of POST /admin/appeals/:id/resolve.
+type ResolveAppealRequest struct {
+	Decision string  `json:"decision" binding:"required,oneof=reinstate uphold"`
+	Note     *string `json:"note,omitempty" binding:"omitempty,max=2000"` // Sent to the owner
+}
+
+// AppealListQuery filters GET /admin/appeals.
+type AppealListQuery struct {
+	Status TakedownStatus `form:"status" binding:"omitempty,oneof=active appealed upheld reversed"` // Defaults to appealed
+}
+
+// TakedownResponse is the API representation of a takedown. The admins involved are recorded in the audit log.
+type TakedownResponse struct {
+	ID             uuid.UUID        `json:"id"`
+	ListingID      uuid.UUID        `json:"listing_id"`
+	Reason         TakedownReason   `json:"reason"`
+	Note           *string          `json:"note,omitempty"`
+	PreviousStatus ListingStatus    `json:"previous_status"`
+	Status         TakedownStatus   `json:"status"`
+	AppealMessage  *string          `json:"appeal_message,omitempty"`
+	AppealedAt     *time.Time       `json:"appealed_at,omitempty"`
+	ResolvedAt     *time.Time       `json:"resolved_at,omitempty"`
+	ResolutionNote *string          `json:"resolution_note,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	Listing        *ListingResponse `json:"listing,omitempty"` // Admin list only
+}
+
+// ToTakedownResponse converts a Takedown model to a TakedownResponse DTO.
+func ToTakedownResponse(t *Takedown) TakedownResponse {
+	return TakedownResponse{
+		ID:             t.ID,
+		ListingID:      t.ListingID,
+		Reason:         t.Reason,
+		Note:           t.Note,
+		PreviousStatus: t.PreviousStatus,
+		Status:         t.Status,
+		AppealMessage:  t.AppealMessage,
+		AppealedAt:     t.AppealedAt,
+		ResolvedAt:     t.ResolvedAt,
+		ResolutionNote: t.ResolutionNote,
+		CreatedAt:      t.CreatedAt,
+	}
+}
+
+// TakeDownListing removes a listing for a stated reason and tells the owner how to appeal. The removal and its
+// audit entry are saved together.
+func (s *ServiceImplementation) TakeDownListing(ctx context.Context, id uuid.UUID, adminID uuid.UUID, req TakedownRequest) (*Takedown, error) {
+	l, err := s.repo.FindByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if l.Status == StatusAdminRemoved {
+		return nil, common.ErrConflict.WithDetails("The listing has already been removed.")
+	}
+
+	takedown := &Takedown{
+		ListingID:      l.ID,
+		AdminID:        &adminID,
+		Reason:         req.Reason,
+		Note:           trimmedOrNil(req.Note),
+		PreviousStatus: l.Status,
+		Status:         TakedownActive,
+	}
+	entry, err := audit.NewEntry(audit.Event{
+		ActorID:    &adminID,
+		Action:     audit.ActionListingTakedown,
+		EntityType: audit.EntityListing,
+		EntityID:   l.ID.String(),
+		Changes: map[string]audit.FieldChange{
+			"status":          {From: l.Status, To: StatusAdminRemoved},
+			"takedown_reason": {From: nil, To: req.Reason},
+		},
+		Note: takedown.Note,
+	})
+	if err != nil {
+		s.logger.Error("Failed to encode takedown audit entry", zap.String("listingID", id.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not remove listing.")
+	}
+	if err := s.repo.CreateTakedown(ctx, takedown, entry); err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to take down listing", zap.String("listingID", id.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not remove listing.")
+	}
+	l.Status = StatusAdminRemoved
+
+	s.notifyOwner(ctx, l, notification.ListingTakenDown, s.takedownMessage(l, takedown))
+	s.logger.Info("Listing taken down",
+		zap.String("listingID", id.String()),
+		zap.String("adminID", adminID.String()),
+		zap.String("reason", string(req.Reason)))
+	return takedown, nil
+}
+
+// AppealTakedown records the owner's appeal against the active takedown of their listing. Each takedown can be
+// appealed once.
+func (s *ServiceImplementation) AppealTakedown(ctx context.Context, listingID uuid.UUID, userID uuid.UUID, req AppealRequest) (*Takedown, error) {
+	l, err := s.repo.FindByID(ctx, listingID, false)
+	if err != nil {
+		return nil, err
+	}
+	if l.UserID != userID {
+		return nil, common.ErrForbidden.WithDetails("You do not have permission to appeal for this listing.")
+	}
+	takedown, err := s.repo.FindLatestTakedown(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+	switch takedown.Status {
+	case TakedownAppealed:
+		return nil, common.ErrConflict.WithDetails("This removal has already been appealed. We will notify you of the decision.")
+	case TakedownUpheld, TakedownReversed:
+		return nil, common.ErrConflict.WithDetails("The appeal against this removal has already been decided.")
+	}
+
+	now := time.Now()
+	message := strings.TrimSpace(req.Message)
+	takedown.Status = TakedownAppealed
+	takedown.AppealMessage = &message
+	takedown.AppealedAt = &now
+	entry, err := audit.NewEntry(audit.Event{
+		ActorID:    &userID,
+		Action:     audit.ActionListingAppeal,
+		EntityType: audit.EntityListing,
+		EntityID:   listingID.String(),
+		Changes:    map[string]audit.FieldChange{"takedown_status": {From: TakedownActive, To: TakedownAppealed}},
+	})
+	if err != nil {
+		s.logger.Error("Failed to encode appeal audit entry", zap.String("listingID", listingID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not record appeal.")
+	}
+	if err := s.repo.UpdateTakedown(ctx, takedown, TakedownActive, nil, entry); err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to record appeal", zap.String("listingID", listingID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not record appeal.")
+	}
+
+	s.logger.Info("Listing takedown appealed", zap.String("listingID", listingID.String()), zap.String("takedownID", takedown.ID.String()))
+	return takedown, nil
+}
+
+// ListAppeals returns a page of takedowns in the given status, appealed by default, oldest appeal first, each with
+// its listing.
+func (s *ServiceImplementation) ListAppeals(ctx context.Context, query AppealListQuery, page, pageSize int) ([]TakedownResponse, *common.Pagination, error) {
+	status := query.Status
+	if status == "" {
+		status = TakedownAppealed
+	}
+	takedowns, pagination, err := s.repo.FindTakedownsByStatus(ctx, status, page, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to list appeals", zap.String("status", string(status)), zap.Error(err))
+		return nil, nil, common.ErrInternalServer.WithDetails("Could not retrieve appeals.")
+	}
+
+	ids := make([]uuid.UUID, 0, len(takedowns))
+	for _, t := range takedowns {
+		ids = append(ids, t.ListingID)
+	}
+	listings, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("Failed to load listings of appeals", zap.Error(err))
+		return nil, nil, common.ErrInternalServer.WithDetails("Could not retrieve appeals.")
+	}
+	byID := make(map[uuid.UUID]*Listing, len(listings))
+	for i := range listings {
+		byID[listings[i].ID] = &listings[i]
+	}
+
+	responses := make([]TakedownResponse, 0, len(takedowns))
+	for i := range takedowns {
+		response := ToTakedownResponse(&takedowns[i])
+		if l, ok := byID[takedowns[i].ListingID]; ok {
+			listingResponse := ToListingResponse(l, s.imageURLs)
+			response.Listing = &listingResponse
+		}
+		responses = append(responses, response)
+	}
+	return responses, pagination, nil
+}
+
+// ResolveAppeal decides an open appeal: reinstate restores the listing to its status before the takedown
+// (expired if its lifespan ran out meanwhile), uphold keeps it removed. The owner is notified either way.
+func (s *ServiceImplementation) ResolveAppeal(ctx context.Context, takedownID uuid.UUID, adminID uuid.UUID, req ResolveAppealRequest) (*Takedown, error) {
+	takedown, err := s.repo.FindTakedownByID(ctx, takedownID)
+	if err != nil {
+		return nil, err
+	}
+	if takedown.Status != TakedownAppealed {
+		return nil, common.ErrConflict.WithDetails(fmt.Sprintf("Only appealed takedowns can be resolved; this one is %s.", takedown.Status))
+	}
+	l, err := s.repo.FindByID(ctx, takedown.ListingID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	changes := map[string]audit.FieldChange{}
+	var restoreStatus *ListingStatus
+	if req.Decision == AppealReinstate {
+		status := takedown.PreviousStatus
+		if status == StatusActive && l.ExpiresAt.Before(now) {
+			status = StatusExpired
+		}
+		restoreStatus = &status
+		takedown.Status = TakedownReversed
+		changes["status"] = audit.FieldChange{From: l.Status, To: status}
+	} else {
+		takedown.Status = TakedownUpheld
+	}
+	changes["takedown_status"] = audit.FieldChange{From: TakedownAppealed, To: takedown.Status}
+	takedown.ResolvedBy = &adminID
+	takedown.ResolvedAt = &now
+	takedown.ResolutionNote = trimmedOrNil(req.Note)
+
+	entry, err := audit.NewEntry(audit.Event{
+		ActorID:    &adminID,
+		Action:     audit.ActionListingAppealResolve,
+		EntityType: audit.EntityListing,
+		EntityID:   l.ID.String(),
+		Changes:    changes,
+		Note:       takedown.ResolutionNote,
+	})
+	if err != nil {
+		s.logger.Error("Failed to encode appeal resolution audit entry", zap.String("takedownID", takedownID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not resolve appeal.")
+	}
+	if err := s.repo.UpdateTakedown(ctx, takedown, TakedownAppealed, restoreStatus, entry); err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to resolve appeal", zap.String("takedownID", takedownID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not resolve appeal.")
+	}
+
+	s.notifyOwner(ctx, l, notification.ListingAppealResolved, appealResolutionMessage(l, takedown))
+	s.logger.Info("Listing appeal resolved",
+		zap.String("takedownID", takedownID.String()),
+		zap.String("listingID", l.ID.String()),
+		zap.String("adminID", adminID.String()),
+		zap.String("decision", req.Decision))
+	return takedown, nil
+}
+
+// notifyOwner sends a notification about the listing to its owner. Failures are logged; the change is already saved.
+func (s *ServiceImplementation) notifyOwner(ctx context.Context, l *Listing, notificationType notification.NotificationType, message string) {
+	if s.notificationService == nil {
+		return
+	}
+	if _, err := s.notificationService.CreateNotification(ctx, l.UserID, notificationType, message, &l.ID); err != nil {
+		s.logger.Error("Failed to send listing notification",
+			zap.Error(err),
+			zap.String("type", string(notificationType)),
+			zap.String("listingID", l.ID.String()),
+			zap.String("userID", l.UserID.String()),
+		)
+	}
+}
+
+// takedownMessage explains a takedown to the listing's owner and links to the appeal page of the web app.
+func (s *ServiceImplementation) takedownMessage(l *Listing, t *Takedown) string {
+	message := fmt.Sprintf("Your listing '%s' was removed because %s.", l.Title, t.Reason.Description())
+	if t.Note != nil {
+		message += " Note from our team: " + *t.Note
+	}
+	if webBaseURL := strings.TrimSuffix(s.cfg.WebBaseURL, "/"); webBaseURL != "" {
+		message += fmt.Sprintf(" If you believe this is a mistake, you can appeal: %s/listings/%s/appeal", webBaseURL, l.Slug)
+	} else {
+		message += " If you believe this is a mistake, you can appeal from the listing's page."
+	}
+	return message
+}
+
+// appealResolutionMessage tells the owner the decision on their appeal.
+func appealResolutionMessage(l *Listing, t *Takedown) string {
+	var message string
+	if t.Status == TakedownReversed {
+		message = fmt.Sprintf("Your appeal was accepted and your listing '%s' has been reinstated.", l.Title)
+	} else {
+		message = fmt.Sprintf("Your appeal was reviewed and your listing '%s' will remain removed.", l.Title)
+	}
+	if t.ResolutionNote != nil {
+		message += " Note from our team: " + *t.ResolutionNote
+	}
+	return message
+}
+
+// trimmedOrNil returns s without surrounding whitespace, or nil when nothing is left.
+func trimmedOrNil(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
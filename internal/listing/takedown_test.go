@@ -0,0 +1,165 @@
+package listing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/audit"
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/notification"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// takedownRepository keeps one listing and its takedowns in memory.
+type takedownRepository struct {
+	Repository
+	listing   Listing
+	takedowns []*Takedown
+	entries   []*audit.Entry
+}
+
+func (r *takedownRepository) FindByID(_ context.Context, id uuid.UUID, _ bool) (*Listing, error) {
+	if id != r.listing.ID {
+		return nil, common.ErrNotFound.WithDetails("Listing not found.")
+	}
+	l := r.listing
+	return &l, nil
+}
+
+func (r *takedownRepository) CreateTakedown(_ context.Context, takedown *Takedown, entry *audit.Entry) error {
+	takedown.ID = uuid.New()
+	r.takedowns = append(r.takedowns, takedown)
+	r.listing.Status = StatusAdminRemoved
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *takedownRepository) UpdateTakedown(_ context.Context, takedown *Takedown, _ TakedownStatus, listingStatus *ListingStatus, entry *audit.Entry) error {
+	if listingStatus != nil {
+		r.listing.Status = *listingStatus
+	}
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *takedownRepository) FindLatestTakedown(_ context.Context, listingID uuid.UUID) (*Takedown, error) {
+	for i := len(r.takedowns) - 1; i >= 0; i-- {
+		if r.takedowns[i].ListingID == listingID {
+			return r.takedowns[i], nil
+		}
+	}
+	return nil, common.ErrNotFound.WithDetails("This listing has not been removed by an admin.")
+}
+
+func (r *takedownRepository) FindTakedownByID(_ context.Context, id uuid.UUID) (*Takedown, error) {
+	for _, t := range r.takedowns {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return nil, common.ErrNotFound.WithDetails("Takedown not found.")
+}
+
+// sentNotifications records the notifications sent to owners.
+type sentNotifications struct {
+	notification.Service
+	types    []notification.NotificationType
+	messages []string
+}
+
+func (n *sentNotifications) CreateNotification(_ context.Context, _ uuid.UUID, notificationType notification.NotificationType, message string, _ *uuid.UUID) (*notification.Notification, error) {
+	n.types = append(n.types, notificationType)
+	n.messages = append(n.messages, message)
+	return &notification.Notification{}, nil
+}
+
+func TestTakedownAppealFlow(t *testing.T) {
+	owner, admin := uuid.New(), uuid.New()
+	repo := &takedownRepository{listing: Listing{UserID: owner, Title: "Bike", Slug: "bike-1", Status: StatusActive, ExpiresAt: time.Now().Add(24 * time.Hour)}}
+	repo.listing.ID = uuid.New()
+	notifications := &sentNotifications{}
+	svc := &ServiceImplementation{
+		repo:                repo,
+		notificationService: notifications,
+		cfg:                 &config.Config{WebBaseURL: "https://seattle.example/"},
+		logger:              zap.NewNop(),
+	}
+	ctx := context.Background()
+
+	takedown, err := svc.TakeDownListing(ctx, repo.listing.ID, admin, TakedownRequest{Reason: TakedownScam})
+	require.NoError(t, err)
+	assert.Equal(t, StatusAdminRemoved, repo.listing.Status)
+	assert.Equal(t, StatusActive, takedown.PreviousStatus)
+	require.Len(t, notifications.messages, 1)
+	assert.Equal(t, notification.ListingTakenDown, notifications.types[0])
+	assert.Contains(t, notifications.messages[0], "https://seattle.example/listings/bike-1/appeal")
+
+	_, err = svc.TakeDownListing(ctx, repo.listing.ID, admin, TakedownRequest{Reason: TakedownTOS})
+	assert.ErrorIs(t, err, common.ErrConflict, "a removed listing cannot be taken down again")
+
+	_, err = svc.AppealTakedown(ctx, repo.listing.ID, uuid.New(), AppealRequest{Message: "This is my listing, not yours."})
+	assert.ErrorIs(t, err, common.ErrForbidden, "only the owner can appeal")
+
+	appealed, err := svc.AppealTakedown(ctx, repo.listing.ID, owner, AppealRequest{Message: "  The bike is real, see the receipt.  "})
+	require.NoError(t, err)
+	assert.Equal(t, TakedownAppealed, appealed.Status)
+	assert.Equal(t, "The bike is real, see the receipt.", *appealed.AppealMessage)
+
+	_, err = svc.AppealTakedown(ctx, repo.listing.ID, owner, AppealRequest{Message: "Please look again at it."})
+	assert.ErrorIs(t, err, common.ErrConflict, "a takedown is appealed once")
+
+	resolved, err := svc.ResolveAppeal(ctx, takedown.ID, admin, ResolveAppealRequest{Decision: AppealReinstate})
+	require.NoError(t, err)
+	assert.Equal(t, TakedownReversed, resolved.Status)
+	assert.Equal(t, StatusActive, repo.listing.Status, "the listing gets its status back")
+	assert.Equal(t, notification.ListingAppealResolved, notifications.types[1])
+
+	_, err = svc.ResolveAppeal(ctx, takedown.ID, admin, ResolveAppealRequest{Decision: AppealUphold})
+	assert.ErrorIs(t, err, common.ErrConflict, "a decided appeal cannot be resolved again")
+
+	actions := make([]string, 0, len(repo.entries))
+	for _, entry := range repo.entries {
+		actions = append(actions, entry.Action)
+	}
+	assert.Equal(t, []string{audit.ActionListingTakedown, audit.ActionListingAppeal, audit.ActionListingAppealResolve}, actions)
+}
+
+func TestResolveAppealExpiresListingsPastTheirLifespan(t *testing.T) {
+	owner := uuid.New()
+	repo := &takedownRepository{listing: Listing{UserID: owner, Status: StatusAdminRemoved, ExpiresAt: time.Now().Add(-time.Hour)}}
+	repo.listing.ID = uuid.New()
+	now := time.Now()
+	takedown := &Takedown{ListingID: repo.listing.ID, PreviousStatus: StatusActive, Status: TakedownAppealed, AppealedAt: &now}
+	takedown.ID = uuid.New()
+	repo.takedowns = []*Takedown{takedown}
+	svc := &ServiceImplementation{repo: repo, cfg: &config.Config{}, logger: zap.NewNop()}
+
+	_, err := svc.ResolveAppeal(context.Background(), takedown.ID, uuid.New(), ResolveAppealRequest{Decision: AppealReinstate})
+	require.NoError(t, err)
+	assert.Equal(t, StatusExpired, repo.listing.Status)
+}
+
+func TestTakenDownListingIsHiddenFromNonOwners(t *testing.T) {
+	owner, other := uuid.New(), uuid.New()
+	repo := &takedownRepository{listing: Listing{UserID: owner, Title: "Bike", Slug: "bike-1", Status: StatusActive, ExpiresAt: time.Now().Add(24 * time.Hour)}}
+	repo.listing.ID = uuid.New()
+	svc := &ServiceImplementation{repo: repo, notificationService: &sentNotifications{}, cfg: &config.Config{}, logger: zap.NewNop()}
+	ctx := context.Background()
+
+	_, err := svc.TakeDownListing(ctx, repo.listing.ID, uuid.New(), TakedownRequest{Reason: TakedownDMCA})
+	require.NoError(t, err)
+
+	_, err = svc.GetListingByID(ctx, repo.listing.ID, nil)
+	assert.ErrorIs(t, err, common.ErrNotFound)
+	_, err = svc.GetListingByID(ctx, repo.listing.ID, &other)
+	assert.ErrorIs(t, err, common.ErrNotFound)
+	l, err := svc.GetListingByID(ctx, repo.listing.ID, &owner)
+	require.NoError(t, err, "the owner still sees it, to appeal")
+	assert.Equal(t, StatusAdminRemoved, l.Status)
+}
@@ -0,0 +1,150 @@
+// File: internal/listing/trending.go
+package listing
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// trendingWindow is how far back views count towards the trending score.
+	trendingWindow = 7 * 24 * time.Hour
+	// maxTrendingListings caps the size of the cached ranking.
+	maxTrendingListings = 100
+	// defaultTrendingHalfLife is used when TRENDING_HALF_LIFE_HOURS is not positive.
+	defaultTrendingHalfLife = 48 * time.Hour
+)
+
+// RecordListingView counts a view of the listing's detail page and adds the listing to a signed-in viewer's
+// recently viewed listings. Views by the owner and of listings that are not active are ignored.
+// Failures are logged and never reach the viewer.
+func (s *ServiceImplementation) RecordListingView(ctx context.Context, l *Listing, viewerID *uuid.UUID) {
+	if l.Status != StatusActive || (viewerID != nil && *viewerID == l.UserID) {
+		return
+	}
+	if err := s.repo.IncrementDailyViews(ctx, l.ID, time.Now().UTC()); err != nil {
+		s.logger.Warn("Failed to record listing view", zap.String("listingID", l.ID.String()), zap.Error(err))
+	}
+	if viewerID != nil {
+		s.recordRecentView(ctx, *viewerID, l.ID)
+	}
+}
+
+// RefreshTrendingListings recomputes the trending ranking from the last week of views and replaces the cached one.
+// It returns the number of ranked listings.
+func (s *ServiceImplementation) RefreshTrendingListings(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	views, err := s.repo.FindActiveDailyViewsSince(ctx, now.Add(-trendingWindow))
+	if err != nil {
+		s.logger.Error("Failed to load listing views for trending ranking", zap.Error(err))
+		return 0, common.ErrInternalServer.WithDetails("Could not compute trending listings.")
+	}
+
+	halfLife := s.cfg.TrendingHalfLife
+	if halfLife <= 0 {
+		halfLife = defaultTrendingHalfLife
+	}
+	ids := rankByScore(trendingScores(views, now, halfLife), maxTrendingListings)
+
+	found, err := s.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("Failed to load trending listings", zap.Error(err))
+		return 0, common.ErrInternalServer.WithDetails("Could not compute trending listings.")
+	}
+	byID := make(map[uuid.UUID]Listing, len(found))
+	for _, l := range found {
+		byID[l.ID] = l
+	}
+	ranked := make([]Listing, 0, len(ids))
+	for _, id := range ids {
+		if l, ok := byID[id]; ok {
+			ranked = append(ranked, l)
+		}
+	}
+
+	s.trendingMu.Lock()
+	s.trendingListings = ranked
+	s.trendingBuiltAt = now
+	s.trendingMu.Unlock()
+	return len(ranked), nil
+}
+
+// GetTrendingListings returns a page of the cached trending ranking, most popular first.
+// The ranking is computed on the first request if the trending job has not run yet.
+// Listings that expired since the last refresh are skipped.
+func (s *ServiceImplementation) GetTrendingListings(ctx context.Context, page, pageSize int) ([]ListingResponse, *common.Pagination, error) {
+	s.trendingMu.RLock()
+	built := !s.trendingBuiltAt.IsZero()
+	s.trendingMu.RUnlock()
+	if !built {
+		if _, err := s.RefreshTrendingListings(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	s.trendingMu.RLock()
+	cached := s.trendingListings
+	s.trendingMu.RUnlock()
+
+	now := time.Now()
+	current := make([]Listing, 0, len(cached))
+	for _, l := range cached {
+		if l.ExpiresAt.After(now) {
+			current = append(current, l)
+		}
+	}
+
+	pagination := common.NewPagination(int64(len(current)), page, pageSize)
+	start := (pagination.CurrentPage - 1) * pagination.PageSize
+	if start > len(current) {
+		start = len(current)
+	}
+	end := start + pagination.PageSize
+	if end > len(current) {
+		end = len(current)
+	}
+	responses := make([]ListingResponse, 0, end-start)
+	for i := start; i < end; i++ {
+		responses = append(responses, ToListingResponse(&current[i], s.imageURLs))
+	}
+	return responses, pagination, nil
+}
+
+// trendingScores sums each listing's daily views, weighting a day by 0.5^(age/halfLife).
+// A day's age is measured from its midpoint, so today's views count (almost) fully.
+func trendingScores(views []DailyViews, now time.Time, halfLife time.Duration) map[uuid.UUID]float64 {
+	scores := make(map[uuid.UUID]float64)
+	for _, v := range views {
+		age := now.Sub(v.Day.UTC().Add(12 * time.Hour))
+		if age < 0 {
+			age = 0
+		}
+		scores[v.ListingID] += float64(v.Views) * math.Pow(0.5, age.Hours()/halfLife.Hours())
+	}
+	return scores
+}
+
+// rankByScore returns up to limit IDs with the highest scores, highest first. Ties are broken by ID for a stable order.
+func rankByScore(scores map[uuid.UUID]float64, limit int) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i].String() < ids[j].String()
+	})
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids
+}
@@ -0,0 +1,33 @@
+package listing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrendingScoresDecayWithAge(t *testing.T) {
+	now := time.Date(2024, 6, 8, 12, 0, 0, 0, time.UTC)
+	today := time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC)
+	fresh, old := uuid.New(), uuid.New()
+
+	scores := trendingScores([]DailyViews{
+		{ListingID: fresh, Day: today, Views: 10},
+		{ListingID: old, Day: today.AddDate(0, 0, -2), Views: 10},
+		{ListingID: old, Day: today.AddDate(0, 0, -4), Views: 20},
+	}, now, 48*time.Hour)
+
+	assert.InDelta(t, 10, scores[fresh], 1e-9, "views from the middle of today count fully")
+	assert.InDelta(t, 5+5, scores[old], 1e-9, "two days old counts half, four days old a quarter")
+}
+
+func TestRankByScore(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	scores := map[uuid.UUID]float64{a: 1, b: 3, c: 2}
+
+	assert.Equal(t, []uuid.UUID{b, c, a}, rankByScore(scores, 10))
+	assert.Equal(t, []uuid.UUID{b, c}, rankByScore(scores, 2))
+	assert.Empty(t, rankByScore(map[uuid.UUID]float64{}, 10))
+}
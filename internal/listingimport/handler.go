@@ -0,0 +1,103 @@
+// File: internal/listingimport/handler.go
+package listingimport
+
+import (
+	"io"
+	"net/http"
+	"path/filepath"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Handler struct holds dependencies for listing import handlers.
+type Handler struct {
+	service      Service
+	logger       *zap.Logger
+	uploadLimits common.MultipartLimits
+}
+
+// NewHandler creates a new listing import handler.
+func NewHandler(service Service, cfg *config.Config, logger *zap.Logger) *Handler {
+	return &Handler{
+		service:      service,
+		logger:       logger,
+		uploadLimits: common.MultipartLimits{MaxBytes: maxFileSize + (1 << 20), MaxParts: cfg.MaxMultipartParts, MaxMemory: cfg.MultipartMemoryBytes},
+	}
+}
+
+// RegisterAdminRoutes adds listing imports to the admin API group, which already requires the admin role.
+func (h *Handler) RegisterAdminRoutes(router *gin.RouterGroup) {
+	router.POST("/listings/import", h.startImport)
+	router.GET("/listings/import/:id", h.getImport)
+}
+
+// startImport accepts a multipart upload with the file in the "file" field and an optional "owner_id".
+func (h *Handler) startImport(c *gin.Context) {
+	adminID := common.GetUserIDFromContext(c)
+	if adminID == uuid.Nil {
+		common.RespondWithError(c, common.ErrInternalServer.WithDetails("User ID not found."))
+		return
+	}
+
+	if apiErr := common.ParseMultipartForm(c, h.uploadLimits); apiErr != nil {
+		common.RespondWithError(c, apiErr)
+		return
+	}
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Missing required 'file' field in multipart form."))
+		return
+	}
+	if fileHeader.Size > maxFileSize {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("The file is too large."))
+		return
+	}
+
+	var ownerID *uuid.UUID
+	if raw := c.PostForm("owner_id"); raw != "" {
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid owner_id format."))
+			return
+		}
+		ownerID = &parsed
+	}
+
+	f, err := fileHeader.Open()
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Could not read the uploaded file."))
+		return
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, maxFileSize+1))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Could not read the uploaded file."))
+		return
+	}
+
+	imp, err := h.service.StartImport(c.Request.Context(), adminID, ownerID, filepath.Base(fileHeader.Filename), data)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondSuccess(c, http.StatusAccepted, "Import queued.", ToImportResponse(imp))
+}
+
+func (h *Handler) getImport(c *gin.Context) {
+	importID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid import ID format."))
+		return
+	}
+	imp, err := h.service.GetImport(c.Request.Context(), importID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Import retrieved successfully.", ToImportResponse(imp))
+}
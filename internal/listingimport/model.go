@@ -0,0 +1,91 @@
+// File: internal/listingimport/model.go
+package listingimport
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is the state of an import.
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // Waiting for a worker
+	StatusRunning   Status = "running"   // Rows are being created
+	StatusCompleted Status = "completed" // Every row was processed; see RowErrors for the ones that failed
+)
+
+// Import is a bulk upload of listings from a CSV or XLSX file. The parsed rows are kept until the import completes.
+type Import struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	AdminID       uuid.UUID  `gorm:"type:uuid;not null"` // Admin who uploaded the file
+	OwnerID       uuid.UUID  `gorm:"type:uuid;not null"` // User the listings are created for
+	Filename      string     `gorm:"type:varchar(255);not null"`
+	Status        Status     `gorm:"type:varchar(20);not null;default:'pending'"`
+	Rows          string     `gorm:"type:jsonb;not null"` // Encoded []Row
+	TotalRows     int        `gorm:"not null"`
+	ProcessedRows int        `gorm:"not null;default:0"` // Rows handled so far; a retried task resumes after them
+	CreatedCount  int        `gorm:"not null;default:0"`
+	FailedCount   int        `gorm:"not null;default:0"`
+	RowErrors     string     `gorm:"type:jsonb;not null;default:'[]'"` // Encoded []RowError
+	CreatedAt     time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	UpdatedAt     time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	CompletedAt   *time.Time // Set when Status becomes completed
+}
+
+// TableName specifies the table name for GORM.
+func (Import) TableName() string {
+	return "listing_imports"
+}
+
+// Row is one data row of an uploaded file: its values keyed by column name, and its line number in the file.
+type Row struct {
+	Line   int               `json:"line"`
+	Values map[string]string `json:"values"`
+}
+
+// RowError explains why the row at Line did not become a listing.
+type RowError struct {
+	Line   int      `json:"line"`
+	Errors []string `json:"errors"`
+}
+
+// --- Response DTOs ---
+
+// ImportResponse is the API representation of an import.
+type ImportResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Filename      string     `json:"filename"`
+	Status        Status     `json:"status"`
+	OwnerID       uuid.UUID  `json:"owner_id"`
+	TotalRows     int        `json:"total_rows"`
+	ProcessedRows int        `json:"processed_rows"`
+	CreatedCount  int        `json:"created_count"`
+	FailedCount   int        `json:"failed_count"`
+	Errors        []RowError `json:"errors"`
+	CreatedAt     time.Time  `json:"created_at"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+}
+
+// ToImportResponse converts an Import model to an ImportResponse DTO.
+func ToImportResponse(imp *Import) ImportResponse {
+	errs := []RowError{}
+	if imp.RowErrors != "" {
+		_ = json.Unmarshal([]byte(imp.RowErrors), &errs)
+	}
+	return ImportResponse{
+		ID:            imp.ID,
+		Filename:      imp.Filename,
+		Status:        imp.Status,
+		OwnerID:       imp.OwnerID,
+		TotalRows:     imp.TotalRows,
+		ProcessedRows: imp.ProcessedRows,
+		CreatedCount:  imp.CreatedCount,
+		FailedCount:   imp.FailedCount,
+		Errors:        errs,
+		CreatedAt:     imp.CreatedAt,
+		CompletedAt:   imp.CompletedAt,
+	}
+}
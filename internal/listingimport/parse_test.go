@@ -0,0 +1,115 @@
+package listingimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"seattle_info_backend/internal/category"
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	data := "\ufeffCategory,Title, Event Date ,Unknown\n" +
+		"events,Summer fair,2024-07-01,x\n" +
+		",,,\n" +
+		"events,\"Quoted, title\",,\n"
+	columns, rows, err := parseFile("events.CSV", []byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"category", "title", "event_date", "unknown"}, columns)
+	require.Len(t, rows, 2, "blank rows are skipped")
+	assert.Equal(t, Row{Line: 2, Values: map[string]string{"category": "events", "title": "Summer fair", "event_date": "2024-07-01", "unknown": "x"}}, rows[0])
+	assert.Equal(t, 4, rows[1].Line)
+	assert.Equal(t, "Quoted, title", rows[1].Values["title"])
+
+	_, _, err = parseFile("events.txt", []byte(data))
+	assert.ErrorIs(t, err, errUnsupportedFormat)
+}
+
+// buildXLSX writes a minimal workbook whose first sheet has the given cells XML.
+func buildXLSX(t *testing.T, sheetData string, sharedStrings ...string) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	write := func(name, content string) {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	write("xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Events" sheetId="1" r:id="rId7"/></sheets></workbook>`)
+	write("xl/_rels/workbook.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId7" Target="worksheets/events.xml"/></Relationships>`)
+	sst := `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`
+	for _, s := range sharedStrings {
+		sst += "<si><t>" + s + "</t></si>"
+	}
+	write("xl/sharedStrings.xml", sst+`<si><r><t>Rich </t></r><r><t>text</t></r></si></sst>`)
+	write("xl/worksheets/events.xml", `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`+sheetData+`</sheetData></worksheet>`)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestParseXLSX(t *testing.T) {
+	data := buildXLSX(t, `
+		<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c></row>
+		<row r="2"><c r="A2" t="inlineStr"><is><t>events</t></is></c><c r="C2"><v>45474</v></c></row>
+		<row r="3"><c r="B3" t="s"><v>3</v></c></row>`,
+		"category", "title", "event_date")
+
+	columns, rows, err := parseFile("events.xlsx", data)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"category", "title", "event_date"}, columns)
+	require.Len(t, rows, 2)
+	assert.Equal(t, map[string]string{"category": "events", "event_date": "45474"}, rows[0].Values, "cells are placed by reference, skipping empty ones")
+	assert.Equal(t, map[string]string{"title": "Rich text"}, rows[1].Values)
+
+	_, _, err = parseFile("events.xlsx", []byte("not a zip"))
+	assert.Error(t, err)
+}
+
+func TestToRequest(t *testing.T) {
+	sub := category.SubCategory{BaseModel: common.BaseModel{ID: uuid.New()}, Slug: "festivals"}
+	cat := &category.Category{BaseModel: common.BaseModel{ID: uuid.New()}, Slug: "events", SubCategories: []category.SubCategory{sub}}
+
+	req, problems := toRequest(Row{Values: map[string]string{
+		"category":     "events",
+		"sub_category": "Festivals",
+		"title":        "Summer fair",
+		"description":  "A fair with music and food trucks.",
+		"city":         "Seattle",
+		"price":        "1,250.50",
+		"event_date":   "45474",
+		"event_time":   "0.75",
+		"draft":        "yes",
+	}}, cat)
+	require.Empty(t, problems)
+	assert.Equal(t, cat.ID, req.CategoryID)
+	assert.Equal(t, sub.ID, *req.SubCategoryID)
+	assert.Equal(t, "Seattle", *req.City)
+	assert.Nil(t, req.ContactEmail)
+	assert.Equal(t, 1250.5, req.Price.Amount)
+	require.NotNil(t, req.EventDetails)
+	assert.Equal(t, "2024-07-01", req.EventDetails.EventDate, "Excel serial dates are converted")
+	assert.Equal(t, "18:00:00", *req.EventDetails.EventTime)
+	assert.True(t, req.Draft)
+	assert.Nil(t, req.HousingDetails)
+
+	req, problems = toRequest(Row{Values: map[string]string{
+		"category":        "events",
+		"sub_category":    "concerts",
+		"latitude":        "north",
+		"event_date":      "July 1st",
+		"employment_type": "full_time",
+	}}, cat)
+	assert.ElementsMatch(t, []string{
+		`sub_category: "concerts" is not a subcategory of events`,
+		`latitude: "north" is not a number`,
+		`event_date: "July 1st" is not a date (YYYY-MM-DD)`,
+	}, problems)
+	assert.Equal(t, listing.JobEmploymentType("full_time"), req.JobDetails.EmploymentType)
+}
@@ -0,0 +1,58 @@
+// File: internal/listingimport/repository.go
+package listingimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for listing import data operations.
+type Repository interface {
+	Create(ctx context.Context, imp *Import) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Import, error)
+	Update(ctx context.Context, imp *Import) error
+}
+
+// GORMRepository implements the listing import Repository interface using GORM.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+// NewGORMRepository creates a new GORM listing import repository.
+func NewGORMRepository(db *gorm.DB) Repository {
+	return &GORMRepository{db: db}
+}
+
+// Create inserts a new import.
+func (r *GORMRepository) Create(ctx context.Context, imp *Import) error {
+	if err := r.db.WithContext(ctx).Create(imp).Error; err != nil {
+		return fmt.Errorf("failed to create listing import: %w", err)
+	}
+	return nil
+}
+
+// FindByID retrieves an import by its ID.
+func (r *GORMRepository) FindByID(ctx context.Context, id uuid.UUID) (*Import, error) {
+	var imp Import
+	if err := r.db.WithContext(ctx).First(&imp, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Import not found.")
+		}
+		return nil, fmt.Errorf("failed to find listing import: %w", err)
+	}
+	return &imp, nil
+}
+
+// Update saves the progress and results of an import.
+func (r *GORMRepository) Update(ctx context.Context, imp *Import) error {
+	if err := r.db.WithContext(ctx).Save(imp).Error; err != nil {
+		return fmt.Errorf("failed to update listing import: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+// File: internal/listingimport/row.go
+package listingimport
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/category"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/google/uuid"
+)
+
+// requiredColumns must be present in the header of every import file.
+var requiredColumns = []string{"category", "title", "description"}
+
+// excelEpoch is day zero of the serial numbers XLSX files store dates as.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// rowParser builds a listing request from the values of one row, collecting every problem it finds.
+type rowParser struct {
+	values map[string]string
+	errs   []string
+}
+
+// toRequest converts row into a CreateListingRequest for cat, the category named in its "category" column.
+// It returns the problems found instead when the row cannot be converted.
+func toRequest(row Row, cat *category.Category) (listing.CreateListingRequest, []string) {
+	p := &rowParser{values: row.Values}
+	req := listing.CreateListingRequest{
+		CategoryID:   cat.ID,
+		Title:        row.Values["title"],
+		Description:  row.Values["description"],
+		ContactName:  p.optional("contact_name"),
+		ContactEmail: p.optional("contact_email"),
+		ContactPhone: p.optional("contact_phone"),
+		AddressLine1: p.optional("address_line1"),
+		AddressLine2: p.optional("address_line2"),
+		City:         p.optional("city"),
+		State:        p.optional("state"),
+		ZipCode:      p.optional("zip_code"),
+		Latitude:     p.float("latitude"),
+		Longitude:    p.float("longitude"),
+		Draft:        p.bool("draft"),
+	}
+
+	if slug := row.Values["sub_category"]; slug != "" {
+		found := false
+		for _, sc := range cat.SubCategories {
+			if strings.EqualFold(sc.Slug, slug) || sc.ID.String() == slug {
+				id := sc.ID
+				req.SubCategoryID = &id
+				found = true
+				break
+			}
+		}
+		if !found {
+			p.errs = append(p.errs, fmt.Sprintf("sub_category: %q is not a subcategory of %s", slug, cat.Slug))
+		}
+	}
+
+	if amount := p.float("price"); amount != nil {
+		req.Price = &listing.PriceRequest{
+			Amount:   *amount,
+			Currency: row.Values["price_currency"],
+			Period:   listing.PricePeriod(row.Values["price_period"]),
+		}
+	}
+
+	if eventDate := p.date("event_date"); eventDate != "" {
+		req.EventDetails = &listing.CreateListingEventDetailsRequest{
+			EventDate:     eventDate,
+			EventTime:     p.time("event_time"),
+			OrganizerName: p.optional("organizer_name"),
+			VenueName:     p.optional("venue_name"),
+		}
+	}
+
+	if propertyType := row.Values["property_type"]; propertyType != "" {
+		req.HousingDetails = &listing.CreateListingHousingDetailsRequest{
+			PropertyType: listing.HousingPropertyType(propertyType),
+			RentDetails:  p.optional("rent_details"),
+			SalePrice:    p.float("sale_price"),
+			RentAmount:   p.float("rent_amount"),
+			Bedrooms:     p.int("bedrooms"),
+			Bathrooms:    p.float("bathrooms"),
+			SquareFeet:   p.int("square_feet"),
+		}
+		if rentPeriod := p.optional("rent_period"); rentPeriod != nil {
+			period := listing.PricePeriod(*rentPeriod)
+			req.HousingDetails.RentPeriod = &period
+		}
+		if petPolicy := p.optional("pet_policy"); petPolicy != nil {
+			policy := listing.HousingPetPolicy(*petPolicy)
+			req.HousingDetails.PetPolicy = &policy
+		}
+		if parking := p.optional("parking"); parking != nil {
+			kind := listing.HousingParking(*parking)
+			req.HousingDetails.Parking = &kind
+		}
+		if availableFrom := p.date("available_from"); availableFrom != "" {
+			req.HousingDetails.AvailableFrom = &availableFrom
+		}
+	}
+
+	if row.Values["employment_type"] != "" || row.Values["workplace_type"] != "" {
+		req.JobDetails = &listing.CreateListingJobDetailsRequest{
+			EmploymentType: listing.JobEmploymentType(row.Values["employment_type"]),
+			WorkplaceType:  listing.JobWorkplaceType(row.Values["workplace_type"]),
+			SalaryMin:      p.float("salary_min"),
+			SalaryMax:      p.float("salary_max"),
+			SalaryCurrency: p.optional("salary_currency"),
+			ApplicationURL: p.optional("application_url"),
+		}
+	}
+
+	return req, p.errs
+}
+
+func (p *rowParser) optional(column string) *string {
+	if v, ok := p.values[column]; ok {
+		return &v
+	}
+	return nil
+}
+
+func (p *rowParser) float(column string) *float64 {
+	v, ok := p.values[column]
+	if !ok {
+		return nil
+	}
+	f, err := strconv.ParseFloat(strings.ReplaceAll(v, ",", ""), 64)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Sprintf("%s: %q is not a number", column, v))
+		return nil
+	}
+	return &f
+}
+
+func (p *rowParser) int(column string) *int {
+	v, ok := p.values[column]
+	if !ok {
+		return nil
+	}
+	n, err := strconv.Atoi(strings.ReplaceAll(v, ",", ""))
+	if err != nil {
+		p.errs = append(p.errs, fmt.Sprintf("%s: %q is not a whole number", column, v))
+		return nil
+	}
+	return &n
+}
+
+func (p *rowParser) bool(column string) bool {
+	v, ok := p.values[column]
+	if !ok {
+		return false
+	}
+	switch strings.ToLower(v) {
+	case "true", "yes", "y", "1":
+		return true
+	case "false", "no", "n", "0":
+		return false
+	}
+	p.errs = append(p.errs, fmt.Sprintf("%s: %q is not true or false", column, v))
+	return false
+}
+
+// date accepts YYYY-MM-DD or, for XLSX date cells, an Excel serial number, and returns YYYY-MM-DD.
+func (p *rowParser) date(column string) string {
+	v, ok := p.values[column]
+	if !ok {
+		return ""
+	}
+	if _, err := time.Parse("2006-01-02", v); err == nil {
+		return v
+	}
+	if serial, err := strconv.ParseFloat(v, 64); err == nil && serial > 0 {
+		return excelEpoch.AddDate(0, 0, int(serial)).Format("2006-01-02")
+	}
+	p.errs = append(p.errs, fmt.Sprintf("%s: %q is not a date (YYYY-MM-DD)", column, v))
+	return ""
+}
+
+// time accepts HH:MM, HH:MM:SS or, for XLSX time cells, a fraction of a day, and returns HH:MM:SS.
+func (p *rowParser) time(column string) *string {
+	v, ok := p.values[column]
+	if !ok {
+		return nil
+	}
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			formatted := t.Format("15:04:05")
+			return &formatted
+		}
+	}
+	if fraction, err := strconv.ParseFloat(v, 64); err == nil && fraction >= 0 && fraction < 1 {
+		seconds := int(math.Round(fraction * 24 * 60 * 60))
+		formatted := fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+		return &formatted
+	}
+	p.errs = append(p.errs, fmt.Sprintf("%s: %q is not a time (HH:MM)", column, v))
+	return nil
+}
+
+// categoryKey returns the value of the row's category column, which holds a category slug or ID.
+func categoryKey(row Row) (slug string, id uuid.UUID) {
+	v := row.Values["category"]
+	if parsed, err := uuid.Parse(v); err == nil {
+		return "", parsed
+	}
+	return strings.ToLower(v), uuid.Nil
+}
@@ -0,0 +1,324 @@
+// File: internal/listingimport/service.go
+package listingimport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/category"
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/listing"
+	"seattle_info_backend/internal/queue"
+	"seattle_info_backend/internal/user"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// TaskImport is the queue task type that creates the listings of an import.
+	TaskImport = "listing.import"
+	// maxFileSize caps the size of an uploaded import file.
+	maxFileSize = 10 << 20
+	// defaultMaxRows is used when LISTING_IMPORT_MAX_ROWS is not positive.
+	defaultMaxRows = 1000
+)
+
+// importTask is the payload of a TaskImport task.
+type importTask struct {
+	ImportID uuid.UUID `json:"import_id"`
+}
+
+// Service defines the interface for bulk listing imports.
+type Service interface {
+	// StartImport parses an uploaded CSV or XLSX file and queues the creation of its listings for ownerID
+	// (the admin when nil). Row contents are validated when the rows are processed.
+	StartImport(ctx context.Context, adminID uuid.UUID, ownerID *uuid.UUID, filename string, data []byte) (*Import, error)
+	GetImport(ctx context.Context, id uuid.UUID) (*Import, error)
+
+	// HandleImportTask is the queue handler for TaskImport.
+	HandleImportTask(ctx context.Context, payload []byte) error
+}
+
+// ServiceImplementation implements the listing import Service interface.
+type ServiceImplementation struct {
+	repo            Repository
+	listingService  listing.Service
+	categoryService category.Service
+	userRepo        user.Repository
+	tasks           queue.Service
+	cfg             *config.Config
+	logger          *zap.Logger
+	validator       *validator.Validate
+}
+
+// NewService creates a new listing import service.
+func NewService(
+	repo Repository,
+	listingService listing.Service,
+	categoryService category.Service,
+	userRepo user.Repository,
+	tasks queue.Service,
+	cfg *config.Config,
+	logger *zap.Logger,
+) Service {
+	return &ServiceImplementation{
+		repo:            repo,
+		listingService:  listingService,
+		categoryService: categoryService,
+		userRepo:        userRepo,
+		tasks:           tasks,
+		cfg:             cfg,
+		logger:          logger.Named("ListingImport"),
+		validator:       common.NewValidator(),
+	}
+}
+
+// StartImport implements Service.
+func (s *ServiceImplementation) StartImport(ctx context.Context, adminID uuid.UUID, ownerID *uuid.UUID, filename string, data []byte) (*Import, error) {
+	if len(data) > maxFileSize {
+		return nil, common.ErrBadRequest.WithDetails(fmt.Sprintf("The file is larger than %d MB.", maxFileSize>>20))
+	}
+	columns, rows, err := parseFile(filename, data)
+	if err != nil {
+		return nil, common.ErrBadRequest.WithDetails(err.Error())
+	}
+	if missing := missingColumns(columns); len(missing) > 0 {
+		return nil, common.ErrBadRequest.WithDetails("Missing required columns: " + strings.Join(missing, ", ") + ".")
+	}
+	if len(rows) == 0 {
+		return nil, common.ErrBadRequest.WithDetails("The file has no data rows.")
+	}
+	if maxRows := s.maxRows(); len(rows) > maxRows {
+		return nil, common.ErrBadRequest.WithDetails(fmt.Sprintf("The file has %d rows; at most %d can be imported at once.", len(rows), maxRows))
+	}
+
+	owner := adminID
+	if ownerID != nil {
+		if _, err := s.userRepo.FindByID(ctx, *ownerID); err != nil {
+			if errors.Is(err, common.ErrNotFound) {
+				return nil, common.ErrBadRequest.WithDetails("Owner not found.")
+			}
+			s.logger.Error("Failed to look up import owner", zap.Error(err), zap.String("ownerID", ownerID.String()))
+			return nil, common.ErrInternalServer.WithDetails("Could not start import.")
+		}
+		owner = *ownerID
+	}
+
+	encodedRows, err := json.Marshal(rows)
+	if err != nil {
+		return nil, common.ErrInternalServer.WithDetails("Could not start import.")
+	}
+	imp := &Import{
+		ID:        uuid.New(),
+		AdminID:   adminID,
+		OwnerID:   owner,
+		Filename:  filename,
+		Status:    StatusPending,
+		Rows:      string(encodedRows),
+		TotalRows: len(rows),
+		RowErrors: "[]",
+	}
+	if err := s.repo.Create(ctx, imp); err != nil {
+		s.logger.Error("Failed to create listing import", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not start import.")
+	}
+	if err := s.tasks.Enqueue(ctx, TaskImport, importTask{ImportID: imp.ID}); err != nil {
+		s.logger.Error("Failed to enqueue listing import", zap.Error(err), zap.String("importID", imp.ID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not start import.")
+	}
+	s.logger.Info("Listing import queued",
+		zap.String("importID", imp.ID.String()),
+		zap.String("adminID", adminID.String()),
+		zap.String("ownerID", owner.String()),
+		zap.Int("rows", len(rows)))
+	return imp, nil
+}
+
+// GetImport retrieves an import with its progress and per-row errors.
+func (s *ServiceImplementation) GetImport(ctx context.Context, id uuid.UUID) (*Import, error) {
+	imp, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to get listing import", zap.Error(err), zap.String("importID", id.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve import.")
+	}
+	return imp, nil
+}
+
+// HandleImportTask creates the listings of an import row by row, saving progress after each row.
+// Rows with invalid content are recorded as row errors; an infrastructure error fails the task,
+// and the retried task resumes at the first unprocessed row.
+func (s *ServiceImplementation) HandleImportTask(ctx context.Context, payload []byte) error {
+	var task importTask
+	if err := json.Unmarshal(payload, &task); err != nil {
+		return fmt.Errorf("invalid %s payload: %w", TaskImport, err)
+	}
+	imp, err := s.repo.FindByID(ctx, task.ImportID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			s.logger.Warn("Listing import no longer exists", zap.String("importID", task.ImportID.String()))
+			return nil
+		}
+		return err
+	}
+	if imp.Status == StatusCompleted {
+		return nil
+	}
+
+	var rows []Row
+	if err := json.Unmarshal([]byte(imp.Rows), &rows); err != nil {
+		return fmt.Errorf("failed to decode rows of import %s: %w", imp.ID, err)
+	}
+	var rowErrors []RowError
+	if err := json.Unmarshal([]byte(imp.RowErrors), &rowErrors); err != nil {
+		return fmt.Errorf("failed to decode row errors of import %s: %w", imp.ID, err)
+	}
+
+	imp.Status = StatusRunning
+	categories := make(map[string]*category.Category)
+	for imp.ProcessedRows < len(rows) {
+		row := rows[imp.ProcessedRows]
+		problems, err := s.importRow(ctx, imp.OwnerID, row, categories)
+		if err != nil {
+			s.logger.Error("Listing import interrupted", zap.String("importID", imp.ID.String()), zap.Int("line", row.Line), zap.Error(err))
+			return err
+		}
+		if len(problems) > 0 {
+			rowErrors = append(rowErrors, RowError{Line: row.Line, Errors: problems})
+			imp.FailedCount++
+		} else {
+			imp.CreatedCount++
+		}
+		imp.ProcessedRows++
+
+		encoded, _ := json.Marshal(rowErrors)
+		imp.RowErrors = string(encoded)
+		if imp.ProcessedRows == len(rows) {
+			now := time.Now()
+			imp.Status = StatusCompleted
+			imp.CompletedAt = &now
+			imp.Rows = "[]" // The file's contents are no longer needed
+		}
+		if err := s.repo.Update(ctx, imp); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Info("Listing import completed",
+		zap.String("importID", imp.ID.String()),
+		zap.Int("created", imp.CreatedCount),
+		zap.Int("failed", imp.FailedCount))
+	return nil
+}
+
+// importRow creates the listing described by row. It returns the problems that kept the row from
+// becoming a listing, or an error when the row could not be processed at all.
+func (s *ServiceImplementation) importRow(ctx context.Context, ownerID uuid.UUID, row Row, categories map[string]*category.Category) ([]string, error) {
+	if row.Values["category"] == "" {
+		return []string{"category: required"}, nil
+	}
+	cat, problems, err := s.lookupCategory(ctx, row, categories)
+	if err != nil || len(problems) > 0 {
+		return problems, err
+	}
+
+	req, problems := toRequest(row, cat)
+	if len(problems) > 0 {
+		return problems, nil
+	}
+	if err := s.validator.Struct(req); err != nil {
+		var ve validator.ValidationErrors
+		if errors.As(err, &ve) {
+			return validationProblems(common.FormatValidationErrors(ve)), nil
+		}
+		return []string{err.Error()}, nil
+	}
+
+	if _, err := s.listingService.CreateListing(ctx, ownerID, req, nil); err != nil {
+		return rejectedRow(err)
+	}
+	return nil, nil
+}
+
+// lookupCategory finds the category named in the row by slug or ID, caching it for the following rows.
+func (s *ServiceImplementation) lookupCategory(ctx context.Context, row Row, categories map[string]*category.Category) (*category.Category, []string, error) {
+	key := strings.ToLower(row.Values["category"])
+	if cat, ok := categories[key]; ok {
+		return cat, nil, nil
+	}
+	slug, id := categoryKey(row)
+	var cat *category.Category
+	var err error
+	if id != uuid.Nil {
+		cat, err = s.categoryService.GetCategoryByID(ctx, id, true)
+	} else {
+		cat, err = s.categoryService.GetCategoryBySlug(ctx, slug, true)
+	}
+	if err != nil {
+		if apiErr, ok := err.(*common.APIError); ok && apiErr.StatusCode < 500 {
+			return nil, []string{fmt.Sprintf("category: %q not found", row.Values["category"])}, nil
+		}
+		return nil, nil, err
+	}
+	categories[key] = cat
+	return cat, nil, nil
+}
+
+// rejectedRow turns a CreateListing error into row problems. Errors caused by the row's content
+// (4xx) are problems; anything else is returned as an error so that the task is retried.
+func rejectedRow(err error) ([]string, error) {
+	apiErr, ok := err.(*common.APIError)
+	if !ok || apiErr.StatusCode >= 500 {
+		return nil, err
+	}
+	switch details := apiErr.Details.(type) {
+	case string:
+		return []string{details}, nil
+	case common.ValidationMessages:
+		return validationProblems(details), nil
+	default:
+		return []string{apiErr.Message}, nil
+	}
+}
+
+// validationProblems lists validation messages in a stable order.
+func validationProblems(messages common.ValidationMessages) []string {
+	problems := make([]string, 0, len(messages))
+	for _, m := range messages {
+		problems = append(problems, fmt.Sprintf("%s: %s", m.Field, m.Message))
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// missingColumns returns the required columns absent from the header.
+func missingColumns(columns []string) []string {
+	present := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		present[c] = true
+	}
+	var missing []string
+	for _, c := range requiredColumns {
+		if !present[c] {
+			missing = append(missing, c)
+		}
+	}
+	return missing
+}
+
+func (s *ServiceImplementation) maxRows() int {
+	if s.cfg.ListingImportMaxRows > 0 {
+		return s.cfg.ListingImportMaxRows
+	}
+	return defaultMaxRows
+}
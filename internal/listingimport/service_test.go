@@ -0,0 +1,138 @@
+package listingimport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"testing"
+
+	"seattle_info_backend/internal/category"
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type memoryRepository struct {
+	imports map[uuid.UUID]Import
+}
+
+func (r *memoryRepository) Create(_ context.Context, imp *Import) error {
+	r.imports[imp.ID] = *imp
+	return nil
+}
+
+func (r *memoryRepository) FindByID(_ context.Context, id uuid.UUID) (*Import, error) {
+	imp, ok := r.imports[id]
+	if !ok {
+		return nil, common.ErrNotFound.WithDetails("Import not found.")
+	}
+	return &imp, nil
+}
+
+func (r *memoryRepository) Update(_ context.Context, imp *Import) error {
+	r.imports[imp.ID] = *imp
+	return nil
+}
+
+// fakeCategories knows one category; other category.Service methods are not used by imports.
+type fakeCategories struct {
+	category.Service
+	cat *category.Category
+}
+
+func (f *fakeCategories) GetCategoryBySlug(_ context.Context, slug string, _ bool) (*category.Category, error) {
+	if slug == f.cat.Slug {
+		return f.cat, nil
+	}
+	return nil, common.ErrNotFound.WithDetails("Category not found.")
+}
+
+// fakeListings records created listings and fails for titles listed in errs.
+type fakeListings struct {
+	listing.Service
+	created []listing.CreateListingRequest
+	errs    map[string]error
+}
+
+func (f *fakeListings) CreateListing(_ context.Context, _ uuid.UUID, req listing.CreateListingRequest, _ []*multipart.FileHeader) (*listing.Listing, error) {
+	if err := f.errs[req.Title]; err != nil {
+		return nil, err
+	}
+	f.created = append(f.created, req)
+	return &listing.Listing{}, nil
+}
+
+func TestImportCreatesListingsAndRecordsRowErrors(t *testing.T) {
+	repo := &memoryRepository{imports: make(map[uuid.UUID]Import)}
+	listings := &fakeListings{errs: map[string]error{
+		"Rejected listing": common.ErrBadRequest.WithDetails("Missing required details for this category."),
+		"Flaky listing":    errors.New("connection reset"),
+	}}
+	svc := &ServiceImplementation{
+		repo:            repo,
+		listingService:  listings,
+		categoryService: &fakeCategories{cat: &category.Category{BaseModel: common.BaseModel{ID: uuid.New()}, Slug: "events"}},
+		cfg:             &config.Config{},
+		logger:          zap.NewNop(),
+		validator:       common.NewValidator(),
+	}
+
+	description := "A description long enough to pass validation."
+	rows := []Row{
+		{Line: 2, Values: map[string]string{"category": "events", "title": "Summer fair", "description": description}},
+		{Line: 3, Values: map[string]string{"category": "events", "title": "Tiny", "description": description}},
+		{Line: 4, Values: map[string]string{"category": "unknown", "title": "Lost listing", "description": description}},
+		{Line: 5, Values: map[string]string{"category": "events", "title": "Rejected listing", "description": description}},
+		{Line: 6, Values: map[string]string{"category": "events", "title": "Flaky listing", "description": description}},
+	}
+	encoded, err := json.Marshal(rows)
+	require.NoError(t, err)
+	imp := Import{ID: uuid.New(), Status: StatusPending, Rows: string(encoded), TotalRows: len(rows), RowErrors: "[]"}
+	repo.imports[imp.ID] = imp
+	payload, _ := json.Marshal(importTask{ImportID: imp.ID})
+
+	require.Error(t, svc.HandleImportTask(context.Background(), payload), "infrastructure errors fail the task")
+	stored := repo.imports[imp.ID]
+	assert.Equal(t, StatusRunning, stored.Status)
+	assert.Equal(t, 4, stored.ProcessedRows, "progress is kept for the retry")
+
+	delete(listings.errs, "Flaky listing")
+	require.NoError(t, svc.HandleImportTask(context.Background(), payload))
+	stored = repo.imports[imp.ID]
+	assert.Equal(t, StatusCompleted, stored.Status)
+	assert.NotNil(t, stored.CompletedAt)
+	assert.Equal(t, "[]", stored.Rows)
+	assert.Equal(t, 2, stored.CreatedCount)
+	assert.Equal(t, 3, stored.FailedCount)
+	require.Len(t, listings.created, 2, "rows before the failure are not created twice")
+	assert.Equal(t, "Flaky listing", listings.created[1].Title)
+
+	errs := ToImportResponse(&stored).Errors
+	require.Len(t, errs, 3)
+	assert.Equal(t, 3, errs[0].Line)
+	assert.Len(t, errs[0].Errors, 1)
+	assert.Contains(t, errs[0].Errors[0], "title:")
+	assert.Equal(t, RowError{Line: 4, Errors: []string{`category: "unknown" not found`}}, errs[1])
+	assert.Equal(t, RowError{Line: 5, Errors: []string{"Missing required details for this category."}}, errs[2])
+
+	require.NoError(t, svc.HandleImportTask(context.Background(), payload), "a completed import is not processed again")
+	assert.Len(t, listings.created, 2)
+}
+
+func TestStartImportRejectsInvalidFiles(t *testing.T) {
+	svc := NewService(&memoryRepository{imports: make(map[uuid.UUID]Import)}, nil, nil, nil, nil, &config.Config{ListingImportMaxRows: 1}, zap.NewNop())
+	admin := uuid.New()
+
+	_, err := svc.StartImport(context.Background(), admin, nil, "a.csv", []byte("title,description\nx,y\n"))
+	assert.ErrorIs(t, err, common.ErrBadRequest)
+	_, err = svc.StartImport(context.Background(), admin, nil, "a.csv", []byte("category,title,description\n"))
+	assert.ErrorIs(t, err, common.ErrBadRequest)
+	_, err = svc.StartImport(context.Background(), admin, nil, "a.csv", []byte("category,title,description\na,b,c\nd,e,f\n"))
+	assert.ErrorIs(t, err, common.ErrBadRequest, "more rows than LISTING_IMPORT_MAX_ROWS")
+}
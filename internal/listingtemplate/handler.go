@@ -0,0 +1,173 @@
+// File: internal/listingtemplate/handler.go
+package listingtemplate
+
+import (
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/filestorage"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Handler struct holds dependencies for listing template handlers.
+type Handler struct {
+	service Service
+	logger  *zap.Logger
+	// imageURLs builds the image URLs of listings created from templates.
+	imageURLs *filestorage.ImageURLBuilder
+}
+
+// NewHandler creates a new listing template handler.
+func NewHandler(service Service, logger *zap.Logger, cfg *config.Config) *Handler {
+	return &Handler{
+		service:   service,
+		logger:    logger,
+		imageURLs: filestorage.NewImageURLBuilder(cfg),
+	}
+}
+
+// RegisterRoutes sets up the routes for listing template operations, including
+// POST /listings/from-template/:id. All listing template routes require authentication.
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMW gin.HandlerFunc) {
+	templateGroup := router.Group("/listing-templates")
+	templateGroup.Use(authMW)
+	{
+		templateGroup.POST("", h.createTemplate)
+		templateGroup.GET("", h.listTemplates)
+		templateGroup.GET("/:id", h.getTemplate)
+		templateGroup.PUT("/:id", h.updateTemplate)
+		templateGroup.DELETE("/:id", h.deleteTemplate)
+	}
+	router.POST("/listings/from-template/:id", authMW, h.createListingFromTemplate)
+}
+
+func (h *Handler) createTemplate(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	var req CreateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Create listing template: Invalid request body", zap.Error(err))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	template, err := h.service.CreateTemplate(c.Request.Context(), userID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondCreated(c, "Listing template created successfully.", ToTemplateResponse(template))
+}
+
+func (h *Handler) listTemplates(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	page, pageSize := common.GetPaginationParams(c)
+	templates, pagination, err := h.service.ListTemplates(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]TemplateResponse, len(templates))
+	for i := range templates {
+		responses[i] = ToTemplateResponse(&templates[i])
+	}
+	common.RespondPaginated(c, "Listing templates retrieved successfully.", responses, pagination)
+}
+
+func (h *Handler) getTemplate(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	templateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing template ID format."))
+		return
+	}
+
+	template, err := h.service.GetTemplate(c.Request.Context(), templateID, userID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Listing template retrieved successfully.", ToTemplateResponse(template))
+}
+
+func (h *Handler) updateTemplate(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	templateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing template ID format."))
+		return
+	}
+
+	var req UpdateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Update listing template: Invalid request body", zap.Error(err), zap.String("templateID", templateID.String()))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	template, err := h.service.UpdateTemplate(c.Request.Context(), templateID, userID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Listing template updated successfully.", ToTemplateResponse(template))
+}
+
+func (h *Handler) deleteTemplate(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	templateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing template ID format."))
+		return
+	}
+
+	if err := h.service.DeleteTemplate(c.Request.Context(), templateID, userID); err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondNoContent(c)
+}
+
+func (h *Handler) createListingFromTemplate(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	templateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing template ID format."))
+		return
+	}
+
+	draft, err := h.service.CreateListingFromTemplate(c.Request.Context(), templateID, userID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondCreated(c, "Draft listing created from template.", listing.ToOwnerListingResponse(draft, h.imageURLs))
+}
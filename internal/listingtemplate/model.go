@@ -0,0 +1,86 @@
+// File: internal/listingtemplate/model.go
+package listingtemplate
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/google/uuid"
+)
+
+// Fields is a persisted, partial listing.CreateListingRequest, stored as JSONB.
+type Fields listing.CreateListingRequest
+
+// Value implements the driver.Valuer interface for Fields.
+func (f Fields) Value() (driver.Value, error) {
+	b, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface for Fields.
+func (f *Fields) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	case nil:
+		*f = Fields{}
+		return nil
+	default:
+		return errors.New("failed to scan Fields: invalid type")
+	}
+	return json.Unmarshal(b, f)
+}
+
+// ListingTemplate is a named set of listing fields a user reuses to post similar listings, such as a weekly event.
+type ListingTemplate struct {
+	common.BaseModel
+	UserID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name   string    `gorm:"type:varchar(150);not null"`
+	Fields Fields    `gorm:"type:jsonb;not null"`
+}
+
+func (ListingTemplate) TableName() string {
+	return "listing_templates"
+}
+
+// --- DTOs for API ---
+
+type CreateTemplateRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=150"`
+	// Fields uses the listing create keys; only category_id is required. Images are not stored.
+	Fields listing.CreateListingRequest `json:"fields"`
+}
+
+type UpdateTemplateRequest struct {
+	Name   *string                       `json:"name,omitempty" binding:"omitempty,min=1,max=150"`
+	Fields *listing.CreateListingRequest `json:"fields,omitempty"` // Replaces all stored fields when present
+}
+
+type TemplateResponse struct {
+	ID        uuid.UUID                    `json:"id"`
+	Name      string                       `json:"name"`
+	Fields    listing.CreateListingRequest `json:"fields"`
+	CreatedAt time.Time                    `json:"created_at"`
+	UpdatedAt time.Time                    `json:"updated_at"`
+}
+
+func ToTemplateResponse(t *ListingTemplate) TemplateResponse {
+	return TemplateResponse{
+		ID:        t.ID,
+		Name:      t.Name,
+		Fields:    listing.CreateListingRequest(t.Fields),
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
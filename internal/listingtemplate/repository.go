@@ -0,0 +1,102 @@
+// File: internal/listingtemplate/repository.go
+package listingtemplate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for listing template data operations.
+type Repository interface {
+	Create(ctx context.Context, template *ListingTemplate) error
+	FindByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*ListingTemplate, error) // userID for ownership check
+	FindByUserID(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]ListingTemplate, *common.Pagination, error)
+	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+	Update(ctx context.Context, template *ListingTemplate) error
+	Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+}
+
+// GORMRepository implements the listing template Repository interface using GORM.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+// NewGORMRepository creates a new GORM listing template repository.
+func NewGORMRepository(db *gorm.DB) Repository {
+	return &GORMRepository{db: db}
+}
+
+// Create inserts a new listing template.
+func (r *GORMRepository) Create(ctx context.Context, template *ListingTemplate) error {
+	if err := r.db.WithContext(ctx).Create(template).Error; err != nil {
+		return fmt.Errorf("failed to create listing template: %w", err)
+	}
+	return nil
+}
+
+// FindByID retrieves a listing template by ID, ensuring it belongs to the given user.
+func (r *GORMRepository) FindByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*ListingTemplate, error) {
+	var template ListingTemplate
+	err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&template).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Listing template not found.")
+		}
+		return nil, fmt.Errorf("failed to find listing template %s: %w", id, err)
+	}
+	return &template, nil
+}
+
+// FindByUserID retrieves a paginated list of a user's listing templates, ordered by name.
+func (r *GORMRepository) FindByUserID(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]ListingTemplate, *common.Pagination, error) {
+	var templates []ListingTemplate
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&ListingTemplate{}).Where("user_id = ?", userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, nil, fmt.Errorf("counting listing templates for user %s failed: %w", userID, err)
+	}
+
+	pagination := common.NewPagination(total, page, pageSize)
+	err := query.Order("name ASC").Order("created_at DESC").
+		Limit(pagination.PageSize).
+		Offset((pagination.CurrentPage - 1) * pagination.PageSize).
+		Find(&templates).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetching listing templates for user %s failed: %w", userID, err)
+	}
+	return templates, pagination, nil
+}
+
+// CountByUserID counts the listing templates owned by a user.
+func (r *GORMRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&ListingTemplate{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// Update saves changes to an existing listing template.
+func (r *GORMRepository) Update(ctx context.Context, template *ListingTemplate) error {
+	if err := r.db.WithContext(ctx).Save(template).Error; err != nil {
+		return fmt.Errorf("failed to update listing template %s: %w", template.ID, err)
+	}
+	return nil
+}
+
+// Delete removes a listing template, ensuring ownership.
+func (r *GORMRepository) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&ListingTemplate{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete listing template %s: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound.WithDetails("Listing template not found.")
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// File: internal/listingtemplate/service.go
+package listingtemplate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// maxTemplatesPerUser caps how many listing templates a single user may keep.
+const maxTemplatesPerUser = 25
+
+// fieldValidator checks template fields against the validate tags of listing.CreateListingRequest.
+var fieldValidator = common.NewValidator()
+
+// Service defines the interface for listing template business logic.
+type Service interface {
+	CreateTemplate(ctx context.Context, userID uuid.UUID, req CreateTemplateRequest) (*ListingTemplate, error)
+	GetTemplate(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*ListingTemplate, error)
+	ListTemplates(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]ListingTemplate, *common.Pagination, error)
+	UpdateTemplate(ctx context.Context, id uuid.UUID, userID uuid.UUID, req UpdateTemplateRequest) (*ListingTemplate, error)
+	DeleteTemplate(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	CreateListingFromTemplate(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*listing.Listing, error)
+}
+
+// ServiceImplementation implements the listing template Service interface.
+type ServiceImplementation struct {
+	repo           Repository
+	listingService listing.Service
+	logger         *zap.Logger
+}
+
+// NewService creates a new listing template service.
+func NewService(repo Repository, listingService listing.Service, logger *zap.Logger) Service {
+	return &ServiceImplementation{
+		repo:           repo,
+		listingService: listingService,
+		logger:         logger,
+	}
+}
+
+// CreateTemplate persists a new named template for the user.
+func (s *ServiceImplementation) CreateTemplate(ctx context.Context, userID uuid.UUID, req CreateTemplateRequest) (*ListingTemplate, error) {
+	if err := validateFields(req.Fields); err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to count listing templates", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not create listing template.")
+	}
+	if count >= maxTemplatesPerUser {
+		return nil, common.ErrBadRequest.WithDetails(fmt.Sprintf("You can keep at most %d listing templates.", maxTemplatesPerUser))
+	}
+
+	template := &ListingTemplate{
+		UserID: userID,
+		Name:   req.Name,
+		Fields: toFields(req.Fields),
+	}
+	if err := s.repo.Create(ctx, template); err != nil {
+		s.logger.Error("Failed to create listing template", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not create listing template.")
+	}
+	return template, nil
+}
+
+// GetTemplate retrieves one of the user's listing templates.
+func (s *ServiceImplementation) GetTemplate(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*ListingTemplate, error) {
+	template, err := s.repo.FindByID(ctx, id, userID)
+	if err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to get listing template", zap.Error(err), zap.String("templateID", id.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve listing template.")
+	}
+	return template, nil
+}
+
+// ListTemplates retrieves the user's listing templates.
+func (s *ServiceImplementation) ListTemplates(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]ListingTemplate, *common.Pagination, error) {
+	templates, pagination, err := s.repo.FindByUserID(ctx, userID, page, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to list listing templates", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, nil, common.ErrInternalServer.WithDetails("Could not retrieve listing templates.")
+	}
+	return templates, pagination, nil
+}
+
+// UpdateTemplate renames a template or replaces its fields.
+func (s *ServiceImplementation) UpdateTemplate(ctx context.Context, id uuid.UUID, userID uuid.UUID, req UpdateTemplateRequest) (*ListingTemplate, error) {
+	template, err := s.GetTemplate(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		template.Name = *req.Name
+	}
+	if req.Fields != nil {
+		if err := validateFields(*req.Fields); err != nil {
+			return nil, err
+		}
+		template.Fields = toFields(*req.Fields)
+	}
+
+	if err := s.repo.Update(ctx, template); err != nil {
+		s.logger.Error("Failed to update listing template", zap.Error(err), zap.String("templateID", id.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not update listing template.")
+	}
+	return template, nil
+}
+
+// DeleteTemplate removes one of the user's listing templates. Listings created from it are not affected.
+func (s *ServiceImplementation) DeleteTemplate(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	if err := s.repo.Delete(ctx, id, userID); err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return err
+		}
+		s.logger.Error("Failed to delete listing template", zap.Error(err), zap.String("templateID", id.String()))
+		return common.ErrInternalServer.WithDetails("Could not delete listing template.")
+	}
+	return nil
+}
+
+// CreateListingFromTemplate creates a draft listing pre-populated with the template's fields. The owner completes
+// and publishes it like any other draft, so the category's required details are only checked on publish.
+func (s *ServiceImplementation) CreateListingFromTemplate(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*listing.Listing, error) {
+	template, err := s.GetTemplate(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listing.CreateListingRequest(template.Fields)
+	req.Draft = true
+	draft, err := s.listingService.CreateListing(ctx, userID, req, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("Draft listing created from template", zap.String("templateID", id.String()), zap.String("listingID", draft.ID.String()))
+	return draft, nil
+}
+
+// validateFields applies the listing create rules to template fields, except that only category_id is required:
+// a template may leave out anything the owner fills in per listing.
+func validateFields(fields listing.CreateListingRequest) error {
+	if fields.CategoryID == uuid.Nil {
+		return common.ErrBadRequest.WithDetails("A listing template needs a category_id.")
+	}
+	var validationErrs validator.ValidationErrors
+	if err := fieldValidator.Struct(fields); !errors.As(err, &validationErrs) {
+		return err
+	}
+	var kept validator.ValidationErrors
+	for _, fieldErr := range validationErrs {
+		if fieldErr.Tag() != "required" {
+			kept = append(kept, fieldErr)
+		}
+	}
+	if len(kept) > 0 {
+		return common.BindingError(kept)
+	}
+	return nil
+}
+
+// toFields drops the per-listing publishing options before fields are persisted.
+func toFields(req listing.CreateListingRequest) Fields {
+	req.Draft = false
+	req.PublishAt = nil
+	return Fields(req)
+}
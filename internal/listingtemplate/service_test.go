@@ -0,0 +1,123 @@
+package listingtemplate
+
+import (
+	"context"
+	"mime/multipart"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type memoryRepository struct {
+	Repository
+	templates map[uuid.UUID]ListingTemplate
+}
+
+func (r *memoryRepository) Create(_ context.Context, template *ListingTemplate) error {
+	template.ID = uuid.New()
+	r.templates[template.ID] = *template
+	return nil
+}
+
+func (r *memoryRepository) FindByID(_ context.Context, id uuid.UUID, userID uuid.UUID) (*ListingTemplate, error) {
+	template, ok := r.templates[id]
+	if !ok || template.UserID != userID {
+		return nil, common.ErrNotFound.WithDetails("Listing template not found.")
+	}
+	return &template, nil
+}
+
+func (r *memoryRepository) CountByUserID(_ context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	for _, template := range r.templates {
+		if template.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// fakeListings records the create requests it receives; other listing.Service methods are not used by templates.
+type fakeListings struct {
+	listing.Service
+	created []listing.CreateListingRequest
+}
+
+func (f *fakeListings) CreateListing(_ context.Context, userID uuid.UUID, req listing.CreateListingRequest, _ []*multipart.FileHeader) (*listing.Listing, error) {
+	f.created = append(f.created, req)
+	return &listing.Listing{BaseModel: common.BaseModel{ID: uuid.New()}, UserID: userID, Title: req.Title, Status: listing.StatusDraft}, nil
+}
+
+func newTestService() (*ServiceImplementation, *fakeListings) {
+	listings := &fakeListings{}
+	return &ServiceImplementation{
+		repo:           &memoryRepository{templates: make(map[uuid.UUID]ListingTemplate)},
+		listingService: listings,
+		logger:         zap.NewNop(),
+	}, listings
+}
+
+func TestCreateTemplateAllowsPartialFields(t *testing.T) {
+	svc, _ := newTestService()
+	venue := "Fremont Library"
+	publishAt := time.Now().Add(time.Hour)
+
+	template, err := svc.CreateTemplate(context.Background(), uuid.New(), CreateTemplateRequest{
+		Name: "Weekly story time",
+		Fields: listing.CreateListingRequest{
+			CategoryID:   uuid.New(),
+			EventDetails: &listing.CreateListingEventDetailsRequest{EventDate: "2024-06-01", VenueName: &venue},
+			Draft:        true,
+			PublishAt:    &publishAt,
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, template.Fields.Title)
+	assert.False(t, template.Fields.Draft, "publishing options are not stored")
+	assert.Nil(t, template.Fields.PublishAt)
+}
+
+func TestCreateTemplateValidatesFields(t *testing.T) {
+	svc, _ := newTestService()
+	userID := uuid.New()
+
+	_, err := svc.CreateTemplate(context.Background(), userID, CreateTemplateRequest{Name: "No category"})
+	assert.ErrorIs(t, err, common.ErrBadRequest)
+
+	badEmail := "not-an-email"
+	_, err = svc.CreateTemplate(context.Background(), userID, CreateTemplateRequest{
+		Name:   "Bad contact",
+		Fields: listing.CreateListingRequest{CategoryID: uuid.New(), Title: "Hi", ContactEmail: &badEmail},
+	})
+	var apiErr *common.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "VALIDATION_ERROR", apiErr.Code)
+}
+
+func TestCreateListingFromTemplateCreatesDraft(t *testing.T) {
+	svc, listings := newTestService()
+	userID := uuid.New()
+	template, err := svc.CreateTemplate(context.Background(), userID, CreateTemplateRequest{
+		Name:   "Weekly meetup",
+		Fields: listing.CreateListingRequest{CategoryID: uuid.New(), Title: "Thursday board games"},
+	})
+	require.NoError(t, err)
+
+	draft, err := svc.CreateListingFromTemplate(context.Background(), template.ID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, listing.StatusDraft, draft.Status)
+	require.Len(t, listings.created, 1)
+	assert.True(t, listings.created[0].Draft)
+	assert.Equal(t, "Thursday board games", listings.created[0].Title)
+
+	_, err = svc.CreateListingFromTemplate(context.Background(), template.ID, uuid.New())
+	assert.ErrorIs(t, err, common.ErrNotFound, "templates are private to their owner")
+	assert.Len(t, listings.created, 1)
+}
@@ -0,0 +1,153 @@
+// File: internal/messaging/contact.go
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/listing"
+	"seattle_info_backend/internal/platform/email"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// contactMessageWindow is the period over which a user's contact form messages are counted for rate limiting.
+const contactMessageWindow = time.Hour
+
+// ContactMessage records a message sent with a listing's contact form.
+type ContactMessage struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	ListingID      uuid.UUID  `gorm:"type:uuid;not null"`
+	SenderID       uuid.UUID  `gorm:"type:uuid;not null"`
+	ConversationID uuid.UUID  `gorm:"type:uuid;not null"`
+	MessageID      uuid.UUID  `gorm:"type:uuid;not null"`
+	EmailedAt      *time.Time // Nil when the owner could not be emailed
+	CreatedAt      time.Time  `gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM.
+func (ContactMessage) TableName() string {
+	return "listing_contact_messages"
+}
+
+// ContactListingRequest is the payload of POST /listings/:id/contact.
+type ContactListingRequest struct {
+	Body string `json:"body" binding:"required,min=1,max=2000"`
+}
+
+// ContactListing sends a message to the owner of a listing. The message is stored in the sender's conversation on
+// the listing, as with StartConversation, and relayed to the owner by email. The email never shows the sender's
+// address: it comes from EMAIL_FROM_ADDRESS and replies go to a relay address identifying the conversation.
+// A failed email does not fail the request; emailed reports whether it was sent.
+func (s *ServiceImplementation) ContactListing(ctx context.Context, senderID uuid.UUID, listingID uuid.UUID, req ContactListingRequest) (conversation *Conversation, message *Message, emailed bool, err error) {
+	if err := s.checkContactMessageLimit(ctx, senderID); err != nil {
+		return nil, nil, false, err
+	}
+	conversation, message, err = s.StartConversation(ctx, senderID, StartConversationRequest{ListingID: listingID, Body: req.Body})
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	record := &ContactMessage{
+		ListingID:      listingID,
+		SenderID:       senderID,
+		ConversationID: conversation.ID,
+		MessageID:      message.ID,
+	}
+	if s.relayContactEmail(ctx, conversation, message) {
+		now := time.Now().UTC()
+		record.EmailedAt = &now
+	}
+	if err := s.repo.CreateContactMessage(ctx, record); err != nil {
+		// The message is already delivered in the app; only the rate limit misses this one.
+		s.logger.Error("Failed to record contact message", zap.Error(err), zap.String("listingID", listingID.String()))
+	}
+	return conversation, message, record.EmailedAt != nil, nil
+}
+
+// checkContactMessageLimit rejects the message once the user has sent cfg.ContactMessagesPerHour contact form
+// messages in the last hour.
+func (s *ServiceImplementation) checkContactMessageLimit(ctx context.Context, senderID uuid.UUID) error {
+	limit := s.cfg.ContactMessagesPerHour
+	if limit <= 0 {
+		return nil
+	}
+	count, err := s.repo.CountContactMessagesSince(ctx, senderID, time.Now().Add(-contactMessageWindow))
+	if err != nil {
+		s.logger.Error("Failed to count contact messages", zap.Error(err), zap.String("userID", senderID.String()))
+		return common.ErrInternalServer.WithDetails("Could not send message.")
+	}
+	if count >= int64(limit) {
+		return common.ErrTooManyRequests.WithDetails("You have contacted too many listings. Please try again later.")
+	}
+	return nil
+}
+
+// relayContactEmail emails a contact form message to the listing's owner and reports whether it was sent.
+func (s *ServiceImplementation) relayContactEmail(ctx context.Context, conversation *Conversation, message *Message) bool {
+	if s.emailSender == nil || s.userRepo == nil {
+		return false
+	}
+	owner, err := s.userRepo.FindByID(ctx, conversation.SellerID)
+	if err != nil {
+		s.logger.Warn("Failed to look up listing owner for contact email", zap.Error(err), zap.String("conversationID", conversation.ID.String()))
+		return false
+	}
+	if owner.Email == nil || *owner.Email == "" {
+		return false
+	}
+	l, err := s.listingService.GetListingByID(ctx, conversation.ListingID, &conversation.SellerID)
+	if err != nil {
+		s.logger.Warn("Failed to load listing for contact email", zap.Error(err), zap.String("conversationID", conversation.ID.String()))
+		return false
+	}
+	senderName := "A Seattle Info user"
+	if sender, err := s.userRepo.FindByID(ctx, message.SenderID); err == nil && sender.FirstName != nil && *sender.FirstName != "" {
+		senderName = *sender.FirstName
+	}
+
+	err = s.emailSender.Send(ctx, email.Message{
+		To:      *owner.Email,
+		ReplyTo: s.contactReplyAddress(conversation),
+		Subject: fmt.Sprintf("New message about your listing '%s'", l.Title),
+		Body:    s.contactEmailBody(l, senderName, message.Body),
+	})
+	if err != nil {
+		s.logger.Warn("Failed to email contact message", zap.Error(err), zap.String("conversationID", conversation.ID.String()))
+		return false
+	}
+	return true
+}
+
+// contactReplyAddress returns the Reply-To address of relayed emails: reply+<conversation id>@CONTACT_RELAY_REPLY_DOMAIN,
+// or none, so replies go to EMAIL_FROM_ADDRESS, when no relay domain is configured.
+func (s *ServiceImplementation) contactReplyAddress(conversation *Conversation) string {
+	domain := strings.TrimSpace(s.cfg.ContactRelayReplyDomain)
+	if domain == "" {
+		return ""
+	}
+	return "reply+" + conversation.ID.String() + "@" + domain
+}
+
+// contactEmailBody is the text of a relayed contact form message.
+func (s *ServiceImplementation) contactEmailBody(l *listing.Listing, senderName, body string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s sent you a message about your listing '%s':\n\n", senderName, l.Title)
+	b.WriteString(body)
+	b.WriteString("\n\n")
+	reply := "Reply from your messages in the app"
+	if s.cfg.ContactRelayReplyDomain != "" {
+		b.WriteString("Reply to this email to answer; your email address is not shown to the sender.\n")
+		reply = "You can also reply from your messages in the app"
+	}
+	if webBaseURL := strings.TrimSuffix(s.cfg.WebBaseURL, "/"); webBaseURL != "" {
+		fmt.Fprintf(&b, "%s: %s/listings/%s\n", reply, webBaseURL, l.Slug)
+	} else {
+		b.WriteString(reply + ".\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,294 @@
+// File: internal/messaging/handler.go
+package messaging
+
+import (
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Handler struct holds dependencies for messaging handlers.
+type Handler struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewHandler creates a new messaging handler.
+func NewHandler(service Service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes sets up the routes for conversations and user blocks.
+// All messaging routes require authentication; captchaMW guards starting conversations and the contact form.
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMW, captchaMW gin.HandlerFunc) {
+	conversationGroup := router.Group("/conversations")
+	conversationGroup.Use(authMW)
+	{
+		conversationGroup.POST("", captchaMW, h.startConversation)
+		conversationGroup.GET("", h.listConversations)
+		conversationGroup.GET("/unread-count", h.getUnreadCount)
+		conversationGroup.GET("/:id", h.getConversation)
+		conversationGroup.GET("/:id/messages", h.listMessages)
+		conversationGroup.POST("/:id/messages", h.sendMessage)
+		conversationGroup.POST("/:id/read", h.markConversationRead)
+	}
+
+	// Contact form: a message to a listing's owner, relayed by email
+	router.POST("/listings/:id/contact", authMW, captchaMW, h.contactListing)
+
+	blockGroup := router.Group("/blocks")
+	blockGroup.Use(authMW)
+	{
+		blockGroup.GET("", h.listBlockedUsers)
+		blockGroup.POST("", h.blockUser)
+		blockGroup.DELETE("/:user_id", h.unblockUser)
+	}
+}
+
+func (h *Handler) startConversation(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	var req StartConversationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Start conversation: Invalid request body", zap.Error(err))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	conversation, message, err := h.service.StartConversation(c.Request.Context(), userID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondCreated(c, "Message sent successfully.", gin.H{
+		"conversation": ToConversationResponse(conversation),
+		"message":      ToMessageResponse(message),
+	})
+}
+
+// contactListing sends a message to a listing's owner, stored in the conversation and relayed by email.
+func (h *Handler) contactListing(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	listingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid listing ID format."))
+		return
+	}
+
+	var req ContactListingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Contact listing: Invalid request body", zap.Error(err), zap.String("listingID", listingID.String()))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	conversation, message, emailed, err := h.service.ContactListing(c.Request.Context(), userID, listingID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondCreated(c, "Message sent successfully.", gin.H{
+		"conversation": ToConversationResponse(conversation),
+		"message":      ToMessageResponse(message),
+		"emailed":      emailed,
+	})
+}
+
+func (h *Handler) listConversations(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	page, pageSize := common.GetPaginationParams(c)
+	conversations, pagination, err := h.service.ListConversations(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]ConversationResponse, len(conversations))
+	for i := range conversations {
+		responses[i] = ToConversationResponse(&conversations[i])
+	}
+	common.RespondPaginated(c, "Conversations retrieved successfully.", responses, pagination)
+}
+
+func (h *Handler) getUnreadCount(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	count, err := h.service.GetUnreadCount(c.Request.Context(), userID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Unread message count retrieved successfully.", gin.H{"unread_count": count})
+}
+
+func (h *Handler) getConversation(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid conversation ID format."))
+		return
+	}
+
+	conversation, err := h.service.GetConversation(c.Request.Context(), conversationID, userID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Conversation retrieved successfully.", ToConversationResponse(conversation))
+}
+
+func (h *Handler) listMessages(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid conversation ID format."))
+		return
+	}
+
+	page, pageSize := common.GetPaginationParams(c)
+	messages, pagination, err := h.service.ListMessages(c.Request.Context(), conversationID, userID, page, pageSize)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]MessageResponse, len(messages))
+	for i := range messages {
+		responses[i] = ToMessageResponse(&messages[i])
+	}
+	common.RespondPaginated(c, "Messages retrieved successfully.", responses, pagination)
+}
+
+func (h *Handler) sendMessage(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid conversation ID format."))
+		return
+	}
+
+	var req SendMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Send message: Invalid request body", zap.Error(err), zap.String("conversationID", conversationID.String()))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	message, err := h.service.SendMessage(c.Request.Context(), conversationID, userID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondCreated(c, "Message sent successfully.", ToMessageResponse(message))
+}
+
+func (h *Handler) markConversationRead(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid conversation ID format."))
+		return
+	}
+
+	if err := h.service.MarkConversationRead(c.Request.Context(), conversationID, userID); err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Conversation marked as read successfully.", nil)
+}
+
+func (h *Handler) listBlockedUsers(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	blocks, err := h.service.ListBlockedUsers(c.Request.Context(), userID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]BlockResponse, len(blocks))
+	for i := range blocks {
+		responses[i] = ToBlockResponse(&blocks[i])
+	}
+	common.RespondOK(c, "Blocked users retrieved successfully.", responses)
+}
+
+func (h *Handler) blockUser(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	var req BlockUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Block user: Invalid request body", zap.Error(err))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	block, err := h.service.BlockUser(c.Request.Context(), userID, req.UserID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondCreated(c, "User blocked successfully.", ToBlockResponse(block))
+}
+
+func (h *Handler) unblockUser(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	blockedID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid user ID format."))
+		return
+	}
+
+	if err := h.service.UnblockUser(c.Request.Context(), userID, blockedID); err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondNoContent(c)
+}
@@ -0,0 +1,146 @@
+// File: internal/messaging/model.go
+package messaging
+
+import (
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+)
+
+// Conversation is a private thread between a prospective buyer and the poster of a listing.
+// There is at most one conversation per (listing, buyer) pair.
+type Conversation struct {
+	common.BaseModel
+	ListingID        uuid.UUID `gorm:"type:uuid;not null"`
+	BuyerID          uuid.UUID `gorm:"type:uuid;not null"` // User who started the conversation
+	SellerID         uuid.UUID `gorm:"type:uuid;not null"` // Owner of the listing
+	LastMessageAt    time.Time `gorm:"not null;default:current_timestamp"`
+	BuyerLastReadAt  *time.Time
+	SellerLastReadAt *time.Time
+
+	// UnreadCount is computed per viewer when listing conversations; it is not a column.
+	UnreadCount int64 `gorm:"->;-:migration"`
+}
+
+// TableName specifies the table name for GORM.
+func (Conversation) TableName() string {
+	return "conversations"
+}
+
+// IsParticipant reports whether userID is the buyer or the seller.
+func (c *Conversation) IsParticipant(userID uuid.UUID) bool {
+	return c.BuyerID == userID || c.SellerID == userID
+}
+
+// OtherParticipant returns the ID of the participant who is not userID.
+func (c *Conversation) OtherParticipant(userID uuid.UUID) uuid.UUID {
+	if c.BuyerID == userID {
+		return c.SellerID
+	}
+	return c.BuyerID
+}
+
+// Message is a single message in a conversation.
+type Message struct {
+	common.BaseModel
+	ConversationID uuid.UUID `gorm:"type:uuid;not null"`
+	SenderID       uuid.UUID `gorm:"type:uuid;not null"`
+	Body           string    `gorm:"type:text;not null"`
+}
+
+// TableName specifies the table name for GORM.
+func (Message) TableName() string {
+	return "messages"
+}
+
+// UserBlock records that BlockerID does not want to exchange messages with BlockedID.
+type UserBlock struct {
+	BlockerID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	BlockedID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `gorm:"not null;default:current_timestamp"`
+}
+
+// TableName specifies the table name for GORM.
+func (UserBlock) TableName() string {
+	return "user_blocks"
+}
+
+// --- Request DTOs ---
+
+// StartConversationRequest opens (or reuses) a conversation on a listing with a first message.
+type StartConversationRequest struct {
+	ListingID uuid.UUID `json:"listing_id" binding:"required"`
+	Body      string    `json:"body" binding:"required,min=1,max=2000"`
+}
+
+// SendMessageRequest is the payload for posting a message to a conversation.
+type SendMessageRequest struct {
+	Body string `json:"body" binding:"required,min=1,max=2000"`
+}
+
+// BlockUserRequest is the payload for blocking a user.
+type BlockUserRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required"`
+}
+
+// --- Response DTOs ---
+
+// ConversationResponse is the API representation of a conversation from the viewer's side.
+type ConversationResponse struct {
+	ID            uuid.UUID `json:"id"`
+	ListingID     uuid.UUID `json:"listing_id"`
+	BuyerID       uuid.UUID `json:"buyer_id"`
+	SellerID      uuid.UUID `json:"seller_id"`
+	LastMessageAt time.Time `json:"last_message_at"`
+	UnreadCount   int64     `json:"unread_count"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// MessageResponse is the API representation of a message.
+type MessageResponse struct {
+	ID             uuid.UUID `json:"id"`
+	ConversationID uuid.UUID `json:"conversation_id"`
+	SenderID       uuid.UUID `json:"sender_id"`
+	Body           string    `json:"body"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// BlockResponse is the API representation of a block.
+type BlockResponse struct {
+	UserID    uuid.UUID `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToConversationResponse converts a Conversation model to a ConversationResponse DTO.
+func ToConversationResponse(c *Conversation) ConversationResponse {
+	return ConversationResponse{
+		ID:            c.ID,
+		ListingID:     c.ListingID,
+		BuyerID:       c.BuyerID,
+		SellerID:      c.SellerID,
+		LastMessageAt: c.LastMessageAt,
+		UnreadCount:   c.UnreadCount,
+		CreatedAt:     c.CreatedAt,
+	}
+}
+
+// ToMessageResponse converts a Message model to a MessageResponse DTO.
+func ToMessageResponse(m *Message) MessageResponse {
+	return MessageResponse{
+		ID:             m.ID,
+		ConversationID: m.ConversationID,
+		SenderID:       m.SenderID,
+		Body:           m.Body,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+// ToBlockResponse converts a UserBlock model to a BlockResponse DTO.
+func ToBlockResponse(b *UserBlock) BlockResponse {
+	return BlockResponse{
+		UserID:    b.BlockedID,
+		CreatedAt: b.CreatedAt,
+	}
+}
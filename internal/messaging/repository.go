@@ -0,0 +1,255 @@
+// File: internal/messaging/repository.go
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// unreadCountSQL counts messages in a conversation sent by the other participant after the viewer last read it.
+// It expects the viewer's user ID twice.
+const unreadCountSQL = `(SELECT COUNT(*) FROM messages m
+	WHERE m.conversation_id = conversations.id
+	AND m.sender_id <> ?
+	AND m.created_at > COALESCE(
+		CASE WHEN conversations.buyer_id = ? THEN conversations.buyer_last_read_at ELSE conversations.seller_last_read_at END,
+		'-infinity'::timestamptz))`
+
+// Repository defines the interface for conversation, message and block data operations.
+type Repository interface {
+	CreateConversation(ctx context.Context, conversation *Conversation) error
+	FindConversationByID(ctx context.Context, id uuid.UUID) (*Conversation, error)
+	FindConversationByListingAndBuyer(ctx context.Context, listingID, buyerID uuid.UUID) (*Conversation, error)
+	FindConversationsByUser(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]Conversation, *common.Pagination, error)
+	MarkConversationRead(ctx context.Context, conversation *Conversation, userID uuid.UUID, at time.Time) error
+	CountUnreadInConversation(ctx context.Context, conversationID, userID uuid.UUID) (int64, error)
+	CountUnreadForUser(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	AddMessage(ctx context.Context, conversation *Conversation, message *Message) error
+	FindMessages(ctx context.Context, conversationID uuid.UUID, page, pageSize int) ([]Message, *common.Pagination, error)
+
+	CreateBlock(ctx context.Context, block *UserBlock) error
+	DeleteBlock(ctx context.Context, blockerID, blockedID uuid.UUID) error
+	FindBlocksByUser(ctx context.Context, blockerID uuid.UUID) ([]UserBlock, error)
+	IsBlockedEitherWay(ctx context.Context, userA, userB uuid.UUID) (bool, error)
+
+	CreateContactMessage(ctx context.Context, record *ContactMessage) error
+	CountContactMessagesSince(ctx context.Context, senderID uuid.UUID, since time.Time) (int64, error)
+}
+
+// GORMRepository implements the messaging Repository interface using GORM.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+// NewGORMRepository creates a new GORM messaging repository.
+func NewGORMRepository(db *gorm.DB) Repository {
+	return &GORMRepository{db: db}
+}
+
+// CreateConversation inserts a new conversation.
+func (r *GORMRepository) CreateConversation(ctx context.Context, conversation *Conversation) error {
+	if err := r.db.WithContext(ctx).Create(conversation).Error; err != nil {
+		return fmt.Errorf("failed to create conversation: %w", err)
+	}
+	return nil
+}
+
+// FindConversationByID retrieves a conversation by its ID.
+func (r *GORMRepository) FindConversationByID(ctx context.Context, id uuid.UUID) (*Conversation, error) {
+	var conversation Conversation
+	if err := r.db.WithContext(ctx).First(&conversation, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Conversation not found.")
+		}
+		return nil, fmt.Errorf("failed to find conversation: %w", err)
+	}
+	return &conversation, nil
+}
+
+// FindConversationByListingAndBuyer retrieves the buyer's existing conversation on a listing.
+func (r *GORMRepository) FindConversationByListingAndBuyer(ctx context.Context, listingID, buyerID uuid.UUID) (*Conversation, error) {
+	var conversation Conversation
+	err := r.db.WithContext(ctx).
+		Where("listing_id = ? AND buyer_id = ?", listingID, buyerID).
+		First(&conversation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Conversation not found.")
+		}
+		return nil, fmt.Errorf("failed to find conversation: %w", err)
+	}
+	return &conversation, nil
+}
+
+// FindConversationsByUser retrieves the user's conversations, most recently active first,
+// with the number of messages the user has not read yet.
+func (r *GORMRepository) FindConversationsByUser(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]Conversation, *common.Pagination, error) {
+	var conversations []Conversation
+	var totalItems int64
+
+	dbQuery := r.db.WithContext(ctx).Model(&Conversation{}).Where("buyer_id = ? OR seller_id = ?", userID, userID)
+	if err := dbQuery.Count(&totalItems).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to count conversations: %w", err)
+	}
+
+	offset := (page - 1) * pageSize
+	err := dbQuery.
+		Select("conversations.*, "+unreadCountSQL+" AS unread_count", userID, userID).
+		Order("last_message_at DESC").
+		Offset(offset).Limit(pageSize).
+		Find(&conversations).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list conversations: %w", err)
+	}
+	return conversations, common.NewPagination(totalItems, page, pageSize), nil
+}
+
+// MarkConversationRead records that userID has read the conversation up to at.
+func (r *GORMRepository) MarkConversationRead(ctx context.Context, conversation *Conversation, userID uuid.UUID, at time.Time) error {
+	column := "seller_last_read_at"
+	if conversation.BuyerID == userID {
+		column = "buyer_last_read_at"
+	}
+	err := r.db.WithContext(ctx).Model(&Conversation{}).
+		Where("id = ?", conversation.ID).
+		UpdateColumn(column, at).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark conversation as read: %w", err)
+	}
+	return nil
+}
+
+// CountUnreadInConversation counts the messages in one conversation that userID has not read.
+func (r *GORMRepository) CountUnreadInConversation(ctx context.Context, conversationID, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Conversation{}).
+		Select(unreadCountSQL, userID, userID).
+		Where("id = ?", conversationID).
+		Scan(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread messages: %w", err)
+	}
+	return count, nil
+}
+
+// CountUnreadForUser counts unread messages across all of the user's conversations.
+func (r *GORMRepository) CountUnreadForUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Conversation{}).
+		Select("COALESCE(SUM("+unreadCountSQL+"), 0)", userID, userID).
+		Where("buyer_id = ? OR seller_id = ?", userID, userID).
+		Scan(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread messages: %w", err)
+	}
+	return count, nil
+}
+
+// AddMessage inserts a message, bumps the conversation's activity time and marks it read for the sender.
+func (r *GORMRepository) AddMessage(ctx context.Context, conversation *Conversation, message *Message) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(message).Error; err != nil {
+			return fmt.Errorf("failed to create message: %w", err)
+		}
+		readColumn := "seller_last_read_at"
+		if conversation.BuyerID == message.SenderID {
+			readColumn = "buyer_last_read_at"
+		}
+		err := tx.Model(&Conversation{}).Where("id = ?", conversation.ID).Updates(map[string]interface{}{
+			"last_message_at": message.CreatedAt,
+			readColumn:        message.CreatedAt,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to update conversation: %w", err)
+		}
+		return nil
+	})
+}
+
+// FindMessages retrieves a page of messages in a conversation, newest first.
+func (r *GORMRepository) FindMessages(ctx context.Context, conversationID uuid.UUID, page, pageSize int) ([]Message, *common.Pagination, error) {
+	var messages []Message
+	var totalItems int64
+
+	dbQuery := r.db.WithContext(ctx).Model(&Message{}).Where("conversation_id = ?", conversationID)
+	if err := dbQuery.Count(&totalItems).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to count messages: %w", err)
+	}
+
+	offset := (page - 1) * pageSize
+	if err := dbQuery.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&messages).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to list messages: %w", err)
+	}
+	return messages, common.NewPagination(totalItems, page, pageSize), nil
+}
+
+// CreateBlock records a block. Blocking an already blocked user is a no-op.
+func (r *GORMRepository) CreateBlock(ctx context.Context, block *UserBlock) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(block).Error
+	if err != nil {
+		return fmt.Errorf("failed to create user block: %w", err)
+	}
+	return nil
+}
+
+// DeleteBlock removes a block.
+func (r *GORMRepository) DeleteBlock(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&UserBlock{}, "blocker_id = ? AND blocked_id = ?", blockerID, blockedID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete user block: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound.WithDetails("User is not blocked.")
+	}
+	return nil
+}
+
+// FindBlocksByUser retrieves the users blocked by blockerID, newest first.
+func (r *GORMRepository) FindBlocksByUser(ctx context.Context, blockerID uuid.UUID) ([]UserBlock, error) {
+	var blocks []UserBlock
+	if err := r.db.WithContext(ctx).Where("blocker_id = ?", blockerID).Order("created_at DESC").Find(&blocks).Error; err != nil {
+		return nil, fmt.Errorf("failed to list user blocks: %w", err)
+	}
+	return blocks, nil
+}
+
+// IsBlockedEitherWay reports whether either user has blocked the other.
+func (r *GORMRepository) IsBlockedEitherWay(ctx context.Context, userA, userB uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&UserBlock{}).
+		Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)", userA, userB, userB, userA).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check user block: %w", err)
+	}
+	return count > 0, nil
+}
+
+// CreateContactMessage records a message sent with a listing's contact form.
+func (r *GORMRepository) CreateContactMessage(ctx context.Context, record *ContactMessage) error {
+	if err := r.db.WithContext(ctx).Create(record).Error; err != nil {
+		return fmt.Errorf("failed to create contact message: %w", err)
+	}
+	return nil
+}
+
+// CountContactMessagesSince counts the contact form messages the user sent from since onwards.
+func (r *GORMRepository) CountContactMessagesSince(ctx context.Context, senderID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&ContactMessage{}).
+		Where("sender_id = ? AND created_at >= ?", senderID, since).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count contact messages: %w", err)
+	}
+	return count, nil
+}
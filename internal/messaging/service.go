@@ -0,0 +1,278 @@
+// File: internal/messaging/service.go
+package messaging
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/listing"
+	"seattle_info_backend/internal/notification"
+	"seattle_info_backend/internal/platform/email"
+	"seattle_info_backend/internal/user"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Service defines the interface for in-app messaging business logic.
+type Service interface {
+	StartConversation(ctx context.Context, buyerID uuid.UUID, req StartConversationRequest) (*Conversation, *Message, error)
+	ContactListing(ctx context.Context, senderID uuid.UUID, listingID uuid.UUID, req ContactListingRequest) (*Conversation, *Message, bool, error)
+	ListConversations(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]Conversation, *common.Pagination, error)
+	GetConversation(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*Conversation, error)
+	SendMessage(ctx context.Context, conversationID uuid.UUID, senderID uuid.UUID, req SendMessageRequest) (*Message, error)
+	ListMessages(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, page, pageSize int) ([]Message, *common.Pagination, error)
+	MarkConversationRead(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error
+	GetUnreadCount(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	BlockUser(ctx context.Context, blockerID uuid.UUID, blockedID uuid.UUID) (*UserBlock, error)
+	UnblockUser(ctx context.Context, blockerID uuid.UUID, blockedID uuid.UUID) error
+	ListBlockedUsers(ctx context.Context, blockerID uuid.UUID) ([]UserBlock, error)
+}
+
+// ServiceImplementation implements the messaging Service interface.
+type ServiceImplementation struct {
+	repo                Repository
+	listingService      listing.Service
+	notificationService notification.Service
+	userRepo            user.Repository
+	emailSender         email.Sender
+	cfg                 *config.Config
+	logger              *zap.Logger
+}
+
+// NewService creates a new messaging service.
+func NewService(repo Repository, listingService listing.Service, notificationService notification.Service, userRepo user.Repository, emailSender email.Sender, cfg *config.Config, logger *zap.Logger) Service {
+	return &ServiceImplementation{
+		repo:                repo,
+		listingService:      listingService,
+		notificationService: notificationService,
+		userRepo:            userRepo,
+		emailSender:         emailSender,
+		cfg:                 cfg,
+		logger:              logger,
+	}
+}
+
+// StartConversation contacts the poster of a listing. If the buyer already has a conversation on
+// the listing, the message is added to it instead of opening a new one.
+func (s *ServiceImplementation) StartConversation(ctx context.Context, buyerID uuid.UUID, req StartConversationRequest) (*Conversation, *Message, error) {
+	l, err := s.listingService.GetListingByID(ctx, req.ListingID, &buyerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if l.UserID == buyerID {
+		return nil, nil, common.ErrBadRequest.WithDetails("You cannot start a conversation on your own listing.")
+	}
+
+	conversation, err := s.repo.FindConversationByListingAndBuyer(ctx, l.ID, buyerID)
+	if err != nil {
+		if _, ok := err.(*common.APIError); !ok {
+			s.logger.Error("Failed to look up conversation", zap.Error(err), zap.String("listingID", l.ID.String()))
+			return nil, nil, common.ErrInternalServer.WithDetails("Could not start conversation.")
+		}
+		if l.Status != listing.StatusActive {
+			return nil, nil, common.ErrBadRequest.WithDetails("Only active listings can be contacted.")
+		}
+		if err := s.ensureNotBlocked(ctx, buyerID, l.UserID); err != nil {
+			return nil, nil, err
+		}
+		conversation = &Conversation{
+			ListingID:     l.ID,
+			BuyerID:       buyerID,
+			SellerID:      l.UserID,
+			LastMessageAt: time.Now().UTC(),
+		}
+		if err := s.repo.CreateConversation(ctx, conversation); err != nil {
+			s.logger.Error("Failed to create conversation", zap.Error(err), zap.String("listingID", l.ID.String()))
+			return nil, nil, common.ErrInternalServer.WithDetails("Could not start conversation.")
+		}
+	}
+
+	message, err := s.SendMessage(ctx, conversation.ID, buyerID, SendMessageRequest{Body: req.Body})
+	if err != nil {
+		return nil, nil, err
+	}
+	return conversation, message, nil
+}
+
+// ListConversations returns the user's conversations with per-conversation unread counts.
+func (s *ServiceImplementation) ListConversations(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]Conversation, *common.Pagination, error) {
+	conversations, pagination, err := s.repo.FindConversationsByUser(ctx, userID, page, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to list conversations", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, nil, common.ErrInternalServer.WithDetails("Could not retrieve conversations.")
+	}
+	return conversations, pagination, nil
+}
+
+// GetConversation returns a conversation if userID takes part in it.
+func (s *ServiceImplementation) GetConversation(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*Conversation, error) {
+	conversation, err := s.repo.FindConversationByID(ctx, id)
+	if err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to get conversation", zap.Error(err), zap.String("conversationID", id.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve conversation.")
+	}
+	if !conversation.IsParticipant(userID) {
+		// Do not reveal that the conversation exists.
+		return nil, common.ErrNotFound.WithDetails("Conversation not found.")
+	}
+	return conversation, nil
+}
+
+// SendMessage posts a message to a conversation. The recipient gets a notification when this is
+// the first message they have not read yet, so a burst of messages produces a single notification.
+func (s *ServiceImplementation) SendMessage(ctx context.Context, conversationID uuid.UUID, senderID uuid.UUID, req SendMessageRequest) (*Message, error) {
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, common.ErrBadRequest.WithDetails("Message body cannot be empty.")
+	}
+
+	conversation, err := s.GetConversation(ctx, conversationID, senderID)
+	if err != nil {
+		return nil, err
+	}
+	recipientID := conversation.OtherParticipant(senderID)
+	if err := s.ensureNotBlocked(ctx, senderID, recipientID); err != nil {
+		return nil, err
+	}
+
+	message := &Message{
+		ConversationID: conversation.ID,
+		SenderID:       senderID,
+		Body:           body,
+	}
+	message.CreatedAt = time.Now().UTC()
+	if err := s.repo.AddMessage(ctx, conversation, message); err != nil {
+		s.logger.Error("Failed to send message", zap.Error(err), zap.String("conversationID", conversation.ID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not send message.")
+	}
+
+	s.notifyRecipient(ctx, conversation, recipientID)
+	return message, nil
+}
+
+// ListMessages returns a page of messages (newest first) and marks the conversation as read.
+func (s *ServiceImplementation) ListMessages(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, page, pageSize int) ([]Message, *common.Pagination, error) {
+	conversation, err := s.GetConversation(ctx, conversationID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	messages, pagination, err := s.repo.FindMessages(ctx, conversation.ID, page, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to list messages", zap.Error(err), zap.String("conversationID", conversation.ID.String()))
+		return nil, nil, common.ErrInternalServer.WithDetails("Could not retrieve messages.")
+	}
+	if page == 1 {
+		if err := s.repo.MarkConversationRead(ctx, conversation, userID, time.Now().UTC()); err != nil {
+			s.logger.Warn("Failed to mark conversation as read", zap.Error(err), zap.String("conversationID", conversation.ID.String()))
+		}
+	}
+	return messages, pagination, nil
+}
+
+// MarkConversationRead marks every message in the conversation as read for userID.
+func (s *ServiceImplementation) MarkConversationRead(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error {
+	conversation, err := s.GetConversation(ctx, conversationID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.MarkConversationRead(ctx, conversation, userID, time.Now().UTC()); err != nil {
+		s.logger.Error("Failed to mark conversation as read", zap.Error(err), zap.String("conversationID", conversation.ID.String()))
+		return common.ErrInternalServer.WithDetails("Could not mark conversation as read.")
+	}
+	return nil
+}
+
+// GetUnreadCount returns the number of unread messages across all of the user's conversations.
+func (s *ServiceImplementation) GetUnreadCount(ctx context.Context, userID uuid.UUID) (int64, error) {
+	count, err := s.repo.CountUnreadForUser(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to count unread messages", zap.Error(err), zap.String("userID", userID.String()))
+		return 0, common.ErrInternalServer.WithDetails("Could not count unread messages.")
+	}
+	return count, nil
+}
+
+// BlockUser stops blockedID and blockerID from messaging each other.
+func (s *ServiceImplementation) BlockUser(ctx context.Context, blockerID uuid.UUID, blockedID uuid.UUID) (*UserBlock, error) {
+	if blockerID == blockedID {
+		return nil, common.ErrBadRequest.WithDetails("You cannot block yourself.")
+	}
+	block := &UserBlock{BlockerID: blockerID, BlockedID: blockedID, CreatedAt: time.Now().UTC()}
+	if err := s.repo.CreateBlock(ctx, block); err != nil {
+		s.logger.Error("Failed to block user", zap.Error(err), zap.String("blockerID", blockerID.String()), zap.String("blockedID", blockedID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not block user.")
+	}
+	s.logger.Info("User blocked", zap.String("blockerID", blockerID.String()), zap.String("blockedID", blockedID.String()))
+	return block, nil
+}
+
+// UnblockUser removes a block created by blockerID.
+func (s *ServiceImplementation) UnblockUser(ctx context.Context, blockerID uuid.UUID, blockedID uuid.UUID) error {
+	if err := s.repo.DeleteBlock(ctx, blockerID, blockedID); err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return err
+		}
+		s.logger.Error("Failed to unblock user", zap.Error(err), zap.String("blockerID", blockerID.String()), zap.String("blockedID", blockedID.String()))
+		return common.ErrInternalServer.WithDetails("Could not unblock user.")
+	}
+	return nil
+}
+
+// ListBlockedUsers returns the users blocked by blockerID.
+func (s *ServiceImplementation) ListBlockedUsers(ctx context.Context, blockerID uuid.UUID) ([]UserBlock, error) {
+	blocks, err := s.repo.FindBlocksByUser(ctx, blockerID)
+	if err != nil {
+		s.logger.Error("Failed to list blocked users", zap.Error(err), zap.String("blockerID", blockerID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve blocked users.")
+	}
+	return blocks, nil
+}
+
+// ensureNotBlocked returns ErrForbidden if either user has blocked the other.
+func (s *ServiceImplementation) ensureNotBlocked(ctx context.Context, senderID, recipientID uuid.UUID) error {
+	blocked, err := s.repo.IsBlockedEitherWay(ctx, senderID, recipientID)
+	if err != nil {
+		s.logger.Error("Failed to check user block", zap.Error(err))
+		return common.ErrInternalServer.WithDetails("Could not send message.")
+	}
+	if blocked {
+		return common.ErrForbidden.WithDetails("You cannot message this user.")
+	}
+	return nil
+}
+
+// notifyRecipient creates a new-message notification unless the recipient already had unread messages
+// in the conversation.
+func (s *ServiceImplementation) notifyRecipient(ctx context.Context, conversation *Conversation, recipientID uuid.UUID) {
+	if s.notificationService == nil {
+		return
+	}
+	unread, err := s.repo.CountUnreadInConversation(ctx, conversation.ID, recipientID)
+	if err != nil {
+		s.logger.Warn("Failed to count unread messages for notification", zap.Error(err), zap.String("conversationID", conversation.ID.String()))
+		return
+	}
+	if unread != 1 {
+		return
+	}
+
+	notifMessage := "You have a new message about a listing you contacted."
+	if recipientID == conversation.SellerID {
+		notifMessage = "You have a new message about your listing."
+	}
+	if _, err := s.notificationService.CreateNotification(ctx, recipientID, notification.NewMessage, notifMessage, &conversation.ListingID); err != nil {
+		s.logger.Error("Failed to send new message notification",
+			zap.Error(err),
+			zap.String("conversationID", conversation.ID.String()),
+			zap.String("userID", recipientID.String()),
+		)
+	}
+}
@@ -0,0 +1,48 @@
+// File: internal/middleware/anonsession.go
+package middleware
+
+import (
+	"seattle_info_backend/internal/anonsession"
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AnonymousSessionHeader is the header visitors who are not signed in send their anonymous session token in.
+const AnonymousSessionHeader = "X-Anonymous-Session"
+
+// AnonymousSessionMiddleware identifies the anonymous session of requests that carry AnonymousSessionHeader.
+// Browsing never fails because of the session: a missing, invalid or expired token leaves the request anonymous.
+func AnonymousSessionMiddleware(sessionService anonsession.Service, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token := c.GetHeader(AnonymousSessionHeader); token != "" {
+			if session, err := sessionService.Resolve(c.Request.Context(), token); err != nil {
+				logger.Debug("Anonymous session not recognized", zap.Error(err))
+			} else {
+				c.Set(common.AnonymousSessionIDKey, session.ID)
+			}
+		}
+		c.Next()
+	}
+}
+
+// RequireAnonymousSessionMiddleware is AnonymousSessionMiddleware for routes that act on the session itself:
+// requests without a valid session are rejected.
+func RequireAnonymousSessionMiddleware(sessionService anonsession.Service, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader(AnonymousSessionHeader)
+		if token == "" {
+			common.RespondWithError(c, common.ErrUnauthorized.WithDetails(AnonymousSessionHeader+" header is required."))
+			return
+		}
+		session, err := sessionService.Resolve(c.Request.Context(), token)
+		if err != nil {
+			logger.Debug("Anonymous session rejected", zap.Error(err))
+			common.RespondWithError(c, err)
+			return
+		}
+		c.Set(common.AnonymousSessionIDKey, session.ID)
+		c.Next()
+	}
+}
@@ -0,0 +1,87 @@
+// File: internal/middleware/anonymous.go
+package middleware
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AnonymousBrowseMiddleware guards the public browse routes. Requests with an Authorization header are
+// authenticated by authMW, as with OptionalAuthMiddleware. Other requests carry no user: they are marked as
+// anonymous, so common.RespondSuccess trims contact details, addresses and coordinates from their responses,
+// and each client IP may make requestsPerMinute of them (0 disables the limit).
+func AnonymousBrowseMiddleware(authMW gin.HandlerFunc, requestsPerMinute int, logger *zap.Logger) gin.HandlerFunc {
+	quota := newIPQuota(time.Minute)
+	return func(c *gin.Context) {
+		if c.GetHeader(common.AuthorizationHeader) != "" {
+			authMW(c)
+			return
+		}
+
+		if requestsPerMinute > 0 && !quota.Allow(c.ClientIP(), requestsPerMinute) {
+			logger.Info("Anonymous request quota exceeded", zap.String("ip", c.ClientIP()), zap.String("path", c.Request.URL.Path))
+			c.Header("Retry-After", strconv.Itoa(int(quota.window/time.Second)))
+			common.RespondWithError(c, common.ErrTooManyRequests.WithDetails("Sign in to browse without the anonymous rate limit."))
+			return
+		}
+		c.Set(common.AnonymousBrowseKey, true)
+		c.Next()
+	}
+}
+
+// ipQuota is an in-memory fixed-window request counter keyed by client IP.
+// Counts are per process; a multi-instance deployment allows limit*instances in total.
+type ipQuota struct {
+	mu        sync.Mutex
+	window    time.Duration
+	windows   map[string]*quotaWindow
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type quotaWindow struct {
+	start time.Time
+	count int
+}
+
+func newIPQuota(window time.Duration) *ipQuota {
+	return &ipQuota{window: window, windows: make(map[string]*quotaWindow), now: time.Now}
+}
+
+// Allow records a request from ip and reports whether it is within limit for the current window.
+func (q *ipQuota) Allow(ip string, limit int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.now()
+	q.sweep(now)
+	w, ok := q.windows[ip]
+	if !ok || now.Sub(w.start) >= q.window {
+		q.windows[ip] = &quotaWindow{start: now, count: 1}
+		return true
+	}
+	if w.count >= limit {
+		return false
+	}
+	w.count++
+	return true
+}
+
+// sweep drops expired windows once per window, so addresses that stopped calling do not accumulate.
+func (q *ipQuota) sweep(now time.Time) {
+	if now.Sub(q.lastSweep) < q.window {
+		return
+	}
+	for ip, w := range q.windows {
+		if now.Sub(w.start) >= q.window {
+			delete(q.windows, ip)
+		}
+	}
+	q.lastSweep = now
+}
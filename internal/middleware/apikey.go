@@ -0,0 +1,50 @@
+// File: internal/middleware/apikey.go
+package middleware
+
+import (
+	"seattle_info_backend/internal/apikey"
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// APIKeyHeader is the header partners send their API key in.
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyMiddleware authenticates partner requests by X-API-Key, enforces the required scope
+// and the key's rate limit, and counts the request towards the key's usage.
+func APIKeyMiddleware(apiKeyService apikey.Service, requiredScope string, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		plaintext := c.GetHeader(APIKeyHeader)
+		if plaintext == "" {
+			common.RespondWithError(c, common.ErrUnauthorized.WithDetails(APIKeyHeader+" header is required."))
+			return
+		}
+
+		key, err := apiKeyService.Authenticate(c.Request.Context(), plaintext)
+		if err != nil {
+			logger.Debug("API key authentication failed", zap.Error(err))
+			common.RespondWithError(c, err)
+			return
+		}
+
+		if !key.HasScope(requiredScope) {
+			logger.Warn("API key missing required scope",
+				zap.String("apiKeyID", key.ID.String()),
+				zap.String("requiredScope", requiredScope))
+			common.RespondWithError(c, common.ErrForbidden.WithDetails("API key does not have the '"+requiredScope+"' scope."))
+			return
+		}
+
+		if !apiKeyService.AllowRequest(key) {
+			logger.Warn("API key rate limit exceeded", zap.String("apiKeyID", key.ID.String()))
+			common.RespondWithError(c, common.ErrTooManyRequests.WithDetails("Rate limit exceeded for this API key."))
+			return
+		}
+
+		apiKeyService.RecordUsage(c.Request.Context(), key)
+		c.Set(common.APIKeyIDKey, key.ID)
+		c.Next()
+	}
+}
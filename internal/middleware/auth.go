@@ -3,36 +3,63 @@ package middleware
 
 import (
 	"strings"
+	"time"
 
+	"seattle_info_backend/internal/abuse"
 	"seattle_info_backend/internal/auth"
 	"seattle_info_backend/internal/common" // For common.RespondWithError and error types
 	"seattle_info_backend/internal/firebase"
 	"seattle_info_backend/internal/shared" // For shared.Service (user service)
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// Authenticator verifies the Firebase ID token in the Authorization header and sets the user in the context. If
+// the request is not authenticated it responds with the error and returns false.
+type Authenticator func(c *gin.Context) bool
+
 // AuthMiddleware creates a Gin middleware for Firebase authentication.
 func AuthMiddleware(
 	firebaseService *firebase.FirebaseService,
 	userService shared.Service,
 	blocklistService auth.TokenBlocklistService, // Add blocklist service
+	abuseService abuse.Service,
 	logger *zap.Logger,
 ) gin.HandlerFunc {
+	authenticate := NewAuthenticator(firebaseService, userService, blocklistService, abuseService, logger)
 	return func(c *gin.Context) {
+		// The request may already be authenticated earlier in the chain, by MaintenanceMiddleware.
+		if common.GetUserIDFromContext(c) == uuid.Nil && !authenticate(c) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// NewAuthenticator creates the Authenticator behind AuthMiddleware, for middleware that needs the user before the
+// route's own authentication runs.
+func NewAuthenticator(
+	firebaseService *firebase.FirebaseService,
+	userService shared.Service,
+	blocklistService auth.TokenBlocklistService,
+	abuseService abuse.Service,
+	logger *zap.Logger,
+) Authenticator {
+	return func(c *gin.Context) bool {
 		authHeader := c.GetHeader(common.AuthorizationHeader)
 		if authHeader == "" {
 			logger.Debug("Authorization header missing")
 			common.RespondWithError(c, common.ErrUnauthorized.WithDetails("Authorization header is required."))
-			return
+			return false
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || strings.ToLower(parts[0]) != strings.ToLower(common.AuthorizationTypeBearer) {
 			logger.Debug("Authorization header format invalid", zap.String("header", authHeader))
 			common.RespondWithError(c, common.ErrUnauthorized.WithDetails("Authorization header format must be 'Bearer <token>'."))
-			return
+			return false
 		}
 
 		tokenString := parts[1]
@@ -42,7 +69,7 @@ func AuthMiddleware(
 		if err != nil {
 			logger.Warn("Firebase token validation failed", zap.Error(err))
 			common.RespondWithError(c, common.ErrUnauthorized.WithDetails("Invalid or expired token: "+err.Error()))
-			return
+			return false
 		}
 
 		// After verification, check if the token's JTI is in the blocklist.
@@ -62,24 +89,25 @@ func AuthMiddleware(
 		if err != nil {
 			logger.Error("Error checking token blocklist", zap.Error(err))
 			common.RespondWithError(c, common.ErrInternalServer.WithDetails("Could not verify token session."))
-			return
+			return false
 		}
 		if isBlocklisted {
 			logger.Warn("Attempted to use a blocklisted token", zap.String("firebaseUID", firebaseToken.UID))
 			common.RespondWithError(c, common.ErrUnauthorized.WithDetails("Token has been invalidated. Please log in again."))
-			return
+			return false
 		}
 
 		localUser, wasCreated, err := userService.GetOrCreateUserFromFirebaseClaims(c.Request.Context(), firebaseToken)
 		if err != nil {
 			logger.Error("Failed to get or create user from Firebase claims", zap.Error(err), zap.String("firebaseUID", firebaseToken.UID))
 			common.RespondWithError(c, common.ErrInternalServer.WithDetails("Failed to process user authentication."))
-			return
+			return false
 		}
 
 		if wasCreated {
 			logger.Info("New local user created from Firebase token", zap.String("userID", localUser.ID.String()), zap.String("firebaseUID", firebaseToken.UID))
 		}
+		abuseService.RecordAuthentication(c.Request.Context(), localUser.ID, wasCreated)
 
 		// Set user information in context for downstream handlers
 		c.Set(common.UserIDKey, localUser.ID)
@@ -90,6 +118,7 @@ func AuthMiddleware(
 		}
 		c.Set(common.UserRoleKey, localUser.Role)
 		c.Set(common.FirebaseUIDKey, firebaseToken.UID)
+		c.Set(common.AuthTimeKey, time.Unix(firebaseToken.AuthTime, 0))
 
 		logger.Debug("User authenticated via Firebase successfully",
 			zap.String("localUserID", localUser.ID.String()),
@@ -98,10 +127,21 @@ func AuthMiddleware(
 			zap.String("role", localUser.Role),
 		)
 
-		c.Next()
+		return true
 	}
 }
 
+// OptionalAuthMiddleware runs authMW on requests that carry an Authorization header and lets anonymous requests
+// through, for public routes that personalize their response for signed-in users. An invalid token is still rejected.
+func OptionalAuthMiddleware(authMW gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader(common.AuthorizationHeader) == "" {
+			c.Next()
+			return
+		}
+		authMW(c)
+	}
+}
 
 // RoleAuthMiddleware creates a middleware to check if the authenticated user has one of the required roles.
 func RoleAuthMiddleware(allowedRoles ...string) gin.HandlerFunc {
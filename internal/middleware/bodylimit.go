@@ -0,0 +1,19 @@
+// File: internal/middleware/bodylimit.go
+package middleware
+
+import (
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodyLimitMiddleware caps every request body at maxBytes (MAX_REQUEST_BODY_BYTES). The cap applies as the body is
+// read, not to its declared Content-Length, so routes that take uploads can raise it with common.LimitRequestBody
+// or common.ParseMultipartForm. Handlers binding a larger body get an *http.MaxBytesError, which
+// common.BindingError answers with 413 PAYLOAD_TOO_LARGE.
+func BodyLimitMiddleware(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		common.CapRequestBody(c, maxBytes)
+		c.Next()
+	}
+}
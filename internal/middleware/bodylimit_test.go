@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testMaxRequestBodyBytes = 1 << 10
+	testMaxUploadBodyBytes  = 64 << 10
+)
+
+// bodyLimitRouter has the global body limit, a JSON route under it and an upload route raising it.
+func bodyLimitRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(BodyLimitMiddleware(testMaxRequestBodyBytes))
+	router.POST("/json", func(c *gin.Context) {
+		var body map[string]interface{}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			common.RespondWithError(c, common.BindingError(err))
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+	router.POST("/upload", func(c *gin.Context) {
+		limits := common.MultipartLimits{MaxBytes: testMaxUploadBodyBytes, MaxParts: 4, MaxMemory: 1 << 10}
+		if apiErr := common.ParseMultipartForm(c, limits); apiErr != nil {
+			common.RespondWithError(c, apiErr)
+			return
+		}
+		if _, err := c.FormFile("file"); err != nil {
+			common.RespondWithError(c, common.ErrBadRequest.WithDetails(err.Error()))
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+	return router
+}
+
+func uploadRequest(t *testing.T, fileSize int) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("file", "photo.jpg")
+	require.NoError(t, err)
+	_, err = part.Write(bytes.Repeat([]byte("x"), fileSize))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	req := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func TestBodyLimitMiddlewareLetsUploadRoutesRaiseTheLimit(t *testing.T) {
+	router := bodyLimitRouter()
+
+	// Between MAX_REQUEST_BODY_BYTES and MAX_UPLOAD_BODY_BYTES
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, uploadRequest(t, 8<<10))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, uploadRequest(t, 2*testMaxUploadBodyBytes))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestBodyLimitMiddlewareRefusesLargeBodies(t *testing.T) {
+	router := bodyLimitRouter()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/json", bytes.NewBufferString(`{"title":"ok"}`)))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	large := `{"title":"` + string(bytes.Repeat([]byte("x"), 2*testMaxRequestBodyBytes)) + `"}`
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/json", bytes.NewBufferString(large)))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), "PAYLOAD_TOO_LARGE")
+}
@@ -0,0 +1,59 @@
+// File: internal/middleware/captcha.go
+package middleware
+
+import (
+	"errors"
+
+	"seattle_info_backend/internal/captcha"
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// CaptchaTokenHeader carries the CAPTCHA response token solved by the client.
+const CaptchaTokenHeader = "X-Captcha-Token"
+
+// CaptchaMiddleware requires a valid CAPTCHA token from anonymous and new users before action.
+// It must run after AuthMiddleware on authenticated routes so the user can be identified.
+// Missing or rejected tokens get 403 CAPTCHA_REQUIRED; when the provider cannot be reached the
+// request is refused with 503 rather than let through.
+func CaptchaMiddleware(guard *captcha.Guard, action captcha.Action, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !guard.Enabled() {
+			c.Next()
+			return
+		}
+
+		userID := common.GetUserIDFromContext(c)
+		required, err := guard.Required(c.Request.Context(), userID, action)
+		if err != nil {
+			// Fail open: an account lookup error should not block established users.
+			logger.Error("Could not decide whether a CAPTCHA is required", zap.Error(err), zap.String("userID", userID.String()))
+			c.Next()
+			return
+		}
+		if !required {
+			c.Next()
+			return
+		}
+
+		token := c.GetHeader(CaptchaTokenHeader)
+		if token == "" {
+			common.RespondWithError(c, common.ErrCaptchaRequired.WithDetails("Send the solved CAPTCHA token in the "+CaptchaTokenHeader+" header."))
+			return
+		}
+		if err := guard.Verify(c.Request.Context(), token, c.ClientIP()); err != nil {
+			if errors.Is(err, captcha.ErrRejected) {
+				logger.Info("CAPTCHA rejected", zap.Error(err), zap.Bool("anonymous", userID == uuid.Nil))
+				common.RespondWithError(c, common.ErrCaptchaRequired.WithDetails("CAPTCHA verification failed. Please try again."))
+				return
+			}
+			logger.Error("CAPTCHA verification unavailable", zap.Error(err))
+			common.RespondWithError(c, common.ErrServiceUnavailable.WithDetails("CAPTCHA verification is temporarily unavailable."))
+			return
+		}
+		c.Next()
+	}
+}
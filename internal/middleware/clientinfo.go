@@ -0,0 +1,33 @@
+// File: internal/middleware/clientinfo.go
+package middleware
+
+import (
+	"strings"
+
+	"seattle_info_backend/internal/platform/clientinfo"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DeviceFingerprintHeader carries an opaque device identifier computed by the client apps
+	DeviceFingerprintHeader = "X-Device-Fingerprint"
+	// maxDeviceIDLength bounds the stored fingerprint; longer values are truncated
+	maxDeviceIDLength = 128
+)
+
+// ClientInfoMiddleware stores the client IP and device fingerprint in the request context so
+// services can record where a request came from without depending on Gin.
+func ClientInfoMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		info := clientinfo.Info{IP: c.ClientIP()}
+		if deviceID := strings.TrimSpace(c.GetHeader(DeviceFingerprintHeader)); deviceID != "" {
+			if len(deviceID) > maxDeviceIDLength {
+				deviceID = deviceID[:maxDeviceIDLength]
+			}
+			info.DeviceID = deviceID
+		}
+		c.Request = c.Request.WithContext(clientinfo.WithInfo(c.Request.Context(), info))
+		c.Next()
+	}
+}
@@ -18,7 +18,7 @@ func ErrorHandler(logger *zap.Logger) gin.HandlerFunc {
 				apiErr, isAPIErr := common.IsAPIError(ginErr.Err)
 
 				if isAPIErr {
-					c.AbortWithStatusJSON(apiErr.StatusCode, apiErr)
+					c.AbortWithStatusJSON(apiErr.StatusCode, apiErr.Localize(common.GetLanguageFromContext(c)))
 				} else {
 					logger.Error("Unhandled application error",
 						zap.Error(ginErr.Err),
@@ -30,7 +30,7 @@ func ErrorHandler(logger *zap.Logger) gin.HandlerFunc {
 					if gin.Mode() == gin.DebugMode && ginErr.Err != nil {
 						genericError.Details = ginErr.Err.Error()
 					}
-					c.AbortWithStatusJSON(genericError.StatusCode, genericError)
+					c.AbortWithStatusJSON(genericError.StatusCode, genericError.Localize(common.GetLanguageFromContext(c)))
 				}
 				return
 			}
@@ -38,12 +38,12 @@ func ErrorHandler(logger *zap.Logger) gin.HandlerFunc {
 
 		if c.Writer.Status() == 404 && len(c.Errors) == 0 {
 			notFoundErr := common.ErrNotFound.WithDetails("The requested endpoint does not exist.")
-			c.AbortWithStatusJSON(notFoundErr.StatusCode, notFoundErr)
+			c.AbortWithStatusJSON(notFoundErr.StatusCode, notFoundErr.Localize(common.GetLanguageFromContext(c)))
 			return
 		}
 		if c.Writer.Status() == 405 && len(c.Errors) == 0 {
 			methodNotAllowedErr := common.NewAPIError(405, "METHOD_NOT_ALLOWED", "The method is not allowed for the requested URL.")
-			c.AbortWithStatusJSON(methodNotAllowedErr.StatusCode, methodNotAllowedErr)
+			c.AbortWithStatusJSON(methodNotAllowedErr.StatusCode, methodNotAllowedErr.Localize(common.GetLanguageFromContext(c)))
 			return
 		}
 	}
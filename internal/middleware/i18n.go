@@ -0,0 +1,37 @@
+// File: internal/middleware/i18n.go
+package middleware
+
+import (
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// AcceptLanguageHeader is the request header used to negotiate the response language
+	AcceptLanguageHeader = "Accept-Language"
+	// ContentLanguageHeader reports the language the response was rendered in
+	ContentLanguageHeader = "Content-Language"
+	// LanguageQueryParam overrides Accept-Language when present (e.g. ?lang=es)
+	LanguageQueryParam = "lang"
+)
+
+// LanguageMiddleware negotiates the response language from the lang query parameter or the
+// Accept-Language header and stores it in both the Gin context and the request context.
+func LanguageMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := i18n.DefaultLanguage
+		if q := c.Query(LanguageQueryParam); q != "" {
+			lang = i18n.Match(q)
+		} else if header := c.GetHeader(AcceptLanguageHeader); header != "" {
+			lang = i18n.Match(header)
+		}
+
+		c.Set(common.LanguageKey, lang)
+		c.Request = c.Request.WithContext(i18n.WithLanguage(c.Request.Context(), lang))
+		c.Header(ContentLanguageHeader, lang)
+		c.Writer.Header().Add("Vary", AcceptLanguageHeader)
+		c.Next()
+	}
+}
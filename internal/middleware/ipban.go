@@ -0,0 +1,23 @@
+// File: internal/middleware/ipban.go
+package middleware
+
+import (
+	"seattle_info_backend/internal/abuse"
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// IPBanMiddleware rejects requests from banned IP ranges with 403 Forbidden.
+func IPBanMiddleware(abuseService abuse.Service, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ip := c.ClientIP()
+		if abuseService.IsBanned(c.Request.Context(), ip) {
+			logger.Info("Rejected request from banned IP", zap.String("ip", ip), zap.String("path", c.Request.URL.Path))
+			common.RespondWithError(c, common.ErrForbidden.WithDetails("Requests from your network are blocked."))
+			return
+		}
+		c.Next()
+	}
+}
@@ -2,9 +2,18 @@
 package middleware
 
 import (
-	"seattle_info_backend/internal/config" // For config.Config if needed for logger settings
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+	"strings"
 	"time"
 
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config" // For config.Config if needed for logger settings
+	"seattle_info_backend/internal/platform/redact"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -19,7 +28,15 @@ const (
 )
 
 // ZapLogger is a Gin middleware that logs requests using Zap.
+// Query strings, and the request and response bodies when LOG_REQUEST_BODIES is set, are logged with personal
+// data and credentials redacted. Successful requests on the routes in LOG_SAMPLE_RATES are only logged at the
+// configured rate; errors are always logged.
 func ZapLogger(logger *zap.Logger, cfg *config.Config) gin.HandlerFunc {
+	sampler, err := newLogSampler(cfg.LogSampleRates)
+	if err != nil {
+		logger.Warn("Ignoring invalid request log sampling rules", zap.Error(err))
+	}
+
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
@@ -32,22 +49,46 @@ func ZapLogger(logger *zap.Logger, cfg *config.Config) gin.HandlerFunc {
 		}
 		c.Set(RequestIDContextKey, requestID) // Use exported constant
 
+		var requestBody []byte
+		var responseWriter *bodyLogWriter
+		if cfg.LogRequestBodies {
+			requestBody = peekRequestBody(c, cfg.LogBodyMaxBytes)
+			responseWriter = &bodyLogWriter{ResponseWriter: c.Writer, maxBytes: cfg.LogBodyMaxBytes}
+			c.Writer = responseWriter
+		}
+
 		c.Next()
 
 		end := time.Now()
 		latency := end.Sub(start)
 		statusCode := c.Writer.Status()
 
+		// c.FullPath is the route pattern, e.g. /api/v1/listings/:id; it is empty for unmatched routes.
+		route := c.FullPath()
+		if statusCode < 400 && !sampler.sample(c.Request.Method, route) {
+			return
+		}
+
 		fields := []zapcore.Field{
 			zap.Int("status_code", statusCode),
 			zap.String("method", c.Request.Method),
+			zap.String("route", route),
 			zap.String("path", path),
-			zap.String("query", query),
+			zap.String("query", redact.Query(query)),
 			zap.String("ip", c.ClientIP()),
 			zap.String("user_agent", c.Request.UserAgent()),
 			zap.Duration("latency", latency),
 			zap.String("request_id", requestID),
 		}
+		if userID := common.GetUserIDFromContext(c); userID != uuid.Nil {
+			fields = append(fields, zap.String("user_id", userID.String()))
+		}
+		if len(requestBody) > 0 {
+			fields = append(fields, zap.String("request_body", redact.Body(requestBody, cfg.LogBodyMaxBytes)))
+		}
+		if responseWriter != nil && responseWriter.body.Len() > 0 && isLoggableContentType(c.Writer.Header().Get("Content-Type")) {
+			fields = append(fields, zap.String("response_body", redact.Body(responseWriter.body.Bytes(), cfg.LogBodyMaxBytes)))
+		}
 
 		if len(c.Errors) > 0 {
 			for _, e := range c.Errors.ByType(gin.ErrorTypePrivate) {
@@ -64,3 +105,100 @@ func ZapLogger(logger *zap.Logger, cfg *config.Config) gin.HandlerFunc {
 		}
 	}
 }
+
+// peekRequestBody returns up to maxBytes+1 bytes of a textual request body, leaving the body intact for the
+// handlers. Uploads and other binary bodies are not read.
+func peekRequestBody(c *gin.Context, maxBytes int) []byte {
+	if c.Request.Body == nil || !isLoggableContentType(c.ContentType()) {
+		return nil
+	}
+	// One byte more than is logged, so that a longer body is marked as truncated.
+	prefix, err := io.ReadAll(io.LimitReader(c.Request.Body, int64(maxBytes)+1))
+	c.Request.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(prefix), c.Request.Body), Closer: c.Request.Body}
+	if err != nil {
+		return nil
+	}
+	return prefix
+}
+
+func isLoggableContentType(contentType string) bool {
+	return strings.Contains(contentType, "json") ||
+		strings.HasPrefix(contentType, "application/x-www-form-urlencoded") ||
+		strings.HasPrefix(contentType, "text/")
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// bodyLogWriter keeps the first maxBytes+1 bytes of the response body for the request log.
+type bodyLogWriter struct {
+	gin.ResponseWriter
+	body     bytes.Buffer
+	maxBytes int
+}
+
+func (w *bodyLogWriter) Write(b []byte) (int, error) {
+	w.keep(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyLogWriter) WriteString(s string) (int, error) {
+	w.keep([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *bodyLogWriter) keep(b []byte) {
+	if remaining := w.maxBytes + 1 - w.body.Len(); remaining > 0 {
+		if len(b) > remaining {
+			b = b[:remaining]
+		}
+		w.body.Write(b)
+	}
+}
+
+// logSampler decides which successful requests of high-volume routes are logged (LOG_SAMPLE_RATES).
+type logSampler struct {
+	rates map[string]float64 // Keyed by "METHOD route" or by route alone
+}
+
+// newLogSampler parses comma-separated route=rate pairs, e.g. "GET /api/v1/health=0,/api/v1/listings=0.1".
+// Invalid pairs are skipped and reported in the error; the sampler returned is usable either way.
+func newLogSampler(spec string) (*logSampler, error) {
+	s := &logSampler{rates: map[string]float64{}}
+	var invalid []string
+	for _, rule := range strings.Split(spec, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		i := strings.LastIndex(rule, "=")
+		if i <= 0 {
+			invalid = append(invalid, rule)
+			continue
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(rule[i+1:]), 64)
+		if err != nil || rate < 0 || rate > 1 {
+			invalid = append(invalid, rule)
+			continue
+		}
+		s.rates[strings.Join(strings.Fields(rule[:i]), " ")] = rate
+	}
+	if len(invalid) > 0 {
+		return s, fmt.Errorf("expected route=rate with a rate between 0 and 1: %s", strings.Join(invalid, ", "))
+	}
+	return s, nil
+}
+
+// sample reports whether a successful request on the route is logged.
+func (s *logSampler) sample(method, route string) bool {
+	rate, ok := s.rates[method+" "+route]
+	if !ok {
+		rate, ok = s.rates[route]
+	}
+	if !ok {
+		return true
+	}
+	return rand.Float64() < rate
+}
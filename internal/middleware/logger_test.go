@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"seattle_info_backend/internal/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNewLogSamplerParsesRules(t *testing.T) {
+	s, err := newLogSampler("GET /api/v1/listings=0, /api/v1/listings = 1 ,/api/v1/health=0,bad,/x=2,/y=abc,=0.3")
+	require.Error(t, err, "invalid rules are reported")
+	for _, rule := range []string{"bad", "/x=2", "/y=abc", "=0.3"} {
+		assert.Contains(t, err.Error(), rule)
+	}
+	assert.Equal(t, map[string]float64{"GET /api/v1/listings": 0, "/api/v1/listings": 1, "/api/v1/health": 0}, s.rates,
+		"valid rules still apply")
+
+	assert.False(t, s.sample(http.MethodGet, "/api/v1/listings"), "a method-qualified rule wins over the route's")
+	assert.True(t, s.sample(http.MethodPost, "/api/v1/listings"), "the route's rule applies to other methods")
+	assert.False(t, s.sample(http.MethodHead, "/api/v1/health"))
+	assert.True(t, s.sample(http.MethodGet, "/api/v1/categories"), "routes without a rule are always logged")
+
+	s, err = newLogSampler("")
+	require.NoError(t, err)
+	assert.Empty(t, s.rates)
+}
+
+func TestZapLoggerAlwaysLogsErrors(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ZapLogger(zap.New(core), &config.Config{GinMode: "release", LogSampleRates: "/ok=0,/fail=0"}))
+	router.GET("/ok", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/fail", func(c *gin.Context) { c.Status(http.StatusInternalServerError) })
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok", nil))
+	assert.Zero(t, logs.Len(), "successful requests are sampled")
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+	require.Equal(t, 1, logs.Len(), "errors are logged whatever the sampling rate")
+	assert.Equal(t, "Server error", logs.All()[0].Message)
+}
+
+func TestZapLoggerLeavesRequestBodyIntact(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ZapLogger(zap.New(core), &config.Config{LogRequestBodies: true, LogBodyMaxBytes: 16}))
+	var received string
+	router.POST("/echo", func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		require.NoError(t, err)
+		received = string(body)
+		c.String(http.StatusOK, strings.Repeat("r", 100))
+	})
+
+	body := `{"title":"` + strings.Repeat("x", 100) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, body, received, "the handler reads the whole body after it is peeked")
+	assert.Equal(t, strings.Repeat("r", 100), w.Body.String(), "the client gets the whole response")
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Less(t, len(fields["request_body"].(string)), len(body))
+	assert.Less(t, len(fields["response_body"].(string)), 100)
+}
+
+func TestPeekRequestBodySkipsBinaryBodies(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("\x89PNG image data"))
+	c.Request.Header.Set("Content-Type", "image/png")
+
+	assert.Nil(t, peekRequestBody(c, 16))
+	body, err := io.ReadAll(c.Request.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "\x89PNG image data", string(body))
+}
+
+func TestBodyLogWriterTruncates(t *testing.T) {
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	w := &bodyLogWriter{ResponseWriter: c.Writer, maxBytes: 4}
+
+	_, err := w.Write([]byte("abc"))
+	require.NoError(t, err)
+	_, err = w.WriteString("defgh")
+	require.NoError(t, err)
+
+	assert.Equal(t, "abcde", w.body.String(), "maxBytes and one more byte, to mark the body as truncated")
+	assert.Equal(t, "abcdefgh", rec.Body.String())
+}
@@ -0,0 +1,44 @@
+// File: internal/middleware/maintenance.go
+package middleware
+
+import (
+	"net/http"
+
+	"seattle_info_backend/internal/appconfig"
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// MaintenanceMiddleware rejects write requests with 503 MAINTENANCE_MODE while maintenance mode is on (MAINTENANCE_MODE,
+// or PUT /api/v1/admin/maintenance). Reads and health checks keep working, and admins can still write, so they can
+// turn maintenance mode off again. Requests with an Authorization header are authenticated here to tell admins apart.
+func MaintenanceMiddleware(appConfigService appconfig.Service, authenticate Authenticator, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		status := appConfigService.Maintenance(c.Request.Context())
+		if !status.Enabled {
+			c.Next()
+			return
+		}
+
+		if c.GetHeader(common.AuthorizationHeader) != "" {
+			if !authenticate(c) {
+				return
+			}
+			if common.GetUserRoleFromContext(c) == common.RoleAdmin {
+				c.Next()
+				return
+			}
+		}
+
+		logger.Debug("Rejected write during maintenance", zap.String("method", c.Request.Method), zap.String("path", c.Request.URL.Path))
+		common.RespondWithError(c, common.ErrMaintenance.WithDetails(status.Message))
+	}
+}
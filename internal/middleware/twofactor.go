@@ -0,0 +1,33 @@
+// File: internal/middleware/twofactor.go
+package middleware
+
+import (
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/twofactor"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TwoFactorTokenHeader is the header admins send the token of their verified second factor in.
+const TwoFactorTokenHeader = "X-Two-Factor-Token"
+
+// AdminAuthMiddleware is RoleAuthMiddleware(common.RoleAdmin) with second factor enforcement: admins who have
+// enabled 2FA must also send the token of a two-factor session opened in the same sign-in, and while
+// ADMIN_2FA_REQUIRED is set admins without 2FA are refused.
+func AdminAuthMiddleware(twoFactorService twofactor.Service, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if common.GetUserRoleFromContext(c) != common.RoleAdmin {
+			common.RespondWithError(c, common.ErrForbidden.WithDetails("You do not have sufficient permissions for this resource."))
+			return
+		}
+		userID := common.GetUserIDFromContext(c)
+		err := twoFactorService.Authorize(c.Request.Context(), userID, common.GetAuthTimeFromContext(c), c.GetHeader(TwoFactorTokenHeader))
+		if err != nil {
+			logger.Debug("Admin request refused by second factor check", zap.String("userID", userID.String()), zap.Error(err))
+			common.RespondWithError(c, err)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,81 @@
+// File: internal/moderation/external.go
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ExternalModerator delegates moderation to an HTTP API.
+// The API receives {"text": "..."} and must answer {"flagged": bool, "reasons": ["..."]}.
+type ExternalModerator struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+type externalRequest struct {
+	Text string `json:"text"`
+}
+
+type externalResponse struct {
+	Flagged bool     `json:"flagged"`
+	Reasons []string `json:"reasons"`
+}
+
+// NewExternalModerator creates an ExternalModerator. The API key, if set, is sent as a Bearer token.
+func NewExternalModerator(url, apiKey string, timeout time.Duration) *ExternalModerator {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &ExternalModerator{
+		url:    url,
+		apiKey: apiKey,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Moderate implements Moderator.
+func (m *ExternalModerator) Moderate(ctx context.Context, text string) (*Result, error) {
+	body, err := json.Marshal(externalRequest{Text: text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode moderation request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build moderation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if m.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+m.apiKey)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("moderation API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("moderation API returned status %d", resp.StatusCode)
+	}
+
+	var out externalResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode moderation response: %w", err)
+	}
+
+	result := &Result{Flagged: out.Flagged}
+	for _, reason := range out.Reasons {
+		result.Reasons = append(result.Reasons, "external:"+reason)
+	}
+	if result.Flagged && len(result.Reasons) == 0 {
+		result.Reasons = []string{"external:flagged"}
+	}
+	return result, nil
+}
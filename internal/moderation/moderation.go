@@ -0,0 +1,84 @@
+// File: internal/moderation/moderation.go
+package moderation
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/config"
+
+	"go.uber.org/zap"
+)
+
+// Result is the outcome of moderating a piece of text.
+type Result struct {
+	Flagged bool
+	Reasons []string
+}
+
+// Moderator checks user-supplied text for content that needs admin review.
+type Moderator interface {
+	Moderate(ctx context.Context, text string) (*Result, error)
+}
+
+// Pipeline runs a sequence of moderators and merges their findings.
+// A failing moderator is logged and skipped so one unavailable backend does not block posting.
+type Pipeline struct {
+	moderators []Moderator
+	logger     *zap.Logger
+}
+
+// NewPipeline creates a Pipeline from the given moderators.
+func NewPipeline(logger *zap.Logger, moderators ...Moderator) *Pipeline {
+	return &Pipeline{moderators: moderators, logger: logger}
+}
+
+// Moderate implements Moderator.
+func (p *Pipeline) Moderate(ctx context.Context, text string) (*Result, error) {
+	result := &Result{}
+	seen := make(map[string]bool)
+	for _, m := range p.moderators {
+		r, err := m.Moderate(ctx, text)
+		if err != nil {
+			p.logger.Warn("Moderator failed, skipping", zap.Error(err))
+			continue
+		}
+		if r == nil || !r.Flagged {
+			continue
+		}
+		result.Flagged = true
+		for _, reason := range r.Reasons {
+			if !seen[reason] {
+				seen[reason] = true
+				result.Reasons = append(result.Reasons, reason)
+			}
+		}
+	}
+	return result, nil
+}
+
+// NewModerator builds the moderation pipeline from configuration: the built-in word list,
+// plus an external moderation API when MODERATION_API_URL is set.
+func NewModerator(cfg *config.Config, logger *zap.Logger) Moderator {
+	log := logger.Named("Moderation")
+
+	moderators := []Moderator{NewWordListModerator(splitWords(cfg.ModerationBlockedWords))}
+	if cfg.ModerationAPIURL != "" {
+		timeout := time.Duration(cfg.ModerationAPITimeoutSeconds) * time.Second
+		moderators = append(moderators, NewExternalModerator(cfg.ModerationAPIURL, cfg.ModerationAPIKey, timeout))
+		log.Info("External moderation API enabled", zap.String("url", cfg.ModerationAPIURL))
+	}
+	return NewPipeline(log, moderators...)
+}
+
+// splitWords parses a comma-separated word list, ignoring blanks.
+func splitWords(raw string) []string {
+	var words []string
+	for _, w := range strings.Split(raw, ",") {
+		if w = strings.TrimSpace(w); w != "" {
+			words = append(words, w)
+		}
+	}
+	return words
+}
@@ -0,0 +1,47 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestWordListModerator(t *testing.T) {
+	m := NewWordListModerator([]string{"Scam"})
+
+	res, err := m.Moderate(context.Background(), "Lovely apartment, no SCAM, no shit!")
+	require.NoError(t, err)
+	assert.True(t, res.Flagged)
+	assert.ElementsMatch(t, []string{"blocked_word:scam", "blocked_word:shit"}, res.Reasons)
+
+	// Whole words only: "Scunthorpe" and "classic" must not trip the filter.
+	res, err = m.Moderate(context.Background(), "Classic bike for sale in Scunthorpe")
+	require.NoError(t, err)
+	assert.False(t, res.Flagged)
+	assert.Empty(t, res.Reasons)
+}
+
+type stubModerator struct {
+	result *Result
+	err    error
+}
+
+func (s stubModerator) Moderate(context.Context, string) (*Result, error) { return s.result, s.err }
+
+func TestPipelineMergesAndSkipsFailures(t *testing.T) {
+	p := NewPipeline(zap.NewNop(),
+		stubModerator{err: errors.New("unavailable")},
+		stubModerator{result: &Result{Flagged: true, Reasons: []string{"a", "b"}}},
+		stubModerator{result: &Result{Flagged: true, Reasons: []string{"b", "c"}}},
+		stubModerator{result: &Result{}},
+	)
+
+	res, err := p.Moderate(context.Background(), "text")
+	require.NoError(t, err)
+	assert.True(t, res.Flagged)
+	assert.Equal(t, []string{"a", "b", "c"}, res.Reasons)
+}
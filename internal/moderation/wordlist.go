@@ -0,0 +1,49 @@
+// File: internal/moderation/wordlist.go
+package moderation
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// defaultBlockedWords is the built-in list of terms that always send a listing to review.
+var defaultBlockedWords = []string{
+	"fuck", "shit", "bitch", "cunt", "asshole", "bastard", "motherfucker",
+	"nigger", "faggot", "retard",
+}
+
+// WordListModerator flags text containing any blocked word (case-insensitive, whole words only).
+type WordListModerator struct {
+	blocked map[string]struct{}
+}
+
+// NewWordListModerator creates a WordListModerator with the built-in list plus any extra words.
+func NewWordListModerator(extraWords []string) *WordListModerator {
+	blocked := make(map[string]struct{}, len(defaultBlockedWords)+len(extraWords))
+	for _, w := range defaultBlockedWords {
+		blocked[w] = struct{}{}
+	}
+	for _, w := range extraWords {
+		blocked[strings.ToLower(w)] = struct{}{}
+	}
+	return &WordListModerator{blocked: blocked}
+}
+
+// Moderate implements Moderator.
+func (m *WordListModerator) Moderate(_ context.Context, text string) (*Result, error) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	result := &Result{}
+	seen := make(map[string]bool)
+	for _, w := range words {
+		if _, ok := m.blocked[w]; ok && !seen[w] {
+			seen[w] = true
+			result.Flagged = true
+			result.Reasons = append(result.Reasons, "blocked_word:"+w)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,43 @@
+package notification
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// groupSummaries are the messages shown for a group of more than one notification of a type.
+// Types without an entry are never grouped.
+var groupSummaries = map[NotificationType]string{
+	NewMessage:           "You have %d new messages about this listing.",
+	ListingEditedByAdmin: "An admin edited this listing %d times.",
+	SavedSearchDigest:    "Your saved searches found new listings %d times.",
+	ListingQuestionAsked: "Your listing has %d new questions.",
+	ApprovalSLABreached:  "Listings have waited for approval longer than the SLA in %d alerts.",
+}
+
+// groupKeyFor returns the group a new notification joins: one per type and related listing. It returns nil
+// for types that are never grouped.
+func groupKeyFor(notificationType NotificationType, relatedListingID *uuid.UUID) *string {
+	if _, ok := groupSummaries[notificationType]; !ok {
+		return nil
+	}
+	key := string(notificationType)
+	if relatedListingID != nil {
+		key += ":" + relatedListingID.String()
+	}
+	return &key
+}
+
+// summarizeGroup replaces the message of an entry that stands for several notifications with a summary.
+func summarizeGroup(n *Notification) {
+	if n.GroupCount < 1 {
+		n.GroupCount = 1
+	}
+	if n.GroupCount == 1 {
+		return
+	}
+	if summary, ok := groupSummaries[n.Type]; ok {
+		n.Message = fmt.Sprintf(summary, n.GroupCount)
+	}
+}
@@ -24,7 +24,9 @@ func NewHandler(service Service, logger *zap.Logger) *Handler {
 // All routes in this group should be authenticated.
 func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 	router.GET("", h.getNotifications)
+	router.GET("/unread-count", h.getUnreadCount)
 	router.POST("/:notification_id/mark-read", h.markNotificationAsRead)
+	router.POST("/mark-read", h.markNotificationsAsRead)
 	router.POST("/mark-all-read", h.markAllNotificationsAsRead)
 }
 
@@ -46,6 +48,26 @@ func (h *Handler) getNotifications(c *gin.Context) {
 	common.RespondPaginated(c, "Notifications retrieved successfully.", notifications, pagination)
 }
 
+func (h *Handler) getUnreadCount(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User ID not found in token."))
+		return
+	}
+
+	count, err := h.service.GetUnreadCount(c.Request.Context(), userID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	groupCount, err := h.service.GetUnreadGroupCount(c.Request.Context(), userID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Unread notification count retrieved successfully.", gin.H{"unread_count": count, "unread_group_count": groupCount})
+}
+
 func (h *Handler) markNotificationAsRead(c *gin.Context) {
 	userID := common.GetUserIDFromContext(c)
 	if userID == uuid.Nil {
@@ -69,6 +91,29 @@ func (h *Handler) markNotificationAsRead(c *gin.Context) {
 	common.RespondSuccess(c, 200, "Notification marked as read successfully.", nil) // Or 204 No Content
 }
 
+// markNotificationsAsRead marks the unread notifications matching the filter in the body as read.
+// An empty object marks every unread notification.
+func (h *Handler) markNotificationsAsRead(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User ID not found in token."))
+		return
+	}
+
+	var filter MarkReadFilter
+	if err := c.ShouldBindJSON(&filter); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	count, err := h.service.MarkNotificationsAsRead(c.Request.Context(), userID, filter)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Notifications marked as read successfully.", gin.H{"marked_count": count})
+}
+
 func (h *Handler) markAllNotificationsAsRead(c *gin.Context) {
 	userID := common.GetUserIDFromContext(c)
 	if userID == uuid.Nil {
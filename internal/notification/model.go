@@ -14,18 +14,30 @@ const (
 	ListingCreatedPendingApproval NotificationType = "listing_created_pending_approval"
 	ListingCreatedLive            NotificationType = "listing_created_live"
 	ListingApprovedLive           NotificationType = "listing_approved_live"
-	// ListingRejected             NotificationType = "listing_rejected" // Future
+	SavedSearchDigest             NotificationType = "saved_search_digest"
+	NewMessage                    NotificationType = "new_message"
+	ListingEditedByAdmin          NotificationType = "listing_edited_by_admin"
+	ListingRejected               NotificationType = "listing_rejected"
+	ListingTakenDown              NotificationType = "listing_taken_down"
+	ListingAppealResolved         NotificationType = "listing_appeal_resolved"
+	ListingQuestionAsked          NotificationType = "listing_question_asked"
+	ListingQuestionAnswered       NotificationType = "listing_question_answered"
+	ListingImageRejected          NotificationType = "listing_image_rejected"
+	BackgroundCheckReviewed       NotificationType = "background_check_reviewed"
+	ApprovalSLABreached           NotificationType = "approval_sla_breached" // Sent to admins
 )
 
 // Notification represents a user notification.
 type Notification struct {
-	ID                 uuid.UUID        `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
-	UserID             uuid.UUID        `gorm:"type:uuid;not null;index:idx_notification_user_status" json:"user_id"` // User who receives it
-	Type               NotificationType `gorm:"type:varchar(100);not null" json:"type"`
-	Message            string           `gorm:"type:text;not null" json:"message"`
-	RelatedListingID   *uuid.UUID       `gorm:"type:uuid" json:"related_listing_id,omitempty"` // Nullable
-	IsRead             bool             `gorm:"not null;default:false;index:idx_notification_user_status" json:"is_read"`
-	CreatedAt          time.Time        `gorm:"not null;default:CURRENT_TIMESTAMP;index:idx_notification_user_status" json:"created_at"`
+	ID               uuid.UUID        `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID           uuid.UUID        `gorm:"type:uuid;not null;index:idx_notification_user_status" json:"user_id"` // User who receives it
+	Type             NotificationType `gorm:"type:varchar(100);not null" json:"type"`
+	Message          string           `gorm:"type:text;not null" json:"message"`
+	RelatedListingID *uuid.UUID       `gorm:"type:uuid" json:"related_listing_id,omitempty"` // Nullable
+	IsRead           bool             `gorm:"not null;default:false;index:idx_notification_user_status" json:"is_read"`
+	CreatedAt        time.Time        `gorm:"not null;default:CURRENT_TIMESTAMP;index:idx_notification_user_status" json:"created_at"`
+	GroupKey         *string          `gorm:"type:varchar(150)" json:"group_key,omitempty"` // Notifications sharing a key are listed as one entry
+	GroupCount       int              `gorm:"column:group_count;->" json:"group_count"`     // Populated only when listing; notifications merged into this entry
 	// Removed UpdatedAt as notifications are typically immutable once created. If edits are needed, add it back.
 
 	// Associations (optional, depending on query needs)
@@ -37,3 +49,11 @@ type Notification struct {
 func (Notification) TableName() string {
 	return "notifications"
 }
+
+// MarkReadFilter selects the unread notifications that POST /notifications/mark-read marks as read.
+// Fields left out match every notification.
+type MarkReadFilter struct {
+	Type             *NotificationType `json:"type" binding:"omitempty,oneof=listing_created_pending_approval listing_created_live listing_approved_live saved_search_digest new_message listing_edited_by_admin listing_rejected listing_taken_down listing_appeal_resolved listing_question_asked listing_question_answered"`
+	RelatedListingID *uuid.UUID        `json:"related_listing_id"`
+	Before           *time.Time        `json:"before"` // Only notifications created before this time
+}
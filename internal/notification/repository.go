@@ -16,6 +16,9 @@ type Repository interface {
 	FindByID(ctx context.Context, notificationID uuid.UUID, userID uuid.UUID) (*Notification, error) // userID for ownership check
 	MarkAsRead(ctx context.Context, notificationID uuid.UUID, userID uuid.UUID) error
 	MarkAllAsRead(ctx context.Context, userID uuid.UUID) (int64, error) // Return count of marked notifications
+	MarkAsReadByFilter(ctx context.Context, userID uuid.UUID, filter MarkReadFilter) (int64, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int64, error)
+	CountUnreadGroups(ctx context.Context, userID uuid.UUID) (int64, error)
 }
 
 // GORMRepository implements the Repository interface using GORM.
@@ -36,15 +39,20 @@ func (r *GORMRepository) Create(ctx context.Context, notification *Notification)
 	return nil
 }
 
-// GetByUserID retrieves a paginated list of notifications for a specific user, ordered by creation date.
+// groupPartition is the SQL expression notifications are grouped by when listed. A group's unread and read
+// notifications are separate entries, and notifications without a group key are never merged.
+const groupPartition = "COALESCE(group_key, id::text), is_read"
+
+// GetByUserID retrieves a paginated list of a user's notifications, newest first. Notifications sharing a group
+// key are merged into one entry: the newest of them, with GroupCount set to the size of the group.
 func (r *GORMRepository) GetByUserID(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]Notification, *common.Pagination, error) {
 	var notifications []Notification
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&Notification{}).Where("user_id = ?", userID)
-
-	countQuery := r.db.WithContext(ctx).Model(&Notification{}).Where("user_id = ?", userID)
-	if err := countQuery.Count(&total).Error; err != nil {
+	err := r.db.WithContext(ctx).
+		Raw("SELECT COUNT(DISTINCT ("+groupPartition+")) FROM notifications WHERE user_id = ?", userID).
+		Scan(&total).Error
+	if err != nil {
 		return nil, nil, fmt.Errorf("counting notifications for user %s failed: %w", userID, err)
 	}
 
@@ -55,10 +63,18 @@ func (r *GORMRepository) GetByUserID(ctx context.Context, userID uuid.UUID, page
 		offset = 0
 	}
 
-	err := query.Order("created_at DESC").
-		Limit(pageSize).
-		Offset(offset).
-		Find(&notifications).Error
+	err = r.db.WithContext(ctx).Raw(`
+		SELECT * FROM (
+			SELECT n.*,
+				COUNT(*) OVER (PARTITION BY `+groupPartition+`) AS group_count,
+				ROW_NUMBER() OVER (PARTITION BY `+groupPartition+` ORDER BY created_at DESC, id) AS group_rank
+			FROM notifications n
+			WHERE user_id = ?
+		) grouped
+		WHERE group_rank = 1
+		ORDER BY created_at DESC, id
+		LIMIT ? OFFSET ?`, userID, pageSize, offset).
+		Scan(&notifications).Error
 	if err != nil {
 		return nil, nil, fmt.Errorf("fetching notifications for user %s failed: %w", userID, err)
 	}
@@ -78,17 +94,22 @@ func (r *GORMRepository) FindByID(ctx context.Context, notificationID uuid.UUID,
 	return &notification, nil
 }
 
-// MarkAsRead marks a specific notification as read for a user.
+// MarkAsRead marks a specific notification, and the unread notifications of its group, as read for a user.
 // It first verifies ownership using FindByID.
 func (r *GORMRepository) MarkAsRead(ctx context.Context, notificationID uuid.UUID, userID uuid.UUID) error {
-	_, err := r.FindByID(ctx, notificationID, userID)
+	notification, err := r.FindByID(ctx, notificationID, userID)
 	if err != nil {
 		return err
 	}
 
-	result := r.db.WithContext(ctx).Model(&Notification{}).
-		Where("id = ? AND user_id = ?", notificationID, userID).
-		Update("is_read", true)
+	// A grouped notification is listed as one entry, so reading it reads the whole group.
+	query := r.db.WithContext(ctx).Model(&Notification{}).Where("user_id = ?", userID)
+	if notification.GroupKey != nil {
+		query = query.Where("(id = ? OR (group_key = ? AND is_read = ?))", notificationID, *notification.GroupKey, false)
+	} else {
+		query = query.Where("id = ?", notificationID)
+	}
+	result := query.Update("is_read", true)
 
 	if result.Error != nil {
 		return fmt.Errorf("failed to mark notification %s as read for user %s: %w", notificationID, userID, result.Error)
@@ -111,3 +132,49 @@ func (r *GORMRepository) MarkAllAsRead(ctx context.Context, userID uuid.UUID) (i
 	}
 	return result.RowsAffected, nil
 }
+
+// MarkAsReadByFilter marks a user's unread notifications that match the filter as read, in a single UPDATE.
+// It returns the count of notifications that were updated.
+func (r *GORMRepository) MarkAsReadByFilter(ctx context.Context, userID uuid.UUID, filter MarkReadFilter) (int64, error) {
+	query := r.db.WithContext(ctx).Model(&Notification{}).
+		Where("user_id = ? AND is_read = ?", userID, false)
+	if filter.Type != nil {
+		query = query.Where("type = ?", *filter.Type)
+	}
+	if filter.RelatedListingID != nil {
+		query = query.Where("related_listing_id = ?", *filter.RelatedListingID)
+	}
+	if filter.Before != nil {
+		query = query.Where("created_at < ?", *filter.Before)
+	}
+
+	result := query.Update("is_read", true)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to mark filtered notifications as read for user %s: %w", userID, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// CountUnread counts a user's unread notifications.
+func (r *GORMRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Notification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications for user %s: %w", userID, err)
+	}
+	return count, nil
+}
+
+// CountUnreadGroups counts a user's unread notifications the way they are listed, with each group counted once.
+func (r *GORMRepository) CountUnreadGroups(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Raw("SELECT COUNT(DISTINCT COALESCE(group_key, id::text)) FROM notifications WHERE user_id = ? AND is_read = ?", userID, false).
+		Scan(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notification groups for user %s: %w", userID, err)
+	}
+	return count, nil
+}
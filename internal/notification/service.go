@@ -15,6 +15,9 @@ type Service interface {
 	GetNotificationsForUser(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]Notification, *common.Pagination, error)
 	MarkNotificationAsRead(ctx context.Context, notificationID uuid.UUID, userID uuid.UUID) error
 	MarkAllUserNotificationsAsRead(ctx context.Context, userID uuid.UUID) (int64, error)
+	MarkNotificationsAsRead(ctx context.Context, userID uuid.UUID, filter MarkReadFilter) (int64, error)
+	GetUnreadCount(ctx context.Context, userID uuid.UUID) (int64, error)
+	GetUnreadGroupCount(ctx context.Context, userID uuid.UUID) (int64, error)
 }
 
 // ServiceImplementation implements the notification Service interface.
@@ -32,12 +35,13 @@ func NewService(repo Repository, logger *zap.Logger) Service {
 func (s *ServiceImplementation) CreateNotification(ctx context.Context, userID uuid.UUID, notificationType NotificationType, message string, relatedListingID *uuid.UUID) (*Notification, error) {
 	notification := &Notification{
 		// ID will be generated by GORM default uuid_generate_v4()
-		UserID:             userID,
-		Type:               notificationType,
-		Message:            message,
-		RelatedListingID:   relatedListingID,
-		IsRead:             false,
-		CreatedAt:          time.Now().UTC(), // Explicitly set to UTC, though DB default CURRENT_TIMESTAMP should handle timezone
+		UserID:           userID,
+		Type:             notificationType,
+		Message:          message,
+		RelatedListingID: relatedListingID,
+		GroupKey:         groupKeyFor(notificationType, relatedListingID),
+		IsRead:           false,
+		CreatedAt:        time.Now().UTC(), // Explicitly set to UTC, though DB default CURRENT_TIMESTAMP should handle timezone
 	}
 
 	if err := s.repo.Create(ctx, notification); err != nil {
@@ -51,7 +55,7 @@ func (s *ServiceImplementation) CreateNotification(ctx context.Context, userID u
 	return notification, nil
 }
 
-// GetNotificationsForUser retrieves paginated notifications for a user.
+// GetNotificationsForUser retrieves paginated notifications for a user, with similar notifications grouped.
 func (s *ServiceImplementation) GetNotificationsForUser(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]Notification, *common.Pagination, error) {
 	notifications, pagination, err := s.repo.GetByUserID(ctx, userID, page, pageSize)
 	if err != nil {
@@ -61,6 +65,9 @@ func (s *ServiceImplementation) GetNotificationsForUser(ctx context.Context, use
 		}
 		return nil, nil, common.ErrInternalServer.WithDetails("Could not retrieve notifications.")
 	}
+	for i := range notifications {
+		summarizeGroup(&notifications[i])
+	}
 	return notifications, pagination, nil
 }
 
@@ -92,3 +99,34 @@ func (s *ServiceImplementation) MarkAllUserNotificationsAsRead(ctx context.Conte
 	s.logger.Info("All unread notifications marked as read for user", zap.Int64("count", count), zap.String("userID", userID.String()))
 	return count, nil
 }
+
+// MarkNotificationsAsRead marks the user's unread notifications that match the filter as read.
+func (s *ServiceImplementation) MarkNotificationsAsRead(ctx context.Context, userID uuid.UUID, filter MarkReadFilter) (int64, error) {
+	count, err := s.repo.MarkAsReadByFilter(ctx, userID, filter)
+	if err != nil {
+		s.logger.Error("Failed to mark filtered notifications as read in repo", zap.Error(err), zap.String("userID", userID.String()))
+		return 0, common.ErrInternalServer.WithDetails("Could not mark notifications as read.")
+	}
+	s.logger.Info("Filtered notifications marked as read for user", zap.Int64("count", count), zap.String("userID", userID.String()))
+	return count, nil
+}
+
+// GetUnreadCount returns how many of a user's notifications are unread.
+func (s *ServiceImplementation) GetUnreadCount(ctx context.Context, userID uuid.UUID) (int64, error) {
+	count, err := s.repo.CountUnread(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to count unread notifications in repo", zap.Error(err), zap.String("userID", userID.String()))
+		return 0, common.ErrInternalServer.WithDetails("Could not count unread notifications.")
+	}
+	return count, nil
+}
+
+// GetUnreadGroupCount returns how many unread entries the user's grouped notification list has.
+func (s *ServiceImplementation) GetUnreadGroupCount(ctx context.Context, userID uuid.UUID) (int64, error) {
+	count, err := s.repo.CountUnreadGroups(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to count unread notification groups in repo", zap.Error(err), zap.String("userID", userID.String()))
+		return 0, common.ErrInternalServer.WithDetails("Could not count unread notifications.")
+	}
+	return count, nil
+}
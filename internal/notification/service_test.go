@@ -57,6 +57,21 @@ func (m *MockNotificationRepository) MarkAllAsRead(ctx context.Context, userID u
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockNotificationRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationRepository) CountUnreadGroups(ctx context.Context, userID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationRepository) MarkAsReadByFilter(ctx context.Context, userID uuid.UUID, filter MarkReadFilter) (int64, error) {
+	args := m.Called(ctx, userID, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // Test Suite Setup
 type NotificationServiceTestSuite struct {
 	service        Service // notification.Service (the one we are testing)
@@ -243,3 +258,59 @@ func TestNotificationService_MarkAllUserNotificationsAsRead_Error(t *testing.T)
 	assert.Equal(t, expectedError.Code, apiErr.Code)
 	ts.mockNotifRepo.AssertExpectations(t)
 }
+
+func TestNotificationService_CreateNotification_SetsGroupKey(t *testing.T) {
+	ts := setupNotificationServiceTestSuite(t)
+	ctx := context.Background()
+	listingID := uuid.New()
+	ts.mockNotifRepo.On("Create", ctx, mock.AnythingOfType("*notification.Notification")).Return(nil)
+
+	grouped, err := ts.service.CreateNotification(ctx, uuid.New(), NewMessage, "You have a new message about your listing.", &listingID)
+	assert.NoError(t, err)
+	if assert.NotNil(t, grouped.GroupKey) {
+		assert.Equal(t, "new_message:"+listingID.String(), *grouped.GroupKey)
+	}
+
+	single, err := ts.service.CreateNotification(ctx, uuid.New(), ListingRejected, "Your listing was not approved.", &listingID)
+	assert.NoError(t, err)
+	assert.Nil(t, single.GroupKey, "rejections are never grouped")
+}
+
+func TestNotificationService_GetNotificationsForUser_SummarizesGroups(t *testing.T) {
+	ts := setupNotificationServiceTestSuite(t)
+	ctx := context.Background()
+	userID := uuid.New()
+	key := "new_message:" + uuid.New().String()
+
+	ts.mockNotifRepo.On("GetByUserID", ctx, userID, 1, 20).Return([]Notification{
+		{ID: uuid.New(), UserID: userID, Type: NewMessage, Message: "You have a new message about your listing.", GroupKey: &key, GroupCount: 3},
+		{ID: uuid.New(), UserID: userID, Type: ListingApprovedLive, Message: "Your listing is live!", GroupCount: 1},
+	}, &common.Pagination{CurrentPage: 1, PageSize: 20, TotalItems: 2, TotalPages: 1}, nil)
+
+	notifications, _, err := ts.service.GetNotificationsForUser(ctx, userID, 1, 20)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "You have 3 new messages about this listing.", notifications[0].Message)
+	assert.Equal(t, "Your listing is live!", notifications[1].Message)
+}
+
+func TestNotificationService_MarkNotificationsAsRead(t *testing.T) {
+	ts := setupNotificationServiceTestSuite(t)
+	ctx := context.Background()
+	userID := uuid.New()
+	notifType := NewMessage
+	filter := MarkReadFilter{Type: &notifType}
+
+	ts.mockNotifRepo.On("MarkAsReadByFilter", ctx, userID, filter).Return(int64(4), nil).Once()
+	count, err := ts.service.MarkNotificationsAsRead(ctx, userID, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+
+	ts.mockNotifRepo.On("MarkAsReadByFilter", ctx, userID, filter).Return(int64(0), errors.New("repo error")).Once()
+	_, err = ts.service.MarkNotificationsAsRead(ctx, userID, filter)
+	apiErr, ok := err.(*common.APIError)
+	if assert.True(t, ok) {
+		assert.Equal(t, common.ErrInternalServer.Code, apiErr.Code)
+	}
+	ts.mockNotifRepo.AssertExpectations(t)
+}
@@ -0,0 +1,37 @@
+// File: internal/overview/handler.go
+package overview
+
+import (
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Handler struct holds dependencies for the admin overview handler.
+type Handler struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewHandler creates a new overview handler.
+func NewHandler(service Service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterAdminRoutes sets up the overview route under the admin group (/api/v1/admin).
+func (h *Handler) RegisterAdminRoutes(adminGroup *gin.RouterGroup) {
+	adminGroup.GET("/overview", h.adminGetOverview)
+}
+
+func (h *Handler) adminGetOverview(c *gin.Context) {
+	overview, err := h.service.GetOverview(c.Request.Context())
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Overview retrieved successfully.", overview)
+}
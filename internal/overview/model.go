@@ -0,0 +1,30 @@
+// File: internal/overview/model.go
+package overview
+
+import "time"
+
+// Counts are the numbers on the admin home page. "Today" counts start at DayStart.
+type Counts struct {
+	NewListingsToday   int64 `gorm:"column:new_listings_today"`
+	NewUsersToday      int64 `gorm:"column:new_users_today"`
+	PendingApprovals   int64 `gorm:"column:pending_approvals"`
+	OpenAppeals        int64 `gorm:"column:open_appeals"`
+	OpenDuplicateFlags int64 `gorm:"column:open_duplicate_flags"`
+	DeadLetterTasks    int64 `gorm:"column:dead_letter_tasks"`
+}
+
+// Overview is the response of GET /admin/overview.
+type Overview struct {
+	DayStart    time.Time `json:"day_start"` // Midnight in EVENTS_TIMEZONE, where "today" counts start
+	GeneratedAt time.Time `json:"generated_at"`
+
+	NewListingsToday int64 `json:"new_listings_today"` // Listings created today, drafts included
+	NewUsersToday    int64 `json:"new_users_today"`
+	PendingApprovals int64 `json:"pending_approvals"` // Listings waiting for an admin decision
+
+	// Items waiting for an admin review
+	OpenAppeals        int64 `json:"open_appeals"`         // Takedowns appealed by the owner
+	OpenDuplicateFlags int64 `json:"open_duplicate_flags"` // Possible duplicate accounts not merged or dismissed
+
+	DeadLetterTasks int64 `json:"dead_letter_tasks"` // Background tasks out of attempts, see GET /admin/queue/dead
+}
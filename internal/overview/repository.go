@@ -0,0 +1,45 @@
+// File: internal/overview/repository.go
+package overview
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// countsQuery reads every count in one round trip. Each subquery is served by an index: listings and users by
+// created_at and listings by status, takedowns by (status, appealed_at), and the open duplicate candidates and
+// dead tasks by partial indexes.
+const countsQuery = `SELECT
+	(SELECT COUNT(*) FROM listings WHERE created_at >= @since) AS new_listings_today,
+	(SELECT COUNT(*) FROM users WHERE created_at >= @since) AS new_users_today,
+	(SELECT COUNT(*) FROM listings WHERE status = 'pending_approval') AS pending_approvals,
+	(SELECT COUNT(*) FROM listing_takedowns WHERE status = 'appealed') AS open_appeals,
+	(SELECT COUNT(*) FROM user_duplicate_candidates WHERE resolved_at IS NULL) AS open_duplicate_flags,
+	(SELECT COUNT(*) FROM queue_tasks WHERE status = 'dead') AS dead_letter_tasks`
+
+// Repository defines the interface for the admin overview queries.
+type Repository interface {
+	Counts(ctx context.Context, since time.Time) (*Counts, error)
+}
+
+// GORMRepository implements the overview Repository interface using GORM.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+// NewGORMRepository creates a new GORM overview repository.
+func NewGORMRepository(db *gorm.DB) Repository {
+	return &GORMRepository{db: db}
+}
+
+// Counts returns the overview counts, counting listings and users created at or after since.
+func (r *GORMRepository) Counts(ctx context.Context, since time.Time) (*Counts, error) {
+	var counts Counts
+	if err := r.db.WithContext(ctx).Raw(countsQuery, map[string]interface{}{"since": since}).Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("failed to count overview figures: %w", err)
+	}
+	return &counts, nil
+}
@@ -0,0 +1,57 @@
+// File: internal/overview/service.go
+package overview
+
+import (
+	"context"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+
+	"go.uber.org/zap"
+)
+
+// Service defines the interface for the admin overview.
+type Service interface {
+	GetOverview(ctx context.Context) (*Overview, error)
+}
+
+// ServiceImplementation implements the overview Service interface.
+type ServiceImplementation struct {
+	repo     Repository
+	location *time.Location // Where "today" starts; EVENTS_TIMEZONE
+	logger   *zap.Logger
+	now      func() time.Time
+}
+
+// NewService creates a new overview service. Days start at midnight in EVENTS_TIMEZONE, or UTC if it is invalid.
+func NewService(repo Repository, cfg *config.Config, logger *zap.Logger) Service {
+	location, err := time.LoadLocation(cfg.EventsTimezone)
+	if err != nil {
+		logger.Warn("Invalid EVENTS_TIMEZONE, falling back to UTC", zap.String("timezone", cfg.EventsTimezone), zap.Error(err))
+		location = time.UTC
+	}
+	return &ServiceImplementation{repo: repo, location: location, logger: logger, now: time.Now}
+}
+
+// GetOverview returns today's figures for the admin home page.
+func (s *ServiceImplementation) GetOverview(ctx context.Context) (*Overview, error) {
+	now := s.now().In(s.location)
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, s.location)
+
+	counts, err := s.repo.Counts(ctx, dayStart)
+	if err != nil {
+		s.logger.Error("Failed to compute admin overview", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve overview.")
+	}
+	return &Overview{
+		DayStart:           dayStart,
+		GeneratedAt:        now,
+		NewListingsToday:   counts.NewListingsToday,
+		NewUsersToday:      counts.NewUsersToday,
+		PendingApprovals:   counts.PendingApprovals,
+		OpenAppeals:        counts.OpenAppeals,
+		OpenDuplicateFlags: counts.OpenDuplicateFlags,
+		DeadLetterTasks:    counts.DeadLetterTasks,
+	}, nil
+}
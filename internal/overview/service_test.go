@@ -0,0 +1,62 @@
+package overview
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeRepo struct {
+	counts Counts
+	err    error
+	since  time.Time
+}
+
+func (f *fakeRepo) Counts(_ context.Context, since time.Time) (*Counts, error) {
+	f.since = since
+	if f.err != nil {
+		return nil, f.err
+	}
+	c := f.counts
+	return &c, nil
+}
+
+func TestGetOverviewCountsFromLocalMidnight(t *testing.T) {
+	repo := &fakeRepo{counts: Counts{NewListingsToday: 4, PendingApprovals: 2, DeadLetterTasks: 1}}
+	svc := NewService(repo, &config.Config{EventsTimezone: "America/Los_Angeles"}, zap.NewNop()).(*ServiceImplementation)
+	// 05:30 UTC on 2 May is still 1 May in Seattle.
+	svc.now = func() time.Time { return time.Date(2024, 5, 2, 5, 30, 0, 0, time.UTC) }
+
+	overview, err := svc.GetOverview(context.Background())
+	require.NoError(t, err)
+	assert.True(t, repo.since.Equal(time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC)), "day starts at %s", repo.since)
+	assert.True(t, overview.DayStart.Equal(repo.since))
+	assert.Equal(t, int64(4), overview.NewListingsToday)
+	assert.Equal(t, int64(2), overview.PendingApprovals)
+	assert.Equal(t, int64(1), overview.DeadLetterTasks)
+}
+
+func TestGetOverviewInvalidTimezoneUsesUTC(t *testing.T) {
+	repo := &fakeRepo{}
+	svc := NewService(repo, &config.Config{EventsTimezone: "Nowhere/Special"}, zap.NewNop()).(*ServiceImplementation)
+	svc.now = func() time.Time { return time.Date(2024, 5, 2, 5, 30, 0, 0, time.UTC) }
+
+	_, err := svc.GetOverview(context.Background())
+	require.NoError(t, err)
+	assert.True(t, repo.since.Equal(time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestGetOverviewRepositoryError(t *testing.T) {
+	svc := NewService(&fakeRepo{err: errors.New("connection refused")}, &config.Config{}, zap.NewNop())
+
+	_, err := svc.GetOverview(context.Background())
+	assert.ErrorIs(t, err, common.ErrInternalServer)
+}
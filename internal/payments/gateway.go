@@ -0,0 +1,83 @@
+// File: internal/payments/gateway.go
+package payments
+
+import (
+	"context"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+
+	"go.uber.org/zap"
+)
+
+// EventType is a provider webhook event, normalized across providers.
+type EventType string
+
+const (
+	EventCheckoutCompleted EventType = "checkout_completed" // The user finished checkout; Paid says whether the money is in
+	EventCheckoutExpired   EventType = "checkout_expired"   // The checkout session was abandoned
+	EventRefunded          EventType = "refunded"           // A payment was refunded in full
+)
+
+// CheckoutParams describes a one-off purchase to start a hosted checkout for.
+type CheckoutParams struct {
+	Reference   string // Our payment ID, echoed back in webhook events
+	Description string // Shown to the user on the checkout page
+	AmountCents int64
+	Currency    string // ISO 4217, e.g. "usd"
+	SuccessURL  string
+	CancelURL   string
+}
+
+// CheckoutSession is a hosted checkout page the user is sent to.
+type CheckoutSession struct {
+	ID  string
+	URL string
+}
+
+// WebhookEvent is a verified event from the provider. Events of other types have an EventType
+// the service does not handle and are ignored.
+type WebhookEvent struct {
+	Type      EventType
+	SessionID string // Set for checkout events
+	Reference string // Set for checkout events
+	PaymentID string // Set for completed checkouts and refunds
+	Paid      bool   // Set for completed checkouts
+}
+
+// Gateway is a payment provider.
+type Gateway interface {
+	// Name identifies the provider, e.g. "stripe".
+	Name() string
+	CreateCheckoutSession(ctx context.Context, params CheckoutParams) (*CheckoutSession, error)
+	// Refund refunds a payment in full.
+	Refund(ctx context.Context, paymentID string) error
+	// ParseWebhook verifies the signature of a webhook request and decodes its event.
+	ParseWebhook(payload []byte, signature string) (*WebhookEvent, error)
+}
+
+// disabledGateway is used when no payment provider is configured. Every call fails.
+type disabledGateway struct{}
+
+var errPaymentsDisabled = common.ErrServiceUnavailable.WithDetails("Payments are not configured.")
+
+func (disabledGateway) Name() string { return "none" }
+
+func (disabledGateway) CreateCheckoutSession(context.Context, CheckoutParams) (*CheckoutSession, error) {
+	return nil, errPaymentsDisabled
+}
+
+func (disabledGateway) Refund(context.Context, string) error { return errPaymentsDisabled }
+
+func (disabledGateway) ParseWebhook([]byte, string) (*WebhookEvent, error) {
+	return nil, errPaymentsDisabled
+}
+
+// NewGateway returns a StripeGateway when STRIPE_SECRET_KEY is set, and a gateway that rejects every call otherwise.
+func NewGateway(cfg *config.Config, logger *zap.Logger) Gateway {
+	if cfg.StripeSecretKey == "" {
+		logger.Named("Payments").Warn("STRIPE_SECRET_KEY is not set; featured listing purchases are disabled")
+		return disabledGateway{}
+	}
+	return NewStripeGateway(cfg.StripeAPIBaseURL, cfg.StripeSecretKey, cfg.StripeWebhookSecret, 0)
+}
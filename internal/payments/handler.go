@@ -0,0 +1,115 @@
+// File: internal/payments/handler.go
+package payments
+
+import (
+	"io"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// maxWebhookBodySize caps the payment provider webhook requests that are read.
+const maxWebhookBodySize = 1 << 20
+
+// Handler struct holds dependencies for payment handlers.
+type Handler struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewHandler creates a new payments handler.
+func NewHandler(service Service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes sets up the checkout route and the provider webhook. The webhook is not behind auth;
+// each request is verified by its signature.
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMW gin.HandlerFunc) {
+	paymentGroup := router.Group("/payments")
+	{
+		paymentGroup.POST("/featured-checkout", authMW, h.createFeatureCheckout)
+		paymentGroup.POST("/stripe/webhook", h.stripeWebhook)
+	}
+}
+
+// RegisterAdminRoutes sets up the payment routes of the admin API.
+// The router group passed here is expected to be /api/v1/admin, already guarded by auth and admin role middleware.
+func (h *Handler) RegisterAdminRoutes(router *gin.RouterGroup) {
+	router.GET("/payments/revenue", h.adminRevenueReport)
+	router.POST("/payments/:id/refund", h.adminRefundPayment)
+}
+
+func (h *Handler) createFeatureCheckout(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	var req CreateCheckoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	checkout, err := h.service.CreateFeatureCheckout(c.Request.Context(), userID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondCreated(c, "Checkout started. Send the user to checkout_url to pay.", checkout)
+}
+
+// stripeWebhook receives Stripe events. The raw body is needed to verify the Stripe-Signature header.
+func (h *Handler) stripeWebhook(c *gin.Context) {
+	payload, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodySize))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Could not read request body."))
+		return
+	}
+	if err := h.service.HandleWebhook(c.Request.Context(), payload, c.GetHeader("Stripe-Signature")); err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Webhook processed.", nil)
+}
+
+func (h *Handler) adminRefundPayment(c *gin.Context) {
+	paymentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid payment ID format."))
+		return
+	}
+	adminID := common.GetUserIDFromContext(c)
+	if adminID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	payment, err := h.service.RefundPayment(c.Request.Context(), paymentID, adminID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Admin: Payment refunded successfully.", ToPaymentResponse(payment))
+}
+
+func (h *Handler) adminRevenueReport(c *gin.Context) {
+	var query RevenueQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	report, err := h.service.GetRevenueReport(c.Request.Context(), query)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Admin: Revenue report retrieved successfully.", report)
+}
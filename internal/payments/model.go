@@ -0,0 +1,118 @@
+// File: internal/payments/model.go
+package payments
+
+import (
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+)
+
+// PaymentStatus is the state of a payment.
+type PaymentStatus string
+
+const (
+	StatusPending  PaymentStatus = "pending"  // Checkout started, not paid yet
+	StatusPaid     PaymentStatus = "paid"     // Paid; the listing has been featured
+	StatusRefunded PaymentStatus = "refunded" // Refunded in full; the feature days were taken back
+	StatusExpired  PaymentStatus = "expired"  // Checkout abandoned or failed to start
+)
+
+// Payment is a user's purchase of featured days for one of their listings.
+type Payment struct {
+	common.BaseModel
+	UserID            uuid.UUID     `gorm:"type:uuid;not null"`
+	ListingID         uuid.UUID     `gorm:"type:uuid;not null"`
+	Provider          string        `gorm:"type:varchar(20);not null"`
+	ProviderSessionID *string       `gorm:"type:varchar(255);uniqueIndex"` // Checkout session at the provider
+	ProviderPaymentID *string       `gorm:"type:varchar(255);index"`       // Payment (intent) at the provider, known once paid
+	Status            PaymentStatus `gorm:"type:varchar(20);not null;default:'pending'"`
+	AmountCents       int64         `gorm:"not null"`
+	Currency          string        `gorm:"type:varchar(3);not null"`
+	FeatureDays       int           `gorm:"not null"`
+	PaidAt            *time.Time
+	RefundedAt        *time.Time
+}
+
+// TableName specifies the table name for GORM.
+func (Payment) TableName() string {
+	return "payments"
+}
+
+// --- Request DTOs ---
+
+// CreateCheckoutRequest is the payload for starting the purchase of featured days.
+type CreateCheckoutRequest struct {
+	ListingID uuid.UUID `json:"listing_id" binding:"required"`
+	Days      int       `json:"days" binding:"required,min=1,max=90"`
+}
+
+// RevenueQuery selects the period of the revenue report. Dates are inclusive, in UTC.
+type RevenueQuery struct {
+	From    string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To      string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+	GroupBy string `form:"group_by" binding:"omitempty,oneof=day month"`
+}
+
+// --- Response DTOs ---
+
+// CheckoutResponse tells the client where to send the user to pay.
+type CheckoutResponse struct {
+	PaymentID   uuid.UUID `json:"payment_id"`
+	CheckoutURL string    `json:"checkout_url"`
+	AmountCents int64     `json:"amount_cents"`
+	Currency    string    `json:"currency"`
+	Days        int       `json:"days"`
+}
+
+// PaymentResponse is the API representation of a payment.
+type PaymentResponse struct {
+	ID          uuid.UUID     `json:"id"`
+	UserID      uuid.UUID     `json:"user_id"`
+	ListingID   uuid.UUID     `json:"listing_id"`
+	Provider    string        `json:"provider"`
+	Status      PaymentStatus `json:"status"`
+	AmountCents int64         `json:"amount_cents"`
+	Currency    string        `json:"currency"`
+	FeatureDays int           `json:"feature_days"`
+	PaidAt      *time.Time    `json:"paid_at,omitempty"`
+	RefundedAt  *time.Time    `json:"refunded_at,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// RevenueRow is the revenue of one period in one currency. Refunds count against the period the payment was made in.
+type RevenueRow struct {
+	Period        string `json:"period"` // YYYY-MM-DD or YYYY-MM
+	Currency      string `json:"currency"`
+	PaymentCount  int64  `json:"payment_count"`
+	GrossCents    int64  `json:"gross_cents"`
+	RefundedCents int64  `json:"refunded_cents"`
+	NetCents      int64  `json:"net_cents"`
+}
+
+// RevenueReport is the revenue from featured listings over a period.
+type RevenueReport struct {
+	From    string       `json:"from"`
+	To      string       `json:"to"`
+	GroupBy string       `json:"group_by"`
+	Rows    []RevenueRow `json:"rows"`
+	Totals  []RevenueRow `json:"totals"` // One per currency; Period is empty
+}
+
+// ToPaymentResponse converts a Payment model to a PaymentResponse DTO.
+func ToPaymentResponse(p *Payment) PaymentResponse {
+	return PaymentResponse{
+		ID:          p.ID,
+		UserID:      p.UserID,
+		ListingID:   p.ListingID,
+		Provider:    p.Provider,
+		Status:      p.Status,
+		AmountCents: p.AmountCents,
+		Currency:    p.Currency,
+		FeatureDays: p.FeatureDays,
+		PaidAt:      p.PaidAt,
+		RefundedAt:  p.RefundedAt,
+		CreatedAt:   p.CreatedAt,
+	}
+}
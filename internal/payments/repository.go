@@ -0,0 +1,134 @@
+// File: internal/payments/repository.go
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for payment data operations.
+type Repository interface {
+	Create(ctx context.Context, payment *Payment) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Payment, error)
+	FindByProviderPaymentID(ctx context.Context, providerPaymentID string) (*Payment, error)
+	SetProviderSession(ctx context.Context, id uuid.UUID, sessionID string) error
+	// MarkPaid, MarkRefunded and MarkExpired only change payments in the state they move from, and report
+	// whether they did. A webhook event delivered twice is therefore applied once.
+	MarkPaid(ctx context.Context, id uuid.UUID, providerPaymentID string, paidAt time.Time) (bool, error)
+	MarkRefunded(ctx context.Context, id uuid.UUID, refundedAt time.Time) (bool, error)
+	MarkExpired(ctx context.Context, id uuid.UUID) (bool, error)
+	Revenue(ctx context.Context, from, to time.Time, groupBy string) ([]RevenueRow, error)
+}
+
+// GORMRepository implements the payments Repository interface using GORM.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+// NewGORMRepository creates a new GORM payments repository.
+func NewGORMRepository(db *gorm.DB) Repository {
+	return &GORMRepository{db: db}
+}
+
+// Create inserts a new payment.
+func (r *GORMRepository) Create(ctx context.Context, payment *Payment) error {
+	if err := r.db.WithContext(ctx).Create(payment).Error; err != nil {
+		return fmt.Errorf("failed to create payment: %w", err)
+	}
+	return nil
+}
+
+// FindByID retrieves a payment by its ID.
+func (r *GORMRepository) FindByID(ctx context.Context, id uuid.UUID) (*Payment, error) {
+	var payment Payment
+	if err := r.db.WithContext(ctx).First(&payment, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Payment not found.")
+		}
+		return nil, fmt.Errorf("failed to find payment: %w", err)
+	}
+	return &payment, nil
+}
+
+// FindByProviderPaymentID retrieves a payment by the provider's ID for it.
+func (r *GORMRepository) FindByProviderPaymentID(ctx context.Context, providerPaymentID string) (*Payment, error) {
+	var payment Payment
+	if err := r.db.WithContext(ctx).First(&payment, "provider_payment_id = ?", providerPaymentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Payment not found.")
+		}
+		return nil, fmt.Errorf("failed to find payment by provider payment ID: %w", err)
+	}
+	return &payment, nil
+}
+
+// SetProviderSession stores the checkout session created for a payment.
+func (r *GORMRepository) SetProviderSession(ctx context.Context, id uuid.UUID, sessionID string) error {
+	if err := r.db.WithContext(ctx).Model(&Payment{}).Where("id = ?", id).Update("provider_session_id", sessionID).Error; err != nil {
+		return fmt.Errorf("failed to store payment checkout session: %w", err)
+	}
+	return nil
+}
+
+// MarkPaid moves a pending payment to paid.
+func (r *GORMRepository) MarkPaid(ctx context.Context, id uuid.UUID, providerPaymentID string, paidAt time.Time) (bool, error) {
+	updates := map[string]interface{}{"status": StatusPaid, "paid_at": paidAt}
+	if providerPaymentID != "" {
+		updates["provider_payment_id"] = providerPaymentID
+	}
+	return r.transition(ctx, id, StatusPending, updates)
+}
+
+// MarkRefunded moves a paid payment to refunded.
+func (r *GORMRepository) MarkRefunded(ctx context.Context, id uuid.UUID, refundedAt time.Time) (bool, error) {
+	return r.transition(ctx, id, StatusPaid, map[string]interface{}{"status": StatusRefunded, "refunded_at": refundedAt})
+}
+
+// MarkExpired moves a pending payment to expired.
+func (r *GORMRepository) MarkExpired(ctx context.Context, id uuid.UUID) (bool, error) {
+	return r.transition(ctx, id, StatusPending, map[string]interface{}{"status": StatusExpired})
+}
+
+// transition applies updates to the payment if it is in status from.
+func (r *GORMRepository) transition(ctx context.Context, id uuid.UUID, from PaymentStatus, updates map[string]interface{}) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Payment{}).Where("id = ? AND status = ?", id, from).Updates(updates)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update payment status: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Revenue sums the payments made in [from, to) per period ("day" or "month", in UTC) and currency.
+func (r *GORMRepository) Revenue(ctx context.Context, from, to time.Time, groupBy string) ([]RevenueRow, error) {
+	format := "YYYY-MM-DD"
+	if groupBy == "month" {
+		format = "YYYY-MM"
+	}
+	var rows []RevenueRow
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT to_char(date_trunc(?, paid_at AT TIME ZONE 'UTC'), ?) AS period,
+			currency,
+			COUNT(*) AS payment_count,
+			SUM(amount_cents) AS gross_cents,
+			COALESCE(SUM(amount_cents) FILTER (WHERE status = ?), 0) AS refunded_cents
+		FROM payments
+		WHERE paid_at >= ? AND paid_at < ? AND status IN (?, ?)
+		GROUP BY 1, 2
+		ORDER BY 1, 2`,
+		groupBy, format, StatusRefunded, from, to, StatusPaid, StatusRefunded).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute revenue: %w", err)
+	}
+	for i := range rows {
+		rows[i].NetCents = rows[i].GrossCents - rows[i].RefundedCents
+	}
+	return rows, nil
+}
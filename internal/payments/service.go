@@ -0,0 +1,320 @@
+// File: internal/payments/service.go
+package payments
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// maxRevenueRange caps the period of one revenue report.
+const maxRevenueRange = 366 * 24 * time.Hour
+
+// Service defines the interface for featured listing purchases.
+type Service interface {
+	// CreateFeatureCheckout starts the purchase of featured days for a listing the user owns.
+	CreateFeatureCheckout(ctx context.Context, userID uuid.UUID, req CreateCheckoutRequest) (*CheckoutResponse, error)
+	// HandleWebhook applies a payment provider webhook request.
+	HandleWebhook(ctx context.Context, payload []byte, signature string) error
+
+	// Admin methods
+	RefundPayment(ctx context.Context, id uuid.UUID, adminID uuid.UUID) (*Payment, error)
+	GetRevenueReport(ctx context.Context, query RevenueQuery) (*RevenueReport, error)
+}
+
+// ServiceImplementation implements the payments Service interface.
+type ServiceImplementation struct {
+	repo           Repository
+	gateway        Gateway
+	listingService listing.Service
+	cfg            *config.Config
+	logger         *zap.Logger
+}
+
+// NewService creates a new payments service.
+func NewService(repo Repository, gateway Gateway, listingService listing.Service, cfg *config.Config, logger *zap.Logger) Service {
+	return &ServiceImplementation{
+		repo:           repo,
+		gateway:        gateway,
+		listingService: listingService,
+		cfg:            cfg,
+		logger:         logger,
+	}
+}
+
+// CreateFeatureCheckout records a pending payment and opens a checkout session for it. The listing is
+// featured once the provider reports the payment as completed.
+func (s *ServiceImplementation) CreateFeatureCheckout(ctx context.Context, userID uuid.UUID, req CreateCheckoutRequest) (*CheckoutResponse, error) {
+	webBaseURL := strings.TrimSuffix(s.cfg.WebBaseURL, "/")
+	if webBaseURL == "" || s.cfg.FeaturedPricePerDayCents <= 0 {
+		return nil, common.ErrServiceUnavailable.WithDetails("Payments are not configured.")
+	}
+	l, err := s.listingService.GetListingByID(ctx, req.ListingID, &userID)
+	if err != nil {
+		return nil, err
+	}
+	if l.UserID != userID {
+		return nil, common.ErrForbidden.WithDetails("You can only feature your own listings.")
+	}
+	if l.Status != listing.StatusActive {
+		return nil, common.ErrConflict.WithDetails("Only active listings can be featured.")
+	}
+
+	payment := &Payment{
+		UserID:      userID,
+		ListingID:   l.ID,
+		Provider:    s.gateway.Name(),
+		Status:      StatusPending,
+		AmountCents: int64(req.Days) * s.cfg.FeaturedPricePerDayCents,
+		Currency:    strings.ToLower(s.cfg.PaymentsCurrency),
+		FeatureDays: req.Days,
+	}
+	if err := s.repo.Create(ctx, payment); err != nil {
+		s.logger.Error("Failed to create payment", zap.Error(err), zap.String("listingID", l.ID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not start checkout.")
+	}
+
+	listingURL := webBaseURL + "/listings/" + l.Slug
+	session, err := s.gateway.CreateCheckoutSession(ctx, CheckoutParams{
+		Reference:   payment.ID.String(),
+		Description: fmt.Sprintf("Featured listing for %d days: %s", req.Days, l.Title),
+		AmountCents: payment.AmountCents,
+		Currency:    payment.Currency,
+		SuccessURL:  listingURL + "?featured=success",
+		CancelURL:   listingURL + "?featured=cancelled",
+	})
+	if err != nil {
+		if _, markErr := s.repo.MarkExpired(ctx, payment.ID); markErr != nil {
+			s.logger.Error("Failed to expire payment after checkout failure", zap.Error(markErr), zap.String("paymentID", payment.ID.String()))
+		}
+		if _, ok := common.IsAPIError(err); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to create checkout session", zap.Error(err), zap.String("paymentID", payment.ID.String()))
+		return nil, common.ErrServiceUnavailable.WithDetails("The payment provider is not available. Please try again later.")
+	}
+	if err := s.repo.SetProviderSession(ctx, payment.ID, session.ID); err != nil {
+		// Webhook events carry our payment ID, so the purchase still completes.
+		s.logger.Error("Failed to store checkout session", zap.Error(err), zap.String("paymentID", payment.ID.String()))
+	}
+
+	s.logger.Info("Featured listing checkout started",
+		zap.String("paymentID", payment.ID.String()),
+		zap.String("listingID", l.ID.String()),
+		zap.Int("days", req.Days),
+	)
+	return &CheckoutResponse{
+		PaymentID:   payment.ID,
+		CheckoutURL: session.URL,
+		AmountCents: payment.AmountCents,
+		Currency:    payment.Currency,
+		Days:        req.Days,
+	}, nil
+}
+
+// HandleWebhook verifies and applies a provider event. Events for unknown payments and event types the
+// service does not use are acknowledged and ignored, so that the provider stops resending them.
+func (s *ServiceImplementation) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	event, err := s.gateway.ParseWebhook(payload, signature)
+	if err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return err
+		}
+		s.logger.Warn("Rejected payment webhook", zap.Error(err))
+		return common.ErrBadRequest.WithDetails("Invalid webhook signature or payload.")
+	}
+
+	switch event.Type {
+	case EventCheckoutCompleted:
+		if !event.Paid {
+			return nil // Delayed payment methods report again once the money is in
+		}
+		payment, err := s.findByReference(ctx, event.Reference)
+		if err != nil || payment == nil {
+			return err
+		}
+		return s.completePayment(ctx, payment, event.PaymentID)
+	case EventCheckoutExpired:
+		payment, err := s.findByReference(ctx, event.Reference)
+		if err != nil || payment == nil {
+			return err
+		}
+		if _, err := s.repo.MarkExpired(ctx, payment.ID); err != nil {
+			s.logger.Error("Failed to expire payment", zap.Error(err), zap.String("paymentID", payment.ID.String()))
+			return common.ErrInternalServer.WithDetails("Failed to process webhook.")
+		}
+		return nil
+	case EventRefunded:
+		payment, err := s.repo.FindByProviderPaymentID(ctx, event.PaymentID)
+		if err != nil {
+			if _, ok := common.IsAPIError(err); ok {
+				s.logger.Warn("Refund webhook for unknown payment", zap.String("providerPaymentID", event.PaymentID))
+				return nil
+			}
+			s.logger.Error("Failed to find refunded payment", zap.Error(err), zap.String("providerPaymentID", event.PaymentID))
+			return common.ErrInternalServer.WithDetails("Failed to process webhook.")
+		}
+		return s.applyRefund(ctx, payment)
+	default:
+		s.logger.Debug("Ignoring payment webhook event", zap.String("type", string(event.Type)))
+		return nil
+	}
+}
+
+// findByReference looks up the payment a checkout event refers to. It returns nil, nil for unknown payments.
+func (s *ServiceImplementation) findByReference(ctx context.Context, reference string) (*Payment, error) {
+	id, err := uuid.Parse(reference)
+	if err != nil {
+		s.logger.Warn("Payment webhook without a valid reference", zap.String("reference", reference))
+		return nil, nil
+	}
+	payment, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			s.logger.Warn("Payment webhook for unknown payment", zap.String("paymentID", reference))
+			return nil, nil
+		}
+		s.logger.Error("Failed to find payment", zap.Error(err), zap.String("paymentID", reference))
+		return nil, common.ErrInternalServer.WithDetails("Failed to process webhook.")
+	}
+	return payment, nil
+}
+
+// completePayment marks a payment as paid and features its listing.
+func (s *ServiceImplementation) completePayment(ctx context.Context, payment *Payment, providerPaymentID string) error {
+	applied, err := s.repo.MarkPaid(ctx, payment.ID, providerPaymentID, time.Now())
+	if err != nil {
+		s.logger.Error("Failed to mark payment as paid", zap.Error(err), zap.String("paymentID", payment.ID.String()))
+		return common.ErrInternalServer.WithDetails("Failed to process webhook.")
+	}
+	if !applied {
+		return nil // Already handled
+	}
+
+	note := fmt.Sprintf("Paid through %s, payment %s", payment.Provider, payment.ID)
+	if _, err := s.listingService.FeatureListing(ctx, payment.ListingID, payment.FeatureDays, &payment.UserID, &note); err != nil {
+		// The money is in, so the payment stays paid; an admin can feature the listing by hand or refund it.
+		s.logger.Error("Payment received but the listing could not be featured",
+			zap.Error(err),
+			zap.String("paymentID", payment.ID.String()),
+			zap.String("listingID", payment.ListingID.String()),
+		)
+		return nil
+	}
+	s.logger.Info("Featured listing payment completed", zap.String("paymentID", payment.ID.String()), zap.String("listingID", payment.ListingID.String()))
+	return nil
+}
+
+// applyRefund marks a paid payment as refunded and takes back the featured days it bought.
+func (s *ServiceImplementation) applyRefund(ctx context.Context, payment *Payment) error {
+	applied, err := s.repo.MarkRefunded(ctx, payment.ID, time.Now())
+	if err != nil {
+		s.logger.Error("Failed to mark payment as refunded", zap.Error(err), zap.String("paymentID", payment.ID.String()))
+		return common.ErrInternalServer.WithDetails("Failed to record refund.")
+	}
+	if !applied {
+		return nil
+	}
+
+	note := fmt.Sprintf("Refund of payment %s", payment.ID)
+	if _, err := s.listingService.RevokeFeature(ctx, payment.ListingID, payment.FeatureDays, &note); err != nil {
+		s.logger.Error("Payment refunded but the featured days could not be taken back",
+			zap.Error(err),
+			zap.String("paymentID", payment.ID.String()),
+			zap.String("listingID", payment.ListingID.String()),
+		)
+		return nil
+	}
+	s.logger.Info("Featured listing payment refunded", zap.String("paymentID", payment.ID.String()), zap.String("listingID", payment.ListingID.String()))
+	return nil
+}
+
+// RefundPayment refunds a paid payment in full through the provider.
+func (s *ServiceImplementation) RefundPayment(ctx context.Context, id uuid.UUID, adminID uuid.UUID) (*Payment, error) {
+	payment, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Status != StatusPaid || payment.ProviderPaymentID == nil {
+		return nil, common.ErrConflict.WithDetails("Only paid payments can be refunded.")
+	}
+	if err := s.gateway.Refund(ctx, *payment.ProviderPaymentID); err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to refund payment", zap.Error(err), zap.String("paymentID", id.String()))
+		return nil, common.ErrServiceUnavailable.WithDetails("The payment provider could not refund the payment.")
+	}
+	if err := s.applyRefund(ctx, payment); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Admin refunded payment", zap.String("paymentID", id.String()), zap.String("adminID", adminID.String()))
+	return s.repo.FindByID(ctx, id)
+}
+
+// GetRevenueReport sums featured listing revenue per day or month. It defaults to the last 30 days, by day.
+func (s *ServiceImplementation) GetRevenueReport(ctx context.Context, query RevenueQuery) (*RevenueReport, error) {
+	groupBy := query.GroupBy
+	if groupBy == "" {
+		groupBy = "day"
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	to := today
+	if query.To != "" {
+		to, _ = time.Parse("2006-01-02", query.To) // Validated by binding
+	}
+	from := to.AddDate(0, 0, -29)
+	if query.From != "" {
+		from, _ = time.Parse("2006-01-02", query.From)
+	}
+	if from.After(to) {
+		return nil, common.ErrBadRequest.WithDetails("from must not be after to.")
+	}
+	end := to.AddDate(0, 0, 1) // to is inclusive
+	if end.Sub(from) > maxRevenueRange {
+		return nil, common.ErrBadRequest.WithDetails("The report can cover at most 366 days.")
+	}
+
+	rows, err := s.repo.Revenue(ctx, from, end, groupBy)
+	if err != nil {
+		s.logger.Error("Failed to compute revenue report", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Failed to compute revenue report.")
+	}
+	if rows == nil {
+		rows = []RevenueRow{}
+	}
+	return &RevenueReport{
+		From:    from.Format("2006-01-02"),
+		To:      to.Format("2006-01-02"),
+		GroupBy: groupBy,
+		Rows:    rows,
+		Totals:  revenueTotals(rows),
+	}, nil
+}
+
+// revenueTotals adds up rows per currency, in the order the currencies first appear.
+func revenueTotals(rows []RevenueRow) []RevenueRow {
+	totals := []RevenueRow{}
+	index := map[string]int{}
+	for _, row := range rows {
+		i, ok := index[row.Currency]
+		if !ok {
+			i = len(totals)
+			index[row.Currency] = i
+			totals = append(totals, RevenueRow{Currency: row.Currency})
+		}
+		totals[i].PaymentCount += row.PaymentCount
+		totals[i].GrossCents += row.GrossCents
+		totals[i].RefundedCents += row.RefundedCents
+		totals[i].NetCents += row.NetCents
+	}
+	return totals
+}
@@ -0,0 +1,120 @@
+package payments
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryRepository keeps payments in memory.
+type memoryRepository struct {
+	Repository
+	payments map[uuid.UUID]*Payment
+}
+
+func (r *memoryRepository) FindByID(_ context.Context, id uuid.UUID) (*Payment, error) {
+	p := *r.payments[id]
+	return &p, nil
+}
+
+func (r *memoryRepository) FindByProviderPaymentID(_ context.Context, providerPaymentID string) (*Payment, error) {
+	for _, p := range r.payments {
+		if p.ProviderPaymentID != nil && *p.ProviderPaymentID == providerPaymentID {
+			found := *p
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryRepository) MarkPaid(_ context.Context, id uuid.UUID, providerPaymentID string, paidAt time.Time) (bool, error) {
+	p := r.payments[id]
+	if p.Status != StatusPending {
+		return false, nil
+	}
+	p.Status, p.ProviderPaymentID, p.PaidAt = StatusPaid, &providerPaymentID, &paidAt
+	return true, nil
+}
+
+func (r *memoryRepository) MarkRefunded(_ context.Context, id uuid.UUID, refundedAt time.Time) (bool, error) {
+	p := r.payments[id]
+	if p.Status != StatusPaid {
+		return false, nil
+	}
+	p.Status, p.RefundedAt = StatusRefunded, &refundedAt
+	return true, nil
+}
+
+// fakeGateway returns the events it is given, without checking signatures.
+type fakeGateway struct {
+	Gateway
+	events []*WebhookEvent
+}
+
+func (g *fakeGateway) ParseWebhook([]byte, string) (*WebhookEvent, error) {
+	event := g.events[0]
+	g.events = g.events[1:]
+	return event, nil
+}
+
+// featureRecorder records the feature days added to and taken from listings.
+type featureRecorder struct {
+	listing.Service
+	featured map[uuid.UUID]int
+}
+
+func (f *featureRecorder) FeatureListing(_ context.Context, id uuid.UUID, days int, _ *uuid.UUID, _ *string) (*listing.Listing, error) {
+	f.featured[id] += days
+	return &listing.Listing{}, nil
+}
+
+func (f *featureRecorder) RevokeFeature(_ context.Context, id uuid.UUID, days int, _ *string) (*listing.Listing, error) {
+	f.featured[id] -= days
+	return &listing.Listing{}, nil
+}
+
+func TestHandleWebhookFeaturesOnceAndRevokesOnRefund(t *testing.T) {
+	payment := &Payment{UserID: uuid.New(), ListingID: uuid.New(), Provider: "stripe", Status: StatusPending, FeatureDays: 7}
+	payment.ID = uuid.New()
+	repo := &memoryRepository{payments: map[uuid.UUID]*Payment{payment.ID: payment}}
+	completed := &WebhookEvent{Type: EventCheckoutCompleted, Reference: payment.ID.String(), PaymentID: "pi_1", Paid: true}
+	gateway := &fakeGateway{events: []*WebhookEvent{
+		completed,
+		completed, // Redelivered
+		{Type: EventRefunded, PaymentID: "pi_1"},
+		{Type: EventRefunded, PaymentID: "pi_1"},
+	}}
+	listings := &featureRecorder{featured: map[uuid.UUID]int{}}
+	svc := NewService(repo, gateway, listings, &config.Config{}, zap.NewNop())
+	ctx := context.Background()
+
+	require.NoError(t, svc.HandleWebhook(ctx, nil, ""))
+	require.NoError(t, svc.HandleWebhook(ctx, nil, ""))
+	assert.Equal(t, StatusPaid, payment.Status)
+	assert.Equal(t, 7, listings.featured[payment.ListingID], "a redelivered event is applied once")
+
+	require.NoError(t, svc.HandleWebhook(ctx, nil, ""))
+	require.NoError(t, svc.HandleWebhook(ctx, nil, ""))
+	assert.Equal(t, StatusRefunded, payment.Status)
+	assert.Equal(t, 0, listings.featured[payment.ListingID], "the refund takes the days back once")
+}
+
+func TestRevenueTotals(t *testing.T) {
+	rows := []RevenueRow{
+		{Period: "2025-06-01", Currency: "usd", PaymentCount: 2, GrossCents: 2800, RefundedCents: 1400, NetCents: 1400},
+		{Period: "2025-06-02", Currency: "usd", PaymentCount: 1, GrossCents: 600, NetCents: 600},
+		{Period: "2025-06-02", Currency: "eur", PaymentCount: 1, GrossCents: 500, NetCents: 500},
+	}
+	assert.Equal(t, []RevenueRow{
+		{Currency: "usd", PaymentCount: 3, GrossCents: 3400, RefundedCents: 1400, NetCents: 2000},
+		{Currency: "eur", PaymentCount: 1, GrossCents: 500, NetCents: 500},
+	}, revenueTotals(rows))
+}
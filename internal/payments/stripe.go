@@ -0,0 +1,206 @@
+// File: internal/payments/stripe.go
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stripeSignatureTolerance is how old a webhook signature may be before the event is rejected as a replay.
+const stripeSignatureTolerance = 5 * time.Minute
+
+// StripeGateway takes payments through Stripe Checkout, using the Stripe REST API.
+type StripeGateway struct {
+	baseURL       string
+	secretKey     string
+	webhookSecret string
+	client        *http.Client
+	now           func() time.Time
+}
+
+// NewStripeGateway creates a StripeGateway. baseURL is normally https://api.stripe.com.
+func NewStripeGateway(baseURL, secretKey, webhookSecret string, timeout time.Duration) *StripeGateway {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &StripeGateway{
+		baseURL:       strings.TrimRight(baseURL, "/"),
+		secretKey:     secretKey,
+		webhookSecret: webhookSecret,
+		client:        &http.Client{Timeout: timeout},
+		now:           time.Now,
+	}
+}
+
+// Name implements Gateway.
+func (g *StripeGateway) Name() string { return "stripe" }
+
+// CreateCheckoutSession implements Gateway.
+func (g *StripeGateway) CreateCheckoutSession(ctx context.Context, params CheckoutParams) (*CheckoutSession, error) {
+	form := url.Values{
+		"mode":                                   {"payment"},
+		"success_url":                            {params.SuccessURL},
+		"cancel_url":                             {params.CancelURL},
+		"client_reference_id":                    {params.Reference},
+		"metadata[payment_id]":                   {params.Reference},
+		"line_items[0][quantity]":                {"1"},
+		"line_items[0][price_data][currency]":    {strings.ToLower(params.Currency)},
+		"line_items[0][price_data][unit_amount]": {strconv.FormatInt(params.AmountCents, 10)},
+		"line_items[0][price_data][product_data][name]": {params.Description},
+	}
+	var session struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+	if err := g.post(ctx, "/v1/checkout/sessions", form, &session); err != nil {
+		return nil, fmt.Errorf("failed to create Stripe checkout session: %w", err)
+	}
+	return &CheckoutSession{ID: session.ID, URL: session.URL}, nil
+}
+
+// Refund implements Gateway.
+func (g *StripeGateway) Refund(ctx context.Context, paymentID string) error {
+	if err := g.post(ctx, "/v1/refunds", url.Values{"payment_intent": {paymentID}}, nil); err != nil {
+		return fmt.Errorf("failed to refund Stripe payment: %w", err)
+	}
+	return nil
+}
+
+// post sends a form-encoded request to the Stripe API and decodes the JSON response into out, if given.
+func (g *StripeGateway) post(ctx context.Context, path string, form url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.baseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(g.secretKey, "")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.Unmarshal(body, &apiErr)
+		return fmt.Errorf("stripe returned status %d: %s", resp.StatusCode, apiErr.Error.Message)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// stripeEvent is the part of a Stripe event the gateway reads. Object holds a checkout session or a charge.
+type stripeEvent struct {
+	Type string `json:"type"`
+	Data struct {
+		Object struct {
+			ID                string            `json:"id"`
+			ClientReferenceID string            `json:"client_reference_id"`
+			Metadata          map[string]string `json:"metadata"`
+			PaymentIntent     string            `json:"payment_intent"`
+			PaymentStatus     string            `json:"payment_status"`
+			Refunded          bool              `json:"refunded"`
+		} `json:"object"`
+	} `json:"data"`
+}
+
+// ParseWebhook implements Gateway. signature is the Stripe-Signature header.
+func (g *StripeGateway) ParseWebhook(payload []byte, signature string) (*WebhookEvent, error) {
+	if err := g.verifySignature(payload, signature); err != nil {
+		return nil, err
+	}
+	var event stripeEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode Stripe event: %w", err)
+	}
+
+	object := event.Data.Object
+	reference := object.ClientReferenceID
+	if reference == "" {
+		reference = object.Metadata["payment_id"]
+	}
+	switch event.Type {
+	case "checkout.session.completed", "checkout.session.async_payment_succeeded":
+		return &WebhookEvent{Type: EventCheckoutCompleted, SessionID: object.ID, Reference: reference,
+			PaymentID: object.PaymentIntent, Paid: object.PaymentStatus == "paid"}, nil
+	case "checkout.session.expired", "checkout.session.async_payment_failed":
+		return &WebhookEvent{Type: EventCheckoutExpired, SessionID: object.ID, Reference: reference}, nil
+	case "charge.refunded":
+		if !object.Refunded {
+			// Partial refunds leave the purchase in place.
+			return &WebhookEvent{Type: EventType(event.Type)}, nil
+		}
+		return &WebhookEvent{Type: EventRefunded, PaymentID: object.PaymentIntent}, nil
+	default:
+		return &WebhookEvent{Type: EventType(event.Type)}, nil
+	}
+}
+
+// verifySignature checks a Stripe-Signature header of the form "t=<unix time>,v1=<hex HMAC-SHA256>[,v1=...]".
+// The signed content is "<t>.<payload>", keyed with the endpoint's webhook secret.
+func (g *StripeGateway) verifySignature(payload []byte, header string) error {
+	if g.webhookSecret == "" {
+		return errors.New("STRIPE_WEBHOOK_SECRET is not set")
+	}
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return errors.New("malformed Stripe-Signature header")
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("malformed Stripe-Signature timestamp")
+	}
+	if age := g.now().Sub(time.Unix(seconds, 0)); age > stripeSignatureTolerance || age < -stripeSignatureTolerance {
+		return errors.New("stripe signature timestamp outside the tolerance")
+	}
+
+	mac := hmac.New(sha256.New, []byte(g.webhookSecret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	for _, sig := range signatures {
+		if hmac.Equal([]byte(expected), []byte(sig)) {
+			return nil
+		}
+	}
+	return errors.New("stripe signature mismatch")
+}
@@ -0,0 +1,80 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stripeSignature(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.%s", timestamp, payload)
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+func TestStripeParseWebhookVerifiesSignature(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	gateway := NewStripeGateway("https://api.stripe.com", "sk_test", "whsec_test", 0)
+	gateway.now = func() time.Time { return now }
+	payload := []byte(`{"type":"checkout.session.completed","data":{"object":{"id":"cs_1","client_reference_id":"ref-1","payment_intent":"pi_1","payment_status":"paid"}}}`)
+
+	event, err := gateway.ParseWebhook(payload, stripeSignature("whsec_test", now.Unix(), payload))
+	require.NoError(t, err)
+	assert.Equal(t, &WebhookEvent{Type: EventCheckoutCompleted, SessionID: "cs_1", Reference: "ref-1", PaymentID: "pi_1", Paid: true}, event)
+
+	_, err = gateway.ParseWebhook(payload, stripeSignature("other_secret", now.Unix(), payload))
+	assert.Error(t, err, "signed with another secret")
+	_, err = gateway.ParseWebhook(payload, stripeSignature("whsec_test", now.Add(-10*time.Minute).Unix(), payload))
+	assert.Error(t, err, "replayed outside the tolerance")
+	_, err = gateway.ParseWebhook(payload, "v1=abc")
+	assert.Error(t, err, "malformed header")
+}
+
+func TestStripeParseWebhookRefunds(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	gateway := NewStripeGateway("https://api.stripe.com", "sk_test", "whsec_test", 0)
+	gateway.now = func() time.Time { return now }
+
+	full := []byte(`{"type":"charge.refunded","data":{"object":{"id":"ch_1","payment_intent":"pi_1","refunded":true}}}`)
+	event, err := gateway.ParseWebhook(full, stripeSignature("whsec_test", now.Unix(), full))
+	require.NoError(t, err)
+	assert.Equal(t, EventRefunded, event.Type)
+	assert.Equal(t, "pi_1", event.PaymentID)
+
+	partial := []byte(`{"type":"charge.refunded","data":{"object":{"id":"ch_1","payment_intent":"pi_1","refunded":false}}}`)
+	event, err = gateway.ParseWebhook(partial, stripeSignature("whsec_test", now.Unix(), partial))
+	require.NoError(t, err)
+	assert.NotEqual(t, EventRefunded, event.Type, "partial refunds are ignored")
+}
+
+func TestStripeCreateCheckoutSession(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/checkout/sessions", r.URL.Path)
+		user, _, _ := r.BasicAuth()
+		assert.Equal(t, "sk_test", user)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "payment", r.PostForm.Get("mode"))
+		assert.Equal(t, "ref-1", r.PostForm.Get("client_reference_id"))
+		assert.Equal(t, "1400", r.PostForm.Get("line_items[0][price_data][unit_amount]"))
+		assert.Equal(t, "usd", r.PostForm.Get("line_items[0][price_data][currency]"))
+		fmt.Fprint(w, `{"id":"cs_1","url":"https://checkout.stripe.com/c/cs_1"}`)
+	}))
+	defer server.Close()
+
+	gateway := NewStripeGateway(server.URL, "sk_test", "whsec_test", 0)
+	session, err := gateway.CreateCheckoutSession(context.Background(), CheckoutParams{
+		Reference: "ref-1", Description: "Featured listing", AmountCents: 1400, Currency: "USD",
+		SuccessURL: "https://example.com/ok", CancelURL: "https://example.com/cancel",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &CheckoutSession{ID: "cs_1", URL: "https://checkout.stripe.com/c/cs_1"}, session)
+}
@@ -0,0 +1,26 @@
+// File: internal/platform/clientinfo/clientinfo.go
+package clientinfo
+
+import "context"
+
+// Info describes the client that sent a request.
+type Info struct {
+	// IP is the client address as resolved by the router, honouring trusted proxies.
+	IP string
+	// DeviceID is the device fingerprint the client sent, if any.
+	DeviceID string
+}
+
+type contextKey struct{}
+
+// WithInfo returns a copy of ctx carrying the client info.
+func WithInfo(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, contextKey{}, info)
+}
+
+// FromContext returns the client info stored in ctx, or the zero Info when the context
+// does not come from an HTTP request (e.g. background jobs).
+func FromContext(ctx context.Context) Info {
+	info, _ := ctx.Value(contextKey{}).(Info)
+	return info
+}
@@ -30,6 +30,45 @@ func NewGORM(cfg *config.Config) (*gorm.DB, error) {
 		cfg.DBTimezone,
 	)
 
+	db, err := openGORM(dsn, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	if cfg.DBQueryTimeout > 0 {
+		if err := db.Use(queryTimeout{timeout: cfg.DBQueryTimeout}); err != nil {
+			return nil, fmt.Errorf("failed to register query timeout: %w", err)
+		}
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	// Ping the database to verify connection
+	if err = sqlDB.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	log.Println("Successfully connected to the database.") // Use standard log for this one-time message
+
+	if dsns := replicaDSNs(cfg.DBReplicaDSNs); len(dsns) > 0 {
+		resolver, err := newReplicaResolver(dsns, cfg)
+		if err != nil {
+			return nil, err
+		}
+		if err := db.Use(resolver); err != nil {
+			resolver.close()
+			return nil, fmt.Errorf("failed to register read replicas: %w", err)
+		}
+	}
+	return db, nil
+}
+
+// openGORM opens a connection pool for dsn with the application's GORM, pool and statement_timeout settings.
+// It does not ping the database.
+func openGORM(dsn string, cfg *config.Config) (*gorm.DB, error) {
+	dsn = withStatementTimeout(dsn, cfg.DBStatementTimeout)
+
 	// GORM Logger Configuration
 	var gormLogLevel gormlogger.LogLevel
 	switch cfg.LogLevel {
@@ -61,11 +100,11 @@ func NewGORM(cfg *config.Config) (*gorm.DB, error) {
 		// TablePrefix:   "si_", // Optional: if you want a prefix for all tables
 		// SingularTable: false, // Use plural table names (e.g., "users" instead of "user")
 		// },
-		PrepareStmt: true, // Caches compiled statements for performance
+		PrepareStmt:          true, // Caches compiled statements for performance
+		DisableAutomaticPing: true, // Callers ping when they need the connection to be up
 	})
-
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
+		return nil, err
 	}
 
 	// Connection Pool Settings
@@ -73,17 +112,10 @@ func NewGORM(cfg *config.Config) (*gorm.DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
 	}
-
 	sqlDB.SetMaxIdleConns(cfg.DBMaxIdleConns)
 	sqlDB.SetMaxOpenConns(cfg.DBMaxOpenConns)
 	sqlDB.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
-
-	// Ping the database to verify connection
-	if err = sqlDB.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
-	}
-
-	log.Println("Successfully connected to the database.") // Use standard log for this one-time message
+	sqlDB.SetConnMaxIdleTime(cfg.DBConnMaxIdleTime)
 	return db, nil
 }
 
@@ -91,6 +123,9 @@ func NewGORM(cfg *config.Config) (*gorm.DB, error) {
 // This is useful for the cleanup function in main.
 func CloseGORMDB(db *gorm.DB) {
 	if db != nil {
+		if resolver, ok := db.Config.Plugins[replicaPluginName].(*replicaResolver); ok {
+			resolver.close()
+		}
 		sqlDB, err := db.DB()
 		if err != nil {
 			log.Printf("Error getting underlying SQL DB for closing: %v\n", err)
@@ -0,0 +1,190 @@
+// File: internal/platform/database/replicas.go
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"seattle_info_backend/internal/config"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// replicaPluginName is the name the replica resolver is registered under in gorm.Config.Plugins.
+	replicaPluginName = "seattle_info:read_replicas"
+	// replicaPingTimeout bounds a single replica health check.
+	replicaPingTimeout = 2 * time.Second
+	// defaultReplicaHealthCheckInterval is used when DB_REPLICA_HEALTH_CHECK_SECONDS is not positive.
+	defaultReplicaHealthCheckInterval = 10 * time.Second
+)
+
+type readFromReplicaKey struct{}
+
+// ReadFromReplica marks ctx so that the queries run with it may be served by a read replica.
+// Use it only for reads that tolerate replication lag. Without configured replicas, or inside
+// a transaction, the queries still go to the primary.
+func ReadFromReplica(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readFromReplicaKey{}, true)
+}
+
+// replicaDSNs splits the comma-separated DB_REPLICA_DSNS value.
+func replicaDSNs(value string) []string {
+	var dsns []string
+	for _, dsn := range strings.Split(value, ",") {
+		if dsn = strings.TrimSpace(dsn); dsn != "" {
+			dsns = append(dsns, dsn)
+		}
+	}
+	return dsns
+}
+
+// replica is one read replica connection pool and its last known health.
+type replica struct {
+	name    string // "replica N", so that logs never contain the DSN and its password
+	db      *gorm.DB
+	pool    gorm.ConnPool
+	healthy atomic.Bool
+}
+
+// replicaResolver is a GORM plugin that runs queries whose context was marked with ReadFromReplica
+// on a healthy read replica, round robin. Replicas are pinged periodically; while none is healthy,
+// marked queries fall back to the primary. Writes never use a replica.
+type replicaResolver struct {
+	replicas []*replica
+	next     atomic.Uint64
+	interval time.Duration
+	stop     chan struct{}
+	wg       sync.WaitGroup
+	once     sync.Once
+}
+
+func newReplicaResolver(dsns []string, cfg *config.Config) (*replicaResolver, error) {
+	r := &replicaResolver{
+		interval: cfg.DBReplicaHealthCheckInterval,
+		stop:     make(chan struct{}),
+	}
+	if r.interval <= 0 {
+		r.interval = defaultReplicaHealthCheckInterval
+	}
+	for i, dsn := range dsns {
+		db, err := openGORM(dsn, cfg)
+		if err != nil {
+			r.closeReplicas()
+			return nil, fmt.Errorf("failed to open read replica %d: %w", i+1, err)
+		}
+		r.replicas = append(r.replicas, &replica{name: fmt.Sprintf("replica %d", i+1), db: db, pool: db.ConnPool})
+	}
+	return r, nil
+}
+
+// Name implements gorm.Plugin.
+func (r *replicaResolver) Name() string {
+	return replicaPluginName
+}
+
+// Initialize implements gorm.Plugin. It checks the replicas once, so that the first requests
+// already use the healthy ones, and starts the periodic health checks.
+func (r *replicaResolver) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Query().Before("gorm:query").Register("replicas:route_query", r.route); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register("replicas:route_row", r.route); err != nil {
+		return err
+	}
+
+	r.checkHealth()
+	healthy := 0
+	for _, rep := range r.replicas {
+		if rep.healthy.Load() {
+			healthy++
+		}
+	}
+	log.Printf("Read replicas configured: %d, healthy: %d.\n", len(r.replicas), healthy)
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				r.checkHealth()
+			case <-r.stop:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// route switches a marked statement to a healthy replica.
+func (r *replicaResolver) route(db *gorm.DB) {
+	ctx := db.Statement.Context
+	if ctx == nil || ctx.Value(readFromReplicaKey{}) == nil {
+		return
+	}
+	if _, inTransaction := db.Statement.ConnPool.(gorm.TxCommitter); inTransaction {
+		return // Reads in a transaction must see its writes
+	}
+	if rep := r.pick(); rep != nil {
+		db.Statement.ConnPool = rep.pool
+	}
+}
+
+// pick returns the next healthy replica, or nil when there is none.
+func (r *replicaResolver) pick() *replica {
+	n := uint64(len(r.replicas))
+	start := r.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		if rep := r.replicas[(start+i)%n]; rep.healthy.Load() {
+			return rep
+		}
+	}
+	return nil
+}
+
+// checkHealth pings every replica and logs the ones whose health changed.
+func (r *replicaResolver) checkHealth() {
+	for _, rep := range r.replicas {
+		healthy := false
+		if sqlDB, err := rep.db.DB(); err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), replicaPingTimeout)
+			err = sqlDB.PingContext(ctx)
+			cancel()
+			healthy = err == nil
+			if !healthy && rep.healthy.Load() {
+				log.Printf("Read %s is unhealthy and is skipped until it answers again: %v\n", rep.name, err)
+			}
+		}
+		if healthy && !rep.healthy.Load() {
+			log.Printf("Read %s is healthy.\n", rep.name)
+		}
+		rep.healthy.Store(healthy)
+	}
+}
+
+// close stops the health checks and closes the replica connections.
+func (r *replicaResolver) close() {
+	r.once.Do(func() {
+		close(r.stop)
+		r.wg.Wait()
+		r.closeReplicas()
+	})
+}
+
+func (r *replicaResolver) closeReplicas() {
+	for _, rep := range r.replicas {
+		if sqlDB, err := rep.db.DB(); err == nil {
+			if err := sqlDB.Close(); err != nil {
+				log.Printf("Error closing read %s: %v\n", rep.name, err)
+			}
+		}
+	}
+}
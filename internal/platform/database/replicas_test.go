@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// fakePool stands in for a connection pool; routing only compares pools.
+type fakePool struct {
+	gorm.ConnPool
+	name string
+}
+
+type fakeTx struct {
+	fakePool
+}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+func TestReplicaDSNs(t *testing.T) {
+	assert.Nil(t, replicaDSNs(""))
+	assert.Equal(t, []string{"host=a", "host=b port=5433"}, replicaDSNs(" host=a ,, host=b port=5433,"))
+}
+
+func TestReplicaResolverRoute(t *testing.T) {
+	primary := &fakePool{name: "primary"}
+	r1, r2 := &replica{pool: &fakePool{name: "r1"}}, &replica{pool: &fakePool{name: "r2"}}
+	resolver := &replicaResolver{replicas: []*replica{r1, r2}}
+	r1.healthy.Store(true)
+	r2.healthy.Store(true)
+
+	routed := func(ctx context.Context, pool gorm.ConnPool) gorm.ConnPool {
+		db := &gorm.DB{Statement: &gorm.Statement{Context: ctx, ConnPool: pool}}
+		resolver.route(db)
+		return db.Statement.ConnPool
+	}
+	replicaCtx := ReadFromReplica(context.Background())
+
+	assert.Same(t, primary, routed(context.Background(), primary), "unmarked queries stay on the primary")
+	first, second := routed(replicaCtx, primary), routed(replicaCtx, primary)
+	assert.NotSame(t, first, second, "healthy replicas are used in turn")
+	assert.ElementsMatch(t, []gorm.ConnPool{r1.pool, r2.pool}, []gorm.ConnPool{first, second})
+
+	tx := &fakeTx{fakePool{name: "tx"}}
+	assert.Same(t, tx, routed(replicaCtx, tx), "reads in a transaction stay on it")
+
+	r1.healthy.Store(false)
+	assert.Same(t, r2.pool, routed(replicaCtx, primary))
+	assert.Same(t, r2.pool, routed(replicaCtx, primary))
+
+	r2.healthy.Store(false)
+	assert.Same(t, primary, routed(replicaCtx, primary), "falls back to the primary without healthy replicas")
+}
@@ -0,0 +1,59 @@
+// File: internal/platform/database/stats.go
+package database
+
+import (
+	"database/sql"
+
+	"gorm.io/gorm"
+)
+
+// PoolStats describes one connection pool, the primary's or a read replica's.
+type PoolStats struct {
+	Name              string `json:"name"`
+	Healthy           *bool  `json:"healthy,omitempty"` // Read replicas only
+	MaxOpen           int    `json:"max_open"`
+	Open              int    `json:"open"`
+	InUse             int    `json:"in_use"`
+	Idle              int    `json:"idle"`
+	WaitCount         int64  `json:"wait_count"`       // Total waits for a free connection
+	WaitDurationMS    int64  `json:"wait_duration_ms"` // Total time spent waiting
+	MaxIdleClosed     int64  `json:"max_idle_closed"`
+	MaxLifetimeClosed int64  `json:"max_lifetime_closed"`
+}
+
+// PoolStatistics reports the connection pools behind db: the primary first, then its read replicas.
+// A WaitCount that keeps growing means requests are queuing for connections.
+func PoolStatistics(db *gorm.DB) ([]PoolStats, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	stats := []PoolStats{poolStats("primary", sqlDB.Stats())}
+	if resolver, ok := db.Config.Plugins[replicaPluginName].(*replicaResolver); ok {
+		for _, rep := range resolver.replicas {
+			replicaDB, err := rep.db.DB()
+			if err != nil {
+				return nil, err
+			}
+			s := poolStats(rep.name, replicaDB.Stats())
+			healthy := rep.healthy.Load()
+			s.Healthy = &healthy
+			stats = append(stats, s)
+		}
+	}
+	return stats, nil
+}
+
+func poolStats(name string, s sql.DBStats) PoolStats {
+	return PoolStats{
+		Name:              name,
+		MaxOpen:           s.MaxOpenConnections,
+		Open:              s.OpenConnections,
+		InUse:             s.InUse,
+		Idle:              s.Idle,
+		WaitCount:         s.WaitCount,
+		WaitDurationMS:    s.WaitDuration.Milliseconds(),
+		MaxIdleClosed:     s.MaxIdleClosed,
+		MaxLifetimeClosed: s.MaxLifetimeClosed,
+	}
+}
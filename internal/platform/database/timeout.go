@@ -0,0 +1,92 @@
+// File: internal/platform/database/timeout.go
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// queryTimeoutPluginName is the name the query timeout plugin is registered under in gorm.Config.Plugins.
+	queryTimeoutPluginName = "seattle_info:query_timeout"
+	// queryTimeoutCancelKey stores a statement's timedStatement between the first and the last callback.
+	queryTimeoutCancelKey = "seattle_info:query_timeout_cancel"
+)
+
+// withStatementTimeout adds Postgres' statement_timeout session parameter to dsn, unless dsn sets it already.
+// Both key=value and URL DSNs are supported; the driver passes unknown settings on to the server.
+func withStatementTimeout(dsn string, timeout time.Duration) string {
+	if timeout <= 0 || strings.Contains(dsn, "statement_timeout") {
+		return dsn
+	}
+	ms := timeout.Milliseconds()
+	if !strings.Contains(dsn, "://") {
+		return fmt.Sprintf("%s statement_timeout=%d", dsn, ms)
+	}
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+	return fmt.Sprintf("%s%sstatement_timeout=%d", dsn, separator, ms)
+}
+
+// queryTimeout is a GORM plugin that gives every create, query, update, delete and raw statement
+// a context deadline. The request context already reaches GORM through WithContext; the deadline
+// keeps a single statement, including the wait for a free pool connection, from outliving it.
+// Row and Rows are not covered, because their results are read after the callbacks return.
+type queryTimeout struct {
+	timeout time.Duration
+}
+
+// Name implements gorm.Plugin.
+func (q queryTimeout) Name() string {
+	return queryTimeoutPluginName
+}
+
+// Initialize implements gorm.Plugin.
+func (q queryTimeout) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("*").Register("query_timeout:start_create", q.start),
+		cb.Create().After("*").Register("query_timeout:end_create", q.end),
+		cb.Query().Before("*").Register("query_timeout:start_query", q.start),
+		cb.Query().After("*").Register("query_timeout:end_query", q.end),
+		cb.Update().Before("*").Register("query_timeout:start_update", q.start),
+		cb.Update().After("*").Register("query_timeout:end_update", q.end),
+		cb.Delete().Before("*").Register("query_timeout:start_delete", q.start),
+		cb.Delete().After("*").Register("query_timeout:end_delete", q.end),
+		cb.Raw().Before("*").Register("query_timeout:start_raw", q.start),
+		cb.Raw().After("*").Register("query_timeout:end_raw", q.end),
+	)
+}
+
+// timedStatement remembers what start changed on a statement, so that end can undo it.
+type timedStatement struct {
+	parent context.Context
+	cancel context.CancelFunc
+}
+
+func (q queryTimeout) start(db *gorm.DB) {
+	parent := db.Statement.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, q.timeout)
+	db.Statement.Context = ctx
+	db.InstanceSet(queryTimeoutCancelKey, timedStatement{parent: parent, cancel: cancel})
+}
+
+// end cancels the deadline and restores the statement's own context, since chained calls
+// such as Count followed by Find run on the same statement.
+func (q queryTimeout) end(db *gorm.DB) {
+	if v, ok := db.InstanceGet(queryTimeoutCancelKey); ok {
+		timed := v.(timedStatement)
+		timed.cancel()
+		db.Statement.Context = timed.parent
+	}
+}
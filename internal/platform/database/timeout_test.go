@@ -0,0 +1,37 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestWithStatementTimeout(t *testing.T) {
+	assert.Equal(t, "host=db user=app statement_timeout=1500", withStatementTimeout("host=db user=app", 1500*time.Millisecond))
+	assert.Equal(t, "postgres://db/app?statement_timeout=2000", withStatementTimeout("postgres://db/app", 2*time.Second))
+	assert.Equal(t, "postgres://db/app?sslmode=disable&statement_timeout=2000", withStatementTimeout("postgres://db/app?sslmode=disable", 2*time.Second))
+	assert.Equal(t, "host=db statement_timeout=50", withStatementTimeout("host=db statement_timeout=50", time.Second), "an explicit setting wins")
+	assert.Equal(t, "host=db", withStatementTimeout("host=db", 0))
+}
+
+func TestQueryTimeoutRestoresStatementContext(t *testing.T) {
+	q := queryTimeout{timeout: time.Minute}
+	parent := context.WithValue(context.Background(), readFromReplicaKey{}, true)
+	db := &gorm.DB{Config: &gorm.Config{}, Statement: &gorm.Statement{Context: parent}}
+	db.Statement.DB = db
+
+	q.start(db)
+	ctx := db.Statement.Context
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+	assert.NotNil(t, ctx.Value(readFromReplicaKey{}), "values of the request context are kept")
+
+	q.end(db)
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	assert.Equal(t, parent, db.Statement.Context, "a chained call on the same statement starts from the original context")
+}
@@ -0,0 +1,202 @@
+// File: internal/platform/email/email.go
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/config"
+
+	"go.uber.org/zap"
+)
+
+// sendTimeout bounds connecting to the SMTP server and delivering one message.
+const sendTimeout = 15 * time.Second
+
+// Message is a plain text email.
+type Message struct {
+	To      string
+	ReplyTo string // Optional
+	Subject string
+	Body    string
+}
+
+// Sender delivers emails.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SMTPSender sends emails through an SMTP server.
+type SMTPSender struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     mail.Address
+}
+
+// NewSMTPSender creates an SMTPSender. Port 465 uses implicit TLS; on other ports the connection is upgraded with
+// STARTTLS when the server offers it.
+func NewSMTPSender(host, port, username, password string, from mail.Address) *SMTPSender {
+	return &SMTPSender{host: host, port: port, username: username, password: password, from: from}
+}
+
+// Send implements Sender.
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	data, err := compose(s.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(sendTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	dialer := &net.Dialer{Deadline: deadline}
+	addr := net.JoinHostPort(s.host, s.port)
+	var conn net.Conn
+	if s.port == "465" {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{ServerName: s.host})
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to set SMTP deadline: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("SMTP handshake failed: %w", err)
+	}
+	defer client.Close()
+
+	if _, isTLS := conn.(*tls.Conn); !isTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+				return fmt.Errorf("SMTP STARTTLS failed: %w", err)
+			}
+		}
+	}
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+	if err := client.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM rejected: %w", err)
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return fmt.Errorf("SMTP RCPT TO rejected: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA rejected: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("SMTP server did not accept the email: %w", err)
+	}
+	return client.Quit()
+}
+
+// compose renders msg as an RFC 5322 message with a quoted-printable UTF-8 body.
+func compose(from mail.Address, msg Message, now time.Time) ([]byte, error) {
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient address: %w", err)
+	}
+	headers := [][2]string{
+		{"From", from.String()},
+		{"To", to.String()},
+	}
+	if msg.ReplyTo != "" {
+		replyTo, err := mail.ParseAddress(msg.ReplyTo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reply-to address: %w", err)
+		}
+		headers = append(headers, [2]string{"Reply-To", replyTo.String()})
+	}
+	headers = append(headers,
+		[2]string{"Subject", mime.QEncoding.Encode("utf-8", singleLine(msg.Subject))},
+		[2]string{"Date", now.Format(time.RFC1123Z)},
+		[2]string{"Message-ID", messageID(from.Address)},
+		[2]string{"MIME-Version", "1.0"},
+		[2]string{"Content-Type", "text/plain; charset=utf-8"},
+		[2]string{"Content-Transfer-Encoding", "quoted-printable"},
+	)
+
+	var buf bytes.Buffer
+	for _, h := range headers {
+		buf.WriteString(h[0] + ": " + h[1] + "\r\n")
+	}
+	buf.WriteString("\r\n")
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(msg.Body, "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// singleLine replaces line breaks, so user-provided text cannot add headers.
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func messageID(fromAddress string) string {
+	domain := "localhost"
+	if at := strings.LastIndexByte(fromAddress, '@'); at >= 0 {
+		domain = fromAddress[at+1:]
+	}
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">"
+}
+
+// LogSender writes emails to the log instead of sending them. It is used when no SMTP server is configured, so
+// that features sending email can be exercised in local development.
+type LogSender struct {
+	logger *zap.Logger
+}
+
+// Send implements Sender.
+func (s *LogSender) Send(_ context.Context, msg Message) error {
+	s.logger.Warn("SMTP server not configured; email not sent",
+		zap.String("to", msg.To),
+		zap.String("replyTo", msg.ReplyTo),
+		zap.String("subject", msg.Subject),
+		zap.String("body", msg.Body),
+	)
+	return nil
+}
+
+// NewSender returns an SMTPSender when SMTP_HOST is set, and a LogSender otherwise.
+func NewSender(cfg *config.Config, logger *zap.Logger) Sender {
+	log := logger.Named("Email")
+	if cfg.SMTPHost == "" {
+		log.Warn("SMTP_HOST is not set; emails will only be logged")
+		return &LogSender{logger: log}
+	}
+	from := mail.Address{Name: cfg.EmailFromName, Address: cfg.EmailFromAddress}
+	return NewSMTPSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, from)
+}
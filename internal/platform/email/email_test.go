@@ -0,0 +1,60 @@
+package email
+
+import (
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompose(t *testing.T) {
+	from := mail.Address{Name: "Seattle Info", Address: "no-reply@seattleinfo.example"}
+	msg := Message{
+		To:      "owner@example.com",
+		ReplyTo: "reply+1234@relay.seattleinfo.example",
+		Subject: "New message\r\nBcc: victim@example.com",
+		Body:    "Is the bike still available?\nThanks",
+	}
+	data, err := compose(from, msg, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("compose: %v", err)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("composed message does not parse: %v", err)
+	}
+	if got := parsed.Header.Get("From"); got != `"Seattle Info" <no-reply@seattleinfo.example>` {
+		t.Errorf("From = %q", got)
+	}
+	if got := parsed.Header.Get("Reply-To"); got != "<reply+1234@relay.seattleinfo.example>" {
+		t.Errorf("Reply-To = %q", got)
+	}
+	if got := parsed.Header.Get("Bcc"); got != "" {
+		t.Errorf("subject line break added a header: Bcc = %q", got)
+	}
+	if got := parsed.Header.Get("Subject"); got != "New message Bcc: victim@example.com" {
+		t.Errorf("Subject = %q", got)
+	}
+	if !strings.Contains(string(data), "Is the bike still available?\r\nThanks") {
+		t.Errorf("body not found in message:\n%s", data)
+	}
+}
+
+func TestComposeRejectsInvalidRecipient(t *testing.T) {
+	from := mail.Address{Address: "no-reply@seattleinfo.example"}
+	if _, err := compose(from, Message{To: "not an address", Subject: "s", Body: "b"}, time.Now()); err == nil {
+		t.Error("expected an error for an invalid recipient")
+	}
+}
+
+func TestComposeOmitsEmptyReplyTo(t *testing.T) {
+	from := mail.Address{Address: "no-reply@seattleinfo.example"}
+	data, err := compose(from, Message{To: "owner@example.com", Subject: "s", Body: "b"}, time.Now())
+	if err != nil {
+		t.Fatalf("compose: %v", err)
+	}
+	if strings.Contains(string(data), "Reply-To:") {
+		t.Errorf("unexpected Reply-To header:\n%s", data)
+	}
+}
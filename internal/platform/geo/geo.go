@@ -1 +1,191 @@
+// File: internal/platform/geo/geo.go
 package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// earthRadiusKM is the mean Earth radius used for great-circle distances.
+const earthRadiusKM = 6371.0
+
+// BoundingBox is a WGS84 rectangle expressed as min/max longitude and latitude.
+type BoundingBox struct {
+	MinLon float64
+	MinLat float64
+	MaxLon float64
+	MaxLat float64
+}
+
+// ParseBoundingBox parses a "minLon,minLat,maxLon,maxLat" string into a BoundingBox.
+func ParseBoundingBox(raw string) (*BoundingBox, error) {
+	parts := strings.Split(raw, ",")
+	if len(parts) != 4 {
+		return nil, errors.New("bbox must have four comma-separated values: minLon,minLat,maxLon,maxLat")
+	}
+
+	values := make([]float64, 4)
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("bbox value %q is not a number", part)
+		}
+		values[i] = v
+	}
+
+	box := &BoundingBox{MinLon: values[0], MinLat: values[1], MaxLon: values[2], MaxLat: values[3]}
+	if err := box.Validate(); err != nil {
+		return nil, err
+	}
+	return box, nil
+}
+
+// Validate checks that the box uses valid coordinates and that min values do not exceed max values.
+func (b BoundingBox) Validate() error {
+	if !validLon(b.MinLon) || !validLon(b.MaxLon) {
+		return errors.New("bbox longitudes must be between -180 and 180")
+	}
+	if !validLat(b.MinLat) || !validLat(b.MaxLat) {
+		return errors.New("bbox latitudes must be between -90 and 90")
+	}
+	if b.MinLon > b.MaxLon || b.MinLat > b.MaxLat {
+		return errors.New("bbox minimum values must not exceed maximum values")
+	}
+	return nil
+}
+
+// geoJSONPolygon is the subset of a GeoJSON geometry needed to validate a polygon.
+type geoJSONPolygon struct {
+	Type        string         `json:"type"`
+	Coordinates [][][2]float64 `json:"coordinates"`
+}
+
+// ValidateGeoJSONPolygon checks that raw is a GeoJSON Polygon geometry with closed rings of valid coordinates.
+// The original string is meant to be passed to PostGIS (ST_GeomFromGeoJSON) once validated.
+func ValidateGeoJSONPolygon(raw string) error {
+	_, err := parseGeoJSONPolygon(raw)
+	return err
+}
+
+// Polygon is a polygon with an outer ring and optional holes, as [lon, lat] positions.
+type Polygon struct {
+	rings [][][2]float64
+}
+
+// ParseGeoJSONPolygon parses a GeoJSON Polygon geometry, validated as by ValidateGeoJSONPolygon.
+func ParseGeoJSONPolygon(raw string) (*Polygon, error) {
+	poly, err := parseGeoJSONPolygon(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &Polygon{rings: poly.Coordinates}, nil
+}
+
+// Contains reports whether the point lies inside the outer ring and outside every hole. Edges are treated as
+// straight lines in longitude and latitude, which is accurate enough for areas the size of a city or region.
+func (p *Polygon) Contains(lat, lon float64) bool {
+	if !ringContains(p.rings[0], lat, lon) {
+		return false
+	}
+	for _, hole := range p.rings[1:] {
+		if ringContains(hole, lat, lon) {
+			return false
+		}
+	}
+	return true
+}
+
+// ringContains is the even-odd ray casting test for a closed ring.
+func ringContains(ring [][2]float64, lat, lon float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		lonI, latI := ring[i][0], ring[i][1]
+		lonJ, latJ := ring[j][0], ring[j][1]
+		if (latI > lat) != (latJ > lat) && lon < (lonJ-lonI)*(lat-latI)/(latJ-latI)+lonI {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// DistanceKM returns the great-circle (haversine) distance between two points in kilometers.
+func DistanceKM(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKM * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// ServiceArea is the region listings may be placed in: a polygon, or else a radius around a center point.
+type ServiceArea struct {
+	polygon   *Polygon
+	centerLat float64
+	centerLon float64
+	radiusKM  float64
+}
+
+// NewServiceArea builds a service area from a GeoJSON Polygon geometry, which takes precedence, or a radius in
+// kilometers around a center. It returns nil, an area without bounds, when neither is set.
+func NewServiceArea(polygonGeoJSON string, centerLat, centerLon, radiusKM float64) (*ServiceArea, error) {
+	if strings.TrimSpace(polygonGeoJSON) != "" {
+		polygon, err := ParseGeoJSONPolygon(polygonGeoJSON)
+		if err != nil {
+			return nil, err
+		}
+		return &ServiceArea{polygon: polygon}, nil
+	}
+	if radiusKM <= 0 {
+		return nil, nil
+	}
+	if !validLat(centerLat) || !validLon(centerLon) {
+		return nil, errors.New("service area center must be a valid latitude and longitude")
+	}
+	return &ServiceArea{centerLat: centerLat, centerLon: centerLon, radiusKM: radiusKM}, nil
+}
+
+// Contains reports whether the point lies in the area. A nil area contains every point.
+func (a *ServiceArea) Contains(lat, lon float64) bool {
+	if a == nil {
+		return true
+	}
+	if a.polygon != nil {
+		return a.polygon.Contains(lat, lon)
+	}
+	return DistanceKM(a.centerLat, a.centerLon, lat, lon) <= a.radiusKM
+}
+
+// parseGeoJSONPolygon unmarshals and validates a GeoJSON Polygon geometry.
+func parseGeoJSONPolygon(raw string) (*geoJSONPolygon, error) {
+	var poly geoJSONPolygon
+	if err := json.Unmarshal([]byte(raw), &poly); err != nil {
+		return nil, fmt.Errorf("polygon is not valid GeoJSON: %w", err)
+	}
+	if poly.Type != "Polygon" {
+		return nil, errors.New("polygon must be a GeoJSON geometry of type Polygon")
+	}
+	if len(poly.Coordinates) == 0 {
+		return nil, errors.New("polygon must contain at least one ring")
+	}
+	for _, ring := range poly.Coordinates {
+		if len(ring) < 4 {
+			return nil, errors.New("polygon rings must contain at least four positions")
+		}
+		if ring[0] != ring[len(ring)-1] {
+			return nil, errors.New("polygon rings must be closed (first and last positions equal)")
+		}
+		for _, pos := range ring {
+			if !validLon(pos[0]) || !validLat(pos[1]) {
+				return nil, errors.New("polygon positions must be valid [lon, lat] coordinates")
+			}
+		}
+	}
+	return &poly, nil
+}
+
+func validLon(v float64) bool { return v >= -180 && v <= 180 }
+func validLat(v float64) bool { return v >= -90 && v <= 90 }
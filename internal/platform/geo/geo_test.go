@@ -0,0 +1,84 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBoundingBox(t *testing.T) {
+	box, err := ParseBoundingBox("-122.45, 47.55,-122.25,47.70")
+	require.NoError(t, err)
+	assert.Equal(t, BoundingBox{MinLon: -122.45, MinLat: 47.55, MaxLon: -122.25, MaxLat: 47.70}, *box)
+
+	invalid := []string{
+		"",
+		"1,2,3",
+		"a,47.5,-122.2,47.7",
+		"-122.2,47.5,-122.4,47.7", // min lon > max lon
+		"-200,47.5,-122.2,47.7",
+		"-122.4,-95,-122.2,47.7",
+	}
+	for _, raw := range invalid {
+		_, err := ParseBoundingBox(raw)
+		assert.Error(t, err, "expected error for %q", raw)
+	}
+}
+
+func TestValidateGeoJSONPolygon(t *testing.T) {
+	valid := `{"type":"Polygon","coordinates":[[[-122.4,47.5],[-122.2,47.5],[-122.2,47.7],[-122.4,47.5]]]}`
+	assert.NoError(t, ValidateGeoJSONPolygon(valid))
+
+	invalid := []string{
+		`not json`,
+		`{"type":"Point","coordinates":[-122.4,47.5]}`,
+		`{"type":"Polygon","coordinates":[]}`,
+		`{"type":"Polygon","coordinates":[[[-122.4,47.5],[-122.2,47.5],[-122.4,47.5]]]}`,
+		`{"type":"Polygon","coordinates":[[[-122.4,47.5],[-122.2,47.5],[-122.2,47.7],[-122.3,47.6]]]}`,
+		`{"type":"Polygon","coordinates":[[[-222.4,47.5],[-122.2,47.5],[-122.2,47.7],[-222.4,47.5]]]}`,
+	}
+	for _, raw := range invalid {
+		assert.Error(t, ValidateGeoJSONPolygon(raw), "expected error for %s", raw)
+	}
+}
+
+func TestPolygonContains(t *testing.T) {
+	// A square around downtown Seattle with a hole over its north-east quarter.
+	poly, err := ParseGeoJSONPolygon(`{"type":"Polygon","coordinates":[
+		[[-122.40,47.55],[-122.30,47.55],[-122.30,47.65],[-122.40,47.65],[-122.40,47.55]],
+		[[-122.35,47.60],[-122.30,47.60],[-122.30,47.65],[-122.35,47.65],[-122.35,47.60]]]}`)
+	require.NoError(t, err)
+
+	assert.True(t, poly.Contains(47.57, -122.38))
+	assert.False(t, poly.Contains(47.62, -122.32), "inside the hole")
+	assert.False(t, poly.Contains(47.70, -122.38), "north of the square")
+	assert.False(t, poly.Contains(45.52, -122.68), "Portland")
+}
+
+func TestDistanceKM(t *testing.T) {
+	// Seattle to Portland is about 233 km as the crow flies.
+	assert.InDelta(t, 233, DistanceKM(47.6062, -122.3321, 45.5152, -122.6784), 2)
+	assert.Zero(t, DistanceKM(47.6, -122.3, 47.6, -122.3))
+}
+
+func TestServiceArea(t *testing.T) {
+	unbounded, err := NewServiceArea("", 47.6062, -122.3321, 0)
+	require.NoError(t, err)
+	assert.Nil(t, unbounded)
+	assert.True(t, unbounded.Contains(-33.87, 151.21), "a nil area contains every point")
+
+	radius, err := NewServiceArea("", 47.6062, -122.3321, 80)
+	require.NoError(t, err)
+	assert.True(t, radius.Contains(47.2529, -122.4443), "Tacoma")
+	assert.False(t, radius.Contains(45.5152, -122.6784), "Portland")
+
+	polygon, err := NewServiceArea(`{"type":"Polygon","coordinates":[[[-122.5,47.4],[-122.1,47.4],[-122.1,47.8],[-122.5,47.8],[-122.5,47.4]]]}`, 0, 0, 500)
+	require.NoError(t, err)
+	assert.False(t, polygon.Contains(47.2529, -122.4443), "the polygon takes precedence over the radius")
+
+	_, err = NewServiceArea(`{"type":"Point"}`, 0, 0, 0)
+	assert.Error(t, err)
+	_, err = NewServiceArea("", 95, 0, 10)
+	assert.Error(t, err)
+}
@@ -0,0 +1,31 @@
+// File: internal/platform/logger/sinks.go
+package logger
+
+import (
+	"io"
+	"os"
+	"seattle_info_backend/internal/config"
+
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+func stdStream(name string) io.Writer {
+	if name == "stderr" {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// newRotatingFile returns a sink writing to path, rotated by lumberjack once it reaches LOG_FILE_MAX_SIZE_MB.
+// Rotated files are kept for LOG_FILE_MAX_AGE_DAYS, at most LOG_FILE_MAX_BACKUPS of them.
+func newRotatingFile(cfg *config.Config, path string) zapcore.WriteSyncer {
+	// lumberjack.Logger is safe for concurrent use, and writes straight to the file: there is nothing to sync.
+	return zapcore.AddSync(&lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    cfg.LogFileMaxSizeMB,
+		MaxBackups: cfg.LogFileMaxBackups,
+		MaxAge:     cfg.LogFileMaxAgeDays,
+		Compress:   cfg.LogFileCompress,
+	})
+}
@@ -0,0 +1,20 @@
+// File: internal/platform/logger/syslog.go
+//go:build !windows && !plan9
+
+package logger
+
+import (
+	"log/syslog"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// newSyslogSink connects to a syslog daemon: the local one when network and address are empty, otherwise
+// address over network ("udp" or "tcp"). Each entry is sent as one message.
+func newSyslogSink(network, address, tag string) (zapcore.WriteSyncer, error) {
+	writer, err := syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	if err != nil {
+		return nil, err
+	}
+	return zapcore.Lock(zapcore.AddSync(writer)), nil
+}
@@ -0,0 +1,14 @@
+// File: internal/platform/logger/syslog_other.go
+//go:build windows || plan9
+
+package logger
+
+import (
+	"errors"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func newSyslogSink(network, address, tag string) (zapcore.WriteSyncer, error) {
+	return nil, errors.New("syslog is not supported on this platform")
+}
@@ -2,85 +2,115 @@
 package logger
 
 import (
+	"fmt"
 	"seattle_info_backend/internal/config" // Import your config package
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-// New initializes a new Zap logger based on the application configuration.
-// It takes the ginMode and logLevel from the config.
-func New(cfg *config.Config) (*zap.Logger, error) {
-	var zapConfig zap.Config
+// NewLevel returns the level of the application logger, set from LOG_LEVEL. It is shared by all the cores of the
+// logger, so changing it at runtime (PUT /api/v1/admin/log-level) takes effect everywhere at once.
+func NewLevel(cfg *config.Config) zap.AtomicLevel {
+	level, err := ParseLevel(cfg.LogLevel)
+	if err != nil {
+		level = zapcore.InfoLevel // Default level
+	}
+	return zap.NewAtomicLevelAt(level)
+}
 
-	// Set log level
-	logLevel := strings.ToLower(cfg.LogLevel)
-	level := zapcore.InfoLevel // Default level
-	switch logLevel {
+// ParseLevel parses a level name as accepted in LOG_LEVEL.
+func ParseLevel(name string) (zapcore.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
 	case "debug":
-		level = zapcore.DebugLevel
+		return zapcore.DebugLevel, nil
 	case "info":
-		level = zapcore.InfoLevel
+		return zapcore.InfoLevel, nil
 	case "warn", "warning":
-		level = zapcore.WarnLevel
+		return zapcore.WarnLevel, nil
 	case "error":
-		level = zapcore.ErrorLevel
+		return zapcore.ErrorLevel, nil
 	case "dpanic":
-		level = zapcore.DPanicLevel
+		return zapcore.DPanicLevel, nil
 	case "panic":
-		level = zapcore.PanicLevel
+		return zapcore.PanicLevel, nil
 	case "fatal":
-		level = zapcore.FatalLevel
+		return zapcore.FatalLevel, nil
 	}
+	return zapcore.InfoLevel, fmt.Errorf("unknown log level %q", name)
+}
 
+// New initializes a new Zap logger based on the application configuration.
+// Entries at level and above go to every sink in LOG_OUTPUTS (stdout, stderr, a rotating file, syslog); errors are
+// also written to LOG_ERROR_FILE_PATH when it is set.
+func New(cfg *config.Config, level zap.AtomicLevel) (*zap.Logger, error) {
 	// Configure based on Gin mode (similar to how Gin sets up its logger)
+	var encoderConfig zapcore.EncoderConfig
 	if cfg.GinMode == "release" {
-		zapConfig = zap.NewProductionConfig()
-		zapConfig.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoderConfig = zap.NewProductionEncoderConfig()
 	} else { // "debug" or "test"
-		zapConfig = zap.NewDevelopmentConfig()
-		// More human-readable output for development
-		zapConfig.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
-		zapConfig.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder // Or zapcore.RFC3339TimeEncoder
+		encoderConfig = zap.NewDevelopmentEncoderConfig()
 	}
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 
-	// Override level from config
-	zapConfig.Level = zap.NewAtomicLevelAt(level)
+	// Output format from config: "json", or "console" (any other value) for dev-friendly logs
+	json := strings.ToLower(cfg.LogFormat) == "json"
 
-	// Output format from config
-	logFormat := strings.ToLower(cfg.LogFormat)
-	if logFormat == "json" {
-		zapConfig.Encoding = "json"
-	} else { // "console" or any other value defaults to console for dev-friendly logs
-		zapConfig.Encoding = "console"
-		if cfg.GinMode == "release" && logFormat != "json" {
-			// In release mode, if not json, still prefer json for machine readability
-			// unless explicitly console was requested. But production defaults to json.
-			// For simplicity, if logFormat is not 'json', it's 'console'.
-			// ProductionConfig already sets Encoding to "json".
-			// DevelopmentConfig sets Encoding to "console".
-			// So, this mostly affects if someone sets GinMode=release and LogFormat=console.
+	var cores []zapcore.Core
+	for _, output := range splitOutputs(cfg.LogOutputs) {
+		var sink zapcore.WriteSyncer
+		switch output {
+		case "stdout", "stderr":
+			sink = zapcore.Lock(zapcore.AddSync(stdStream(output)))
+		case "file":
+			if strings.TrimSpace(cfg.LogFilePath) == "" {
+				return nil, fmt.Errorf("LOG_OUTPUTS includes file but LOG_FILE_PATH is not set")
+			}
+			sink = newRotatingFile(cfg, cfg.LogFilePath)
+		case "syslog":
+			s, err := newSyslogSink(cfg.LogSyslogNetwork, cfg.LogSyslogAddress, cfg.LogSyslogTag)
+			if err != nil {
+				return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+			}
+			sink = s
+		default:
+			return nil, fmt.Errorf("unknown log output %q in LOG_OUTPUTS (expected stdout, stderr, file or syslog)", output)
 		}
+		// Colors only help on a terminal; files and syslog get plain level names.
+		colored := !json && cfg.GinMode != "release" && output != "file" && output != "syslog"
+		cores = append(cores, zapcore.NewCore(newEncoder(encoderConfig, json, colored), sink, level))
 	}
 
-	// Build the logger
-	logger, err := zapConfig.Build(zap.AddCallerSkip(1)) // AddCallerSkip to show correct caller
-	if err != nil {
-		return nil, err
+	if strings.TrimSpace(cfg.LogErrorFilePath) != "" {
+		errorsOnly := zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+			return l >= zapcore.ErrorLevel && level.Enabled(l)
+		})
+		cores = append(cores, zapcore.NewCore(newEncoder(encoderConfig, json, false), newRotatingFile(cfg, cfg.LogErrorFilePath), errorsOnly))
+	}
+
+	core := zapcore.NewTee(cores...)
+	options := []zap.Option{zap.AddCaller(), zap.AddCallerSkip(1)} // AddCallerSkip to show correct caller
+	if cfg.GinMode == "release" {
+		// As zap.NewProductionConfig: sample repeated entries and add stack traces to errors.
+		core = zapcore.NewSamplerWithOptions(core, time.Second, 100, 100)
+		options = append(options, zap.AddStacktrace(zapcore.ErrorLevel))
+	} else {
+		options = append(options, zap.Development(), zap.AddStacktrace(zapcore.WarnLevel))
 	}
 
 	// Redirect standard log output to Zap
 	// This is optional but can be useful to capture logs from libraries that use the standard `log` package.
 	// zap.RedirectStdLog(logger) // Be cautious with this, can have performance implications or duplicate logs in some cases.
 
-	return logger, nil
+	return zap.New(core, options...), nil
 }
 
 // NewSugaredLogger provides a SugaredLogger for convenience.
 // It's often easier to use for simple, less performance-critical logging.
 func NewSugaredLogger(cfg *config.Config) (*zap.SugaredLogger, error) {
-	logger, err := New(cfg)
+	logger, err := New(cfg, NewLevel(cfg))
 	if err != nil {
 		return nil, err
 	}
@@ -92,3 +122,32 @@ func NewDefaultLogger() *zap.Logger {
 	logger, _ := zap.NewDevelopment() // Errors are ignored for simplicity here
 	return logger
 }
+
+func newEncoder(encoderConfig zapcore.EncoderConfig, json, colored bool) zapcore.Encoder {
+	if json {
+		return zapcore.NewJSONEncoder(encoderConfig)
+	}
+	if colored {
+		encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	} else {
+		encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+	}
+	return zapcore.NewConsoleEncoder(encoderConfig)
+}
+
+// splitOutputs returns the distinct names in LOG_OUTPUTS, stdout when it is empty.
+func splitOutputs(spec string) []string {
+	var outputs []string
+	seen := map[string]bool{}
+	for _, output := range strings.Split(spec, ",") {
+		output = strings.ToLower(strings.TrimSpace(output))
+		if output != "" && !seen[output] {
+			seen[output] = true
+			outputs = append(outputs, output)
+		}
+	}
+	if len(outputs) == 0 {
+		return []string{"stdout"}
+	}
+	return outputs
+}
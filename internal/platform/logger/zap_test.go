@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"seattle_info_backend/internal/config"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewWritesFileSinksAndErrorStream(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{
+		GinMode:          "release",
+		LogLevel:         "info",
+		LogFormat:        "json",
+		LogOutputs:       "file",
+		LogFilePath:      filepath.Join(dir, "app.log"),
+		LogErrorFilePath: filepath.Join(dir, "error.log"),
+		LogFileMaxSizeMB: 1,
+	}
+	level := NewLevel(cfg)
+	log, err := New(cfg, level)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	log.Debug("hidden debug entry")
+	log.Info("info entry")
+	log.Error("error entry")
+	level.SetLevel(zapcore.DebugLevel)
+	log.Debug("visible debug entry")
+	_ = log.Sync()
+
+	app := readFile(t, cfg.LogFilePath)
+	for _, want := range []string{"info entry", "error entry", "visible debug entry"} {
+		if !strings.Contains(app, want) {
+			t.Errorf("app.log is missing %q:\n%s", want, app)
+		}
+	}
+	if strings.Contains(app, "hidden debug entry") {
+		t.Errorf("app.log has an entry below the level:\n%s", app)
+	}
+
+	errors := readFile(t, cfg.LogErrorFilePath)
+	if !strings.Contains(errors, "error entry") || strings.Contains(errors, "info entry") {
+		t.Errorf("error.log should hold only the error entry:\n%s", errors)
+	}
+}
+
+func TestNewRejectsUnknownOutput(t *testing.T) {
+	if _, err := New(&config.Config{LogOutputs: "stdout,kafka"}, NewLevel(&config.Config{})); err == nil {
+		t.Fatal("expected an error for an unknown output")
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	if level, err := ParseLevel(" Warning "); err != nil || level != zapcore.WarnLevel {
+		t.Errorf("ParseLevel(Warning) = %v, %v", level, err)
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	return string(b)
+}
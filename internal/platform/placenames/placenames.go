@@ -0,0 +1,128 @@
+// File: internal/platform/placenames/placenames.go
+package placenames
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"seattle_info_backend/internal/config"
+
+	"go.uber.org/zap"
+)
+
+//go:embed seattle.txt
+var builtin string
+
+// maxVariants bounds how many spellings a search term expands to.
+const maxVariants = 8
+
+// group is a set of equivalent place names with the pattern matching any of them.
+type group struct {
+	names   []string // Normalized; names[0] is canonical
+	pattern *regexp.Regexp
+}
+
+// Synonyms maps colloquial place names to the other names for the same place.
+type Synonyms struct {
+	groups []group
+}
+
+// New creates Synonyms from groups of equivalent names.
+func New(groups [][]string) *Synonyms {
+	s := &Synonyms{}
+	for _, names := range groups {
+		var g group
+		alternatives := make([]string, 0, len(names))
+		for _, name := range names {
+			if name = Normalize(name); name != "" {
+				g.names = append(g.names, name)
+				alternatives = append(alternatives, regexp.QuoteMeta(name))
+			}
+		}
+		if len(g.names) < 2 {
+			continue
+		}
+		// Longest first, so "the u district" wins over "u district" at the same position.
+		sort.Slice(alternatives, func(i, j int) bool { return len(alternatives[i]) > len(alternatives[j]) })
+		g.pattern = regexp.MustCompile(`\b(?:` + strings.Join(alternatives, "|") + `)\b`)
+		s.groups = append(s.groups, g)
+	}
+	return s
+}
+
+// Parse reads synonym groups: one group per line, names separated by commas, # starts a comment.
+func Parse(r io.Reader) ([][]string, error) {
+	var groups [][]string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		groups = append(groups, strings.Split(line, ","))
+	}
+	return groups, scanner.Err()
+}
+
+// NewSynonyms loads the built-in Seattle place names plus the groups in SEARCH_SYNONYMS_FILE, if set.
+func NewSynonyms(cfg *config.Config, logger *zap.Logger) (*Synonyms, error) {
+	groups, err := Parse(strings.NewReader(builtin))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse built-in place names: %w", err)
+	}
+	if cfg.SearchSynonymsFile != "" {
+		f, err := os.Open(cfg.SearchSynonymsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open search synonyms file: %w", err)
+		}
+		defer f.Close()
+		extra, err := Parse(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse search synonyms file: %w", err)
+		}
+		groups = append(groups, extra...)
+		logger.Info("Loaded search synonyms", zap.String("file", cfg.SearchSynonymsFile), zap.Int("groups", len(extra)))
+	}
+	return New(groups), nil
+}
+
+// Normalize lower-cases text and turns hyphens and runs of whitespace into single spaces.
+func Normalize(text string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(strings.ToLower(text), "-", " ")), " ")
+}
+
+// Expand returns the spellings of term to search for: term itself (lower-cased) followed by the term
+// with each place name it mentions replaced by the other names for that place.
+func (s *Synonyms) Expand(term string) []string {
+	variants := []string{strings.ToLower(strings.TrimSpace(term))}
+	seen := map[string]bool{variants[0]: true}
+	add := func(v string) {
+		if !seen[v] && len(variants) < maxVariants {
+			seen[v] = true
+			variants = append(variants, v)
+		}
+	}
+
+	normalized := Normalize(term)
+	add(normalized)
+	for _, g := range s.groups {
+		for _, v := range variants {
+			if !g.pattern.MatchString(v) {
+				continue
+			}
+			for _, name := range g.names {
+				add(g.pattern.ReplaceAllLiteralString(v, name))
+			}
+		}
+	}
+	return variants
+}
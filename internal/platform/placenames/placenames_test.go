@@ -0,0 +1,43 @@
+package placenames
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpand(t *testing.T) {
+	s := New([][]string{
+		{"capitol hill", "cap hill"},
+		{"university district", "u district", "the u district"},
+	})
+
+	assert.Equal(t, []string{"cap hill studio", "capitol hill studio"}, s.Expand("Cap Hill studio"))
+	assert.Equal(t, []string{"u-district room", "u district room", "university district room", "the u district room"}, s.Expand("U-District room"))
+	assert.Equal(t, []string{"bike"}, s.Expand("Bike"))
+	assert.Equal(t, []string{"capitol hill", "cap hill"}, s.Expand("capitol   hill")[1:])
+	assert.Equal(t, []string{"escape hill"}, s.Expand("escape hill"), "names match whole words only")
+}
+
+func TestExpandCombinesPlaces(t *testing.T) {
+	s := New([][]string{{"capitol hill", "cap hill"}, {"south lake union", "slu"}})
+
+	assert.ElementsMatch(t,
+		[]string{"cap hill or slu", "capitol hill or slu", "cap hill or south lake union", "capitol hill or south lake union"},
+		s.Expand("cap hill or slu"))
+}
+
+func TestParseBuiltin(t *testing.T) {
+	groups, err := Parse(strings.NewReader(builtin))
+	require.NoError(t, err)
+	require.NotEmpty(t, groups)
+	for _, g := range groups {
+		assert.GreaterOrEqual(t, len(g), 2, "every group needs a synonym: %v", g)
+	}
+
+	s := New(groups)
+	assert.Contains(t, s.Expand("U District apartment"), "university district apartment")
+	assert.Contains(t, s.Expand("slu office"), "south lake union office")
+}
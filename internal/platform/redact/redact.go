@@ -0,0 +1,156 @@
+// File: internal/platform/redact/redact.go
+// Package redact removes personal data and credentials from values before they are logged.
+package redact
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces personal data and credentials in logged values.
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are JSON fields and query parameters whose values are always redacted, whatever they look like.
+// Keys are compared in lower case with '-' treated as '_'.
+var sensitiveKeys = map[string]bool{
+	"password":         true,
+	"token":            true,
+	"access_token":     true,
+	"refresh_token":    true,
+	"id_token":         true,
+	"api_key":          true,
+	"secret":           true,
+	"authorization":    true,
+	"captcha_token":    true,
+	"code":             true,
+	"backup_codes":     true,
+	"email":            true,
+	"contact_email":    true,
+	"phone":            true,
+	"phone_number":     true,
+	"contact_phone":    true,
+	"address":          true,
+	"address_line1":    true,
+	"address_line2":    true,
+	"street":           true,
+	"street_address":   true,
+	"first_name":       true,
+	"last_name":        true,
+	"provisioning_uri": true,
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	// US phone numbers, e.g. (206) 555-0100, 206.555.0100, +1 206 555 0100.
+	phonePattern = regexp.MustCompile(`(?:\+?1[\s.-]?)?(?:\(\d{3}\)|\b\d{3})[\s.-]?\d{3}[\s.-]?\d{4}\b`)
+	// Bearer credentials, JWTs (Firebase ID tokens) and the prefixed tokens this API issues.
+	tokenPattern = regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9._~+/=-]+|eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*|\b(?:sik|anon|2fa)_[A-Za-z0-9_-]{8,}`)
+	// Street addresses: a house number followed by up to four words and a street suffix, e.g. 400 Broad St.
+	addressPattern = regexp.MustCompile(`(?i)\b\d{1,6}\s+(?:[A-Za-z0-9.'-]+\s+){0,4}(?:st|street|ave|avenue|rd|road|blvd|boulevard|dr|drive|ln|lane|way|pl|place|ct|court|ter|terrace|pkwy|parkway|hwy|highway)\b\.?`)
+	// errorCodePattern matches the codes of API errors, which share the "code" field with verification codes.
+	errorCodePattern = regexp.MustCompile(`^[A-Z][A-Z_]+$`)
+	// JSON string fields with a sensitive key, for bodies that are cut off and no longer parse.
+	sensitiveFieldPattern = regexp.MustCompile(`(?i)"([A-Za-z0-9_-]+)"\s*:\s*"((?:[^"\\]|\\.)*)"?`)
+)
+
+// String replaces emails, phone numbers, tokens and street addresses in s.
+func String(s string) string {
+	s = tokenPattern.ReplaceAllString(s, Redacted)
+	s = emailPattern.ReplaceAllString(s, Redacted)
+	s = addressPattern.ReplaceAllString(s, Redacted)
+	return phonePattern.ReplaceAllString(s, Redacted)
+}
+
+// Body returns a body for logging, at most maxBytes long. JSON bodies have the values of sensitive fields
+// replaced and the other strings redacted with String; other bodies are redacted as text.
+func Body(body []byte, maxBytes int) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err == nil {
+		if redacted, err := json.Marshal(redactValue(value)); err == nil {
+			return truncate(string(redacted), maxBytes)
+		}
+	}
+
+	text := sensitiveFieldPattern.ReplaceAllStringFunc(string(body), func(field string) string {
+		match := sensitiveFieldPattern.FindStringSubmatch(field)
+		key := match[1]
+		if !isSensitiveKey(key) || isErrorCode(key, match[2]) {
+			return field
+		}
+		return `"` + key + `":"` + Redacted + `"`
+	})
+	return truncate(String(text), maxBytes)
+}
+
+// Query returns a raw query string for logging, with the values of sensitive parameters replaced and the
+// others redacted with String. A query that does not parse is redacted as text.
+func Query(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return String(rawQuery)
+	}
+	for key, vs := range values {
+		for i, v := range vs {
+			if isSensitiveKey(key) {
+				vs[i] = Redacted
+			} else {
+				vs[i] = String(v)
+			}
+		}
+	}
+	// Encode escapes the brackets of the placeholder; they are kept readable.
+	return strings.ReplaceAll(values.Encode(), url.QueryEscape(Redacted), Redacted)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isSensitiveKey(key) && field != nil && !isErrorCode(key, field) {
+				v[key] = Redacted
+			} else {
+				v[key] = redactValue(field)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+		return v
+	case string:
+		return String(v)
+	default:
+		return v
+	}
+}
+
+// isErrorCode reports whether a "code" field holds the code of an API error rather than a verification code.
+func isErrorCode(key string, value interface{}) bool {
+	s, ok := value.(string)
+	return ok && strings.EqualFold(key, "code") && errorCodePattern.MatchString(s)
+}
+
+func isSensitiveKey(key string) bool {
+	return sensitiveKeys[strings.ReplaceAll(strings.ToLower(key), "-", "_")]
+}
+
+func truncate(s string, maxBytes int) string {
+	if maxBytes <= 0 || len(s) <= maxBytes {
+		return s
+	}
+	// Back off to the start of a UTF-8 sequence so the cut does not split a character.
+	cut := maxBytes
+	for cut > 0 && s[cut]&0xC0 == 0x80 {
+		cut--
+	}
+	return s[:cut] + "...(truncated)"
+}
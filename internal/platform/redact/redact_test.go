@@ -0,0 +1,73 @@
+package redact
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"email", "contact jane.doe+ads@example.com today", "contact [REDACTED] today"},
+		{"phone with parentheses", "call (206) 555-0100", "call [REDACTED]"},
+		{"phone with country code", "call +1 206.555.0100 now", "call [REDACTED] now"},
+		{"bearer token", "Authorization: Bearer abc.def-ghi", "Authorization: [REDACTED]"},
+		{"jwt", "token eyJhbGciOiJSUzI1NiJ9.eyJzdWIiOiIxIn0.c2ln", "token [REDACTED]"},
+		{"issued token", "X-Anonymous-Session: anon_q8Xr0mV3abcd", "X-Anonymous-Session: [REDACTED]"},
+		{"street address", "Meet at 400 Broad St. in the lobby", "Meet at [REDACTED] in the lobby"},
+		{"date is kept", "2023-01-15T10:00:00Z", "2023-01-15T10:00:00Z"},
+		{"uuid is kept", "a1b2c3d4-e5f6-7890-1234-567890abcdef", "a1b2c3d4-e5f6-7890-1234-567890abcdef"},
+		{"price is kept", "$1,250 per month", "$1,250 per month"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := String(tt.in); got != tt.want {
+				t.Errorf("String(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBodyJSON(t *testing.T) {
+	body := `{"title":"Bike for sale","contact_email":"seller@example.com","password":"hunter2",` +
+		`"description":"Text 206-555-0100","tags":["call 206 555 0100"],"code":"123456","price":150}`
+	got := Body([]byte(body), 0)
+	for _, leaked := range []string{"seller@example.com", "hunter2", "555", "123456"} {
+		if strings.Contains(got, leaked) {
+			t.Errorf("Body leaked %q: %s", leaked, got)
+		}
+	}
+	for _, kept := range []string{`"title":"Bike for sale"`, `"price":150`, `"description":"Text [REDACTED]"`} {
+		if !strings.Contains(got, kept) {
+			t.Errorf("Body lost %q: %s", kept, got)
+		}
+	}
+}
+
+func TestBodyKeepsErrorCodes(t *testing.T) {
+	got := Body([]byte(`{"code":"NOT_FOUND","message":"Listing not found."}`), 0)
+	if !strings.Contains(got, `"code":"NOT_FOUND"`) {
+		t.Errorf("Body redacted the error code: %s", got)
+	}
+}
+
+func TestBodyTruncated(t *testing.T) {
+	// Cut off inside a sensitive field, so the body no longer parses as JSON.
+	body := `{"title":"Room","contact_email":"seller@exam`
+	got := Body([]byte(body), 30)
+	if strings.Contains(got, "seller") {
+		t.Errorf("Body leaked a cut-off field: %s", got)
+	}
+	if !strings.HasSuffix(got, "...(truncated)") {
+		t.Errorf("expected a truncation marker: %s", got)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	got := Query("q=bike&token=abc123&near=jane@example.com")
+	want := "near=[REDACTED]&q=bike&token=[REDACTED]"
+	if got != want {
+		t.Errorf("Query() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,202 @@
+// File: internal/queue/consumer.go
+package queue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"seattle_info_backend/internal/config"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// baseRetryDelay is the wait before a failed task is retried; it doubles on each further failure.
+	baseRetryDelay = 30 * time.Second
+	// maxRetryDelay caps the backoff between attempts.
+	maxRetryDelay = time.Hour
+	// maxErrorLength bounds the error text stored on a task.
+	maxErrorLength = 1000
+)
+
+// HandlerFunc processes the JSON payload of a task. Returning an error retries the task with backoff
+// until it runs out of attempts and is moved to the dead-letter queue.
+type HandlerFunc func(ctx context.Context, payload []byte) error
+
+// Consumer runs queued tasks with the handlers registered for their type.
+type Consumer struct {
+	repo     Repository
+	cfg      *config.Config
+	logger   *zap.Logger
+	handlers map[string]HandlerFunc
+	now      func() time.Time
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewConsumer creates a Consumer. Register handlers with Handle before calling Start.
+func NewConsumer(repo Repository, cfg *config.Config, logger *zap.Logger) *Consumer {
+	return &Consumer{
+		repo:     repo,
+		cfg:      cfg,
+		logger:   logger.Named("QueueConsumer"),
+		handlers: make(map[string]HandlerFunc),
+		now:      time.Now,
+	}
+}
+
+// Handle registers fn for tasks of taskType. Tasks of types without a handler are left in the queue.
+func (c *Consumer) Handle(taskType string, fn HandlerFunc) {
+	c.handlers[taskType] = fn
+}
+
+// Start launches QUEUE_WORKERS goroutines polling for due tasks.
+func (c *Consumer) Start() {
+	if len(c.handlers) == 0 {
+		c.logger.Info("No task handlers registered; queue consumer not started.")
+		return
+	}
+	workers := c.cfg.QueueWorkers
+	if workers <= 0 {
+		workers = 1
+	}
+	c.stop = make(chan struct{})
+	for i := 0; i < workers; i++ {
+		c.wg.Add(1)
+		go c.loop()
+	}
+	c.logger.Info("Queue consumer started", zap.Int("workers", workers), zap.Strings("types", c.types()))
+}
+
+// Shutdown stops polling and waits for running tasks to finish, or until ctx is done.
+// Tasks still running when ctx ends are claimed again once their lock expires.
+func (c *Consumer) Shutdown(ctx context.Context) error {
+	if c.stop == nil {
+		return nil
+	}
+	close(c.stop)
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		c.logger.Info("Queue consumer stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Consumer) loop() {
+	defer c.wg.Done()
+	pollInterval := c.cfg.QueuePollInterval
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+	types := c.types()
+	for {
+		select {
+		case <-c.stop:
+			return
+		default:
+		}
+		if c.runNext(types) {
+			continue // Drain the backlog before sleeping
+		}
+		select {
+		case <-c.stop:
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// runNext claims and runs one task. It reports whether a task was found.
+func (c *Consumer) runNext(types []string) bool {
+	timeout := c.taskTimeout()
+	now := c.now().UTC()
+	task, err := c.repo.ClaimNext(context.Background(), types, now, now.Add(timeout))
+	if err != nil {
+		c.logger.Error("Failed to claim task", zap.Error(err))
+		return false
+	}
+	if task == nil {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	runErr := c.run(ctx, task)
+	c.finish(context.Background(), task, runErr)
+	return true
+}
+
+// run calls the task's handler, converting a panic into an error.
+func (c *Consumer) run(ctx context.Context, task *Task) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+	return c.handlers[task.Type](ctx, []byte(task.Payload))
+}
+
+// finish records the outcome of a task run: completed tasks are deleted, failed ones retried or buried.
+func (c *Consumer) finish(ctx context.Context, task *Task, runErr error) {
+	fields := []zap.Field{zap.String("type", task.Type), zap.String("taskID", task.ID.String()), zap.Int("attempt", task.Attempts)}
+	if runErr == nil {
+		if err := c.repo.Delete(ctx, task.ID); err != nil {
+			c.logger.Error("Failed to delete completed task", append(fields, zap.Error(err))...)
+		}
+		return
+	}
+
+	msg := runErr.Error()
+	if len(msg) > maxErrorLength {
+		msg = msg[:maxErrorLength]
+	}
+	if task.Attempts >= task.MaxAttempts {
+		c.logger.Error("Task failed permanently; moved to dead-letter queue", append(fields, zap.String("error", msg))...)
+		if err := c.repo.Bury(ctx, task.ID, msg); err != nil {
+			c.logger.Error("Failed to bury task", append(fields, zap.Error(err))...)
+		}
+		return
+	}
+	runAt := c.now().UTC().Add(retryDelay(task.Attempts))
+	c.logger.Warn("Task failed; will retry", append(fields, zap.String("error", msg), zap.Time("runAt", runAt))...)
+	if err := c.repo.Reschedule(ctx, task.ID, runAt, msg); err != nil {
+		c.logger.Error("Failed to reschedule task", append(fields, zap.Error(err))...)
+	}
+}
+
+func (c *Consumer) types() []string {
+	types := make([]string, 0, len(c.handlers))
+	for t := range c.handlers {
+		types = append(types, t)
+	}
+	return types
+}
+
+func (c *Consumer) taskTimeout() time.Duration {
+	if c.cfg.QueueTaskTimeout > 0 {
+		return c.cfg.QueueTaskTimeout
+	}
+	return 5 * time.Minute
+}
+
+// retryDelay returns the exponential backoff after the given number of failed attempts.
+func retryDelay(attempts int) time.Duration {
+	delay := baseRetryDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+	return delay
+}
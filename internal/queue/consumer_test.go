@@ -0,0 +1,188 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryRepository is an in-memory Repository for exercising the consumer without a database.
+type memoryRepository struct {
+	tasks map[uuid.UUID]*Task
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{tasks: make(map[uuid.UUID]*Task)}
+}
+
+func (r *memoryRepository) Create(_ context.Context, task *Task) error {
+	r.tasks[task.ID] = task
+	return nil
+}
+
+func (r *memoryRepository) ClaimNext(_ context.Context, types []string, now, lockedUntil time.Time) (*Task, error) {
+	for _, task := range r.tasks {
+		if !contains(types, task.Type) {
+			continue
+		}
+		due := task.Status == TaskPending && !task.RunAt.After(now)
+		expired := task.Status == TaskRunning && task.LockedUntil != nil && !task.LockedUntil.After(now)
+		if due || expired {
+			task.Status = TaskRunning
+			task.Attempts++
+			task.LockedUntil = &lockedUntil
+			claimed := *task
+			return &claimed, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryRepository) Delete(_ context.Context, id uuid.UUID) error {
+	delete(r.tasks, id)
+	return nil
+}
+
+func (r *memoryRepository) Reschedule(_ context.Context, id uuid.UUID, runAt time.Time, lastError string) error {
+	task := r.tasks[id]
+	task.Status, task.RunAt, task.LockedUntil, task.LastError = TaskPending, runAt, nil, &lastError
+	return nil
+}
+
+func (r *memoryRepository) Bury(_ context.Context, id uuid.UUID, lastError string) error {
+	task := r.tasks[id]
+	task.Status, task.LockedUntil, task.LastError = TaskDead, nil, &lastError
+	return nil
+}
+
+func (r *memoryRepository) FindDead(_ context.Context, page, pageSize int) ([]Task, *common.Pagination, error) {
+	var dead []Task
+	for _, task := range r.tasks {
+		if task.Status == TaskDead {
+			dead = append(dead, *task)
+		}
+	}
+	return dead, common.NewPagination(int64(len(dead)), page, pageSize), nil
+}
+
+func (r *memoryRepository) Requeue(_ context.Context, id uuid.UUID, runAt time.Time) error {
+	task, ok := r.tasks[id]
+	if !ok {
+		return common.ErrNotFound.WithDetails("Task not found.")
+	}
+	if task.Status != TaskDead {
+		return common.ErrConflict.WithDetails("Only tasks in the dead-letter queue can be retried.")
+	}
+	task.Status, task.Attempts, task.RunAt = TaskPending, 0, runAt
+	return nil
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+func newTestQueue(t *testing.T, maxAttempts int) (*memoryRepository, Service, *Consumer, *time.Time) {
+	t.Helper()
+	repo := newMemoryRepository()
+	cfg := &config.Config{QueueMaxAttempts: maxAttempts, QueueTaskTimeout: time.Minute}
+	now := time.Now().UTC().Add(time.Second) // Tasks enqueued by the test are due immediately
+	consumer := NewConsumer(repo, cfg, zap.NewNop())
+	consumer.now = func() time.Time { return now }
+	return repo, NewService(repo, cfg, zap.NewNop()), consumer, &now
+}
+
+func TestRetryDelayBacksOffExponentially(t *testing.T) {
+	assert.Equal(t, 30*time.Second, retryDelay(1))
+	assert.Equal(t, time.Minute, retryDelay(2))
+	assert.Equal(t, 2*time.Minute, retryDelay(3))
+	assert.Equal(t, maxRetryDelay, retryDelay(20))
+}
+
+func TestConsumerDeletesCompletedTask(t *testing.T) {
+	repo, svc, consumer, _ := newTestQueue(t, 3)
+	var got []byte
+	consumer.Handle("test.ok", func(_ context.Context, payload []byte) error {
+		got = payload
+		return nil
+	})
+	require.NoError(t, svc.Enqueue(context.Background(), "test.ok", map[string]string{"id": "42"}))
+
+	assert.True(t, consumer.runNext(consumer.types()))
+	assert.JSONEq(t, `{"id":"42"}`, string(got))
+	assert.Empty(t, repo.tasks)
+	assert.False(t, consumer.runNext(consumer.types()), "queue should be empty")
+}
+
+func TestConsumerRetriesThenBuriesFailingTask(t *testing.T) {
+	repo, svc, consumer, now := newTestQueue(t, 2)
+	calls := 0
+	consumer.Handle("test.fail", func(context.Context, []byte) error {
+		calls++
+		return errors.New("boom")
+	})
+	require.NoError(t, svc.Enqueue(context.Background(), "test.fail", nil))
+
+	assert.True(t, consumer.runNext(consumer.types()))
+	var task *Task
+	for _, tk := range repo.tasks {
+		task = tk
+	}
+	require.NotNil(t, task)
+	assert.Equal(t, TaskPending, task.Status)
+	assert.Equal(t, now.Add(baseRetryDelay), task.RunAt)
+	require.NotNil(t, task.LastError)
+	assert.Equal(t, "boom", *task.LastError)
+
+	assert.False(t, consumer.runNext(consumer.types()), "retry is not due yet")
+	*now = now.Add(baseRetryDelay)
+	assert.True(t, consumer.runNext(consumer.types()))
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, TaskDead, task.Status)
+
+	dead, _, err := svc.ListDead(context.Background(), 1, 10)
+	require.NoError(t, err)
+	assert.Len(t, dead, 1)
+
+	require.NoError(t, svc.RetryDead(context.Background(), task.ID))
+	assert.Equal(t, TaskPending, task.Status)
+	assert.Equal(t, 0, task.Attempts)
+}
+
+func TestConsumerRecoversFromHandlerPanic(t *testing.T) {
+	repo, svc, consumer, _ := newTestQueue(t, 1)
+	consumer.Handle("test.panic", func(context.Context, []byte) error {
+		panic("unexpected")
+	})
+	require.NoError(t, svc.Enqueue(context.Background(), "test.panic", nil))
+
+	assert.True(t, consumer.runNext(consumer.types()))
+	for _, task := range repo.tasks {
+		assert.Equal(t, TaskDead, task.Status)
+		require.NotNil(t, task.LastError)
+		assert.Contains(t, *task.LastError, "unexpected")
+	}
+}
+
+func TestRetryDeadRejectsTaskNotInDeadLetterQueue(t *testing.T) {
+	repo, svc, _, _ := newTestQueue(t, 3)
+	require.NoError(t, svc.Enqueue(context.Background(), "test.ok", nil, WithDelay(time.Hour)))
+	for id := range repo.tasks {
+		assert.True(t, errors.Is(svc.RetryDead(context.Background(), id), common.ErrConflict))
+	}
+
+	assert.True(t, errors.Is(svc.RetryDead(context.Background(), uuid.New()), common.ErrNotFound))
+}
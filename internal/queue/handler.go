@@ -0,0 +1,57 @@
+// File: internal/queue/handler.go
+package queue
+
+import (
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Handler struct holds dependencies for queue admin handlers.
+type Handler struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewHandler creates a new queue handler.
+func NewHandler(service Service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterAdminRoutes adds the dead-letter queue to the admin API group, which already requires the admin role.
+func (h *Handler) RegisterAdminRoutes(router *gin.RouterGroup) {
+	router.GET("/queue/dead", h.adminListDead)
+	router.POST("/queue/dead/:id/retry", h.adminRetryDead)
+}
+
+func (h *Handler) adminListDead(c *gin.Context) {
+	page, pageSize := common.GetPaginationParams(c)
+	tasks, pagination, err := h.service.ListDead(c.Request.Context(), page, pageSize)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]TaskResponse, len(tasks))
+	for i := range tasks {
+		responses[i] = ToTaskResponse(&tasks[i])
+	}
+	common.RespondPaginated(c, "Dead-letter queue retrieved successfully.", responses, pagination)
+}
+
+func (h *Handler) adminRetryDead(c *gin.Context) {
+	taskID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid task ID format."))
+		return
+	}
+	if err := h.service.RetryDead(c.Request.Context(), taskID); err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Task requeued.", nil)
+}
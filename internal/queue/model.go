@@ -0,0 +1,66 @@
+// File: internal/queue/model.go
+package queue
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskStatus is the state of a queued task. Tasks that succeed are deleted.
+type TaskStatus string
+
+const (
+	TaskPending TaskStatus = "pending" // Waiting for RunAt
+	TaskRunning TaskStatus = "running" // Claimed by a consumer until LockedUntil
+	TaskDead    TaskStatus = "dead"    // Out of attempts; kept in the dead-letter queue until retried
+)
+
+// Task is a unit of background work.
+type Task struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Type        string     `gorm:"type:varchar(100);not null"`
+	Payload     string     `gorm:"type:jsonb;not null"`
+	Status      TaskStatus `gorm:"type:varchar(20);not null;default:'pending'"`
+	Attempts    int        `gorm:"not null;default:0"`
+	MaxAttempts int        `gorm:"not null"`
+	RunAt       time.Time  `gorm:"not null"`
+	LockedUntil *time.Time // A running task whose lock expired is claimed again, e.g. after a crash
+	LastError   *string    `gorm:"type:text"`
+	CreatedAt   time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	UpdatedAt   time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName specifies the table name for GORM.
+func (Task) TableName() string {
+	return "queue_tasks"
+}
+
+// --- Response DTOs ---
+
+// TaskResponse is the API representation of a task in the dead-letter queue.
+type TaskResponse struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	Status    TaskStatus      `json:"status"`
+	Attempts  int             `json:"attempts"`
+	LastError *string         `json:"last_error,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// ToTaskResponse converts a Task model to a TaskResponse DTO.
+func ToTaskResponse(t *Task) TaskResponse {
+	return TaskResponse{
+		ID:        t.ID,
+		Type:      t.Type,
+		Payload:   json.RawMessage(t.Payload),
+		Status:    t.Status,
+		Attempts:  t.Attempts,
+		LastError: t.LastError,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
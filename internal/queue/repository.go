@@ -0,0 +1,150 @@
+// File: internal/queue/repository.go
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository is the storage backend of the queue. GORMRepository keeps tasks in Postgres, so the
+// queue needs no infrastructure beyond the database; another backend only has to implement this interface.
+type Repository interface {
+	Create(ctx context.Context, task *Task) error
+	// ClaimNext locks the next due task of one of the given types until lockedUntil and counts the attempt.
+	// It returns nil if no task is due.
+	ClaimNext(ctx context.Context, types []string, now, lockedUntil time.Time) (*Task, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Reschedule(ctx context.Context, id uuid.UUID, runAt time.Time, lastError string) error
+	Bury(ctx context.Context, id uuid.UUID, lastError string) error
+	FindDead(ctx context.Context, page, pageSize int) ([]Task, *common.Pagination, error)
+	Requeue(ctx context.Context, id uuid.UUID, runAt time.Time) error
+}
+
+// GORMRepository implements the queue Repository interface using GORM.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+// NewGORMRepository creates a new GORM queue repository.
+func NewGORMRepository(db *gorm.DB) Repository {
+	return &GORMRepository{db: db}
+}
+
+// Create inserts a new task.
+func (r *GORMRepository) Create(ctx context.Context, task *Task) error {
+	if err := r.db.WithContext(ctx).Create(task).Error; err != nil {
+		return fmt.Errorf("failed to create queue task: %w", err)
+	}
+	return nil
+}
+
+// ClaimNext claims the oldest due task. SKIP LOCKED lets several consumers poll concurrently
+// without claiming the same task.
+func (r *GORMRepository) ClaimNext(ctx context.Context, types []string, now, lockedUntil time.Time) (*Task, error) {
+	var tasks []Task
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE queue_tasks
+		SET status = ?, attempts = attempts + 1, locked_until = ?, updated_at = ?
+		WHERE id = (
+			SELECT id FROM queue_tasks
+			WHERE type IN ?
+			  AND ((status = ? AND run_at <= ?) OR (status = ? AND locked_until <= ?))
+			ORDER BY run_at ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		TaskRunning, lockedUntil, now,
+		types,
+		TaskPending, now, TaskRunning, now,
+	).Scan(&tasks).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim queue task: %w", err)
+	}
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+	return &tasks[0], nil
+}
+
+// Delete removes a task that completed.
+func (r *GORMRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&Task{}).Error; err != nil {
+		return fmt.Errorf("failed to delete queue task: %w", err)
+	}
+	return nil
+}
+
+// Reschedule returns a failed task to the queue to be retried at runAt.
+func (r *GORMRepository) Reschedule(ctx context.Context, id uuid.UUID, runAt time.Time, lastError string) error {
+	err := r.db.WithContext(ctx).Model(&Task{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       TaskPending,
+		"run_at":       runAt,
+		"locked_until": nil,
+		"last_error":   lastError,
+		"updated_at":   time.Now(),
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to reschedule queue task: %w", err)
+	}
+	return nil
+}
+
+// Bury moves a task to the dead-letter queue.
+func (r *GORMRepository) Bury(ctx context.Context, id uuid.UUID, lastError string) error {
+	err := r.db.WithContext(ctx).Model(&Task{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       TaskDead,
+		"locked_until": nil,
+		"last_error":   lastError,
+		"updated_at":   time.Now(),
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to bury queue task: %w", err)
+	}
+	return nil
+}
+
+// FindDead retrieves the dead-letter queue, most recently failed first.
+func (r *GORMRepository) FindDead(ctx context.Context, page, pageSize int) ([]Task, *common.Pagination, error) {
+	var tasks []Task
+	var totalItems int64
+
+	dbQuery := r.db.WithContext(ctx).Model(&Task{}).Where("status = ?", TaskDead)
+	if err := dbQuery.Count(&totalItems).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to count dead queue tasks: %w", err)
+	}
+
+	offset := (page - 1) * pageSize
+	if err := dbQuery.Order("updated_at DESC").Offset(offset).Limit(pageSize).Find(&tasks).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to list dead queue tasks: %w", err)
+	}
+	return tasks, common.NewPagination(totalItems, page, pageSize), nil
+}
+
+// Requeue moves a dead task back to the queue with a fresh set of attempts.
+func (r *GORMRepository) Requeue(ctx context.Context, id uuid.UUID, runAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&Task{}).Where("id = ? AND status = ?", id, TaskDead).Updates(map[string]interface{}{
+		"status":     TaskPending,
+		"attempts":   0,
+		"run_at":     runAt,
+		"updated_at": time.Now(),
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to requeue task: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		var task Task
+		if err := r.db.WithContext(ctx).Select("id").First(&task, "id = ?", id).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.ErrNotFound.WithDetails("Task not found.")
+		}
+		return common.ErrConflict.WithDetails("Only tasks in the dead-letter queue can be retried.")
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// File: internal/queue/service.go
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Option customises a task passed to Service.Enqueue.
+type Option func(*Task)
+
+// WithDelay makes the task due after d instead of immediately.
+func WithDelay(d time.Duration) Option {
+	return func(t *Task) { t.RunAt = t.RunAt.Add(d) }
+}
+
+// WithRunAt makes the task due at runAt.
+func WithRunAt(runAt time.Time) Option {
+	return func(t *Task) { t.RunAt = runAt }
+}
+
+// WithMaxAttempts overrides QUEUE_MAX_ATTEMPTS for the task.
+func WithMaxAttempts(n int) Option {
+	return func(t *Task) {
+		if n > 0 {
+			t.MaxAttempts = n
+		}
+	}
+}
+
+// Service defines the interface for adding tasks to the queue and managing its dead-letter queue.
+type Service interface {
+	// Enqueue adds a task of taskType; payload is encoded as JSON and handed to the task's handler.
+	Enqueue(ctx context.Context, taskType string, payload interface{}, opts ...Option) error
+
+	// Admin methods
+	ListDead(ctx context.Context, page, pageSize int) ([]Task, *common.Pagination, error)
+	RetryDead(ctx context.Context, id uuid.UUID) error
+}
+
+// ServiceImplementation implements the queue Service interface.
+type ServiceImplementation struct {
+	repo   Repository
+	cfg    *config.Config
+	logger *zap.Logger
+}
+
+// NewService creates a new queue service.
+func NewService(repo Repository, cfg *config.Config, logger *zap.Logger) Service {
+	return &ServiceImplementation{repo: repo, cfg: cfg, logger: logger.Named("Queue")}
+}
+
+// Enqueue implements Service.
+func (s *ServiceImplementation) Enqueue(ctx context.Context, taskType string, payload interface{}, opts ...Option) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s task payload: %w", taskType, err)
+	}
+	now := time.Now().UTC()
+	task := &Task{
+		ID:          uuid.New(),
+		Type:        taskType,
+		Payload:     string(encoded),
+		Status:      TaskPending,
+		MaxAttempts: maxAttempts(s.cfg),
+		RunAt:       now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	for _, opt := range opts {
+		opt(task)
+	}
+	if err := s.repo.Create(ctx, task); err != nil {
+		return err
+	}
+	s.logger.Debug("Task enqueued", zap.String("type", taskType), zap.String("taskID", task.ID.String()), zap.Time("runAt", task.RunAt))
+	return nil
+}
+
+// ListDead returns a page of the dead-letter queue.
+func (s *ServiceImplementation) ListDead(ctx context.Context, page, pageSize int) ([]Task, *common.Pagination, error) {
+	tasks, pagination, err := s.repo.FindDead(ctx, page, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to list dead tasks", zap.Error(err))
+		return nil, nil, common.ErrInternalServer.WithDetails("Could not retrieve dead-letter queue.")
+	}
+	return tasks, pagination, nil
+}
+
+// RetryDead moves a task from the dead-letter queue back to the queue.
+func (s *ServiceImplementation) RetryDead(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.Requeue(ctx, id, time.Now().UTC()); err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return err
+		}
+		s.logger.Error("Failed to retry dead task", zap.Error(err), zap.String("taskID", id.String()))
+		return common.ErrInternalServer.WithDetails("Could not retry task.")
+	}
+	s.logger.Info("Dead task requeued", zap.String("taskID", id.String()))
+	return nil
+}
+
+func maxAttempts(cfg *config.Config) int {
+	if cfg.QueueMaxAttempts > 0 {
+		return cfg.QueueMaxAttempts
+	}
+	return 1
+}
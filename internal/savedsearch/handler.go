@@ -0,0 +1,178 @@
+// File: internal/savedsearch/handler.go
+package savedsearch
+
+import (
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/filestorage"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Handler struct holds dependencies for saved search handlers.
+type Handler struct {
+	service Service
+	logger  *zap.Logger
+	// imageURLs builds the image URLs in search results.
+	imageURLs *filestorage.ImageURLBuilder
+}
+
+// NewHandler creates a new saved search handler.
+func NewHandler(service Service, logger *zap.Logger, cfg *config.Config) *Handler {
+	return &Handler{
+		service:   service,
+		logger:    logger,
+		imageURLs: filestorage.NewImageURLBuilder(cfg),
+	}
+}
+
+// RegisterRoutes sets up the routes for saved search operations.
+// All saved search routes require authentication.
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMW gin.HandlerFunc) {
+	savedSearchGroup := router.Group("/saved-searches")
+	savedSearchGroup.Use(authMW)
+	{
+		savedSearchGroup.POST("", h.createSavedSearch)
+		savedSearchGroup.GET("", h.listSavedSearches)
+		savedSearchGroup.GET("/:id", h.getSavedSearch)
+		savedSearchGroup.PUT("/:id", h.updateSavedSearch)
+		savedSearchGroup.DELETE("/:id", h.deleteSavedSearch)
+		savedSearchGroup.GET("/:id/results", h.getSavedSearchResults)
+	}
+}
+
+func (h *Handler) createSavedSearch(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	var req CreateSavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Create saved search: Invalid request body", zap.Error(err))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	search, err := h.service.CreateSavedSearch(c.Request.Context(), userID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondCreated(c, "Saved search created successfully.", ToSavedSearchResponse(search))
+}
+
+func (h *Handler) listSavedSearches(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	page, pageSize := common.GetPaginationParams(c)
+	searches, pagination, err := h.service.ListSavedSearches(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]SavedSearchResponse, len(searches))
+	for i := range searches {
+		responses[i] = ToSavedSearchResponse(&searches[i])
+	}
+	common.RespondPaginated(c, "Saved searches retrieved successfully.", responses, pagination)
+}
+
+func (h *Handler) getSavedSearch(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	searchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid saved search ID format."))
+		return
+	}
+
+	search, err := h.service.GetSavedSearch(c.Request.Context(), searchID, userID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Saved search retrieved successfully.", ToSavedSearchResponse(search))
+}
+
+func (h *Handler) updateSavedSearch(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	searchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid saved search ID format."))
+		return
+	}
+
+	var req UpdateSavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Update saved search: Invalid request body", zap.Error(err), zap.String("savedSearchID", searchID.String()))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	search, err := h.service.UpdateSavedSearch(c.Request.Context(), searchID, userID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Saved search updated successfully.", ToSavedSearchResponse(search))
+}
+
+func (h *Handler) deleteSavedSearch(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	searchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid saved search ID format."))
+		return
+	}
+
+	if err := h.service.DeleteSavedSearch(c.Request.Context(), searchID, userID); err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondNoContent(c)
+}
+
+func (h *Handler) getSavedSearchResults(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	searchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid saved search ID format."))
+		return
+	}
+
+	page, pageSize := common.GetPaginationParams(c)
+	listings, pagination, err := h.service.RunSavedSearch(c.Request.Context(), searchID, userID, page, pageSize)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	listingResponses := make([]listing.ListingResponse, len(listings))
+	for i := range listings {
+		listingResponses[i] = listing.ToListingResponse(&listings[i], h.imageURLs)
+	}
+	common.RespondPaginated(c, "Saved search results retrieved successfully.", listingResponses, pagination)
+}
@@ -0,0 +1,93 @@
+// File: internal/savedsearch/model.go
+package savedsearch
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/listing"
+
+	"github.com/google/uuid"
+)
+
+// SearchCriteria is a persisted listing.ListingSearchQuery, stored as JSONB.
+type SearchCriteria listing.ListingSearchQuery
+
+// Value implements the driver.Valuer interface for SearchCriteria.
+func (sc SearchCriteria) Value() (driver.Value, error) {
+	b, err := json.Marshal(sc)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface for SearchCriteria.
+func (sc *SearchCriteria) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	case nil:
+		*sc = SearchCriteria{}
+		return nil
+	default:
+		return errors.New("failed to scan SearchCriteria: invalid type")
+	}
+	return json.Unmarshal(b, sc)
+}
+
+// SavedSearch is a named listing search persisted by a user.
+type SavedSearch struct {
+	common.BaseModel
+	UserID        uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Name          string         `gorm:"type:varchar(150);not null"`
+	Criteria      SearchCriteria `gorm:"column:query;type:jsonb;not null"`
+	DigestEnabled bool           `gorm:"not null;default:false"`
+	LastDigestAt  *time.Time
+}
+
+func (SavedSearch) TableName() string {
+	return "saved_searches"
+}
+
+// --- DTOs for API ---
+
+type CreateSavedSearchRequest struct {
+	Name          string                     `json:"name" binding:"required,min=1,max=150"`
+	Query         listing.ListingSearchQuery `json:"query"`
+	DigestEnabled bool                       `json:"digest_enabled"`
+}
+
+type UpdateSavedSearchRequest struct {
+	Name          *string                     `json:"name,omitempty" binding:"omitempty,min=1,max=150"`
+	Query         *listing.ListingSearchQuery `json:"query,omitempty"`
+	DigestEnabled *bool                       `json:"digest_enabled,omitempty"`
+}
+
+type SavedSearchResponse struct {
+	ID            uuid.UUID                  `json:"id"`
+	Name          string                     `json:"name"`
+	Query         listing.ListingSearchQuery `json:"query"`
+	DigestEnabled bool                       `json:"digest_enabled"`
+	LastDigestAt  *time.Time                 `json:"last_digest_at,omitempty"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at"`
+}
+
+func ToSavedSearchResponse(s *SavedSearch) SavedSearchResponse {
+	return SavedSearchResponse{
+		ID:            s.ID,
+		Name:          s.Name,
+		Query:         listing.ListingSearchQuery(s.Criteria),
+		DigestEnabled: s.DigestEnabled,
+		LastDigestAt:  s.LastDigestAt,
+		CreatedAt:     s.CreatedAt,
+		UpdatedAt:     s.UpdatedAt,
+	}
+}
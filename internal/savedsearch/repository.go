@@ -0,0 +1,123 @@
+// File: internal/savedsearch/repository.go
+package savedsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for saved search data operations.
+type Repository interface {
+	Create(ctx context.Context, search *SavedSearch) error
+	FindByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*SavedSearch, error) // userID for ownership check
+	FindByUserID(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]SavedSearch, *common.Pagination, error)
+	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+	Update(ctx context.Context, search *SavedSearch) error
+	Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	FindDigestEnabled(ctx context.Context) ([]SavedSearch, error)
+	UpdateLastDigestAt(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// GORMRepository implements the saved search Repository interface using GORM.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+// NewGORMRepository creates a new GORM saved search repository.
+func NewGORMRepository(db *gorm.DB) Repository {
+	return &GORMRepository{db: db}
+}
+
+// Create inserts a new saved search.
+func (r *GORMRepository) Create(ctx context.Context, search *SavedSearch) error {
+	if err := r.db.WithContext(ctx).Create(search).Error; err != nil {
+		return fmt.Errorf("failed to create saved search: %w", err)
+	}
+	return nil
+}
+
+// FindByID retrieves a saved search by ID, ensuring it belongs to the given user.
+func (r *GORMRepository) FindByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*SavedSearch, error) {
+	var search SavedSearch
+	err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&search).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Saved search not found.")
+		}
+		return nil, fmt.Errorf("failed to find saved search %s: %w", id, err)
+	}
+	return &search, nil
+}
+
+// FindByUserID retrieves a paginated list of a user's saved searches, newest first.
+func (r *GORMRepository) FindByUserID(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]SavedSearch, *common.Pagination, error) {
+	var searches []SavedSearch
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&SavedSearch{}).Where("user_id = ?", userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, nil, fmt.Errorf("counting saved searches for user %s failed: %w", userID, err)
+	}
+
+	pagination := common.NewPagination(total, page, pageSize)
+	err := query.Order("created_at DESC").
+		Limit(pagination.PageSize).
+		Offset((pagination.CurrentPage - 1) * pagination.PageSize).
+		Find(&searches).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetching saved searches for user %s failed: %w", userID, err)
+	}
+	return searches, pagination, nil
+}
+
+// CountByUserID counts the saved searches owned by a user.
+func (r *GORMRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&SavedSearch{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// Update saves changes to an existing saved search.
+func (r *GORMRepository) Update(ctx context.Context, search *SavedSearch) error {
+	if err := r.db.WithContext(ctx).Save(search).Error; err != nil {
+		return fmt.Errorf("failed to update saved search %s: %w", search.ID, err)
+	}
+	return nil
+}
+
+// Delete removes a saved search, ensuring ownership.
+func (r *GORMRepository) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&SavedSearch{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete saved search %s: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound.WithDetails("Saved search not found.")
+	}
+	return nil
+}
+
+// FindDigestEnabled retrieves all saved searches that opted into the daily digest.
+func (r *GORMRepository) FindDigestEnabled(ctx context.Context) ([]SavedSearch, error) {
+	var searches []SavedSearch
+	if err := r.db.WithContext(ctx).Where("digest_enabled = ?", true).Find(&searches).Error; err != nil {
+		return nil, fmt.Errorf("failed to find digest-enabled saved searches: %w", err)
+	}
+	return searches, nil
+}
+
+// UpdateLastDigestAt records when a digest was last generated for a saved search.
+func (r *GORMRepository) UpdateLastDigestAt(ctx context.Context, id uuid.UUID, at time.Time) error {
+	err := r.db.WithContext(ctx).Model(&SavedSearch{}).Where("id = ?", id).Update("last_digest_at", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to update last digest time for saved search %s: %w", id, err)
+	}
+	return nil
+}
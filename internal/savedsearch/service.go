@@ -0,0 +1,215 @@
+// File: internal/savedsearch/service.go
+package savedsearch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/listing"
+	"seattle_info_backend/internal/notification"
+	"seattle_info_backend/internal/platform/geo"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// maxSavedSearchesPerUser caps how many saved searches a single user may keep.
+const maxSavedSearchesPerUser = 25
+
+// Service defines the interface for saved search business logic.
+type Service interface {
+	CreateSavedSearch(ctx context.Context, userID uuid.UUID, req CreateSavedSearchRequest) (*SavedSearch, error)
+	GetSavedSearch(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*SavedSearch, error)
+	ListSavedSearches(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]SavedSearch, *common.Pagination, error)
+	UpdateSavedSearch(ctx context.Context, id uuid.UUID, userID uuid.UUID, req UpdateSavedSearchRequest) (*SavedSearch, error)
+	DeleteSavedSearch(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	RunSavedSearch(ctx context.Context, id uuid.UUID, userID uuid.UUID, page, pageSize int) ([]listing.Listing, *common.Pagination, error)
+	SendDailyDigests(ctx context.Context) (int, error)
+}
+
+// ServiceImplementation implements the saved search Service interface.
+type ServiceImplementation struct {
+	repo                Repository
+	listingService      listing.Service
+	notificationService notification.Service
+	logger              *zap.Logger
+}
+
+// NewService creates a new saved search service.
+func NewService(repo Repository, listingService listing.Service, notificationService notification.Service, logger *zap.Logger) Service {
+	return &ServiceImplementation{
+		repo:                repo,
+		listingService:      listingService,
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
+
+// CreateSavedSearch persists a new named search for the user.
+func (s *ServiceImplementation) CreateSavedSearch(ctx context.Context, userID uuid.UUID, req CreateSavedSearchRequest) (*SavedSearch, error) {
+	if err := validateQuery(req.Query); err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to count saved searches", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not create saved search.")
+	}
+	if count >= maxSavedSearchesPerUser {
+		return nil, common.ErrBadRequest.WithDetails(fmt.Sprintf("You can keep at most %d saved searches.", maxSavedSearchesPerUser))
+	}
+
+	search := &SavedSearch{
+		UserID:        userID,
+		Name:          req.Name,
+		Criteria:      toCriteria(req.Query),
+		DigestEnabled: req.DigestEnabled,
+	}
+	if err := s.repo.Create(ctx, search); err != nil {
+		s.logger.Error("Failed to create saved search", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not create saved search.")
+	}
+	return search, nil
+}
+
+// GetSavedSearch retrieves one of the user's saved searches.
+func (s *ServiceImplementation) GetSavedSearch(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*SavedSearch, error) {
+	search, err := s.repo.FindByID(ctx, id, userID)
+	if err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to get saved search", zap.Error(err), zap.String("savedSearchID", id.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve saved search.")
+	}
+	return search, nil
+}
+
+// ListSavedSearches retrieves the user's saved searches.
+func (s *ServiceImplementation) ListSavedSearches(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]SavedSearch, *common.Pagination, error) {
+	searches, pagination, err := s.repo.FindByUserID(ctx, userID, page, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to list saved searches", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, nil, common.ErrInternalServer.WithDetails("Could not retrieve saved searches.")
+	}
+	return searches, pagination, nil
+}
+
+// UpdateSavedSearch changes the name, query, or digest preference of a saved search.
+func (s *ServiceImplementation) UpdateSavedSearch(ctx context.Context, id uuid.UUID, userID uuid.UUID, req UpdateSavedSearchRequest) (*SavedSearch, error) {
+	search, err := s.GetSavedSearch(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		search.Name = *req.Name
+	}
+	if req.Query != nil {
+		if err := validateQuery(*req.Query); err != nil {
+			return nil, err
+		}
+		search.Criteria = toCriteria(*req.Query)
+	}
+	if req.DigestEnabled != nil {
+		search.DigestEnabled = *req.DigestEnabled
+	}
+
+	if err := s.repo.Update(ctx, search); err != nil {
+		s.logger.Error("Failed to update saved search", zap.Error(err), zap.String("savedSearchID", id.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not update saved search.")
+	}
+	return search, nil
+}
+
+// DeleteSavedSearch removes one of the user's saved searches.
+func (s *ServiceImplementation) DeleteSavedSearch(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	if err := s.repo.Delete(ctx, id, userID); err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return err
+		}
+		s.logger.Error("Failed to delete saved search", zap.Error(err), zap.String("savedSearchID", id.String()))
+		return common.ErrInternalServer.WithDetails("Could not delete saved search.")
+	}
+	return nil
+}
+
+// RunSavedSearch re-executes a saved search through the listing service.
+func (s *ServiceImplementation) RunSavedSearch(ctx context.Context, id uuid.UUID, userID uuid.UUID, page, pageSize int) ([]listing.Listing, *common.Pagination, error) {
+	search, err := s.GetSavedSearch(ctx, id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query := listing.ListingSearchQuery(search.Criteria)
+	query.Page, query.PageSize = page, pageSize
+	return s.listingService.SearchListings(ctx, query, &userID)
+}
+
+// SendDailyDigests notifies owners of digest-enabled saved searches about listings
+// created since their previous digest. It returns the number of notifications sent.
+func (s *ServiceImplementation) SendDailyDigests(ctx context.Context) (int, error) {
+	searches, err := s.repo.FindDigestEnabled(ctx)
+	if err != nil {
+		s.logger.Error("Failed to load digest-enabled saved searches", zap.Error(err))
+		return 0, err
+	}
+
+	sent := 0
+	for _, search := range searches {
+		runAt := time.Now().UTC()
+		since := search.CreatedAt
+		if search.LastDigestAt != nil {
+			since = *search.LastDigestAt
+		}
+
+		query := listing.ListingSearchQuery(search.Criteria)
+		query.CreatedAfter = &since
+		query.Page, query.PageSize = 1, 1 // Only the total count is needed
+
+		_, pagination, err := s.listingService.SearchListings(ctx, query, &search.UserID)
+		if err != nil {
+			s.logger.Error("Failed to run saved search for digest", zap.Error(err), zap.String("savedSearchID", search.ID.String()))
+			continue
+		}
+
+		if pagination != nil && pagination.TotalItems > 0 {
+			message := fmt.Sprintf("%d new listing(s) match your saved search \"%s\".", pagination.TotalItems, search.Name)
+			if _, err := s.notificationService.CreateNotification(ctx, search.UserID, notification.SavedSearchDigest, message, nil); err != nil {
+				s.logger.Error("Failed to send saved search digest notification", zap.Error(err), zap.String("savedSearchID", search.ID.String()))
+				continue
+			}
+			sent++
+		}
+
+		if err := s.repo.UpdateLastDigestAt(ctx, search.ID, runAt); err != nil {
+			s.logger.Error("Failed to record saved search digest time", zap.Error(err), zap.String("savedSearchID", search.ID.String()))
+		}
+	}
+	return sent, nil
+}
+
+// validateQuery rejects saved queries whose geo or price filters could never run.
+func validateQuery(query listing.ListingSearchQuery) error {
+	if query.BBox != "" {
+		if _, err := geo.ParseBoundingBox(query.BBox); err != nil {
+			return common.ErrBadRequest.WithDetails("Invalid bbox: " + err.Error())
+		}
+	}
+	if query.Polygon != "" {
+		if err := geo.ValidateGeoJSONPolygon(query.Polygon); err != nil {
+			return common.ErrBadRequest.WithDetails("Invalid polygon: " + err.Error())
+		}
+	}
+	return listing.ValidatePriceRange(query)
+}
+
+// toCriteria strips per-request pagination, including the created_before cursor, before a query is persisted.
+func toCriteria(query listing.ListingSearchQuery) SearchCriteria {
+	query.PaginationQuery = common.PaginationQuery{}
+	query.CreatedBefore = nil
+	return SearchCriteria(query)
+}
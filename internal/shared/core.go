@@ -20,6 +20,7 @@ type User struct {
 	AuthProvider        string    // New field
 	IsEmailVerified     bool      // New field
 	IsFirstPostApproved bool      // New field
+	PhoneVerified       bool
 	CreatedAt           time.Time // New field
 	UpdatedAt           time.Time // New field
 	LastLoginAt         *time.Time // New field
@@ -43,6 +44,13 @@ type Service interface {
 	GetUserByFirebaseUID(ctx context.Context, firebaseUID string) (*User, error)
 	SearchUsers(ctx context.Context, query UserSearchQuery) ([]*User, *common.Pagination, error) // Now uses shared.UserSearchQuery
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	ListIdentities(ctx context.Context, userID uuid.UUID) ([]*Identity, error)
+	UnlinkIdentity(ctx context.Context, userID, identityID uuid.UUID) error
+	ListDuplicateCandidates(ctx context.Context, page, pageSize int) ([]*DuplicateCandidate, *common.Pagination, error)
+	DismissDuplicateCandidate(ctx context.Context, id uuid.UUID) error
+	MergeUsers(ctx context.Context, adminID uuid.UUID, req MergeUsersRequest) (*MergeResult, error)
+	GetOnboarding(ctx context.Context, userID uuid.UUID) (*OnboardingStatus, error)
+	CompleteOnboardingStep(ctx context.Context, userID uuid.UUID, step OnboardingStep) (*OnboardingStatus, error)
 }
 
 // Obsolete structs and interfaces related to old JWT/OAuth system are removed below.
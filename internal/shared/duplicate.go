@@ -0,0 +1,44 @@
+// File: internal/shared/duplicate.go
+package shared
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DuplicateCandidate is a pair of accounts whose emails name the same mailbox, awaiting admin review.
+type DuplicateCandidate struct {
+	ID             uuid.UUID
+	User           *User // The newer account
+	DuplicateOf    *User // The older account it duplicates
+	CanonicalEmail string
+	CreatedAt      time.Time
+}
+
+// DuplicateCandidateResponse defines the structure for duplicate account candidates sent in API responses.
+type DuplicateCandidateResponse struct {
+	ID             uuid.UUID     `json:"id"`
+	User           *UserResponse `json:"user,omitempty"`
+	DuplicateOf    *UserResponse `json:"duplicate_of,omitempty"`
+	CanonicalEmail string        `json:"canonical_email"`
+	DetectedAt     time.Time     `json:"detected_at"`
+}
+
+// ToDuplicateCandidateResponse converts a shared.DuplicateCandidate to a DuplicateCandidateResponse DTO.
+func ToDuplicateCandidateResponse(candidate *DuplicateCandidate) DuplicateCandidateResponse {
+	resp := DuplicateCandidateResponse{
+		ID:             candidate.ID,
+		CanonicalEmail: candidate.CanonicalEmail,
+		DetectedAt:     candidate.CreatedAt,
+	}
+	if candidate.User != nil {
+		user := ToUserResponse(candidate.User)
+		resp.User = &user
+	}
+	if candidate.DuplicateOf != nil {
+		duplicateOf := ToUserResponse(candidate.DuplicateOf)
+		resp.DuplicateOf = &duplicateOf
+	}
+	return resp
+}
@@ -0,0 +1,38 @@
+// File: internal/shared/identity.go
+package shared
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Identity is a sign-in provider account linked to a user.
+type Identity struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Provider  string  // Firebase sign-in provider, e.g. "google.com", "apple.com", "password"
+	Email     *string // Email reported by the provider when the identity was linked
+	IsPrimary bool    // The identity the user account was created with
+	CreatedAt time.Time
+}
+
+// IdentityResponse defines the structure for linked identities sent in API responses.
+type IdentityResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Provider  string    `json:"provider"`
+	Email     *string   `json:"email,omitempty"`
+	IsPrimary bool      `json:"is_primary"`
+	LinkedAt  time.Time `json:"linked_at"`
+}
+
+// ToIdentityResponse converts a shared.Identity to an IdentityResponse DTO.
+func ToIdentityResponse(identity *Identity) IdentityResponse {
+	return IdentityResponse{
+		ID:        identity.ID,
+		Provider:  identity.Provider,
+		Email:     identity.Email,
+		IsPrimary: identity.IsPrimary,
+		LinkedAt:  identity.CreatedAt,
+	}
+}
@@ -0,0 +1,23 @@
+// File: internal/shared/merge.go
+package shared
+
+import "github.com/google/uuid"
+
+// MergeUsersRequest is the body of POST /admin/users/merge.
+type MergeUsersRequest struct {
+	SourceUserID uuid.UUID `json:"source_user_id" binding:"required"`           // Deactivated after the merge
+	TargetUserID uuid.UUID `json:"target_user_id" binding:"required"`           // Receives the source's data
+	Note         *string   `json:"note,omitempty" binding:"omitempty,max=1000"` // Stored in the audit log
+}
+
+// MergeResult reports what a user merge moved from the source account to the target.
+type MergeResult struct {
+	SourceUserID  uuid.UUID `json:"source_user_id"`
+	TargetUserID  uuid.UUID `json:"target_user_id"`
+	Listings      int64     `json:"listings"`
+	Notifications int64     `json:"notifications"`
+	Conversations int64     `json:"conversations"` // Conversations of the source; ones about a listing the target also asked about are combined
+	Messages      int64     `json:"messages"`      // Messages sent by the source
+	SavedSearches int64     `json:"saved_searches"`
+	Identities    int64     `json:"identities"` // Sign-in identities, so the source's logins reach the target
+}
@@ -0,0 +1,42 @@
+// File: internal/shared/onboarding.go
+package shared
+
+import "time"
+
+// OnboardingStep is a step new users are guided through. Steps can be done in any order.
+type OnboardingStep string
+
+const (
+	OnboardingProfileCompleted   OnboardingStep = "profile_completed"            // The account has a name and an email
+	OnboardingPhoneVerified      OnboardingStep = "phone_verified"               // A phone number was verified by SMS
+	OnboardingFirstListingPosted OnboardingStep = "first_listing_posted"         // A listing was submitted (not only saved as a draft)
+	OnboardingNotificationPrefs  OnboardingStep = "notification_preferences_set" // Reported by the app once the user has chosen their notification settings
+)
+
+// OnboardingSteps lists the steps in the order apps present them.
+var OnboardingSteps = []OnboardingStep{
+	OnboardingProfileCompleted,
+	OnboardingPhoneVerified,
+	OnboardingFirstListingPosted,
+	OnboardingNotificationPrefs,
+}
+
+// ClientReported reports whether the step is completed by the app calling the API rather than automatically.
+func (s OnboardingStep) ClientReported() bool {
+	return s == OnboardingNotificationPrefs
+}
+
+// OnboardingStepStatus is one step of OnboardingStatus.
+type OnboardingStepStatus struct {
+	Step        OnboardingStep `json:"step"`
+	Completed   bool           `json:"completed"`
+	CompletedAt *time.Time     `json:"completed_at,omitempty"`
+}
+
+// OnboardingStatus reports a user's progress through onboarding.
+type OnboardingStatus struct {
+	Completed      bool                   `json:"completed"`
+	CompletedAt    *time.Time             `json:"completed_at,omitempty"`
+	Steps          []OnboardingStepStatus `json:"steps"`
+	RemainingSteps []OnboardingStep       `json:"remaining_steps"`
+}
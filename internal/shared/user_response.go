@@ -18,6 +18,7 @@ type UserResponse struct {
 	IsEmailVerified     bool       `json:"is_email_verified"`
 	Role                string     `json:"role"`
 	IsFirstPostApproved bool       `json:"is_first_post_approved"`
+	PhoneVerified       bool       `json:"phone_verified"`
 	CreatedAt           time.Time  `json:"created_at"`
 	UpdatedAt           time.Time  `json:"updated_at"`
 	LastLoginAt         *time.Time `json:"last_login_at,omitempty"`
@@ -35,6 +36,7 @@ func ToUserResponse(svUser *User) UserResponse {
 		IsEmailVerified:     svUser.IsEmailVerified,
 		Role:                svUser.Role,
 		IsFirstPostApproved: svUser.IsFirstPostApproved,
+		PhoneVerified:       svUser.PhoneVerified,
 		CreatedAt:           svUser.CreatedAt,
 		UpdatedAt:           svUser.UpdatedAt,
 		LastLoginAt:         svUser.LastLoginAt,
@@ -0,0 +1,145 @@
+// File: internal/twofactor/handler.go
+package twofactor
+
+import (
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Handler struct holds dependencies for two-factor authentication handlers.
+type Handler struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewHandler creates a new two-factor authentication handler.
+func NewHandler(service Service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes sets up the routes for managing the caller's second factor.
+// The router group passed here is expected to be /api/v1/auth/2fa, guarded by auth middleware and an admin role
+// check without second factor enforcement, so that admins can enroll and verify.
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("", h.getStatus)
+	router.POST("/enroll", h.enroll)
+	router.POST("/enable", h.enable)
+	router.POST("/verify", h.verify)
+	router.POST("/backup-codes", h.regenerateBackupCodes)
+	router.POST("/disable", h.disable)
+}
+
+func (h *Handler) getStatus(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	status, err := h.service.GetStatus(c.Request.Context(), userID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Two-factor status retrieved successfully.", status)
+}
+
+func (h *Handler) enroll(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	enrollment, err := h.service.Enroll(c.Request.Context(), userID, c.GetString(common.UserEmailKey))
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Two-factor enrollment started. Add the secret to an authenticator app and confirm with a code.", enrollment)
+}
+
+func (h *Handler) enable(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	var req CodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	result, err := h.service.Enable(c.Request.Context(), userID, common.GetAuthTimeFromContext(c), req.Code)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Two-factor authentication enabled. Store the backup codes; they cannot be retrieved again.", result)
+}
+
+func (h *Handler) verify(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	var req CodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	session, err := h.service.Verify(c.Request.Context(), userID, common.GetAuthTimeFromContext(c), req.Code)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Two-factor verification succeeded.", session)
+}
+
+func (h *Handler) regenerateBackupCodes(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	var req CodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	codes, err := h.service.RegenerateBackupCodes(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Backup codes regenerated. The previous codes no longer work.", codes)
+}
+
+func (h *Handler) disable(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	var req CodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	if err := h.service.Disable(c.Request.Context(), userID, req.Code); err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondNoContent(c)
+}
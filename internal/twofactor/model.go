@@ -0,0 +1,103 @@
+// File: internal/twofactor/model.go
+package twofactor
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Credential is the TOTP second factor of a user. It is pending until EnabledAt is set, which happens once the user
+// has proven with a code that their authenticator app holds the secret.
+type Credential struct {
+	UserID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	SecretEncrypted string     `gorm:"type:text;not null"` // Base32 secret, encrypted with TWO_FACTOR_ENCRYPTION_KEY
+	EnabledAt       *time.Time // Nil while the enrollment is not confirmed
+	LastUsedStep    int64      `gorm:"not null;default:0"` // Time step of the last accepted code; codes up to it are replays
+	FailedAttempts  int        `gorm:"not null;default:0"`
+	LockedUntil     *time.Time
+	CreatedAt       time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	UpdatedAt       time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName specifies the table name for GORM.
+func (Credential) TableName() string {
+	return "user_two_factor"
+}
+
+// Enabled reports whether the second factor is in use.
+func (c *Credential) Enabled() bool {
+	return c.EnabledAt != nil
+}
+
+// BackupCode is a single-use code that stands in for a TOTP code when the authenticator app is lost.
+// Only the SHA-256 hash of the code is stored.
+type BackupCode struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null"`
+	CodeHash  string    `gorm:"type:varchar(64);not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName specifies the table name for GORM.
+func (BackupCode) TableName() string {
+	return "user_two_factor_backup_codes"
+}
+
+// Session is opened by verifying the second factor. Its token grants admin access together with the Firebase ID
+// token of the same sign-in, identified by the token's auth_time. Only the SHA-256 hash of the token is stored.
+type Session struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null"`
+	TokenHash string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	AuthTime  time.Time `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName specifies the table name for GORM.
+func (Session) TableName() string {
+	return "two_factor_sessions"
+}
+
+// --- Request DTOs ---
+
+// CodeRequest carries a code from the authenticator app, or a backup code where the endpoint accepts one.
+type CodeRequest struct {
+	Code string `json:"code" binding:"required,max=32"`
+}
+
+// --- Response DTOs ---
+
+// StatusResponse describes the caller's second factor.
+type StatusResponse struct {
+	Enabled              bool       `json:"enabled"`
+	EnabledAt            *time.Time `json:"enabled_at,omitempty"`
+	Required             bool       `json:"required"` // ADMIN_2FA_REQUIRED: admin routes are refused until 2FA is enabled
+	BackupCodesRemaining int64      `json:"backup_codes_remaining"`
+}
+
+// EnrollmentResponse is returned when enrollment starts. Clients render ProvisioningURI as a QR code for the
+// authenticator app, and show Secret for manual entry.
+type EnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// SessionResponse carries the token to send in the X-Two-Factor-Token header of admin requests.
+type SessionResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// EnableResponse is returned once when 2FA is enabled; the backup codes cannot be retrieved later.
+type EnableResponse struct {
+	BackupCodes []string `json:"backup_codes"`
+	SessionResponse
+}
+
+// BackupCodesResponse carries a new set of backup codes, which replace the previous ones.
+type BackupCodesResponse struct {
+	BackupCodes []string `json:"backup_codes"`
+}
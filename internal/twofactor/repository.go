@@ -0,0 +1,210 @@
+// File: internal/twofactor/repository.go
+package twofactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository defines the interface for two-factor authentication data operations.
+type Repository interface {
+	FindCredential(ctx context.Context, userID uuid.UUID) (*Credential, error)
+	// SaveEnrollment stores a pending credential, replacing a pending one of the same user.
+	SaveEnrollment(ctx context.Context, credential *Credential) error
+	// Enable confirms the credential and replaces the user's backup codes, in one transaction.
+	Enable(ctx context.Context, userID uuid.UUID, enabledAt time.Time, codeHashes []string) error
+	// UseStep records step as the last accepted time step. It returns false if a code of that step or a later one
+	// was already accepted.
+	UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+	UpdateAttempts(ctx context.Context, userID uuid.UUID, failedAttempts int, lockedUntil *time.Time) error
+	// UseBackupCode marks the unused backup code with codeHash as used. It returns false if there is none.
+	UseBackupCode(ctx context.Context, userID uuid.UUID, codeHash string, usedAt time.Time) (bool, error)
+	ReplaceBackupCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+	CountUnusedBackupCodes(ctx context.Context, userID uuid.UUID) (int64, error)
+	// Delete removes the user's credential, backup codes and sessions, in one transaction.
+	Delete(ctx context.Context, userID uuid.UUID) error
+	CreateSession(ctx context.Context, session *Session) error
+	FindSessionByTokenHash(ctx context.Context, tokenHash string) (*Session, error)
+	DeleteExpiredSessions(ctx context.Context, now time.Time) (int64, error)
+}
+
+// GORMRepository implements the two-factor Repository interface using GORM.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+// NewGORMRepository creates a new GORM two-factor repository.
+func NewGORMRepository(db *gorm.DB) Repository {
+	return &GORMRepository{db: db}
+}
+
+// FindCredential retrieves the user's credential, pending or enabled.
+func (r *GORMRepository) FindCredential(ctx context.Context, userID uuid.UUID) (*Credential, error) {
+	var credential Credential
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&credential).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Two-factor authentication is not set up.")
+		}
+		return nil, fmt.Errorf("failed to find two-factor credential: %w", err)
+	}
+	return &credential, nil
+}
+
+// SaveEnrollment stores a pending credential, replacing a pending one of the same user. An enabled credential is
+// left alone and ErrConflict returned.
+func (r *GORMRepository) SaveEnrollment(ctx context.Context, credential *Credential) error {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"secret_encrypted": credential.SecretEncrypted,
+			"last_used_step":   0,
+			"failed_attempts":  0,
+			"locked_until":     nil,
+			"created_at":       gorm.Expr("CURRENT_TIMESTAMP"),
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "user_two_factor.enabled_at IS NULL"}}},
+	}).Create(credential)
+	if result.Error != nil {
+		return fmt.Errorf("failed to save two-factor enrollment: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrConflict.WithDetails("Two-factor authentication is already enabled.")
+	}
+	return nil
+}
+
+// Enable confirms the credential and replaces the user's backup codes, in one transaction.
+func (r *GORMRepository) Enable(ctx context.Context, userID uuid.UUID, enabledAt time.Time, codeHashes []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Credential{}).
+			Where("user_id = ? AND enabled_at IS NULL", userID).
+			Updates(map[string]interface{}{"enabled_at": enabledAt, "failed_attempts": 0, "locked_until": nil})
+		if result.Error != nil {
+			return fmt.Errorf("failed to enable two-factor authentication: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return common.ErrConflict.WithDetails("Two-factor authentication is already enabled.")
+		}
+		return replaceBackupCodes(tx, userID, codeHashes)
+	})
+}
+
+// UseStep records step as the last accepted time step. It returns false if a code of that step or a later one was
+// already accepted; the condition in the update makes this hold for concurrent requests too.
+func (r *GORMRepository) UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Credential{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to record two-factor time step: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateAttempts stores the count of failed attempts and the lock they caused.
+func (r *GORMRepository) UpdateAttempts(ctx context.Context, userID uuid.UUID, failedAttempts int, lockedUntil *time.Time) error {
+	err := r.db.WithContext(ctx).Model(&Credential{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{"failed_attempts": failedAttempts, "locked_until": lockedUntil}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update two-factor attempts: %w", err)
+	}
+	return nil
+}
+
+// UseBackupCode marks the unused backup code with codeHash as used. It returns false if there is none.
+func (r *GORMRepository) UseBackupCode(ctx context.Context, userID uuid.UUID, codeHash string, usedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&BackupCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to use backup code: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ReplaceBackupCodes deletes the user's backup codes and stores new ones, in one transaction.
+func (r *GORMRepository) ReplaceBackupCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceBackupCodes(tx, userID, codeHashes)
+	})
+}
+
+func replaceBackupCodes(tx *gorm.DB, userID uuid.UUID, codeHashes []string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&BackupCode{}).Error; err != nil {
+		return fmt.Errorf("failed to delete backup codes: %w", err)
+	}
+	codes := make([]BackupCode, 0, len(codeHashes))
+	for _, hash := range codeHashes {
+		codes = append(codes, BackupCode{ID: uuid.New(), UserID: userID, CodeHash: hash})
+	}
+	if len(codes) > 0 {
+		if err := tx.Create(&codes).Error; err != nil {
+			return fmt.Errorf("failed to create backup codes: %w", err)
+		}
+	}
+	return nil
+}
+
+// CountUnusedBackupCodes returns how many of the user's backup codes are left.
+func (r *GORMRepository) CountUnusedBackupCodes(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&BackupCode{}).Where("user_id = ? AND used_at IS NULL", userID).Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count backup codes: %w", err)
+	}
+	return count, nil
+}
+
+// Delete removes the user's credential, backup codes and sessions, in one transaction.
+func (r *GORMRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&Session{}).Error; err != nil {
+			return fmt.Errorf("failed to delete two-factor sessions: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&BackupCode{}).Error; err != nil {
+			return fmt.Errorf("failed to delete backup codes: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&Credential{}).Error; err != nil {
+			return fmt.Errorf("failed to delete two-factor credential: %w", err)
+		}
+		return nil
+	})
+}
+
+// CreateSession inserts a new session.
+func (r *GORMRepository) CreateSession(ctx context.Context, session *Session) error {
+	if err := r.db.WithContext(ctx).Create(session).Error; err != nil {
+		return fmt.Errorf("failed to create two-factor session: %w", err)
+	}
+	return nil
+}
+
+// FindSessionByTokenHash retrieves a session by the hash of its token, expired or not.
+func (r *GORMRepository) FindSessionByTokenHash(ctx context.Context, tokenHash string) (*Session, error) {
+	var session Session
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Two-factor session not found.")
+		}
+		return nil, fmt.Errorf("failed to find two-factor session: %w", err)
+	}
+	return &session, nil
+}
+
+// DeleteExpiredSessions removes the sessions that expired before now and returns how many.
+func (r *GORMRepository) DeleteExpiredSessions(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&Session{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired two-factor sessions: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,444 @@
+// File: internal/twofactor/service.go
+package twofactor
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/audit"
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/platform/crypto"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// tokenPrefix marks two-factor session tokens, so they are not mistaken for other credentials.
+	tokenPrefix = "2fa_"
+	// defaultSessionTTL is used when TWO_FACTOR_SESSION_TTL_HOURS is not positive.
+	defaultSessionTTL = 12 * time.Hour
+	// backupCodeCount is how many backup codes are issued at a time.
+	backupCodeCount = 10
+	// backupCodeLength is the number of characters of a backup code, shown in two halves.
+	backupCodeLength = 10
+	// backupCodeAlphabet leaves out characters that are easily confused when copied by hand.
+	backupCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+	// maxFailedAttempts incorrect codes in a row lock the second factor for lockDuration.
+	maxFailedAttempts = 5
+	lockDuration      = 15 * time.Minute
+)
+
+// Service defines the interface for two-factor authentication of admin accounts.
+type Service interface {
+	GetStatus(ctx context.Context, userID uuid.UUID) (*StatusResponse, error)
+	Enroll(ctx context.Context, userID uuid.UUID, accountName string) (*EnrollmentResponse, error)
+	Enable(ctx context.Context, userID uuid.UUID, authTime time.Time, code string) (*EnableResponse, error)
+	Verify(ctx context.Context, userID uuid.UUID, authTime time.Time, code string) (*SessionResponse, error)
+	RegenerateBackupCodes(ctx context.Context, userID uuid.UUID, code string) (*BackupCodesResponse, error)
+	Disable(ctx context.Context, userID uuid.UUID, code string) error
+	// Authorize checks that an admin request may proceed: the user has no second factor (and ADMIN_2FA_REQUIRED is
+	// false), or token belongs to a live session opened in the same sign-in.
+	Authorize(ctx context.Context, userID uuid.UUID, authTime time.Time, token string) error
+}
+
+// ServiceImplementation implements the two-factor Service interface.
+type ServiceImplementation struct {
+	repo         Repository
+	auditService audit.Service
+	cfg          *config.Config
+	logger       *zap.Logger
+	now          func() time.Time
+}
+
+// NewService creates a new two-factor authentication service.
+func NewService(repo Repository, auditService audit.Service, cfg *config.Config, logger *zap.Logger) Service {
+	return &ServiceImplementation{
+		repo:         repo,
+		auditService: auditService,
+		cfg:          cfg,
+		logger:       logger,
+		now:          time.Now,
+	}
+}
+
+// GetStatus describes the user's second factor.
+func (s *ServiceImplementation) GetStatus(ctx context.Context, userID uuid.UUID) (*StatusResponse, error) {
+	status := &StatusResponse{Required: s.cfg.AdminTwoFactorRequired}
+	credential, err := s.findCredential(ctx, userID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return status, nil
+		}
+		return nil, err
+	}
+	if !credential.Enabled() {
+		return status, nil
+	}
+	remaining, err := s.repo.CountUnusedBackupCodes(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to count backup codes", zap.String("userID", userID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve two-factor status.")
+	}
+	status.Enabled = true
+	status.EnabledAt = credential.EnabledAt
+	status.BackupCodesRemaining = remaining
+	return status, nil
+}
+
+// Enroll starts enrollment with a new secret, replacing an enrollment that was not confirmed. 2FA is enabled only
+// once Enable receives a code generated from the secret.
+func (s *ServiceImplementation) Enroll(ctx context.Context, userID uuid.UUID, accountName string) (*EnrollmentResponse, error) {
+	if s.cfg.TwoFactorEncryptionKey == "" {
+		return nil, common.ErrServiceUnavailable.WithDetails("Two-factor authentication is not configured on this server.")
+	}
+	secret, err := generateSecret()
+	if err != nil {
+		s.logger.Error("Failed to generate TOTP secret", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not start two-factor enrollment.")
+	}
+	encrypted, err := crypto.Encrypt(s.cfg.TwoFactorEncryptionKey, []byte(secret))
+	if err != nil {
+		s.logger.Error("Failed to encrypt TOTP secret", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not start two-factor enrollment.")
+	}
+	if err := s.repo.SaveEnrollment(ctx, &Credential{UserID: userID, SecretEncrypted: encrypted}); err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to save two-factor enrollment", zap.String("userID", userID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not start two-factor enrollment.")
+	}
+
+	if accountName == "" {
+		accountName = userID.String()
+	}
+	return &EnrollmentResponse{
+		Secret:          secret,
+		ProvisioningURI: provisioningURI(s.cfg.TwoFactorIssuer, accountName, secret),
+	}, nil
+}
+
+// Enable confirms a pending enrollment with a code from the authenticator app. It returns the backup codes and a
+// session for the current sign-in, so the admin does not have to verify again right away.
+func (s *ServiceImplementation) Enable(ctx context.Context, userID uuid.UUID, authTime time.Time, code string) (*EnableResponse, error) {
+	credential, err := s.findCredential(ctx, userID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, common.ErrConflict.WithDetails("Start two-factor enrollment first.")
+		}
+		return nil, err
+	}
+	if credential.Enabled() {
+		return nil, common.ErrConflict.WithDetails("Two-factor authentication is already enabled.")
+	}
+	if _, err := s.checkCode(ctx, credential, code, false); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateBackupCodes()
+	if err != nil {
+		s.logger.Error("Failed to generate backup codes", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not enable two-factor authentication.")
+	}
+	if err := s.repo.Enable(ctx, userID, s.now(), hashes); err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to enable two-factor authentication", zap.String("userID", userID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not enable two-factor authentication.")
+	}
+	s.record(ctx, userID, audit.ActionUserTwoFactorEnable)
+
+	session, err := s.openSession(ctx, userID, authTime)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("Two-factor authentication enabled", zap.String("userID", userID.String()))
+	return &EnableResponse{BackupCodes: codes, SessionResponse: *session}, nil
+}
+
+// Verify checks a code from the authenticator app, or a backup code, and opens a session for the current sign-in.
+func (s *ServiceImplementation) Verify(ctx context.Context, userID uuid.UUID, authTime time.Time, code string) (*SessionResponse, error) {
+	credential, err := s.enabledCredential(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	usedBackupCode, err := s.checkCode(ctx, credential, code, true)
+	if err != nil {
+		return nil, err
+	}
+	if usedBackupCode {
+		s.logger.Info("Backup code used for two-factor verification", zap.String("userID", userID.String()))
+	}
+	return s.openSession(ctx, userID, authTime)
+}
+
+// RegenerateBackupCodes replaces the user's backup codes. A code from the authenticator app is required, so a
+// stolen backup code cannot be turned into a fresh set.
+func (s *ServiceImplementation) RegenerateBackupCodes(ctx context.Context, userID uuid.UUID, code string) (*BackupCodesResponse, error) {
+	credential, err := s.enabledCredential(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.checkCode(ctx, credential, code, false); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateBackupCodes()
+	if err != nil {
+		s.logger.Error("Failed to generate backup codes", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not generate backup codes.")
+	}
+	if err := s.repo.ReplaceBackupCodes(ctx, userID, hashes); err != nil {
+		s.logger.Error("Failed to replace backup codes", zap.String("userID", userID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not generate backup codes.")
+	}
+	s.record(ctx, userID, audit.ActionUserTwoFactorBackupCodes)
+	return &BackupCodesResponse{BackupCodes: codes}, nil
+}
+
+// Disable turns 2FA off after checking a code, ending the user's two-factor sessions. It is refused while
+// ADMIN_2FA_REQUIRED is set.
+func (s *ServiceImplementation) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	if s.cfg.AdminTwoFactorRequired {
+		return common.ErrConflict.WithDetails("Two-factor authentication is required for admin accounts and cannot be disabled.")
+	}
+	credential, err := s.enabledCredential(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.checkCode(ctx, credential, code, true); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, userID); err != nil {
+		s.logger.Error("Failed to disable two-factor authentication", zap.String("userID", userID.String()), zap.Error(err))
+		return common.ErrInternalServer.WithDetails("Could not disable two-factor authentication.")
+	}
+	s.record(ctx, userID, audit.ActionUserTwoFactorDisable)
+	s.logger.Info("Two-factor authentication disabled", zap.String("userID", userID.String()))
+	return nil
+}
+
+// Authorize checks that an admin request may proceed: the user has no second factor (and ADMIN_2FA_REQUIRED is
+// false), or token belongs to a live session opened in the same sign-in.
+func (s *ServiceImplementation) Authorize(ctx context.Context, userID uuid.UUID, authTime time.Time, token string) error {
+	credential, err := s.findCredential(ctx, userID)
+	if err != nil && !errors.Is(err, common.ErrNotFound) {
+		return err
+	}
+	if err != nil || !credential.Enabled() {
+		if s.cfg.AdminTwoFactorRequired {
+			return common.ErrTwoFactorSetupRequired.WithDetails("Set up two-factor authentication to use admin features.")
+		}
+		return nil
+	}
+
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return common.ErrTwoFactorRequired.WithDetails("Verify your second factor and send the token in the X-Two-Factor-Token header.")
+	}
+	session, err := s.repo.FindSessionByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return common.ErrTwoFactorRequired.WithDetails("The two-factor session is invalid. Verify your second factor again.")
+		}
+		s.logger.Error("Failed to look up two-factor session", zap.Error(err))
+		return common.ErrInternalServer.WithDetails("Could not verify two-factor session.")
+	}
+	if session.UserID != userID || session.AuthTime.Unix() != authTime.Unix() {
+		// A session of another sign-in: signing in again requires the second factor again.
+		return common.ErrTwoFactorRequired.WithDetails("The two-factor session belongs to another sign-in. Verify your second factor again.")
+	}
+	if !session.ExpiresAt.After(s.now()) {
+		return common.ErrTwoFactorRequired.WithDetails("The two-factor session has expired. Verify your second factor again.")
+	}
+	return nil
+}
+
+// checkCode checks code against the credential and reports whether a backup code was used. Accepted TOTP codes
+// cannot be replayed, and maxFailedAttempts incorrect codes in a row lock the credential for lockDuration.
+func (s *ServiceImplementation) checkCode(ctx context.Context, credential *Credential, code string, allowBackupCode bool) (bool, error) {
+	now := s.now()
+	if credential.LockedUntil != nil && credential.LockedUntil.After(now) {
+		return false, common.ErrTooManyRequests.WithDetails("Too many incorrect codes. Please try again later.")
+	}
+	secret, err := crypto.Decrypt(s.cfg.TwoFactorEncryptionKey, credential.SecretEncrypted)
+	if err != nil {
+		s.logger.Error("Failed to decrypt TOTP secret", zap.String("userID", credential.UserID.String()), zap.Error(err))
+		return false, common.ErrInternalServer.WithDetails("Could not verify code.")
+	}
+
+	normalized := normalizeCode(code)
+	accepted, usedBackupCode := false, false
+	if isTOTPCode(normalized) {
+		step, matched, err := matchStep(string(secret), normalized, now)
+		if err != nil {
+			s.logger.Error("Failed to compute TOTP code", zap.String("userID", credential.UserID.String()), zap.Error(err))
+			return false, common.ErrInternalServer.WithDetails("Could not verify code.")
+		}
+		if matched {
+			// A code that was already accepted is refused like a wrong one.
+			if accepted, err = s.repo.UseStep(ctx, credential.UserID, step); err != nil {
+				s.logger.Error("Failed to record TOTP step", zap.String("userID", credential.UserID.String()), zap.Error(err))
+				return false, common.ErrInternalServer.WithDetails("Could not verify code.")
+			}
+		}
+	} else if allowBackupCode && len(normalized) == backupCodeLength {
+		if accepted, err = s.repo.UseBackupCode(ctx, credential.UserID, hashToken(normalized), now); err != nil {
+			s.logger.Error("Failed to use backup code", zap.String("userID", credential.UserID.String()), zap.Error(err))
+			return false, common.ErrInternalServer.WithDetails("Could not verify code.")
+		}
+		usedBackupCode = accepted
+	}
+
+	if !accepted {
+		failed, lockedUntil := credential.FailedAttempts+1, (*time.Time)(nil)
+		if failed >= maxFailedAttempts {
+			until := now.Add(lockDuration)
+			failed, lockedUntil = 0, &until
+		}
+		if err := s.repo.UpdateAttempts(ctx, credential.UserID, failed, lockedUntil); err != nil {
+			s.logger.Error("Failed to record two-factor attempt", zap.String("userID", credential.UserID.String()), zap.Error(err))
+			return false, common.ErrInternalServer.WithDetails("Could not verify code.")
+		}
+		if lockedUntil != nil {
+			s.logger.Warn("Two-factor authentication locked after failed attempts", zap.String("userID", credential.UserID.String()))
+			return false, common.ErrTooManyRequests.WithDetails("Too many incorrect codes. Please try again later.")
+		}
+		return false, common.ErrBadRequest.WithDetails("The code is incorrect.")
+	}
+	if credential.FailedAttempts > 0 || credential.LockedUntil != nil {
+		if err := s.repo.UpdateAttempts(ctx, credential.UserID, 0, nil); err != nil {
+			s.logger.Warn("Failed to reset two-factor attempts", zap.String("userID", credential.UserID.String()), zap.Error(err))
+		}
+	}
+	return usedBackupCode, nil
+}
+
+// openSession issues a session for the sign-in identified by authTime. Sessions that have expired are deleted on
+// the way.
+func (s *ServiceImplementation) openSession(ctx context.Context, userID uuid.UUID, authTime time.Time) (*SessionResponse, error) {
+	now := s.now()
+	if deleted, err := s.repo.DeleteExpiredSessions(ctx, now); err != nil {
+		s.logger.Warn("Failed to delete expired two-factor sessions", zap.Error(err))
+	} else if deleted > 0 {
+		s.logger.Debug("Deleted expired two-factor sessions", zap.Int64("count", deleted))
+	}
+
+	random, err := crypto.GenerateSecureRandomString(32)
+	if err != nil {
+		s.logger.Error("Failed to generate two-factor session token", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not open two-factor session.")
+	}
+	token := tokenPrefix + strings.TrimRight(random, "=")
+	session := &Session{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TokenHash: hashToken(token),
+		AuthTime:  authTime,
+		ExpiresAt: now.Add(s.sessionTTL()),
+		CreatedAt: now,
+	}
+	if err := s.repo.CreateSession(ctx, session); err != nil {
+		s.logger.Error("Failed to create two-factor session", zap.String("userID", userID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not open two-factor session.")
+	}
+	return &SessionResponse{Token: token, ExpiresAt: session.ExpiresAt}, nil
+}
+
+func (s *ServiceImplementation) findCredential(ctx context.Context, userID uuid.UUID) (*Credential, error) {
+	credential, err := s.repo.FindCredential(ctx, userID)
+	if err != nil {
+		if _, ok := common.IsAPIError(err); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to find two-factor credential", zap.String("userID", userID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not verify two-factor authentication.")
+	}
+	return credential, nil
+}
+
+func (s *ServiceImplementation) enabledCredential(ctx context.Context, userID uuid.UUID) (*Credential, error) {
+	credential, err := s.findCredential(ctx, userID)
+	if err != nil && !errors.Is(err, common.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil || !credential.Enabled() {
+		return nil, common.ErrConflict.WithDetails("Two-factor authentication is not enabled.")
+	}
+	return credential, nil
+}
+
+// record writes an audit entry for a change to the user's own second factor.
+func (s *ServiceImplementation) record(ctx context.Context, userID uuid.UUID, action string) {
+	if s.auditService == nil {
+		return
+	}
+	// The change is already saved; a failure to record it is logged by the audit service.
+	_ = s.auditService.Record(ctx, audit.Event{
+		ActorID:    &userID,
+		Action:     action,
+		EntityType: audit.EntityUser,
+		EntityID:   userID.String(),
+	})
+}
+
+func (s *ServiceImplementation) sessionTTL() time.Duration {
+	if s.cfg.TwoFactorSessionTTL <= 0 {
+		return defaultSessionTTL
+	}
+	return s.cfg.TwoFactorSessionTTL
+}
+
+// generateBackupCodes returns backupCodeCount new backup codes, formatted for display, and their hashes.
+func generateBackupCodes() ([]string, []string, error) {
+	codes := make([]string, 0, backupCodeCount)
+	hashes := make([]string, 0, backupCodeCount)
+	alphabetSize := big.NewInt(int64(len(backupCodeAlphabet)))
+	for i := 0; i < backupCodeCount; i++ {
+		var b strings.Builder
+		for j := 0; j < backupCodeLength; j++ {
+			n, err := rand.Int(rand.Reader, alphabetSize)
+			if err != nil {
+				return nil, nil, err
+			}
+			b.WriteByte(backupCodeAlphabet[n.Int64()])
+		}
+		code := b.String()
+		codes = append(codes, fmt.Sprintf("%s-%s", code[:backupCodeLength/2], code[backupCodeLength/2:]))
+		hashes = append(hashes, hashToken(code))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeCode removes the separators users type or paste along with codes.
+func normalizeCode(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code)))
+}
+
+// isTOTPCode reports whether code has the shape of an authenticator app code.
+func isTOTPCode(code string) bool {
+	if len(code) != codeDigits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// hashToken returns the hex SHA-256 digest stored in place of a plaintext token or backup code.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,307 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// memoryRepository is an in-memory Repository for service tests.
+type memoryRepository struct {
+	Repository
+	credentials map[uuid.UUID]*Credential
+	backupCodes map[uuid.UUID]map[string]bool // code hash -> used
+	sessions    map[string]*Session
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		credentials: map[uuid.UUID]*Credential{},
+		backupCodes: map[uuid.UUID]map[string]bool{},
+		sessions:    map[string]*Session{},
+	}
+}
+
+func (r *memoryRepository) FindCredential(_ context.Context, userID uuid.UUID) (*Credential, error) {
+	c, ok := r.credentials[userID]
+	if !ok {
+		return nil, common.ErrNotFound.WithDetails("Two-factor authentication is not set up.")
+	}
+	copied := *c
+	return &copied, nil
+}
+
+func (r *memoryRepository) SaveEnrollment(_ context.Context, credential *Credential) error {
+	if c, ok := r.credentials[credential.UserID]; ok && c.Enabled() {
+		return common.ErrConflict.WithDetails("Two-factor authentication is already enabled.")
+	}
+	copied := *credential
+	r.credentials[credential.UserID] = &copied
+	return nil
+}
+
+func (r *memoryRepository) Enable(_ context.Context, userID uuid.UUID, enabledAt time.Time, codeHashes []string) error {
+	c := r.credentials[userID]
+	c.EnabledAt, c.FailedAttempts, c.LockedUntil = &enabledAt, 0, nil
+	return r.ReplaceBackupCodes(context.Background(), userID, codeHashes)
+}
+
+func (r *memoryRepository) UseStep(_ context.Context, userID uuid.UUID, step int64) (bool, error) {
+	c := r.credentials[userID]
+	if c.LastUsedStep >= step {
+		return false, nil
+	}
+	c.LastUsedStep = step
+	return true, nil
+}
+
+func (r *memoryRepository) UpdateAttempts(_ context.Context, userID uuid.UUID, failedAttempts int, lockedUntil *time.Time) error {
+	c := r.credentials[userID]
+	c.FailedAttempts, c.LockedUntil = failedAttempts, lockedUntil
+	return nil
+}
+
+func (r *memoryRepository) UseBackupCode(_ context.Context, userID uuid.UUID, codeHash string, _ time.Time) (bool, error) {
+	used, ok := r.backupCodes[userID][codeHash]
+	if !ok || used {
+		return false, nil
+	}
+	r.backupCodes[userID][codeHash] = true
+	return true, nil
+}
+
+func (r *memoryRepository) ReplaceBackupCodes(_ context.Context, userID uuid.UUID, codeHashes []string) error {
+	r.backupCodes[userID] = map[string]bool{}
+	for _, hash := range codeHashes {
+		r.backupCodes[userID][hash] = false
+	}
+	return nil
+}
+
+func (r *memoryRepository) CountUnusedBackupCodes(_ context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	for _, used := range r.backupCodes[userID] {
+		if !used {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryRepository) Delete(_ context.Context, userID uuid.UUID) error {
+	delete(r.credentials, userID)
+	delete(r.backupCodes, userID)
+	for hash, s := range r.sessions {
+		if s.UserID == userID {
+			delete(r.sessions, hash)
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepository) CreateSession(_ context.Context, session *Session) error {
+	r.sessions[session.TokenHash] = session
+	return nil
+}
+
+func (r *memoryRepository) FindSessionByTokenHash(_ context.Context, tokenHash string) (*Session, error) {
+	s, ok := r.sessions[tokenHash]
+	if !ok {
+		return nil, common.ErrNotFound.WithDetails("Two-factor session not found.")
+	}
+	return s, nil
+}
+
+func (r *memoryRepository) DeleteExpiredSessions(_ context.Context, now time.Time) (int64, error) {
+	var deleted int64
+	for hash, s := range r.sessions {
+		if s.ExpiresAt.Before(now) {
+			delete(r.sessions, hash)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func newTestService(repo Repository, cfg *config.Config, now *time.Time) *ServiceImplementation {
+	if cfg.TwoFactorEncryptionKey == "" {
+		cfg.TwoFactorEncryptionKey = "test-encryption-key"
+	}
+	svc := NewService(repo, nil, cfg, zap.NewNop()).(*ServiceImplementation)
+	svc.now = func() time.Time { return *now }
+	return svc
+}
+
+// enroll enables 2FA for userID and returns the secret and the result of enabling.
+func enroll(t *testing.T, svc *ServiceImplementation, userID uuid.UUID, authTime time.Time) (string, *EnableResponse) {
+	t.Helper()
+	ctx := context.Background()
+	enrollment, err := svc.Enroll(ctx, userID, "admin@example.com")
+	if err != nil {
+		t.Fatalf("Enroll: %v", err)
+	}
+	uri, err := url.Parse(enrollment.ProvisioningURI)
+	if err != nil || uri.Query().Get("secret") != enrollment.Secret {
+		t.Fatalf("provisioning URI %q does not carry the secret", enrollment.ProvisioningURI)
+	}
+	code, err := codeAt(enrollment.Secret, timeStep(svc.now()))
+	if err != nil {
+		t.Fatalf("codeAt: %v", err)
+	}
+	result, err := svc.Enable(ctx, userID, authTime, code)
+	if err != nil {
+		t.Fatalf("Enable: %v", err)
+	}
+	return enrollment.Secret, result
+}
+
+func TestServiceEnableAndAuthorize(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc := newTestService(newMemoryRepository(), &config.Config{}, &now)
+	userID := uuid.New()
+	authTime := now.Add(-time.Minute)
+
+	if err := svc.Authorize(ctx, userID, authTime, ""); err != nil {
+		t.Fatalf("admins without 2FA should be authorized, got %v", err)
+	}
+
+	_, enabled := enroll(t, svc, userID, authTime)
+	if len(enabled.BackupCodes) != backupCodeCount {
+		t.Fatalf("expected %d backup codes, got %d", backupCodeCount, len(enabled.BackupCodes))
+	}
+	if err := svc.Authorize(ctx, userID, authTime, ""); !errors.Is(err, common.ErrTwoFactorRequired) {
+		t.Fatalf("expected ErrTwoFactorRequired without a token, got %v", err)
+	}
+	if err := svc.Authorize(ctx, userID, authTime, enabled.Token); err != nil {
+		t.Fatalf("expected the session from Enable to authorize, got %v", err)
+	}
+	if err := svc.Authorize(ctx, userID, authTime.Add(time.Hour), enabled.Token); !errors.Is(err, common.ErrTwoFactorRequired) {
+		t.Fatalf("a session must not carry over to another sign-in, got %v", err)
+	}
+	if err := svc.Authorize(ctx, uuid.New(), authTime, enabled.Token); err != nil {
+		// The other user has no 2FA, so the token does not matter.
+		t.Fatalf("unexpected error for a user without 2FA: %v", err)
+	}
+
+	now = now.Add(defaultSessionTTL + time.Minute)
+	if err := svc.Authorize(ctx, userID, authTime, enabled.Token); !errors.Is(err, common.ErrTwoFactorRequired) {
+		t.Fatalf("expected an expired session to be refused, got %v", err)
+	}
+}
+
+func TestServiceVerifyRefusesReplayedCode(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc := newTestService(newMemoryRepository(), &config.Config{}, &now)
+	userID := uuid.New()
+	secret, _ := enroll(t, svc, userID, now)
+
+	now = now.Add(stepPeriod)
+	code, _ := codeAt(secret, timeStep(now))
+	session, err := svc.Verify(ctx, userID, now, code)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if err := svc.Authorize(ctx, userID, now, session.Token); err != nil {
+		t.Fatalf("expected the verified session to authorize, got %v", err)
+	}
+	if _, err := svc.Verify(ctx, userID, now, code); !errors.Is(err, common.ErrBadRequest) {
+		t.Fatalf("expected a replayed code to be refused, got %v", err)
+	}
+}
+
+func TestServiceBackupCodes(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc := newTestService(newMemoryRepository(), &config.Config{}, &now)
+	userID := uuid.New()
+	secret, enabled := enroll(t, svc, userID, now)
+
+	backupCode := enabled.BackupCodes[0]
+	if _, err := svc.Verify(ctx, userID, now, " "+backupCode+" "); err != nil {
+		t.Fatalf("Verify with backup code: %v", err)
+	}
+	if _, err := svc.Verify(ctx, userID, now, backupCode); !errors.Is(err, common.ErrBadRequest) {
+		t.Fatalf("expected a used backup code to be refused, got %v", err)
+	}
+	if _, err := svc.RegenerateBackupCodes(ctx, userID, enabled.BackupCodes[1]); !errors.Is(err, common.ErrBadRequest) {
+		t.Fatalf("regenerating backup codes must require an authenticator code, got %v", err)
+	}
+
+	status, err := svc.GetStatus(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetStatus: %v", err)
+	}
+	if !status.Enabled || status.BackupCodesRemaining != backupCodeCount-1 {
+		t.Fatalf("unexpected status %+v", status)
+	}
+
+	now = now.Add(stepPeriod)
+	code, _ := codeAt(secret, timeStep(now))
+	regenerated, err := svc.RegenerateBackupCodes(ctx, userID, code)
+	if err != nil {
+		t.Fatalf("RegenerateBackupCodes: %v", err)
+	}
+	if _, err := svc.Verify(ctx, userID, now, enabled.BackupCodes[2]); !errors.Is(err, common.ErrBadRequest) {
+		t.Fatalf("expected old backup codes to stop working, got %v", err)
+	}
+	if _, err := svc.Verify(ctx, userID, now, regenerated.BackupCodes[0]); err != nil {
+		t.Fatalf("Verify with regenerated backup code: %v", err)
+	}
+}
+
+func TestServiceLocksAfterFailedAttempts(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc := newTestService(newMemoryRepository(), &config.Config{}, &now)
+	userID := uuid.New()
+	secret, _ := enroll(t, svc, userID, now)
+
+	for i := 1; i < maxFailedAttempts; i++ {
+		if _, err := svc.Verify(ctx, userID, now, "000000"); !errors.Is(err, common.ErrBadRequest) {
+			t.Fatalf("attempt %d: expected ErrBadRequest, got %v", i, err)
+		}
+	}
+	if _, err := svc.Verify(ctx, userID, now, "000000"); !errors.Is(err, common.ErrTooManyRequests) {
+		t.Fatalf("expected the last attempt to lock, got %v", err)
+	}
+
+	now = now.Add(stepPeriod)
+	code, _ := codeAt(secret, timeStep(now))
+	if _, err := svc.Verify(ctx, userID, now, code); !errors.Is(err, common.ErrTooManyRequests) {
+		t.Fatalf("expected a correct code to be refused while locked, got %v", err)
+	}
+
+	now = now.Add(lockDuration)
+	code, _ = codeAt(secret, timeStep(now))
+	if _, err := svc.Verify(ctx, userID, now, code); err != nil {
+		t.Fatalf("expected the lock to expire, got %v", err)
+	}
+}
+
+func TestServiceRequiredForAdmins(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc := newTestService(newMemoryRepository(), &config.Config{AdminTwoFactorRequired: true}, &now)
+	userID := uuid.New()
+
+	if err := svc.Authorize(ctx, userID, now, ""); !errors.Is(err, common.ErrTwoFactorSetupRequired) {
+		t.Fatalf("expected ErrTwoFactorSetupRequired, got %v", err)
+	}
+	secret, _ := enroll(t, svc, userID, now)
+
+	now = now.Add(stepPeriod)
+	code, _ := codeAt(secret, timeStep(now))
+	if err := svc.Disable(ctx, userID, code); !errors.Is(err, common.ErrConflict) {
+		t.Fatalf("expected disabling to be refused while 2FA is required, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+// File: internal/twofactor/totp.go
+package twofactor
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP parameters (RFC 6238). They are the defaults of authenticator apps, which ignore anything else.
+const (
+	secretBytes = 20 // 160 bits, as RFC 4226 recommends for HMAC-SHA1
+	codeDigits  = 6
+	stepPeriod  = 30 * time.Second
+	// stepSkew is how many steps before and after the current one are accepted, for clock drift between devices.
+	stepSkew = 1
+)
+
+// secretEncoding is the unpadded base32 that authenticator apps expect.
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// generateSecret returns a new random TOTP secret, base32 encoded.
+func generateSecret() (string, error) {
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return secretEncoding.EncodeToString(b), nil
+}
+
+// timeStep returns the TOTP time step of t.
+func timeStep(t time.Time) int64 {
+	return t.Unix() / int64(stepPeriod/time.Second)
+}
+
+// codeAt returns the code of secret at a time step.
+func codeAt(secret string, step int64) (string, error) {
+	key, err := secretEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("invalid TOTP secret: %w", err)
+	}
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation (RFC 4226, section 5.3)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	modulus := uint32(1)
+	for i := 0; i < codeDigits; i++ {
+		modulus *= 10
+	}
+	return fmt.Sprintf("%0*d", codeDigits, value%modulus), nil
+}
+
+// matchStep returns the time step around now whose code is code, or false when none matches.
+func matchStep(secret, code string, now time.Time) (int64, bool, error) {
+	current := timeStep(now)
+	for step := current - stepSkew; step <= current+stepSkew; step++ {
+		expected, err := codeAt(secret, step)
+		if err != nil {
+			return 0, false, err
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// provisioningURI returns the otpauth:// URI that authenticator apps import, usually from a QR code.
+func provisioningURI(issuer, accountName, secret string) string {
+	label := url.PathEscape(issuer + ":" + accountName)
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(codeDigits))
+	query.Set("period", fmt.Sprint(int(stepPeriod/time.Second)))
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
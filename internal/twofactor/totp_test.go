@@ -0,0 +1,59 @@
+package twofactor
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeAtMatchesRFC6238(t *testing.T) {
+	// The SHA-1 test vectors of RFC 6238, appendix B, truncated to six digits.
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, want := range vectors {
+		got, err := codeAt(secret, timeStep(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "at %d", unix)
+	}
+}
+
+func TestMatchStepAllowsOneStepOfDrift(t *testing.T) {
+	secret, err := generateSecret()
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+	previous, err := codeAt(secret, timeStep(now)-1)
+	require.NoError(t, err)
+	tooOld, err := codeAt(secret, timeStep(now)-2)
+	require.NoError(t, err)
+
+	step, ok, err := matchStep(secret, previous, now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, timeStep(now)-1, step)
+
+	_, ok, err = matchStep(secret, tooOld, now)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := provisioningURI("Seattle Info", "admin@example.com", "JBSWY3DPEHPK3PXP")
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/Seattle%20Info:admin@example.com?"))
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", parsed.Query().Get("secret"))
+	assert.Equal(t, "Seattle Info", parsed.Query().Get("issuer"))
+	assert.Equal(t, "6", parsed.Query().Get("digits"))
+}
@@ -20,6 +20,7 @@ func DBToShared(dbUser *User) *shared.User {
 		AuthProvider:        dbUser.AuthProvider,
 		IsEmailVerified:     dbUser.IsEmailVerified,
 		IsFirstPostApproved: dbUser.IsFirstPostApproved,
+		PhoneVerified:       dbUser.PhoneVerified,
 		CreatedAt:           dbUser.CreatedAt,
 		UpdatedAt:           dbUser.UpdatedAt,
 		LastLoginAt:         dbUser.LastLoginAt,
@@ -0,0 +1,40 @@
+// File: internal/user/duplicate.go
+package user
+
+import (
+	"seattle_info_backend/internal/shared"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DuplicateCandidate records a user who signed up with an email whose canonical form (see CanonicalEmail)
+// matches an older account that could not be linked automatically, e.g. because one of the addresses is
+// unverified. Admins review candidates and merge or dismiss them.
+type DuplicateCandidate struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null"`
+	DuplicateOfID  uuid.UUID  `gorm:"type:uuid;not null"` // The older account
+	CanonicalEmail string     `gorm:"type:varchar(255);not null"`
+	ResolvedAt     *time.Time // Set once merged or dismissed
+	CreatedAt      time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`
+
+	User        *User `gorm:"foreignKey:UserID"`
+	DuplicateOf *User `gorm:"foreignKey:DuplicateOfID"`
+}
+
+// TableName specifies the table name for the DuplicateCandidate model.
+func (DuplicateCandidate) TableName() string {
+	return "user_duplicate_candidates"
+}
+
+// DuplicateCandidateToShared converts a DuplicateCandidate, with its users loaded, to a shared.DuplicateCandidate DTO.
+func DuplicateCandidateToShared(candidate *DuplicateCandidate) *shared.DuplicateCandidate {
+	return &shared.DuplicateCandidate{
+		ID:             candidate.ID,
+		User:           DBToShared(candidate.User),
+		DuplicateOf:    DBToShared(candidate.DuplicateOf),
+		CanonicalEmail: candidate.CanonicalEmail,
+		CreatedAt:      candidate.CreatedAt,
+	}
+}
@@ -0,0 +1,37 @@
+// File: internal/user/email.go
+package user
+
+import "strings"
+
+// gmailDomains are the domains of Gmail mailboxes, which ignore dots and +tags in the local part.
+var gmailDomains = map[string]bool{"gmail.com": true, "googlemail.com": true}
+
+// CanonicalEmail returns the form of an email address under which differently written addresses of the
+// same mailbox compare equal: lower case, and for Gmail without dots or a +tag in the local part and on
+// gmail.com. E.g. "User.Name+ads@googlemail.com" becomes "username@gmail.com". It returns "" for "".
+func CanonicalEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return email
+	}
+	local, domain := email[:at], email[at+1:]
+	if !gmailDomains[domain] {
+		return email
+	}
+	if plus := strings.IndexByte(local, '+'); plus >= 0 {
+		local = local[:plus]
+	}
+	return strings.ReplaceAll(local, ".", "") + "@gmail.com"
+}
+
+// normalizeEmails lower-cases the user's email and sets its canonical form.
+func normalizeEmails(user *User) {
+	if user.Email == nil {
+		user.CanonicalEmail = nil
+		return
+	}
+	*user.Email = strings.ToLower(strings.TrimSpace(*user.Email))
+	canonical := CanonicalEmail(*user.Email)
+	user.CanonicalEmail = &canonical
+}
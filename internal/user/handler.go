@@ -49,6 +49,10 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMW gin.HandlerFunc
 	{
 		authenticatedUserGroup.GET("", h.getMe)    // Responds to GET /users/me
 		authenticatedUserGroup.DELETE("", h.deleteMe) // Responds to DELETE /users/me
+		authenticatedUserGroup.GET("/identities", h.listMyIdentities)
+		authenticatedUserGroup.DELETE("/identities/:identity_id", h.unlinkMyIdentity)
+		authenticatedUserGroup.GET("/onboarding", h.getMyOnboarding)
+		authenticatedUserGroup.POST("/onboarding/:step/complete", h.completeMyOnboardingStep)
 	}
 
 	// Admin-only route for searching/listing users
@@ -56,6 +60,13 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMW gin.HandlerFunc
 	userGroup.GET("", authMW, adminRoleMW, h.searchUsers)
 }
 
+// RegisterAdminRoutes sets up the user routes under /api/v1/admin. The router must already require an admin.
+func (h *Handler) RegisterAdminRoutes(router *gin.RouterGroup) {
+	router.GET("/users/duplicates", h.adminListDuplicates)
+	router.POST("/users/duplicates/:id/dismiss", h.adminDismissDuplicate)
+	router.POST("/users/merge", h.adminMergeUsers)
+}
+
 func (h *Handler) getMe(c *gin.Context) {
 	// This /me handler in user.Handler might be redundant if /auth/me already serves user profiles.
 	// However, if it's intended for user-specific profile management (e.g., PUT /users/me), it's fine.
@@ -71,7 +82,7 @@ func (h *Handler) getMe(c *gin.Context) {
 		common.RespondWithError(c, err)
 		return
 	}
-	common.RespondOK(c, "User profile retrieved successfully.", shared.ToUserResponse(usr))
+	common.RespondOK(c, "User profile retrieved successfully.", common.SparseFields(c, shared.ToUserResponse(usr)))
 }
 
 func (h *Handler) getUserByID(c *gin.Context) {
@@ -98,7 +109,7 @@ func (h *Handler) getUserByID(c *gin.Context) {
 		common.RespondWithError(c, err) // Handles common.ErrNotFound appropriately
 		return
 	}
-	common.RespondOK(c, "User retrieved successfully.", shared.ToUserResponse(usr))
+	common.RespondOK(c, "User retrieved successfully.", common.SparseFields(c, shared.ToUserResponse(usr)))
 }
 
 func (h *Handler) deleteMe(c *gin.Context) {
@@ -151,6 +162,74 @@ func (h *Handler) deleteMe(c *gin.Context) {
 	common.RespondNoContent(c)
 }
 
+// listMyIdentities returns the sign-in providers linked to the authenticated user's account.
+func (h *Handler) listMyIdentities(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	identities, err := h.service.ListIdentities(c.Request.Context(), userID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]shared.IdentityResponse, 0, len(identities))
+	for _, identity := range identities {
+		responses = append(responses, shared.ToIdentityResponse(identity))
+	}
+	common.RespondOK(c, "Linked accounts retrieved successfully.", responses)
+}
+
+// unlinkMyIdentity removes a sign-in provider from the authenticated user's account.
+func (h *Handler) unlinkMyIdentity(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	identityID, err := uuid.Parse(c.Param("identity_id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid identity ID format."))
+		return
+	}
+	if err := h.service.UnlinkIdentity(c.Request.Context(), userID, identityID); err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondNoContent(c)
+}
+
+// getMyOnboarding reports which onboarding steps the authenticated user has completed and which remain.
+func (h *Handler) getMyOnboarding(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	status, err := h.service.GetOnboarding(c.Request.Context(), userID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Onboarding progress retrieved successfully.", status)
+}
+
+// completeMyOnboardingStep records an onboarding step the app reports as done.
+func (h *Handler) completeMyOnboardingStep(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+	status, err := h.service.CompleteOnboardingStep(c.Request.Context(), userID, shared.OnboardingStep(c.Param("step")))
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Onboarding step completed.", status)
+}
+
 // searchUsers handles GET requests to search for users based on query parameters.
 // It supports pagination and filtering by email, name, and role.
 func (h *Handler) searchUsers(c *gin.Context) {
@@ -159,7 +238,7 @@ func (h *Handler) searchUsers(c *gin.Context) {
 	// Bind query parameters (e.g., email, name, role)
 	if err := c.ShouldBindQuery(&query); err != nil {
 		h.logger.Warn("Failed to bind query parameters for user search", zap.Error(err))
-		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid search query parameters: "+err.Error()))
+		common.RespondWithError(c, common.BindingError(err))
 		return
 	}
 
@@ -187,5 +266,49 @@ func (h *Handler) searchUsers(c *gin.Context) {
 	}
 
 	h.logger.Info("Handler: User search successful", zap.Int("count", len(userResponses)), zap.Any("pagination", pagination))
-	common.RespondPaginated(c, "Users retrieved successfully.", userResponses, pagination)
-}
\ No newline at end of file
+	common.RespondPaginated(c, "Users retrieved successfully.", common.SparseFields(c, userResponses), pagination)
+}
+
+// adminListDuplicates lists accounts flagged on sign-in as possible duplicates of older accounts.
+func (h *Handler) adminListDuplicates(c *gin.Context) {
+	page, pageSize := common.GetPaginationParams(c)
+	candidates, pagination, err := h.service.ListDuplicateCandidates(c.Request.Context(), page, pageSize)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]shared.DuplicateCandidateResponse, 0, len(candidates))
+	for _, candidate := range candidates {
+		responses = append(responses, shared.ToDuplicateCandidateResponse(candidate))
+	}
+	common.RespondPaginated(c, "Duplicate accounts retrieved successfully.", responses, pagination)
+}
+
+// adminDismissDuplicate marks a flagged pair of accounts as separate people.
+func (h *Handler) adminDismissDuplicate(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid duplicate ID format."))
+		return
+	}
+	if err := h.service.DismissDuplicateCandidate(c.Request.Context(), id); err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondNoContent(c)
+}
+
+// adminMergeUsers moves one account's data to another and deactivates it.
+func (h *Handler) adminMergeUsers(c *gin.Context) {
+	var req shared.MergeUsersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	result, err := h.service.MergeUsers(c.Request.Context(), common.GetUserIDFromContext(c), req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Accounts merged successfully.", result)
+}
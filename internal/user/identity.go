@@ -0,0 +1,38 @@
+// File: internal/user/identity.go
+package user
+
+import (
+	"seattle_info_backend/internal/shared"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Identity links a Firebase account, signed in through one provider, to a local user.
+// A user signing in with Google and later with Apple under the same verified email ends up
+// with two identities and a single user record.
+type Identity struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	Provider    string    `gorm:"type:varchar(50);not null"`
+	FirebaseUID string    `gorm:"type:varchar(255);not null;uniqueIndex"`
+	Email       *string   `gorm:"type:varchar(255)"`
+	CreatedAt   time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName specifies the table name for the Identity model.
+func (Identity) TableName() string {
+	return "user_identities"
+}
+
+// IdentityToShared converts an Identity to a shared.Identity DTO. primaryUID is the user's own FirebaseUID.
+func IdentityToShared(identity *Identity, primaryUID *string) *shared.Identity {
+	return &shared.Identity{
+		ID:        identity.ID,
+		UserID:    identity.UserID,
+		Provider:  identity.Provider,
+		Email:     identity.Email,
+		IsPrimary: primaryUID != nil && *primaryUID == identity.FirebaseUID,
+		CreatedAt: identity.CreatedAt,
+	}
+}
@@ -12,6 +12,7 @@ import (
 type User struct {
 	common.BaseModel            // Embeds ID, CreatedAt, UpdatedAt
 	Email               *string `gorm:"type:varchar(255);uniqueIndex"` // Pointer to allow NULL
+	CanonicalEmail      *string `gorm:"type:varchar(255);index"`       // Email with case, and for Gmail dots and +tags, removed; see CanonicalEmail
 	PasswordHash        *string `gorm:"type:varchar(255)"`             // Deprecated: Passwords will be managed by Firebase
 	FirstName           *string `gorm:"type:varchar(100)"`
 	LastName            *string `gorm:"type:varchar(100)"`
@@ -22,7 +23,18 @@ type User struct {
 	IsEmailVerified     bool    `gorm:"not null;default:false"`
 	Role                string  `gorm:"type:varchar(50);not null;default:'user'"` // e.g., "user", "admin"
 	IsFirstPostApproved bool    `gorm:"not null;default:false"`
+	PhoneNumber         *string `gorm:"type:varchar(20)"` // E.164, set once verified by SMS
+	PhoneVerified       bool    `gorm:"not null;default:false"`
 	LastLoginAt         *time.Time
+	DeactivatedAt       *time.Time // Set when the account was merged into another
+	MergedIntoID        *uuid.UUID `gorm:"type:uuid"`
+
+	// Onboarding: when each step was first completed. Written only by Repository.CompleteOnboardingStep.
+	ProfileCompletedAt     *time.Time
+	PhoneVerifiedAt        *time.Time
+	FirstListingPostedAt   *time.Time
+	NotificationPrefsSetAt *time.Time
+	OnboardingCompletedAt  *time.Time // Set once every step is completed
 	// Listings            []listing.Listing `gorm:"foreignKey:UserID"` // This will cause import cycle if listing imports user
 }
 
@@ -0,0 +1,71 @@
+// File: internal/user/onboarding.go
+package user
+
+import (
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/shared"
+)
+
+// onboardingColumn returns the users column recording when step was completed, or "" for an unknown step.
+func onboardingColumn(step shared.OnboardingStep) string {
+	switch step {
+	case shared.OnboardingProfileCompleted:
+		return "profile_completed_at"
+	case shared.OnboardingPhoneVerified:
+		return "phone_verified_at"
+	case shared.OnboardingFirstListingPosted:
+		return "first_listing_posted_at"
+	case shared.OnboardingNotificationPrefs:
+		return "notification_prefs_set_at"
+	}
+	return ""
+}
+
+// onboardingColumns returns every onboarding column, including onboarding_completed_at.
+func onboardingColumns() []string {
+	columns := []string{"onboarding_completed_at"}
+	for _, step := range shared.OnboardingSteps {
+		columns = append(columns, onboardingColumn(step))
+	}
+	return columns
+}
+
+// onboardingCompletedAt returns when u completed step, or nil.
+func (u *User) onboardingCompletedAt(step shared.OnboardingStep) *time.Time {
+	switch step {
+	case shared.OnboardingProfileCompleted:
+		return u.ProfileCompletedAt
+	case shared.OnboardingPhoneVerified:
+		return u.PhoneVerifiedAt
+	case shared.OnboardingFirstListingPosted:
+		return u.FirstListingPostedAt
+	case shared.OnboardingNotificationPrefs:
+		return u.NotificationPrefsSetAt
+	}
+	return nil
+}
+
+// hasCompleteProfile reports whether the profile step is done: the account has a name and an email.
+func (u *User) hasCompleteProfile() bool {
+	return u.FirstName != nil && strings.TrimSpace(*u.FirstName) != "" && u.Email != nil && *u.Email != ""
+}
+
+// OnboardingStatusOf builds the onboarding progress of u.
+func OnboardingStatusOf(u *User) *shared.OnboardingStatus {
+	status := &shared.OnboardingStatus{
+		Completed:      u.OnboardingCompletedAt != nil,
+		CompletedAt:    u.OnboardingCompletedAt,
+		Steps:          make([]shared.OnboardingStepStatus, 0, len(shared.OnboardingSteps)),
+		RemainingSteps: []shared.OnboardingStep{},
+	}
+	for _, step := range shared.OnboardingSteps {
+		completedAt := u.onboardingCompletedAt(step)
+		status.Steps = append(status.Steps, shared.OnboardingStepStatus{Step: step, Completed: completedAt != nil, CompletedAt: completedAt})
+		if completedAt == nil {
+			status.RemainingSteps = append(status.RemainingSteps, step)
+		}
+	}
+	return status
+}
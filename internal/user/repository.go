@@ -3,27 +3,50 @@ package user
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"strings"
 
+	"seattle_info_backend/internal/audit"
 	"seattle_info_backend/internal/common"
 	"seattle_info_backend/internal/shared" // Added for shared.UserSearchQuery
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Repository defines the interface for user data operations.
 type Repository interface {
 	Create(ctx context.Context, user *User) error
 	FindByEmail(ctx context.Context, email string) (*User, error)
+	FindByCanonicalEmail(ctx context.Context, canonicalEmail string) ([]User, error)
 	FindByID(ctx context.Context, id uuid.UUID) (*User, error)
 	Update(ctx context.Context, user *User) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	FindByProvider(ctx context.Context, authProvider string, providerID string) (*User, error)
 	FindByFirebaseUID(ctx context.Context, firebaseUID string) (*User, error)
 	SearchUsers(ctx context.Context, query shared.UserSearchQuery) ([]User, *common.Pagination, error)
+
+	// Linked sign-in identities
+	CreateIdentity(ctx context.Context, identity *Identity) error
+	FindIdentityByFirebaseUID(ctx context.Context, firebaseUID string) (*Identity, error)
+	FindIdentitiesByUserID(ctx context.Context, userID uuid.UUID) ([]Identity, error)
+	DeleteIdentity(ctx context.Context, id uuid.UUID) error
+
+	// Accounts that may belong to the same person
+	CreateDuplicateCandidate(ctx context.Context, candidate *DuplicateCandidate) error
+	FindOpenDuplicateCandidates(ctx context.Context, page, pageSize int) ([]DuplicateCandidate, int64, error)
+	ResolveDuplicateCandidate(ctx context.Context, id uuid.UUID) error
+
+	// CompleteOnboardingStep records that the user completed step, if they had not already. Once every step is
+	// completed, the user's onboarding is marked completed too.
+	CompleteOnboardingStep(ctx context.Context, userID uuid.UUID, step shared.OnboardingStep) error
+
+	// MergeUsers moves the source user's data to the target and deactivates the source in one transaction,
+	// writing auditEntry with it.
+	MergeUsers(ctx context.Context, sourceID, targetID uuid.UUID, auditEntry *audit.Entry) (*shared.MergeResult, error)
 }
 
 // GORMRepository implements the Repository interface using GORM.
@@ -39,9 +62,7 @@ func NewGORMRepository(db *gorm.DB) Repository {
 
 // Create inserts a new user record into the database.
 func (r *GORMRepository) Create(ctx context.Context, user *User) error {
-	if user.Email != nil {
-		*user.Email = strings.ToLower(strings.TrimSpace(*user.Email))
-	}
+	normalizeEmails(user)
 	err := r.db.WithContext(ctx).Create(user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrDuplicatedKey) ||
@@ -74,6 +95,20 @@ func (r *GORMRepository) FindByEmail(ctx context.Context, email string) (*User,
 	return &userModel, nil
 }
 
+// FindByCanonicalEmail retrieves the users whose email has the given canonical form (see CanonicalEmail),
+// oldest first.
+func (r *GORMRepository) FindByCanonicalEmail(ctx context.Context, canonicalEmail string) ([]User, error) {
+	var users []User
+	err := r.db.WithContext(ctx).
+		Where("canonical_email = ?", canonicalEmail).
+		Order("created_at ASC, id ASC").
+		Find(&users).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users by canonical email: %w", err)
+	}
+	return users, nil
+}
+
 // SearchUsers retrieves users based on search criteria and returns paginated results.
 func (r *GORMRepository) SearchUsers(ctx context.Context, query shared.UserSearchQuery) ([]User, *common.Pagination, error) {
 	var users []User
@@ -162,10 +197,9 @@ func (r *GORMRepository) FindByID(ctx context.Context, id uuid.UUID) (*User, err
 
 // Update modifies an existing user record in the database.
 func (r *GORMRepository) Update(ctx context.Context, user *User) error {
-	if user.Email != nil {
-		*user.Email = strings.ToLower(strings.TrimSpace(*user.Email))
-	}
-	err := r.db.WithContext(ctx).Save(user).Error
+	normalizeEmails(user)
+	// Onboarding timestamps are left alone: a user loaded before a step was completed must not clear it.
+	err := r.db.WithContext(ctx).Omit(onboardingColumns()...).Save(user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrDuplicatedKey) || strings.Contains(err.Error(), "unique constraint") {
 			if user.Email != nil && strings.Contains(err.Error(), "users_email_key") {
@@ -214,3 +248,197 @@ func (r *GORMRepository) FindByProvider(ctx context.Context, authProvider string
 	}
 	return &userModel, nil
 }
+
+// CreateIdentity links a sign-in identity to a user.
+func (r *GORMRepository) CreateIdentity(ctx context.Context, identity *Identity) error {
+	err := r.db.WithContext(ctx).Create(identity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) || strings.Contains(err.Error(), "unique constraint") {
+			return common.ErrConflict.WithDetails("This sign-in account is already linked to a user.")
+		}
+		return fmt.Errorf("failed to create user identity: %w", err)
+	}
+	return nil
+}
+
+// FindIdentityByFirebaseUID retrieves the identity for a Firebase account.
+func (r *GORMRepository) FindIdentityByFirebaseUID(ctx context.Context, firebaseUID string) (*Identity, error) {
+	var identity Identity
+	if err := r.db.WithContext(ctx).Where("firebase_uid = ?", firebaseUID).First(&identity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("No user is linked to this sign-in account.")
+		}
+		return nil, fmt.Errorf("failed to find user identity: %w", err)
+	}
+	return &identity, nil
+}
+
+// FindIdentitiesByUserID retrieves all identities linked to a user, oldest first.
+func (r *GORMRepository) FindIdentitiesByUserID(ctx context.Context, userID uuid.UUID) ([]Identity, error) {
+	var identities []Identity
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at ASC").Find(&identities).Error; err != nil {
+		return nil, fmt.Errorf("failed to list user identities: %w", err)
+	}
+	return identities, nil
+}
+
+// DeleteIdentity removes a linked identity.
+func (r *GORMRepository) DeleteIdentity(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&Identity{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete user identity: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound.WithDetails("Identity not found.")
+	}
+	return nil
+}
+
+// CreateDuplicateCandidate records that two users may be the same person. A pair that is already recorded,
+// resolved or not, is left as it is.
+func (r *GORMRepository) CreateDuplicateCandidate(ctx context.Context, candidate *DuplicateCandidate) error {
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "user_id"}, {Name: "duplicate_of_id"}}, DoNothing: true}).
+		Create(candidate).Error
+	if err != nil {
+		return fmt.Errorf("failed to create duplicate candidate: %w", err)
+	}
+	return nil
+}
+
+// FindOpenDuplicateCandidates retrieves a page of unresolved duplicate candidates, newest first, with both
+// users loaded, and the total number of unresolved candidates.
+func (r *GORMRepository) FindOpenDuplicateCandidates(ctx context.Context, page, pageSize int) ([]DuplicateCandidate, int64, error) {
+	var candidates []DuplicateCandidate
+	var total int64
+	db := r.db.WithContext(ctx).Model(&DuplicateCandidate{}).Where("resolved_at IS NULL")
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count duplicate candidates: %w", err)
+	}
+	err := db.Preload("User").Preload("DuplicateOf").
+		Order("created_at DESC").
+		Offset((page - 1) * pageSize).Limit(pageSize).
+		Find(&candidates).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list duplicate candidates: %w", err)
+	}
+	return candidates, total, nil
+}
+
+// ResolveDuplicateCandidate marks an unresolved duplicate candidate as handled.
+func (r *GORMRepository) ResolveDuplicateCandidate(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Model(&DuplicateCandidate{}).
+		Where("id = ? AND resolved_at IS NULL", id).
+		Update("resolved_at", gorm.Expr("CURRENT_TIMESTAMP"))
+	if result.Error != nil {
+		return fmt.Errorf("failed to resolve duplicate candidate: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound.WithDetails("Open duplicate candidate not found.")
+	}
+	return nil
+}
+
+// CompleteOnboardingStep implements Repository. Completing a step twice keeps the first time.
+func (r *GORMRepository) CompleteOnboardingStep(ctx context.Context, userID uuid.UUID, step shared.OnboardingStep) error {
+	column := onboardingColumn(step)
+	if column == "" {
+		return fmt.Errorf("unknown onboarding step %q", step)
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&User{}).
+			Where("id = ? AND "+column+" IS NULL", userID).
+			UpdateColumn(column, gorm.Expr("CURRENT_TIMESTAMP")).Error
+		if err != nil {
+			return fmt.Errorf("failed to complete onboarding step %s: %w", step, err)
+		}
+		pending := tx.Model(&User{}).Where("id = ? AND onboarding_completed_at IS NULL", userID)
+		for _, s := range shared.OnboardingSteps {
+			pending = pending.Where(onboardingColumn(s) + " IS NOT NULL")
+		}
+		if err := pending.UpdateColumn("onboarding_completed_at", gorm.Expr("CURRENT_TIMESTAMP")).Error; err != nil {
+			return fmt.Errorf("failed to complete onboarding: %w", err)
+		}
+		return nil
+	})
+}
+
+// MergeUsers moves the source user's listings, notifications, saved searches, conversations, messages, blocks
+// and sign-in identities to the target, resolves the duplicate candidates involving the source, and deactivates
+// the source. A conversation of the source about a listing the target also asked about is folded into the
+// target's. It returns common.ErrConflict if the source was deactivated meanwhile.
+func (r *GORMRepository) MergeUsers(ctx context.Context, sourceID, targetID uuid.UUID, auditEntry *audit.Entry) (*shared.MergeResult, error) {
+	result := &shared.MergeResult{SourceUserID: sourceID, TargetUserID: targetID}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		args := []interface{}{sql.Named("source", sourceID), sql.Named("target", targetID)}
+		exec := func(query string, count *int64) error {
+			res := tx.Exec(query, args...)
+			if res.Error != nil {
+				return res.Error
+			}
+			if count != nil {
+				*count += res.RowsAffected
+			}
+			return nil
+		}
+
+		steps := []struct {
+			query string
+			count *int64
+		}{
+			{`UPDATE listings SET user_id = @target WHERE user_id = @source`, &result.Listings},
+			{`UPDATE notifications SET user_id = @target WHERE user_id = @source`, &result.Notifications},
+			{`UPDATE saved_searches SET user_id = @target WHERE user_id = @source`, &result.SavedSearches},
+
+			// Conversations are unique per listing and buyer: fold the source's into the target's first.
+			{`UPDATE conversations t SET last_message_at = GREATEST(t.last_message_at, s.last_message_at)
+				FROM conversations s
+				WHERE s.buyer_id = @source AND t.buyer_id = @target AND t.listing_id = s.listing_id`, nil},
+			{`UPDATE messages m SET conversation_id = t.id
+				FROM conversations s, conversations t
+				WHERE m.conversation_id = s.id AND s.buyer_id = @source AND t.buyer_id = @target AND t.listing_id = s.listing_id`, nil},
+			{`DELETE FROM conversations s USING conversations t
+				WHERE s.buyer_id = @source AND t.buyer_id = @target AND t.listing_id = s.listing_id`, &result.Conversations},
+			{`UPDATE conversations SET buyer_id = @target WHERE buyer_id = @source`, &result.Conversations},
+			{`UPDATE conversations SET seller_id = @target WHERE seller_id = @source`, &result.Conversations},
+			{`UPDATE messages SET sender_id = @target WHERE sender_id = @source`, &result.Messages},
+
+			{`INSERT INTO user_blocks (blocker_id, blocked_id, created_at)
+				SELECT @target, blocked_id, created_at FROM user_blocks WHERE blocker_id = @source AND blocked_id <> @target
+				ON CONFLICT DO NOTHING`, nil},
+			{`INSERT INTO user_blocks (blocker_id, blocked_id, created_at)
+				SELECT blocker_id, @target, created_at FROM user_blocks WHERE blocked_id = @source AND blocker_id <> @target
+				ON CONFLICT DO NOTHING`, nil},
+			{`DELETE FROM user_blocks WHERE blocker_id = @source OR blocked_id = @source`, nil},
+
+			{`UPDATE user_identities SET user_id = @target WHERE user_id = @source`, &result.Identities},
+			{`UPDATE user_duplicate_candidates SET resolved_at = CURRENT_TIMESTAMP
+				WHERE resolved_at IS NULL AND (user_id = @source OR duplicate_of_id = @source)`, nil},
+		}
+		for _, step := range steps {
+			if err := exec(step.query, step.count); err != nil {
+				return fmt.Errorf("failed to merge users: %w", err)
+			}
+		}
+
+		// The source's Firebase account now signs in to the target through the identity moved above.
+		res := tx.Exec(`UPDATE users
+			SET firebase_uid = NULL, deactivated_at = CURRENT_TIMESTAMP, merged_into_id = @target, updated_at = CURRENT_TIMESTAMP
+			WHERE id = @source AND deactivated_at IS NULL`, args...)
+		if res.Error != nil {
+			return fmt.Errorf("failed to deactivate merged user: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return common.ErrConflict.WithDetails("The source account has already been merged or deactivated.")
+		}
+
+		if err := tx.Create(auditEntry).Error; err != nil {
+			return fmt.Errorf("failed to record user merge: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
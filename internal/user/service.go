@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
+	"seattle_info_backend/internal/audit"
 	"seattle_info_backend/internal/common"
 	"seattle_info_backend/internal/config"
 	"seattle_info_backend/internal/shared"
@@ -72,12 +73,30 @@ func (s *ServiceImplementation) GetOrCreateUserFromFirebaseClaims(ctx context.Co
 	dbUser, err := s.repo.FindByFirebaseUID(ctx, firebaseToken.UID)
 	wasCreated := false
 
+	if errors.Is(err, common.ErrNotFound) {
+		// The Firebase account may be a further sign-in method of an existing user.
+		linkedUser, linkErr := s.findLinkedUser(ctx, firebaseToken)
+		if linkErr != nil {
+			return nil, false, linkErr
+		}
+		if linkedUser != nil {
+			dbUser, err = linkedUser, nil
+		}
+	}
+
+	if err == nil && dbUser.DeactivatedAt != nil {
+		s.logger.Warn("Sign-in to a deactivated user", zap.String("firebaseUID", firebaseToken.UID), zap.String("localUserID", dbUser.ID.String()))
+		return nil, false, common.ErrForbidden.WithDetails("This account has been deactivated.")
+	}
+
 	if err == nil { // User found
 		s.logger.Debug("User found by Firebase UID", zap.String("firebaseUID", firebaseToken.UID), zap.String("localUserID", dbUser.ID.String()))
 		needsUpdate := false
 
-		// Check and update email if necessary
-		if emailClaim, ok := firebaseToken.Claims["email"].(string); ok && emailClaim != "" {
+		// Check and update email if necessary. Only the identity the user signed up with may change it;
+		// linked providers can report a different address (e.g. an Apple private relay).
+		isPrimaryIdentity := dbUser.FirebaseUID != nil && *dbUser.FirebaseUID == firebaseToken.UID
+		if emailClaim, ok := firebaseToken.Claims["email"].(string); ok && emailClaim != "" && isPrimaryIdentity {
 			emailVerifiedClaim, _ := firebaseToken.Claims["email_verified"].(bool) // Default to false if not present
 			normalizedEmailClaim := strings.ToLower(strings.TrimSpace(emailClaim))
 
@@ -191,15 +210,339 @@ func (s *ServiceImplementation) GetOrCreateUserFromFirebaseClaims(ctx context.Co
 			return nil, false, common.ErrInternalServer.WithDetails("Could not create new user account from Firebase.")
 		}
 		s.logger.Info("New user created successfully from Firebase claims", zap.String("firebaseUID", firebaseToken.UID), zap.String("localUserID", dbNewUser.ID.String()))
+		if errLink := s.linkIdentity(ctx, dbNewUser.ID, firebaseToken); errLink != nil {
+			// Non-critical: the user is still found by users.firebase_uid.
+			s.logger.Warn("Failed to record identity for new user", zap.Error(errLink), zap.String("firebaseUID", firebaseToken.UID))
+		}
+		s.flagDuplicateAccounts(ctx, dbNewUser)
 		dbUser = dbNewUser // Assign to dbUser to be returned
 	} else { // Other error
 		s.logger.Error("Error finding user by Firebase UID", zap.Error(err), zap.String("firebaseUID", firebaseToken.UID))
 		return nil, false, common.ErrInternalServer.WithDetails("Failed to retrieve user by Firebase UID.")
 	}
 
+	if dbUser.ProfileCompletedAt == nil && dbUser.hasCompleteProfile() {
+		s.completeOnboardingStep(ctx, dbUser.ID, shared.OnboardingProfileCompleted)
+	}
 	return DBToShared(dbUser), wasCreated, nil
 }
 
+// GetOnboarding reports the user's progress through onboarding.
+func (s *ServiceImplementation) GetOnboarding(ctx context.Context, userID uuid.UUID) (*shared.OnboardingStatus, error) {
+	u, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to load user for onboarding", zap.String("userID", userID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve onboarding progress.")
+	}
+	return OnboardingStatusOf(u), nil
+}
+
+// CompleteOnboardingStep records a step the app reports as done. Other steps are completed by the services
+// concerned and cannot be completed this way.
+func (s *ServiceImplementation) CompleteOnboardingStep(ctx context.Context, userID uuid.UUID, step shared.OnboardingStep) (*shared.OnboardingStatus, error) {
+	if onboardingColumn(step) == "" {
+		return nil, common.ErrNotFound.WithDetails("Unknown onboarding step.")
+	}
+	if !step.ClientReported() {
+		return nil, common.ErrBadRequest.WithDetails("This onboarding step is completed automatically.")
+	}
+	if err := s.repo.CompleteOnboardingStep(ctx, userID, step); err != nil {
+		s.logger.Error("Failed to complete onboarding step", zap.String("userID", userID.String()), zap.String("step", string(step)), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not update onboarding progress.")
+	}
+	return s.GetOnboarding(ctx, userID)
+}
+
+// completeOnboardingStep records a step completed as a side effect of another action, which succeeds regardless.
+func (s *ServiceImplementation) completeOnboardingStep(ctx context.Context, userID uuid.UUID, step shared.OnboardingStep) {
+	if err := s.repo.CompleteOnboardingStep(ctx, userID, step); err != nil {
+		s.logger.Warn("Failed to record onboarding step", zap.String("userID", userID.String()), zap.String("step", string(step)), zap.Error(err))
+	}
+}
+
+// findLinkedUser returns the user that a Firebase account belongs to when it is not the account the user
+// signed up with: either through an identity linked earlier, or through a user with the same verified
+// email, to whom the account is then linked. It returns nil if the account belongs to no user yet.
+func (s *ServiceImplementation) findLinkedUser(ctx context.Context, firebaseToken *firebaseauth.Token) (*User, error) {
+	identity, err := s.repo.FindIdentityByFirebaseUID(ctx, firebaseToken.UID)
+	if err == nil {
+		dbUser, errFind := s.repo.FindByID(ctx, identity.UserID)
+		if errFind != nil {
+			s.logger.Error("Failed to load user for linked identity", zap.Error(errFind), zap.String("firebaseUID", firebaseToken.UID))
+			return nil, common.ErrInternalServer.WithDetails("Failed to retrieve linked user.")
+		}
+		return dbUser, nil
+	}
+	if !errors.Is(err, common.ErrNotFound) {
+		s.logger.Error("Error finding identity by Firebase UID", zap.Error(err), zap.String("firebaseUID", firebaseToken.UID))
+		return nil, common.ErrInternalServer.WithDetails("Failed to retrieve user by Firebase UID.")
+	}
+
+	// Link by email only if both the provider and our record vouch for it; otherwise anyone able to
+	// register an unverified address with some provider could take over the account. Emails are compared
+	// in canonical form, so user.name@gmail.com finds the account of username@gmail.com.
+	emailClaim, _ := firebaseToken.Claims["email"].(string)
+	emailVerifiedClaim, _ := firebaseToken.Claims["email_verified"].(bool)
+	canonicalEmail := CanonicalEmail(emailClaim)
+	if canonicalEmail == "" || !emailVerifiedClaim {
+		return nil, nil
+	}
+	matches, err := s.repo.FindByCanonicalEmail(ctx, canonicalEmail)
+	if err != nil {
+		s.logger.Error("Error finding user by email for account linking", zap.Error(err), zap.String("firebaseUID", firebaseToken.UID))
+		return nil, common.ErrInternalServer.WithDetails("Failed to retrieve user by email.")
+	}
+	var existing *User
+	for i := range matches {
+		if matches[i].IsEmailVerified {
+			existing = &matches[i]
+			break
+		}
+	}
+	if existing == nil {
+		if len(matches) > 0 {
+			s.logger.Info("Not linking sign-in account: existing user's email is unverified",
+				zap.String("firebaseUID", firebaseToken.UID), zap.String("localUserID", matches[0].ID.String()))
+		}
+		return nil, nil
+	}
+
+	if err := s.linkIdentity(ctx, existing.ID, firebaseToken); err != nil {
+		s.logger.Error("Failed to link sign-in account to existing user", zap.Error(err),
+			zap.String("firebaseUID", firebaseToken.UID), zap.String("localUserID", existing.ID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not link sign-in account.")
+	}
+	s.logger.Info("Linked sign-in account to existing user by verified email",
+		zap.String("firebaseUID", firebaseToken.UID),
+		zap.String("provider", signInProvider(firebaseToken)),
+		zap.String("localUserID", existing.ID.String()))
+	return existing, nil
+}
+
+// flagDuplicateAccounts records a newly created user as a possible duplicate of the older accounts whose email
+// has the same canonical form, for an admin to merge or dismiss. Failures are logged and otherwise ignored.
+func (s *ServiceImplementation) flagDuplicateAccounts(ctx context.Context, newUser *User) {
+	if newUser.Email == nil || *newUser.Email == "" {
+		return
+	}
+	canonicalEmail := CanonicalEmail(*newUser.Email)
+	matches, err := s.repo.FindByCanonicalEmail(ctx, canonicalEmail)
+	if err != nil {
+		s.logger.Warn("Failed to check new user for duplicate accounts", zap.Error(err), zap.String("localUserID", newUser.ID.String()))
+		return
+	}
+	for i := range matches {
+		if matches[i].ID == newUser.ID {
+			continue
+		}
+		candidate := &DuplicateCandidate{
+			ID:             uuid.New(),
+			UserID:         newUser.ID,
+			DuplicateOfID:  matches[i].ID,
+			CanonicalEmail: canonicalEmail,
+			CreatedAt:      time.Now(),
+		}
+		if err := s.repo.CreateDuplicateCandidate(ctx, candidate); err != nil {
+			s.logger.Warn("Failed to record duplicate account candidate", zap.Error(err),
+				zap.String("localUserID", newUser.ID.String()), zap.String("duplicateOfID", matches[i].ID.String()))
+			continue
+		}
+		s.logger.Info("New user may duplicate an existing account",
+			zap.String("localUserID", newUser.ID.String()), zap.String("duplicateOfID", matches[i].ID.String()))
+	}
+}
+
+// ListDuplicateCandidates returns a page of the account pairs flagged as possible duplicates and not yet resolved.
+func (s *ServiceImplementation) ListDuplicateCandidates(ctx context.Context, page, pageSize int) ([]*shared.DuplicateCandidate, *common.Pagination, error) {
+	candidates, total, err := s.repo.FindOpenDuplicateCandidates(ctx, page, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to list duplicate account candidates", zap.Error(err))
+		return nil, nil, common.ErrInternalServer.WithDetails("Could not retrieve duplicate accounts.")
+	}
+	result := make([]*shared.DuplicateCandidate, 0, len(candidates))
+	for i := range candidates {
+		result = append(result, DuplicateCandidateToShared(&candidates[i]))
+	}
+	return result, common.NewPagination(total, page, pageSize), nil
+}
+
+// DismissDuplicateCandidate resolves a duplicate candidate without merging, for accounts that are rightly separate.
+func (s *ServiceImplementation) DismissDuplicateCandidate(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.ResolveDuplicateCandidate(ctx, id); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return err
+		}
+		s.logger.Error("Failed to dismiss duplicate account candidate", zap.Error(err), zap.String("candidateID", id.String()))
+		return common.ErrInternalServer.WithDetails("Could not dismiss duplicate account.")
+	}
+	s.logger.Info("Duplicate account candidate dismissed", zap.String("candidateID", id.String()))
+	return nil
+}
+
+// MergeUsers consolidates two accounts of one person: the source's listings, notifications, saved searches,
+// conversations, messages and sign-in identities move to the target, and the source is deactivated. The merge
+// and its audit log entry are written in one transaction.
+func (s *ServiceImplementation) MergeUsers(ctx context.Context, adminID uuid.UUID, req shared.MergeUsersRequest) (*shared.MergeResult, error) {
+	if req.SourceUserID == req.TargetUserID {
+		return nil, common.ErrBadRequest.WithDetails("Source and target must be different users.")
+	}
+	source, err := s.findMergeUser(ctx, req.SourceUserID, "Source")
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.findMergeUser(ctx, req.TargetUserID, "Target")
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := audit.NewEntry(audit.Event{
+		ActorID:    &adminID,
+		Action:     audit.ActionUserMerge,
+		EntityType: audit.EntityUser,
+		EntityID:   source.ID.String(),
+		Changes: map[string]audit.FieldChange{
+			"merged_into_id": {From: nil, To: target.ID},
+			"deactivated":    {From: false, To: true},
+		},
+		Note: req.Note,
+	})
+	if err != nil {
+		s.logger.Error("Failed to encode audit entry for user merge", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not merge accounts.")
+	}
+
+	result, err := s.repo.MergeUsers(ctx, source.ID, target.ID, entry)
+	if err != nil {
+		if errors.Is(err, common.ErrConflict) {
+			return nil, err
+		}
+		s.logger.Error("Failed to merge users", zap.Error(err),
+			zap.String("sourceUserID", source.ID.String()), zap.String("targetUserID", target.ID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not merge accounts.")
+	}
+	s.logger.Info("Users merged",
+		zap.String("adminID", adminID.String()),
+		zap.String("sourceUserID", source.ID.String()),
+		zap.String("targetUserID", target.ID.String()),
+		zap.Int64("listings", result.Listings),
+		zap.Int64("identities", result.Identities))
+	return result, nil
+}
+
+// findMergeUser loads one side of a merge, which must exist and still be active. role names it in errors.
+func (s *ServiceImplementation) findMergeUser(ctx context.Context, id uuid.UUID, role string) (*User, error) {
+	dbUser, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, common.ErrNotFound.WithDetails(role + " user not found.")
+		}
+		s.logger.Error("Failed to load user for merge", zap.Error(err), zap.String("userID", id.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not merge accounts.")
+	}
+	if dbUser.DeactivatedAt != nil {
+		return nil, common.ErrConflict.WithDetails(role + " user has already been merged or deactivated.")
+	}
+	return dbUser, nil
+}
+
+// linkIdentity records the Firebase account in firebaseToken as an identity of the user.
+func (s *ServiceImplementation) linkIdentity(ctx context.Context, userID uuid.UUID, firebaseToken *firebaseauth.Token) error {
+	identity := &Identity{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Provider:    signInProvider(firebaseToken),
+		FirebaseUID: firebaseToken.UID,
+		CreatedAt:   time.Now(),
+	}
+	if emailClaim, ok := firebaseToken.Claims["email"].(string); ok && emailClaim != "" {
+		email := strings.ToLower(strings.TrimSpace(emailClaim))
+		identity.Email = &email
+	}
+	return s.repo.CreateIdentity(ctx, identity)
+}
+
+// signInProvider returns the provider the token was issued for, e.g. "google.com".
+func signInProvider(firebaseToken *firebaseauth.Token) string {
+	if firebaseToken.Firebase.SignInProvider != "" {
+		return firebaseToken.Firebase.SignInProvider
+	}
+	return "firebase"
+}
+
+// ListIdentities returns the sign-in identities linked to a user.
+func (s *ServiceImplementation) ListIdentities(ctx context.Context, userID uuid.UUID) ([]*shared.Identity, error) {
+	dbUser, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, err
+		}
+		s.logger.Error("Failed to load user for identities", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve linked accounts.")
+	}
+	identities, err := s.repo.FindIdentitiesByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list identities", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve linked accounts.")
+	}
+	result := make([]*shared.Identity, 0, len(identities))
+	for i := range identities {
+		result = append(result, IdentityToShared(&identities[i], dbUser.FirebaseUID))
+	}
+	return result, nil
+}
+
+// UnlinkIdentity removes a sign-in identity from a user. The last identity cannot be removed.
+// Unlinking the identity the user signed up with makes the oldest remaining one primary.
+func (s *ServiceImplementation) UnlinkIdentity(ctx context.Context, userID, identityID uuid.UUID) error {
+	identities, err := s.repo.FindIdentitiesByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list identities", zap.Error(err), zap.String("userID", userID.String()))
+		return common.ErrInternalServer.WithDetails("Could not unlink account.")
+	}
+	var target *Identity
+	var remaining []Identity
+	for i := range identities {
+		if identities[i].ID == identityID {
+			target = &identities[i]
+		} else {
+			remaining = append(remaining, identities[i])
+		}
+	}
+	if target == nil {
+		return common.ErrNotFound.WithDetails("Identity not found.")
+	}
+	if len(remaining) == 0 {
+		return common.ErrConflict.WithDetails("Cannot unlink the only sign-in method of an account.")
+	}
+
+	dbUser, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to load user for unlinking", zap.Error(err), zap.String("userID", userID.String()))
+		return common.ErrInternalServer.WithDetails("Could not unlink account.")
+	}
+	if dbUser.FirebaseUID != nil && *dbUser.FirebaseUID == target.FirebaseUID {
+		newPrimaryUID := remaining[0].FirebaseUID
+		dbUser.FirebaseUID = &newPrimaryUID
+		dbUser.UpdatedAt = time.Now()
+		if err := s.repo.Update(ctx, dbUser); err != nil {
+			s.logger.Error("Failed to move primary identity", zap.Error(err), zap.String("userID", userID.String()))
+			return common.ErrInternalServer.WithDetails("Could not unlink account.")
+		}
+	}
+	if err := s.repo.DeleteIdentity(ctx, identityID); err != nil {
+		s.logger.Error("Failed to delete identity", zap.Error(err), zap.String("userID", userID.String()))
+		return common.ErrInternalServer.WithDetails("Could not unlink account.")
+	}
+	s.logger.Info("Sign-in account unlinked",
+		zap.String("userID", userID.String()),
+		zap.String("identityID", identityID.String()),
+		zap.String("provider", target.Provider))
+	return nil
+}
+
 // GetUserByFirebaseUID retrieves a user by their Firebase UID.
 func (s *ServiceImplementation) GetUserByFirebaseUID(ctx context.Context, firebaseUID string) (*shared.User, error) {
 	dbUser, err := s.repo.FindByFirebaseUID(ctx, firebaseUID)
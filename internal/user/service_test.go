@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"seattle_info_backend/internal/audit"
 	"seattle_info_backend/internal/common"
 	"seattle_info_backend/internal/config"
 	"seattle_info_backend/internal/shared" // Added
@@ -30,6 +31,12 @@ type MockUserRepository struct {
 	// FindByEmailFunc func(ctx context.Context, email string) (*User, error)
 	// FindByIDFunc func(ctx context.Context, id uuid.UUID) (*User, error)
 	// FindByProviderFunc func(ctx context.Context, provider, providerID string) (*User, error)
+
+	users      []*User                 // Returned by FindByEmail and FindByID
+	identities []Identity              // Backing store for the identity methods
+	duplicates []DuplicateCandidate    // Backing store for the duplicate candidate methods
+	merges     []*audit.Entry          // Audit entries passed to MergeUsers
+	onboarding []shared.OnboardingStep // Steps passed to CompleteOnboardingStep
 }
 
 // Implement Repository interface for MockUserRepository (actual mocking logic to be filled in)
@@ -64,14 +71,118 @@ func (m *MockUserRepository) Update(ctx context.Context, user *User) error {
 }
 
 func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*User, error) {
+	for _, u := range m.users {
+		if u.Email != nil && *u.Email == email {
+			return u, nil
+		}
+	}
 	return nil, common.ErrNotFound
 }
+func (m *MockUserRepository) FindByCanonicalEmail(ctx context.Context, canonicalEmail string) ([]User, error) {
+	var result []User
+	for _, u := range m.users {
+		if u.Email != nil && CanonicalEmail(*u.Email) == canonicalEmail {
+			result = append(result, *u)
+		}
+	}
+	return result, nil
+}
 func (m *MockUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*User, error) {
+	for _, u := range m.users {
+		if u.ID == id {
+			return u, nil
+		}
+	}
 	return nil, common.ErrNotFound
 }
 func (m *MockUserRepository) FindByProvider(ctx context.Context, provider, providerID string) (*User, error) {
 	return nil, common.ErrNotFound
 }
+func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+
+func (m *MockUserRepository) CreateIdentity(ctx context.Context, identity *Identity) error {
+	m.identities = append(m.identities, *identity)
+	return nil
+}
+func (m *MockUserRepository) FindIdentityByFirebaseUID(ctx context.Context, firebaseUID string) (*Identity, error) {
+	for i := range m.identities {
+		if m.identities[i].FirebaseUID == firebaseUID {
+			return &m.identities[i], nil
+		}
+	}
+	return nil, common.ErrNotFound
+}
+func (m *MockUserRepository) FindIdentitiesByUserID(ctx context.Context, userID uuid.UUID) ([]Identity, error) {
+	var result []Identity
+	for _, identity := range m.identities {
+		if identity.UserID == userID {
+			result = append(result, identity)
+		}
+	}
+	return result, nil
+}
+func (m *MockUserRepository) DeleteIdentity(ctx context.Context, id uuid.UUID) error {
+	for i := range m.identities {
+		if m.identities[i].ID == id {
+			m.identities = append(m.identities[:i], m.identities[i+1:]...)
+			return nil
+		}
+	}
+	return common.ErrNotFound
+}
+
+func (m *MockUserRepository) CreateDuplicateCandidate(ctx context.Context, candidate *DuplicateCandidate) error {
+	m.duplicates = append(m.duplicates, *candidate)
+	return nil
+}
+func (m *MockUserRepository) FindOpenDuplicateCandidates(ctx context.Context, page, pageSize int) ([]DuplicateCandidate, int64, error) {
+	var result []DuplicateCandidate
+	for _, candidate := range m.duplicates {
+		if candidate.ResolvedAt == nil {
+			result = append(result, candidate)
+		}
+	}
+	return result, int64(len(result)), nil
+}
+func (m *MockUserRepository) ResolveDuplicateCandidate(ctx context.Context, id uuid.UUID) error {
+	for i := range m.duplicates {
+		if m.duplicates[i].ID == id && m.duplicates[i].ResolvedAt == nil {
+			now := time.Now()
+			m.duplicates[i].ResolvedAt = &now
+			return nil
+		}
+	}
+	return common.ErrNotFound
+}
+
+func (m *MockUserRepository) MergeUsers(ctx context.Context, sourceID, targetID uuid.UUID, auditEntry *audit.Entry) (*shared.MergeResult, error) {
+	m.merges = append(m.merges, auditEntry)
+	now := time.Now()
+	for _, u := range m.users {
+		if u.ID == sourceID {
+			u.DeactivatedAt, u.MergedIntoID = &now, &targetID
+		}
+	}
+	return &shared.MergeResult{SourceUserID: sourceID, TargetUserID: targetID, Listings: 2}, nil
+}
+
+func (m *MockUserRepository) CompleteOnboardingStep(ctx context.Context, userID uuid.UUID, step shared.OnboardingStep) error {
+	m.onboarding = append(m.onboarding, step)
+	now := time.Now()
+	for _, u := range m.users {
+		if u.ID == userID {
+			switch step {
+			case shared.OnboardingProfileCompleted:
+				u.ProfileCompletedAt = &now
+			case shared.OnboardingNotificationPrefs:
+				u.NotificationPrefsSetAt = &now
+			}
+		}
+	}
+	return nil
+}
 
 // SearchUsers implements a mock for the Repository interface.
 func (m *MockUserRepository) SearchUsers(ctx context.Context, params shared.UserSearchQuery) ([]User, *common.Pagination, error) {
@@ -269,3 +380,275 @@ func TestUserService_GetUserByFirebaseUID(t *testing.T) {
 // 4. Configuration of mockRepo per test case within the tt.setupMock functions.
 // 5. The `user.ServiceImplementation` logic for splitting `firebaseToken.Claims["name"]` into
 //    `FirstName` (and potentially `LastName`) needs to be accurately reflected in test expectations.
+
+func TestUserService_LinksProviderByVerifiedEmail(t *testing.T) {
+	logger := zap.NewNop()
+	email := "jane@example.com"
+	googleUID := "google_uid"
+	existing := &User{
+		BaseModel:       common.BaseModel{ID: uuid.New()},
+		FirebaseUID:     &googleUID,
+		Email:           &email,
+		IsEmailVerified: true,
+		Role:            common.RoleUser,
+	}
+	mockRepo := &MockUserRepository{users: []*User{existing}}
+	userService := NewService(mockRepo, &config.Config{}, logger)
+
+	appleToken := &firebaseauth.Token{
+		UID: "apple_uid",
+		Claims: map[string]interface{}{
+			"email":          "Jane@Example.com",
+			"email_verified": true,
+		},
+		Firebase: firebaseauth.FirebaseInfo{SignInProvider: "apple.com"},
+	}
+
+	sharedUser, wasCreated, err := userService.GetOrCreateUserFromFirebaseClaims(context.Background(), appleToken)
+	if err != nil {
+		t.Fatalf("GetOrCreateUserFromFirebaseClaims() error = %v", err)
+	}
+	if wasCreated || sharedUser.ID != existing.ID {
+		t.Fatalf("expected Apple sign-in to resolve to existing user %s, got %s (created=%v)", existing.ID, sharedUser.ID, wasCreated)
+	}
+	if len(mockRepo.identities) != 1 || mockRepo.identities[0].Provider != "apple.com" || mockRepo.identities[0].UserID != existing.ID {
+		t.Fatalf("expected an apple.com identity linked to the existing user, got %+v", mockRepo.identities)
+	}
+
+	// A second sign-in resolves through the identity without creating another one.
+	if _, _, err := userService.GetOrCreateUserFromFirebaseClaims(context.Background(), appleToken); err != nil {
+		t.Fatalf("second sign-in error = %v", err)
+	}
+	if len(mockRepo.identities) != 1 {
+		t.Errorf("expected identity to be reused, got %d identities", len(mockRepo.identities))
+	}
+}
+
+func TestUserService_DoesNotLinkUnverifiedEmail(t *testing.T) {
+	email := "jane@example.com"
+	existing := &User{
+		BaseModel:       common.BaseModel{ID: uuid.New()},
+		Email:           &email,
+		IsEmailVerified: true,
+	}
+	mockRepo := &MockUserRepository{users: []*User{existing}}
+	userService := NewService(mockRepo, &config.Config{}, zap.NewNop())
+
+	token := &firebaseauth.Token{
+		UID:    "unverified_uid",
+		Claims: map[string]interface{}{"email": email, "email_verified": false},
+	}
+	sharedUser, _, err := userService.GetOrCreateUserFromFirebaseClaims(context.Background(), token)
+	if err != nil {
+		t.Fatalf("GetOrCreateUserFromFirebaseClaims() error = %v", err)
+	}
+	if sharedUser.ID == existing.ID {
+		t.Errorf("an unverified email must not be linked to an existing account")
+	}
+}
+
+func TestUserService_UnlinkIdentity(t *testing.T) {
+	googleUID, appleUID := "google_uid", "apple_uid"
+	u := &User{BaseModel: common.BaseModel{ID: uuid.New()}, FirebaseUID: &googleUID}
+	google := Identity{ID: uuid.New(), UserID: u.ID, Provider: "google.com", FirebaseUID: googleUID}
+	apple := Identity{ID: uuid.New(), UserID: u.ID, Provider: "apple.com", FirebaseUID: appleUID}
+	mockRepo := &MockUserRepository{users: []*User{u}, identities: []Identity{google, apple}}
+	userService := NewService(mockRepo, &config.Config{}, zap.NewNop())
+	ctx := context.Background()
+
+	if err := userService.UnlinkIdentity(ctx, u.ID, uuid.New()); !errors.Is(err, common.ErrNotFound) {
+		t.Errorf("unlinking an unknown identity: error = %v, want not found", err)
+	}
+	if err := userService.UnlinkIdentity(ctx, u.ID, google.ID); err != nil {
+		t.Fatalf("UnlinkIdentity() error = %v", err)
+	}
+	if u.FirebaseUID == nil || *u.FirebaseUID != appleUID {
+		t.Errorf("expected remaining identity to become primary, FirebaseUID = %v", u.FirebaseUID)
+	}
+	if err := userService.UnlinkIdentity(ctx, u.ID, apple.ID); !errors.Is(err, common.ErrConflict) {
+		t.Errorf("unlinking the last identity: error = %v, want conflict", err)
+	}
+
+	identities, err := userService.ListIdentities(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("ListIdentities() error = %v", err)
+	}
+	if len(identities) != 1 || !identities[0].IsPrimary || identities[0].Provider != "apple.com" {
+		t.Errorf("unexpected identities after unlinking: %+v", identities)
+	}
+}
+
+func TestCanonicalEmail(t *testing.T) {
+	tests := map[string]string{
+		"":                           "",
+		" Jane@Example.com ":         "jane@example.com",
+		"jane.doe+news@example.com":  "jane.doe+news@example.com",
+		"User.Name@gmail.com":        "username@gmail.com",
+		"u.s.e.r.name+ads@Gmail.com": "username@gmail.com",
+		"user.name@googlemail.com":   "username@gmail.com",
+		"not-an-email":               "not-an-email",
+	}
+	for email, want := range tests {
+		if got := CanonicalEmail(email); got != want {
+			t.Errorf("CanonicalEmail(%q) = %q, want %q", email, got, want)
+		}
+	}
+}
+
+func TestUserService_LinksGmailAddressVariant(t *testing.T) {
+	email := "username@gmail.com"
+	existing := &User{
+		BaseModel:       common.BaseModel{ID: uuid.New()},
+		Email:           &email,
+		IsEmailVerified: true,
+	}
+	mockRepo := &MockUserRepository{users: []*User{existing}}
+	userService := NewService(mockRepo, &config.Config{}, zap.NewNop())
+
+	token := &firebaseauth.Token{
+		UID:      "google_uid",
+		Claims:   map[string]interface{}{"email": "User.Name@gmail.com", "email_verified": true},
+		Firebase: firebaseauth.FirebaseInfo{SignInProvider: "google.com"},
+	}
+	sharedUser, wasCreated, err := userService.GetOrCreateUserFromFirebaseClaims(context.Background(), token)
+	if err != nil {
+		t.Fatalf("GetOrCreateUserFromFirebaseClaims() error = %v", err)
+	}
+	if wasCreated || sharedUser.ID != existing.ID {
+		t.Errorf("expected the dotted Gmail address to resolve to existing user %s, got %s (created=%v)", existing.ID, sharedUser.ID, wasCreated)
+	}
+	if len(mockRepo.duplicates) != 0 {
+		t.Errorf("a linked sign-in must not be flagged as a duplicate, got %+v", mockRepo.duplicates)
+	}
+}
+
+func TestUserService_FlagsUnlinkedDuplicateAccount(t *testing.T) {
+	email := "username@gmail.com"
+	existing := &User{
+		BaseModel:       common.BaseModel{ID: uuid.New()},
+		Email:           &email,
+		IsEmailVerified: false, // Cannot be linked automatically
+	}
+	mockRepo := &MockUserRepository{users: []*User{existing}}
+	userService := NewService(mockRepo, &config.Config{}, zap.NewNop())
+	ctx := context.Background()
+
+	token := &firebaseauth.Token{
+		UID:    "apple_uid",
+		Claims: map[string]interface{}{"email": "user.name@gmail.com", "email_verified": true},
+	}
+	sharedUser, wasCreated, err := userService.GetOrCreateUserFromFirebaseClaims(ctx, token)
+	if err != nil {
+		t.Fatalf("GetOrCreateUserFromFirebaseClaims() error = %v", err)
+	}
+	if !wasCreated || sharedUser.ID == existing.ID {
+		t.Fatalf("expected a new user, got %s (created=%v)", sharedUser.ID, wasCreated)
+	}
+	if len(mockRepo.duplicates) != 1 {
+		t.Fatalf("expected one duplicate candidate, got %+v", mockRepo.duplicates)
+	}
+	candidate := mockRepo.duplicates[0]
+	if candidate.UserID != sharedUser.ID || candidate.DuplicateOfID != existing.ID || candidate.CanonicalEmail != "username@gmail.com" {
+		t.Errorf("unexpected duplicate candidate %+v", candidate)
+	}
+
+	if err := userService.DismissDuplicateCandidate(ctx, candidate.ID); err != nil {
+		t.Fatalf("DismissDuplicateCandidate() error = %v", err)
+	}
+	open, pagination, err := userService.ListDuplicateCandidates(ctx, 1, 10)
+	if err != nil {
+		t.Fatalf("ListDuplicateCandidates() error = %v", err)
+	}
+	if len(open) != 0 || pagination.TotalItems != 0 {
+		t.Errorf("expected no open candidates after dismissing, got %d", len(open))
+	}
+	if err := userService.DismissDuplicateCandidate(ctx, candidate.ID); !errors.Is(err, common.ErrNotFound) {
+		t.Errorf("dismissing twice: error = %v, want not found", err)
+	}
+}
+
+func TestUserService_MergeUsers(t *testing.T) {
+	source := &User{BaseModel: common.BaseModel{ID: uuid.New()}}
+	target := &User{BaseModel: common.BaseModel{ID: uuid.New()}}
+	mockRepo := &MockUserRepository{users: []*User{source, target}}
+	userService := NewService(mockRepo, &config.Config{}, zap.NewNop())
+	ctx := context.Background()
+	adminID := uuid.New()
+	note := "Same person, Gmail dots"
+
+	if _, err := userService.MergeUsers(ctx, adminID, shared.MergeUsersRequest{SourceUserID: source.ID, TargetUserID: source.ID}); !errors.Is(err, common.ErrBadRequest) {
+		t.Errorf("merging a user into itself: error = %v, want bad request", err)
+	}
+	if _, err := userService.MergeUsers(ctx, adminID, shared.MergeUsersRequest{SourceUserID: source.ID, TargetUserID: uuid.New()}); !errors.Is(err, common.ErrNotFound) {
+		t.Errorf("merging into an unknown user: error = %v, want not found", err)
+	}
+
+	result, err := userService.MergeUsers(ctx, adminID, shared.MergeUsersRequest{SourceUserID: source.ID, TargetUserID: target.ID, Note: &note})
+	if err != nil {
+		t.Fatalf("MergeUsers() error = %v", err)
+	}
+	if result.Listings != 2 || result.TargetUserID != target.ID {
+		t.Errorf("unexpected merge result %+v", result)
+	}
+	if len(mockRepo.merges) != 1 {
+		t.Fatalf("expected one audit entry, got %d", len(mockRepo.merges))
+	}
+	entry := mockRepo.merges[0]
+	if entry.Action != audit.ActionUserMerge || entry.EntityID != source.ID.String() || *entry.ActorID != adminID || *entry.Note != note {
+		t.Errorf("unexpected audit entry %+v", entry)
+	}
+
+	if _, err := userService.MergeUsers(ctx, adminID, shared.MergeUsersRequest{SourceUserID: source.ID, TargetUserID: target.ID}); !errors.Is(err, common.ErrConflict) {
+		t.Errorf("merging a deactivated user again: error = %v, want conflict", err)
+	}
+}
+
+func TestOnboardingProfileStepCompletedOnSignIn(t *testing.T) {
+	mockRepo := &MockUserRepository{}
+	userService := NewService(mockRepo, &config.Config{}, zap.NewNop())
+	token := &firebaseauth.Token{
+		UID:    "new_fb_uid",
+		Claims: map[string]interface{}{"email": "new@example.com", "name": "New User"},
+	}
+	if _, _, err := userService.GetOrCreateUserFromFirebaseClaims(context.Background(), token); err != nil {
+		t.Fatalf("GetOrCreateUserFromFirebaseClaims() error = %v", err)
+	}
+	if len(mockRepo.onboarding) != 1 || mockRepo.onboarding[0] != shared.OnboardingProfileCompleted {
+		t.Errorf("onboarding steps = %v, want [%s]", mockRepo.onboarding, shared.OnboardingProfileCompleted)
+	}
+
+	// Without a name the profile is not complete yet.
+	mockRepo.onboarding = nil
+	token = &firebaseauth.Token{UID: "nameless_fb_uid", Claims: map[string]interface{}{"email": "nameless@example.com"}}
+	if _, _, err := userService.GetOrCreateUserFromFirebaseClaims(context.Background(), token); err != nil {
+		t.Fatalf("GetOrCreateUserFromFirebaseClaims() error = %v", err)
+	}
+	if len(mockRepo.onboarding) != 0 {
+		t.Errorf("onboarding steps = %v, want none", mockRepo.onboarding)
+	}
+}
+
+func TestCompleteOnboardingStep(t *testing.T) {
+	u := &User{BaseModel: common.BaseModel{ID: uuid.New()}}
+	verifiedAt := time.Now()
+	u.PhoneVerifiedAt = &verifiedAt
+	mockRepo := &MockUserRepository{users: []*User{u}}
+	userService := NewService(mockRepo, &config.Config{}, zap.NewNop())
+	ctx := context.Background()
+
+	if _, err := userService.CompleteOnboardingStep(ctx, u.ID, shared.OnboardingPhoneVerified); !errors.Is(err, common.ErrBadRequest) {
+		t.Errorf("completing an automatic step: err = %v, want ErrBadRequest", err)
+	}
+	if _, err := userService.CompleteOnboardingStep(ctx, u.ID, "tour_taken"); !errors.Is(err, common.ErrNotFound) {
+		t.Errorf("completing an unknown step: err = %v, want ErrNotFound", err)
+	}
+
+	status, err := userService.CompleteOnboardingStep(ctx, u.ID, shared.OnboardingNotificationPrefs)
+	if err != nil {
+		t.Fatalf("CompleteOnboardingStep() error = %v", err)
+	}
+	want := []shared.OnboardingStep{shared.OnboardingProfileCompleted, shared.OnboardingFirstListingPosted}
+	if status.Completed || len(status.RemainingSteps) != 2 || status.RemainingSteps[0] != want[0] || status.RemainingSteps[1] != want[1] {
+		t.Errorf("status = %+v, want remaining steps %v", status, want)
+	}
+}
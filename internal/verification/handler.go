@@ -0,0 +1,90 @@
+// File: internal/verification/handler.go
+package verification
+
+import (
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Handler struct holds dependencies for verification handlers.
+type Handler struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewHandler creates a new verification handler.
+func NewHandler(service Service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes sets up the routes for phone verification of the authenticated user.
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMW gin.HandlerFunc) {
+	phoneGroup := router.Group("/users/me/phone/verification")
+	phoneGroup.Use(authMW)
+	{
+		phoneGroup.POST("", h.requestCode)
+		phoneGroup.POST("/confirm", h.confirmCode)
+	}
+}
+
+func (h *Handler) requestCode(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	var req RequestCodeRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	v, err := h.service.RequestCode(c.Request.Context(), userID, req.PhoneNumber)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Verification code sent.", CodeSentResponse{
+		PhoneNumber: v.PhoneNumber,
+		ExpiresAt:   v.ExpiresAt,
+	})
+}
+
+func (h *Handler) confirmCode(c *gin.Context) {
+	userID := common.GetUserIDFromContext(c)
+	if userID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	var req ConfirmCodeRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	u, err := h.service.ConfirmCode(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	resp := PhoneStatusResponse{PhoneVerified: u.PhoneVerified}
+	if u.PhoneNumber != nil {
+		resp.PhoneNumber = *u.PhoneNumber
+	}
+	common.RespondOK(c, "Phone number verified.", resp)
+}
+
+// bindJSON binds the request body into req and responds with an error if that fails.
+func (h *Handler) bindJSON(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		common.RespondWithError(c, common.BindingError(err))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+// File: internal/verification/model.go
+package verification
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PhoneVerification is one code sent by SMS to confirm that a user owns a phone number.
+// Only a hash of the code is stored.
+type PhoneVerification struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index"`
+	PhoneNumber string     `gorm:"type:varchar(20);not null"`
+	CodeHash    string     `gorm:"type:varchar(64);not null"`
+	Attempts    int        `gorm:"not null;default:0"` // Wrong codes entered so far
+	ExpiresAt   time.Time  `gorm:"not null"`
+	VerifiedAt  *time.Time // Set once the correct code was entered
+	CreatedAt   time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName specifies the table name for GORM.
+func (PhoneVerification) TableName() string {
+	return "phone_verifications"
+}
+
+// IsOpen reports whether the code can still be confirmed at now.
+func (v *PhoneVerification) IsOpen(now time.Time, maxAttempts int) bool {
+	return v.VerifiedAt == nil && now.Before(v.ExpiresAt) && v.Attempts < maxAttempts
+}
+
+// --- Request DTOs ---
+
+// RequestCodeRequest asks for a verification code to be sent to a phone number.
+type RequestCodeRequest struct {
+	PhoneNumber string `json:"phone_number" binding:"required,e164"`
+}
+
+// ConfirmCodeRequest submits the code received by SMS.
+type ConfirmCodeRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// --- Response DTOs ---
+
+// CodeSentResponse tells the client where the code went and how long it is valid.
+type CodeSentResponse struct {
+	PhoneNumber string    `json:"phone_number"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// PhoneStatusResponse is the user's phone verification state after a successful confirmation.
+type PhoneStatusResponse struct {
+	PhoneNumber   string `json:"phone_number"`
+	PhoneVerified bool   `json:"phone_verified"`
+}
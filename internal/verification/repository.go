@@ -0,0 +1,74 @@
+// File: internal/verification/repository.go
+package verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for phone verification data operations.
+type Repository interface {
+	Create(ctx context.Context, v *PhoneVerification) error
+	FindLatestByUser(ctx context.Context, userID uuid.UUID) (*PhoneVerification, error)
+	CountSentSince(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error)
+	Update(ctx context.Context, v *PhoneVerification) error
+}
+
+// GORMRepository implements the verification Repository interface using GORM.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+// NewGORMRepository creates a new GORM verification repository.
+func NewGORMRepository(db *gorm.DB) Repository {
+	return &GORMRepository{db: db}
+}
+
+// Create inserts a new verification.
+func (r *GORMRepository) Create(ctx context.Context, v *PhoneVerification) error {
+	if err := r.db.WithContext(ctx).Create(v).Error; err != nil {
+		return fmt.Errorf("failed to create phone verification: %w", err)
+	}
+	return nil
+}
+
+// FindLatestByUser retrieves the most recently sent verification for a user.
+func (r *GORMRepository) FindLatestByUser(ctx context.Context, userID uuid.UUID) (*PhoneVerification, error) {
+	var v PhoneVerification
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").First(&v).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("No verification code has been requested.")
+		}
+		return nil, fmt.Errorf("failed to find phone verification: %w", err)
+	}
+	return &v, nil
+}
+
+// CountSentSince counts the codes sent to a user since the given time.
+func (r *GORMRepository) CountSentSince(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&PhoneVerification{}).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count phone verifications: %w", err)
+	}
+	return count, nil
+}
+
+// Update saves the attempt counter and verification time.
+func (r *GORMRepository) Update(ctx context.Context, v *PhoneVerification) error {
+	err := r.db.WithContext(ctx).Model(v).Select("attempts", "verified_at").Updates(v).Error
+	if err != nil {
+		return fmt.Errorf("failed to update phone verification: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+// File: internal/verification/service.go
+package verification
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/shared"
+	"seattle_info_backend/internal/user"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// codeDigits is the length of the numeric code sent by SMS.
+	codeDigits = 6
+	// sendWindow is the period over which PHONE_VERIFICATION_MAX_SENDS applies.
+	sendWindow = time.Hour
+)
+
+// Service defines the interface for phone number verification.
+type Service interface {
+	RequestCode(ctx context.Context, userID uuid.UUID, phoneNumber string) (*PhoneVerification, error)
+	ConfirmCode(ctx context.Context, userID uuid.UUID, code string) (*user.User, error)
+}
+
+// ServiceImplementation implements the verification Service interface.
+type ServiceImplementation struct {
+	repo     Repository
+	userRepo user.Repository
+	sms      SMSSender
+	cfg      *config.Config
+	logger   *zap.Logger
+	now      func() time.Time
+}
+
+// NewService creates a new verification service.
+func NewService(repo Repository, userRepo user.Repository, sms SMSSender, cfg *config.Config, logger *zap.Logger) Service {
+	return &ServiceImplementation{
+		repo:     repo,
+		userRepo: userRepo,
+		sms:      sms,
+		cfg:      cfg,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// RequestCode sends a new code to phoneNumber. Codes sent earlier stop being accepted.
+func (s *ServiceImplementation) RequestCode(ctx context.Context, userID uuid.UUID, phoneNumber string) (*PhoneVerification, error) {
+	now := s.now()
+	sent, err := s.repo.CountSentSince(ctx, userID, now.Add(-sendWindow))
+	if err != nil {
+		s.logger.Error("Failed to count phone verifications", zap.String("userID", userID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not send verification code.")
+	}
+	if sent >= int64(s.cfg.PhoneVerificationMaxSends) {
+		return nil, common.ErrTooManyRequests.WithDetails("Too many verification codes requested. Please try again later.")
+	}
+
+	code, err := generateCode()
+	if err != nil {
+		s.logger.Error("Failed to generate verification code", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not send verification code.")
+	}
+	v := &PhoneVerification{
+		ID:          uuid.New(),
+		UserID:      userID,
+		PhoneNumber: phoneNumber,
+		ExpiresAt:   now.Add(s.cfg.PhoneVerificationCodeTTL),
+		CreatedAt:   now,
+	}
+	v.CodeHash = hashCode(v.ID, code)
+	if err := s.repo.Create(ctx, v); err != nil {
+		s.logger.Error("Failed to store phone verification", zap.String("userID", userID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not send verification code.")
+	}
+
+	body := fmt.Sprintf("Your Seattle Info verification code is %s. It expires in %d minutes.",
+		code, int(s.cfg.PhoneVerificationCodeTTL.Minutes()))
+	if err := s.sms.Send(ctx, phoneNumber, body); err != nil {
+		s.logger.Error("Failed to send verification SMS", zap.String("userID", userID.String()), zap.Error(err))
+		return nil, common.ErrServiceUnavailable.WithDetails("Could not send the verification SMS. Please try again later.")
+	}
+	return v, nil
+}
+
+// ConfirmCode checks code against the user's latest verification and, if it matches,
+// marks the phone number as verified on the user.
+func (s *ServiceImplementation) ConfirmCode(ctx context.Context, userID uuid.UUID, code string) (*user.User, error) {
+	v, err := s.repo.FindLatestByUser(ctx, userID)
+	if err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to find phone verification", zap.String("userID", userID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not verify code.")
+	}
+
+	now := s.now()
+	if !v.IsOpen(now, s.cfg.PhoneVerificationMaxAttempts) {
+		return nil, common.ErrBadRequest.WithDetails("The verification code has expired. Please request a new one.")
+	}
+	if subtle.ConstantTimeCompare([]byte(hashCode(v.ID, code)), []byte(v.CodeHash)) != 1 {
+		v.Attempts++
+		if err := s.repo.Update(ctx, v); err != nil {
+			s.logger.Error("Failed to record verification attempt", zap.String("userID", userID.String()), zap.Error(err))
+			return nil, common.ErrInternalServer.WithDetails("Could not verify code.")
+		}
+		return nil, common.ErrBadRequest.WithDetails("The verification code is incorrect.")
+	}
+
+	v.VerifiedAt = &now
+	if err := s.repo.Update(ctx, v); err != nil {
+		s.logger.Error("Failed to complete phone verification", zap.String("userID", userID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not verify code.")
+	}
+
+	u, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to load user for phone verification", zap.String("userID", userID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not verify code.")
+	}
+	phone := v.PhoneNumber
+	u.PhoneNumber = &phone
+	u.PhoneVerified = true
+	if err := s.userRepo.Update(ctx, u); err != nil {
+		s.logger.Error("Failed to mark phone as verified", zap.String("userID", userID.String()), zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not verify code.")
+	}
+	if err := s.userRepo.CompleteOnboardingStep(ctx, userID, shared.OnboardingPhoneVerified); err != nil {
+		s.logger.Warn("Failed to record onboarding step", zap.String("userID", userID.String()), zap.Error(err))
+	}
+	s.logger.Info("Phone number verified", zap.String("userID", userID.String()))
+	return u, nil
+}
+
+// generateCode returns a random numeric code of codeDigits digits.
+func generateCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < codeDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", codeDigits, n), nil
+}
+
+// hashCode returns the hex SHA-256 digest stored in place of the code. The verification ID acts as a salt.
+func hashCode(id uuid.UUID, code string) string {
+	sum := sha256.Sum256([]byte(id.String() + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,156 @@
+package verification
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/shared"
+	"seattle_info_backend/internal/user"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeRepo struct {
+	items []*PhoneVerification
+}
+
+func (r *fakeRepo) Create(_ context.Context, v *PhoneVerification) error {
+	r.items = append(r.items, v)
+	return nil
+}
+
+func (r *fakeRepo) FindLatestByUser(_ context.Context, userID uuid.UUID) (*PhoneVerification, error) {
+	for i := len(r.items) - 1; i >= 0; i-- {
+		if r.items[i].UserID == userID {
+			return r.items[i], nil
+		}
+	}
+	return nil, common.ErrNotFound.WithDetails("No verification code has been requested.")
+}
+
+func (r *fakeRepo) CountSentSince(_ context.Context, userID uuid.UUID, since time.Time) (int64, error) {
+	var n int64
+	for _, v := range r.items {
+		if v.UserID == userID && !v.CreatedAt.Before(since) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeRepo) Update(context.Context, *PhoneVerification) error { return nil }
+
+type fakeUserRepo struct {
+	user.Repository
+	u          *user.User
+	onboarding []shared.OnboardingStep
+}
+
+func (r *fakeUserRepo) FindByID(context.Context, uuid.UUID) (*user.User, error) { return r.u, nil }
+func (r *fakeUserRepo) Update(_ context.Context, u *user.User) error            { r.u = u; return nil }
+func (r *fakeUserRepo) CompleteOnboardingStep(_ context.Context, _ uuid.UUID, step shared.OnboardingStep) error {
+	r.onboarding = append(r.onboarding, step)
+	return nil
+}
+
+type fakeSMS struct {
+	to, body string
+}
+
+func (s *fakeSMS) Send(_ context.Context, to, body string) error {
+	s.to, s.body = to, body
+	return nil
+}
+
+var codePattern = regexp.MustCompile(`\b\d{6}\b`)
+
+func newTestService(u *user.User) (*ServiceImplementation, *fakeSMS) {
+	sms := &fakeSMS{}
+	cfg := &config.Config{
+		PhoneVerificationCodeTTL:     10 * time.Minute,
+		PhoneVerificationMaxAttempts: 3,
+		PhoneVerificationMaxSends:    2,
+	}
+	svc := NewService(&fakeRepo{}, &fakeUserRepo{u: u}, sms, cfg, zap.NewNop()).(*ServiceImplementation)
+	return svc, sms
+}
+
+func TestConfirmCodeMarksPhoneVerified(t *testing.T) {
+	u := &user.User{}
+	u.ID = uuid.New()
+	svc, sms := newTestService(u)
+
+	v, err := svc.RequestCode(context.Background(), u.ID, "+12065550100")
+	require.NoError(t, err)
+	assert.Equal(t, "+12065550100", sms.to)
+	code := codePattern.FindString(sms.body)
+	require.NotEmpty(t, code)
+	assert.NotContains(t, v.CodeHash, code, "only the hash of the code is stored")
+
+	got, err := svc.ConfirmCode(context.Background(), u.ID, code)
+	require.NoError(t, err)
+	assert.True(t, got.PhoneVerified)
+	require.NotNil(t, got.PhoneNumber)
+	assert.Equal(t, "+12065550100", *got.PhoneNumber)
+	assert.Equal(t, []shared.OnboardingStep{shared.OnboardingPhoneVerified}, svc.userRepo.(*fakeUserRepo).onboarding)
+
+	_, err = svc.ConfirmCode(context.Background(), u.ID, code)
+	assert.Error(t, err, "a code can only be used once")
+}
+
+func TestConfirmCodeLocksAfterMaxAttempts(t *testing.T) {
+	u := &user.User{}
+	u.ID = uuid.New()
+	svc, sms := newTestService(u)
+
+	_, err := svc.RequestCode(context.Background(), u.ID, "+12065550100")
+	require.NoError(t, err)
+	code := codePattern.FindString(sms.body)
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err = svc.ConfirmCode(context.Background(), u.ID, wrong)
+		assert.Error(t, err)
+	}
+	_, err = svc.ConfirmCode(context.Background(), u.ID, code)
+	assert.Error(t, err, "the right code is rejected once the attempts are used up")
+	assert.False(t, u.PhoneVerified)
+}
+
+func TestConfirmCodeRejectsExpiredCode(t *testing.T) {
+	u := &user.User{}
+	u.ID = uuid.New()
+	svc, sms := newTestService(u)
+
+	_, err := svc.RequestCode(context.Background(), u.ID, "+12065550100")
+	require.NoError(t, err)
+	svc.now = func() time.Time { return time.Now().Add(11 * time.Minute) }
+
+	_, err = svc.ConfirmCode(context.Background(), u.ID, codePattern.FindString(sms.body))
+	assert.Error(t, err)
+	assert.False(t, u.PhoneVerified)
+}
+
+func TestRequestCodeIsRateLimited(t *testing.T) {
+	userID := uuid.New()
+	svc, _ := newTestService(&user.User{})
+
+	for i := 0; i < 2; i++ {
+		_, err := svc.RequestCode(context.Background(), userID, "+12065550100")
+		require.NoError(t, err)
+	}
+	_, err := svc.RequestCode(context.Background(), userID, "+12065550100")
+	var apiErr *common.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, common.ErrTooManyRequests.Code, apiErr.Code)
+}
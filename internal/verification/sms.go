@@ -0,0 +1,90 @@
+// File: internal/verification/sms.go
+package verification
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/config"
+
+	"go.uber.org/zap"
+)
+
+// SMSSender delivers a text message to a phone number in E.164 format.
+type SMSSender interface {
+	Send(ctx context.Context, to, body string) error
+}
+
+// TwilioSender sends messages through the Twilio Messages API, or any API compatible with it.
+type TwilioSender struct {
+	baseURL    string
+	accountSID string
+	authToken  string
+	from       string
+	client     *http.Client
+}
+
+// NewTwilioSender creates a TwilioSender. baseURL is normally https://api.twilio.com.
+func NewTwilioSender(baseURL, accountSID, authToken, from string, timeout time.Duration) *TwilioSender {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &TwilioSender{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		accountSID: accountSID,
+		authToken:  authToken,
+		from:       from,
+		client:     &http.Client{Timeout: timeout},
+	}
+}
+
+// Send implements SMSSender.
+func (s *TwilioSender) Send(ctx context.Context, to, body string) error {
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", s.baseURL, url.PathEscape(s.accountSID))
+	form := url.Values{"To": {to}, "From": {s.from}, "Body": {body}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to build SMS request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(s.accountSID, s.authToken)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("SMS API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("SMS API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// LogSender writes messages to the log instead of sending them. It is used when no SMS provider is
+// configured, so that verification can be exercised in local development.
+type LogSender struct {
+	logger *zap.Logger
+}
+
+// Send implements SMSSender.
+func (s *LogSender) Send(_ context.Context, to, body string) error {
+	s.logger.Warn("SMS provider not configured; message not sent", zap.String("to", to), zap.String("body", body))
+	return nil
+}
+
+// NewSMSSender returns a TwilioSender when SMS_ACCOUNT_SID is set, and a LogSender otherwise.
+func NewSMSSender(cfg *config.Config, logger *zap.Logger) SMSSender {
+	log := logger.Named("SMS")
+	if cfg.SMSAccountSID == "" {
+		log.Warn("SMS_ACCOUNT_SID is not set; verification codes will only be logged")
+		return &LogSender{logger: log}
+	}
+	timeout := time.Duration(cfg.SMSTimeoutSeconds) * time.Second
+	return NewTwilioSender(cfg.SMSAPIBaseURL, cfg.SMSAccountSID, cfg.SMSAuthToken, cfg.SMSFromNumber, timeout)
+}
@@ -0,0 +1,97 @@
+// File: internal/virusscan/clamav.go
+package virusscan
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamAVChunkSize is the size of the chunks a file is streamed to clamd in.
+const clamAVChunkSize = 64 << 10
+
+// ClamAV scans files with a clamd daemon, streaming them over its INSTREAM command.
+type ClamAV struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamAV creates a ClamAV scanner. address is host:port for TCP, or unix:/path for a Unix socket.
+func NewClamAV(address string, timeout time.Duration) *ClamAV {
+	if timeout <= 0 {
+		timeout = time.Minute
+	}
+	network := "tcp"
+	if strings.HasPrefix(address, "unix:") {
+		network, address = "unix", strings.TrimPrefix(address, "unix:")
+	}
+	return &ClamAV{network: network, address: address, timeout: timeout}
+}
+
+// Scan implements Scanner.
+func (c *ClamAV) Scan(ctx context.Context, r io.Reader) (*Result, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+	deadline := time.Now().Add(c.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, fmt.Errorf("failed to set clamd deadline: %w", err)
+	}
+
+	// Each chunk is prefixed with its length as a 4-byte big-endian integer; a zero length ends the stream.
+	if _, err := io.WriteString(conn, "zINSTREAM\x00"); err != nil {
+		return nil, fmt.Errorf("failed to send clamd command: %w", err)
+	}
+	chunk := make([]byte, 4+clamAVChunkSize)
+	for {
+		n, readErr := r.Read(chunk[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(chunk, uint32(n))
+			if _, err := conn.Write(chunk[:4+n]); err != nil {
+				return nil, fmt.Errorf("failed to stream file to clamd: %w", err)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read file to scan: %w", readErr)
+		}
+	}
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return nil, fmt.Errorf("failed to stream file to clamd: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && reply == "" {
+		return nil, fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+	return parseClamAVReply(reply)
+}
+
+// parseClamAVReply interprets clamd's answer to INSTREAM: "stream: OK", "stream: <signature> FOUND"
+// or "<message> ERROR".
+func parseClamAVReply(reply string) (*Result, error) {
+	reply = strings.TrimSpace(strings.TrimRight(reply, "\x00"))
+	status := strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case status == "OK":
+		return &Result{}, nil
+	case strings.HasSuffix(status, " FOUND"):
+		return &Result{Infected: true, Signature: strings.TrimSuffix(status, " FOUND")}, nil
+	default:
+		return nil, fmt.Errorf("clamd could not scan the file: %s", reply)
+	}
+}
@@ -0,0 +1,78 @@
+package virusscan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClamd accepts one INSTREAM scan and reports a signature when the streamed bytes contain "EICAR".
+func fakeClamd(t *testing.T) (string, <-chan []byte) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		if command, err := r.ReadString(0); err != nil || command != "zINSTREAM\x00" {
+			io.WriteString(conn, "UNKNOWN COMMAND\x00")
+			return
+		}
+		var data bytes.Buffer
+		for {
+			var size uint32
+			if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+				return
+			}
+			if size == 0 {
+				break
+			}
+			if _, err := io.CopyN(&data, r, int64(size)); err != nil {
+				return
+			}
+		}
+		received <- data.Bytes()
+		if bytes.Contains(data.Bytes(), []byte("EICAR")) {
+			io.WriteString(conn, "stream: Eicar-Test-Signature FOUND\x00")
+			return
+		}
+		io.WriteString(conn, "stream: OK\x00")
+	}()
+	return listener.Addr().String(), received
+}
+
+func TestClamAVScan(t *testing.T) {
+	address, received := fakeClamd(t)
+	clean := strings.Repeat("a", clamAVChunkSize+10) // Sent in two chunks
+	result, err := NewClamAV(address, time.Second).Scan(context.Background(), strings.NewReader(clean))
+	require.NoError(t, err)
+	assert.False(t, result.Infected)
+	assert.Equal(t, clean, string(<-received))
+
+	address, _ = fakeClamd(t)
+	result, err = NewClamAV(address, time.Second).Scan(context.Background(), strings.NewReader("X5O!P%@AP EICAR test file"))
+	require.NoError(t, err)
+	assert.True(t, result.Infected)
+	assert.Equal(t, "Eicar-Test-Signature", result.Signature)
+}
+
+func TestParseClamAVReply(t *testing.T) {
+	_, err := parseClamAVReply("INSTREAM size limit exceeded. ERROR\x00")
+	assert.Error(t, err)
+	_, err = parseClamAVReply("")
+	assert.Error(t, err)
+}
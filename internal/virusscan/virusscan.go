@@ -0,0 +1,33 @@
+// File: internal/virusscan/virusscan.go
+package virusscan
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"seattle_info_backend/internal/config"
+
+	"go.uber.org/zap"
+)
+
+// Result is the outcome of scanning a file.
+type Result struct {
+	Infected  bool
+	Signature string // Name of the malware found, e.g. "Eicar-Test-Signature"
+}
+
+// Scanner checks uploaded files for viruses and other malware.
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (*Result, error)
+}
+
+// NewScanner builds the scanner from configuration: clamd at CLAMAV_ADDRESS, or nil when it is not set,
+// which disables scanning.
+func NewScanner(cfg *config.Config, logger *zap.Logger) Scanner {
+	if cfg.ClamAVAddress == "" {
+		return nil
+	}
+	logger.Named("VirusScan").Info("ClamAV virus scanning enabled", zap.String("address", cfg.ClamAVAddress))
+	return NewClamAV(cfg.ClamAVAddress, time.Duration(cfg.VirusScanTimeoutSeconds)*time.Second)
+}
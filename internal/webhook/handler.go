@@ -0,0 +1,143 @@
+// File: internal/webhook/handler.go
+package webhook
+
+import (
+	"seattle_info_backend/internal/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Handler struct holds dependencies for webhook admin handlers.
+type Handler struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewHandler creates a new webhook handler.
+func NewHandler(service Service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes sets up the admin-only routes for managing webhook endpoints.
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMW gin.HandlerFunc, adminRoleMW gin.HandlerFunc) {
+	adminWebhookGroup := router.Group("/webhooks/admin")
+	adminWebhookGroup.Use(authMW)
+	adminWebhookGroup.Use(adminRoleMW)
+	{
+		adminWebhookGroup.POST("", h.adminCreateEndpoint)
+		adminWebhookGroup.GET("", h.adminListEndpoints)
+		adminWebhookGroup.GET("/:id", h.adminGetEndpoint)
+		adminWebhookGroup.PUT("/:id", h.adminUpdateEndpoint)
+		adminWebhookGroup.DELETE("/:id", h.adminDeleteEndpoint)
+		adminWebhookGroup.GET("/:id/deliveries", h.adminListDeliveries)
+	}
+}
+
+func (h *Handler) adminCreateEndpoint(c *gin.Context) {
+	adminID := common.GetUserIDFromContext(c)
+	if adminID == uuid.Nil {
+		common.RespondWithError(c, common.ErrUnauthorized.WithDetails("User not authenticated."))
+		return
+	}
+
+	var req AdminCreateEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Admin create webhook: Invalid request body", zap.Error(err))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+
+	endpoint, err := h.service.CreateEndpoint(c.Request.Context(), adminID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondCreated(c, "Webhook endpoint registered successfully. Store the secret now; it cannot be shown again.", CreatedEndpointResponse{
+		EndpointResponse: ToEndpointResponse(endpoint),
+		Secret:           endpoint.Secret,
+	})
+}
+
+func (h *Handler) adminListEndpoints(c *gin.Context) {
+	endpoints, err := h.service.ListEndpoints(c.Request.Context())
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]EndpointResponse, len(endpoints))
+	for i := range endpoints {
+		responses[i] = ToEndpointResponse(&endpoints[i])
+	}
+	common.RespondOK(c, "Webhook endpoints retrieved successfully.", responses)
+}
+
+func (h *Handler) adminGetEndpoint(c *gin.Context) {
+	endpointID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid webhook endpoint ID format."))
+		return
+	}
+	endpoint, err := h.service.GetEndpoint(c.Request.Context(), endpointID)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Webhook endpoint retrieved successfully.", ToEndpointResponse(endpoint))
+}
+
+func (h *Handler) adminUpdateEndpoint(c *gin.Context) {
+	endpointID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid webhook endpoint ID format."))
+		return
+	}
+	var req AdminUpdateEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Admin update webhook: Invalid request body", zap.Error(err), zap.String("endpointID", endpointID.String()))
+		common.RespondWithError(c, common.BindingError(err))
+		return
+	}
+	endpoint, err := h.service.UpdateEndpoint(c.Request.Context(), endpointID, req)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondOK(c, "Webhook endpoint updated successfully.", ToEndpointResponse(endpoint))
+}
+
+func (h *Handler) adminDeleteEndpoint(c *gin.Context) {
+	endpointID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid webhook endpoint ID format."))
+		return
+	}
+	if err := h.service.DeleteEndpoint(c.Request.Context(), endpointID); err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	common.RespondNoContent(c)
+}
+
+func (h *Handler) adminListDeliveries(c *gin.Context) {
+	endpointID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.RespondWithError(c, common.ErrBadRequest.WithDetails("Invalid webhook endpoint ID format."))
+		return
+	}
+	page, pageSize := common.GetPaginationParams(c)
+	deliveries, pagination, err := h.service.ListDeliveries(c.Request.Context(), endpointID, page, pageSize)
+	if err != nil {
+		common.RespondWithError(c, err)
+		return
+	}
+	responses := make([]DeliveryResponse, len(deliveries))
+	for i := range deliveries {
+		responses[i] = ToDeliveryResponse(&deliveries[i])
+	}
+	common.RespondPaginated(c, "Webhook deliveries retrieved successfully.", responses, pagination)
+}
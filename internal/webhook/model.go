@@ -0,0 +1,146 @@
+// File: internal/webhook/model.go
+package webhook
+
+import (
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Event names delivered to webhook endpoints.
+const (
+	EventListingCreated  = "listing.created"
+	EventListingApproved = "listing.approved"
+	EventListingExpired  = "listing.expired"
+)
+
+// validEvents lists every event an endpoint may subscribe to.
+var validEvents = map[string]bool{
+	EventListingCreated:  true,
+	EventListingApproved: true,
+	EventListingExpired:  true,
+}
+
+// DeliveryStatus is the state of a single webhook delivery.
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// Endpoint is an HTTPS URL registered to receive signed event notifications.
+type Endpoint struct {
+	common.BaseModel
+	URL       string         `gorm:"type:text;not null"`
+	Secret    string         `gorm:"type:varchar(100);not null"` // HMAC signing key shared with the receiver
+	Events    pq.StringArray `gorm:"type:text[];not null"`
+	IsActive  bool           `gorm:"not null;default:true"`
+	CreatedBy *uuid.UUID     `gorm:"type:uuid"`
+}
+
+// TableName specifies the table name for GORM.
+func (Endpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+// Delivery is one attempt-tracked send of an event to an endpoint.
+type Delivery struct {
+	common.BaseModel
+	EndpointID     uuid.UUID      `gorm:"type:uuid;not null"`
+	Endpoint       *Endpoint      `gorm:"foreignKey:EndpointID;references:ID;constraint:OnDelete:CASCADE;"`
+	Event          string         `gorm:"type:varchar(100);not null"`
+	Payload        string         `gorm:"type:jsonb;not null"`
+	Status         DeliveryStatus `gorm:"type:varchar(20);not null;default:'pending'"`
+	Attempts       int            `gorm:"not null;default:0"`
+	NextAttemptAt  *time.Time
+	LastAttemptAt  *time.Time
+	ResponseStatus *int
+	LastError      *string `gorm:"type:text"`
+}
+
+// TableName specifies the table name for GORM.
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// --- Request DTOs ---
+
+// AdminCreateEndpointRequest is the payload for registering an endpoint.
+type AdminCreateEndpointRequest struct {
+	URL    string   `json:"url" binding:"required,url,max=2000"`
+	Events []string `json:"events" binding:"required,min=1,dive,required"`
+}
+
+// AdminUpdateEndpointRequest is the payload for changing an endpoint.
+type AdminUpdateEndpointRequest struct {
+	URL      *string  `json:"url,omitempty" binding:"omitempty,url,max=2000"`
+	Events   []string `json:"events,omitempty" binding:"omitempty,min=1,dive,required"`
+	IsActive *bool    `json:"is_active,omitempty"`
+}
+
+// --- Response DTOs ---
+
+// EndpointResponse is the API representation of an endpoint. The secret is only shown on creation.
+type EndpointResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	URL       string     `json:"url"`
+	Events    []string   `json:"events"`
+	IsActive  bool       `json:"is_active"`
+	CreatedBy *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// CreatedEndpointResponse is returned once, when an endpoint is registered, and carries the signing secret.
+type CreatedEndpointResponse struct {
+	EndpointResponse
+	Secret string `json:"secret"`
+}
+
+// DeliveryResponse is the API representation of a delivery log entry.
+type DeliveryResponse struct {
+	ID             uuid.UUID      `json:"id"`
+	EndpointID     uuid.UUID      `json:"endpoint_id"`
+	Event          string         `json:"event"`
+	Status         DeliveryStatus `json:"status"`
+	Attempts       int            `json:"attempts"`
+	NextAttemptAt  *time.Time     `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time     `json:"last_attempt_at,omitempty"`
+	ResponseStatus *int           `json:"response_status,omitempty"`
+	LastError      *string        `json:"last_error,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+// ToEndpointResponse converts an Endpoint model to an EndpointResponse DTO.
+func ToEndpointResponse(e *Endpoint) EndpointResponse {
+	return EndpointResponse{
+		ID:        e.ID,
+		URL:       e.URL,
+		Events:    e.Events,
+		IsActive:  e.IsActive,
+		CreatedBy: e.CreatedBy,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+// ToDeliveryResponse converts a Delivery model to a DeliveryResponse DTO.
+func ToDeliveryResponse(d *Delivery) DeliveryResponse {
+	return DeliveryResponse{
+		ID:             d.ID,
+		EndpointID:     d.EndpointID,
+		Event:          d.Event,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastAttemptAt:  d.LastAttemptAt,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+	}
+}
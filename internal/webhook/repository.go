@@ -0,0 +1,170 @@
+// File: internal/webhook/repository.go
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"seattle_info_backend/internal/common"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for webhook endpoint and delivery data operations.
+type Repository interface {
+	CreateEndpoint(ctx context.Context, endpoint *Endpoint) error
+	FindEndpointByID(ctx context.Context, id uuid.UUID) (*Endpoint, error)
+	FindEndpoints(ctx context.Context) ([]Endpoint, error)
+	FindActiveEndpointsForEvent(ctx context.Context, event string) ([]Endpoint, error)
+	UpdateEndpoint(ctx context.Context, endpoint *Endpoint) error
+	DeleteEndpoint(ctx context.Context, id uuid.UUID) error
+
+	CreateDeliveries(ctx context.Context, deliveries []Delivery) error
+	FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]Delivery, error)
+	FindDeliveryByID(ctx context.Context, id uuid.UUID) (*Delivery, error)
+	FindDeliveriesByEndpoint(ctx context.Context, endpointID uuid.UUID, page, pageSize int) ([]Delivery, *common.Pagination, error)
+	UpdateDelivery(ctx context.Context, delivery *Delivery) error
+}
+
+// GORMRepository implements the webhook Repository interface using GORM.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+// NewGORMRepository creates a new GORM webhook repository.
+func NewGORMRepository(db *gorm.DB) Repository {
+	return &GORMRepository{db: db}
+}
+
+// CreateEndpoint inserts a new endpoint.
+func (r *GORMRepository) CreateEndpoint(ctx context.Context, endpoint *Endpoint) error {
+	if err := r.db.WithContext(ctx).Create(endpoint).Error; err != nil {
+		return fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+	return nil
+}
+
+// FindEndpointByID retrieves an endpoint by its ID.
+func (r *GORMRepository) FindEndpointByID(ctx context.Context, id uuid.UUID) (*Endpoint, error) {
+	var endpoint Endpoint
+	if err := r.db.WithContext(ctx).First(&endpoint, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Webhook endpoint not found.")
+		}
+		return nil, fmt.Errorf("failed to find webhook endpoint: %w", err)
+	}
+	return &endpoint, nil
+}
+
+// FindEndpoints retrieves all endpoints, newest first.
+func (r *GORMRepository) FindEndpoints(ctx context.Context) ([]Endpoint, error) {
+	var endpoints []Endpoint
+	if err := r.db.WithContext(ctx).Order("created_at DESC").Find(&endpoints).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+	return endpoints, nil
+}
+
+// FindActiveEndpointsForEvent retrieves active endpoints subscribed to event.
+func (r *GORMRepository) FindActiveEndpointsForEvent(ctx context.Context, event string) ([]Endpoint, error) {
+	var endpoints []Endpoint
+	if err := r.db.WithContext(ctx).
+		Where("is_active = ? AND ? = ANY(events)", true, event).
+		Find(&endpoints).Error; err != nil {
+		return nil, fmt.Errorf("failed to find webhook endpoints for event: %w", err)
+	}
+	return endpoints, nil
+}
+
+// UpdateEndpoint saves changes to an endpoint.
+func (r *GORMRepository) UpdateEndpoint(ctx context.Context, endpoint *Endpoint) error {
+	if err := r.db.WithContext(ctx).Save(endpoint).Error; err != nil {
+		return fmt.Errorf("failed to update webhook endpoint: %w", err)
+	}
+	return nil
+}
+
+// DeleteEndpoint removes an endpoint and, by cascade, its delivery log.
+func (r *GORMRepository) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&Endpoint{}, "id = ?", id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete webhook endpoint: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound.WithDetails("Webhook endpoint not found.")
+	}
+	return nil
+}
+
+// CreateDeliveries enqueues deliveries.
+func (r *GORMRepository) CreateDeliveries(ctx context.Context, deliveries []Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Create(&deliveries).Error; err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// FindDueDeliveries retrieves pending deliveries whose next attempt is due, with their endpoint.
+func (r *GORMRepository) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]Delivery, error) {
+	var deliveries []Delivery
+	if err := r.db.WithContext(ctx).
+		Preload("Endpoint").
+		Where("status = ? AND next_attempt_at <= ?", DeliveryPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&deliveries).Error; err != nil {
+		return nil, fmt.Errorf("failed to find due webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// FindDeliveryByID retrieves a delivery with its endpoint.
+func (r *GORMRepository) FindDeliveryByID(ctx context.Context, id uuid.UUID) (*Delivery, error) {
+	var delivery Delivery
+	if err := r.db.WithContext(ctx).Preload("Endpoint").First(&delivery, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.ErrNotFound.WithDetails("Webhook delivery not found.")
+		}
+		return nil, fmt.Errorf("failed to find webhook delivery: %w", err)
+	}
+	return &delivery, nil
+}
+
+// FindDeliveriesByEndpoint retrieves the delivery log of an endpoint, newest first.
+func (r *GORMRepository) FindDeliveriesByEndpoint(ctx context.Context, endpointID uuid.UUID, page, pageSize int) ([]Delivery, *common.Pagination, error) {
+	var deliveries []Delivery
+	var totalItems int64
+
+	dbQuery := r.db.WithContext(ctx).Model(&Delivery{}).Where("endpoint_id = ?", endpointID)
+	if err := dbQuery.Count(&totalItems).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	offset := (page - 1) * pageSize
+	if err := dbQuery.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&deliveries).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return deliveries, common.NewPagination(totalItems, page, pageSize), nil
+}
+
+// UpdateDelivery records the outcome of a delivery attempt.
+func (r *GORMRepository) UpdateDelivery(ctx context.Context, delivery *Delivery) error {
+	err := r.db.WithContext(ctx).Model(&Delivery{}).Where("id = ?", delivery.ID).Updates(map[string]interface{}{
+		"status":          delivery.Status,
+		"attempts":        delivery.Attempts,
+		"next_attempt_at": delivery.NextAttemptAt,
+		"last_attempt_at": delivery.LastAttemptAt,
+		"response_status": delivery.ResponseStatus,
+		"last_error":      delivery.LastError,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,442 @@
+// File: internal/webhook/service.go
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"seattle_info_backend/internal/common"
+	"seattle_info_backend/internal/config"
+	"seattle_info_backend/internal/platform/crypto"
+	"seattle_info_backend/internal/queue"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// deliveryBatchSize caps how many deliveries one job run sends.
+	deliveryBatchSize = 100
+	// sweepGrace is how overdue a delivery must be before the delivery job sends it. Until then
+	// its queue task is expected to send it.
+	sweepGrace = 5 * time.Minute
+	// baseRetryDelay is the wait before the first retry; it doubles on each further failure.
+	baseRetryDelay = time.Minute
+	// maxRetryDelay caps the backoff between attempts.
+	maxRetryDelay = 6 * time.Hour
+	// maxErrorLength bounds the response/error text stored on a delivery.
+	maxErrorLength = 500
+)
+
+// TaskDeliver is the queue task that makes one attempt of a delivery.
+const TaskDeliver = "webhook.deliver"
+
+// deliverTask is the payload of a TaskDeliver task.
+type deliverTask struct {
+	DeliveryID uuid.UUID `json:"delivery_id"`
+}
+
+// Headers sent with every delivery.
+const (
+	HeaderEvent      = "X-Webhook-Event"
+	HeaderDeliveryID = "X-Webhook-Delivery"
+	HeaderTimestamp  = "X-Webhook-Timestamp"
+	HeaderSignature  = "X-Webhook-Signature"
+)
+
+// Service defines the interface for webhook management and delivery.
+type Service interface {
+	// Admin methods
+	CreateEndpoint(ctx context.Context, adminID uuid.UUID, req AdminCreateEndpointRequest) (*Endpoint, error)
+	ListEndpoints(ctx context.Context) ([]Endpoint, error)
+	GetEndpoint(ctx context.Context, id uuid.UUID) (*Endpoint, error)
+	UpdateEndpoint(ctx context.Context, id uuid.UUID, req AdminUpdateEndpointRequest) (*Endpoint, error)
+	DeleteEndpoint(ctx context.Context, id uuid.UUID) error
+	ListDeliveries(ctx context.Context, endpointID uuid.UUID, page, pageSize int) ([]Delivery, *common.Pagination, error)
+
+	// Dispatch enqueues event for every active endpoint subscribed to it.
+	Dispatch(ctx context.Context, event string, data interface{})
+	// HandleDeliverTask makes one attempt of a delivery (the queue.HandlerFunc for TaskDeliver).
+	HandleDeliverTask(ctx context.Context, payload []byte) error
+	// ProcessDueDeliveries sends overdue deliveries whose queue task was lost (called by the delivery job).
+	ProcessDueDeliveries(ctx context.Context) (int, error)
+}
+
+// ServiceImplementation implements the webhook Service interface.
+type ServiceImplementation struct {
+	repo   Repository
+	tasks  queue.Service
+	cfg    *config.Config
+	logger *zap.Logger
+	client *http.Client
+}
+
+// NewService creates a new webhook service. Delivery attempts are run as tasks on the queue.
+func NewService(repo Repository, tasks queue.Service, cfg *config.Config, logger *zap.Logger) Service {
+	timeout := time.Duration(cfg.WebhookTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &ServiceImplementation{
+		repo:   repo,
+		tasks:  tasks,
+		cfg:    cfg,
+		logger: logger,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// --- Admin Methods ---
+
+// CreateEndpoint registers a new HTTPS endpoint and generates its signing secret.
+func (s *ServiceImplementation) CreateEndpoint(ctx context.Context, adminID uuid.UUID, req AdminCreateEndpointRequest) (*Endpoint, error) {
+	if err := validateEndpointURL(req.URL); err != nil {
+		return nil, err
+	}
+	if err := validateEvents(req.Events); err != nil {
+		return nil, err
+	}
+
+	secret, err := crypto.GenerateSecureRandomString(32)
+	if err != nil {
+		s.logger.Error("Failed to generate webhook secret", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not generate webhook secret.")
+	}
+
+	endpoint := &Endpoint{
+		URL:       req.URL,
+		Secret:    "whsec_" + strings.TrimRight(secret, "="),
+		Events:    req.Events,
+		IsActive:  true,
+		CreatedBy: &adminID,
+	}
+	if err := s.repo.CreateEndpoint(ctx, endpoint); err != nil {
+		s.logger.Error("Failed to create webhook endpoint", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not create webhook endpoint.")
+	}
+	s.logger.Info("Webhook endpoint registered", zap.String("endpointID", endpoint.ID.String()), zap.String("url", endpoint.URL))
+	return endpoint, nil
+}
+
+// ListEndpoints returns all registered endpoints.
+func (s *ServiceImplementation) ListEndpoints(ctx context.Context) ([]Endpoint, error) {
+	endpoints, err := s.repo.FindEndpoints(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list webhook endpoints", zap.Error(err))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve webhook endpoints.")
+	}
+	return endpoints, nil
+}
+
+// GetEndpoint returns a single endpoint.
+func (s *ServiceImplementation) GetEndpoint(ctx context.Context, id uuid.UUID) (*Endpoint, error) {
+	endpoint, err := s.repo.FindEndpointByID(ctx, id)
+	if err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to get webhook endpoint", zap.Error(err), zap.String("endpointID", id.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not retrieve webhook endpoint.")
+	}
+	return endpoint, nil
+}
+
+// UpdateEndpoint changes the URL, subscribed events, or active flag of an endpoint.
+func (s *ServiceImplementation) UpdateEndpoint(ctx context.Context, id uuid.UUID, req AdminUpdateEndpointRequest) (*Endpoint, error) {
+	endpoint, err := s.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if err := validateEndpointURL(*req.URL); err != nil {
+			return nil, err
+		}
+		endpoint.URL = *req.URL
+	}
+	if req.Events != nil {
+		if err := validateEvents(req.Events); err != nil {
+			return nil, err
+		}
+		endpoint.Events = req.Events
+	}
+	if req.IsActive != nil {
+		endpoint.IsActive = *req.IsActive
+	}
+
+	if err := s.repo.UpdateEndpoint(ctx, endpoint); err != nil {
+		s.logger.Error("Failed to update webhook endpoint", zap.Error(err), zap.String("endpointID", id.String()))
+		return nil, common.ErrInternalServer.WithDetails("Could not update webhook endpoint.")
+	}
+	return endpoint, nil
+}
+
+// DeleteEndpoint removes an endpoint and its delivery log.
+func (s *ServiceImplementation) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.DeleteEndpoint(ctx, id); err != nil {
+		if _, ok := err.(*common.APIError); ok {
+			return err
+		}
+		s.logger.Error("Failed to delete webhook endpoint", zap.Error(err), zap.String("endpointID", id.String()))
+		return common.ErrInternalServer.WithDetails("Could not delete webhook endpoint.")
+	}
+	return nil
+}
+
+// ListDeliveries returns the delivery log of an endpoint.
+func (s *ServiceImplementation) ListDeliveries(ctx context.Context, endpointID uuid.UUID, page, pageSize int) ([]Delivery, *common.Pagination, error) {
+	if _, err := s.GetEndpoint(ctx, endpointID); err != nil {
+		return nil, nil, err
+	}
+	deliveries, pagination, err := s.repo.FindDeliveriesByEndpoint(ctx, endpointID, page, pageSize)
+	if err != nil {
+		s.logger.Error("Failed to list webhook deliveries", zap.Error(err), zap.String("endpointID", endpointID.String()))
+		return nil, nil, common.ErrInternalServer.WithDetails("Could not retrieve webhook deliveries.")
+	}
+	return deliveries, pagination, nil
+}
+
+// --- Delivery ---
+
+// Dispatch enqueues event for every subscribed endpoint. Errors are logged so callers never fail
+// their own operation because of webhook bookkeeping.
+func (s *ServiceImplementation) Dispatch(ctx context.Context, event string, data interface{}) {
+	endpoints, err := s.repo.FindActiveEndpointsForEvent(ctx, event)
+	if err != nil {
+		s.logger.Error("Failed to load webhook endpoints for event", zap.Error(err), zap.String("event", event))
+		return
+	}
+	if len(endpoints) == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	payload, err := json.Marshal(map[string]interface{}{
+		"event":       event,
+		"occurred_at": now,
+		"data":        data,
+	})
+	if err != nil {
+		s.logger.Error("Failed to encode webhook payload", zap.Error(err), zap.String("event", event))
+		return
+	}
+
+	deliveries := make([]Delivery, len(endpoints))
+	for i, endpoint := range endpoints {
+		deliveries[i] = Delivery{
+			BaseModel:     common.BaseModel{ID: uuid.New()},
+			EndpointID:    endpoint.ID,
+			Event:         event,
+			Payload:       string(payload),
+			Status:        DeliveryPending,
+			NextAttemptAt: &now,
+		}
+	}
+	if err := s.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		s.logger.Error("Failed to enqueue webhook deliveries", zap.Error(err), zap.String("event", event))
+		return
+	}
+	for i := range deliveries {
+		s.enqueueAttempt(ctx, &deliveries[i])
+	}
+	s.logger.Debug("Webhook deliveries enqueued", zap.String("event", event), zap.Int("count", len(deliveries)))
+}
+
+// HandleDeliverTask implements Service. Deliveries that are no longer pending, or whose next attempt
+// is not due yet (it was already attempted by the delivery job), are skipped.
+func (s *ServiceImplementation) HandleDeliverTask(ctx context.Context, payload []byte) error {
+	var task deliverTask
+	if err := json.Unmarshal(payload, &task); err != nil {
+		return fmt.Errorf("invalid %s payload: %w", TaskDeliver, err)
+	}
+	d, err := s.repo.FindDeliveryByID(ctx, task.DeliveryID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return nil // Endpoint deleted in the meantime
+		}
+		return err
+	}
+	if d.Status != DeliveryPending || d.NextAttemptAt == nil || d.NextAttemptAt.After(time.Now()) {
+		return nil
+	}
+	s.deliver(ctx, d)
+	return nil
+}
+
+// enqueueAttempt schedules a queue task for the next attempt of d. If that fails the delivery job
+// picks the delivery up once it is overdue.
+func (s *ServiceImplementation) enqueueAttempt(ctx context.Context, d *Delivery) {
+	if s.tasks == nil || d.NextAttemptAt == nil {
+		return
+	}
+	if err := s.tasks.Enqueue(ctx, TaskDeliver, deliverTask{DeliveryID: d.ID}, queue.WithRunAt(*d.NextAttemptAt)); err != nil {
+		s.logger.Warn("Failed to enqueue webhook delivery; the delivery job will retry it", zap.Error(err), zap.String("deliveryID", d.ID.String()))
+	}
+}
+
+// deliver attempts d, records the outcome and schedules the retry, if any.
+func (s *ServiceImplementation) deliver(ctx context.Context, d *Delivery) {
+	s.attempt(ctx, d)
+	if err := s.repo.UpdateDelivery(ctx, d); err != nil {
+		s.logger.Error("Failed to record webhook delivery attempt", zap.Error(err), zap.String("deliveryID", d.ID.String()))
+		return
+	}
+	if d.Status == DeliveryPending {
+		s.enqueueAttempt(ctx, d)
+	}
+}
+
+// ProcessDueDeliveries sends every delivery that is overdue by more than sweepGrace, which happens when
+// its queue task could not be enqueued, and schedules retries for failures.
+// It returns the number of deliveries that succeeded.
+func (s *ServiceImplementation) ProcessDueDeliveries(ctx context.Context) (int, error) {
+	deliveries, err := s.repo.FindDueDeliveries(ctx, time.Now().Add(-sweepGrace), deliveryBatchSize)
+	if err != nil {
+		s.logger.Error("Failed to load due webhook deliveries", zap.Error(err))
+		return 0, err
+	}
+
+	succeeded := 0
+	for i := range deliveries {
+		d := &deliveries[i]
+		s.deliver(ctx, d)
+		if d.Status == DeliverySucceeded {
+			succeeded++
+		}
+	}
+	return succeeded, nil
+}
+
+// attempt sends d once and updates its status, attempt count and next retry time in place.
+func (s *ServiceImplementation) attempt(ctx context.Context, d *Delivery) {
+	now := time.Now()
+	d.Attempts++
+	d.LastAttemptAt = &now
+	d.ResponseStatus = nil
+	d.LastError = nil
+
+	var sendErr error
+	if d.Endpoint == nil || !d.Endpoint.IsActive {
+		sendErr = fmt.Errorf("endpoint is inactive or deleted")
+		d.Attempts = s.maxAttempts() // Do not retry
+	} else {
+		status, err := s.send(ctx, d.Endpoint, d)
+		if status != 0 {
+			d.ResponseStatus = &status
+		}
+		sendErr = err
+	}
+
+	if sendErr == nil {
+		d.Status = DeliverySucceeded
+		d.NextAttemptAt = nil
+		return
+	}
+
+	msg := truncate(sendErr.Error(), maxErrorLength)
+	d.LastError = &msg
+	if d.Attempts >= s.maxAttempts() {
+		d.Status = DeliveryFailed
+		d.NextAttemptAt = nil
+		s.logger.Warn("Webhook delivery failed permanently", zap.String("deliveryID", d.ID.String()), zap.Int("attempts", d.Attempts), zap.String("error", msg))
+		return
+	}
+	next := now.Add(retryDelay(d.Attempts))
+	d.NextAttemptAt = &next
+}
+
+// send POSTs the signed payload and returns the response status code.
+func (s *ServiceImplementation) send(ctx context.Context, endpoint *Endpoint, d *Delivery) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	body := []byte(d.Payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, d.Event)
+	req.Header.Set(HeaderDeliveryID, d.ID.String())
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(endpoint.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorLength))
+		return resp.StatusCode, fmt.Errorf("endpoint responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	return resp.StatusCode, nil
+}
+
+func (s *ServiceImplementation) maxAttempts() int {
+	if s.cfg.WebhookMaxAttempts > 0 {
+		return s.cfg.WebhookMaxAttempts
+	}
+	return 1
+}
+
+// Sign computes the hex HMAC-SHA256 of "<timestamp>.<body>" with secret.
+// Receivers verify deliveries by recomputing it from the X-Webhook-Timestamp header and the raw body.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryDelay returns the exponential backoff after the given number of failed attempts.
+func retryDelay(attempts int) time.Duration {
+	delay := baseRetryDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+	return delay
+}
+
+func validateEndpointURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return common.ErrBadRequest.WithDetails("Invalid webhook URL.")
+	}
+	if u.Scheme != "https" {
+		return common.ErrBadRequest.WithDetails("Webhook URL must use HTTPS.")
+	}
+	return nil
+}
+
+func validateEvents(events []string) error {
+	for _, ev := range events {
+		if !validEvents[ev] {
+			return common.ErrBadRequest.WithDetails(fmt.Sprintf("Unknown webhook event '%s'.", ev))
+		}
+	}
+	return nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"seattle_info_backend/internal/config"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSignIsVerifiableHMAC(t *testing.T) {
+	body := []byte(`{"event":"listing.created"}`)
+	sig := Sign("whsec_test", "1700000000", body)
+	assert.Len(t, sig, 64)
+	assert.Equal(t, sig, Sign("whsec_test", "1700000000", body))
+	assert.NotEqual(t, sig, Sign("whsec_other", "1700000000", body), "signature depends on the secret")
+	assert.NotEqual(t, sig, Sign("whsec_test", "1700000001", body), "signature depends on the timestamp")
+}
+
+func TestRetryDelayBacksOffExponentially(t *testing.T) {
+	assert.Equal(t, time.Minute, retryDelay(1))
+	assert.Equal(t, 2*time.Minute, retryDelay(2))
+	assert.Equal(t, 4*time.Minute, retryDelay(3))
+	assert.Equal(t, maxRetryDelay, retryDelay(20))
+}
+
+func TestAttemptSignsAndSchedulesRetries(t *testing.T) {
+	status := http.StatusInternalServerError
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "sha256="+Sign("whsec_test", r.Header.Get(HeaderTimestamp), body), r.Header.Get(HeaderSignature))
+		assert.Equal(t, EventListingCreated, r.Header.Get(HeaderEvent))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	svc := NewService(nil, nil, &config.Config{WebhookMaxAttempts: 2, WebhookTimeoutSeconds: 5}, zap.NewNop()).(*ServiceImplementation)
+	d := &Delivery{
+		EndpointID: uuid.New(),
+		Endpoint:   &Endpoint{URL: server.URL, Secret: "whsec_test", IsActive: true},
+		Event:      EventListingCreated,
+		Payload:    `{"event":"listing.created"}`,
+		Status:     DeliveryPending,
+	}
+
+	svc.attempt(context.Background(), d)
+	assert.Equal(t, DeliveryPending, d.Status)
+	assert.Equal(t, 1, d.Attempts)
+	require.NotNil(t, d.NextAttemptAt)
+	require.NotNil(t, d.ResponseStatus)
+	assert.Equal(t, http.StatusInternalServerError, *d.ResponseStatus)
+
+	svc.attempt(context.Background(), d)
+	assert.Equal(t, DeliveryFailed, d.Status, "gives up after the configured number of attempts")
+	assert.Nil(t, d.NextAttemptAt)
+
+	status = http.StatusOK
+	d.Status, d.Attempts = DeliveryPending, 0
+	svc.attempt(context.Background(), d)
+	assert.Equal(t, DeliverySucceeded, d.Status)
+	assert.Nil(t, d.LastError)
+}
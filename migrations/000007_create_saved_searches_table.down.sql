@@ -0,0 +1,6 @@
+-- File: migrations/000007_create_saved_searches_table.down.sql
+
+DROP TRIGGER IF EXISTS set_timestamp_saved_searches ON saved_searches;
+DROP INDEX IF EXISTS idx_saved_searches_digest_enabled;
+DROP INDEX IF EXISTS idx_saved_searches_user_id;
+DROP TABLE IF EXISTS saved_searches;
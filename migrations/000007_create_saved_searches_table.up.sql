@@ -0,0 +1,22 @@
+-- File: migrations/000007_create_saved_searches_table.up.sql
+
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(150) NOT NULL,
+    query JSONB NOT NULL DEFAULT '{}'::jsonb, -- Serialized listing search query
+    digest_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    last_digest_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_searches_user_id ON saved_searches(user_id);
+
+-- Partial index for the daily digest job, which only reads digest-enabled searches.
+CREATE INDEX IF NOT EXISTS idx_saved_searches_digest_enabled ON saved_searches(digest_enabled) WHERE digest_enabled = TRUE;
+
+CREATE TRIGGER set_timestamp_saved_searches
+BEFORE UPDATE ON saved_searches
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
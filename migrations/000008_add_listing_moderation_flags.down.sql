@@ -0,0 +1,3 @@
+-- File: migrations/000008_add_listing_moderation_flags.down.sql
+
+ALTER TABLE listings DROP COLUMN IF EXISTS moderation_flags;
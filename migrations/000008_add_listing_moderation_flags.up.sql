@@ -0,0 +1,4 @@
+-- File: migrations/000008_add_listing_moderation_flags.up.sql
+
+-- Reasons a listing was flagged by content moderation; reviewed by admins before approval.
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS moderation_flags TEXT[];
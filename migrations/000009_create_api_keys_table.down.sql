@@ -0,0 +1,5 @@
+-- File: migrations/000009_create_api_keys_table.down.sql
+
+DROP TRIGGER IF EXISTS set_timestamp_api_keys ON api_keys;
+DROP TABLE IF EXISTS api_key_usage;
+DROP TABLE IF EXISTS api_keys;
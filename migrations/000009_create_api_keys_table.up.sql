@@ -0,0 +1,28 @@
+-- File: migrations/000009_create_api_keys_table.up.sql
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(150) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL, -- First characters of the key, shown to admins for identification
+    key_hash VARCHAR(64) NOT NULL UNIQUE, -- SHA-256 hex digest; the plaintext key is never stored
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    rate_limit_per_minute INTEGER NOT NULL DEFAULT 60,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Per-key, per-day request counters for usage accounting.
+CREATE TABLE IF NOT EXISTS api_key_usage (
+    api_key_id UUID NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    usage_date DATE NOT NULL,
+    request_count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (api_key_id, usage_date)
+);
+
+CREATE TRIGGER set_timestamp_api_keys
+BEFORE UPDATE ON api_keys
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
@@ -0,0 +1,6 @@
+-- File: migrations/000010_create_webhooks_tables.down.sql
+
+DROP TRIGGER IF EXISTS set_timestamp_webhook_deliveries ON webhook_deliveries;
+DROP TRIGGER IF EXISTS set_timestamp_webhook_endpoints ON webhook_endpoints;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_endpoints;
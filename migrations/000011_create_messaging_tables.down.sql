@@ -0,0 +1,6 @@
+-- File: migrations/000011_create_messaging_tables.down.sql
+
+DROP TRIGGER IF EXISTS set_timestamp_conversations ON conversations;
+DROP TABLE IF EXISTS user_blocks;
+DROP TABLE IF EXISTS messages;
+DROP TABLE IF EXISTS conversations;
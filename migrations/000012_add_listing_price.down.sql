@@ -0,0 +1,10 @@
+-- File: migrations/000012_add_listing_price.down.sql
+
+DROP INDEX IF EXISTS idx_listings_price_amount;
+
+ALTER TABLE listings
+    DROP CONSTRAINT IF EXISTS chk_listings_price_period,
+    DROP CONSTRAINT IF EXISTS chk_listings_price_amount,
+    DROP COLUMN IF EXISTS price_period,
+    DROP COLUMN IF EXISTS price_currency,
+    DROP COLUMN IF EXISTS price_amount;
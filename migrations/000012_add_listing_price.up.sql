@@ -0,0 +1,14 @@
+-- File: migrations/000012_add_listing_price.up.sql
+
+ALTER TABLE listings
+    ADD COLUMN IF NOT EXISTS price_amount NUMERIC(12, 2),
+    ADD COLUMN IF NOT EXISTS price_currency VARCHAR(3),
+    ADD COLUMN IF NOT EXISTS price_period VARCHAR(20);
+
+ALTER TABLE listings
+    ADD CONSTRAINT chk_listings_price_amount CHECK (price_amount IS NULL OR price_amount >= 0),
+    ADD CONSTRAINT chk_listings_price_period CHECK (
+        price_period IS NULL OR price_period IN ('one_time', 'hourly', 'daily', 'weekly', 'monthly', 'yearly')
+    );
+
+CREATE INDEX IF NOT EXISTS idx_listings_price_amount ON listings(price_amount) WHERE price_amount IS NOT NULL;
@@ -0,0 +1,4 @@
+-- File: migrations/000013_create_category_translations.down.sql
+
+DROP TRIGGER IF EXISTS set_timestamp_category_translations ON category_translations;
+DROP TABLE IF EXISTS category_translations;
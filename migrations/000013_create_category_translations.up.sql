@@ -0,0 +1,18 @@
+-- File: migrations/000013_create_category_translations.up.sql
+
+CREATE TABLE IF NOT EXISTS category_translations (
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    language VARCHAR(10) NOT NULL, -- e.g. 'es', 'vi', 'zh'; the default language lives on categories itself
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (category_id, language)
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_translations_language ON category_translations(language);
+
+CREATE TRIGGER set_timestamp_category_translations
+BEFORE UPDATE ON category_translations
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
@@ -0,0 +1,7 @@
+-- File: migrations/000014_create_category_attributes.down.sql
+
+DROP INDEX IF EXISTS idx_listings_attributes;
+ALTER TABLE listings DROP COLUMN IF EXISTS attributes;
+
+DROP TRIGGER IF EXISTS set_timestamp_category_attributes ON category_attributes;
+DROP TABLE IF EXISTS category_attributes;
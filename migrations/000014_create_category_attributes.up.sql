@@ -0,0 +1,24 @@
+-- File: migrations/000014_create_category_attributes.up.sql
+
+CREATE TABLE IF NOT EXISTS category_attributes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    key VARCHAR(50) NOT NULL, -- Key under which listings store the value in listings.attributes
+    label VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('string', 'number', 'date', 'enum')),
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    options TEXT[], -- Allowed values for enum attributes
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (category_id, key)
+);
+
+CREATE TRIGGER set_timestamp_category_attributes
+BEFORE UPDATE ON category_attributes
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+CREATE INDEX IF NOT EXISTS idx_listings_attributes ON listings USING GIN (attributes);
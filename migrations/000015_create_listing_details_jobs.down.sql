@@ -0,0 +1,3 @@
+-- File: migrations/000015_create_listing_details_jobs.down.sql
+
+DROP TABLE IF EXISTS listing_details_jobs;
@@ -0,0 +1,15 @@
+-- File: migrations/000015_create_listing_details_jobs.up.sql
+
+CREATE TABLE IF NOT EXISTS listing_details_jobs (
+    listing_id UUID PRIMARY KEY REFERENCES listings(id) ON DELETE CASCADE,
+    employment_type VARCHAR(30) NOT NULL CHECK (employment_type IN ('full_time', 'part_time', 'contract', 'temporary', 'internship')),
+    workplace_type VARCHAR(20) NOT NULL CHECK (workplace_type IN ('on_site', 'remote', 'hybrid')),
+    salary_min NUMERIC(12, 2) CHECK (salary_min IS NULL OR salary_min >= 0),
+    salary_max NUMERIC(12, 2) CHECK (salary_max IS NULL OR salary_max >= 0),
+    salary_currency VARCHAR(3),
+    salary_period VARCHAR(20) CHECK (salary_period IS NULL OR salary_period IN ('hourly', 'daily', 'weekly', 'monthly', 'yearly')),
+    application_url VARCHAR(500),
+    CHECK (salary_min IS NULL OR salary_max IS NULL OR salary_min <= salary_max)
+);
+CREATE INDEX IF NOT EXISTS idx_listing_details_jobs_employment_type ON listing_details_jobs(employment_type);
+CREATE INDEX IF NOT EXISTS idx_listing_details_jobs_workplace_type ON listing_details_jobs(workplace_type);
@@ -0,0 +1,8 @@
+-- File: migrations/000016_add_event_recurrence.down.sql
+
+DROP INDEX IF EXISTS idx_listing_details_events_recurring;
+ALTER TABLE listing_details_events DROP CONSTRAINT IF EXISTS chk_listing_details_events_recurrence_until;
+ALTER TABLE listing_details_events
+    DROP COLUMN IF EXISTS recurrence_until,
+    DROP COLUMN IF EXISTS recurrence_interval,
+    DROP COLUMN IF EXISTS recurrence_frequency;
@@ -0,0 +1,11 @@
+-- File: migrations/000016_add_event_recurrence.up.sql
+
+ALTER TABLE listing_details_events
+    ADD COLUMN IF NOT EXISTS recurrence_frequency VARCHAR(10) CHECK (recurrence_frequency IS NULL OR recurrence_frequency IN ('weekly', 'monthly')),
+    ADD COLUMN IF NOT EXISTS recurrence_interval INTEGER NOT NULL DEFAULT 1 CHECK (recurrence_interval BETWEEN 1 AND 12),
+    ADD COLUMN IF NOT EXISTS recurrence_until DATE;
+
+ALTER TABLE listing_details_events
+    ADD CONSTRAINT chk_listing_details_events_recurrence_until CHECK (recurrence_until IS NULL OR recurrence_until >= event_date);
+
+CREATE INDEX IF NOT EXISTS idx_listing_details_events_recurring ON listing_details_events(recurrence_until) WHERE recurrence_frequency IS NOT NULL;
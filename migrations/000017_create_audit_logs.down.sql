@@ -0,0 +1,3 @@
+-- File: migrations/000017_create_audit_logs.down.sql
+
+DROP TABLE IF EXISTS audit_logs;
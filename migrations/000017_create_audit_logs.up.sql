@@ -0,0 +1,16 @@
+-- File: migrations/000017_create_audit_logs.up.sql
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL, -- NULL for system actions or deleted actors
+    action VARCHAR(100) NOT NULL, -- e.g. 'listing.admin_edit'
+    entity_type VARCHAR(50) NOT NULL, -- e.g. 'listing'
+    entity_id VARCHAR(100) NOT NULL,
+    changes JSONB NOT NULL DEFAULT '{}'::jsonb, -- {"field": {"from": ..., "to": ...}}
+    note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+    -- No updated_at: audit entries are never modified
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs(entity_type, entity_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor ON audit_logs(actor_id, created_at DESC);
@@ -0,0 +1,6 @@
+-- File: migrations/000018_add_listing_rejection_reason.down.sql
+
+ALTER TABLE listings DROP CONSTRAINT IF EXISTS chk_listings_rejection_reason;
+ALTER TABLE listings
+    DROP COLUMN IF EXISTS rejection_reason,
+    DROP COLUMN IF EXISTS admin_notes;
@@ -0,0 +1,13 @@
+-- File: migrations/000018_add_listing_rejection_reason.up.sql
+
+-- The outcome of the latest admin status decision, shown to the listing's owner.
+ALTER TABLE listings
+    ADD COLUMN IF NOT EXISTS admin_notes TEXT,
+    ADD COLUMN IF NOT EXISTS rejection_reason VARCHAR(50);
+
+ALTER TABLE listings
+    ADD CONSTRAINT chk_listings_rejection_reason CHECK (
+        rejection_reason IS NULL OR rejection_reason IN (
+            'spam', 'prohibited_content', 'duplicate', 'incomplete', 'wrong_category', 'misleading', 'other'
+        )
+    );
@@ -0,0 +1,3 @@
+-- File: migrations/000019_create_listing_daily_views.down.sql
+
+DROP TABLE IF EXISTS listing_daily_views;
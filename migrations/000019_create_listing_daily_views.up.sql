@@ -0,0 +1,11 @@
+-- File: migrations/000019_create_listing_daily_views.up.sql
+
+-- Detail page views per listing and UTC day; the trending ranking is computed from the last week of rows.
+CREATE TABLE IF NOT EXISTS listing_daily_views (
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    views INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (listing_id, day)
+);
+
+CREATE INDEX IF NOT EXISTS idx_listing_daily_views_day ON listing_daily_views(day);
@@ -0,0 +1,7 @@
+-- File: migrations/000020_add_phone_verification.down.sql
+
+DROP TABLE IF EXISTS phone_verifications;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS phone_verified,
+    DROP COLUMN IF EXISTS phone_number;
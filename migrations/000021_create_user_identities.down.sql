@@ -0,0 +1,3 @@
+-- File: migrations/000021_create_user_identities.down.sql
+
+DROP TABLE IF EXISTS user_identities;
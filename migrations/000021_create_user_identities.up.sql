@@ -0,0 +1,22 @@
+-- File: migrations/000021_create_user_identities.up.sql
+
+-- Sign-in provider accounts linked to a user, so that one person signing in with
+-- several providers under the same verified email keeps a single account.
+CREATE TABLE IF NOT EXISTS user_identities (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    firebase_uid VARCHAR(255) NOT NULL UNIQUE,
+    email VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities(user_id);
+
+-- Existing users get an identity for the account they signed up with. The original
+-- sign-in provider was not recorded, so the user's auth_provider (normally 'firebase') is used.
+INSERT INTO user_identities (user_id, provider, firebase_uid, email, created_at)
+SELECT id, auth_provider, firebase_uid, email, created_at
+FROM users
+WHERE firebase_uid IS NOT NULL
+ON CONFLICT (firebase_uid) DO NOTHING;
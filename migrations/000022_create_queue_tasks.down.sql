@@ -0,0 +1,3 @@
+-- File: migrations/000022_create_queue_tasks.down.sql
+
+DROP TABLE IF EXISTS queue_tasks;
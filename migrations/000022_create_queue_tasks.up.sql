@@ -0,0 +1,21 @@
+-- File: migrations/000022_create_queue_tasks.up.sql
+
+-- Background tasks. Completed tasks are deleted; tasks out of attempts stay with status 'dead'.
+CREATE TABLE IF NOT EXISTS queue_tasks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL,
+    run_at TIMESTAMPTZ NOT NULL,
+    locked_until TIMESTAMPTZ,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Consumers poll for due pending tasks and for running tasks whose lock expired.
+CREATE INDEX IF NOT EXISTS idx_queue_tasks_pending ON queue_tasks(run_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_queue_tasks_running ON queue_tasks(locked_until) WHERE status = 'running';
+CREATE INDEX IF NOT EXISTS idx_queue_tasks_dead ON queue_tasks(updated_at DESC) WHERE status = 'dead';
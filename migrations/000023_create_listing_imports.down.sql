@@ -0,0 +1,3 @@
+-- File: migrations/000023_create_listing_imports.down.sql
+
+DROP TABLE IF EXISTS listing_imports;
@@ -0,0 +1,12 @@
+-- File: migrations/000024_add_category_visibility.down.sql
+
+DROP INDEX IF EXISTS idx_sub_categories_category_id_display_order;
+DROP INDEX IF EXISTS idx_categories_display_order;
+
+ALTER TABLE sub_categories
+    DROP COLUMN IF EXISTS is_active,
+    DROP COLUMN IF EXISTS display_order;
+
+ALTER TABLE categories
+    DROP COLUMN IF EXISTS is_active,
+    DROP COLUMN IF EXISTS display_order;
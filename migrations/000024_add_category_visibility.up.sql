@@ -0,0 +1,25 @@
+-- File: migrations/000024_add_category_visibility.up.sql
+
+-- Admin-controlled display order and visibility. Hidden categories keep their listings but are
+-- left out of public category lists and listing browsing until they are shown again.
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS display_order INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT TRUE;
+
+ALTER TABLE sub_categories
+    ADD COLUMN IF NOT EXISTS display_order INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- Keep the current alphabetical order as the initial display order.
+UPDATE categories c
+SET display_order = ordered.position
+FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY name) - 1 AS position FROM categories) ordered
+WHERE c.id = ordered.id;
+
+UPDATE sub_categories sc
+SET display_order = ordered.position
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY category_id ORDER BY name) - 1 AS position FROM sub_categories) ordered
+WHERE sc.id = ordered.id;
+
+CREATE INDEX IF NOT EXISTS idx_categories_display_order ON categories (display_order);
+CREATE INDEX IF NOT EXISTS idx_sub_categories_category_id_display_order ON sub_categories (category_id, display_order);
@@ -0,0 +1,5 @@
+-- File: migrations/000025_add_category_artwork.down.sql
+
+ALTER TABLE categories
+    DROP COLUMN IF EXISTS image_path,
+    DROP COLUMN IF EXISTS icon_path;
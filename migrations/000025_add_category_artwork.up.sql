@@ -0,0 +1,6 @@
+-- File: migrations/000025_add_category_artwork.up.sql
+
+-- Paths of the category's icon and image, relative to the image storage root (e.g. "categories/<uuid>.png").
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS icon_path VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS image_path VARCHAR(255);
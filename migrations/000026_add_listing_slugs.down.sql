@@ -0,0 +1,4 @@
+-- File: migrations/000026_add_listing_slugs.down.sql
+
+DROP INDEX IF EXISTS idx_listings_slug;
+ALTER TABLE listings DROP COLUMN IF EXISTS slug;
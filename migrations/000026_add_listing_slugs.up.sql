@@ -0,0 +1,20 @@
+-- File: migrations/000026_add_listing_slugs.up.sql
+
+-- Readable, unique URL names for listings: the title made URL-safe followed by a short ID prefix,
+-- e.g. "2br-apartment-in-ballard-3f2a9c1e". The application keeps them in step with the title.
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS slug VARCHAR(100);
+
+UPDATE listings
+SET slug = CONCAT_WS('-',
+    NULLIF(TRIM(BOTH '-' FROM LEFT(TRIM(BOTH '-' FROM REGEXP_REPLACE(LOWER(title), '[^a-z0-9]+', '-', 'g')), 60)), ''),
+    LEFT(REPLACE(id::text, '-', ''), 8))
+WHERE slug IS NULL;
+
+-- Fall back to the whole ID for the rare listings whose short slug is taken.
+UPDATE listings l
+SET slug = CONCAT_WS('-', NULLIF(LEFT(l.slug, GREATEST(LENGTH(l.slug) - 9, 0)), ''), REPLACE(l.id::text, '-', ''))
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY slug ORDER BY created_at, id) AS position FROM listings) dup
+WHERE l.id = dup.id AND dup.position > 1;
+
+ALTER TABLE listings ALTER COLUMN slug SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_listings_slug ON listings (slug);
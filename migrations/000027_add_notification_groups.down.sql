@@ -0,0 +1,4 @@
+-- File: migrations/000027_add_notification_groups.down.sql
+
+DROP INDEX IF EXISTS idx_notifications_user_group_key;
+ALTER TABLE notifications DROP COLUMN IF EXISTS group_key;
@@ -0,0 +1,7 @@
+-- File: migrations/000027_add_notification_groups.up.sql
+
+-- Similar notifications, e.g. new messages about the same listing, share a group key and are listed as one
+-- entry. NULL means the notification is never grouped.
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS group_key VARCHAR(150);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_group_key ON notifications (user_id, group_key) WHERE group_key IS NOT NULL;
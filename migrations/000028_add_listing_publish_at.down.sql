@@ -0,0 +1,7 @@
+-- File: migrations/000028_add_listing_publish_at.down.sql
+
+-- Scheduled listings go back to drafts, since the status no longer exists.
+UPDATE listings SET status = 'draft' WHERE status = 'scheduled';
+
+DROP INDEX IF EXISTS idx_listings_scheduled_publish_at;
+ALTER TABLE listings DROP COLUMN IF EXISTS publish_at;
@@ -0,0 +1,7 @@
+-- File: migrations/000028_add_listing_publish_at.up.sql
+
+-- Listings can be scheduled to go live at a future time. Until then their status is 'scheduled' and
+-- they are hidden from everyone but their owner; a background job makes them active when publish_at passes.
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_listings_scheduled_publish_at ON listings (publish_at) WHERE status = 'scheduled';
@@ -0,0 +1,4 @@
+-- File: migrations/000029_add_listing_featured_until.down.sql
+
+DROP INDEX IF EXISTS idx_listings_featured_until;
+ALTER TABLE listings DROP COLUMN IF EXISTS featured_until;
@@ -0,0 +1,7 @@
+-- File: migrations/000029_add_listing_featured_until.up.sql
+
+-- A listing is featured, and sorted ahead of others in search and recent listings, until featured_until.
+-- A background job clears the column once it has passed.
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS featured_until TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_listings_featured_until ON listings (featured_until) WHERE featured_until IS NOT NULL;
@@ -0,0 +1,4 @@
+-- File: migrations/000030_create_payments_table.down.sql
+
+DROP TRIGGER IF EXISTS set_timestamp_payments ON payments;
+DROP TABLE IF EXISTS payments;
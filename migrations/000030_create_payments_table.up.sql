@@ -0,0 +1,30 @@
+-- File: migrations/000030_create_payments_table.up.sql
+
+-- Purchases of featured days for a listing. A payment is created pending when checkout starts and is
+-- moved to paid, refunded or expired by the payment provider's webhook events. user_id and listing_id have
+-- no foreign keys so that payments stay on record for accounting after the user or listing is deleted.
+CREATE TABLE IF NOT EXISTS payments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    listing_id UUID NOT NULL,
+    provider VARCHAR(20) NOT NULL,
+    provider_session_id VARCHAR(255) UNIQUE,
+    provider_payment_id VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, paid, refunded, expired
+    amount_cents BIGINT NOT NULL CHECK (amount_cents >= 0),
+    currency VARCHAR(3) NOT NULL,
+    feature_days INTEGER NOT NULL CHECK (feature_days > 0),
+    paid_at TIMESTAMPTZ,
+    refunded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_payments_provider_payment_id ON payments(provider_payment_id);
+CREATE INDEX IF NOT EXISTS idx_payments_paid_at ON payments(paid_at) WHERE paid_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_payments_listing_id ON payments(listing_id);
+
+CREATE TRIGGER set_timestamp_payments
+BEFORE UPDATE ON payments
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
@@ -0,0 +1,5 @@
+-- File: migrations/000031_add_listing_spam_fields.down.sql
+
+DROP INDEX IF EXISTS idx_listings_created_ip_created_at;
+ALTER TABLE listings DROP COLUMN IF EXISTS spam_score;
+ALTER TABLE listings DROP COLUMN IF EXISTS created_ip;
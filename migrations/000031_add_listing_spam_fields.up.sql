@@ -0,0 +1,9 @@
+-- File: migrations/000031_add_listing_spam_fields.up.sql
+
+-- created_ip records the client address a listing was submitted from, for per-IP posting velocity.
+-- spam_score is the heuristic score computed when the listing was published; the rules that fired
+-- are stored in moderation_flags with a "spam:" prefix.
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS created_ip VARCHAR(45);
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS spam_score INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_listings_created_ip_created_at ON listings (created_ip, created_at) WHERE created_ip IS NOT NULL;
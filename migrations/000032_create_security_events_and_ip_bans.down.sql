@@ -0,0 +1,4 @@
+-- File: migrations/000032_create_security_events_and_ip_bans.down.sql
+
+DROP TABLE IF EXISTS ip_bans;
+DROP TABLE IF EXISTS security_events;
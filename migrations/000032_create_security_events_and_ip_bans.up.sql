@@ -0,0 +1,24 @@
+-- File: migrations/000032_create_security_events_and_ip_bans.up.sql
+
+CREATE TABLE IF NOT EXISTS security_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL, -- Kept after account deletion for abuse investigations
+    event_type VARCHAR(50) NOT NULL, -- 'sign_up', 'sign_in' or 'listing_created'
+    ip VARCHAR(45) NOT NULL,
+    device_id VARCHAR(128), -- X-Device-Fingerprint header, when sent
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+    -- No updated_at: events are never modified
+);
+
+CREATE INDEX IF NOT EXISTS idx_security_events_user ON security_events(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_security_events_ip ON security_events(ip, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_security_events_device ON security_events(device_id, created_at DESC) WHERE device_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS ip_bans (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    cidr CIDR NOT NULL, -- A single address is stored as a /32 or /128 range
+    reason TEXT,
+    created_by UUID NOT NULL, -- Admin user ID; no foreign key so bans outlive the admin account
+    expires_at TIMESTAMPTZ, -- NULL bans permanently
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+-- File: migrations/000033_create_listing_contact_reveals.down.sql
+
+DROP TABLE IF EXISTS listing_contact_reveals;
@@ -0,0 +1,13 @@
+-- File: migrations/000033_create_listing_contact_reveals.up.sql
+
+-- One row per user who revealed a listing's contact details; repeated reveals by the same user are not recorded again.
+CREATE TABLE IF NOT EXISTS listing_contact_reveals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (listing_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_listing_contact_reveals_listing ON listing_contact_reveals(listing_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_listing_contact_reveals_user ON listing_contact_reveals(user_id, created_at DESC);
@@ -0,0 +1,4 @@
+-- File: migrations/000034_create_listing_stats_daily.down.sql
+
+DROP TABLE IF EXISTS listing_stats_daily;
+DROP TABLE IF EXISTS listing_daily_impressions;
@@ -0,0 +1,19 @@
+-- File: migrations/000034_create_listing_stats_daily.up.sql
+
+-- Search result appearances per listing and UTC day, counted when GET /listings returns the listing.
+CREATE TABLE IF NOT EXISTS listing_daily_impressions (
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    impressions INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (listing_id, day)
+);
+
+-- Owner analytics per listing and UTC day, rolled up nightly from the view, contact reveal and impression counters.
+CREATE TABLE IF NOT EXISTS listing_stats_daily (
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    views INTEGER NOT NULL DEFAULT 0,
+    contact_reveals INTEGER NOT NULL DEFAULT 0,
+    search_impressions INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (listing_id, day)
+);
@@ -0,0 +1,17 @@
+-- File: migrations/000035_create_neighborhoods.down.sql
+
+CREATE OR REPLACE FUNCTION update_location_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.longitude IS NOT NULL AND NEW.latitude IS NOT NULL THEN
+        NEW.location = ST_SetSRID(ST_MakePoint(NEW.longitude, NEW.latitude), 4326);
+    ELSE
+        NEW.location = NULL;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS idx_listings_neighborhood_id;
+ALTER TABLE listings DROP COLUMN IF EXISTS neighborhood_id;
+DROP TABLE IF EXISTS neighborhoods;
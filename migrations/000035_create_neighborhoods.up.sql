@@ -0,0 +1,78 @@
+-- File: migrations/000035_create_neighborhoods.up.sql
+
+CREATE TABLE IF NOT EXISTS neighborhoods (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    boundary GEOMETRY(Polygon, 4326) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_neighborhoods_boundary ON neighborhoods USING GIST (boundary);
+
+CREATE TRIGGER set_timestamp_neighborhoods
+BEFORE UPDATE ON neighborhoods
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+-- Approximate boundaries of common Seattle neighborhoods as bounding boxes. Where boxes overlap, a listing is
+-- assigned to the smallest one. Refine a boundary by updating its row; listings are reassigned when next saved.
+INSERT INTO neighborhoods (name, slug, boundary) VALUES
+    ('Downtown', 'downtown', ST_MakeEnvelope(-122.345, 47.600, -122.325, 47.615, 4326)),
+    ('Belltown', 'belltown', ST_MakeEnvelope(-122.360, 47.610, -122.338, 47.622, 4326)),
+    ('Pioneer Square', 'pioneer-square', ST_MakeEnvelope(-122.340, 47.595, -122.328, 47.603, 4326)),
+    ('Chinatown-International District', 'international-district', ST_MakeEnvelope(-122.328, 47.593, -122.315, 47.602, 4326)),
+    ('First Hill', 'first-hill', ST_MakeEnvelope(-122.328, 47.602, -122.315, 47.612, 4326)),
+    ('South Lake Union', 'south-lake-union', ST_MakeEnvelope(-122.345, 47.617, -122.328, 47.634, 4326)),
+    ('Capitol Hill', 'capitol-hill', ST_MakeEnvelope(-122.328, 47.612, -122.300, 47.640, 4326)),
+    ('Central District', 'central-district', ST_MakeEnvelope(-122.315, 47.590, -122.285, 47.612, 4326)),
+    ('Queen Anne', 'queen-anne', ST_MakeEnvelope(-122.375, 47.622, -122.345, 47.650, 4326)),
+    ('Fremont', 'fremont', ST_MakeEnvelope(-122.365, 47.645, -122.340, 47.660, 4326)),
+    ('Wallingford', 'wallingford', ST_MakeEnvelope(-122.340, 47.645, -122.320, 47.665, 4326)),
+    ('University District', 'university-district', ST_MakeEnvelope(-122.320, 47.650, -122.295, 47.675, 4326)),
+    ('Ballard', 'ballard', ST_MakeEnvelope(-122.405, 47.655, -122.365, 47.695, 4326)),
+    ('Green Lake', 'green-lake', ST_MakeEnvelope(-122.350, 47.665, -122.320, 47.690, 4326)),
+    ('Northgate', 'northgate', ST_MakeEnvelope(-122.340, 47.695, -122.310, 47.720, 4326)),
+    ('Lake City', 'lake-city', ST_MakeEnvelope(-122.310, 47.700, -122.275, 47.735, 4326)),
+    ('SoDo', 'sodo', ST_MakeEnvelope(-122.345, 47.550, -122.320, 47.593, 4326)),
+    ('Georgetown', 'georgetown', ST_MakeEnvelope(-122.335, 47.530, -122.310, 47.550, 4326)),
+    ('Beacon Hill', 'beacon-hill', ST_MakeEnvelope(-122.320, 47.540, -122.295, 47.590, 4326)),
+    ('Columbia City', 'columbia-city', ST_MakeEnvelope(-122.295, 47.550, -122.275, 47.570, 4326)),
+    ('Rainier Valley', 'rainier-valley', ST_MakeEnvelope(-122.295, 47.500, -122.255, 47.590, 4326)),
+    ('West Seattle', 'west-seattle', ST_MakeEnvelope(-122.420, 47.500, -122.350, 47.590, 4326))
+ON CONFLICT (slug) DO NOTHING;
+
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS neighborhood_id UUID REFERENCES neighborhoods(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_listings_neighborhood_id ON listings(neighborhood_id);
+
+-- Keep neighborhood_id in step with the location, which this trigger already derives from latitude and longitude.
+CREATE OR REPLACE FUNCTION update_location_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.longitude IS NOT NULL AND NEW.latitude IS NOT NULL THEN
+        NEW.location = ST_SetSRID(ST_MakePoint(NEW.longitude, NEW.latitude), 4326);
+        NEW.neighborhood_id = (
+            SELECT id FROM neighborhoods
+            WHERE ST_Contains(boundary, ST_SetSRID(ST_MakePoint(NEW.longitude, NEW.latitude), 4326))
+            ORDER BY ST_Area(boundary)
+            LIMIT 1
+        );
+    ELSE
+        NEW.location = NULL;
+        NEW.neighborhood_id = NULL;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Assign existing listings without touching their updated_at.
+ALTER TABLE listings DISABLE TRIGGER set_timestamp_listings;
+UPDATE listings SET neighborhood_id = (
+    SELECT n.id FROM neighborhoods n
+    WHERE ST_Contains(n.boundary, listings.location::geometry)
+    ORDER BY ST_Area(n.boundary)
+    LIMIT 1
+)
+WHERE listings.location IS NOT NULL;
+ALTER TABLE listings ENABLE TRIGGER set_timestamp_listings;
@@ -0,0 +1,5 @@
+-- File: migrations/000036_add_user_canonical_email.down.sql
+
+DROP TABLE IF EXISTS user_duplicate_candidates;
+DROP INDEX IF EXISTS idx_users_canonical_email;
+ALTER TABLE users DROP COLUMN IF EXISTS canonical_email;
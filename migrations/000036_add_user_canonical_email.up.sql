@@ -0,0 +1,43 @@
+-- File: migrations/000036_add_user_canonical_email.up.sql
+
+-- The address with provider-insignificant differences removed (case everywhere; dots and +tags for Gmail),
+-- so that user.name@gmail.com and username@gmail.com are recognised as one mailbox. Not unique: accounts
+-- created before this column existed may share it until an admin merges them.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS canonical_email VARCHAR(255);
+
+UPDATE users
+SET canonical_email = CASE
+    WHEN split_part(lower(trim(email)), '@', 2) IN ('gmail.com', 'googlemail.com')
+        THEN replace(split_part(split_part(lower(trim(email)), '@', 1), '+', 1), '.', '') || '@gmail.com'
+    ELSE lower(trim(email))
+END
+WHERE email IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_canonical_email ON users(canonical_email);
+
+-- Accounts found on sign-in to share a canonical email with an existing account without being linked to it,
+-- for admins to review and merge or dismiss.
+CREATE TABLE IF NOT EXISTS user_duplicate_candidates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    duplicate_of_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    canonical_email VARCHAR(255) NOT NULL,
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, duplicate_of_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_duplicate_candidates_open ON user_duplicate_candidates(created_at DESC) WHERE resolved_at IS NULL;
+
+-- Existing duplicates: each later account is a candidate duplicate of the oldest one.
+INSERT INTO user_duplicate_candidates (user_id, duplicate_of_id, canonical_email)
+SELECT u.id, first.id, u.canonical_email
+FROM users u
+JOIN LATERAL (
+    SELECT o.id FROM users o
+    WHERE o.canonical_email = u.canonical_email
+    ORDER BY o.created_at, o.id
+    LIMIT 1
+) first ON first.id <> u.id
+WHERE u.canonical_email IS NOT NULL
+ON CONFLICT (user_id, duplicate_of_id) DO NOTHING;
@@ -0,0 +1,4 @@
+-- File: migrations/000037_add_user_merge_columns.down.sql
+
+ALTER TABLE users DROP COLUMN IF EXISTS merged_into_id;
+ALTER TABLE users DROP COLUMN IF EXISTS deactivated_at;
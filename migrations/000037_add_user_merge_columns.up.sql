@@ -0,0 +1,6 @@
+-- File: migrations/000037_add_user_merge_columns.up.sql
+
+-- An account merged into another by an admin is kept, deactivated, with a pointer to the account that took over
+-- its listings, messages and sign-in identities.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS merged_into_id UUID REFERENCES users(id) ON DELETE SET NULL;
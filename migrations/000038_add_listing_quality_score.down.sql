@@ -0,0 +1,4 @@
+-- File: migrations/000038_add_listing_quality_score.down.sql
+
+ALTER TABLE listings DROP COLUMN IF EXISTS quality_hints;
+ALTER TABLE listings DROP COLUMN IF EXISTS quality_score;
@@ -0,0 +1,47 @@
+-- File: migrations/000038_add_listing_quality_score.up.sql
+
+-- How complete a listing is, from 0 to 100, and what its owner could add to improve it. Written by the
+-- listing service on every save; search orders otherwise equal results by the score.
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS quality_score INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS quality_hints TEXT[];
+
+-- Score existing listings with the checks of computeQuality in internal/listing/quality.go, without
+-- touching updated_at.
+ALTER TABLE listings DISABLE TRIGGER set_timestamp_listings;
+
+WITH checks AS (
+    SELECT
+        l.id,
+        (SELECT COUNT(*) FROM listing_images i WHERE i.listing_id = l.id) AS photos,
+        char_length(btrim(l.description)) >= 150 AS described,
+        (NULLIF(btrim(l.contact_email), '') IS NOT NULL OR NULLIF(btrim(l.contact_phone), '') IS NOT NULL) AS reachable,
+        (l.latitude IS NOT NULL AND l.longitude IS NOT NULL) AS located,
+        CASE c.slug
+            WHEN 'baby-sitting' THEN EXISTS (SELECT 1 FROM listing_details_babysitting d WHERE d.listing_id = l.id AND cardinality(d.languages_spoken) > 0)
+            WHEN 'housing' THEN EXISTS (SELECT 1 FROM listing_details_housing d WHERE d.listing_id = l.id)
+            WHEN 'events' THEN EXISTS (SELECT 1 FROM listing_details_events d WHERE d.listing_id = l.id AND NULLIF(btrim(d.venue_name), '') IS NOT NULL)
+            WHEN 'jobs' THEN EXISTS (SELECT 1 FROM listing_details_jobs d WHERE d.listing_id = l.id)
+            ELSE TRUE
+        END AS detailed
+    FROM listings l
+    JOIN categories c ON c.id = l.category_id
+)
+UPDATE listings l
+SET quality_score = (CASE WHEN checks.photos > 0 THEN 20 ELSE 0 END)
+        + (CASE WHEN checks.photos >= 3 THEN 10 ELSE 0 END)
+        + (CASE WHEN checks.described THEN 20 ELSE 0 END)
+        + (CASE WHEN checks.reachable THEN 15 ELSE 0 END)
+        + (CASE WHEN checks.located THEN 20 ELSE 0 END)
+        + (CASE WHEN checks.detailed THEN 15 ELSE 0 END),
+    quality_hints = array_remove(ARRAY[
+        CASE WHEN checks.photos = 0 THEN 'add_photos' END,
+        CASE WHEN checks.photos < 3 THEN 'add_more_photos' END,
+        CASE WHEN NOT checks.described THEN 'expand_description' END,
+        CASE WHEN NOT checks.reachable THEN 'add_contact' END,
+        CASE WHEN NOT checks.located THEN 'add_location' END,
+        CASE WHEN NOT checks.detailed THEN 'add_category_details' END
+    ]::TEXT[], NULL)
+FROM checks
+WHERE checks.id = l.id;
+
+ALTER TABLE listings ENABLE TRIGGER set_timestamp_listings;
@@ -0,0 +1,5 @@
+-- File: migrations/000039_create_listing_templates_table.down.sql
+
+DROP TRIGGER IF EXISTS set_timestamp_listing_templates ON listing_templates;
+DROP INDEX IF EXISTS idx_listing_templates_user_id;
+DROP TABLE IF EXISTS listing_templates;
@@ -0,0 +1,17 @@
+-- File: migrations/000039_create_listing_templates_table.up.sql
+
+CREATE TABLE IF NOT EXISTS listing_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(150) NOT NULL,
+    fields JSONB NOT NULL DEFAULT '{}'::jsonb, -- Serialized partial listing create request
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_listing_templates_user_id ON listing_templates(user_id);
+
+CREATE TRIGGER set_timestamp_listing_templates
+BEFORE UPDATE ON listing_templates
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
@@ -0,0 +1,4 @@
+-- File: migrations/000040_create_listing_recent_views_table.down.sql
+
+DROP INDEX IF EXISTS idx_listing_recent_views_user_viewed_at;
+DROP TABLE IF EXISTS listing_recent_views;
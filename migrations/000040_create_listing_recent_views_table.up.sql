@@ -0,0 +1,12 @@
+-- File: migrations/000040_create_listing_recent_views_table.up.sql
+
+-- Each user's recently viewed listings, one row per listing with the time of the last view.
+-- The service trims a user's rows to RECENTLY_VIEWED_LIMIT on every view.
+CREATE TABLE IF NOT EXISTS listing_recent_views (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    viewed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, listing_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_listing_recent_views_user_viewed_at ON listing_recent_views(user_id, viewed_at DESC);
@@ -0,0 +1,6 @@
+-- File: migrations/000041_create_anonymous_sessions_table.down.sql
+
+DROP INDEX IF EXISTS idx_listing_anonymous_recent_views_session_viewed_at;
+DROP TABLE IF EXISTS listing_anonymous_recent_views;
+DROP INDEX IF EXISTS idx_anonymous_sessions_expires_at;
+DROP TABLE IF EXISTS anonymous_sessions;
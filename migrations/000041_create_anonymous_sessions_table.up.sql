@@ -0,0 +1,21 @@
+-- File: migrations/000041_create_anonymous_sessions_table.up.sql
+
+-- Server-issued sessions of visitors who are not signed in. Only the SHA-256 hash of the token is stored.
+CREATE TABLE IF NOT EXISTS anonymous_sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_anonymous_sessions_expires_at ON anonymous_sessions(expires_at);
+
+-- Recently viewed listings of anonymous sessions; merged into listing_recent_views when the visitor signs in.
+CREATE TABLE IF NOT EXISTS listing_anonymous_recent_views (
+    session_id UUID NOT NULL REFERENCES anonymous_sessions(id) ON DELETE CASCADE,
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    viewed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (session_id, listing_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_listing_anonymous_recent_views_session_viewed_at ON listing_anonymous_recent_views(session_id, viewed_at DESC);
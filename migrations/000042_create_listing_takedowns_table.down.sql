@@ -0,0 +1,7 @@
+-- File: migrations/000042_create_listing_takedowns_table.down.sql
+
+DROP TRIGGER IF EXISTS set_timestamp_listing_takedowns ON listing_takedowns;
+DROP INDEX IF EXISTS idx_listing_takedowns_open;
+DROP INDEX IF EXISTS idx_listing_takedowns_status;
+DROP INDEX IF EXISTS idx_listing_takedowns_listing_id;
+DROP TABLE IF EXISTS listing_takedowns;
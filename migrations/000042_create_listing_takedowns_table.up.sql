@@ -0,0 +1,29 @@
+-- File: migrations/000042_create_listing_takedowns_table.up.sql
+
+-- Listings removed by an admin for a stated reason, and the owner's appeal against the removal.
+CREATE TABLE IF NOT EXISTS listing_takedowns (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    admin_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    reason VARCHAR(20) NOT NULL, -- dmca, scam or tos
+    note TEXT,
+    previous_status VARCHAR(50) NOT NULL, -- Restored when an appeal is granted
+    status VARCHAR(20) NOT NULL DEFAULT 'active', -- active, appealed, upheld or reversed
+    appeal_message TEXT,
+    appealed_at TIMESTAMPTZ,
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMPTZ,
+    resolution_note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_listing_takedowns_listing_id ON listing_takedowns(listing_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_listing_takedowns_status ON listing_takedowns(status, appealed_at);
+-- A listing has at most one takedown that has not been resolved.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_listing_takedowns_open ON listing_takedowns(listing_id) WHERE status IN ('active', 'appealed');
+
+CREATE TRIGGER set_timestamp_listing_takedowns
+BEFORE UPDATE ON listing_takedowns
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
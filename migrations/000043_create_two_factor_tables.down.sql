@@ -0,0 +1,11 @@
+-- File: migrations/000043_create_two_factor_tables.down.sql
+
+DROP INDEX IF EXISTS idx_two_factor_sessions_expires_at;
+DROP INDEX IF EXISTS idx_two_factor_sessions_user_id;
+DROP TABLE IF EXISTS two_factor_sessions;
+
+DROP INDEX IF EXISTS idx_user_two_factor_backup_codes_user_id;
+DROP TABLE IF EXISTS user_two_factor_backup_codes;
+
+DROP TRIGGER IF EXISTS set_timestamp_user_two_factor ON user_two_factor;
+DROP TABLE IF EXISTS user_two_factor;
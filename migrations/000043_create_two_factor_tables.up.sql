@@ -0,0 +1,44 @@
+-- File: migrations/000043_create_two_factor_tables.up.sql
+
+-- TOTP second factor of admin accounts. The secret is encrypted with TWO_FACTOR_ENCRYPTION_KEY.
+-- A row without enabled_at is an enrollment that has not been confirmed with a code yet.
+CREATE TABLE IF NOT EXISTS user_two_factor (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret_encrypted TEXT NOT NULL,
+    enabled_at TIMESTAMPTZ,
+    last_used_step BIGINT NOT NULL DEFAULT 0, -- TOTP time step of the last accepted code; older codes are replays
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    locked_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER set_timestamp_user_two_factor
+BEFORE UPDATE ON user_two_factor
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+-- Single-use backup codes, stored as SHA-256 hashes.
+CREATE TABLE IF NOT EXISTS user_two_factor_backup_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_two_factor_backup_codes_user_id ON user_two_factor_backup_codes(user_id);
+
+-- Sessions opened by verifying the second factor, tied to the Firebase sign-in they were opened in.
+-- Only the SHA-256 hash of the token is stored.
+CREATE TABLE IF NOT EXISTS two_factor_sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    auth_time TIMESTAMPTZ NOT NULL, -- auth_time of the Firebase ID token
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_two_factor_sessions_user_id ON two_factor_sessions(user_id);
+CREATE INDEX IF NOT EXISTS idx_two_factor_sessions_expires_at ON two_factor_sessions(expires_at);
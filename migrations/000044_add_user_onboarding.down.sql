@@ -0,0 +1,8 @@
+-- File: migrations/000044_add_user_onboarding.down.sql
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS onboarding_completed_at,
+    DROP COLUMN IF EXISTS notification_prefs_set_at,
+    DROP COLUMN IF EXISTS first_listing_posted_at,
+    DROP COLUMN IF EXISTS phone_verified_at,
+    DROP COLUMN IF EXISTS profile_completed_at;
@@ -0,0 +1,21 @@
+-- File: migrations/000044_add_user_onboarding.up.sql
+
+-- When each onboarding step was first completed; onboarding_completed_at is set once all of them are.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS profile_completed_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS phone_verified_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS first_listing_posted_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS notification_prefs_set_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS onboarding_completed_at TIMESTAMPTZ;
+
+-- Existing users keep the steps they have already done.
+UPDATE users SET profile_completed_at = updated_at
+WHERE profile_completed_at IS NULL AND COALESCE(first_name, '') <> '' AND email IS NOT NULL;
+
+UPDATE users SET phone_verified_at = COALESCE(
+    (SELECT MAX(verified_at) FROM phone_verifications pv WHERE pv.user_id = users.id), updated_at)
+WHERE phone_verified_at IS NULL AND phone_verified;
+
+UPDATE users SET first_listing_posted_at = l.first_created_at
+FROM (SELECT user_id, MIN(created_at) AS first_created_at FROM listings WHERE status <> 'draft' GROUP BY user_id) l
+WHERE l.user_id = users.id AND users.first_listing_posted_at IS NULL;
@@ -0,0 +1,3 @@
+-- File: migrations/000045_create_listing_contact_messages.down.sql
+
+DROP TABLE IF EXISTS listing_contact_messages;
@@ -0,0 +1,15 @@
+-- File: migrations/000045_create_listing_contact_messages.up.sql
+
+-- One row per message sent with a listing's contact form. The message itself is stored in the conversation it opened
+-- (or was added to); this table drives the per-user rate limit and records whether the email relay succeeded.
+CREATE TABLE IF NOT EXISTS listing_contact_messages (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    sender_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    emailed_at TIMESTAMPTZ, -- NULL when the owner has no email address or the email could not be sent
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_listing_contact_messages_sender ON listing_contact_messages(sender_id, created_at DESC);
@@ -0,0 +1,3 @@
+-- File: migrations/000046_create_listing_questions.down.sql
+
+DROP TABLE IF EXISTS listing_questions;
@@ -0,0 +1,16 @@
+-- File: migrations/000046_create_listing_questions.up.sql
+
+-- Public questions about listings and their owners' answers. Answered questions are shown to everyone; unanswered
+-- ones only to the listing's owner and the asker.
+CREATE TABLE IF NOT EXISTS listing_questions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    asker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    answer TEXT,
+    answered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_listing_questions_listing ON listing_questions(listing_id, created_at DESC);
@@ -0,0 +1,4 @@
+-- File: migrations/000047_add_created_at_indexes.down.sql
+
+DROP INDEX IF EXISTS idx_users_created_at;
+DROP INDEX IF EXISTS idx_listings_created_at;
@@ -0,0 +1,5 @@
+-- File: migrations/000047_add_created_at_indexes.up.sql
+
+-- The admin overview counts listings and users created since midnight.
+CREATE INDEX IF NOT EXISTS idx_listings_created_at ON listings(created_at);
+CREATE INDEX IF NOT EXISTS idx_users_created_at ON users(created_at);
@@ -0,0 +1,3 @@
+-- File: migrations/000048_create_job_runs.down.sql
+
+DROP TABLE IF EXISTS job_runs;
@@ -0,0 +1,4 @@
+-- File: migrations/000049_add_listing_expiry_event_pending.down.sql
+
+DROP INDEX IF EXISTS idx_listings_expiry_event_pending;
+ALTER TABLE listings DROP COLUMN IF EXISTS expiry_event_pending;
@@ -0,0 +1,7 @@
+-- File: migrations/000049_add_listing_expiry_event_pending.up.sql
+
+-- Set by the listing expiry job in the statement that expires a listing, and cleared once its listing.expired event
+-- has been emitted, so a run that is interrupted in between can emit the event on the next run.
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS expiry_event_pending BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_listings_expiry_event_pending ON listings(expires_at) WHERE expiry_event_pending;
@@ -0,0 +1,3 @@
+-- File: migrations/000050_add_category_lifespan_days.down.sql
+
+ALTER TABLE categories DROP COLUMN IF EXISTS lifespan_days;
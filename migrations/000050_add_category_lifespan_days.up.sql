@@ -0,0 +1,7 @@
+-- File: migrations/000050_add_category_lifespan_days.up.sql
+
+-- Days listings in a category stay live before they expire. NULL uses the default lifespan
+-- (DEFAULT_LISTING_LIFESPAN_DAYS). Events expire after their event date instead.
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS lifespan_days INTEGER CHECK (lifespan_days > 0);
+
+UPDATE categories SET lifespan_days = 60 WHERE slug = 'housing' AND lifespan_days IS NULL;
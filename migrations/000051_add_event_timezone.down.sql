@@ -0,0 +1,5 @@
+-- File: migrations/000051_add_event_timezone.down.sql
+
+DROP INDEX IF EXISTS idx_listing_details_events_starts_at;
+ALTER TABLE listing_details_events DROP COLUMN IF EXISTS starts_at;
+ALTER TABLE listing_details_events DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,17 @@
+-- File: migrations/000051_add_event_timezone.up.sql
+
+-- Event dates and times are wall-clock values in the event's own time zone. starts_at is the first occurrence as
+-- an instant (midnight for all-day events), so upcoming events can be compared with the current time in UTC.
+ALTER TABLE listing_details_events ADD COLUMN IF NOT EXISTS timezone VARCHAR(64);
+ALTER TABLE listing_details_events ADD COLUMN IF NOT EXISTS starts_at TIMESTAMPTZ;
+
+-- Existing events were entered in EVENTS_TIMEZONE, which defaults to America/Los_Angeles.
+UPDATE listing_details_events SET timezone = 'America/Los_Angeles' WHERE timezone IS NULL;
+UPDATE listing_details_events
+SET starts_at = (event_date + COALESCE(event_time, TIME '00:00')) AT TIME ZONE timezone
+WHERE starts_at IS NULL;
+
+ALTER TABLE listing_details_events ALTER COLUMN timezone SET NOT NULL;
+ALTER TABLE listing_details_events ALTER COLUMN starts_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_listing_details_events_starts_at ON listing_details_events(starts_at);
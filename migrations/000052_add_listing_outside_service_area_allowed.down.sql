@@ -0,0 +1,3 @@
+-- File: migrations/000052_add_listing_outside_service_area_allowed.down.sql
+
+ALTER TABLE listings DROP COLUMN IF EXISTS outside_service_area_allowed;
@@ -0,0 +1,4 @@
+-- File: migrations/000052_add_listing_outside_service_area_allowed.up.sql
+
+-- Set by admins to let a listing keep coordinates outside the configured service area.
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS outside_service_area_allowed BOOLEAN NOT NULL DEFAULT FALSE;
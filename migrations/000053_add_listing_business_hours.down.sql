@@ -0,0 +1,3 @@
+-- File: migrations/000053_add_listing_business_hours.down.sql
+
+ALTER TABLE listings DROP COLUMN IF EXISTS business_hours;
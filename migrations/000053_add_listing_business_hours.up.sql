@@ -0,0 +1,4 @@
+-- File: migrations/000053_add_listing_business_hours.up.sql
+
+-- Weekly opening hours of business listings: {"timezone": "...", "monday": [{"open": "09:00", "close": "17:00"}], ...}
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS business_hours JSONB CHECK (business_hours IS NULL OR jsonb_typeof(business_hours) = 'object');
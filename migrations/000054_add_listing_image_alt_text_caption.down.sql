@@ -0,0 +1,4 @@
+-- File: migrations/000054_add_listing_image_alt_text_caption.down.sql
+
+ALTER TABLE listing_images DROP COLUMN IF EXISTS caption;
+ALTER TABLE listing_images DROP COLUMN IF EXISTS alt_text;
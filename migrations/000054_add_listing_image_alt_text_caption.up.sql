@@ -0,0 +1,5 @@
+-- File: migrations/000054_add_listing_image_alt_text_caption.up.sql
+
+-- Accessibility: a text alternative for screen readers and an optional visible caption per image.
+ALTER TABLE listing_images ADD COLUMN IF NOT EXISTS alt_text VARCHAR(250);
+ALTER TABLE listing_images ADD COLUMN IF NOT EXISTS caption VARCHAR(500);
@@ -0,0 +1,5 @@
+-- File: migrations/000055_add_listing_image_scan.down.sql
+
+ALTER TABLE listing_images DROP COLUMN IF EXISTS scanned_at;
+ALTER TABLE listing_images DROP COLUMN IF EXISTS scan_result;
+ALTER TABLE listing_images DROP COLUMN IF EXISTS scan_status;
@@ -0,0 +1,6 @@
+-- File: migrations/000055_add_listing_image_scan.up.sql
+
+-- Virus scan results of uploaded images, kept for audit. Images stored before scanning existed are 'unscanned'.
+ALTER TABLE listing_images ADD COLUMN IF NOT EXISTS scan_status VARCHAR(20) NOT NULL DEFAULT 'unscanned';
+ALTER TABLE listing_images ADD COLUMN IF NOT EXISTS scan_result TEXT;
+ALTER TABLE listing_images ADD COLUMN IF NOT EXISTS scanned_at TIMESTAMPTZ;
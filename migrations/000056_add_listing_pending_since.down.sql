@@ -0,0 +1,6 @@
+-- File: migrations/000056_add_listing_pending_since.down.sql
+
+DROP TRIGGER IF EXISTS before_insert_or_update_listings_set_pending_since ON listings;
+DROP FUNCTION IF EXISTS set_listing_pending_since();
+DROP INDEX IF EXISTS idx_listings_pending_since;
+ALTER TABLE listings DROP COLUMN IF EXISTS pending_since;
@@ -0,0 +1,29 @@
+-- File: migrations/000056_add_listing_pending_since.up.sql
+
+-- When a listing entered pending_approval, for the approval SLA monitor. NULL while the listing is in another status.
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS pending_since TIMESTAMPTZ;
+
+-- Listings already waiting are counted from their last change, the closest record of when they were submitted.
+UPDATE listings SET pending_since = updated_at WHERE status = 'pending_approval' AND pending_since IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_listings_pending_since ON listings(pending_since) WHERE status = 'pending_approval';
+
+-- Kept by a trigger, so every way a listing is sent to or taken out of review is covered.
+CREATE OR REPLACE FUNCTION set_listing_pending_since()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.status <> 'pending_approval' THEN
+        NEW.pending_since = NULL;
+    ELSIF TG_OP = 'INSERT' OR OLD.status IS DISTINCT FROM 'pending_approval' THEN
+        NEW.pending_since = CURRENT_TIMESTAMP;
+    ELSE
+        NEW.pending_since = OLD.pending_since;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER before_insert_or_update_listings_set_pending_since
+BEFORE INSERT OR UPDATE ON listings
+FOR EACH ROW
+EXECUTE FUNCTION set_listing_pending_since();
@@ -0,0 +1,24 @@
+-- File: migrations/000057_add_listing_location_privacy.down.sql
+
+CREATE OR REPLACE FUNCTION update_location_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.longitude IS NOT NULL AND NEW.latitude IS NOT NULL THEN
+        NEW.location = ST_SetSRID(ST_MakePoint(NEW.longitude, NEW.latitude), 4326);
+        NEW.neighborhood_id = (
+            SELECT id FROM neighborhoods
+            WHERE ST_Contains(boundary, ST_SetSRID(ST_MakePoint(NEW.longitude, NEW.latitude), 4326))
+            ORDER BY ST_Area(boundary)
+            LIMIT 1
+        );
+    ELSE
+        NEW.location = NULL;
+        NEW.neighborhood_id = NULL;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS idx_listings_public_location;
+ALTER TABLE listings DROP COLUMN IF EXISTS public_location;
+ALTER TABLE listings DROP COLUMN IF EXISTS location_privacy;
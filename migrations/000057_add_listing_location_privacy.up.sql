@@ -0,0 +1,44 @@
+-- File: migrations/000057_add_listing_location_privacy.up.sql
+
+-- How precisely a listing's location is shown to other people: the exact address, the street with coordinates
+-- rounded to about 100 m, or only the neighborhood.
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS location_privacy VARCHAR(20) NOT NULL DEFAULT 'exact'
+    CONSTRAINT check_listing_location_privacy CHECK (location_privacy IN ('exact', 'street', 'neighborhood'));
+
+-- The location searches, distances and map clusters use, so they never give away more than the listing shows.
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS public_location GEOGRAPHY(Point, 4326);
+CREATE INDEX IF NOT EXISTS idx_listings_public_location ON listings USING GIST (public_location);
+
+-- Street level snaps to a 0.001 degree grid, matching streetLevelGridDegrees in the listing package. Neighborhood
+-- level uses the centre of the neighborhood, or a 0.01 degree grid outside every neighborhood.
+CREATE OR REPLACE FUNCTION update_location_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.longitude IS NOT NULL AND NEW.latitude IS NOT NULL THEN
+        NEW.location = ST_SetSRID(ST_MakePoint(NEW.longitude, NEW.latitude), 4326);
+        NEW.neighborhood_id = (
+            SELECT id FROM neighborhoods
+            WHERE ST_Contains(boundary, ST_SetSRID(ST_MakePoint(NEW.longitude, NEW.latitude), 4326))
+            ORDER BY ST_Area(boundary)
+            LIMIT 1
+        );
+        NEW.public_location = CASE NEW.location_privacy
+            WHEN 'street' THEN ST_SnapToGrid(NEW.location::geometry, 0.001)::geography
+            WHEN 'neighborhood' THEN COALESCE(
+                (SELECT ST_Centroid(boundary)::geography FROM neighborhoods WHERE id = NEW.neighborhood_id),
+                ST_SnapToGrid(NEW.location::geometry, 0.01)::geography)
+            ELSE NEW.location
+        END;
+    ELSE
+        NEW.location = NULL;
+        NEW.neighborhood_id = NULL;
+        NEW.public_location = NULL;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Existing listings are all exact; fill public_location without touching their updated_at.
+ALTER TABLE listings DISABLE TRIGGER set_timestamp_listings;
+UPDATE listings SET public_location = location WHERE location IS NOT NULL;
+ALTER TABLE listings ENABLE TRIGGER set_timestamp_listings;
@@ -0,0 +1,5 @@
+-- File: migrations/000058_create_event_checkins.down.sql
+
+ALTER TABLE listing_stats_daily DROP COLUMN IF EXISTS check_ins;
+DROP TABLE IF EXISTS event_checkins;
+DROP TABLE IF EXISTS event_checkin_codes;
@@ -0,0 +1,23 @@
+-- File: migrations/000058_create_event_checkins.up.sql
+
+-- The secret an event's organizer shows at the door, usually as a QR code. One per event; rotating replaces it.
+CREATE TABLE IF NOT EXISTS event_checkin_codes (
+    listing_id UUID PRIMARY KEY REFERENCES listings(id) ON DELETE CASCADE,
+    code VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One row per user who checked in to an occurrence of an event; scanning the code again the same day is not recorded.
+CREATE TABLE IF NOT EXISTS event_checkins (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    occurrence_date DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (listing_id, user_id, occurrence_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_checkins_listing ON event_checkins(listing_id, occurrence_date);
+CREATE INDEX IF NOT EXISTS idx_event_checkins_created_at ON event_checkins(created_at);
+
+ALTER TABLE listing_stats_daily ADD COLUMN IF NOT EXISTS check_ins INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,10 @@
+-- File: migrations/000059_add_housing_amenities.down.sql
+
+DROP INDEX IF EXISTS idx_listing_details_housing_bedrooms;
+ALTER TABLE listing_details_housing
+    DROP COLUMN IF EXISTS available_from,
+    DROP COLUMN IF EXISTS parking,
+    DROP COLUMN IF EXISTS pet_policy,
+    DROP COLUMN IF EXISTS square_feet,
+    DROP COLUMN IF EXISTS bathrooms,
+    DROP COLUMN IF EXISTS bedrooms;
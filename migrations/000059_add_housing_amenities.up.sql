@@ -0,0 +1,13 @@
+-- File: migrations/000059_add_housing_amenities.up.sql
+
+-- Structured housing attributes, filled in by owners alongside the free-text rent_details.
+ALTER TABLE listing_details_housing ADD COLUMN IF NOT EXISTS bedrooms SMALLINT CHECK (bedrooms >= 0);
+ALTER TABLE listing_details_housing ADD COLUMN IF NOT EXISTS bathrooms NUMERIC(3,1) CHECK (bathrooms >= 0);
+ALTER TABLE listing_details_housing ADD COLUMN IF NOT EXISTS square_feet INTEGER CHECK (square_feet > 0);
+ALTER TABLE listing_details_housing ADD COLUMN IF NOT EXISTS pet_policy VARCHAR(20)
+    CONSTRAINT check_housing_pet_policy CHECK (pet_policy IN ('no_pets', 'cats_only', 'dogs_only', 'cats_and_dogs'));
+ALTER TABLE listing_details_housing ADD COLUMN IF NOT EXISTS parking VARCHAR(20)
+    CONSTRAINT check_housing_parking CHECK (parking IN ('none', 'street', 'off_street', 'garage'));
+ALTER TABLE listing_details_housing ADD COLUMN IF NOT EXISTS available_from DATE;
+
+CREATE INDEX IF NOT EXISTS idx_listing_details_housing_bedrooms ON listing_details_housing(bedrooms);
@@ -0,0 +1,5 @@
+-- File: migrations/000060_add_housing_rent_amount.down.sql
+
+ALTER TABLE listing_details_housing
+    DROP COLUMN IF EXISTS rent_period,
+    DROP COLUMN IF EXISTS rent_amount;
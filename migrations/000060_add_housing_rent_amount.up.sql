@@ -0,0 +1,7 @@
+-- File: migrations/000060_add_housing_rent_amount.up.sql
+
+-- Numeric rent, replacing the free-text rent_details. rent_details stays, written alongside, until clients have moved
+-- over. Existing rows are filled in by the rent_backfill job, which parses rent_details.
+ALTER TABLE listing_details_housing ADD COLUMN IF NOT EXISTS rent_amount NUMERIC(12,2) CHECK (rent_amount > 0);
+ALTER TABLE listing_details_housing ADD COLUMN IF NOT EXISTS rent_period VARCHAR(20)
+    CONSTRAINT check_housing_rent_period CHECK (rent_period IN ('daily', 'weekly', 'monthly', 'yearly'));
@@ -0,0 +1,8 @@
+-- File: migrations/000061_add_babysitting_provider_profile.down.sql
+
+DROP INDEX IF EXISTS idx_listing_details_babysitting_hourly_rate;
+ALTER TABLE listing_details_babysitting
+    DROP COLUMN IF EXISTS certifications,
+    DROP COLUMN IF EXISTS years_experience,
+    DROP COLUMN IF EXISTS hourly_rate,
+    DROP COLUMN IF EXISTS availability;
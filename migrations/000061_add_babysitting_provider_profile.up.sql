@@ -0,0 +1,10 @@
+-- File: migrations/000061_add_babysitting_provider_profile.up.sql
+
+-- Provider profile of babysitting listings. availability has the shape of listings.business_hours.
+ALTER TABLE listing_details_babysitting ADD COLUMN IF NOT EXISTS availability JSONB
+    CONSTRAINT check_babysitting_availability CHECK (availability IS NULL OR jsonb_typeof(availability) = 'object');
+ALTER TABLE listing_details_babysitting ADD COLUMN IF NOT EXISTS hourly_rate NUMERIC(8,2) CHECK (hourly_rate > 0);
+ALTER TABLE listing_details_babysitting ADD COLUMN IF NOT EXISTS years_experience SMALLINT CHECK (years_experience >= 0);
+ALTER TABLE listing_details_babysitting ADD COLUMN IF NOT EXISTS certifications TEXT[];
+
+CREATE INDEX IF NOT EXISTS idx_listing_details_babysitting_hourly_rate ON listing_details_babysitting(hourly_rate);
@@ -0,0 +1,7 @@
+-- File: migrations/000062_create_babysitting_background_checks.down.sql
+
+ALTER TABLE listing_details_babysitting
+    DROP COLUMN IF EXISTS verified_at,
+    DROP COLUMN IF EXISTS verified_badge;
+DROP TRIGGER IF EXISTS set_timestamp_babysitting_background_checks ON babysitting_background_checks;
+DROP TABLE IF EXISTS babysitting_background_checks;
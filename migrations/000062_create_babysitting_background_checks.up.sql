@@ -0,0 +1,32 @@
+-- File: migrations/000062_create_babysitting_background_checks.up.sql
+
+-- Background-check documents babysitting providers send for review, and the admins' decisions on them.
+CREATE TABLE IF NOT EXISTS babysitting_background_checks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    document_path TEXT NOT NULL, -- Below the storage's private directory; never served publicly
+    document_name VARCHAR(255) NOT NULL, -- The uploaded file's original name
+    note TEXT, -- From the provider
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CONSTRAINT check_background_check_status CHECK (status IN ('pending', 'verified', 'rejected', 'revoked')),
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ,
+    review_note TEXT, -- Sent to the provider
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_babysitting_background_checks_listing_id ON babysitting_background_checks(listing_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_babysitting_background_checks_status ON babysitting_background_checks(status, created_at);
+-- A listing has at most one check waiting for review.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_babysitting_background_checks_pending ON babysitting_background_checks(listing_id) WHERE status = 'pending';
+
+CREATE TRIGGER set_timestamp_babysitting_background_checks
+BEFORE UPDATE ON babysitting_background_checks
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+-- Set while the provider's latest check is verified.
+ALTER TABLE listing_details_babysitting ADD COLUMN IF NOT EXISTS verified_badge BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE listing_details_babysitting ADD COLUMN IF NOT EXISTS verified_at TIMESTAMPTZ;